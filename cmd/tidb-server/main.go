@@ -58,6 +58,8 @@ import (
 	"github.com/pingcap/tidb/pkg/store/copr"
 	"github.com/pingcap/tidb/pkg/store/driver"
 	"github.com/pingcap/tidb/pkg/store/mockstore"
+	"github.com/pingcap/tidb/pkg/tidb-binlog/binlogstore"
+	"github.com/pingcap/tidb/pkg/tidb-binlog/memorypump"
 	pumpcli "github.com/pingcap/tidb/pkg/tidb-binlog/pump_client"
 	"github.com/pingcap/tidb/pkg/util"
 	"github.com/pingcap/tidb/pkg/util/cgmon"
@@ -324,6 +326,10 @@ func main() {
 	executor.Start()
 	resourcemanager.InstanceResourceManager.Start()
 	storage, dom := createStoreAndDomain(keyspaceName)
+	if memorypump.GetPump() != nil {
+		// The replicas identify the source by server_uuid, all the TiDB instances of the cluster share it.
+		variable.SetSysVar("server_uuid", binlogstore.ServerUUID(storage).String())
+	}
 	svr := createServer(storage, dom)
 
 	exited := make(chan struct{})
@@ -425,6 +431,9 @@ func createStoreAndDomain(keyspaceName string) (kv.Storage, *domain.Domain) {
 func setupBinlogClient() {
 	cfg := config.GetGlobalConfig()
 	if !cfg.Binlog.Enable {
+		if cfg.Binlog.EnableDumpSource {
+			setupBinlogDumpSource()
+		}
 		return
 	}
 
@@ -458,6 +467,18 @@ func setupBinlogClient() {
 	log.Info("tidb-server", zap.Bool("create pumps client success, ignore binlog error", cfg.Binlog.IgnoreError))
 }
 
+// setupBinlogDumpSource writes the binlog to an in-process pump, which is written into `mysql.tidb_binlog`
+// by the domain, so MySQL replicas can replicate from the cluster.
+func setupBinlogDumpSource() {
+	cfg := config.GetGlobalConfig()
+	pump := memorypump.New(cfg.Binlog.DumpSourceBufferSize)
+	memorypump.SetPump(pump)
+	binloginfo.SetPumpsClient(pumpcli.NewInProcessPumpsClient(pump, parseDuration(cfg.Binlog.WriteTimeout)))
+	// The replicas require GTID mode to replicate by auto position.
+	variable.SetSysVar("gtid_mode", variable.On)
+	log.Info("binlog dump source enabled")
+}
+
 // Prometheus push.
 const zeroDuration = time.Duration(0)
 
@@ -803,7 +824,7 @@ func setGlobalVars() {
 	variable.SetSysVar(variable.TiDBForcePriority, mysql.Priority2Str[priority])
 	variable.SetSysVar(variable.TiDBOptDistinctAggPushDown, variable.BoolToOnOff(cfg.Performance.DistinctAggPushDown))
 	variable.SetSysVar(variable.TiDBOptProjectionPushDown, variable.BoolToOnOff(cfg.Performance.ProjectionPushDown))
-	variable.SetSysVar(variable.LogBin, variable.BoolToOnOff(cfg.Binlog.Enable))
+	variable.SetSysVar(variable.LogBin, variable.BoolToOnOff(cfg.Binlog.Enable || cfg.Binlog.EnableDumpSource))
 	variable.SetSysVar(variable.Port, fmt.Sprintf("%d", cfg.Port))
 	cfg.Socket = strings.Replace(cfg.Socket, "{Port}", fmt.Sprintf("%d", cfg.Port), 1)
 	variable.SetSysVar(variable.Socket, cfg.Socket)
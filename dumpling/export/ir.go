@@ -28,6 +28,7 @@ type TableMeta interface {
 	ColumnCount() uint
 	ColumnTypes() []string
 	ColumnNames() []string
+	ColumnDecimalSizes() []DecimalSize
 	SelectedField() string
 	SelectedLen() int
 	SpecialComments() StringIter
@@ -79,6 +80,13 @@ type StringIter interface {
 	HasNext() bool
 }
 
+// DecimalSize is the precision and scale of a column, they are 0 if the
+// column isn't a decimal or the sizes are unknown.
+type DecimalSize struct {
+	Precision int64
+	Scale     int64
+}
+
 // MetaIR is the interface that wraps database/table/view's metadata
 type MetaIR interface {
 	SpecialComments() StringIter
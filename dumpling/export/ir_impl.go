@@ -283,6 +283,19 @@ func (tm *tableMeta) ColumnNames() []string {
 	return colNames
 }
 
+func (tm *tableMeta) ColumnDecimalSizes() []DecimalSize {
+	sizes := make([]DecimalSize, len(tm.colTypes))
+	for i, ct := range tm.colTypes {
+		switch ct.DatabaseTypeName() {
+		case "DECIMAL", "NUMERIC", "FIXED":
+			if precision, scale, ok := ct.DecimalSize(); ok {
+				sizes[i] = DecimalSize{Precision: precision, Scale: scale}
+			}
+		}
+	}
+	return sizes
+}
+
 func (tm *tableMeta) DatabaseName() string {
 	return tm.database
 }
@@ -281,6 +281,10 @@ func TestConfigValidation(t *testing.T) {
 	conf.FileType = FileFormatCSVString
 	require.NoError(t, adjustFileFormat(conf))
 
+	conf.FileType = "Parquet"
+	require.NoError(t, adjustFileFormat(conf))
+	require.Equal(t, FileFormatParquetString, conf.FileType)
+
 	conf.FileType = ""
 	require.NoError(t, adjustFileFormat(conf))
 	require.Equal(t, FileFormatCSVString, conf.FileType)
@@ -7,6 +7,14 @@ import (
 	"database/sql"
 	"encoding/base64"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/xitongsys/parquet-go/parquet"
+	parquettypes "github.com/xitongsys/parquet-go/types"
 )
 
 var colTypeRowReceiverMap = map[string]func() RowReceiverStringer{}
@@ -321,3 +329,232 @@ func (s *SQLTypeBytes) WriteToBufferInCsv(bf *bytes.Buffer, escapeBackslash bool
 		bf.WriteString(opt.nullValue)
 	}
 }
+
+// parquetKind is the kind of the parquet column that a database column is stored as.
+type parquetKind int
+
+const (
+	parquetKindString parquetKind = iota
+	parquetKindBinary
+	parquetKindInt
+	parquetKindUint
+	parquetKindBit
+	parquetKindFloat
+	parquetKindDouble
+	parquetKindDecimal
+	parquetKindDate
+	parquetKindTimestamp
+)
+
+const (
+	parquetDateLayout     = "2006-01-02"
+	parquetDatetimeLayout = "2006-01-02 15:04:05.999999999"
+)
+
+// parquetColumn describes how a database column is stored in the parquet file.
+type parquetColumn struct {
+	kind      parquetKind
+	precision int64
+	scale     int64
+}
+
+// makeParquetColumns maps the database column types to parquet columns. The
+// decimal column is stored as the parquet DECIMAL only if its precision and
+// scale are known, otherwise it's stored as a string.
+func makeParquetColumns(colTypes []string, decimalSizes []DecimalSize) []parquetColumn {
+	columns := make([]parquetColumn, len(colTypes))
+	for i, colTp := range colTypes {
+		col := &columns[i]
+		switch colTp {
+		case "FLOAT":
+			col.kind = parquetKindFloat
+		case "REAL", "DOUBLE", "DOUBLE PRECISION":
+			col.kind = parquetKindDouble
+		case "DECIMAL", "NUMERIC", "FIXED":
+			col.kind = parquetKindString
+			if i < len(decimalSizes) {
+				p, s := decimalSizes[i].Precision, decimalSizes[i].Scale
+				if p > 0 && p <= 65 && s >= 0 && s <= p {
+					col.kind, col.precision, col.scale = parquetKindDecimal, p, s
+				}
+			}
+		case "BOOL", "BOOLEAN", "YEAR", "SQL_TSI_YEAR":
+			col.kind = parquetKindInt
+		case "DATE":
+			col.kind = parquetKindDate
+		case "DATETIME", "TIMESTAMP":
+			col.kind = parquetKindTimestamp
+		case "BIT":
+			col.kind = parquetKindBit
+		default:
+			if _, ok := dataTypeInt[colTp]; ok {
+				col.kind = parquetKindInt
+				if strings.HasPrefix(colTp, "UNSIGNED ") {
+					col.kind = parquetKindUint
+				}
+			} else if _, ok := dataTypeBin[colTp]; ok {
+				col.kind = parquetKindBinary
+			}
+		}
+	}
+	return columns
+}
+
+// parquetSchema returns the parquet-go metadata of the columns. All the columns
+// are nullable, and the column names are sanitized to be unique case-insensitively,
+// since parquet-go uses them as the field names.
+func parquetSchema(colNames []string, columns []parquetColumn) []string {
+	md := make([]string, 0, len(columns))
+	used := make(map[string]struct{}, len(columns))
+	for i, col := range columns {
+		var name string
+		if i < len(colNames) {
+			name = strings.Trim(colNames[i], "`")
+		}
+		name = strings.Map(func(r rune) rune {
+			if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+				return r
+			}
+			return '_'
+		}, name)
+		if name == "" {
+			name = fmt.Sprintf("col_%d", i+1)
+		}
+		uniqueName := name
+		for j := 1; ; j++ {
+			if _, ok := used[strings.ToLower(uniqueName)]; !ok {
+				break
+			}
+			uniqueName = fmt.Sprintf("%s_%d", name, j)
+		}
+		used[strings.ToLower(uniqueName)] = struct{}{}
+
+		var typeDesc string
+		switch col.kind {
+		case parquetKindBinary:
+			typeDesc = "type=BYTE_ARRAY"
+		case parquetKindInt:
+			typeDesc = "type=INT64"
+		case parquetKindUint, parquetKindBit:
+			typeDesc = "type=INT64, convertedtype=UINT_64"
+		case parquetKindFloat:
+			typeDesc = "type=FLOAT"
+		case parquetKindDouble:
+			typeDesc = "type=DOUBLE"
+		case parquetKindDecimal:
+			typeDesc = fmt.Sprintf("type=BYTE_ARRAY, convertedtype=DECIMAL, precision=%d, scale=%d", col.precision, col.scale)
+		case parquetKindDate:
+			typeDesc = "type=INT32, convertedtype=DATE"
+		case parquetKindTimestamp:
+			typeDesc = "type=INT64, convertedtype=TIMESTAMP_MICROS"
+		default:
+			typeDesc = "type=BYTE_ARRAY, convertedtype=UTF8"
+		}
+		md = append(md, fmt.Sprintf("name=%s, %s, repetitiontype=OPTIONAL", uniqueName, typeDesc))
+	}
+	return md
+}
+
+func parquetCompressionCodec(compressType storage.CompressType) parquet.CompressionCodec {
+	switch compressType {
+	case storage.Gzip:
+		return parquet.CompressionCodec_GZIP
+	case storage.Snappy:
+		return parquet.CompressionCodec_SNAPPY
+	case storage.Zstd:
+		return parquet.CompressionCodec_ZSTD
+	default:
+		return parquet.CompressionCodec_UNCOMPRESSED
+	}
+}
+
+// isZeroDate returns whether the date has a zero month or day, such as
+// '0000-00-00', which can't be represented in parquet.
+func isZeroDate(s string) bool {
+	return len(s) >= 10 && (s[5:7] == "00" || s[8:10] == "00")
+}
+
+// convert converts the value read by the text protocol to the go type accepted
+// by the parquet-go CSV writer. The datetime and timestamp are stored as their
+// wall clock in UTC, and the zero dates are stored as NULL.
+func (c parquetColumn) convert(raw sql.RawBytes) (any, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	switch c.kind {
+	case parquetKindInt:
+		v, err := strconv.ParseInt(string(raw), 10, 64)
+		return v, errors.Trace(err)
+	case parquetKindUint:
+		v, err := strconv.ParseUint(string(raw), 10, 64)
+		return int64(v), errors.Trace(err)
+	case parquetKindBit:
+		var v uint64
+		for _, b := range raw {
+			v = v<<8 | uint64(b)
+		}
+		return int64(v), nil
+	case parquetKindFloat:
+		v, err := strconv.ParseFloat(string(raw), 32)
+		return float32(v), errors.Trace(err)
+	case parquetKindDouble:
+		v, err := strconv.ParseFloat(string(raw), 64)
+		return v, errors.Trace(err)
+	case parquetKindDecimal:
+		s := string(raw)
+		intPart, fracPart, _ := strings.Cut(s, ".")
+		if int64(len(fracPart)) < c.scale {
+			fracPart += strings.Repeat("0", int(c.scale)-len(fracPart))
+		} else {
+			fracPart = fracPart[:c.scale]
+		}
+		return parquettypes.StrIntToBinary(intPart+fracPart, "BigEndian", 0, true), nil
+	case parquetKindDate:
+		s := string(raw)
+		if isZeroDate(s) {
+			return nil, nil
+		}
+		t, err := time.Parse(parquetDateLayout, s)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		days := t.Unix() / 86400
+		if t.Unix()%86400 < 0 {
+			days--
+		}
+		return int32(days), nil
+	case parquetKindTimestamp:
+		s := string(raw)
+		if isZeroDate(s) {
+			return nil, nil
+		}
+		t, err := time.Parse(parquetDatetimeLayout, s)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return t.UnixMicro(), nil
+	default:
+		return string(raw), nil
+	}
+}
+
+// parquetRowReceiver receives the raw values of a row for the parquet writer.
+type parquetRowReceiver struct {
+	bound  bool
+	values []sql.RawBytes
+}
+
+func newParquetRowReceiver(colCount int) *parquetRowReceiver {
+	return &parquetRowReceiver{values: make([]sql.RawBytes, colCount)}
+}
+
+// BindAddress implements RowReceiver.BindAddress
+func (r *parquetRowReceiver) BindAddress(args []any) {
+	if r.bound {
+		return
+	}
+	r.bound = true
+	for i := range args {
+		args[i] = &r.values[i]
+	}
+}
@@ -5,15 +5,19 @@ package export
 import (
 	"context"
 	"database/sql/driver"
+	"io"
 	"os"
 	"path"
 	"sync"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tidb/br/pkg/version"
 	tcontext "github.com/pingcap/tidb/dumpling/context"
+	"github.com/pingcap/tidb/pkg/lightning/mydump"
 	"github.com/pingcap/tidb/pkg/util/promutil"
 	"github.com/stretchr/testify/require"
 )
@@ -344,6 +348,78 @@ func TestWriteTableDataWithStatementSize(t *testing.T) {
 
 var mu sync.Mutex
 
+func TestWriteTableDataInParquet(t *testing.T) {
+	dir := t.TempDir()
+	config := defaultConfigForTest(t)
+	config.OutputDirPath = dir
+	config.FileType = FileFormatParquetString
+	config.CompressType = storage.Snappy
+	config.StatementSize = 1
+	writer := createTestWriter(config, t)
+
+	data := [][]driver.Value{
+		{"1", "bob", "12.30", "2024-01-02", "2024-01-02 03:04:05.123456", "18446744073709551615", "1.5", `{"a": 1}`, "\x01"},
+		{"2", nil, "-0.5", "0000-00-00", nil, "0", nil, nil, nil},
+	}
+	colTypes := []string{"INT", "VARCHAR", "DECIMAL", "DATE", "DATETIME", "UNSIGNED BIGINT", "DOUBLE", "JSON", "BLOB"}
+	tableIR := newMockTableIR("test", "employee", data, nil, colTypes)
+	tableIR.colNames = []string{"id", "name", "salary", "birthday", "updated_at", "u", "score", "extra", "raw"}
+	tableIR.decimalSizes = []DecimalSize{{}, {}, {Precision: 10, Scale: 2}, {}, {}, {}, {}, {}, {}}
+	require.NoError(t, writer.WriteTableData(tableIR, tableIR, 0))
+
+	readRows := func(fileName string) [][]string {
+		store, err := storage.NewLocalStorage(dir)
+		require.NoError(t, err)
+		r, err := store.Open(context.Background(), fileName, nil)
+		require.NoError(t, err)
+		parser, err := mydump.NewParquetParser(context.Background(), store, r, fileName)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, parser.Close())
+		}()
+		require.Equal(t, tableIR.colNames, parser.Columns())
+		var rows [][]string
+		for {
+			err = parser.ReadRow()
+			if errors.Cause(err) == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			row := make([]string, 0, len(colTypes))
+			for _, d := range parser.LastRow().Row {
+				if d.IsNull() {
+					row = append(row, "NULL")
+					continue
+				}
+				s, err := d.ToString()
+				require.NoError(t, err)
+				row = append(row, s)
+			}
+			rows = append(rows, row)
+		}
+		return rows
+	}
+	require.Equal(t, [][]string{
+		{"1", "bob", "12.30", "2024-01-02", "2024-01-02 03:04:05.123456", "18446744073709551615", "1.5", `{"a": 1}`, "\x01"},
+		{"2", "NULL", "-0.50", "NULL", "NULL", "0", "NULL", "NULL", "NULL"},
+	}, readRows("test.employee.000000000.parquet"))
+
+	// split the parquet files by file size, the decimal is stored as a string
+	// since its precision and scale are unknown
+	config.FileSize = 1
+	tableIR = newMockTableIR("test", "employee2", data, nil, colTypes)
+	tableIR.colNames = []string{"id", "name", "salary", "birthday", "updated_at", "u", "score", "extra", "raw"}
+	require.NoError(t, writer.WriteTableData(tableIR, tableIR, 0))
+	require.Equal(t, [][]string{
+		{"1", "bob", "12.30", "2024-01-02", "2024-01-02 03:04:05.123456", "18446744073709551615", "1.5", `{"a": 1}`, "\x01"},
+	}, readRows("test.employee2.000000000.parquet"))
+	require.Equal(t, [][]string{
+		{"2", "NULL", "-0.5", "NULL", "NULL", "0", "NULL", "NULL", "NULL"},
+	}, readRows("test.employee2.000000001.parquet"))
+	_, err := os.Stat(path.Join(dir, "test.employee2.000000002.parquet"))
+	require.True(t, os.IsNotExist(err))
+}
+
 func createTestWriter(conf *Config, t *testing.T) *Writer {
 	t.Helper()
 	conf.ServerInfo.ServerType = version.ServerTypeMySQL
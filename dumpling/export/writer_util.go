@@ -18,6 +18,7 @@ import (
 	tcontext "github.com/pingcap/tidb/dumpling/context"
 	"github.com/pingcap/tidb/dumpling/log"
 	"github.com/prometheus/client_golang/prometheus"
+	parquetwriter "github.com/xitongsys/parquet-go/writer"
 	"go.uber.org/zap"
 )
 
@@ -418,6 +419,155 @@ func WriteInsertInCsv(
 	return counter, wp.Error()
 }
 
+// WriteInsertInParquet writes TableDataIR to a storage.ExternalFileWriter in parquet type.
+// The statement size is used as the row group size of the parquet file.
+func WriteInsertInParquet(
+	pCtx *tcontext.Context,
+	cfg *Config,
+	meta TableMeta,
+	tblIR TableDataIR,
+	w storage.ExternalFileWriter,
+	metrics *metrics,
+) (n uint64, err error) {
+	fileRowIter := tblIR.Rows()
+	if !fileRowIter.HasNext() {
+		return 0, fileRowIter.Error()
+	}
+	if meta.SelectedField() == "" {
+		return 0, errors.Errorf("can't dump table %s.%s without selected columns in parquet format",
+			meta.DatabaseName(), meta.TableName())
+	}
+
+	bf := pool.Get().(*bytes.Buffer)
+	if bfCap := bf.Cap(); bfCap < lengthLimit {
+		bf.Grow(lengthLimit - bfCap)
+	}
+
+	wp := newWriterPipe(w, cfg.FileSize, UnspecifiedSize, metrics, cfg.Labels)
+
+	// use context.Background here to make sure writerPipe can deplete all the chunks in pipeline
+	ctx, cancel := tcontext.Background().WithLogger(pCtx.L()).WithCancel()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		wp.Run(ctx)
+		wg.Done()
+	}()
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	var (
+		columns     = makeParquetColumns(meta.ColumnTypes(), meta.ColumnDecimalSizes())
+		row         = newParquetRowReceiver(len(columns))
+		out         = &parquetBufferWriter{bf: bf}
+		counter     uint64
+		lastCounter uint64
+	)
+
+	defer func() {
+		if err != nil {
+			pCtx.L().Warn("fail to dumping table(chunk), will revert some metrics and start a retry if possible",
+				zap.String("database", meta.DatabaseName()),
+				zap.String("table", meta.TableName()),
+				zap.Uint64("finished rows", lastCounter),
+				zap.Uint64("finished size", wp.finishedFileSize),
+				log.ShortError(err))
+			SubGauge(metrics.finishedRowsGauge, float64(lastCounter))
+			SubGauge(metrics.finishedSizeGauge, float64(wp.finishedFileSize))
+		} else {
+			pCtx.L().Debug("finish dumping table(chunk)",
+				zap.String("database", meta.DatabaseName()),
+				zap.String("table", meta.TableName()),
+				zap.Uint64("finished rows", counter),
+				zap.Uint64("finished size", wp.finishedFileSize))
+			summary.CollectSuccessUnit(summary.TotalBytes, 1, wp.finishedFileSize)
+			summary.CollectSuccessUnit("total rows", 1, counter)
+		}
+	}()
+
+	pw, err := parquetwriter.NewCSVWriterFromWriter(parquetSchema(meta.ColumnNames(), columns), out, 1)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	pw.CompressionType = parquetCompressionCodec(cfg.CompressType)
+	if cfg.StatementSize != UnspecifiedSize {
+		pw.RowGroupSize = int64(cfg.StatementSize)
+	}
+	if cfg.FileSize != UnspecifiedSize && int64(cfg.FileSize) < pw.RowGroupSize {
+		pw.RowGroupSize = int64(cfg.FileSize)
+	}
+
+	for fileRowIter.HasNext() {
+		if err = fileRowIter.Decode(row); err != nil {
+			return counter, errors.Trace(err)
+		}
+		// the parquet writer buffers the records until a page is flushed, so
+		// the record can't be reused.
+		record := make([]any, len(columns))
+		for i, col := range columns {
+			if record[i], err = col.convert(row.values[i]); err != nil {
+				return counter, errors.Annotatef(err, "column %d", i+1)
+			}
+		}
+		if err = pw.Write(record); err != nil {
+			return counter, errors.Trace(err)
+		}
+		counter++
+		wp.currentFileSize = out.written + uint64(pw.Size+pw.ObjsSize)
+
+		if out.bf.Len() >= lengthLimit {
+			select {
+			case <-pCtx.Done():
+				return counter, pCtx.Err()
+			case err = <-wp.errCh:
+				return counter, err
+			case wp.input <- out.bf:
+				out.bf = pool.Get().(*bytes.Buffer)
+				if bfCap := out.bf.Cap(); bfCap < lengthLimit {
+					out.bf.Grow(lengthLimit - bfCap)
+				}
+				AddGauge(metrics.finishedRowsGauge, float64(counter-lastCounter))
+				lastCounter = counter
+			}
+		}
+
+		fileRowIter.Next()
+		if wp.ShouldSwitchFile() {
+			break
+		}
+	}
+	if err = pw.WriteStop(); err != nil {
+		return counter, errors.Trace(err)
+	}
+
+	if out.bf.Len() > 0 {
+		wp.input <- out.bf
+	}
+	close(wp.input)
+	<-wp.closed
+	AddGauge(metrics.finishedRowsGauge, float64(counter-lastCounter))
+	lastCounter = counter
+	if err = fileRowIter.Error(); err != nil {
+		return counter, errors.Trace(err)
+	}
+	return counter, wp.Error()
+}
+
+// parquetBufferWriter is the io.Writer of the parquet writer, the written
+// buffer is sent to the writerPipe once it's large enough.
+type parquetBufferWriter struct {
+	bf      *bytes.Buffer
+	written uint64
+}
+
+// Write implements io.Writer.
+func (w *parquetBufferWriter) Write(p []byte) (int, error) {
+	w.written += uint64(len(p))
+	return w.bf.Write(p)
+}
+
 func write(tctx *tcontext.Context, writer storage.ExternalFileWriter, str string) error {
 	_, err := writer.Write(tctx, []byte(str))
 	if err != nil {
@@ -608,7 +758,7 @@ func compressFileSuffix(compressType storage.CompressType) string {
 	}
 }
 
-// FileFormat is the format that output to file. Currently we support SQL text and CSV file format.
+// FileFormat is the format that output to file. Currently we support SQL text, CSV and parquet file format.
 type FileFormat int32
 
 const (
@@ -618,6 +768,8 @@ const (
 	FileFormatSQLText
 	// FileFormatCSV indicates the given file type is csv type
 	FileFormatCSV
+	// FileFormatParquet indicates the given file type is parquet type
+	FileFormatParquet
 )
 
 const (
@@ -625,6 +777,8 @@ const (
 	FileFormatSQLTextString = "sql"
 	// FileFormatCSVString indicates the string/suffix of csv type file
 	FileFormatCSVString = "csv"
+	// FileFormatParquetString indicates the string/suffix of parquet type file
+	FileFormatParquetString = "parquet"
 )
 
 // String implement Stringer.String method.
@@ -634,6 +788,8 @@ func (f FileFormat) String() string {
 		return strings.ToUpper(FileFormatSQLTextString)
 	case FileFormatCSV:
 		return strings.ToUpper(FileFormatCSVString)
+	case FileFormatParquet:
+		return strings.ToUpper(FileFormatParquetString)
 	default:
 		return "unknown"
 	}
@@ -641,20 +797,23 @@ func (f FileFormat) String() string {
 
 // Extension returns the extension for specific format.
 //
-//	text -> "sql"
-//	csv  -> "csv"
+//	text    -> "sql"
+//	csv     -> "csv"
+//	parquet -> "parquet"
 func (f FileFormat) Extension() string {
 	switch f {
 	case FileFormatSQLText:
 		return FileFormatSQLTextString
 	case FileFormatCSV:
 		return FileFormatCSVString
+	case FileFormatParquet:
+		return FileFormatParquetString
 	default:
 		return "unknown_format"
 	}
 }
 
-// WriteInsert writes TableDataIR to a storage.ExternalFileWriter in sql/csv type
+// WriteInsert writes TableDataIR to a storage.ExternalFileWriter in sql/csv/parquet type
 func (f FileFormat) WriteInsert(
 	pCtx *tcontext.Context,
 	cfg *Config,
@@ -668,6 +827,8 @@ func (f FileFormat) WriteInsert(
 		return WriteInsert(pCtx, cfg, meta, tblIR, w, metrics)
 	case FileFormatCSV:
 		return WriteInsertInCsv(pCtx, cfg, meta, tblIR, w, metrics)
+	case FileFormatParquet:
+		return WriteInsertInParquet(pCtx, cfg, meta, tblIR, w, metrics)
 	default:
 		return 0, errors.Errorf("unknown file format")
 	}
@@ -111,6 +111,13 @@ func openParser(
 		if err != nil {
 			return nil, err
 		}
+	case mydump.SourceTypeNDJSON:
+		parser = mydump.NewNDJSONParser(ctx, reader, blockBufSize)
+	case mydump.SourceTypeAvro:
+		parser, err = mydump.NewAvroParser(ctx, reader, chunk.FileMeta.Path)
+		if err != nil {
+			return nil, err
+		}
 	default:
 		return nil, errors.Errorf("file '%s' with unknown source type '%s'", chunk.Key.Path, chunk.FileMeta.Type.String())
 	}
@@ -489,6 +489,13 @@ func (p *PreImportInfoGetterImpl) ReadFirstNRowsByFileMeta(ctx context.Context,
 		if err != nil {
 			return nil, nil, errors.Trace(err)
 		}
+	case mydump.SourceTypeNDJSON:
+		parser = mydump.NewNDJSONParser(ctx, reader, blockBufSize)
+	case mydump.SourceTypeAvro:
+		parser, err = mydump.NewAvroParser(ctx, reader, dataFileMeta.Path)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
 	default:
 		panic(fmt.Sprintf("unknown file type '%s'", dataFileMeta.Type))
 	}
@@ -659,6 +666,13 @@ func (p *PreImportInfoGetterImpl) sampleDataFromTable(
 		if err != nil {
 			return 0.0, false, errors.Trace(err)
 		}
+	case mydump.SourceTypeNDJSON:
+		parser = mydump.NewNDJSONParser(ctx, reader, blockBufSize)
+	case mydump.SourceTypeAvro:
+		parser, err = mydump.NewAvroParser(ctx, reader, sampleFile.Path)
+		if err != nil {
+			return 0.0, false, errors.Trace(err)
+		}
 	default:
 		panic(fmt.Sprintf("file '%s' with unknown source type '%s'", sampleFile.Path, sampleFile.Type.String()))
 	}
@@ -0,0 +1,181 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package changefeed captures the row changes of the transactions committed by the cluster and writes
+// them to a local sink, so small deployments and tests can consume the changes without TiCDC.
+//
+// The changes are read from the binlog in `mysql.tidb_binlog`, which is written when
+// `binlog.enable-dump-source` is enabled. A changefeed runs on the TiDB instance where it's created, since
+// the sink is a local file. The commit ts of the last read transaction is saved in `mysql.tidb_changefeeds`
+// as the checkpoint, and a changefeed resumes from its checkpoint after it's paused or the TiDB instance
+// restarts. The sink is written at least once, the transactions after the checkpoint may be written again
+// when a changefeed resumes.
+package changefeed
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain/infosync"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+)
+
+// The states of a changefeed.
+const (
+	StateNormal = "normal"
+	StatePaused = "paused"
+	StateFailed = "failed"
+)
+
+// The formats of the events.
+const (
+	FormatCanalJSON = "canal-json"
+	FormatDebezium  = "debezium"
+)
+
+const selectChangefeedsSQL = `SELECT name, instance, filter_schema, filter_tables, sink_uri, format, state,
+	checkpoint_ts, error, created FROM mysql.tidb_changefeeds`
+
+// Changefeed is a changefeed in `mysql.tidb_changefeeds`.
+type Changefeed struct {
+	Name     string
+	Instance string
+	// Schema and Tables are the filter of the changefeed, the changes of all the user tables are
+	// captured if both are empty. Tables are in the form of `schema.table` in lower case.
+	Schema       string
+	Tables       []string
+	SinkURI      string
+	Format       string
+	State        string
+	CheckpointTS uint64
+	Error        string
+	Created      string
+}
+
+// Match returns whether the changes of the table are captured by the changefeed.
+func (cf *Changefeed) Match(schema, table string) bool {
+	schema, table = strings.ToLower(schema), strings.ToLower(table)
+	if util.IsMemOrSysDB(schema) {
+		return false
+	}
+	if len(cf.Tables) > 0 {
+		name := schema + "." + table
+		for _, tbl := range cf.Tables {
+			if tbl == name {
+				return true
+			}
+		}
+		return false
+	}
+	return cf.Schema == "" || cf.Schema == schema
+}
+
+// EncodeTables encodes the tables of the filter into the `filter_tables` column.
+func EncodeTables(tables []string) (string, error) {
+	if len(tables) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(tables)
+	return string(b), errors.Trace(err)
+}
+
+// LoadChangefeeds loads the changefeeds, only the changefeeds running on the instance are loaded if
+// the instance is not empty.
+func LoadChangefeeds(ctx context.Context, exec sqlexec.RestrictedSQLExecutor, instance string) ([]*Changefeed, error) {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	sql, args := selectChangefeedsSQL, []any(nil)
+	if instance != "" {
+		sql += " WHERE instance = %?"
+		args = append(args, instance)
+	}
+	rows, _, err := exec.ExecRestrictedSQL(ctx, nil, sql+" ORDER BY name", args...)
+	if err != nil {
+		return nil, err
+	}
+	changefeeds := make([]*Changefeed, 0, len(rows))
+	for _, row := range rows {
+		cf := &Changefeed{
+			Name:         row.GetString(0),
+			Instance:     row.GetString(1),
+			Schema:       row.GetString(2),
+			SinkURI:      row.GetString(4),
+			Format:       row.GetString(5),
+			State:        row.GetEnum(6).String(),
+			CheckpointTS: row.GetUint64(7),
+			Error:        row.GetString(8),
+			Created:      row.GetTime(9).String(),
+		}
+		if tables := row.GetString(3); tables != "" {
+			if err := json.Unmarshal([]byte(tables), &cf.Tables); err != nil {
+				return nil, errors.Annotatef(err, "invalid table filter of changefeed %s", cf.Name)
+			}
+		}
+		changefeeds = append(changefeeds, cf)
+	}
+	return changefeeds, nil
+}
+
+// InstanceAddr returns the address of the TiDB instance, which identifies the instance running a
+// changefeed across restarts.
+func InstanceAddr() (string, error) {
+	info, err := infosync.GetServerInfo()
+	if err != nil {
+		return "", err
+	}
+	return net.JoinHostPort(info.IP, strconv.Itoa(int(info.Port))), nil
+}
+
+// CheckFormat checks the format of the events, it returns the default format if the format is empty.
+func CheckFormat(format string) (string, error) {
+	switch format = strings.ToLower(format); format {
+	case "":
+		return FormatCanalJSON, nil
+	case FormatCanalJSON, FormatDebezium:
+		return format, nil
+	}
+	return "", errors.Errorf("unsupported format %q, the format must be %s or %s", format, FormatCanalJSON, FormatDebezium)
+}
+
+// CheckSinkURI checks the URI of the sink. Only the local file sink is supported, the URI is
+// `file:///path/to/file`, and the events are appended to the file one per line.
+func CheckSinkURI(uri string) error {
+	_, err := sinkPath(uri)
+	return err
+}
+
+func sinkPath(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", errors.Annotatef(err, "invalid sink URI %q", uri)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "kafka", "kafka+ssl":
+		return "", dbterror.ErrNotSupportedYet.GenWithStackByArgs("the kafka sink of changefeeds")
+	}
+	if !strings.EqualFold(u.Scheme, "file") {
+		return "", errors.Errorf("unsupported sink scheme %q, only the local file sink is supported", u.Scheme)
+	}
+	if u.Path == "" || strings.HasSuffix(u.Path, "/") {
+		return "", errors.Errorf("the sink URI %q must be the path of a file", uri)
+	}
+	return u.Path, nil
+}
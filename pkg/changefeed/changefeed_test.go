@@ -0,0 +1,211 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changefeed_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/changefeed"
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/sessionctx/binloginfo"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/tidb-binlog/memorypump"
+	pumpcli "github.com/pingcap/tidb/pkg/tidb-binlog/pump_client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatch(t *testing.T) {
+	cf := &changefeed.Changefeed{}
+	require.True(t, cf.Match("test", "t"))
+	require.False(t, cf.Match("mysql", "user"))
+	require.False(t, cf.Match("INFORMATION_SCHEMA", "tables"))
+
+	cf = &changefeed.Changefeed{Schema: "test"}
+	require.True(t, cf.Match("Test", "t"))
+	require.False(t, cf.Match("test2", "t"))
+
+	cf = &changefeed.Changefeed{Tables: []string{"test.t1", "test2.t2"}}
+	require.True(t, cf.Match("test", "T1"))
+	require.True(t, cf.Match("test2", "t2"))
+	require.False(t, cf.Match("test", "t2"))
+}
+
+func TestCheckSinkURIAndFormat(t *testing.T) {
+	require.NoError(t, changefeed.CheckSinkURI("file:///tmp/cdc/events.log"))
+	// The kafka-compatible sink is not implemented yet.
+	require.ErrorContains(t, changefeed.CheckSinkURI("kafka://127.0.0.1:9092/topic"), "doesn't yet support 'the kafka sink of changefeeds'")
+	require.ErrorContains(t, changefeed.CheckSinkURI("Kafka+SSL://127.0.0.1:9093/topic"), "doesn't yet support")
+	require.ErrorContains(t, changefeed.CheckSinkURI("s3://bucket/events.log"), `unsupported sink scheme "s3"`)
+	require.ErrorContains(t, changefeed.CheckSinkURI("file:///tmp/cdc/"), "must be the path of a file")
+	require.ErrorContains(t, changefeed.CheckSinkURI("/tmp/cdc/events.log"), `unsupported sink scheme ""`)
+
+	format, err := changefeed.CheckFormat("")
+	require.NoError(t, err)
+	require.Equal(t, changefeed.FormatCanalJSON, format)
+	format, err = changefeed.CheckFormat("Debezium")
+	require.NoError(t, err)
+	require.Equal(t, changefeed.FormatDebezium, format)
+	_, err = changefeed.CheckFormat("avro")
+	require.ErrorContains(t, err, `unsupported format "avro"`)
+}
+
+func enableMemoryPump(t *testing.T, tk *testkit.TestKit) {
+	pump := memorypump.New(1 << 20)
+	memorypump.SetPump(pump)
+	binloginfo.SetPumpsClient(pumpcli.NewInProcessPumpsClient(pump, time.Second))
+	t.Cleanup(func() {
+		binloginfo.SetPumpsClient(nil)
+		memorypump.SetPump(nil)
+	})
+	// The binlog is kept from the time the pump is registered as a source.
+	require.Eventually(t, func() bool {
+		return len(tk.MustQuery("select * from mysql.tidb_binlog_sources").Rows()) > 0
+	}, 10*time.Second, 100*time.Millisecond)
+}
+
+func readEvents(t *testing.T, path string) []map[string]any {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	require.NoError(t, err)
+	var events []map[string]any
+	for _, line := range bytes.Split(bytes.TrimSpace(content), []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		event := make(map[string]any)
+		dec := json.NewDecoder(bytes.NewReader(line))
+		dec.UseNumber()
+		require.NoError(t, dec.Decode(&event))
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestChangefeed(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustGetErrCode("create changefeed cf into 'file:///tmp/cf.log'", errno.ErrChangefeedInvalid)
+
+	enableMemoryPump(t, tk)
+	// The binlog client of a session is set when the session is created.
+	tk = testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v varchar(10))")
+	tk.MustExec("create table t2 (id int primary key)")
+
+	path := filepath.Join(t.TempDir(), "cf", "events.log")
+	tk.MustGetErrCode("create changefeed cf into 'kafka://127.0.0.1:9092/cdc'", errno.ErrNotSupportedYet)
+	tk.MustGetErrCode("create changefeed cf for table t into 'file://"+path+"' format 'avro'", errno.ErrChangefeedInvalid)
+	tk.MustExec("create changefeed cf for table t into 'file://" + path + "'")
+	tk.MustGetErrCode("create changefeed CF into 'file://"+path+"'", errno.ErrChangefeedExists)
+	tk.MustExec("create changefeed if not exists cf into 'file://" + path + "'")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 8268 Changefeed 'cf' already exists"))
+	tk.MustQuery("select name, filter_tables, format, state from mysql.tidb_changefeeds").Check(
+		testkit.Rows(`cf ["test.t"] canal-json normal`))
+
+	tk.MustExec("insert into t values (1, 'a'), (2, 'b')")
+	tk.MustExec("insert into t2 values (1)")
+	tk.MustExec("update t set v = 'c' where id = 1")
+	tk.MustExec("delete from t where id = 2")
+	var events []map[string]any
+	require.Eventually(t, func() bool {
+		events = readEvents(t, path)
+		return len(events) >= 4
+	}, 10*time.Second, 100*time.Millisecond)
+	require.Len(t, events, 4)
+	types := make([]string, 0, len(events))
+	for _, event := range events {
+		require.Equal(t, "test", event["database"])
+		require.Equal(t, "t", event["table"])
+		types = append(types, event["type"].(string))
+	}
+	require.Equal(t, []string{"INSERT", "INSERT", "UPDATE", "DELETE"}, types)
+	require.Equal(t, []any{map[string]any{"id": "1", "v": "c"}}, events[2]["data"])
+	require.Equal(t, []any{map[string]any{"id": "1", "v": "a"}}, events[2]["old"])
+	require.Equal(t, []any{map[string]any{"id": "2", "v": "b"}}, events[3]["data"])
+
+	// The checkpoint is saved after the events are written.
+	commitTS := events[3]["_tidb"].(map[string]any)["commitTs"].(json.Number).String()
+	require.Eventually(t, func() bool {
+		rows := tk.MustQuery("select checkpoint_ts >= " + commitTS + " from mysql.tidb_changefeeds where name = 'cf'").Rows()
+		return rows[0][0] == "1"
+	}, 10*time.Second, 100*time.Millisecond)
+
+	// The changes are not written when the changefeed is paused, and written after it's resumed. The
+	// running changefeeds are synced every second.
+	tk.MustExec("pause changefeed cf")
+	tk.MustQuery("show changefeeds").CheckAt([]int{0, 2, 3, 4, 5}, testkit.Rows(
+		"cf test.t file://"+path+" canal-json paused"))
+	time.Sleep(2 * time.Second)
+	tk.MustExec("insert into t values (3, 'd')")
+	time.Sleep(time.Second)
+	require.Len(t, readEvents(t, path), 4)
+	tk.MustExec("resume changefeed cf")
+	require.Eventually(t, func() bool {
+		return len(readEvents(t, path)) >= 5
+	}, 10*time.Second, 100*time.Millisecond)
+	events = readEvents(t, path)
+	require.Equal(t, []any{map[string]any{"id": "3", "v": "d"}}, events[len(events)-1]["data"])
+
+	tk.MustGetErrCode("pause changefeed cf2", errno.ErrChangefeedNotExists)
+	tk.MustExec("drop changefeed cf")
+	tk.MustExec("drop changefeed if exists cf")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 8269 Changefeed 'cf' doesn't exist"))
+	tk.MustQuery("show changefeeds").Check(testkit.Rows())
+}
+
+func TestChangefeedPurged(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	enableMemoryPump(t, tk)
+	tk.MustExec("create table test.t (id int primary key)")
+	checkFailed := func(name string) {
+		require.Eventually(t, func() bool {
+			rows := tk.MustQuery("select state, error from mysql.tidb_changefeeds where name = '" + name + "'").Rows()
+			return rows[0][0] == "failed"
+		}, 10*time.Second, 100*time.Millisecond)
+		tk.MustQuery("select error like '%have been purged from the binlog%' from mysql.tidb_changefeeds where name = '" + name + "'").
+			Check(testkit.Rows("1"))
+	}
+
+	// The changefeed fails if the changes after its checkpoint are purged before it starts.
+	tk.MustExec("create changefeed cf into 'file://" + filepath.Join(t.TempDir(), "cf.log") + "'")
+	tk.MustExec("pause changefeed cf")
+	tk.MustExec("update mysql.tidb_changefeeds set checkpoint_ts = 1 where name = 'cf'")
+	tk.MustExec("resume changefeed cf")
+	checkFailed("cf")
+
+	// The changefeed fails if the changes after its checkpoint are purged when it's running.
+	tk.MustExec("create changefeed cf2 into 'file://" + filepath.Join(t.TempDir(), "cf2.log") + "'")
+	checkpoint := tk.MustQuery("select checkpoint_ts from mysql.tidb_changefeeds where name = 'cf2'").Rows()[0][0].(string)
+	// The binlog client of a session is set when the session is created.
+	testkit.NewTestKit(t, store).MustExec("insert into test.t values (1)")
+	require.Eventually(t, func() bool {
+		rows := tk.MustQuery("select checkpoint_ts > " + checkpoint + " from mysql.tidb_changefeeds where name = 'cf2'").Rows()
+		return rows[0][0] == "1"
+	}, 10*time.Second, 100*time.Millisecond)
+	tk.MustExec("begin")
+	ts := tk.MustQuery("select @@tidb_current_ts").Rows()[0][0].(string)
+	tk.MustExec("commit")
+	tk.MustExec("update mysql.tidb set variable_value = '" + ts + "' where variable_name = 'tidb_binlog_purged_ts'")
+	checkFailed("cf2")
+}
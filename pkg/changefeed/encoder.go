@@ -0,0 +1,416 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changefeed
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/tidb-binlog/binlogdump"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tipb/go-binlog"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+// RowEvent is a row change of a committed transaction.
+type RowEvent struct {
+	Schema   string
+	Table    *model.TableInfo
+	CommitTS uint64
+	// Index is the index of the row change in the transaction.
+	Index int
+	binlogdump.RowChange
+}
+
+// Encoder encodes a row event into a single line of JSON.
+type Encoder interface {
+	Encode(ev *RowEvent) ([]byte, error)
+}
+
+// NewEncoder creates an encoder of the format, the name of the changefeed is written in the events
+// if the format has such a field.
+func NewEncoder(format, changefeed string) (Encoder, error) {
+	switch format {
+	case FormatCanalJSON:
+		return canalJSONEncoder{}, nil
+	case FormatDebezium:
+		return debeziumEncoder{changefeed: changefeed}, nil
+	}
+	return nil, errors.Errorf("unsupported format %q", format)
+}
+
+// now returns the current time in milliseconds, it's a variable for testing.
+var now = func() int64 {
+	return time.Now().UnixMilli()
+}
+
+func commitTimeMillis(commitTS uint64) int64 {
+	return oracle.ExtractPhysical(commitTS)
+}
+
+func isBinary(ft *types.FieldType) bool {
+	return types.IsBinaryStr(ft) || ft.GetType() == mysql.TypeBit
+}
+
+// canalJSONEncoder encodes the events in the canal-json format. All the values are strings, and the
+// binary values are decoded as ISO-8859-1 like Canal does.
+type canalJSONEncoder struct{}
+
+type canalJSONMessage struct {
+	ID        int64               `json:"id"`
+	Database  string              `json:"database"`
+	Table     string              `json:"table"`
+	PKNames   []string            `json:"pkNames"`
+	IsDDL     bool                `json:"isDdl"`
+	Type      string              `json:"type"`
+	ES        int64               `json:"es"`
+	TS        int64               `json:"ts"`
+	SQL       string              `json:"sql"`
+	SQLType   map[string]int      `json:"sqlType"`
+	MySQLType map[string]string   `json:"mysqlType"`
+	Data      []map[string]any    `json:"data"`
+	Old       []map[string]any    `json:"old"`
+	TiDB      canalJSONTiDBExtras `json:"_tidb"`
+}
+
+type canalJSONTiDBExtras struct {
+	CommitTS uint64 `json:"commitTs"`
+}
+
+// Encode implements the Encoder interface.
+func (canalJSONEncoder) Encode(ev *RowEvent) ([]byte, error) {
+	cols := ev.Table.Cols()
+	msg := &canalJSONMessage{
+		Database:  ev.Schema,
+		Table:     ev.Table.Name.O,
+		PKNames:   pkNames(ev.Table),
+		ES:        commitTimeMillis(ev.CommitTS),
+		TS:        now(),
+		SQLType:   make(map[string]int, len(cols)),
+		MySQLType: make(map[string]string, len(cols)),
+		TiDB:      canalJSONTiDBExtras{CommitTS: ev.CommitTS},
+	}
+	for _, col := range cols {
+		msg.SQLType[col.Name.O] = javaSQLType(&col.FieldType)
+		msg.MySQLType[col.Name.O] = col.GetTypeDesc()
+	}
+	var err error
+	switch ev.Tp {
+	case binlog.MutationType_Insert:
+		msg.Type = "INSERT"
+		msg.Data, err = canalJSONRows(cols, ev.After)
+	case binlog.MutationType_Update:
+		msg.Type = "UPDATE"
+		if msg.Data, err = canalJSONRows(cols, ev.After); err == nil {
+			msg.Old, err = canalJSONRows(cols, ev.Before)
+		}
+	case binlog.MutationType_DeleteRow:
+		msg.Type = "DELETE"
+		msg.Data, err = canalJSONRows(cols, ev.Before)
+	default:
+		return nil, errors.Errorf("unknown mutation type %v", ev.Tp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(msg)
+}
+
+func canalJSONRows(cols []*model.ColumnInfo, row map[int64]types.Datum) ([]map[string]any, error) {
+	values := make(map[string]any, len(cols))
+	for _, col := range cols {
+		d, ok := row[col.ID]
+		if !ok || d.IsNull() {
+			values[col.Name.O] = nil
+			continue
+		}
+		var s string
+		switch {
+		case col.GetType() == mysql.TypeBit:
+			v, err := d.GetBinaryLiteral().ToInt(types.DefaultStmtNoWarningContext)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			s = strconv.FormatUint(v, 10)
+		case isBinary(&col.FieldType):
+			s = latin1String(d.GetBytes())
+		default:
+			var err error
+			if s, err = d.ToString(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		values[col.Name.O] = s
+	}
+	return []map[string]any{values}, nil
+}
+
+func latin1String(b []byte) string {
+	var sb strings.Builder
+	sb.Grow(len(b))
+	for _, c := range b {
+		sb.WriteRune(rune(c))
+	}
+	return sb.String()
+}
+
+func pkNames(tbl *model.TableInfo) []string {
+	if tbl.PKIsHandle {
+		if col := tbl.GetPkColInfo(); col != nil {
+			return []string{col.Name.O}
+		}
+	}
+	if pk := tbl.GetPrimaryKey(); pk != nil {
+		names := make([]string, 0, len(pk.Columns))
+		for _, col := range pk.Columns {
+			names = append(names, col.Name.O)
+		}
+		return names
+	}
+	return nil
+}
+
+// The values of java.sql.Types used by canal-json.
+const (
+	javaBit       = -7
+	javaTinyInt   = -6
+	javaSmallInt  = 5
+	javaInteger   = 4
+	javaBigInt    = -5
+	javaReal      = 7
+	javaDouble    = 8
+	javaDecimal   = 3
+	javaChar      = 1
+	javaVarchar   = 12
+	javaBinary    = -2
+	javaVarBinary = -3
+	javaDate      = 91
+	javaTime      = 92
+	javaTimestamp = 93
+	javaBlob      = 2004
+	javaClob      = 2005
+	javaOther     = 1111
+)
+
+func javaSQLType(ft *types.FieldType) int {
+	binary := isBinary(ft)
+	switch ft.GetType() {
+	case mysql.TypeBit:
+		return javaBit
+	case mysql.TypeTiny:
+		return javaTinyInt
+	case mysql.TypeShort:
+		return javaSmallInt
+	case mysql.TypeInt24, mysql.TypeLong:
+		return javaInteger
+	case mysql.TypeLonglong:
+		if mysql.HasUnsignedFlag(ft.GetFlag()) {
+			// An unsigned bigint may overflow a Java long.
+			return javaDecimal
+		}
+		return javaBigInt
+	case mysql.TypeFloat:
+		return javaReal
+	case mysql.TypeDouble:
+		return javaDouble
+	case mysql.TypeNewDecimal:
+		return javaDecimal
+	case mysql.TypeString:
+		if binary {
+			return javaBinary
+		}
+		return javaChar
+	case mysql.TypeVarchar, mysql.TypeVarString:
+		if binary {
+			return javaVarBinary
+		}
+		return javaVarchar
+	case mysql.TypeTinyBlob, mysql.TypeBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+		if binary {
+			return javaBlob
+		}
+		return javaClob
+	case mysql.TypeDate:
+		return javaDate
+	case mysql.TypeDuration:
+		return javaTime
+	case mysql.TypeDatetime, mysql.TypeTimestamp:
+		return javaTimestamp
+	case mysql.TypeYear, mysql.TypeEnum, mysql.TypeSet, mysql.TypeJSON, mysql.TypeTiDBVectorFloat32:
+		return javaVarchar
+	}
+	return javaOther
+}
+
+// debeziumEncoder encodes the events in the Debezium format without the schema. The values are
+// converted as Debezium does by default, e.g. DATE is the number of days since the epoch.
+type debeziumEncoder struct {
+	changefeed string
+}
+
+type debeziumMessage struct {
+	Payload debeziumPayload `json:"payload"`
+}
+
+type debeziumPayload struct {
+	Before      map[string]any `json:"before"`
+	After       map[string]any `json:"after"`
+	Source      debeziumSource `json:"source"`
+	Op          string         `json:"op"`
+	TSMs        int64          `json:"ts_ms"`
+	Transaction any            `json:"transaction"`
+}
+
+type debeziumSource struct {
+	Version   string `json:"version"`
+	Connector string `json:"connector"`
+	Name      string `json:"name"`
+	TSMs      int64  `json:"ts_ms"`
+	Snapshot  string `json:"snapshot"`
+	DB        string `json:"db"`
+	Table     string `json:"table"`
+	ServerID  int64  `json:"server_id"`
+	GTID      any    `json:"gtid"`
+	File      string `json:"file"`
+	Pos       int64  `json:"pos"`
+	Row       int    `json:"row"`
+	Thread    int64  `json:"thread"`
+	Query     any    `json:"query"`
+	CommitTS  uint64 `json:"commit_ts"`
+}
+
+// Encode implements the Encoder interface.
+func (e debeziumEncoder) Encode(ev *RowEvent) ([]byte, error) {
+	cols := ev.Table.Cols()
+	msg := &debeziumMessage{Payload: debeziumPayload{
+		Source: debeziumSource{
+			Version:   "2.4.0.Final",
+			Connector: "TiDB",
+			Name:      e.changefeed,
+			TSMs:      commitTimeMillis(ev.CommitTS),
+			Snapshot:  "false",
+			DB:        ev.Schema,
+			Table:     ev.Table.Name.O,
+			Row:       ev.Index,
+			CommitTS:  ev.CommitTS,
+		},
+		TSMs: now(),
+	}}
+	var err error
+	switch ev.Tp {
+	case binlog.MutationType_Insert:
+		msg.Payload.Op = "c"
+	case binlog.MutationType_Update:
+		msg.Payload.Op = "u"
+	case binlog.MutationType_DeleteRow:
+		msg.Payload.Op = "d"
+	default:
+		return nil, errors.Errorf("unknown mutation type %v", ev.Tp)
+	}
+	if ev.Before != nil {
+		if msg.Payload.Before, err = debeziumRow(cols, ev.Before); err != nil {
+			return nil, err
+		}
+	}
+	if ev.After != nil {
+		if msg.Payload.After, err = debeziumRow(cols, ev.After); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(msg)
+}
+
+func debeziumRow(cols []*model.ColumnInfo, row map[int64]types.Datum) (map[string]any, error) {
+	values := make(map[string]any, len(cols))
+	for _, col := range cols {
+		d, ok := row[col.ID]
+		if !ok || d.IsNull() {
+			values[col.Name.O] = nil
+			continue
+		}
+		v, err := debeziumValue(&col.FieldType, &d)
+		if err != nil {
+			return nil, errors.Annotatef(err, "convert column %s", col.Name.O)
+		}
+		values[col.Name.O] = v
+	}
+	return values, nil
+}
+
+func debeziumValue(ft *types.FieldType, d *types.Datum) (any, error) {
+	switch ft.GetType() {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong, mysql.TypeYear:
+		if mysql.HasUnsignedFlag(ft.GetFlag()) {
+			return d.GetUint64(), nil
+		}
+		return d.GetInt64(), nil
+	case mysql.TypeFloat:
+		return d.GetFloat32(), nil
+	case mysql.TypeDouble:
+		return d.GetFloat64(), nil
+	case mysql.TypeNewDecimal:
+		return d.GetMysqlDecimal().String(), nil
+	case mysql.TypeBit:
+		v, err := d.GetBinaryLiteral().ToInt(types.DefaultStmtNoWarningContext)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if ft.GetFlen() == 1 {
+			return v == 1, nil
+		}
+		// The bits are little-endian bytes.
+		b := make([]byte, (ft.GetFlen()+7)/8)
+		for i := range b {
+			b[i] = byte(v >> (8 * i))
+		}
+		return base64.StdEncoding.EncodeToString(b), nil
+	case mysql.TypeDate:
+		t, err := d.GetMysqlTime().GoTime(time.UTC)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return t.Unix() / (24 * 60 * 60), nil
+	case mysql.TypeDatetime:
+		// DATETIME has no time zone, it's converted as if it's in UTC.
+		t, err := d.GetMysqlTime().GoTime(time.UTC)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if ft.GetDecimal() > 3 {
+			return t.UnixMicro(), nil
+		}
+		return t.UnixMilli(), nil
+	case mysql.TypeTimestamp:
+		// The binlog keeps TIMESTAMP in UTC.
+		t, err := d.GetMysqlTime().GoTime(time.UTC)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return t.Format(time.RFC3339Nano), nil
+	case mysql.TypeDuration:
+		return d.GetMysqlDuration().Duration.Microseconds(), nil
+	}
+	if isBinary(ft) {
+		return base64.StdEncoding.EncodeToString(d.GetBytes()), nil
+	}
+	s, err := d.ToString()
+	return s, errors.Trace(err)
+}
@@ -0,0 +1,129 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changefeed_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/changefeed"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/tidb-binlog/binlogdump"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tipb/go-binlog"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTable() *model.TableInfo {
+	newCol := func(id int64, name string, tp byte, flag uint) *model.ColumnInfo {
+		ft := types.NewFieldType(tp)
+		ft.AddFlag(flag)
+		return &model.ColumnInfo{ID: id, Name: model.NewCIStr(name), Offset: int(id - 1), State: model.StatePublic, FieldType: *ft}
+	}
+	id := newCol(1, "id", mysql.TypeLonglong, mysql.PriKeyFlag|mysql.NotNullFlag)
+	v := newCol(2, "v", mysql.TypeVarchar, 0)
+	v.SetFlen(10)
+	v.SetCharset(mysql.DefaultCharset)
+	v.SetCollate(mysql.DefaultCollationName)
+	d := newCol(3, "d", mysql.TypeDate, 0)
+	b := newCol(4, "b", mysql.TypeBit, mysql.UnsignedFlag)
+	b.SetFlen(1)
+	return &model.TableInfo{
+		Name:       model.NewCIStr("t"),
+		PKIsHandle: true,
+		Columns:    []*model.ColumnInfo{id, v, d, b},
+	}
+}
+
+func newTestRow(id int64, v string) map[int64]types.Datum {
+	return map[int64]types.Datum{
+		1: types.NewIntDatum(id),
+		2: types.NewStringDatum(v),
+		3: types.NewTimeDatum(types.NewTime(types.FromDate(1970, 1, 11, 0, 0, 0, 0), mysql.TypeDate, 0)),
+		4: types.NewMysqlBitDatum(types.NewBinaryLiteralFromUint(1, 1)),
+	}
+}
+
+func encode(t *testing.T, format string, ev *changefeed.RowEvent) map[string]any {
+	encoder, err := changefeed.NewEncoder(format, "cf")
+	require.NoError(t, err)
+	b, err := encoder.Encode(ev)
+	require.NoError(t, err)
+	msg := make(map[string]any)
+	require.NoError(t, json.Unmarshal(b, &msg))
+	return msg
+}
+
+func TestCanalJSONEncoder(t *testing.T) {
+	ev := &changefeed.RowEvent{
+		Schema:   "test",
+		Table:    newTestTable(),
+		CommitTS: 449550075326529538,
+		RowChange: binlogdump.RowChange{
+			Tp:     binlog.MutationType_Update,
+			Before: newTestRow(1, "a"),
+			After:  newTestRow(1, "b"),
+		},
+	}
+	msg := encode(t, changefeed.FormatCanalJSON, ev)
+	require.Equal(t, "test", msg["database"])
+	require.Equal(t, "t", msg["table"])
+	require.Equal(t, "UPDATE", msg["type"])
+	require.Equal(t, false, msg["isDdl"])
+	require.Equal(t, []any{"id"}, msg["pkNames"])
+	require.Equal(t, map[string]any{"id": "bigint(20)", "v": "varchar(10)", "d": "date", "b": "bit(1)"}, msg["mysqlType"])
+	require.Equal(t, map[string]any{"id": float64(-5), "v": float64(12), "d": float64(91), "b": float64(-7)}, msg["sqlType"])
+	require.Equal(t, []any{map[string]any{"id": "1", "v": "b", "d": "1970-01-11", "b": "1"}}, msg["data"])
+	require.Equal(t, []any{map[string]any{"id": "1", "v": "a", "d": "1970-01-11", "b": "1"}}, msg["old"])
+	require.Equal(t, map[string]any{"commitTs": float64(449550075326529538)}, msg["_tidb"])
+
+	ev.Tp, ev.Before, ev.After = binlog.MutationType_DeleteRow, newTestRow(2, "c"), nil
+	msg = encode(t, changefeed.FormatCanalJSON, ev)
+	require.Equal(t, "DELETE", msg["type"])
+	require.Equal(t, []any{map[string]any{"id": "2", "v": "c", "d": "1970-01-11", "b": "1"}}, msg["data"])
+	require.Nil(t, msg["old"])
+}
+
+func TestDebeziumEncoder(t *testing.T) {
+	ev := &changefeed.RowEvent{
+		Schema:   "test",
+		Table:    newTestTable(),
+		CommitTS: 449550075326529538,
+		Index:    1,
+		RowChange: binlogdump.RowChange{
+			Tp:    binlog.MutationType_Insert,
+			After: newTestRow(1, "a"),
+		},
+	}
+	msg := encode(t, changefeed.FormatDebezium, ev)
+	payload := msg["payload"].(map[string]any)
+	require.Equal(t, "c", payload["op"])
+	require.Nil(t, payload["before"])
+	require.Equal(t, map[string]any{"id": float64(1), "v": "a", "d": float64(10), "b": true}, payload["after"])
+	source := payload["source"].(map[string]any)
+	require.Equal(t, "cf", source["name"])
+	require.Equal(t, "TiDB", source["connector"])
+	require.Equal(t, "test", source["db"])
+	require.Equal(t, "t", source["table"])
+	require.Equal(t, float64(1), source["row"])
+	require.Equal(t, float64(449550075326529538), source["commit_ts"])
+
+	ev.Tp, ev.Before, ev.After = binlog.MutationType_DeleteRow, newTestRow(1, "a"), nil
+	payload = encode(t, changefeed.FormatDebezium, ev)["payload"].(map[string]any)
+	require.Equal(t, "d", payload["op"])
+	require.NotNil(t, payload["before"])
+	require.Nil(t, payload["after"])
+}
@@ -0,0 +1,337 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changefeed
+
+import (
+	"context"
+	"time"
+
+	"github.com/ngaut/pools"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/tidb-binlog/binlogdump"
+	"github.com/pingcap/tidb/pkg/tidb-binlog/binlogstore"
+	"github.com/pingcap/tidb/pkg/tidb-binlog/memorypump"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+const (
+	// syncInterval is the interval to sync the running changefeeds with `mysql.tidb_changefeeds`.
+	syncInterval = time.Second
+	// checkpointInterval is the min interval to save the checkpoint of a changefeed.
+	checkpointInterval = time.Second
+	// pollInterval is the interval to read the binlog when there is no new transaction.
+	pollInterval  = 100 * time.Millisecond
+	readBatchSize = 64
+)
+
+type sessionPool interface {
+	Get() (pools.Resource, error)
+	Put(pools.Resource)
+}
+
+// Manager runs the changefeeds of the TiDB instance.
+type Manager struct {
+	pool       sessionPool
+	infoSchema func(ts uint64) (infoschema.InfoSchema, error)
+	workers    map[string]*worker
+}
+
+// NewManager creates a new Manager, infoSchema returns the information schema at the ts.
+func NewManager(pool sessionPool, infoSchema func(ts uint64) (infoschema.InfoSchema, error)) *Manager {
+	return &Manager{
+		pool:       pool,
+		infoSchema: infoSchema,
+		workers:    make(map[string]*worker),
+	}
+}
+
+// Run runs the changefeeds until the exit channel is closed.
+func (m *Manager) Run(exit <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		m.stopAll()
+		logutil.BgLogger().Info("changefeed manager exited")
+	}()
+
+	ticker := time.NewTicker(syncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-exit:
+			return
+		case <-ticker.C:
+			if err := m.sync(ctx); err != nil {
+				logutil.BgLogger().Warn("failed to sync changefeeds", zap.Error(err))
+			}
+		}
+	}
+}
+
+// sync starts the changefeeds which should be running, and stops the others.
+func (m *Manager) sync(ctx context.Context) error {
+	if memorypump.GetPump() == nil {
+		m.stopAll()
+		return nil
+	}
+	instance, err := InstanceAddr()
+	if err != nil {
+		return err
+	}
+	var changefeeds []*Changefeed
+	err = m.withSession(func(sctx sessionctx.Context) (err error) {
+		changefeeds, err = LoadChangefeeds(ctx, sctx.GetRestrictedSQLExecutor(), instance)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	running := make(map[string]*Changefeed, len(changefeeds))
+	for _, cf := range changefeeds {
+		if cf.State == StateNormal {
+			running[cf.Name] = cf
+		}
+	}
+	for name, w := range m.workers {
+		select {
+		case <-w.done:
+			delete(m.workers, name)
+			if w.err != nil {
+				logutil.BgLogger().Warn("changefeed failed", zap.String("changefeed", name), zap.Error(w.err))
+				if err := m.setFailed(ctx, name, w.err); err != nil {
+					return err
+				}
+				delete(running, name)
+			}
+			continue
+		default:
+		}
+		if _, ok := running[name]; !ok {
+			w.stop()
+			delete(m.workers, name)
+		}
+	}
+	for name, cf := range running {
+		if _, ok := m.workers[name]; !ok {
+			m.workers[name] = m.startWorker(ctx, cf)
+		}
+	}
+	return nil
+}
+
+func (m *Manager) stopAll() {
+	for name, w := range m.workers {
+		w.stop()
+		delete(m.workers, name)
+	}
+}
+
+func (m *Manager) setFailed(ctx context.Context, name string, cause error) error {
+	return m.withSession(func(sctx sessionctx.Context) error {
+		ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+		_, _, err := sctx.GetRestrictedSQLExecutor().ExecRestrictedSQL(ctx, nil,
+			"UPDATE mysql.tidb_changefeeds SET state = %?, error = %? WHERE name = %? AND state = %?",
+			StateFailed, cause.Error(), name, StateNormal)
+		return err
+	})
+}
+
+func (m *Manager) saveCheckpoint(ctx context.Context, name string, checkpointTS uint64) error {
+	return m.withSession(func(sctx sessionctx.Context) error {
+		ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+		_, _, err := sctx.GetRestrictedSQLExecutor().ExecRestrictedSQL(ctx, nil,
+			"UPDATE mysql.tidb_changefeeds SET checkpoint_ts = %? WHERE name = %? AND checkpoint_ts < %?",
+			checkpointTS, name, checkpointTS)
+		return err
+	})
+}
+
+func (m *Manager) withSession(fn func(sctx sessionctx.Context) error) error {
+	resource, err := m.pool.Get()
+	if err != nil {
+		return err
+	}
+	defer m.pool.Put(resource)
+	sctx, ok := resource.(sessionctx.Context)
+	if !ok {
+		return errors.Errorf("%T is not sessionctx.Context", resource)
+	}
+	return fn(sctx)
+}
+
+// worker writes the changes of a changefeed to its sink.
+type worker struct {
+	m  *Manager
+	cf *Changefeed
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	// err is the error which stops the worker, it's read after done is closed.
+	err error
+}
+
+func (m *Manager) startWorker(ctx context.Context, cf *Changefeed) *worker {
+	ctx, cancel := context.WithCancel(ctx)
+	w := &worker{
+		m:      m,
+		cf:     cf,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go func() {
+		defer close(w.done)
+		w.err = w.run(ctx)
+	}()
+	logutil.BgLogger().Info("changefeed started", zap.String("changefeed", cf.Name),
+		zap.Uint64("checkpointTS", cf.CheckpointTS), zap.String("sink", cf.SinkURI))
+	return w
+}
+
+func (w *worker) stop() {
+	w.cancel()
+	<-w.done
+}
+
+func (w *worker) run(ctx context.Context) (err error) {
+	encoder, err := NewEncoder(w.cf.Format, w.cf.Name)
+	if err != nil {
+		return err
+	}
+	sink, err := NewSink(w.cf.SinkURI)
+	if err != nil {
+		return err
+	}
+	checkpointTS, savedTS := w.cf.CheckpointTS, w.cf.CheckpointTS
+	defer func() {
+		if err1 := sink.Close(); err == nil {
+			err = err1
+		}
+		// Save the checkpoint when the changefeed is paused or dropped, or the instance exits.
+		if err == nil && checkpointTS > savedTS {
+			err = w.m.saveCheckpoint(context.Background(), w.cf.Name, checkpointTS)
+		}
+	}()
+
+	lastSaved := time.Now()
+	saveCheckpoint := func(ctx context.Context) error {
+		if err := sink.Flush(); err != nil {
+			return err
+		}
+		if err := w.m.saveCheckpoint(ctx, w.cf.Name, checkpointTS); err != nil {
+			return err
+		}
+		savedTS, lastSaved = checkpointTS, time.Now()
+		return nil
+	}
+	// The changes after the checkpoint must be kept in the binlog, or the changefeed skips them silently.
+	// The binlog of a new cluster starts after the first source is registered, a changefeed created before
+	// it fails when the source is registered with a later purged ts.
+	reader := binlogstore.NewReader(w.m.pool)
+	purgedTS, err := reader.PurgedTS(ctx)
+	if err != nil {
+		return err
+	}
+	if checkpointTS < purgedTS {
+		return errPurged(checkpointTS, purgedTS)
+	}
+	for {
+		txns, readErr := reader.Read(ctx, checkpointTS, readBatchSize)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if readErr == binlogstore.ErrPurged {
+			purgedTS, err := reader.PurgedTS(ctx)
+			if err != nil {
+				return err
+			}
+			return errPurged(checkpointTS, purgedTS)
+		}
+		if readErr != nil {
+			return readErr
+		}
+		for _, txn := range txns {
+			if err := w.writeTxn(encoder, sink, txn); err != nil {
+				return err
+			}
+			checkpointTS = txn.CommitTS
+		}
+		if checkpointTS > savedTS && time.Since(lastSaved) >= checkpointInterval {
+			if err := saveCheckpoint(ctx); err != nil {
+				return err
+			}
+		}
+		if len(txns) > 0 {
+			continue
+		}
+
+		var timer <-chan time.Time
+		if checkpointTS > savedTS {
+			timer = time.After(checkpointInterval - time.Since(lastSaved))
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(pollInterval):
+		case <-timer:
+			if err := saveCheckpoint(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func errPurged(checkpointTS, purgedTS uint64) error {
+	return errors.Errorf("the changes after the checkpoint %d have been purged from the binlog, which is kept after %d, "+
+		"increase binlog.dump-source-retention to keep more changes", checkpointTS, purgedTS)
+}
+
+// writeTxn writes the row changes of the transaction which match the filter.
+func (w *worker) writeTxn(encoder Encoder, sink Sink, txn *binlogstore.Txn) error {
+	if txn.Prewrite == nil {
+		return nil
+	}
+	is, err := w.m.infoSchema(txn.CommitTS)
+	if err != nil {
+		return err
+	}
+	changes, err := binlogdump.DecodeTxn(is, txn)
+	if err != nil {
+		return err
+	}
+	ev := RowEvent{CommitTS: txn.CommitTS}
+	for _, tbl := range changes {
+		if !w.cf.Match(tbl.Schema, tbl.Table.Name.L) {
+			continue
+		}
+		ev.Schema, ev.Table = tbl.Schema, tbl.Table
+		for _, row := range tbl.Rows {
+			ev.RowChange = row
+			event, err := encoder.Encode(&ev)
+			if err != nil {
+				return errors.Annotatef(err, "encode the row change of table %s.%s", tbl.Schema, tbl.Table.Name.O)
+			}
+			if err := sink.WriteEvent(event); err != nil {
+				return err
+			}
+			ev.Index++
+		}
+	}
+	return nil
+}
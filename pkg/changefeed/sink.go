@@ -0,0 +1,79 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changefeed
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+
+	"github.com/pingcap/errors"
+)
+
+// Sink is where the events of a changefeed are written.
+type Sink interface {
+	// WriteEvent writes an event, the event may be buffered.
+	WriteEvent(event []byte) error
+	// Flush makes the written events durable.
+	Flush() error
+	Close() error
+}
+
+// fileSink appends the events to a local file, one event per line.
+type fileSink struct {
+	f *os.File
+	w *bufio.Writer
+}
+
+// NewSink creates the sink of the URI.
+func NewSink(uri string) (Sink, error) {
+	path, err := sinkPath(uri)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, errors.Trace(err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &fileSink{f: f, w: bufio.NewWriter(f)}, nil
+}
+
+// WriteEvent implements the Sink interface.
+func (s *fileSink) WriteEvent(event []byte) error {
+	if _, err := s.w.Write(event); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(s.w.WriteByte('\n'))
+}
+
+// Flush implements the Sink interface.
+func (s *fileSink) Flush() error {
+	if err := s.w.Flush(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(s.f.Sync())
+}
+
+// Close implements the Sink interface.
+func (s *fileSink) Close() error {
+	err := s.Flush()
+	if err1 := s.f.Close(); err == nil {
+		err = errors.Trace(err1)
+	}
+	return err
+}
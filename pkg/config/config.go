@@ -821,6 +821,15 @@ type Binlog struct {
 	BinlogSocket string `toml:"binlog-socket" json:"binlog-socket"`
 	// The strategy for sending binlog to pump, value can be "range" or "hash" now.
 	Strategy string `toml:"strategy" json:"strategy"`
+	// EnableDumpSource keeps the binlog of the transactions committed by this TiDB instance in
+	// `mysql.tidb_binlog`, so MySQL replicas can stream them by COM_BINLOG_DUMP and COM_BINLOG_DUMP_GTID.
+	// It must be enabled on all the TiDB instances of the cluster, and it only takes effect when Enable is false.
+	EnableDumpSource bool `toml:"enable-dump-source" json:"enable-dump-source"`
+	// DumpSourceBufferSize is the max size in bytes of the binlog buffered in memory before it's written
+	// to `mysql.tidb_binlog`, the transactions wait when the buffer is full.
+	DumpSourceBufferSize uint64 `toml:"dump-source-buffer-size" json:"dump-source-buffer-size"`
+	// DumpSourceRetention is how long the binlog is kept in `mysql.tidb_binlog`, it's kept forever if it's empty.
+	DumpSourceRetention string `toml:"dump-source-retention" json:"dump-source-retention"`
 }
 
 // PessimisticTxn is the config for pessimistic transaction.
@@ -1044,8 +1053,10 @@ var defaultConf = Config{
 	PDClient:   defTiKVCfg.PDClient,
 	TiKVClient: defTiKVCfg.TiKVClient,
 	Binlog: Binlog{
-		WriteTimeout: "15s",
-		Strategy:     "range",
+		WriteTimeout:         "15s",
+		Strategy:             "range",
+		DumpSourceBufferSize: 256 << 20,
+		DumpSourceRetention:  "24h",
 	},
 	Plugin: Plugin{
 		Dir:  "/data/deploy/plugin",
@@ -1389,6 +1400,12 @@ func (c *Config) Valid() error {
 		}
 	}
 
+	if c.Binlog.DumpSourceRetention != "" {
+		if _, err := time.ParseDuration(c.Binlog.DumpSourceRetention); err != nil {
+			return fmt.Errorf("invalid [binlog]dump-source-retention %s", c.Binlog.DumpSourceRetention)
+		}
+	}
+
 	// test security
 	c.Security.SpilledFileEncryptionMethod = strings.ToLower(c.Security.SpilledFileEncryptionMethod)
 	switch c.Security.SpilledFileEncryptionMethod {
@@ -57,6 +57,7 @@ import (
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/sessiontxn"
+	"github.com/pingcap/tidb/pkg/statistics"
 	"github.com/pingcap/tidb/pkg/table"
 	"github.com/pingcap/tidb/pkg/table/tables"
 	"github.com/pingcap/tidb/pkg/tablecodec"
@@ -1023,9 +1024,13 @@ func buildColumnAndConstraint(
 // checkColumnDefaultValue checks the default value of the column.
 // In non-strict SQL mode, if the default value of the column is an empty string, the default value can be ignored.
 // In strict SQL mode, TEXT/BLOB/JSON can't have not null default values.
+// GEOMETRY can't have not null default values in any SQL mode.
 // In NO_ZERO_DATE SQL mode, TIMESTAMP/DATE/DATETIME type can't have zero date like '0000-00-00' or '0000-00-00 00:00:00'.
 func checkColumnDefaultValue(ctx exprctx.BuildContext, col *table.Column, value any) (bool, any, error) {
 	hasDefaultValue := true
+	if value != nil && col.GetType() == mysql.TypeGeometry {
+		return hasDefaultValue, value, dbterror.ErrBlobCantHaveDefault.GenWithStackByArgs(col.Name.O)
+	}
 	if value != nil && (col.GetType() == mysql.TypeJSON ||
 		col.GetType() == mysql.TypeTinyBlob || col.GetType() == mysql.TypeMediumBlob ||
 		col.GetType() == mysql.TypeLongBlob || col.GetType() == mysql.TypeBlob) {
@@ -1254,6 +1259,10 @@ func columnDefToCol(ctx sessionctx.Context, offset int, colDef *ast.ColumnDef, o
 				}
 			case ast.ColumnOptionFulltext:
 				ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrTableCantHandleFt.FastGenByArgs())
+			case ast.ColumnOptionSRID:
+				if err = setColumnSRID(col, v); err != nil {
+					return nil, nil, errors.Trace(err)
+				}
 			case ast.ColumnOptionCheck:
 				if !variable.EnableCheckConstraint.Load() {
 					ctx.GetSessionVars().StmtCtx.AppendWarning(errCheckConstraintIsOff)
@@ -2115,6 +2124,11 @@ func BuildTableInfo(
 	}
 	foreignKeyID := tbInfo.MaxForeignKeyID
 	for _, constr := range constraints {
+		if constr.Tp == ast.ConstraintFulltext {
+			if err := checkFulltextIndexParts(constr.Keys); err != nil {
+				return nil, err
+			}
+		}
 		// Build hidden columns if necessary.
 		hiddenCols, err := buildHiddenColumnInfoWithCheck(ctx, constr.Keys, model.NewCIStr(constr.Name), tbInfo, tblColumns)
 		if err != nil {
@@ -2177,13 +2191,9 @@ func BuildTableInfo(
 			}
 		}
 
-		if constr.Tp == ast.ConstraintFulltext {
-			ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrTableCantHandleFt.FastGenByArgs())
-			continue
-		}
-
 		var (
 			indexName       = constr.Name
+			indexOption     = constr.Option
 			primary, unique bool
 		)
 
@@ -2195,6 +2205,8 @@ func BuildTableInfo(
 			indexName = mysql.PrimaryKeyName
 		case ast.ConstraintUniq, ast.ConstraintUniqKey, ast.ConstraintUniqIndex:
 			unique = true
+		case ast.ConstraintFulltext:
+			indexOption = buildFulltextIndexOption(constr.Option)
 		}
 
 		// check constraint
@@ -2269,7 +2281,7 @@ func BuildTableInfo(
 			unique,
 			false,
 			constr.Keys,
-			constr.Option,
+			indexOption,
 			model.StatePublic,
 		)
 		if err != nil {
@@ -2804,6 +2816,9 @@ func (d *ddl) createTableWithInfoJob(
 		args = append(args, onExist == OnExistReplace, oldViewTblID)
 	case tbInfo.Sequence != nil:
 		actionType = model.ActionCreateSequence
+	case tbInfo.MaterializedView != nil:
+		actionType = model.ActionCreateMaterializedView
+		args = append(args, ctx.GetSessionVars().ForeignKeyChecks)
 	default:
 		actionType = model.ActionCreateTable
 		args = append(args, ctx.GetSessionVars().ForeignKeyChecks)
@@ -3969,7 +3984,8 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 			case ast.ConstraintPrimaryKey:
 				err = d.CreatePrimaryKey(sctx, ident, model.NewCIStr(constr.Name), spec.Constraint.Keys, constr.Option)
 			case ast.ConstraintFulltext:
-				sctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrTableCantHandleFt)
+				err = d.createIndex(sctx, ident, ast.IndexKeyTypeFullText, model.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, constr.IfNotExists)
 			case ast.ConstraintCheck:
 				if !variable.EnableCheckConstraint.Load() {
 					sctx.GetSessionVars().StmtCtx.AppendWarning(errCheckConstraintIsOff)
@@ -5608,6 +5624,23 @@ func setColumnComment(ctx sessionctx.Context, col *table.Column, option *ast.Col
 	return errors.Trace(err)
 }
 
+// setColumnSRID sets the SRID of a geometry column, the SRID must be a supported spatial reference system.
+func setColumnSRID(col *table.Column, option *ast.ColumnOption) error {
+	if col.GetType() != mysql.TypeGeometry {
+		return dbterror.ErrWrongUsage.GenWithStackByArgs("SRID", "non-geometry column")
+	}
+	srid, ok := option.Expr.(ast.ValueExpr).GetValue().(uint64)
+	if !ok || srid > math.MaxUint32 {
+		return dbterror.ErrSRSNotFound.GenWithStackByArgs(option.Expr.(ast.ValueExpr).GetValue())
+	}
+	if _, ok = types.GetSpatialReferenceSystem(uint32(srid)); !ok {
+		return dbterror.ErrSRSNotFound.GenWithStackByArgs(srid)
+	}
+	v := uint32(srid)
+	col.SRID = &v
+	return nil
+}
+
 // ProcessModifyColumnOptions process column options.
 func ProcessModifyColumnOptions(ctx sessionctx.Context, col *table.Column, options []*ast.ColumnOption) error {
 	var sb strings.Builder
@@ -5673,6 +5706,10 @@ func ProcessModifyColumnOptions(ctx sessionctx.Context, col *table.Column, optio
 			return errors.Trace(dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs("can't modify with full text"))
 		case ast.ColumnOptionCheck:
 			return errors.Trace(dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs("can't modify with check"))
+		case ast.ColumnOptionSRID:
+			if err = setColumnSRID(col, opt); err != nil {
+				return errors.Trace(err)
+			}
 		// Ignore ColumnOptionAutoRandom. It will be handled later.
 		case ast.ColumnOptionAutoRandom:
 		default:
@@ -6755,6 +6792,9 @@ func (d *ddl) AlterTableAddStatistics(ctx sessionctx.Context, ident ast.Ident, s
 	if len(colIDs) < 1 && stats.StatsType == ast.StatsTypeCardinality {
 		return errors.New("Only support Cardinality statistics type on at least 2 columns")
 	}
+	if statistics.IsMultiColumnStatsType(stats.StatsType) && (len(colIDs) < 2 || len(colIDs) > statistics.MaxMultiColumnStatsColumns) {
+		return errors.Errorf("Only support multi-column statistics on 2 to %d columns", statistics.MaxMultiColumnStatsColumns)
+	}
 	// TODO: check whether covering index exists for cardinality / dependency types.
 
 	// Call utilities of statistics.Handle to modify system tables instead of doing DML directly,
@@ -6946,9 +6986,10 @@ const (
 	tableObject objectType = iota
 	viewObject
 	sequenceObject
+	materializedViewObject
 )
 
-// dropTableObject provides common logic to DROP TABLE/VIEW/SEQUENCE.
+// dropTableObject provides common logic to DROP TABLE/VIEW/SEQUENCE/MATERIALIZED VIEW.
 func (d *ddl) dropTableObject(
 	ctx sessionctx.Context,
 	objects []*ast.TableName,
@@ -6965,7 +7006,7 @@ func (d *ddl) dropTableObject(
 
 	var jobArgs []any
 	switch tableObjectType {
-	case tableObject:
+	case tableObject, materializedViewObject:
 		dropExistErr = infoschema.ErrTableDropExists
 		jobType = model.ActionDropTable
 		objectIdents := make([]ast.Ident, len(objects))
@@ -7012,7 +7053,7 @@ func (d *ddl) dropTableObject(
 		}
 		switch tableObjectType {
 		case tableObject:
-			if !tableInfo.Meta().IsBaseTable() {
+			if !tableInfo.Meta().IsBaseTable() || tableInfo.Meta().IsMaterializedView() {
 				notExistTables = append(notExistTables, fullti.String())
 				continue
 			}
@@ -7038,6 +7079,10 @@ func (d *ddl) dropTableObject(
 			if !tableInfo.Meta().IsView() {
 				return dbterror.ErrWrongObject.GenWithStackByArgs(fullti.Schema, fullti.Name, "VIEW")
 			}
+		case materializedViewObject:
+			if !tableInfo.Meta().IsMaterializedView() {
+				return dbterror.ErrWrongObject.GenWithStackByArgs(fullti.Schema, fullti.Name, "MATERIALIZED VIEW")
+			}
 		case sequenceObject:
 			if !tableInfo.Meta().IsSequence() {
 				err = dbterror.ErrWrongObject.GenWithStackByArgs(fullti.Schema, fullti.Name, "SEQUENCE")
@@ -7104,12 +7149,17 @@ func (d *ddl) DropView(ctx sessionctx.Context, stmt *ast.DropTableStmt) (err err
 	return d.dropTableObject(ctx, stmt.Tables, stmt.IfExists, viewObject)
 }
 
+// DropMaterializedView will proceed even if some materialized view in the list does not exists.
+func (d *ddl) DropMaterializedView(ctx sessionctx.Context, stmt *ast.DropTableStmt) (err error) {
+	return d.dropTableObject(ctx, stmt.Tables, stmt.IfExists, materializedViewObject)
+}
+
 func (d *ddl) TruncateTable(ctx sessionctx.Context, ti ast.Ident) error {
 	schema, tb, err := d.getSchemaAndTableByIdent(ctx, ti)
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if tb.Meta().IsView() || tb.Meta().IsSequence() {
+	if tb.Meta().IsView() || tb.Meta().IsSequence() || tb.Meta().IsMaterializedView() {
 		return infoschema.ErrTableNotExists.GenWithStackByArgs(schema.Name.O, tb.Meta().Name.O)
 	}
 	if tb.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
@@ -7624,11 +7674,27 @@ func (*ddl) addHypoIndexIntoCtx(ctx sessionctx.Context, schemaName, tableName mo
 	return nil
 }
 
+// buildFulltextIndexOption returns a copy of the index option whose index type is FULLTEXT.
+func buildFulltextIndexOption(indexOption *ast.IndexOption) *ast.IndexOption {
+	option := &ast.IndexOption{}
+	if indexOption != nil {
+		*option = *indexOption
+	}
+	option.Tp = model.IndexTypeFulltext
+	return option
+}
+
 func (d *ddl) createIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.IndexKeyType, indexName model.CIStr,
 	indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption, ifNotExists bool) error {
-	// not support Spatial and FullText index
-	if keyType == ast.IndexKeyTypeFullText || keyType == ast.IndexKeyTypeSpatial {
-		return dbterror.ErrUnsupportedIndexType.GenWithStack("FULLTEXT and SPATIAL index is not supported")
+	// not support Spatial index
+	if keyType == ast.IndexKeyTypeSpatial {
+		return dbterror.ErrUnsupportedIndexType.GenWithStack("SPATIAL index is not supported")
+	}
+	if keyType == ast.IndexKeyTypeFullText {
+		if err := checkFulltextIndexParts(indexPartSpecifications); err != nil {
+			return err
+		}
+		indexOption = buildFulltextIndexOption(indexOption)
 	}
 	unique := keyType == ast.IndexKeyTypeUnique
 	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
@@ -7688,7 +7754,12 @@ func (d *ddl) createIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 	// After DDL job is put to the queue, and if the check fail, TiDB will run the DDL cancel logic.
 	// The recover step causes DDL wait a few seconds, makes the unit test painfully slow.
 	// For same reason, decide whether index is global here.
-	indexColumns, _, err := buildIndexColumns(ctx, finalColumns, indexPartSpecifications)
+	var indexColumns []*model.IndexColumn
+	if keyType == ast.IndexKeyTypeFullText {
+		indexColumns, err = buildFulltextIndexColumns(finalColumns, indexPartSpecifications, indexOption)
+	} else {
+		indexColumns, _, err = buildIndexColumns(ctx, finalColumns, indexPartSpecifications)
+	}
 	if err != nil {
 		return errors.Trace(err)
 	}
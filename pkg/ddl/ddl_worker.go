@@ -1253,7 +1253,7 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 		ver, err = w.onRecoverSchema(d, t, job)
 	case model.ActionModifySchemaDefaultPlacement:
 		ver, err = onModifySchemaDefaultPlacement(d, t, job)
-	case model.ActionCreateTable:
+	case model.ActionCreateTable, model.ActionCreateMaterializedView:
 		ver, err = onCreateTable(d, t, job)
 	case model.ActionCreateTables:
 		ver, err = onCreateTables(d, t, job)
@@ -1301,6 +1301,10 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 		ver, err = w.onShardRowID(d, t, job)
 	case model.ActionModifyTableComment:
 		ver, err = onModifyTableComment(d, t, job)
+	case model.ActionCreateTrigger:
+		ver, err = onCreateTrigger(d, t, job)
+	case model.ActionDropTrigger:
+		ver, err = onDropTrigger(d, t, job)
 	case model.ActionModifyTableAutoIdCache:
 		ver, err = onModifyTableAutoIDCache(d, t, job)
 	case model.ActionAddTablePartition:
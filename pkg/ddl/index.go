@@ -63,6 +63,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/codec"
 	contextutil "github.com/pingcap/tidb/pkg/util/context"
 	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/fulltext"
 	tidblogutil "github.com/pingcap/tidb/pkg/util/logutil"
 	decoder "github.com/pingcap/tidb/pkg/util/rowDecoder"
 	"github.com/pingcap/tidb/pkg/util/size"
@@ -157,6 +158,46 @@ func buildIndexColumns(ctx sessionctx.Context, columns []*model.ColumnInfo, inde
 	return idxParts, mvIndex, nil
 }
 
+// checkFulltextIndexParts checks that no expression is used by a FULLTEXT index. It must be checked before
+// the hidden columns of the expressions are built.
+func checkFulltextIndexParts(indexPartSpecifications []*ast.IndexPartSpecification) error {
+	for _, ip := range indexPartSpecifications {
+		if ip.Expr != nil {
+			return dbterror.ErrFulltextFunctionalIndex
+		}
+	}
+	return nil
+}
+
+// buildFulltextIndexColumns builds the columns of a FULLTEXT index. The index stores the tokens of
+// the texts instead of the column values, so the columns must be non-binary strings and have no
+// prefix length.
+func buildFulltextIndexColumns(columns []*model.ColumnInfo, indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption) ([]*model.IndexColumn, error) {
+	if indexOption != nil && !fulltext.IsSupportedParser(indexOption.ParserName.L) {
+		return nil, dbterror.ErrFunctionNotDefined.GenWithStackByArgs(indexOption.ParserName.O)
+	}
+	if err := checkFulltextIndexParts(indexPartSpecifications); err != nil {
+		return nil, err
+	}
+	idxParts := make([]*model.IndexColumn, 0, len(indexPartSpecifications))
+	for _, ip := range indexPartSpecifications {
+		col := model.FindColumnInfo(columns, ip.Column.Name.L)
+		if col == nil {
+			return nil, dbterror.ErrKeyColumnDoesNotExits.GenWithStack("column does not exist: %s", ip.Column.Name)
+		}
+		tp := col.FieldType.GetType()
+		if !(types.IsTypeChar(tp) || types.IsTypeVarchar(tp) || types.IsTypeBlob(tp)) || col.FieldType.GetCharset() == charset.CharsetBin {
+			return nil, dbterror.ErrBadFtColumn.GenWithStackByArgs(col.Name.O)
+		}
+		idxParts = append(idxParts, &model.IndexColumn{
+			Name:   col.Name,
+			Offset: col.Offset,
+			Length: types.UnspecifiedLength,
+		})
+	}
+	return idxParts, nil
+}
+
 // CheckPKOnGeneratedColumn checks the specification of PK is valid.
 func CheckPKOnGeneratedColumn(tblInfo *model.TableInfo, indexPartSpecifications []*ast.IndexPartSpecification) (*model.ColumnInfo, error) {
 	var lastCol *model.ColumnInfo
@@ -204,6 +245,19 @@ func checkIndexColumn(ctx sessionctx.Context, col *model.ColumnInfo, indexColumn
 		return errors.Trace(dbterror.ErrJSONUsedAsKey.GenWithStackByArgs(col.Name.O))
 	}
 
+	// VECTOR column cannot index, use an ANN search instead.
+	if col.FieldType.GetType() == mysql.TypeTiDBVectorFloat32 {
+		return errors.Trace(dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("index on VECTOR column"))
+	}
+
+	// GEOMETRY column cannot index, SPATIAL index is not supported yet.
+	if col.FieldType.GetType() == mysql.TypeGeometry {
+		if col.Hidden {
+			return dbterror.ErrFunctionalIndexOnJSONOrGeometryFunction
+		}
+		return errors.Trace(dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("index on GEOMETRY column"))
+	}
+
 	// Length must be specified and non-zero for BLOB and TEXT column indexes.
 	if types.IsTypeBlob(col.FieldType.GetType()) {
 		if indexColumnLen == types.UnspecifiedLength {
@@ -308,7 +362,16 @@ func BuildIndexInfo(
 		return nil, errors.Trace(err)
 	}
 
-	idxColumns, mvIndex, err := buildIndexColumns(ctx, allTableColumns, indexPartSpecifications)
+	var (
+		idxColumns []*model.IndexColumn
+		mvIndex    bool
+		err        error
+	)
+	if indexOption != nil && indexOption.Tp == model.IndexTypeFulltext {
+		idxColumns, err = buildFulltextIndexColumns(allTableColumns, indexPartSpecifications, indexOption)
+	} else {
+		idxColumns, mvIndex, err = buildIndexColumns(ctx, allTableColumns, indexPartSpecifications)
+	}
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -335,6 +398,9 @@ func BuildIndexInfo(
 		} else {
 			idxInfo.Tp = indexOption.Tp
 		}
+		if idxInfo.Tp == model.IndexTypeFulltext {
+			idxInfo.ParserName = indexOption.ParserName
+		}
 	} else {
 		// Use btree as default index type.
 		idxInfo.Tp = model.IndexTypeBtree
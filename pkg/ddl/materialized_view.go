@@ -0,0 +1,154 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+)
+
+// CreateMaterializedView creates the table storing the rows of a materialized view. The
+// columns of the table are the ones of the view query, which are filled into the statement
+// by the planner. The table is empty until the view is refreshed.
+func (d *ddl) CreateMaterializedView(ctx sessionctx.Context, s *ast.CreateMaterializedViewStmt) error {
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, ok := is.SchemaByName(s.ViewName.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(s.ViewName.Schema)
+	}
+
+	tbInfo, err := BuildTableInfoWithMaterializedView(ctx, s, schema.Charset, schema.Collate, schema.PlacementPolicyRef)
+	if err != nil {
+		return err
+	}
+
+	onExist := OnExistError
+	if s.IfNotExists {
+		onExist = OnExistIgnore
+	}
+	return d.CreateTableWithInfo(ctx, schema.Name, tbInfo, onExist)
+}
+
+// BuildTableInfoWithMaterializedView builds the table storing the rows of a materialized view
+// from an ast.CreateMaterializedViewStmt, whose view columns have been filled by the planner.
+func BuildTableInfoWithMaterializedView(
+	ctx sessionctx.Context,
+	s *ast.CreateMaterializedViewStmt,
+	dbCharset, dbCollate string,
+	placementPolicyRef *model.PolicyRefInfo,
+) (*model.TableInfo, error) {
+	mvInfo, err := BuildMaterializedViewInfo(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	cols := make([]*ast.ColumnDef, 0, len(s.SchemaCols))
+	for i, name := range s.SchemaCols {
+		cols = append(cols, &ast.ColumnDef{
+			Name: &ast.ColumnName{Name: name},
+			Tp:   s.SchemaColTypes[i],
+		})
+	}
+	createStmt := &ast.CreateTableStmt{
+		IfNotExists: s.IfNotExists,
+		Table:       s.ViewName,
+		Cols:        cols,
+	}
+	tbInfo, err := BuildTableInfoWithStmt(ctx, createStmt, dbCharset, dbCollate, placementPolicyRef)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tbInfo.MaterializedView = mvInfo
+	return tbInfo, nil
+}
+
+// BuildMaterializedViewInfo builds a MaterializedViewInfo structure from an ast.CreateMaterializedViewStmt.
+func BuildMaterializedViewInfo(_ sessionctx.Context, s *ast.CreateMaterializedViewStmt) (*model.MaterializedViewInfo, error) {
+	if s.RefreshMethod != model.RefreshComplete {
+		return nil, dbterror.ErrNotSupportedYet.GenWithStackByArgs("REFRESH " + s.RefreshMethod.String())
+	}
+	interval, err := buildMaterializedViewRefreshInterval(s)
+	if err != nil {
+		return nil, err
+	}
+
+	// Always Use `format.RestoreNameBackQuotes` to restore `SELECT` statement despite the `ANSI_QUOTES` SQL Mode is enabled or not.
+	restoreFlag := format.RestoreStringSingleQuotes | format.RestoreKeyWordUppercase | format.RestoreNameBackQuotes
+	var sb strings.Builder
+	if err := s.Select.Restore(format.NewRestoreCtx(restoreFlag, &sb)); err != nil {
+		return nil, err
+	}
+	query := sb.String()
+	_, digest := parser.NormalizeDigest(query)
+	return &model.MaterializedViewInfo{
+		Query:           query,
+		Digest:          digest.String(),
+		RefreshMethod:   s.RefreshMethod,
+		RefreshInterval: interval,
+	}, nil
+}
+
+// buildMaterializedViewRefreshInterval converts `EVERY interval unit` to a duration
+// string accepted by `duration.ParseDuration`, which is also the format of the interval
+// of the timers refreshing the views.
+func buildMaterializedViewRefreshInterval(s *ast.CreateMaterializedViewStmt) (string, error) {
+	if s.RefreshInterval == nil {
+		return "", nil
+	}
+
+	var suffix string
+	var unit time.Duration
+	var multiplier int64 = 1
+	switch s.RefreshIntervalUnit.Unit {
+	case ast.TimeUnitMinute:
+		suffix, unit = "m", time.Minute
+	case ast.TimeUnitHour:
+		suffix, unit = "h", time.Hour
+	case ast.TimeUnitDay:
+		suffix, unit = "d", 24*time.Hour
+	case ast.TimeUnitWeek:
+		suffix, unit, multiplier = "d", 24*time.Hour, 7
+	default:
+		return "", dbterror.ErrNotSupportedYet.GenWithStackByArgs(
+			fmt.Sprintf("REFRESH EVERY with unit %s", s.RefreshIntervalUnit.Unit.String()))
+	}
+
+	v, ok := s.RefreshInterval.(ast.ValueExpr)
+	if !ok {
+		return "", dbterror.ErrEventIntervalNotPositiveOrTooBig.GenWithStackByArgs()
+	}
+	str, err := types.ToString(v.GetValue())
+	if err != nil {
+		return "", dbterror.ErrEventIntervalNotPositiveOrTooBig.GenWithStackByArgs()
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(str), 10, 64)
+	if err != nil || n <= 0 || n > math.MaxInt64/int64(unit)/multiplier {
+		return "", dbterror.ErrEventIntervalNotPositiveOrTooBig.GenWithStackByArgs()
+	}
+	return strconv.FormatInt(n*multiplier, 10) + suffix, nil
+}
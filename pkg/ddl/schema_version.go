@@ -343,7 +343,7 @@ func updateSchemaVersion(d *ddlCtx, t *meta.Meta, job *model.Job, multiInfos ...
 		SetSchemaDiffForReorganizePartition(diff, job)
 	case model.ActionRemovePartitioning, model.ActionAlterTablePartitioning:
 		err = SetSchemaDiffForPartitionModify(diff, job)
-	case model.ActionCreateTable:
+	case model.ActionCreateTable, model.ActionCreateMaterializedView:
 		SetSchemaDiffForCreateTable(diff, job)
 	case model.ActionRecoverSchema:
 		err = SetSchemaDiffForRecoverSchema(diff, job)
@@ -255,6 +255,21 @@ func (d *Checker) CreateView(ctx sessionctx.Context, stmt *ast.CreateViewStmt) e
 	return nil
 }
 
+// CreateMaterializedView implements the DDL interface.
+func (d *Checker) CreateMaterializedView(ctx sessionctx.Context, stmt *ast.CreateMaterializedViewStmt) error {
+	err := d.realDDL.CreateMaterializedView(ctx, stmt)
+	if err != nil {
+		return err
+	}
+	err = d.tracker.CreateMaterializedView(ctx, stmt)
+	if err != nil {
+		panic(err)
+	}
+
+	d.checkTableInfo(ctx, stmt.ViewName.Schema, stmt.ViewName.Name)
+	return nil
+}
+
 // DropTable implements the DDL interface.
 func (d *Checker) DropTable(ctx sessionctx.Context, stmt *ast.DropTableStmt) (err error) {
 	err = d.realDDL.DropTable(ctx, stmt)
@@ -295,6 +310,23 @@ func (d *Checker) DropView(ctx sessionctx.Context, stmt *ast.DropTableStmt) (err
 	return nil
 }
 
+// DropMaterializedView implements the DDL interface.
+func (d *Checker) DropMaterializedView(ctx sessionctx.Context, stmt *ast.DropTableStmt) (err error) {
+	err = d.realDDL.DropMaterializedView(ctx, stmt)
+	if err != nil {
+		return err
+	}
+	err = d.tracker.DropMaterializedView(ctx, stmt)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, tableName := range stmt.Tables {
+		d.checkTableInfo(ctx, tableName.Schema, tableName.Name)
+	}
+	return nil
+}
+
 // CreateIndex implements the DDL interface.
 func (d *Checker) CreateIndex(ctx sessionctx.Context, stmt *ast.CreateIndexStmt) error {
 	err := d.realDDL.CreateIndex(ctx, stmt)
@@ -413,6 +445,18 @@ func (*Checker) AlterSequence(_ sessionctx.Context, _ *ast.AlterSequenceStmt) er
 	panic("implement me")
 }
 
+// CreateTrigger implements the DDL interface.
+func (*Checker) CreateTrigger(_ sessionctx.Context, _ *ast.CreateTriggerStmt) error {
+	//TODO implement me
+	panic("implement me")
+}
+
+// DropTrigger implements the DDL interface.
+func (*Checker) DropTrigger(_ sessionctx.Context, _ *ast.DropTriggerStmt) error {
+	//TODO implement me
+	panic("implement me")
+}
+
 // CreatePlacementPolicy implements the DDL interface.
 func (*Checker) CreatePlacementPolicy(_ sessionctx.Context, _ *ast.CreatePlacementPolicyStmt) error {
 	//TODO implement me
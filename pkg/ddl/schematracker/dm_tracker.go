@@ -293,12 +293,32 @@ func (d SchemaTracker) CreateView(ctx sessionctx.Context, s *ast.CreateViewStmt)
 	return d.CreateTableWithInfo(ctx, s.ViewName.Schema, tbInfo, onExist)
 }
 
+// CreateMaterializedView implements the DDL interface.
+func (d SchemaTracker) CreateMaterializedView(ctx sessionctx.Context, s *ast.CreateMaterializedViewStmt) error {
+	schema := d.SchemaByName(s.ViewName.Schema)
+	if schema == nil {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(s.ViewName.Schema)
+	}
+
+	tbInfo, err := ddl.BuildTableInfoWithMaterializedView(ctx, s, schema.Charset, schema.Collate, nil)
+	if err != nil {
+		return err
+	}
+
+	onExist := ddl.OnExistError
+	if s.IfNotExists {
+		onExist = ddl.OnExistIgnore
+	}
+
+	return d.CreateTableWithInfo(ctx, schema.Name, tbInfo, onExist)
+}
+
 // DropTable implements the DDL interface.
 func (d SchemaTracker) DropTable(_ sessionctx.Context, stmt *ast.DropTableStmt) (err error) {
 	notExistTables := make([]string, 0, len(stmt.Tables))
 	for _, name := range stmt.Tables {
 		tb, err := d.TableByName(name.Schema, name.Name)
-		if err != nil || !tb.IsBaseTable() {
+		if err != nil || !tb.IsBaseTable() || tb.IsMaterializedView() {
 			if stmt.IfExists {
 				continue
 			}
@@ -365,6 +385,35 @@ func (d SchemaTracker) DropView(_ sessionctx.Context, stmt *ast.DropTableStmt) (
 	return nil
 }
 
+// DropMaterializedView implements the DDL interface.
+func (d SchemaTracker) DropMaterializedView(_ sessionctx.Context, stmt *ast.DropTableStmt) (err error) {
+	notExistTables := make([]string, 0, len(stmt.Tables))
+	for _, name := range stmt.Tables {
+		tb, err := d.TableByName(name.Schema, name.Name)
+		if err != nil {
+			if stmt.IfExists {
+				continue
+			}
+
+			id := ast.Ident{Schema: name.Schema, Name: name.Name}
+			notExistTables = append(notExistTables, id.String())
+			continue
+		}
+
+		// the behaviour is fast fail when type is wrong.
+		if !tb.IsMaterializedView() {
+			return dbterror.ErrWrongObject.GenWithStackByArgs(name.Schema, name.Name, "MATERIALIZED VIEW")
+		}
+
+		_ = d.DeleteTable(name.Schema, name.Name)
+	}
+
+	if len(notExistTables) > 0 {
+		return infoschema.ErrTableDropExists.GenWithStackByArgs(strings.Join(notExistTables, ","))
+	}
+	return nil
+}
+
 // CreateIndex implements the DDL interface.
 func (d SchemaTracker) CreateIndex(ctx sessionctx.Context, stmt *ast.CreateIndexStmt) error {
 	ident := ast.Ident{Schema: stmt.Table.Schema, Name: stmt.Table.Name}
@@ -1156,6 +1205,16 @@ func (SchemaTracker) AlterSequence(_ sessionctx.Context, _ *ast.AlterSequenceStm
 	return nil
 }
 
+// CreateTrigger implements the DDL interface, it's no-op in DM's case.
+func (SchemaTracker) CreateTrigger(_ sessionctx.Context, _ *ast.CreateTriggerStmt) error {
+	return nil
+}
+
+// DropTrigger implements the DDL interface, it's no-op in DM's case.
+func (SchemaTracker) DropTrigger(_ sessionctx.Context, _ *ast.DropTriggerStmt) error {
+	return nil
+}
+
 // CreatePlacementPolicy implements the DDL interface, it's no-op in DM's case.
 func (SchemaTracker) CreatePlacementPolicy(_ sessionctx.Context, _ *ast.CreatePlacementPolicyStmt) error {
 	return nil
@@ -0,0 +1,309 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/meta"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+)
+
+// CreateTrigger creates a row-level trigger. The trigger is stored in the table info of
+// the table it's defined on, so it's dropped, truncated and renamed together with the table.
+func (d *ddl) CreateTrigger(ctx sessionctx.Context, s *ast.CreateTriggerStmt) error {
+	if s.TriggerName.Schema.L != s.Table.Schema.L {
+		return dbterror.ErrTrgInWrongSchema.GenWithStackByArgs()
+	}
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, ok := is.SchemaByName(s.Table.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(s.Table.Schema)
+	}
+	tb, err := is.TableByName(s.Table.Schema, s.Table.Name)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.GenWithStackByArgs(s.Table.Schema, s.Table.Name))
+	}
+	tblInfo := tb.Meta()
+	if tblInfo.IsView() || tblInfo.IsSequence() || tblInfo.IsMaterializedView() || tblInfo.TempTableType != model.TempTableNone {
+		return dbterror.ErrTrgOnViewOrTempTable.GenWithStackByArgs(tblInfo.Name.O)
+	}
+	if findTriggerTable(is, schema.Name, s.TriggerName.Name) != nil {
+		err = dbterror.ErrTrgAlreadyExists.GenWithStackByArgs()
+		if s.IfNotExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	checker := &triggerChecker{tblInfo: tblInfo, timing: s.Timing, event: s.Event}
+	if err = checker.checkStmt(s.Body); err != nil {
+		return err
+	}
+
+	sessVars := ctx.GetSessionVars()
+	var definer *auth.UserIdentity
+	if sessVars.User != nil {
+		definer = &auth.UserIdentity{Username: sessVars.User.AuthUsername, Hostname: sessVars.User.AuthHostname}
+	}
+	charsetClient, _ := sessVars.GetSystemVar(variable.CharacterSetClient)
+	_, collationConnection := sessVars.GetCharsetInfo()
+	trigger := &model.TriggerInfo{
+		Name:                s.TriggerName.Name,
+		Timing:              s.Timing,
+		Event:               s.Event,
+		Statement:           s.Body.Text(),
+		Definer:             definer,
+		SQLMode:             sessVars.SQLMode,
+		CharsetClient:       charsetClient,
+		CollationConnection: collationConnection,
+		Created:             time.Now(),
+	}
+
+	job := &model.Job{
+		SchemaID:       schema.ID,
+		TableID:        tblInfo.ID,
+		SchemaName:     schema.Name.L,
+		TableName:      tblInfo.Name.L,
+		Type:           model.ActionCreateTrigger,
+		BinlogInfo:     &model.HistoryInfo{},
+		Args:           []any{trigger},
+		CDCWriteSource: sessVars.CDCWriteSource,
+		SQLMode:        sessVars.SQLMode,
+	}
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
+// DropTrigger drops a trigger, the table of the trigger is looked up in the schema.
+func (d *ddl) DropTrigger(ctx sessionctx.Context, s *ast.DropTriggerStmt) error {
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	var tblInfo *model.TableInfo
+	schema, ok := is.SchemaByName(s.TriggerName.Schema)
+	if ok {
+		tblInfo = findTriggerTable(is, schema.Name, s.TriggerName.Name)
+	}
+	if tblInfo == nil {
+		err := dbterror.ErrTrgDoesNotExist.GenWithStackByArgs()
+		if s.IfExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	job := &model.Job{
+		SchemaID:       schema.ID,
+		TableID:        tblInfo.ID,
+		SchemaName:     schema.Name.L,
+		TableName:      tblInfo.Name.L,
+		Type:           model.ActionDropTrigger,
+		BinlogInfo:     &model.HistoryInfo{},
+		Args:           []any{s.TriggerName.Name},
+		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
+		SQLMode:        ctx.GetSessionVars().SQLMode,
+	}
+	err := d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
+// findTriggerTable returns the table of the trigger, the names of triggers are unique in a schema.
+func findTriggerTable(is infoschema.InfoSchema, schema, name model.CIStr) *model.TableInfo {
+	for _, tblInfo := range is.SchemaTableInfos(schema) {
+		if tblInfo.FindTrigger(name.L) != nil {
+			return tblInfo
+		}
+	}
+	return nil
+}
+
+func onCreateTrigger(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	trigger := &model.TriggerInfo{}
+	if err := job.DecodeArgs(trigger); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if tblInfo.FindTrigger(trigger.Name.L) != nil {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrTrgAlreadyExists.GenWithStackByArgs()
+	}
+
+	tblInfo.Triggers = append(tblInfo.Triggers, trigger)
+	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+func onDropTrigger(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	var name model.CIStr
+	if err := job.DecodeArgs(&name); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	triggers := make([]*model.TriggerInfo, 0, len(tblInfo.Triggers))
+	for _, trigger := range tblInfo.Triggers {
+		if trigger.Name.L != name.L {
+			triggers = append(triggers, trigger)
+		}
+	}
+	if len(triggers) == len(tblInfo.Triggers) {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrTrgDoesNotExist.GenWithStackByArgs()
+	}
+
+	tblInfo.Triggers = triggers
+	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+// triggerChecker checks the body of a trigger when it's created. A body consists of
+// BEGIN ... END blocks without local variables, IF statements, SET statements assigning
+// NEW columns or user variables, and data-modifying statements in AFTER triggers.
+type triggerChecker struct {
+	tblInfo *model.TableInfo
+	timing  model.TriggerTiming
+	event   model.TriggerEvent
+	err     error
+}
+
+func (c *triggerChecker) checkStmts(stmts []ast.StmtNode) error {
+	for _, stmt := range stmts {
+		if err := c.checkStmt(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *triggerChecker) checkStmt(stmt ast.StmtNode) error {
+	switch x := stmt.(type) {
+	case *ast.ProcedureBlock:
+		if len(x.ProcedureVars) > 0 {
+			return dbterror.ErrNotSupportedYet.GenWithStackByArgs("DECLARE in triggers")
+		}
+		return c.checkStmts(x.ProcedureProcStmts)
+	case *ast.ProcedureIfInfo:
+		return c.checkIfBlock(x.IfBody)
+	case *ast.SetStmt:
+		for _, v := range x.Variables {
+			if v.IsSystem {
+				row, col, ok := strings.Cut(strings.ToLower(v.Name), ".")
+				if !ok || (row != "new" && row != "old") {
+					return dbterror.ErrNotSupportedYet.GenWithStackByArgs("SET system variables in triggers")
+				}
+				if row == "old" {
+					return dbterror.ErrTrgCantChangeRow.GenWithStackByArgs("OLD", "")
+				}
+				if c.timing == model.TriggerAfter {
+					return dbterror.ErrTrgCantChangeRow.GenWithStackByArgs("NEW", "after ")
+				}
+				if err := c.checkRowColumn(row, col); err != nil {
+					return err
+				}
+			}
+			if v.Value != nil {
+				if err := c.checkNode(v.Value); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	case *ast.InsertStmt, *ast.UpdateStmt, *ast.DeleteStmt:
+		if c.timing == model.TriggerBefore {
+			return dbterror.ErrNotSupportedYet.GenWithStackByArgs("data-modifying statements in BEFORE triggers")
+		}
+		return c.checkNode(x)
+	default:
+		return dbterror.ErrNotSupportedYet.GenWithStackByArgs(fmt.Sprintf("%s statements in triggers", ast.GetStmtLabel(stmt)))
+	}
+}
+
+func (c *triggerChecker) checkIfBlock(block *ast.ProcedureIfBlock) error {
+	if err := c.checkNode(block.IfExpr); err != nil {
+		return err
+	}
+	if err := c.checkStmts(block.ProcedureIfStmts); err != nil {
+		return err
+	}
+	switch x := block.ProcedureElseStmt.(type) {
+	case *ast.ProcedureElseIfBlock:
+		return c.checkIfBlock(x.ProcedureIfStmt)
+	case *ast.ProcedureElseBlock:
+		return c.checkStmts(x.ProcedureIfStmts)
+	}
+	return nil
+}
+
+// checkNode checks the NEW and OLD columns referred by the node.
+func (c *triggerChecker) checkNode(node ast.Node) error {
+	c.err = nil
+	node.Accept(c)
+	return c.err
+}
+
+func (c *triggerChecker) checkRowColumn(row, col string) error {
+	if row == "new" && c.event == model.TriggerDelete {
+		return dbterror.ErrTrgNoSuchRowInTrg.GenWithStackByArgs("NEW", "on DELETE")
+	}
+	if row == "old" && c.event == model.TriggerInsert {
+		return dbterror.ErrTrgNoSuchRowInTrg.GenWithStackByArgs("OLD", "on INSERT")
+	}
+	if model.FindColumnInfo(c.tblInfo.Columns, col) == nil {
+		return dbterror.ErrBadField.GenWithStackByArgs(col, strings.ToUpper(row))
+	}
+	return nil
+}
+
+// Enter implements ast.Visitor interface.
+func (c *triggerChecker) Enter(n ast.Node) (ast.Node, bool) {
+	return n, c.err != nil
+}
+
+// Leave implements ast.Visitor interface.
+func (c *triggerChecker) Leave(n ast.Node) (ast.Node, bool) {
+	if col, ok := n.(*ast.ColumnNameExpr); ok && c.err == nil && col.Name.Schema.L == "" &&
+		(col.Name.Table.L == "new" || col.Name.Table.L == "old") {
+		c.err = c.checkRowColumn(col.Name.Table.L, col.Name.Name.L)
+	}
+	return n, c.err == nil
+}
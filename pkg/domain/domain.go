@@ -35,6 +35,7 @@ import (
 	"github.com/pingcap/tidb/br/pkg/streamhelper"
 	"github.com/pingcap/tidb/br/pkg/streamhelper/daemon"
 	"github.com/pingcap/tidb/pkg/bindinfo"
+	"github.com/pingcap/tidb/pkg/changefeed"
 	"github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/ddl"
 	"github.com/pingcap/tidb/pkg/ddl/placement"
@@ -47,6 +48,7 @@ import (
 	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/domain/resourcegroup"
 	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/eventscheduler"
 	"github.com/pingcap/tidb/pkg/infoschema"
 	infoschema_metrics "github.com/pingcap/tidb/pkg/infoschema/metrics"
 	"github.com/pingcap/tidb/pkg/infoschema/perfschema"
@@ -55,6 +57,7 @@ import (
 	"github.com/pingcap/tidb/pkg/meta"
 	"github.com/pingcap/tidb/pkg/meta/autoid"
 	"github.com/pingcap/tidb/pkg/metrics"
+	"github.com/pingcap/tidb/pkg/mview"
 	"github.com/pingcap/tidb/pkg/owner"
 	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/parser/ast"
@@ -69,6 +72,7 @@ import (
 	"github.com/pingcap/tidb/pkg/statistics/handle"
 	statslogutil "github.com/pingcap/tidb/pkg/statistics/handle/logutil"
 	"github.com/pingcap/tidb/pkg/store/helper"
+	"github.com/pingcap/tidb/pkg/tidb-binlog/binlogstore"
 	"github.com/pingcap/tidb/pkg/ttl/ttlworker"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util"
@@ -2998,6 +3002,43 @@ func (do *Domain) StartTTLJobManager() {
 	ttlJobManager.Start()
 }
 
+// StartMaterializedViewRefresher starts the worker refreshing the materialized views on schedule.
+func (do *Domain) StartMaterializedViewRefresher() {
+	refresher := mview.NewRefresher(do.sysSessionPool, do.etcdClient, do.ddl.OwnerManager().IsOwner, do.InfoSchema)
+	do.wg.Run(func() {
+		defer util.Recover(metrics.LabelDomain, "materializedViewRefresher", nil, false)
+		refresher.Run(do.exit)
+	}, "materializedViewRefresher")
+}
+
+// StartEventScheduler starts the worker executing the events on schedule, the runner executes the body of an event.
+func (do *Domain) StartEventScheduler(runner eventscheduler.Runner) {
+	scheduler := eventscheduler.NewScheduler(do.sysSessionPool, do.etcdClient, do.ddl.OwnerManager().IsOwner, runner)
+	do.wg.Run(func() {
+		defer util.Recover(metrics.LabelDomain, "eventScheduler", nil, false)
+		scheduler.Run(do.exit)
+	}, "eventScheduler")
+}
+
+// StartChangefeedManager starts the worker running the changefeeds of this TiDB instance.
+func (do *Domain) StartChangefeedManager() {
+	manager := changefeed.NewManager(do.sysSessionPool, do.GetSnapshotInfoSchema)
+	do.wg.Run(func() {
+		defer util.Recover(metrics.LabelDomain, "changefeedManager", nil, false)
+		manager.Run(do.exit)
+	}, "changefeedManager")
+}
+
+// StartBinlogWriter starts the worker writing the binlog of this TiDB instance into `mysql.tidb_binlog`,
+// it writes nothing unless the memory pump is enabled.
+func (do *Domain) StartBinlogWriter() {
+	writer := binlogstore.NewWriter(do.sysSessionPool, do.ddl.GetID())
+	do.wg.Run(func() {
+		defer util.Recover(metrics.LabelDomain, "binlogWriter", nil, false)
+		writer.Run(do.exit)
+	}, "binlogWriter")
+}
+
 // TTLJobManager returns the ttl job manager on this domain
 func (do *Domain) TTLJobManager() *ttlworker.JobManager {
 	return do.ttlJobManager.Load()
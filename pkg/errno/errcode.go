@@ -243,6 +243,7 @@ const (
 	ErrVarCantBeRead                                         = 1233
 	ErrCantUseOptionHere                                     = 1234
 	ErrNotSupportedYet                                       = 1235
+	ErrMasterFatalErrorReadingBinlog                         = 1236
 	ErrIncorrectGlobalLocalVar                               = 1238
 	ErrWrongFkDef                                            = 1239
 	ErrKeyRefDoNotMatchTableRef                              = 1240
@@ -828,6 +829,8 @@ const (
 	ErrInvalidArgumentForLogarithm                           = 3020
 	ErrMaxExecTimeExceeded                                   = 3024
 	ErrAggregateOrderNonAggQuery                             = 3029
+	ErrGISDifferentSRIDs                                     = 3033
+	ErrGISInvalidData                                        = 3037
 	ErrUserLockWrongName                                     = 3057
 	ErrUserLockDeadlock                                      = 3058
 	ErrIncorrectType                                         = 3064
@@ -863,6 +866,7 @@ const (
 	ErrPKIndexCantBeInvisible                                = 3522
 	ErrGrantRole                                             = 3523
 	ErrRoleNotGranted                                        = 3530
+	ErrSRSNotFound                                           = 3548
 	ErrLockAcquireFailAndNoWaitSet                           = 3572
 	ErrCTERecursiveRequiresUnion                             = 3573
 	ErrCTERecursiveRequiresNonRecursiveFirst                 = 3574
@@ -892,10 +896,20 @@ const (
 	ErrWindowFunctionIgnoresFrame                            = 3599
 	ErrInvalidNumberOfArgs                                   = 3601
 	ErrFieldInGroupingNotGroupBy                             = 3602
+	ErrLongitudeOutOfRange                                   = 3616
+	ErrLatitudeOutOfRange                                    = 3617
+	ErrNotImplementedForGeographicSRS                        = 3618
 	ErrIllegalPrivilegeLevel                                 = 3619
 	ErrCTEMaxRecursionDepth                                  = 3636
 	ErrNotHintUpdatable                                      = 3637
 	ErrExistsInHistoryPassword                               = 3638
+	ErrWrongSRIDForColumn                                    = 3643
+	ErrMissingJSONTableValue                                 = 3665
+	ErrWrongJSONTableValue                                   = 3666
+	ErrTFMustHaveAlias                                       = 3667
+	ErrTFForbiddenReference                                  = 3668
+	ErrNotImplementedForCartesianSRS                         = 3704
+	ErrNonPositiveRadius                                     = 3706
 	ErrInvalidDefaultUTF8MB4Collation                        = 3721
 	ErrForeignKeyCannotDropParent                            = 3730
 	ErrForeignKeyCannotUseVirtualColumn                      = 3733
@@ -1136,6 +1150,15 @@ const (
 	ErrPausedDDLJob       = 8262
 	ErrBDRRestrictedDDL   = 8263
 
+	ErrVectorDimensionNotFit   = 8264
+	ErrVectorDimensionMismatch = 8265
+
+	ErrMergeTargetRowMatchedTwice = 8266
+
+	ErrChangefeedExists    = 8268
+	ErrChangefeedNotExists = 8269
+	ErrChangefeedInvalid   = 8270
+
 	// Resource group errors.
 	ErrResourceGroupExists                    = 8248
 	ErrResourceGroupNotExists                 = 8249
@@ -246,6 +246,7 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrVarCantBeRead:                            mysql.Message("Variable '%-.64s' can only be set, not read", nil),
 	ErrCantUseOptionHere:                        mysql.Message("Incorrect usage/placement of '%s'", nil),
 	ErrNotSupportedYet:                          mysql.Message("This version of TiDB doesn't yet support '%s'", nil),
+	ErrMasterFatalErrorReadingBinlog:            mysql.Message("Got fatal error %d from master when reading data from binary log: '%-.320s'", nil),
 	ErrIncorrectGlobalLocalVar:                  mysql.Message("Variable '%-.192s' is a %s variable", nil),
 	ErrWrongFkDef:                               mysql.Message("Incorrect foreign key definition for '%-.192s': %s", nil),
 	ErrKeyRefDoNotMatchTableRef:                 mysql.Message("Key reference and table reference don't match", nil),
@@ -844,6 +845,8 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrPasswordExpireAnonymousUser:                           mysql.Message("The password for anonymous user cannot be expired.", nil),
 	ErrInvalidArgumentForLogarithm:                           mysql.Message("Invalid argument for logarithm", nil),
 	ErrAggregateOrderNonAggQuery:                             mysql.Message("Expression #%d of ORDER BY contains aggregate function and applies to the result of a non-aggregated query", nil),
+	ErrGISDifferentSRIDs:                                     mysql.Message("Binary geometry function %s given two geometries of different srids: %d and %d, which should have been identical.", nil),
+	ErrGISInvalidData:                                        mysql.Message("Invalid GIS data provided to function %s.", nil),
 	ErrIncorrectType:                                         mysql.Message("Incorrect type for argument %s in function %s.", nil),
 	ErrFieldInOrderNotSelect:                                 mysql.Message("Expression #%d of ORDER BY clause is not in SELECT list, references column '%s' which is not in SELECT list; this is incompatible with %s", nil),
 	ErrAggregateInOrderNotSelect:                             mysql.Message("Expression #%d of ORDER BY clause is not in SELECT list, contains aggregate function; this is incompatible with %s", nil),
@@ -887,11 +890,22 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrWindowFunctionIgnoresFrame:                            mysql.Message("Window function '%s' ignores the frame clause of window '%s' and aggregates over the whole partition", nil),
 	ErrInvalidNumberOfArgs:                                   mysql.Message("Too many arguments for function %s; maximum allowed is %d", nil),
 	ErrFieldInGroupingNotGroupBy:                             mysql.Message("Argument %s of GROUPING function is not in GROUP BY", nil),
+	ErrSRSNotFound:                                           mysql.Message("There's no spatial reference system with SRID %d.", nil),
+	ErrLongitudeOutOfRange:                                   mysql.Message("Longitude %f is out of range in function %s. It must be within (%f, %f].", nil),
+	ErrLatitudeOutOfRange:                                    mysql.Message("Latitude %f is out of range in function %s. It must be within [%f, %f].", nil),
+	ErrNotImplementedForGeographicSRS:                        mysql.Message("%s(%s, ...) has not been implemented for geographic spatial reference systems.", nil),
+	ErrNotImplementedForCartesianSRS:                         mysql.Message("%s(%s, ...) has not been implemented for Cartesian spatial reference systems.", nil),
+	ErrNonPositiveRadius:                                     mysql.Message("Invalid radius provided to function %s: Radius must be greater than zero.", nil),
 	ErrRoleNotGranted:                                        mysql.Message("%s is not granted to %s", nil),
 	ErrMaxExecTimeExceeded:                                   mysql.Message("Query execution was interrupted, maximum statement execution time exceeded", nil),
 	ErrLockAcquireFailAndNoWaitSet:                           mysql.Message("Statement aborted because lock(s) could not be acquired immediately and NOWAIT is set.", nil),
 	ErrNotHintUpdatable:                                      mysql.Message("Variable '%s' might not be affected by SET_VAR hint.", nil),
 	ErrExistsInHistoryPassword:                               mysql.Message("Cannot use these credentials for '%s@%s' because they contradict the password history policy.", nil),
+	ErrWrongSRIDForColumn:                                    mysql.Message("The SRID of the geometry does not match the SRID of the column '%s'. The SRID of the geometry is %d, but the SRID of the column is %d. Consider changing the SRID of the geometry or the SRID property of the column.", nil),
+	ErrMissingJSONTableValue:                                 mysql.Message("Missing value for JSON_TABLE column '%-.192s'", nil),
+	ErrWrongJSONTableValue:                                   mysql.Message("Can't store an array or an object in the scalar column '%-.192s' of JSON_TABLE '%-.192s'.", nil),
+	ErrTFMustHaveAlias:                                       mysql.Message("Every table function must have an alias.", nil),
+	ErrTFForbiddenReference:                                  mysql.Message("INNER or LEFT JOIN must be used for LATERAL references made by '%-.192s'", nil),
 	ErrInvalidDefaultUTF8MB4Collation:                        mysql.Message("Invalid default collation %s: utf8mb4_0900_ai_ci or utf8mb4_general_ci or utf8mb4_bin expected", nil),
 	ErrForeignKeyCannotDropParent:                            mysql.Message("Cannot drop table '%s' referenced by a foreign key constraint '%s' on table '%s'.", nil),
 	ErrForeignKeyCannotUseVirtualColumn:                      mysql.Message("Foreign key '%s' uses virtual column '%s' which is not supported.", nil),
@@ -1166,4 +1180,13 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrCannotResumeDDLJob: mysql.Message("Job [%v] can't be resumed: %s", nil),
 	ErrPausedDDLJob:       mysql.Message("Job [%v] has already been paused", nil),
 	ErrBDRRestrictedDDL:   mysql.Message("The operation is not allowed while the bdr role of this cluster is set to %s.", nil),
+
+	ErrVectorDimensionNotFit:   mysql.Message("vector has %d dimensions, does not fit VECTOR(%d)", nil),
+	ErrVectorDimensionMismatch: mysql.Message("vectors have different dimensions: %d and %d", nil),
+
+	ErrMergeTargetRowMatchedTwice: mysql.Message("The MERGE statement attempted to modify the row %s of table '%s' more than once", nil),
+
+	ErrChangefeedExists:    mysql.Message("Changefeed '%-.192s' already exists", nil),
+	ErrChangefeedNotExists: mysql.Message("Changefeed '%-.192s' doesn't exist", nil),
+	ErrChangefeedInvalid:   mysql.Message("Invalid changefeed: %s", nil),
 }
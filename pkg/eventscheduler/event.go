@@ -0,0 +1,123 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"github.com/pingcap/tidb/pkg/util/timeutil"
+	"go.uber.org/zap"
+)
+
+// selectEventsSQL reads the events, the column offsets are used by newEvent.
+const selectEventsSQL = `SELECT db, name, definer, body, sql_mode, time_zone, character_set_client, collation_connection,
+	execute_at, interval_value, interval_field, starts, ends, status, on_completion, last_altered, last_executed FROM mysql.events`
+
+// Event is an event read from `mysql.events`.
+type Event struct {
+	DB, Name, Definer, Body            string
+	SQLMode, TimeZone                  string
+	CharsetClient, CollationConnection string
+	// Location is the time zone of the event, the times of the schedule are in it.
+	Location *time.Location
+	Schedule Schedule
+	// Enabled is false if the event is disabled, or disabled on the replicas.
+	Enabled bool
+	// Preserve is true if the event is kept after its last execution.
+	Preserve bool
+	// LastAltered is changed every time the event is altered.
+	LastAltered  string
+	LastExecuted time.Time
+}
+
+func newEvent(row chunk.Row) (*Event, error) {
+	event := &Event{
+		DB:                  row.GetString(0),
+		Name:                row.GetString(1),
+		Definer:             row.GetString(2),
+		Body:                row.GetString(3),
+		SQLMode:             row.GetString(4),
+		TimeZone:            row.GetString(5),
+		CharsetClient:       row.GetString(6),
+		CollationConnection: row.GetString(7),
+		Enabled:             row.GetEnum(13).String() == "ENABLED",
+		Preserve:            row.GetEnum(14).String() == "PRESERVE",
+		LastAltered:         row.GetTime(15).String(),
+	}
+	loc, err := timeutil.ParseTimeZone(event.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	event.Location = loc
+	getTime := func(i int) (time.Time, error) {
+		if row.IsNull(i) {
+			return time.Time{}, nil
+		}
+		return row.GetTime(i).CoreTime().GoTime(loc)
+	}
+	if event.Schedule.ExecuteAt, err = getTime(8); err != nil {
+		return nil, err
+	}
+	if !row.IsNull(9) {
+		event.Schedule.IntervalValue = row.GetString(9)
+		event.Schedule.IntervalField = row.GetString(10)
+	}
+	if event.Schedule.Starts, err = getTime(11); err != nil {
+		return nil, err
+	}
+	if event.Schedule.Ends, err = getTime(12); err != nil {
+		return nil, err
+	}
+	if event.LastExecuted, err = getTime(16); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// LoadEvents reads all the events. The events which can't be scheduled are skipped with an error log.
+func LoadEvents(ctx context.Context, exec sqlexec.RestrictedSQLExecutor) ([]*Event, error) {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	rows, _, err := exec.ExecRestrictedSQL(ctx, nil, selectEventsSQL)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	events := make([]*Event, 0, len(rows))
+	for _, row := range rows {
+		event, err := newEvent(row)
+		if err != nil {
+			logutil.BgLogger().Error("invalid event", zap.String("db", row.GetString(0)),
+				zap.String("name", row.GetString(1)), zap.Error(err))
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// loadEvent reads the event, it returns nil if the event doesn't exist.
+func loadEvent(ctx context.Context, exec sqlexec.RestrictedSQLExecutor, db, name string) (*Event, error) {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	rows, _, err := exec.ExecRestrictedSQL(ctx, nil, selectEventsSQL+" WHERE db = %? AND name = %?", db, name)
+	if err != nil || len(rows) == 0 {
+		return nil, errors.Trace(err)
+	}
+	return newEvent(rows[0])
+}
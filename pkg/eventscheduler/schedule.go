@@ -0,0 +1,80 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/types"
+)
+
+// Schedule is the schedule of an event. A one-time event is executed at ExecuteAt, and a recurring
+// event is executed at Starts + k * interval until Ends. The zero times are not specified.
+type Schedule struct {
+	ExecuteAt     time.Time
+	IntervalValue string
+	// IntervalField is the unit of the interval, it's empty for a one-time event.
+	IntervalField string
+	Starts, Ends  time.Time
+}
+
+// Next returns the first execution time not before t, it returns false if the event isn't executed after t.
+func (s *Schedule) Next(t time.Time) (time.Time, bool, error) {
+	if s.IntervalField == "" {
+		return s.ExecuteAt, !s.ExecuteAt.Before(t), nil
+	}
+
+	year, month, day, nano, _, err := types.ParseDurationValue(s.IntervalField, s.IntervalValue)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	if year < 0 || month < 0 || day < 0 || nano < 0 || year+month+day+nano == 0 {
+		return time.Time{}, false, errors.Errorf("invalid interval '%s' %s", s.IntervalValue, s.IntervalField)
+	}
+	nth := func(k int64) (time.Time, error) {
+		next, err := types.AddDate(k*year, k*month, k*day, s.Starts)
+		if err != nil {
+			return next, err
+		}
+		return next.Add(time.Duration(k * nano)), nil
+	}
+
+	// Estimate the number of intervals before t, and then correct it by the calendar.
+	var k int64
+	if t.After(s.Starts) {
+		months := year*12 + month
+		if months > 0 {
+			elapsed := int64(t.Year()-s.Starts.Year())*12 + int64(t.Month()-s.Starts.Month())
+			k = max(elapsed/months-1, 0)
+		} else {
+			step := int64(day)*int64(24*time.Hour) + nano
+			k = max(int64(t.Sub(s.Starts))/step-1, 0)
+		}
+	}
+	next, err := nth(k)
+	for err == nil && next.Before(t) {
+		k++
+		next, err = nth(k)
+	}
+	if err != nil {
+		// The next execution time overflows.
+		return time.Time{}, false, nil
+	}
+	if !s.Ends.IsZero() && next.After(s.Ends) {
+		return time.Time{}, false, nil
+	}
+	return next, true, nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/eventscheduler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleNext(t *testing.T) {
+	date := func(s string) time.Time {
+		tm, err := time.ParseInLocation(time.DateTime, s, time.UTC)
+		require.NoError(t, err)
+		return tm
+	}
+
+	// one-time event
+	s := &eventscheduler.Schedule{ExecuteAt: date("2024-03-01 10:00:00")}
+	next, ok, err := s.Next(date("2024-03-01 09:00:00"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, date("2024-03-01 10:00:00"), next)
+	next, ok, err = s.Next(date("2024-03-01 10:00:00"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, date("2024-03-01 10:00:00"), next)
+	_, ok, err = s.Next(date("2024-03-01 10:00:01"))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	cases := []struct {
+		value, field string
+		starts, ends string
+		t, next      string
+	}{
+		{"1", "DAY", "2024-01-31 00:00:00", "", "2024-01-01 00:00:00", "2024-01-31 00:00:00"},
+		{"1", "DAY", "2024-01-31 00:00:00", "", "2024-03-05 12:00:00", "2024-03-06 00:00:00"},
+		{"1", "DAY", "2024-01-31 00:00:00", "", "2024-03-06 00:00:00", "2024-03-06 00:00:00"},
+		{"90", "MINUTE", "2024-01-01 00:00:00", "", "2024-01-01 02:00:00", "2024-01-01 03:00:00"},
+		{"1:30", "HOUR_MINUTE", "2024-01-01 00:00:00", "", "2024-01-01 02:00:00", "2024-01-01 03:00:00"},
+		{"1", "MONTH", "2024-01-15 08:00:00", "", "2024-03-20 00:00:00", "2024-04-15 08:00:00"},
+		{"1-2", "YEAR_MONTH", "2024-01-15 08:00:00", "", "2025-01-20 00:00:00", "2025-03-15 08:00:00"},
+		{"1", "WEEK", "2024-01-01 00:00:00", "2024-01-15 00:00:00", "2024-01-10 00:00:00", "2024-01-15 00:00:00"},
+		{"1", "WEEK", "2024-01-01 00:00:00", "2024-01-14 00:00:00", "2024-01-10 00:00:00", ""},
+	}
+	for _, c := range cases {
+		s = &eventscheduler.Schedule{IntervalValue: c.value, IntervalField: c.field, Starts: date(c.starts)}
+		if c.ends != "" {
+			s.Ends = date(c.ends)
+		}
+		next, ok, err = s.Next(date(c.t))
+		require.NoError(t, err, c)
+		if c.next == "" {
+			require.False(t, ok, c)
+			continue
+		}
+		require.True(t, ok, c)
+		require.Equal(t, date(c.next), next, c)
+	}
+
+	s = &eventscheduler.Schedule{IntervalValue: "0", IntervalField: "DAY", Starts: date("2024-01-01 00:00:00")}
+	_, _, err = s.Next(date("2024-01-02 00:00:00"))
+	require.Error(t, err)
+}
@@ -0,0 +1,141 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/ngaut/pools"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	timerrt "github.com/pingcap/tidb/pkg/timer/runtime"
+	"github.com/pingcap/tidb/pkg/timer/tablestore"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+)
+
+const (
+	scheduleTickInterval = time.Second
+	// syncTimersInterval is the interval to sync the timers with `mysql.events`.
+	syncTimersInterval = 5 * time.Second
+)
+
+type sessionPool interface {
+	Get() (pools.Resource, error)
+	Put(pools.Resource)
+}
+
+// Scheduler executes the events in `mysql.events` on schedule. Each event has a timer in
+// `mysql.tidb_timers`, and only the DDL owner syncs the timers and executes the events.
+type Scheduler struct {
+	pool    sessionPool
+	etcd    *clientv3.Client
+	isOwner func() bool
+	runner  Runner
+
+	rt       *timerrt.TimerGroupRuntime
+	syncer   *TimersSyncer
+	syncTime time.Time
+}
+
+// NewScheduler creates a new Scheduler.
+func NewScheduler(pool sessionPool, etcd *clientv3.Client, isOwner func() bool, runner Runner) *Scheduler {
+	return &Scheduler{
+		pool:    pool,
+		etcd:    etcd,
+		isOwner: isOwner,
+		runner:  runner,
+	}
+}
+
+// Run runs the scheduler until the exit channel is closed.
+func (s *Scheduler) Run(exit <-chan struct{}) {
+	store := tablestore.NewTableTimerStore(1, s.pool, "mysql", "tidb_timers", s.etcd)
+	s.syncer = NewTimersSyncer(timerapi.NewDefaultTimerClient(store))
+	defer func() {
+		s.pause()
+		store.Close()
+		logutil.BgLogger().Info("event scheduler exited")
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ticker := time.NewTicker(scheduleTickInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-exit:
+			return
+		case <-ticker.C:
+			s.onTick(ctx, store)
+		}
+	}
+}
+
+func (s *Scheduler) onTick(ctx context.Context, store *timerapi.TimerStore) {
+	if !s.isOwner() {
+		s.pause()
+		s.syncTime = time.Time{}
+		return
+	}
+
+	s.resume(store)
+	if time.Since(s.syncTime) > syncTimersInterval {
+		events, err := s.loadEvents(ctx)
+		if err != nil {
+			logutil.BgLogger().Warn("failed to load events", zap.Error(err))
+			return
+		}
+		s.syncer.SyncTimers(ctx, events)
+		s.syncTime = time.Now()
+	}
+}
+
+func (s *Scheduler) loadEvents(ctx context.Context) ([]*Event, error) {
+	resource, err := s.pool.Get()
+	if err != nil {
+		return nil, err
+	}
+	defer s.pool.Put(resource)
+	sctx, ok := resource.(sessionctx.Context)
+	if !ok {
+		return nil, errors.Errorf("%T is not sessionctx.Context", resource)
+	}
+	return LoadEvents(ctx, sctx.GetRestrictedSQLExecutor())
+}
+
+func (s *Scheduler) resume(store *timerapi.TimerStore) {
+	if s.rt != nil {
+		return
+	}
+
+	s.rt = timerrt.NewTimerRuntimeBuilder("event", store).
+		SetCond(&timerapi.TimerCond{Key: timerapi.NewOptionalVal(timerKeyPrefix), KeyPrefix: true}).
+		RegisterHookFactory(timerHookClass, func(_ string, cli timerapi.TimerClient) timerapi.Hook {
+			return newExecuteHook(s.pool, cli, s.runner)
+		}).
+		Build()
+	s.rt.Start()
+}
+
+func (s *Scheduler) pause() {
+	if rt := s.rt; rt != nil {
+		s.rt = nil
+		rt.Stop()
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+const (
+	// executeTimeout is the max time of executing an event.
+	executeTimeout = time.Hour
+	// retryInterval is the interval to retry an event if it fails to be read or scheduled.
+	retryInterval = time.Minute
+)
+
+// Runner executes the body of an event scheduled at a time with the privileges of its definer after the
+// execution is claimed by ClaimExecution, it returns false if the execution isn't claimed.
+type Runner func(ctx context.Context, event *Event, scheduled time.Time) (claimed bool, err error)
+
+type executeHook struct {
+	pool   sessionPool
+	cli    timerapi.TimerClient
+	runner Runner
+	ctx    context.Context
+	cancel func()
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	running map[string]struct{}
+}
+
+func newExecuteHook(pool sessionPool, cli timerapi.TimerClient, runner Runner) *executeHook {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &executeHook{
+		pool:    pool,
+		cli:     cli,
+		runner:  runner,
+		ctx:     ctx,
+		cancel:  cancel,
+		running: make(map[string]struct{}),
+	}
+}
+
+func (*executeHook) Start() {}
+
+func (h *executeHook) Stop() {
+	h.cancel()
+	h.wg.Wait()
+}
+
+func (*executeHook) OnPreSchedEvent(context.Context, timerapi.TimerShedEvent) (r timerapi.PreSchedEventResult, err error) {
+	return
+}
+
+func (h *executeHook) OnSchedEvent(_ context.Context, event timerapi.TimerShedEvent) error {
+	timer := event.Timer()
+	eventID := event.EventID()
+	if err := h.ctx.Err(); err != nil {
+		return err
+	}
+
+	var data TimerData
+	if err := json.Unmarshal(timer.Data, &data); err != nil {
+		logutil.BgLogger().Error("invalid event timer data",
+			zap.String("timerID", timer.ID),
+			zap.String("timerKey", timer.Key),
+			zap.ByteString("data", timer.Data),
+		)
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.running[eventID]; ok {
+		return nil
+	}
+	h.running[eventID] = struct{}{}
+	h.wg.Add(1)
+	go h.execute(data, timer.ID, eventID, timer.Watermark)
+	return nil
+}
+
+// execute executes the event scheduled at the watermark, and closes the timer event with the next
+// execution time. A failed execution is not retried, the event is executed at the next time as usual.
+func (h *executeHook) execute(data TimerData, timerID, eventID string, scheduled time.Time) {
+	logger := logutil.BgLogger().With(
+		zap.String("db", data.DB),
+		zap.String("event", data.Name),
+		zap.String("timerID", timerID),
+		zap.String("eventID", eventID),
+	)
+	defer func() {
+		h.mu.Lock()
+		delete(h.running, eventID)
+		h.mu.Unlock()
+		h.wg.Done()
+	}()
+
+	next, ok, err := h.executeEvent(data, scheduled, logger)
+	if err != nil {
+		logger.Warn("failed to execute event", zap.Error(err))
+		if ok && next.IsZero() {
+			next = time.Now().Add(retryInterval)
+		}
+	}
+	if !ok {
+		// The timer is disabled until the event is altered, or deleted if the event doesn't exist.
+		next = scheduled
+	}
+	if err = h.cli.CloseTimerEvent(h.ctx, timerID, eventID, timerapi.WithSetWatermark(capWatermark(next))); err != nil {
+		logger.Error("CloseTimerEvent error", zap.Error(err))
+		return
+	}
+	if !ok {
+		if err = h.cli.UpdateTimer(h.ctx, timerID, timerapi.WithSetEnable(false)); err != nil {
+			logger.Error("failed to disable event timer", zap.Error(err))
+		}
+	}
+}
+
+// executeEvent executes the event if it's not executed at the scheduled time, and returns the next
+// execution time. The event is dropped or disabled if it won't be executed anymore.
+func (h *executeHook) executeEvent(data TimerData, scheduled time.Time, logger *zap.Logger) (next time.Time, ok bool, err error) {
+	resource, err := h.pool.Get()
+	if err != nil {
+		return next, true, err
+	}
+	defer h.pool.Put(resource)
+	sctx, isCtx := resource.(sessionctx.Context)
+	if !isCtx {
+		return next, true, errors.Errorf("%T is not sessionctx.Context", resource)
+	}
+	exec := sctx.GetRestrictedSQLExecutor()
+	ctx := kv.WithInternalSourceType(h.ctx, kv.InternalTxnOthers)
+
+	event, err := loadEvent(ctx, exec, data.DB, data.Name)
+	if err != nil {
+		return next, true, err
+	}
+	if event == nil {
+		return next, false, nil
+	}
+	due, ok, err := event.Schedule.Next(scheduled)
+	if err != nil {
+		return next, false, err
+	}
+	// The timer may be triggered before the execution time if the time exceeds the max watermark.
+	if ok && due.After(scheduled) {
+		return due, true, nil
+	}
+	if ok && event.Enabled {
+		runCtx, cancel := context.WithTimeout(h.ctx, executeTimeout)
+		claimed, err := h.runner(runCtx, event, scheduled)
+		cancel()
+		if err != nil && !claimed {
+			return next, true, err
+		}
+		if err != nil {
+			// The execution has been claimed, so it isn't retried.
+			logger.Warn("event execution failed", zap.Error(err))
+		}
+	}
+
+	since := scheduled.Add(time.Second)
+	if now := time.Now(); now.After(since) {
+		since = now
+	}
+	next, ok, err = event.Schedule.Next(since)
+	if err != nil || ok {
+		return next, ok, err
+	}
+	if event.Preserve {
+		_, _, err = exec.ExecRestrictedSQL(ctx, nil, "UPDATE mysql.events SET status = 'DISABLED' WHERE db = %? AND name = %?",
+			event.DB, event.Name)
+	} else {
+		_, _, err = exec.ExecRestrictedSQL(ctx, nil, "DELETE FROM mysql.events WHERE db = %? AND name = %?", event.DB, event.Name)
+	}
+	return next, false, err
+}
+
+// ClaimExecution records the execution of the event at the scheduled time, it returns false if the execution
+// has been claimed. The claim is committed before the body is executed, so an execution is never repeated,
+// even if it's interrupted.
+func ClaimExecution(ctx context.Context, sctx sessionctx.Context, event *Event, scheduled time.Time) (bool, error) {
+	lastExecuted := scheduled.In(event.Location).Format(time.DateTime)
+	_, err := sctx.GetSQLExecutor().ExecuteInternal(ctx, `UPDATE mysql.events SET last_executed = %? WHERE db = %? AND name = %?
+		AND (last_executed IS NULL OR last_executed < %?)`, lastExecuted, event.DB, event.Name, lastExecuted)
+	if err != nil {
+		return false, err
+	}
+	return sctx.GetSessionVars().StmtCtx.AffectedRows() > 0, nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+const (
+	timerKeyPrefix = "/tidb/event/"
+	timerHookClass = "tidb.event.execute"
+	// timerSchedExpr makes a timer triggered at its watermark, which is the next execution time of the event.
+	timerSchedExpr = "0"
+)
+
+// maxWatermark is the max value of the TIMESTAMP watermark column. The events executed after it
+// are triggered at it, and then wait for the next execution time again.
+var maxWatermark = time.Date(2038, 1, 19, 3, 14, 7, 0, time.UTC)
+
+func capWatermark(t time.Time) time.Time {
+	if t.After(maxWatermark) {
+		return maxWatermark
+	}
+	return t
+}
+
+// TimerData is the data stored in each timer executing an event.
+type TimerData struct {
+	DB   string `json:"db"`
+	Name string `json:"name"`
+}
+
+// TimersSyncer is used to sync the timers with the events.
+type TimersSyncer struct {
+	cli timerapi.TimerClient
+}
+
+// NewTimersSyncer creates a new TimersSyncer.
+func NewTimersSyncer(cli timerapi.TimerClient) *TimersSyncer {
+	return &TimersSyncer{cli: cli}
+}
+
+// SyncTimers syncs the timers with the events. The next execution time of an event is recomputed
+// only if the event is created or altered, it's maintained by the timer hook after each execution.
+func (g *TimersSyncer) SyncTimers(ctx context.Context, events []*Event) {
+	timers, err := g.cli.GetTimers(ctx, timerapi.WithKeyPrefix(timerKeyPrefix))
+	if err != nil {
+		logutil.BgLogger().Error("failed to pull event timers", zap.Error(err))
+		return
+	}
+	key2Timers := make(map[string]*timerapi.TimerRecord, len(timers))
+	for _, timer := range timers {
+		key2Timers[timer.Key] = timer
+	}
+
+	currentTimerKeys := make(map[string]struct{}, len(events))
+	for _, event := range events {
+		key := buildTimerKey(event.DB, event.Name)
+		currentTimerKeys[key] = struct{}{}
+		if err := g.syncOneTimer(ctx, key2Timers[key], event); err != nil {
+			logutil.BgLogger().Error("failed to sync event timer", zap.Error(err), zap.String("key", key))
+		}
+	}
+
+	for key, timer := range key2Timers {
+		if _, ok := currentTimerKeys[key]; ok {
+			continue
+		}
+		if _, err = g.cli.DeleteTimer(ctx, timer.ID); err != nil {
+			logutil.BgLogger().Error("failed to delete timer", zap.Error(err), zap.String("timerID", timer.ID))
+		}
+	}
+}
+
+func (g *TimersSyncer) syncOneTimer(ctx context.Context, timer *timerapi.TimerRecord, event *Event) error {
+	tags := getTimerTags(event)
+	if timer != nil && slices.Equal(timer.Tags, tags) {
+		return nil
+	}
+
+	// A recurring event is executed at the first time after it's created or altered, the times before
+	// the timer is synced are included since the events are synced periodically. A one-time event is
+	// executed unless it has been executed.
+	since := event.LastExecuted.Add(time.Second)
+	if event.Schedule.IntervalField != "" {
+		if t := time.Now().Add(-syncTimersInterval - scheduleTickInterval).Truncate(time.Second); t.After(since) {
+			since = t
+		}
+	}
+	next, ok, err := event.Schedule.Next(since)
+	if err != nil {
+		return err
+	}
+	enable := event.Enabled && ok
+	if !ok {
+		next = time.Now()
+	}
+	next = capWatermark(next)
+
+	if timer == nil {
+		data, err := json.Marshal(&TimerData{DB: event.DB, Name: event.Name})
+		if err != nil {
+			return err
+		}
+		_, err = g.cli.CreateTimer(ctx, timerapi.TimerSpec{
+			Key:             buildTimerKey(event.DB, event.Name),
+			Tags:            tags,
+			Data:            data,
+			SchedPolicyType: timerapi.SchedEventInterval,
+			SchedPolicyExpr: timerSchedExpr,
+			HookClass:       timerHookClass,
+			Watermark:       next,
+			Enable:          enable,
+		})
+		return err
+	}
+	return g.cli.UpdateTimer(ctx, timer.ID,
+		timerapi.WithSetTags(tags),
+		timerapi.WithSetWatermark(next),
+		timerapi.WithSetEnable(enable),
+	)
+}
+
+// getTimerTags returns the tags of the timer, the timer is updated when the tags are changed.
+func getTimerTags(event *Event) []string {
+	return []string{
+		fmt.Sprintf("db=%s", event.DB),
+		fmt.Sprintf("event=%s", event.Name),
+		fmt.Sprintf("altered=%s", event.LastAltered),
+	}
+}
+
+func buildTimerKey(db, name string) string {
+	return fmt.Sprintf("%s%s/%s", timerKeyPrefix, url.PathEscape(db), url.PathEscape(name))
+}
@@ -0,0 +1,105 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/eventscheduler"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/testkit"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/pingcap/tidb/pkg/timer/tablestore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventTimerSync(t *testing.T) {
+	store, do := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec(tablestore.CreateTimerTableSQL("test", "test_timers"))
+	timerStore := tablestore.NewTableTimerStore(1, do.SysSessionPool(), "test", "test_timers", nil)
+	defer timerStore.Close()
+
+	tk.MustExec("set @@time_zone = '+00:00'")
+	tk.MustExec("create event e1 on schedule every 1 hour disable do select 1")
+	tk.MustExec("create event e2 on schedule at '2030-01-01 00:00:00' disable do select 1")
+	tk.MustExec("create event e3 on schedule at '2099-01-01 00:00:00' do select 1")
+	loadEvents := func() []*eventscheduler.Event {
+		ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnOthers)
+		events, err := eventscheduler.LoadEvents(ctx, tk.Session().GetRestrictedSQLExecutor())
+		require.NoError(t, err)
+		return events
+	}
+
+	cli := timerapi.NewDefaultTimerClient(timerStore)
+	syncer := eventscheduler.NewTimersSyncer(cli)
+	syncer.SyncTimers(context.TODO(), loadEvents())
+	timers, err := cli.GetTimers(context.TODO())
+	require.NoError(t, err)
+	require.Len(t, timers, 3)
+	key2Timers := make(map[string]*timerapi.TimerRecord, len(timers))
+	for _, timer := range timers {
+		key2Timers[timer.Key] = timer
+	}
+	timer := key2Timers["/tidb/event/test/e1"]
+	require.NotNil(t, timer)
+	require.Equal(t, timerapi.SchedEventInterval, timer.SchedPolicyType)
+	require.Equal(t, "0", timer.SchedPolicyExpr)
+	require.Equal(t, "db=test", timer.Tags[0])
+	require.Equal(t, "event=e1", timer.Tags[1])
+	require.False(t, timer.Enable)
+	require.WithinDuration(t, time.Now(), timer.Watermark, 10*time.Second)
+	var data eventscheduler.TimerData
+	require.NoError(t, json.Unmarshal(timer.Data, &data))
+	require.Equal(t, eventscheduler.TimerData{DB: "test", Name: "e1"}, data)
+	timer = key2Timers["/tidb/event/test/e2"]
+	require.NotNil(t, timer)
+	require.False(t, timer.Enable)
+	require.Equal(t, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), timer.Watermark.UTC())
+
+	// the watermark is capped by the max value of TIMESTAMP
+	timer3 := key2Timers["/tidb/event/test/e3"]
+	require.NotNil(t, timer3)
+	require.True(t, timer3.Enable)
+	require.Equal(t, time.Date(2038, 1, 19, 3, 14, 7, 0, time.UTC), timer3.Watermark.UTC())
+
+	// the timer is not changed if the event is not altered
+	syncer.SyncTimers(context.TODO(), loadEvents())
+	timer2, err := cli.GetTimerByID(context.TODO(), timer.ID)
+	require.NoError(t, err)
+	require.Equal(t, timer.Version, timer2.Version)
+
+	// the timer is updated after the event is altered
+	time.Sleep(time.Millisecond)
+	tk.MustExec("alter event e2 on schedule at '2031-01-01 00:00:00' enable")
+	syncer.SyncTimers(context.TODO(), loadEvents())
+	timer2, err = cli.GetTimerByID(context.TODO(), timer.ID)
+	require.NoError(t, err)
+	require.True(t, timer2.Enable)
+	require.Equal(t, time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC), timer2.Watermark.UTC())
+
+	// the timer is deleted after the event is dropped
+	tk.MustExec("drop event e2")
+	tk.MustExec("drop event e3")
+	syncer.SyncTimers(context.TODO(), loadEvents())
+	timers, err = cli.GetTimers(context.TODO())
+	require.NoError(t, err)
+	require.Len(t, timers, 1)
+	require.Equal(t, "/tidb/event/test/e1", timers[0].Key)
+}
@@ -252,6 +252,9 @@ type ExecStmt struct {
 	// OutputNames will be set if using cached plan
 	OutputNames []*types.FieldName
 	PsStmt      *plannercore.PlanCacheStmt
+
+	// triggerTables are the IDs of the tables whose triggers are being executed.
+	triggerTables []int64
 }
 
 // GetStmtNode returns the stmtNode inside Statement
@@ -644,6 +647,9 @@ func (a *ExecStmt) handleForeignKeyTrigger(ctx context.Context, e exec.Executor,
 			return err
 		}
 	}
+	if withTrigger, ok := e.(WithTrigger); ok {
+		return a.handleTriggers(ctx, withTrigger.GetAfterTriggers(), depth)
+	}
 	return nil
 }
 
@@ -716,7 +722,11 @@ func (a *ExecStmt) handleForeignKeyCascade(ctx context.Context, fkc *FKCascadeEx
 // cascade behaviour and this ExecStmt is in transaction.
 func (a *ExecStmt) prepareFKCascadeContext(e exec.Executor) {
 	exec, ok := e.(WithForeignKeyTrigger)
-	if !ok || !exec.HasFKCascades() {
+	if !ok {
+		return
+	}
+	// AFTER triggers are executed like foreign key cascades, after the change of the ExecStmt itself is committed.
+	if withTrigger, ok := e.(WithTrigger); !exec.HasFKCascades() && (!ok || len(withTrigger.GetAfterTriggers()) == 0) {
 		return
 	}
 	sessVar := a.Ctx.GetSessionVars()
@@ -785,11 +795,12 @@ func (a *ExecStmt) handleNoDelay(ctx context.Context, e exec.Executor, isPessimi
 	}
 
 	// If the executor doesn't return any result to the client, we execute it without delay.
-	if toCheck.Schema().Len() == 0 {
+	// The rows of a RETURNING clause are buffered by the write executor, so it's also executed without delay.
+	if toCheck.Schema().Len() == 0 || getReturning(toCheck) != nil {
 		handled = !isExplainAnalyze
 		if isPessimistic {
-			err := a.handlePessimisticDML(ctx, toCheck)
-			return handled, nil, err
+			r, err := a.handlePessimisticDML(ctx, toCheck)
+			return handled, r, err
 		}
 		r, err := a.handleNoDelayExecutor(ctx, toCheck)
 		return handled, r, err
@@ -932,21 +943,23 @@ func (a *ExecStmt) runPessimisticSelectForUpdate(ctx context.Context, e exec.Exe
 	return nil, err
 }
 
-func (a *ExecStmt) handleNoDelayExecutor(ctx context.Context, e exec.Executor) (sqlexec.RecordSet, error) {
+func (a *ExecStmt) handleNoDelayExecutor(ctx context.Context, e exec.Executor) (rs sqlexec.RecordSet, err error) {
 	sctx := a.Ctx
 	r, ctx := tracing.StartRegionEx(ctx, "executor.handleNoDelayExecutor")
 	defer r.End()
 
-	var err error
 	defer func() {
 		terror.Log(exec.Close(e))
-		a.logAudit()
+		// The audit of a statement returning rows is logged when its record set is closed.
+		if rs == nil {
+			a.logAudit()
+		}
 	}()
 
 	// Check if "tidb_snapshot" is set for the write executors.
 	// In history read mode, we can not do write operations.
 	switch e.(type) {
-	case *DeleteExec, *InsertExec, *UpdateExec, *ReplaceExec, *LoadDataExec, *DDLExec, *ImportIntoExec:
+	case *DeleteExec, *InsertExec, *UpdateExec, *ReplaceExec, *MergeExec, *LoadDataExec, *DDLExec, *ImportIntoExec:
 		snapshotTS := sctx.GetSessionVars().SnapshotTS
 		if snapshotTS != 0 {
 			return nil, errors.New("can not execute write statement when 'tidb_snapshot' is set")
@@ -962,17 +975,40 @@ func (a *ExecStmt) handleNoDelayExecutor(ctx context.Context, e exec.Executor) (
 		return nil, err
 	}
 	err = a.handleStmtForeignKeyTrigger(ctx, e)
-	return nil, err
+	if err != nil {
+		return nil, err
+	}
+	if returning := getReturning(e); returning != nil && !sctx.GetSessionVars().StmtCtx.IsExplainAnalyzeDML {
+		var txnStartTS uint64
+		if txn, err := sctx.Txn(false); err == nil && txn.Valid() {
+			txnStartTS = txn.StartTS()
+		}
+		return &recordSet{
+			executor:   returning,
+			stmt:       a,
+			txnStartTS: txnStartTS,
+		}, nil
+	}
+	return nil, nil
 }
 
-func (a *ExecStmt) handlePessimisticDML(ctx context.Context, e exec.Executor) (err error) {
+// getReturning returns the executor of the RETURNING clause of a write executor, it returns nil if the
+// executor has no RETURNING clause.
+func getReturning(e exec.Executor) *returningExec {
+	if w, ok := e.(withReturning); ok {
+		return w.getReturning()
+	}
+	return nil
+}
+
+func (a *ExecStmt) handlePessimisticDML(ctx context.Context, e exec.Executor) (rs sqlexec.RecordSet, err error) {
 	sctx := a.Ctx
 	// Do not activate the transaction here.
 	// When autocommit = 0 and transaction in pessimistic mode,
 	// statements like set xxx = xxx; should not active the transaction.
 	txn, err := sctx.Txn(false)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	txnCtx := sctx.GetSessionVars().TxnCtx
 	defer func() {
@@ -997,7 +1033,7 @@ func (a *ExecStmt) handlePessimisticDML(ctx context.Context, e exec.Executor) (e
 	txnManager := sessiontxn.GetTxnManager(a.Ctx)
 	err = txnManager.OnPessimisticStmtStart(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer func() {
 		isSuccessful := err == nil
@@ -1015,9 +1051,9 @@ func (a *ExecStmt) handlePessimisticDML(ctx context.Context, e exec.Executor) (e
 		}
 
 		startTime := time.Now()
-		_, err = a.handleNoDelayExecutor(ctx, e)
+		rs, err = a.handleNoDelayExecutor(ctx, e)
 		if !txn.Valid() {
-			return err
+			return rs, err
 		}
 
 		if isFirstAttempt {
@@ -1034,24 +1070,24 @@ func (a *ExecStmt) handlePessimisticDML(ctx context.Context, e exec.Executor) (e
 				if exeerrors.ErrDeadlock.Equal(err) {
 					metrics.StatementDeadlockDetectDuration.Observe(time.Since(startTime).Seconds())
 				}
-				return err
+				return nil, err
 			}
 			continue
 		}
 		keys, err1 := txn.(pessimisticTxn).KeysNeedToLock()
 		if err1 != nil {
-			return err1
+			return nil, err1
 		}
 		keys = txnCtx.CollectUnchangedKeysForLock(keys)
 		if len(keys) == 0 {
-			return nil
+			return rs, nil
 		}
 		keys = filterTemporaryTableKeys(sctx.GetSessionVars(), keys)
 		seVars := sctx.GetSessionVars()
 		keys = filterLockTableKeys(seVars.StmtCtx, keys)
 		lockCtx, err := newLockCtx(sctx, seVars.LockWaitTimeout, len(keys))
 		if err != nil {
-			return err
+			return nil, err
 		}
 		var lockKeyStats *util.LockKeysDetails
 		ctx = context.WithValue(ctx, util.LockKeysDetailCtxKey, &lockKeyStats)
@@ -1065,7 +1101,11 @@ func (a *ExecStmt) handlePessimisticDML(ctx context.Context, e exec.Executor) (e
 			seVars.StmtCtx.MergeLockKeysExecDetails(lockKeyStats)
 		}
 		if err == nil {
-			return nil
+			return rs, nil
+		}
+		if rs != nil {
+			// The rows of the RETURNING clause are collected again by the retried statement.
+			terror.Log(exec.Close(rs.(*recordSet).executor))
 		}
 		e, err = a.handlePessimisticLockError(ctx, err)
 		if err != nil {
@@ -1073,7 +1113,7 @@ func (a *ExecStmt) handlePessimisticDML(ctx context.Context, e exec.Executor) (e
 			if exeerrors.ErrDeadlock.Equal(err) {
 				metrics.StatementDeadlockDetectDuration.Observe(time.Since(startLocking).Seconds())
 			}
-			return err
+			return nil, err
 		}
 	}
 }
@@ -1369,6 +1409,10 @@ func (a *ExecStmt) FinishExecuteStmt(txnTS uint64, err error, hasMoreResults boo
 			metrics.TiFlashQueryTotalCounter.WithLabelValues(metrics.ExecuteErrorToLabel(err), metrics.LblError).Inc()
 		}
 	}
+	// The plans from the plan cache are not estimated in this statement, so the applied corrections are unknown.
+	if succ && a.Plan != nil && sessVars.EnableCardinalityFeedback && !sessVars.InRestrictedSQL && !sessVars.FoundInPlanCache {
+		plannercore.CollectCardinalityFeedback(a.Ctx.GetPlanCtx(), a.Plan)
+	}
 	sessVars.PrevStmt = FormatSQL(a.GetTextToLog(false))
 	a.recordLastQueryInfo(err)
 	a.observePhaseDurations(sessVars.InRestrictedSQL, execDetail.CommitDetail)
@@ -1970,6 +2014,7 @@ func (a *ExecStmt) SummaryStmt(succ bool) {
 		ResourceGroupName:   sessVars.StmtCtx.ResourceGroupName,
 
 		PlanCacheUnqualified: sessVars.StmtCtx.PlanCacheUnqualified(),
+		QueryAttributes:      sessVars.QueryAttributes,
 	}
 	if a.retryCount > 0 {
 		stmtExecInfo.ExecRetryTime = costTime - sessVars.DurationParse - sessVars.DurationCompile - time.Since(a.retryStartTime)
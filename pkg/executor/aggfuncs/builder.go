@@ -378,6 +378,8 @@ func buildFirstRow(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
 			return &firstRow4String{base}
 		case types.ETJson:
 			return &firstRow4JSON{base}
+		case types.ETVectorFloat32:
+			return &firstRow4VectorFloat32{base}
 		}
 	}
 	return nil
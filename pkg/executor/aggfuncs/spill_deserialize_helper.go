@@ -374,6 +374,17 @@ func (s *deserializeHelper) deserializePartialResult4FirstRowJSON(dst *partialRe
 	return false
 }
 
+func (s *deserializeHelper) deserializePartialResult4FirstRowVectorFloat32(dst *partialResult4FirstRowVectorFloat32) bool {
+	if s.readRowIndex < s.totalRowCnt {
+		s.pab.Reset(s.column, s.readRowIndex)
+		s.deserializeBasePartialResult4FirstRow(&dst.basePartialResult4FirstRow)
+		dst.val = util.DeserializeVectorFloat32(s.pab)
+		s.readRowIndex++
+		return true
+	}
+	return false
+}
+
 func (s *deserializeHelper) deserializePartialResult4FirstRowEnum(dst *partialResult4FirstRowEnum) bool {
 	if s.readRowIndex < s.totalRowCnt {
 		s.pab.Reset(s.column, s.readRowIndex)
@@ -213,6 +213,12 @@ func (s *SerializeHelper) serializePartialResult4FirstRowJSON(value partialResul
 	return s.buf
 }
 
+func (s *SerializeHelper) serializePartialResult4FirstRowVectorFloat32(value partialResult4FirstRowVectorFloat32) []byte {
+	s.buf = s.serializeBasePartialResult4FirstRow(value.basePartialResult4FirstRow)
+	s.buf = util.SerializeVectorFloat32(value.val, s.buf)
+	return s.buf
+}
+
 func (s *SerializeHelper) serializePartialResult4FirstRowEnum(value partialResult4FirstRowEnum) []byte {
 	s.buf = s.serializeBasePartialResult4FirstRow(value.basePartialResult4FirstRow)
 	s.buf = util.SerializeEnum(&value.val, s.buf)
@@ -62,6 +62,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/terror"
 	plannercore "github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/indexadvisor"
 	plannerutil "github.com/pingcap/tidb/pkg/planner/util"
 	"github.com/pingcap/tidb/pkg/planner/util/coreusage"
 	"github.com/pingcap/tidb/pkg/sessionctx"
@@ -186,6 +187,8 @@ func (b *executorBuilder) build(p base.Plan) exec.Executor {
 		return b.buildDeallocate(v)
 	case *plannercore.Delete:
 		return b.buildDelete(v)
+	case *plannercore.Merge:
+		return b.buildMerge(v)
 	case *plannercore.Execute:
 		return b.buildExecute(v)
 	case *plannercore.Trace:
@@ -278,6 +281,10 @@ func (b *executorBuilder) build(p base.Plan) exec.Executor {
 		return b.buildMemTable(v)
 	case *plannercore.PhysicalTableDual:
 		return b.buildTableDual(v)
+	case *plannercore.PhysicalJSONTable:
+		return b.buildJSONTable(v)
+	case *plannercore.PhysicalUnpivot:
+		return b.buildUnpivot(v)
 	case *plannercore.PhysicalApply:
 		return b.buildApply(v)
 	case *plannercore.PhysicalMaxOneRow:
@@ -881,6 +888,7 @@ func (b *executorBuilder) buildShow(v *plannercore.PhysicalShow) exec.Executor {
 		Extended:              v.Extended,
 		Extractor:             v.Extractor,
 		ImportJobID:           v.ImportJobID,
+		Procedure:             v.Procedure,
 	}
 	if e.Tp == ast.ShowMasterStatus || e.Tp == ast.ShowBinlogStatus {
 		// show master status need start ts.
@@ -910,6 +918,24 @@ func (b *executorBuilder) buildSimple(v *plannercore.Simple) exec.Executor {
 			BaseExecutor:         exec.NewBaseExecutor(b.ctx, v.Schema(), 0),
 			QueryWatchOptionList: s.QueryWatchOptionList,
 		}
+	case *ast.XAStmt:
+		if s.Tp == ast.XARecover {
+			return &XARecoverExec{
+				BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+				convertXID:   s.ConvertXID,
+			}
+		}
+	case *ast.RecommendIndexStmt:
+		options, err := indexadvisor.NewOptions(s.Options)
+		if err != nil {
+			b.err = err
+			return nil
+		}
+		return &RecommendIndexExec{
+			BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+			sql:          s.SQL,
+			options:      options,
+		}
 	case *ast.ImportIntoActionStmt:
 		return &ImportIntoActionExec{
 			BaseExecutor: exec.NewBaseExecutor(b.ctx, nil, 0),
@@ -988,6 +1014,11 @@ func (b *executorBuilder) buildInsert(v *plannercore.Insert) exec.Executor {
 	if b.err != nil {
 		return nil
 	}
+	ivs.triggers, b.err = b.buildTriggerExecs(ivs.Table)
+	if b.err != nil {
+		return nil
+	}
+	ivs.returning = b.buildReturning(v.Returning, v.Schema())
 
 	if v.IsReplace {
 		return b.buildReplace(ivs)
@@ -1241,6 +1272,8 @@ func (b *executorBuilder) buildSelectInto(v *plannercore.SelectInto) exec.Execut
 	return &SelectIntoExec{
 		BaseExecutor:   exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID(), child),
 		intoOpt:        v.IntoOpt,
+		options:        v.Options,
+		outputNames:    v.TargetNames,
 		LineFieldsInfo: v.LineFieldsInfo,
 	}
 }
@@ -1489,7 +1522,10 @@ func (b *executorBuilder) buildHashJoin(v *plannercore.PhysicalHashJoin) exec.Ex
 	if b.err != nil {
 		return nil
 	}
+	return b.buildHashJoinFromChildExecs(v, leftExec, rightExec)
+}
 
+func (b *executorBuilder) buildHashJoinFromChildExecs(v *plannercore.PhysicalHashJoin, leftExec, rightExec exec.Executor) *join.HashJoinExec {
 	e := &join.HashJoinExec{
 		BaseExecutor:          exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID(), leftExec, rightExec),
 		ProbeSideTupleFetcher: &join.ProbeSideTupleFetcher{},
@@ -1815,6 +1851,31 @@ func (b *executorBuilder) buildTableDual(v *plannercore.PhysicalTableDual) exec.
 	return e
 }
 
+func (b *executorBuilder) buildJSONTable(v *plannercore.PhysicalJSONTable) exec.Executor {
+	return &JSONTableExec{
+		BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+		docExpr:      v.DocExpr,
+		root:         v.Root,
+		name:         v.Name,
+	}
+}
+
+func (b *executorBuilder) buildUnpivot(v *plannercore.PhysicalUnpivot) exec.Executor {
+	childExec := b.build(v.Children()[0])
+	if b.err != nil {
+		return nil
+	}
+	e := &UnpivotExec{
+		BaseExecutor:    exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID(), childExec),
+		levelEvaluators: make([]*expression.EvaluatorSuite, 0, len(v.LevelExprs)),
+	}
+	// The columns of the child are referred by every level, so they can't be swapped into the result.
+	for _, exprs := range v.LevelExprs {
+		e.levelEvaluators = append(e.levelEvaluators, expression.NewEvaluatorSuite(exprs, true))
+	}
+	return e
+}
+
 // `getSnapshotTS` returns for-update-ts if in insert/update/delete/lock statement otherwise the isolation read ts
 // Please notice that in RC isolation, the above two ts are the same
 func (b *executorBuilder) getSnapshotTS() (ts uint64, err error) {
@@ -1988,6 +2049,7 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) exec.Ex
 			strings.ToLower(infoschema.TableStatistics),
 			strings.ToLower(infoschema.TableTiDBIndexes),
 			strings.ToLower(infoschema.TableViews),
+			strings.ToLower(infoschema.TableTriggers),
 			strings.ToLower(infoschema.TableTables),
 			strings.ToLower(infoschema.TableReferConst),
 			strings.ToLower(infoschema.TableSequences),
@@ -2019,6 +2081,8 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) exec.Ex
 			strings.ToLower(infoschema.TableTrxSummary),
 			strings.ToLower(infoschema.TableVariablesInfo),
 			strings.ToLower(infoschema.TableUserAttributes),
+			strings.ToLower(infoschema.TableRoutines),
+			strings.ToLower(infoschema.TableEvents),
 			strings.ToLower(infoschema.ClusterTableTrxSummary),
 			strings.ToLower(infoschema.TableMemoryUsage),
 			strings.ToLower(infoschema.TableMemoryUsageOpsHistory),
@@ -2030,7 +2094,8 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) exec.Ex
 			strings.ToLower(infoschema.TableTiDBCheckConstraints),
 			strings.ToLower(infoschema.TableKeywords),
 			strings.ToLower(infoschema.TableTiDBIndexUsage),
-			strings.ToLower(infoschema.ClusterTableTiDBIndexUsage):
+			strings.ToLower(infoschema.ClusterTableTiDBIndexUsage),
+			strings.ToLower(infoschema.TableCardinalityCorrections):
 			memTracker := memory.NewTracker(v.ID(), -1)
 			memTracker.AttachTo(b.ctx.GetSessionVars().StmtCtx.MemTracker)
 			return &MemTableReaderExec{
@@ -2364,6 +2429,25 @@ func (b *executorBuilder) buildSplitRegion(v *plannercore.SplitRegion) exec.Exec
 
 func (b *executorBuilder) buildUpdate(v *plannercore.Update) exec.Executor {
 	b.inUpdateStmt = true
+	if b.err = b.updateForUpdateTS(); b.err != nil {
+		return nil
+	}
+
+	selExec := b.build(v.SelectPlan)
+	if b.err != nil {
+		return nil
+	}
+	base := exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID(), selExec)
+	base.SetInitCap(chunk.ZeroCapacity)
+	updateExec := b.newUpdateExec(v, base, selExec.Schema().Len())
+	if b.err != nil {
+		return nil
+	}
+	return updateExec
+}
+
+// newUpdateExec builds the UpdateExec of v on base, schemaLen is the length of the rows to update.
+func (b *executorBuilder) newUpdateExec(v *plannercore.Update, base exec.BaseExecutor, schemaLen int) *UpdateExec {
 	tblID2table := make(map[int64]table.Table, len(v.TblColPosInfos))
 	multiUpdateOnSameTable := make(map[int64]bool)
 	for _, info := range v.TblColPosInfos {
@@ -2384,18 +2468,8 @@ func (b *executorBuilder) buildUpdate(v *plannercore.Update) exec.Executor {
 			}
 		}
 	}
-	if b.err = b.updateForUpdateTS(); b.err != nil {
-		return nil
-	}
-
-	selExec := b.build(v.SelectPlan)
-	if b.err != nil {
-		return nil
-	}
-	base := exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID(), selExec)
-	base.SetInitCap(chunk.ZeroCapacity)
 	var assignFlag []int
-	assignFlag, b.err = getAssignFlag(b.ctx, v, selExec.Schema().Len())
+	assignFlag, b.err = getAssignFlag(b.ctx, v, schemaLen)
 	if b.err != nil {
 		return nil
 	}
@@ -2422,6 +2496,11 @@ func (b *executorBuilder) buildUpdate(v *plannercore.Update) exec.Executor {
 	if b.err != nil {
 		return nil
 	}
+	updateExec.triggers, b.err = b.buildTblID2TriggerExecs(tblID2table)
+	if b.err != nil {
+		return nil
+	}
+	updateExec.returning = b.buildReturning(v.Returning, v.Schema())
 	return updateExec
 }
 
@@ -2445,11 +2524,6 @@ func getAssignFlag(ctx sessionctx.Context, v *plannercore.Update, schemaLen int)
 
 func (b *executorBuilder) buildDelete(v *plannercore.Delete) exec.Executor {
 	b.inDeleteStmt = true
-	tblID2table := make(map[int64]table.Table, len(v.TblColPosInfos))
-	for _, info := range v.TblColPosInfos {
-		tblID2table[info.TblID], _ = b.is.TableByID(info.TblID)
-	}
-
 	if b.err = b.updateForUpdateTS(); b.err != nil {
 		return nil
 	}
@@ -2460,6 +2534,19 @@ func (b *executorBuilder) buildDelete(v *plannercore.Delete) exec.Executor {
 	}
 	base := exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID(), selExec)
 	base.SetInitCap(chunk.ZeroCapacity)
+	deleteExec := b.newDeleteExec(v, base)
+	if b.err != nil {
+		return nil
+	}
+	return deleteExec
+}
+
+// newDeleteExec builds the DeleteExec of v on base.
+func (b *executorBuilder) newDeleteExec(v *plannercore.Delete, base exec.BaseExecutor) *DeleteExec {
+	tblID2table := make(map[int64]table.Table, len(v.TblColPosInfos))
+	for _, info := range v.TblColPosInfos {
+		tblID2table[info.TblID], _ = b.is.TableByID(info.TblID)
+	}
 	deleteExec := &DeleteExec{
 		BaseExecutor:   base,
 		tblID2Table:    tblID2table,
@@ -2474,6 +2561,11 @@ func (b *executorBuilder) buildDelete(v *plannercore.Delete) exec.Executor {
 	if b.err != nil {
 		return nil
 	}
+	deleteExec.triggers, b.err = b.buildTblID2TriggerExecs(tblID2table)
+	if b.err != nil {
+		return nil
+	}
+	deleteExec.returning = b.buildReturning(v.Returning, v.Schema())
 	return deleteExec
 }
 
@@ -3092,6 +3184,26 @@ func (b *executorBuilder) buildIndexLookUpJoin(v *plannercore.PhysicalIndexJoin)
 
 	e.JoinResult = exec.TryNewCacheChunk(e)
 	executor_metrics.ExecutorCounterIndexLookUpJoin.Inc()
+	if threshold := b.ctx.GetSessionVars().AdaptiveJoinThreshold; v.AdaptiveHashJoin != nil && threshold > 0 {
+		return b.buildAdaptiveJoin(v, e, threshold)
+	}
+	return e
+}
+
+// buildAdaptiveJoin wraps the index lookup join with an executor which switches to the hash join when the outer side
+// produces more rows than the threshold.
+func (b *executorBuilder) buildAdaptiveJoin(v *plannercore.PhysicalIndexJoin, indexJoin *join.IndexLookUpJoin, threshold int64) exec.Executor {
+	e := join.NewAdaptiveJoinExec(b.ctx, v.ID(), indexJoin, threshold)
+	innerExec := b.build(v.AdaptiveHashJoin.Children()[v.InnerChildIdx])
+	if b.err != nil {
+		return nil
+	}
+	children := make([]exec.Executor, 2)
+	children[v.InnerChildIdx], children[1-v.InnerChildIdx] = innerExec, e.OuterSide()
+	e.HashJoin = b.buildHashJoinFromChildExecs(v.AdaptiveHashJoin, children[0], children[1])
+	if b.err != nil {
+		return nil
+	}
 	return e
 }
 
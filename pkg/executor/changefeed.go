@@ -0,0 +1,194 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/changefeed"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/tidb-binlog/memorypump"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+)
+
+func (e *SimpleExec) executeCreateChangefeed(ctx context.Context, s *ast.CreateChangefeedStmt) error {
+	// The changes are captured by the memory pump of this instance.
+	if memorypump.GetPump() == nil {
+		return exeerrors.ErrChangefeedInvalid.GenWithStackByArgs("binlog.enable-dump-source is not enabled on this TiDB instance")
+	}
+	if err := changefeed.CheckSinkURI(s.SinkURI); err != nil {
+		if dbterror.ErrNotSupportedYet.Equal(err) {
+			return err
+		}
+		return exeerrors.ErrChangefeedInvalid.GenWithStackByArgs(err.Error())
+	}
+	format, err := changefeed.CheckFormat(s.Format)
+	if err != nil {
+		return exeerrors.ErrChangefeedInvalid.GenWithStackByArgs(err.Error())
+	}
+	tables := make([]string, 0, len(s.Tables))
+	for _, tbl := range s.Tables {
+		tables = append(tables, tbl.Schema.L+"."+tbl.Name.L)
+	}
+	filterTables, err := changefeed.EncodeTables(tables)
+	if err != nil {
+		return err
+	}
+	instance, err := changefeed.InstanceAddr()
+	if err != nil {
+		return err
+	}
+	// The changes committed after the changefeed is created are captured.
+	ver, err := e.Ctx().GetStore().CurrentVersion(kv.GlobalTxnScope)
+	if err != nil {
+		return err
+	}
+
+	sysSession, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	defer e.ReleaseSysSession(ctx, sysSession)
+	sqlExecutor := sysSession.GetSQLExecutor()
+	internalCtx := kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	if _, err = sqlExecutor.ExecuteInternal(internalCtx, "BEGIN PESSIMISTIC"); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_, _ = sqlExecutor.ExecuteInternal(internalCtx, "ROLLBACK")
+		}
+	}()
+
+	exists, err := changefeedExists(internalCtx, sysSession, s.Name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		err = exeerrors.ErrChangefeedExists.GenWithStackByArgs(s.Name.O)
+		if s.IfNotExists {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(err)
+			_, err = sqlExecutor.ExecuteInternal(internalCtx, "COMMIT")
+		}
+		return err
+	}
+	_, err = sqlExecutor.ExecuteInternal(internalCtx, `INSERT INTO mysql.tidb_changefeeds (name, instance, filter_schema,
+		filter_tables, sink_uri, format, state, checkpoint_ts) VALUES (%?, %?, %?, %?, %?, %?, %?, %?)`,
+		s.Name.L, instance, s.Schema.L, filterTables, s.SinkURI, format, changefeed.StateNormal, ver.Ver)
+	if err != nil {
+		return err
+	}
+	_, err = sqlExecutor.ExecuteInternal(internalCtx, "COMMIT")
+	return err
+}
+
+func (e *SimpleExec) executeDropChangefeed(ctx context.Context, s *ast.DropChangefeedStmt) error {
+	return e.updateChangefeed(ctx, s.Name, s.IfExists, "DELETE FROM mysql.tidb_changefeeds WHERE name = %?", s.Name.L)
+}
+
+func (e *SimpleExec) executeChangefeedAction(ctx context.Context, s *ast.ChangefeedActionStmt) error {
+	if s.Tp == ast.ChangefeedPause {
+		return e.updateChangefeed(ctx, s.Name, false, "UPDATE mysql.tidb_changefeeds SET state = %? WHERE name = %?",
+			changefeed.StatePaused, s.Name.L)
+	}
+	// A failed changefeed is retried from its checkpoint when it's resumed.
+	return e.updateChangefeed(ctx, s.Name, false, "UPDATE mysql.tidb_changefeeds SET state = %?, error = NULL WHERE name = %?",
+		changefeed.StateNormal, s.Name.L)
+}
+
+// updateChangefeed executes the SQL if the changefeed exists, the running changefeeds are synced
+// with `mysql.tidb_changefeeds` by the changefeed manager.
+func (e *SimpleExec) updateChangefeed(ctx context.Context, name model.CIStr, ifExists bool, sql string, args ...any) error {
+	sysSession, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	defer e.ReleaseSysSession(ctx, sysSession)
+	sqlExecutor := sysSession.GetSQLExecutor()
+	internalCtx := kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	if _, err = sqlExecutor.ExecuteInternal(internalCtx, "BEGIN PESSIMISTIC"); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_, _ = sqlExecutor.ExecuteInternal(internalCtx, "ROLLBACK")
+		}
+	}()
+
+	exists, err := changefeedExists(internalCtx, sysSession, name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		err = exeerrors.ErrChangefeedNotExists.GenWithStackByArgs(name.O)
+		if ifExists {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(err)
+			_, err = sqlExecutor.ExecuteInternal(internalCtx, "COMMIT")
+		}
+		return err
+	}
+	if _, err = sqlExecutor.ExecuteInternal(internalCtx, sql, args...); err != nil {
+		return err
+	}
+	_, err = sqlExecutor.ExecuteInternal(internalCtx, "COMMIT")
+	return err
+}
+
+// changefeedExists checks whether the changefeed exists and locks it in the current transaction.
+// The names of the changefeeds are case-insensitive, they're saved in lower case.
+func changefeedExists(ctx context.Context, sctx sessionctx.Context, name model.CIStr) (bool, error) {
+	rs, err := sctx.GetSQLExecutor().ExecuteInternal(ctx, "SELECT 1 FROM mysql.tidb_changefeeds WHERE name = %? FOR UPDATE", name.L)
+	if err != nil {
+		return false, err
+	}
+	defer terror.Call(rs.Close)
+	rows, err := sqlexec.DrainRecordSet(ctx, rs, 1)
+	if err != nil {
+		return false, err
+	}
+	return len(rows) > 0, nil
+}
+
+func (e *ShowExec) fetchShowChangefeeds(ctx context.Context) error {
+	changefeeds, err := changefeed.LoadChangefeeds(ctx, e.Ctx().GetRestrictedSQLExecutor(), "")
+	if err != nil {
+		return err
+	}
+	for _, cf := range changefeeds {
+		filter := "*.*"
+		if len(cf.Tables) > 0 {
+			filter = strings.Join(cf.Tables, ",")
+		} else if cf.Schema != "" {
+			filter = cf.Schema + ".*"
+		}
+		created, err := types.ParseDatetime(types.DefaultStmtNoWarningContext, cf.Created)
+		if err != nil {
+			return err
+		}
+		created.SetType(mysql.TypeDatetime)
+		e.appendRow([]any{cf.Name, cf.Instance, filter, cf.SinkURI, cf.Format, cf.State, cf.CheckpointTS,
+			nullableString(cf.Error), created})
+	}
+	return nil
+}
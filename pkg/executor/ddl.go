@@ -103,7 +103,7 @@ func (e *DDLExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 			return e.createSessionTemporaryTable(s)
 		}
 	case *ast.DropTableStmt:
-		if s.IsView {
+		if s.IsView || s.IsMaterializedView {
 			break
 		}
 
@@ -160,6 +160,8 @@ func (e *DDLExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 		err = e.executeCreateTable(x)
 	case *ast.CreateViewStmt:
 		err = e.executeCreateView(ctx, x)
+	case *ast.CreateMaterializedViewStmt:
+		err = e.executeCreateMaterializedView(ctx, x)
 	case *ast.DropIndexStmt:
 		err = e.executeDropIndex(x)
 	case *ast.DropDatabaseStmt:
@@ -167,6 +169,8 @@ func (e *DDLExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 	case *ast.DropTableStmt:
 		if x.IsView {
 			err = e.executeDropView(x)
+		} else if x.IsMaterializedView {
+			err = e.executeDropMaterializedView(x)
 		} else {
 			err = e.executeDropTable(x)
 			if err == nil {
@@ -201,6 +205,10 @@ func (e *DDLExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 		err = e.executeCreateSequence(x)
 	case *ast.DropSequenceStmt:
 		err = e.executeDropSequence(x)
+	case *ast.CreateTriggerStmt:
+		err = e.executeCreateTrigger(x)
+	case *ast.DropTriggerStmt:
+		err = e.executeDropTrigger(x)
 	case *ast.AlterSequenceStmt:
 		err = e.executeAlterSequence(x)
 	case *ast.CreatePlacementPolicyStmt:
@@ -330,6 +338,13 @@ func (e *DDLExec) executeDropDatabase(s *ast.DropDatabaseStmt) error {
 	}
 
 	err := domain.GetDomain(e.Ctx()).DDL().DropSchema(e.Ctx(), s)
+	if err == nil {
+		// The stored procedures and events are dropped with the schema.
+		err = dropProceduresInSchema(context.Background(), e.Ctx(), dbName)
+	}
+	if err == nil {
+		err = dropEventsInSchema(context.Background(), e.Ctx(), dbName)
+	}
 	sessionVars := e.Ctx().GetSessionVars()
 	if err == nil && strings.ToLower(sessionVars.CurrentDB) == dbName.L {
 		sessionVars.CurrentDB = ""
@@ -51,6 +51,10 @@ type DeleteExec struct {
 	fkChecks map[int64][]*FKCheckExec
 	// fkCascades contains the foreign key cascade. the map is tableID -> []*FKCascadeExec
 	fkCascades map[int64][]*FKCascadeExec
+	// triggers contains the triggers of the deleted tables. the map is tableID -> []*TriggerExec
+	triggers map[int64][]*TriggerExec
+	// returning evaluates the RETURNING clause on the deleted rows.
+	returning *returningExec
 }
 
 // Next implements the Executor Next interface.
@@ -75,7 +79,7 @@ func (e *DeleteExec) deleteOneRow(tbl table.Table, handleCols util.HandleCols, i
 	if err != nil {
 		return err
 	}
-	return nil
+	return e.returning.appendRow(row)
 }
 
 func (e *DeleteExec) deleteSingleTableByChunk(ctx context.Context) error {
@@ -254,15 +258,21 @@ func (e *DeleteExec) removeRowsInTblRowMap(tblRowMap tableRowMapType) error {
 }
 
 func (e *DeleteExec) removeRow(ctx sessionctx.Context, t table.Table, h kv.Handle, data []types.Datum) error {
-	err := t.RemoveRecord(ctx.GetTableCtx(), h, data)
+	tid := t.Meta().ID
+	// BEFORE DELETE triggers can't execute data-modifying statements, so no context is needed.
+	err := runBeforeTriggers(context.Background(), e.triggers[tid], model.TriggerDelete, data, nil)
+	if err != nil {
+		return err
+	}
+	err = t.RemoveRecord(ctx.GetTableCtx(), h, data)
 	if err != nil {
 		return err
 	}
-	tid := t.Meta().ID
 	err = onRemoveRowForFK(ctx, data, e.fkChecks[tid], e.fkCascades[tid])
 	if err != nil {
 		return err
 	}
+	collectAfterTriggerRows(e.triggers[tid], model.TriggerDelete, data, nil)
 	ctx.GetSessionVars().StmtCtx.AddAffectedRows(1)
 	return nil
 }
@@ -287,6 +297,10 @@ func onRemoveRowForFK(ctx sessionctx.Context, data []types.Datum, fkChecks []*FK
 // Close implements the Executor Close interface.
 func (e *DeleteExec) Close() error {
 	defer e.memTracker.ReplaceBytesUsed(0)
+	// The DeleteExec of a MERGE clause has no child, the rows are fed by MergeExec.
+	if e.EmptyChildren() {
+		return nil
+	}
 	return exec.Close(e.Children(0))
 }
 
@@ -295,6 +309,9 @@ func (e *DeleteExec) Open(ctx context.Context) error {
 	e.memTracker = memory.NewTracker(e.ID(), -1)
 	e.memTracker.AttachTo(e.Ctx().GetSessionVars().StmtCtx.MemTracker)
 
+	if e.EmptyChildren() {
+		return nil
+	}
 	return exec.Open(ctx, e.Children(0))
 }
 
@@ -321,7 +338,20 @@ func (e *DeleteExec) HasFKCascades() bool {
 	return len(e.fkCascades) > 0
 }
 
+// GetAfterTriggers implements WithTrigger interface.
+func (e *DeleteExec) GetAfterTriggers() []*TriggerExec {
+	var triggers []*TriggerExec
+	for _, ts := range e.triggers {
+		triggers = append(triggers, getAfterTriggers(ts)...)
+	}
+	return triggers
+}
+
 // tableRowMapType is a map for unique (Table, Row) pair. key is the tableID.
 // the key in map[int64]Row is the joined table handle, which represent a unique reference row.
 // the value in map[int64]Row is the deleting row.
 type tableRowMapType map[int64]*kv.MemAwareHandleMap[[]types.Datum]
+
+func (e *DeleteExec) getReturning() *returningExec {
+	return e.returning
+}
@@ -0,0 +1,669 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	plannerutil "github.com/pingcap/tidb/pkg/planner/util"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"github.com/pingcap/tidb/pkg/util/stringutil"
+)
+
+// The values of the `status` and `on_completion` columns of `mysql.events`.
+const (
+	eventStatusEnabled          = "ENABLED"
+	eventStatusDisabled         = "DISABLED"
+	eventStatusSlavesideDisable = "SLAVESIDE_DISABLED"
+	eventCompletionDrop         = "DROP"
+	eventCompletionPreserve     = "PRESERVE"
+)
+
+// selectEventsSQL reads the events, the column offsets are used by eventRow.
+const selectEventsSQL = `SELECT db, name, definer, body, execute_at, interval_value, interval_field, starts, ends,
+	status, on_completion, sql_mode, time_zone, character_set_client, collation_connection, db_collation, comment,
+	created, last_altered, last_executed FROM mysql.events`
+
+// eventSchedule is the schedule of an event, the times are in the time zone of the event.
+// The zero times are NULL in `mysql.events`.
+type eventSchedule struct {
+	executeAt                    types.Time
+	intervalValue, intervalField string
+	starts, ends                 types.Time
+}
+
+// isRecurring returns whether the event is executed periodically.
+func (s *eventSchedule) isRecurring() bool {
+	return s.intervalField != ""
+}
+
+// expired returns whether the event will never be executed after now.
+func (s *eventSchedule) expired(now types.Time) bool {
+	if s.isRecurring() {
+		return !s.ends.IsZero() && s.ends.Compare(now) < 0
+	}
+	return s.executeAt.Compare(now) < 0
+}
+
+// eventRow is an event read from `mysql.events`.
+type eventRow struct {
+	db, name, definer, body      string
+	schedule                     eventSchedule
+	status, onCompletion         string
+	sqlMode, timeZone            string
+	charsetClient, collationConn string
+	dbCollation, comment         string
+	created, lastAltered         types.Time
+	lastExecuted                 types.Time
+}
+
+func newEventRow(row chunk.Row) *eventRow {
+	getTime := func(i int) types.Time {
+		if row.IsNull(i) {
+			return types.ZeroTime
+		}
+		return row.GetTime(i)
+	}
+	getString := func(i int) string {
+		if row.IsNull(i) {
+			return ""
+		}
+		return row.GetString(i)
+	}
+	return &eventRow{
+		db:      row.GetString(0),
+		name:    row.GetString(1),
+		definer: row.GetString(2),
+		body:    row.GetString(3),
+		schedule: eventSchedule{
+			executeAt:     getTime(4),
+			intervalValue: getString(5),
+			intervalField: getString(6),
+			starts:        getTime(7),
+			ends:          getTime(8),
+		},
+		status:        row.GetEnum(9).String(),
+		onCompletion:  row.GetEnum(10).String(),
+		sqlMode:       row.GetString(11),
+		timeZone:      row.GetString(12),
+		charsetClient: row.GetString(13),
+		collationConn: row.GetString(14),
+		dbCollation:   row.GetString(15),
+		comment:       row.GetString(16),
+		created:       row.GetTime(17),
+		lastAltered:   row.GetTime(18),
+		lastExecuted:  getTime(19),
+	}
+}
+
+// eventType returns the type shown by SHOW EVENTS and `information_schema.EVENTS`.
+func (r *eventRow) eventType() string {
+	if r.schedule.isRecurring() {
+		return "RECURRING"
+	}
+	return "ONE TIME"
+}
+
+// createSQL returns the CREATE EVENT statement shown by SHOW CREATE EVENT.
+func (r *eventRow) createSQL(sqlMode mysql.SQLMode) string {
+	var sb strings.Builder
+	sb.WriteString("CREATE ")
+	if idx := strings.LastIndexByte(r.definer, '@'); idx >= 0 {
+		fmt.Fprintf(&sb, "DEFINER=%s@%s ", stringutil.Escape(r.definer[:idx], sqlMode), stringutil.Escape(r.definer[idx+1:], sqlMode))
+	}
+	fmt.Fprintf(&sb, "EVENT %s ON SCHEDULE ", stringutil.Escape(r.name, sqlMode))
+	if s := &r.schedule; s.isRecurring() {
+		value := s.intervalValue
+		if strings.Trim(value, "0123456789") != "" {
+			value = "'" + value + "'"
+		}
+		fmt.Fprintf(&sb, "EVERY %s %s STARTS '%s'", value, s.intervalField, s.starts)
+		if !s.ends.IsZero() {
+			fmt.Fprintf(&sb, " ENDS '%s'", s.ends)
+		}
+	} else {
+		fmt.Fprintf(&sb, "AT '%s'", s.executeAt)
+	}
+	if r.onCompletion == eventCompletionPreserve {
+		sb.WriteString(" ON COMPLETION PRESERVE")
+	} else {
+		sb.WriteString(" ON COMPLETION NOT PRESERVE")
+	}
+	switch r.status {
+	case eventStatusEnabled:
+		sb.WriteString(" ENABLE")
+	case eventStatusDisabled:
+		sb.WriteString(" DISABLE")
+	default:
+		sb.WriteString(" DISABLE ON SLAVE")
+	}
+	if r.comment != "" {
+		fmt.Fprintf(&sb, " COMMENT '%s'", strings.ReplaceAll(r.comment, "'", "''"))
+	}
+	fmt.Fprintf(&sb, " DO %s", r.body)
+	return sb.String()
+}
+
+// loadEvents reads the events in the schema, or in all schemas if the schema is empty.
+// The events are visible to the users with the EVENT privilege on their schemas.
+func loadEvents(ctx context.Context, sctx sessionctx.Context, schema string) ([]*eventRow, error) {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	sql := selectEventsSQL
+	var args []any
+	if schema != "" {
+		sql += " WHERE db = %?"
+		args = append(args, strings.ToLower(schema))
+	}
+	rows, _, err := sctx.GetRestrictedSQLExecutor().ExecRestrictedSQL(ctx, nil, sql+" ORDER BY db, name", args...)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	events := make([]*eventRow, 0, len(rows))
+	checker := privilege.GetPrivilegeManager(sctx)
+	for _, row := range rows {
+		event := newEventRow(row)
+		if checker != nil && !checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, event.db, "", "", mysql.EventPriv) {
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// checkEventBody returns an error if the body of an event can't be executed.
+func checkEventBody(body ast.StmtNode) error {
+	switch x := body.(type) {
+	case *ast.CreateEventStmt:
+		return exeerrors.ErrEventRecursionForbidden
+	case *ast.AlterEventStmt:
+		if x.Body != nil {
+			return exeerrors.ErrEventRecursionForbidden
+		}
+	}
+	return checkProcedure(&ast.ProcedureInfo{ProcedureBody: body})
+}
+
+// evalEventSchedule evaluates the schedule of an event in the time zone of the session.
+func (e *SimpleExec) evalEventSchedule(s *ast.EventSchedule) (*eventSchedule, error) {
+	sched := &eventSchedule{}
+	var err error
+	if s.At != nil {
+		sched.executeAt, err = e.evalEventTime("AT", s.At)
+		return sched, err
+	}
+
+	switch s.Unit.Unit {
+	case ast.TimeUnitMicrosecond, ast.TimeUnitSecondMicrosecond, ast.TimeUnitMinuteMicrosecond,
+		ast.TimeUnitHourMicrosecond, ast.TimeUnitDayMicrosecond:
+		return nil, dbterror.ErrNotSupportedYet.GenWithStackByArgs("MICROSECOND")
+	}
+	d, err := plannerutil.EvalAstExprWithPlanCtx(e.Ctx().GetPlanCtx(), s.Every)
+	if err != nil {
+		return nil, err
+	}
+	if d.IsNull() {
+		return nil, exeerrors.ErrEventIntervalNotPositiveOrTooBig
+	}
+	sched.intervalValue, err = d.ToString()
+	if err != nil {
+		return nil, err
+	}
+	sched.intervalField = s.Unit.Unit.String()
+	y, m, day, nano, _, err := types.ParseDurationValue(sched.intervalField, sched.intervalValue)
+	if err != nil || y < 0 || m < 0 || day < 0 || nano < 0 || y+m+day+nano == 0 {
+		return nil, exeerrors.ErrEventIntervalNotPositiveOrTooBig
+	}
+
+	if s.Starts != nil {
+		if sched.starts, err = e.evalEventTime("STARTS", s.Starts); err != nil {
+			return nil, err
+		}
+	} else {
+		sched.starts = e.eventNow()
+	}
+	if s.Ends != nil {
+		if sched.ends, err = e.evalEventTime("ENDS", s.Ends); err != nil {
+			return nil, err
+		}
+		if sched.ends.Compare(sched.starts) < 0 {
+			return nil, exeerrors.ErrEventEndsBeforeStarts
+		}
+	}
+	return sched, nil
+}
+
+// evalEventTime evaluates a time of the schedule, the fractional seconds are truncated.
+func (e *SimpleExec) evalEventTime(clause string, expr ast.ExprNode) (types.Time, error) {
+	d, err := plannerutil.EvalAstExprWithPlanCtx(e.Ctx().GetPlanCtx(), expr)
+	if err != nil {
+		return types.ZeroTime, err
+	}
+	if !d.IsNull() {
+		tc := e.Ctx().GetSessionVars().StmtCtx.TypeCtx()
+		converted, err := d.ConvertTo(tc, types.NewFieldType(mysql.TypeDatetime))
+		if err == nil && !converted.IsNull() && !converted.GetMysqlTime().IsZero() {
+			t := converted.GetMysqlTime()
+			return types.NewTime(types.FromDate(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0), mysql.TypeDatetime, 0), nil
+		}
+	}
+	str, _ := d.ToString()
+	return types.ZeroTime, types.ErrWrongValue.GenWithStackByArgs(clause, str)
+}
+
+// eventNow returns the current time in the time zone of the session.
+func (e *SimpleExec) eventNow() types.Time {
+	now := time.Now().In(e.Ctx().GetSessionVars().Location())
+	return types.NewTime(types.FromDate(now.Year(), int(now.Month()), now.Day(), now.Hour(), now.Minute(), now.Second(), 0), mysql.TypeDatetime, 0)
+}
+
+// checkEventInThePast checks whether the event will never be executed. It returns false if the event
+// should be ignored, and disables the preserved event with a warning.
+func (e *SimpleExec) checkEventInThePast(sched *eventSchedule, onCompletion string, status *string, notPreserveErr *terror.Error) bool {
+	if !sched.expired(e.eventNow()) {
+		return true
+	}
+	stmtCtx := e.Ctx().GetSessionVars().StmtCtx
+	if onCompletion != eventCompletionPreserve {
+		stmtCtx.AppendNote(notPreserveErr)
+		return false
+	}
+	stmtCtx.AppendWarning(exeerrors.ErrEventExecTimeInThePast)
+	*status = eventStatusDisabled
+	return true
+}
+
+func eventStatusValue(status ast.EventStatus) string {
+	switch status {
+	case ast.EventStatusDisable:
+		return eventStatusDisabled
+	case ast.EventStatusDisableOnSlave:
+		return eventStatusSlavesideDisable
+	}
+	return eventStatusEnabled
+}
+
+func eventCompletionValue(completion ast.EventCompletion) string {
+	if completion == ast.EventCompletionPreserve {
+		return eventCompletionPreserve
+	}
+	return eventCompletionDrop
+}
+
+// nullableTime returns nil for a zero time, which is NULL in `mysql.events`.
+func nullableTime(t types.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+// eventTimeArg returns the argument of a time in the SQL writing `mysql.events`.
+func eventTimeArg(t types.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.String()
+}
+
+func nullableString(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func (e *SimpleExec) executeCreateEvent(ctx context.Context, s *ast.CreateEventStmt) error {
+	dbInfo, ok := e.is.SchemaByName(s.EventName.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(s.EventName.Schema.O)
+	}
+	if err := checkEventBody(s.Body); err != nil {
+		return err
+	}
+	sched, err := e.evalEventSchedule(s.Schedule)
+	if err != nil {
+		return err
+	}
+
+	sessVars := e.Ctx().GetSessionVars()
+	var definer string
+	if user := sessVars.User; user != nil {
+		definer = user.AuthUsername + "@" + user.AuthHostname
+	}
+	dbCollation := dbInfo.Collate
+	if dbCollation == "" {
+		dbCollation = mysql.DefaultCollationName
+	}
+	sysVars := make(map[string]string, 4)
+	for _, name := range []string{variable.CharacterSetClient, variable.CollationConnection, variable.SQLModeVar, variable.TimeZone} {
+		if sysVars[name], err = sessVars.GetSessionOrGlobalSystemVar(ctx, name); err != nil {
+			return err
+		}
+	}
+	var comment string
+	if s.Comment != nil {
+		comment = *s.Comment
+	}
+
+	sysSession, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	defer e.ReleaseSysSession(ctx, sysSession)
+	sqlExecutor := sysSession.GetSQLExecutor()
+	internalCtx := kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	if _, err = sqlExecutor.ExecuteInternal(internalCtx, "BEGIN PESSIMISTIC"); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_, _ = sqlExecutor.ExecuteInternal(internalCtx, "ROLLBACK")
+		}
+	}()
+
+	exists, err := eventExists(internalCtx, sysSession, s.EventName)
+	if err != nil {
+		return err
+	}
+	if exists {
+		err = exeerrors.ErrEventAlreadyExists.GenWithStackByArgs(s.EventName.Name.O)
+		if s.IfNotExists {
+			sessVars.StmtCtx.AppendNote(err)
+			_, err = sqlExecutor.ExecuteInternal(internalCtx, "COMMIT")
+		}
+		return err
+	}
+	status, onCompletion := eventStatusValue(s.Status), eventCompletionValue(s.Completion)
+	if !e.checkEventInThePast(sched, onCompletion, &status, exeerrors.ErrEventCannotCreateInThePast) {
+		// The event is dropped immediately after creation.
+		_, err = sqlExecutor.ExecuteInternal(internalCtx, "COMMIT")
+		return err
+	}
+	_, err = sqlExecutor.ExecuteInternal(internalCtx, `INSERT INTO mysql.events (db, name, definer, body, execute_at,
+		interval_value, interval_field, starts, ends, status, on_completion, sql_mode, time_zone, character_set_client,
+		collation_connection, db_collation, comment) VALUES (%?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?)`,
+		s.EventName.Schema.L, s.EventName.Name.O, definer, s.Body.Text(), eventTimeArg(sched.executeAt),
+		nullableString(sched.intervalValue), nullableString(sched.intervalField), eventTimeArg(sched.starts), eventTimeArg(sched.ends),
+		status, onCompletion, sysVars[variable.SQLModeVar], sysVars[variable.TimeZone], sysVars[variable.CharacterSetClient],
+		sysVars[variable.CollationConnection], dbCollation, comment)
+	if err != nil {
+		return err
+	}
+	_, err = sqlExecutor.ExecuteInternal(internalCtx, "COMMIT")
+	return err
+}
+
+func (e *SimpleExec) executeAlterEvent(ctx context.Context, s *ast.AlterEventStmt) error {
+	if s.Body != nil {
+		if err := checkEventBody(s.Body); err != nil {
+			return err
+		}
+	}
+	if s.NewName != nil {
+		if s.NewName.Schema.L == s.EventName.Schema.L && s.NewName.Name.L == s.EventName.Name.L {
+			return exeerrors.ErrEventSameName
+		}
+		if _, ok := e.is.SchemaByName(s.NewName.Schema); !ok {
+			return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(s.NewName.Schema.O)
+		}
+	}
+	var sched *eventSchedule
+	if s.Schedule != nil {
+		var err error
+		if sched, err = e.evalEventSchedule(s.Schedule); err != nil {
+			return err
+		}
+	}
+
+	sysSession, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	defer e.ReleaseSysSession(ctx, sysSession)
+	sqlExecutor := sysSession.GetSQLExecutor()
+	internalCtx := kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	if _, err = sqlExecutor.ExecuteInternal(internalCtx, "BEGIN PESSIMISTIC"); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_, _ = sqlExecutor.ExecuteInternal(internalCtx, "ROLLBACK")
+		}
+	}()
+
+	rs, err := sqlExecutor.ExecuteInternal(internalCtx, selectEventsSQL+" WHERE db = %? AND LOWER(name) = %? FOR UPDATE",
+		s.EventName.Schema.L, s.EventName.Name.L)
+	if err != nil {
+		return err
+	}
+	rows, err := sqlexec.DrainRecordSet(internalCtx, rs, 1)
+	terror.Call(rs.Close)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		err = exeerrors.ErrEventDoesNotExist.GenWithStackByArgs(s.EventName.Name.O)
+		return err
+	}
+	event := newEventRow(rows[0])
+
+	name := s.EventName
+	if s.NewName != nil {
+		var exists bool
+		if exists, err = eventExists(internalCtx, sysSession, s.NewName); err != nil {
+			return err
+		}
+		if exists {
+			err = exeerrors.ErrEventAlreadyExists.GenWithStackByArgs(s.NewName.Name.O)
+			return err
+		}
+		name = s.NewName
+	}
+	if s.Completion != ast.EventCompletionDefault {
+		event.onCompletion = eventCompletionValue(s.Completion)
+	}
+	if s.Status != ast.EventStatusDefault {
+		event.status = eventStatusValue(s.Status)
+	}
+	if s.Comment != nil {
+		event.comment = *s.Comment
+	}
+	if s.Body != nil {
+		event.body = s.Body.Text()
+	}
+	if sched != nil {
+		event.schedule = *sched
+		if !e.checkEventInThePast(sched, event.onCompletion, &event.status, exeerrors.ErrEventCannotAlterInThePast) {
+			// The event is not changed.
+			_, err = sqlExecutor.ExecuteInternal(internalCtx, "COMMIT")
+			return err
+		}
+		// The time zone of the schedule is changed to the time zone of the session.
+		if event.timeZone, err = e.Ctx().GetSessionVars().GetSessionOrGlobalSystemVar(ctx, variable.TimeZone); err != nil {
+			return err
+		}
+	}
+
+	_, err = sqlExecutor.ExecuteInternal(internalCtx, `UPDATE mysql.events SET db = %?, name = %?, body = %?, execute_at = %?,
+		interval_value = %?, interval_field = %?, starts = %?, ends = %?, status = %?, on_completion = %?, time_zone = %?,
+		comment = %?, last_altered = CURRENT_TIMESTAMP(6) WHERE db = %? AND name = %?`,
+		name.Schema.L, name.Name.O, event.body, eventTimeArg(event.schedule.executeAt), nullableString(event.schedule.intervalValue),
+		nullableString(event.schedule.intervalField), eventTimeArg(event.schedule.starts), eventTimeArg(event.schedule.ends),
+		event.status, event.onCompletion, event.timeZone, event.comment, event.db, event.name)
+	if err != nil {
+		return err
+	}
+	_, err = sqlExecutor.ExecuteInternal(internalCtx, "COMMIT")
+	return err
+}
+
+func (e *SimpleExec) executeDropEvent(ctx context.Context, s *ast.DropEventStmt) error {
+	sysSession, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	defer e.ReleaseSysSession(ctx, sysSession)
+	sqlExecutor := sysSession.GetSQLExecutor()
+	internalCtx := kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	if _, err = sqlExecutor.ExecuteInternal(internalCtx, "BEGIN PESSIMISTIC"); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_, _ = sqlExecutor.ExecuteInternal(internalCtx, "ROLLBACK")
+		}
+	}()
+
+	exists, err := eventExists(internalCtx, sysSession, s.EventName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		if s.IfExists {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(exeerrors.ErrSpDoesNotExist.GenWithStackByArgs("Event", s.EventName.Name.O))
+			_, err = sqlExecutor.ExecuteInternal(internalCtx, "COMMIT")
+			return err
+		}
+		err = exeerrors.ErrEventDoesNotExist.GenWithStackByArgs(s.EventName.Name.O)
+		return err
+	}
+	_, err = sqlExecutor.ExecuteInternal(internalCtx, "DELETE FROM mysql.events WHERE db = %? AND LOWER(name) = %?",
+		s.EventName.Schema.L, s.EventName.Name.L)
+	if err != nil {
+		return err
+	}
+	_, err = sqlExecutor.ExecuteInternal(internalCtx, "COMMIT")
+	return err
+}
+
+// eventExists checks whether the event exists and locks it in the current transaction.
+// The names of the events are case-insensitive.
+func eventExists(ctx context.Context, sctx sessionctx.Context, name *ast.TableName) (bool, error) {
+	rs, err := sctx.GetSQLExecutor().ExecuteInternal(ctx, "SELECT 1 FROM mysql.events WHERE db = %? AND LOWER(name) = %? FOR UPDATE",
+		name.Schema.L, name.Name.L)
+	if err != nil {
+		return false, err
+	}
+	defer terror.Call(rs.Close)
+	rows, err := sqlexec.DrainRecordSet(ctx, rs, 1)
+	if err != nil {
+		return false, err
+	}
+	return len(rows) > 0, nil
+}
+
+// dropEventsInSchema deletes the events of a dropped schema.
+func dropEventsInSchema(ctx context.Context, sctx sessionctx.Context, schema model.CIStr) error {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	_, _, err := sctx.GetRestrictedSQLExecutor().ExecRestrictedSQL(ctx, nil, "DELETE FROM mysql.events WHERE db = %?", schema.L)
+	if infoschema.ErrTableNotExists.Equal(err) {
+		// The table doesn't exist before the cluster is upgraded.
+		return nil
+	}
+	return err
+}
+
+func (e *ShowExec) fetchShowCreateEvent(ctx context.Context) error {
+	events, err := loadEvents(ctx, e.Ctx(), e.Procedure.Schema.L)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		if strings.EqualFold(event.name, e.Procedure.Name.L) {
+			sqlMode, err := mysql.GetSQLMode(event.sqlMode)
+			if err != nil {
+				return err
+			}
+			e.appendRow([]any{event.name, event.sqlMode, event.timeZone, event.createSQL(sqlMode), event.charsetClient,
+				event.collationConn, event.dbCollation})
+			return nil
+		}
+	}
+	return exeerrors.ErrEventDoesNotExist.GenWithStackByArgs(e.Procedure.Name.O)
+}
+
+func (e *ShowExec) fetchShowEvents(ctx context.Context) error {
+	events, err := loadEvents(ctx, e.Ctx(), e.DBName.L)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		s := &event.schedule
+		e.appendRow([]any{event.db, event.name, event.timeZone, event.definer, event.eventType(), nullableTime(s.executeAt),
+			nullableString(s.intervalValue), nullableString(s.intervalField), nullableTime(s.starts), nullableTime(s.ends),
+			event.status, 0, event.charsetClient, event.collationConn, event.dbCollation})
+	}
+	return nil
+}
+
+func (e *memtableRetriever) setDataForEvents(ctx context.Context, sctx sessionctx.Context) error {
+	events, err := loadEvents(ctx, sctx, "")
+	if err != nil {
+		return err
+	}
+	rows := make([][]types.Datum, 0, len(events))
+	for _, event := range events {
+		s := &event.schedule
+		onCompletion := "NOT PRESERVE"
+		if event.onCompletion == eventCompletionPreserve {
+			onCompletion = "PRESERVE"
+		}
+		rows = append(rows, types.MakeDatums(
+			infoschema.CatalogVal,            // EVENT_CATALOG
+			event.db,                         // EVENT_SCHEMA
+			event.name,                       // EVENT_NAME
+			event.definer,                    // DEFINER
+			event.timeZone,                   // TIME_ZONE
+			"SQL",                            // EVENT_BODY
+			event.body,                       // EVENT_DEFINITION
+			event.eventType(),                // EVENT_TYPE
+			nullableTime(s.executeAt),        // EXECUTE_AT
+			nullableString(s.intervalValue),  // INTERVAL_VALUE
+			nullableString(s.intervalField),  // INTERVAL_FIELD
+			event.sqlMode,                    // SQL_MODE
+			nullableTime(s.starts),           // STARTS
+			nullableTime(s.ends),             // ENDS
+			event.status,                     // STATUS
+			onCompletion,                     // ON_COMPLETION
+			event.created,                    // CREATED
+			event.lastAltered,                // LAST_ALTERED
+			nullableTime(event.lastExecuted), // LAST_EXECUTED
+			event.comment,                    // EVENT_COMMENT
+			0,                                // ORIGINATOR
+			event.charsetClient,              // CHARACTER_SET_CLIENT
+			event.collationConn,              // COLLATION_CONNECTION
+			event.dbCollation,                // DATABASE_COLLATION
+		))
+	}
+	e.rows = rows
+	return nil
+}
@@ -72,6 +72,10 @@ const (
 	DataFormatSQL = "sql"
 	// DataFormatParquet represents the data source file of IMPORT INTO is parquet.
 	DataFormatParquet = "parquet"
+	// DataFormatNDJSON represents the data source file of IMPORT INTO is newline-delimited JSON.
+	DataFormatNDJSON = "ndjson"
+	// DataFormatAvro represents the data source file of IMPORT INTO is avro.
+	DataFormatAvro = "avro"
 
 	// DefaultDiskQuota is the default disk quota for IMPORT INTO
 	DefaultDiskQuota = config.ByteSize(50 << 30) // 50GiB
@@ -147,6 +151,7 @@ var (
 
 	supportedSuffixForServerDisk = []string{
 		".csv", ".sql", ".parquet",
+		".ndjson", ".jsonl", ".avro",
 		".gz", ".gzip",
 		".zstd", ".zst",
 		".snappy",
@@ -507,7 +512,8 @@ func (e *LoadDataController) checkFieldParams() error {
 		return exeerrors.ErrLoadDataEmptyPath
 	}
 	if e.InImportInto {
-		if e.Format != DataFormatCSV && e.Format != DataFormatParquet && e.Format != DataFormatSQL {
+		if e.Format != DataFormatCSV && e.Format != DataFormatParquet && e.Format != DataFormatSQL &&
+			e.Format != DataFormatNDJSON && e.Format != DataFormatAvro {
 			return exeerrors.ErrLoadDataUnsupportedFormat.GenWithStackByArgs(e.Format)
 		}
 	} else {
@@ -870,6 +876,20 @@ func (e *LoadDataController) initFieldMappings() []string {
 	return columns
 }
 
+// fieldNames returns the names of the input fields, which are the names of
+// the columns or user variables in FieldMappings.
+func (e *LoadDataController) fieldNames() []string {
+	names := make([]string, 0, len(e.FieldMappings))
+	for _, m := range e.FieldMappings {
+		if m.Column != nil {
+			names = append(names, m.Column.Name.L)
+		} else {
+			names = append(names, strings.ToLower(m.UserVar.Name))
+		}
+	}
+	return names
+}
+
 // initLoadColumns sets columns which the input fields loaded to.
 func (e *LoadDataController) initLoadColumns(columnNames []string) error {
 	var cols []*table.Column
@@ -1146,6 +1166,10 @@ func (e *LoadDataController) getSourceType() mydump.SourceType {
 	switch e.Format {
 	case DataFormatParquet:
 		return mydump.SourceTypeParquet
+	case DataFormatNDJSON:
+		return mydump.SourceTypeNDJSON
+	case DataFormatAvro:
+		return mydump.SourceTypeAvro
 	case DataFormatDelimitedData, DataFormatCSV:
 		return mydump.SourceTypeCSV
 	default:
@@ -1224,10 +1248,27 @@ func (e *LoadDataController) GetParser(
 			reader,
 			dataFileInfo.Remote.Path,
 		)
+	case DataFormatNDJSON:
+		parser = mydump.NewNDJSONParser(
+			ctx,
+			reader,
+			LoadDataReadBlockSize,
+		)
+	case DataFormatAvro:
+		parser, err = mydump.NewAvroParser(
+			ctx,
+			reader,
+			dataFileInfo.Remote.Path,
+		)
 	}
 	if err != nil {
 		return nil, exeerrors.ErrLoadDataWrongFormatConfig.GenWithStack(err.Error())
 	}
+	if e.Format == DataFormatNDJSON || e.Format == DataFormatAvro {
+		// the fields of NDJSON and avro are named, map them to the input
+		// fields by name.
+		parser.SetColumns(e.fieldNames())
+	}
 	parser.SetLogger(litlog.Logger{Logger: logutil.Logger(ctx)})
 
 	return parser, nil
@@ -319,6 +319,29 @@ func TestProcessChunkWith(t *testing.T) {
 		require.Equal(t, verify.MakeKVChecksum(74, 2, 15625182175392723123), *checksumMap[verify.DataKVGroupID])
 	})
 
+	t.Run("ndjson file chunk", func(t *testing.T) {
+		// keys are mapped to columns by name, so it has the same KVs as the
+		// CSV file above.
+		jsonData := []byte(`{"c": 6, "a": 4, "b": 5}` + "\n" + `{"b": 8, "c": 9, "a": 7, "d": 0}` + "\n")
+		require.NoError(t, os.WriteFile(path.Join(tidbCfg.TempDir, "test.ndjson"), jsonData, 0o644))
+		chunkInfo := &checkpoints.ChunkCheckpoint{
+			FileMeta: mydump.SourceFileMeta{Type: mydump.SourceTypeNDJSON, Path: "test.ndjson"},
+			Chunk:    mydump.Chunk{EndOffset: int64(len(jsonData)), RowIDMax: 10000},
+		}
+		ti := getTableImporter(ctx, t, store, "t", path.Join(tidbCfg.TempDir, "test.ndjson"), nil)
+		defer ti.Backend().CloseEngineMgr()
+		ti.Format = importer.DataFormatNDJSON
+		kvWriter := mock.NewMockEngineWriter(ctrl)
+		kvWriter.EXPECT().AppendRows(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		progress := importer.NewProgress()
+		checksum := verify.NewKVGroupChecksumWithKeyspace(keyspace)
+		err := importer.ProcessChunkWithWriter(ctx, chunkInfo, ti, kvWriter, kvWriter, progress, zap.NewExample(), checksum)
+		require.NoError(t, err)
+		checksumMap := checksum.GetInnerChecksums()
+		require.Len(t, checksumMap, 1)
+		require.Equal(t, verify.MakeKVChecksum(74, 2, 15625182175392723123), *checksumMap[verify.DataKVGroupID])
+	})
+
 	t.Run("query chunk", func(t *testing.T) {
 		chunkInfo := &checkpoints.ChunkCheckpoint{
 			FileMeta: mydump.SourceFileMeta{Type: mydump.SourceTypeCSV, Path: "test.csv"},
@@ -56,6 +56,7 @@ import (
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/sessiontxn"
 	"github.com/pingcap/tidb/pkg/statistics"
+	"github.com/pingcap/tidb/pkg/statistics/feedback"
 	"github.com/pingcap/tidb/pkg/statistics/handle/cache"
 	"github.com/pingcap/tidb/pkg/store/helper"
 	"github.com/pingcap/tidb/pkg/table"
@@ -138,6 +139,8 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			e.setDataFromIndexes(sctx, dbs)
 		case infoschema.TableViews:
 			e.setDataFromViews(sctx, dbs)
+		case infoschema.TableTriggers:
+			e.setDataForTriggers(sctx, dbs)
 		case infoschema.TableEngines:
 			e.setDataFromEngines()
 		case infoschema.TableCharacterSets:
@@ -188,6 +191,10 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			err = e.setDataForVariablesInfo(sctx)
 		case infoschema.TableUserAttributes:
 			err = e.setDataForUserAttributes(ctx, sctx)
+		case infoschema.TableRoutines:
+			err = e.setDataForRoutines(ctx, sctx)
+		case infoschema.TableEvents:
+			err = e.setDataForEvents(ctx, sctx)
 		case infoschema.TableMemoryUsage:
 			err = e.setDataForMemoryUsage()
 		case infoschema.ClusterTableMemoryUsage:
@@ -210,6 +217,8 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			e.setDataFromIndexUsage(sctx, dbs)
 		case infoschema.ClusterTableTiDBIndexUsage:
 			err = e.setDataForClusterIndexUsage(sctx, dbs)
+		case infoschema.TableCardinalityCorrections:
+			e.setDataFromCardinalityCorrections(sctx)
 		}
 		if err != nil {
 			return nil, err
@@ -3631,6 +3640,45 @@ func (e *memtableRetriever) setDataForClusterIndexUsage(ctx sessionctx.Context,
 	return nil
 }
 
+func (e *memtableRetriever) setDataFromCardinalityCorrections(ctx sessionctx.Context) {
+	is := ctx.GetInfoSchema().(infoschema.InfoSchema)
+	checker := privilege.GetPrivilegeManager(ctx)
+	corrections := feedback.Corrections()
+	rows := make([][]types.Datum, 0, len(corrections))
+	for _, c := range corrections {
+		tbl, partDef := infoschema.FindTableByTblOrPartID(is, c.TableID)
+		if tbl == nil {
+			continue
+		}
+		db, ok := infoschema.SchemaByTable(is, tbl.Meta())
+		if !ok {
+			continue
+		}
+		if checker != nil && !checker.RequestVerification(ctx.GetSessionVars().ActiveRoles, db.Name.L, tbl.Meta().Name.L, "", mysql.AllPrivMask) {
+			continue
+		}
+		partitionName := types.Datum{}
+		partitionName.SetNull()
+		if partDef != nil {
+			partitionName = types.NewStringDatum(partDef.Name.O)
+		}
+		lastUpdateTime := types.NewTime(types.FromGoTime(c.LastUpdateTime.In(ctx.GetSessionVars().Location())), mysql.TypeDatetime, types.MaxFsp)
+		rows = append(rows, []types.Datum{
+			types.NewStringDatum(db.Name.O),
+			types.NewStringDatum(tbl.Meta().Name.O),
+			partitionName,
+			types.NewIntDatum(c.TableID),
+			types.NewStringDatum(c.Shape),
+			types.NewFloat64Datum(c.Factor),
+			types.NewIntDatum(c.ExecCount),
+			types.NewFloat64Datum(c.LastEstRows),
+			types.NewFloat64Datum(c.LastActRows),
+			types.NewTimeDatum(lastUpdateTime),
+		})
+	}
+	e.rows = rows
+}
+
 func checkRule(rule *label.Rule) (dbName, tableName string, partitionName string, err error) {
 	s := strings.Split(rule.ID, "/")
 	if len(s) < 3 {
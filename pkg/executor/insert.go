@@ -80,6 +80,11 @@ func (e *InsertExec) exec(ctx context.Context, rows [][]types.Datum) error {
 		}
 	}
 	sessVars.StmtCtx.AddRecordRows(uint64(len(rows)))
+	for _, row := range rows {
+		if err := runBeforeTriggers(ctx, e.triggers, model.TriggerInsert, nil, row); err != nil {
+			return err
+		}
+	}
 	// If you use the IGNORE keyword, duplicate-key error that occurs while executing the INSERT statement are ignored.
 	// For example, without IGNORE, a row that duplicates an existing UNIQUE index or PRIMARY KEY value in
 	// the table causes a duplicate-key error and the statement is aborted. With IGNORE, the row is discarded and no error occurs.
@@ -426,11 +431,11 @@ func (e *InsertExec) doDupRowUpdate(ctx context.Context, handle kv.Handle, oldRo
 	}
 
 	newData := e.row4Update[:len(oldRow)]
-	_, err := updateRecord(ctx, e.Ctx(), handle, oldRow, newData, assignFlag, e.Table, true, e.memTracker, e.fkChecks, e.fkCascades)
+	_, err := updateRecord(ctx, e.Ctx(), handle, oldRow, newData, assignFlag, e.Table, true, e.memTracker, e.fkChecks, e.fkCascades, e.triggers)
 	if err != nil {
 		return err
 	}
-	return nil
+	return e.returning.appendRow(newData)
 }
 
 // setMessage sets info message(ERR_INSERT_INFO) generated by INSERT statement
@@ -469,3 +474,8 @@ func (e *InsertExec) GetFKCascades() []*FKCascadeExec {
 func (e *InsertExec) HasFKCascades() bool {
 	return len(e.fkCascades) > 0
 }
+
+// GetAfterTriggers implements WithTrigger interface.
+func (e *InsertExec) GetAfterTriggers() []*TriggerExec {
+	return getAfterTriggers(e.triggers)
+}
@@ -95,6 +95,10 @@ type InsertValues struct {
 	// fkChecks contains the foreign key checkers.
 	fkChecks   []*FKCheckExec
 	fkCascades []*FKCascadeExec
+	// triggers contains the triggers of the table.
+	triggers []*TriggerExec
+	// returning evaluates the RETURNING clause on the written rows.
+	returning *returningExec
 }
 
 type defaultVal struct {
@@ -1373,6 +1377,9 @@ func (e *InsertValues) removeRow(
 		return true, nil
 	}
 
+	if err = runBeforeTriggers(ctx, e.triggers, model.TriggerDelete, oldRow, nil); err != nil {
+		return false, err
+	}
 	err = r.t.RemoveRecord(e.Ctx().GetTableCtx(), handle, oldRow)
 	if err != nil {
 		return false, err
@@ -1381,6 +1388,7 @@ func (e *InsertValues) removeRow(
 	if err != nil {
 		return false, err
 	}
+	collectAfterTriggerRows(e.triggers, model.TriggerDelete, oldRow, nil)
 	if inReplace {
 		e.Ctx().GetSessionVars().StmtCtx.AddAffectedRows(1)
 	} else {
@@ -1431,6 +1439,7 @@ func (e *InsertValues) addRecordWithAutoIDHint(
 	if e.lastInsertID != 0 {
 		vars.SetLastInsertID(e.lastInsertID)
 	}
+	collectAfterTriggerRows(e.triggers, model.TriggerInsert, nil, row)
 	if !vars.StmtCtx.BatchCheck {
 		for _, fkc := range e.fkChecks {
 			err = fkc.insertRowNeedToCheck(vars.StmtCtx, row)
@@ -1439,7 +1448,11 @@ func (e *InsertValues) addRecordWithAutoIDHint(
 			}
 		}
 	}
-	return nil
+	return e.returning.appendRow(row)
+}
+
+func (e *InsertValues) getReturning() *returningExec {
+	return e.returning
 }
 
 // CreateSession will be assigned by session package.
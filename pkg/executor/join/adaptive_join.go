@@ -0,0 +1,226 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package join
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/execdetails"
+	"github.com/pingcap/tidb/pkg/util/memory"
+)
+
+var (
+	_ exec.Executor = &AdaptiveJoinExec{}
+	_ exec.Executor = &adaptiveJoinOuterExec{}
+)
+
+// AdaptiveJoinExec starts as an IndexLookUpJoin and switches to a HashJoinExec which scans the whole inner table
+// when the outer side turns out to be large.
+//
+// The execution flow is:
+//  1. Buffer the outer rows until more than Threshold rows are read or the outer side is exhausted.
+//  2. Choose the HashJoinExec if the outer side exceeds Threshold, otherwise choose the IndexLookUpJoin.
+//  3. The chosen join reads the buffered outer rows first and then the rest rows from the outer executor.
+type AdaptiveJoinExec struct {
+	exec.BaseExecutor
+
+	// Threshold is the max number of outer rows to run the IndexLookUpJoin.
+	Threshold int64
+	// IndexJoin and HashJoin are the two candidates, both of them read the outer rows from outerSide.
+	IndexJoin *IndexLookUpJoin
+	HashJoin  *HashJoinExec
+
+	outerSide *adaptiveJoinOuterExec
+	chosen    exec.Executor
+
+	memTracker *memory.Tracker // track memory usage.
+
+	stats *adaptiveJoinRuntimeStats
+}
+
+// NewAdaptiveJoinExec creates an AdaptiveJoinExec for the index join. The outer child of the index join is replaced
+// by an executor which replays the buffered outer rows, so the HashJoinExec must be built with OuterSide as its
+// outer child.
+func NewAdaptiveJoinExec(ctx sessionctx.Context, id int, indexJoin *IndexLookUpJoin, threshold int64) *AdaptiveJoinExec {
+	outerExec := indexJoin.Children(0)
+	outerSide := &adaptiveJoinOuterExec{
+		BaseExecutor: exec.NewBaseExecutor(ctx, outerExec.Schema(), 0),
+		outerExec:    outerExec,
+	}
+	indexJoin.SetChildren(0, outerSide)
+	return &AdaptiveJoinExec{
+		BaseExecutor: exec.NewBaseExecutor(ctx, indexJoin.Schema(), id, outerExec),
+		Threshold:    threshold,
+		IndexJoin:    indexJoin,
+		outerSide:    outerSide,
+	}
+}
+
+// OuterSide returns the executor which the joins read the outer rows from.
+func (e *AdaptiveJoinExec) OuterSide() exec.Executor {
+	return e.outerSide
+}
+
+// Open implements the Executor Open interface.
+func (e *AdaptiveJoinExec) Open(ctx context.Context) error {
+	if err := e.BaseExecutor.Open(ctx); err != nil {
+		return err
+	}
+	e.memTracker = memory.NewTracker(e.ID(), -1)
+	e.memTracker.AttachTo(e.Ctx().GetSessionVars().StmtCtx.MemTracker)
+	outerExec := e.Children(0)
+	e.outerSide.buffer = chunk.NewList(exec.RetTypes(outerExec), outerExec.InitCap(), outerExec.MaxChunkSize())
+	e.outerSide.buffer.GetMemTracker().AttachTo(e.memTracker)
+	e.outerSide.chkIdx, e.outerSide.rowIdx, e.outerSide.exhausted = 0, 0, false
+	e.chosen = nil
+	if e.RuntimeStats() != nil {
+		e.stats = &adaptiveJoinRuntimeStats{threshold: e.Threshold}
+	}
+	return nil
+}
+
+// Next implements the Executor Next interface.
+// The chosen join is called directly instead of exec.Next, because it shares the runtime stats with this executor.
+func (e *AdaptiveJoinExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	if e.chosen == nil {
+		if err := e.chooseJoin(ctx); err != nil {
+			return err
+		}
+	}
+	return e.chosen.Next(ctx, req)
+}
+
+func (e *AdaptiveJoinExec) chooseJoin(ctx context.Context) error {
+	outerExec := e.Children(0)
+	buffer := e.outerSide.buffer
+	for int64(buffer.Len()) <= e.Threshold {
+		chk := exec.NewFirstChunk(outerExec)
+		if err := exec.Next(ctx, outerExec, chk); err != nil {
+			return err
+		}
+		if chk.NumRows() == 0 {
+			e.outerSide.exhausted = true
+			break
+		}
+		buffer.Add(chk)
+	}
+	e.chosen = e.IndexJoin
+	if int64(buffer.Len()) > e.Threshold {
+		e.chosen = e.HashJoin
+	}
+	if e.stats != nil {
+		e.stats.hashJoin = e.chosen == e.HashJoin
+		e.stats.outerRows = int64(buffer.Len())
+	}
+	return exec.Open(ctx, e.chosen)
+}
+
+// Close implements the Executor Close interface.
+func (e *AdaptiveJoinExec) Close() error {
+	if e.stats != nil {
+		defer e.Ctx().GetSessionVars().StmtCtx.RuntimeStatsColl.RegisterStats(e.ID(), e.stats)
+	}
+	var firstErr error
+	if e.chosen != nil {
+		firstErr = exec.Close(e.chosen)
+		e.chosen = nil
+	}
+	if e.outerSide.buffer != nil {
+		e.outerSide.buffer.Clear()
+		e.outerSide.buffer = nil
+	}
+	e.memTracker = nil
+	if err := e.BaseExecutor.Close(); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
+}
+
+// adaptiveJoinOuterExec replays the outer rows buffered by AdaptiveJoinExec and then reads the rest rows from the
+// outer executor. The outer executor is opened and closed by AdaptiveJoinExec.
+type adaptiveJoinOuterExec struct {
+	exec.BaseExecutor
+
+	outerExec exec.Executor
+	buffer    *chunk.List
+	chkIdx    int
+	rowIdx    int
+	exhausted bool
+}
+
+// Next implements the Executor Next interface.
+func (e *adaptiveJoinOuterExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	for !req.IsFull() && e.chkIdx < e.buffer.NumChunks() {
+		chk := e.buffer.GetChunk(e.chkIdx)
+		end := min(chk.NumRows(), e.rowIdx+req.RequiredRows()-req.NumRows())
+		req.Append(chk, e.rowIdx, end)
+		e.rowIdx = end
+		if e.rowIdx == chk.NumRows() {
+			e.chkIdx++
+			e.rowIdx = 0
+		}
+	}
+	if req.NumRows() > 0 || e.exhausted {
+		return nil
+	}
+	return exec.Next(ctx, e.outerExec, req)
+}
+
+type adaptiveJoinRuntimeStats struct {
+	threshold int64
+	hashJoin  bool
+	outerRows int64
+}
+
+// Tp implements the RuntimeStats interface.
+func (*adaptiveJoinRuntimeStats) Tp() int {
+	return execdetails.TpAdaptiveJoinRuntimeStats
+}
+
+func (e *adaptiveJoinRuntimeStats) String() string {
+	buf := bytes.NewBuffer(make([]byte, 0, 64))
+	buf.WriteString("adaptive:{threshold:")
+	buf.WriteString(strconv.FormatInt(e.threshold, 10))
+	if e.hashJoin {
+		buf.WriteString(", strategy:hash join, switch at:")
+		buf.WriteString(strconv.FormatInt(e.outerRows, 10))
+		buf.WriteString(" outer rows")
+	} else {
+		buf.WriteString(", strategy:index join")
+	}
+	buf.WriteString("}")
+	return buf.String()
+}
+
+func (e *adaptiveJoinRuntimeStats) Clone() execdetails.RuntimeStats {
+	newStats := *e
+	return &newStats
+}
+
+// Merge implements the RuntimeStats interface.
+// The executor may be executed several times, e.g. as the inner side of Apply, the switch to the hash join is kept.
+func (e *adaptiveJoinRuntimeStats) Merge(rs execdetails.RuntimeStats) {
+	tmp, ok := rs.(*adaptiveJoinRuntimeStats)
+	if !ok || !tmp.hashJoin {
+		return
+	}
+	e.hashJoin, e.outerRows = true, tmp.outerRows
+}
@@ -133,3 +133,55 @@ func TestIssue45716(t *testing.T) {
 	err := tk.QueryToErr("select /*+ inl_join(t2) */ * from t1 join t2 on t1.a = t2.a;")
 	tk.MustContainErrMsg(err.Error(), "test inlNewInnerPanic")
 }
+
+func TestAdaptiveIndexLookUpJoin(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t1(a int, b int)")
+	tk.MustExec("create table t2(a int primary key, b int, c int, key(b))")
+	for i := 0; i < 20; i++ {
+		tk.MustExec(fmt.Sprintf("insert into t1 values (%d, %d)", i, i%7))
+	}
+	for i := 0; i < 30; i += 2 {
+		tk.MustExec(fmt.Sprintf("insert into t2 values (%d, %d, %d)", i, i%5, i))
+	}
+	queries := []string{
+		"select /*+ INL_JOIN(t2) */ t1.a, t2.c from t1 join t2 on t1.a = t2.a",
+		"select /*+ INL_JOIN(t2) */ t1.a, t2.c from t1 left join t2 on t1.a = t2.a and t2.c > 10",
+		"select /*+ INL_JOIN(t2) */ t1.a, t2.a from t1 join t2 on t1.b = t2.b where t2.c < 20",
+		"select /*+ INL_JOIN(t2) */ t1.a, t2.a from t1 left join t2 on t1.b = t2.b and t1.a < t2.a",
+	}
+	getExecInfo := func(sql string) string {
+		for _, row := range tk.MustQuery("explain analyze " + sql).Rows() {
+			if strings.Contains(row[0].(string), "IndexJoin") {
+				return row[5].(string)
+			}
+		}
+		require.FailNow(t, "index join is not chosen", sql)
+		return ""
+	}
+	checkExecInfo := func(sql, expected string) {
+		require.Contains(t, getExecInfo(sql), expected, sql)
+	}
+	for _, sql := range queries {
+		expected := tk.MustQuery(sql).Sort().Rows()
+		tk.MustExec("set @@tidb_adaptive_join_threshold = 5")
+		tk.MustQuery(sql).Sort().Check(expected)
+		checkExecInfo(sql, "adaptive:{threshold:5, strategy:hash join, switch at:20 outer rows}")
+		tk.MustExec("set @@tidb_adaptive_join_threshold = 100")
+		tk.MustQuery(sql).Sort().Check(expected)
+		checkExecInfo(sql, "adaptive:{threshold:100, strategy:index join}")
+
+		// The dirty rows of the inner table are read by both of the joins.
+		tk.MustExec("begin")
+		tk.MustExec("insert into t2 values (1, 1, 1), (3, 3, 30)")
+		tk.MustExec("set @@tidb_adaptive_join_threshold = 0")
+		expectedInTxn := tk.MustQuery(sql).Sort().Rows()
+		tk.MustExec("set @@tidb_adaptive_join_threshold = 5")
+		tk.MustQuery(sql).Sort().Check(expectedInTxn)
+		tk.MustExec("rollback")
+		tk.MustExec("set @@tidb_adaptive_join_threshold = 0")
+		require.NotContains(t, getExecInfo(sql), "adaptive", sql)
+	}
+}
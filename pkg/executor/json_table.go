@@ -0,0 +1,201 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+)
+
+var _ exec.Executor = &JSONTableExec{}
+
+// JSONTableExec represents the JSON_TABLE table function. It extracts all the rows from
+// the JSON document when it is opened, so it's re-opened for each outer row when it
+// refers to the columns of the preceding tables.
+type JSONTableExec struct {
+	exec.BaseExecutor
+
+	docExpr expression.Expression
+	root    *plannercore.JSONTablePath
+	name    model.CIStr
+
+	rows   [][]types.Datum
+	cursor int
+}
+
+// Open implements the Executor Open interface.
+func (e *JSONTableExec) Open(context.Context) error {
+	e.rows = e.rows[:0]
+	e.cursor = 0
+	doc, isNull, err := e.docExpr.EvalJSON(e.Ctx().GetExprCtx().GetEvalCtx(), chunk.Row{})
+	if err != nil || isNull {
+		return err
+	}
+	e.rows, err = e.extractRows(e.root, doc)
+	return err
+}
+
+// Next implements the Executor Next interface.
+func (e *JSONTableExec) Next(_ context.Context, req *chunk.Chunk) error {
+	req.GrowAndReset(e.MaxChunkSize())
+	for ; e.cursor < len(e.rows) && !req.IsFull(); e.cursor++ {
+		for i := range e.rows[e.cursor] {
+			req.AppendDatum(i, &e.rows[e.cursor][i])
+		}
+	}
+	return nil
+}
+
+// Close implements the Executor Close interface.
+func (e *JSONTableExec) Close() error {
+	e.rows = nil
+	return e.BaseExecutor.Close()
+}
+
+// extractRows returns the rows produced by the path on the context item. The rows of
+// sibling nested paths are unioned, and the columns of the other siblings are NULL.
+// The values matched by a path without any row from its nested paths still produce
+// one row, whose nested columns are NULL.
+func (e *JSONTableExec) extractRows(p *plannercore.JSONTablePath, item types.BinaryJSON) ([][]types.Datum, error) {
+	var rows [][]types.Datum
+	for i, value := range item.ExtractAll(p.Path) {
+		row := make([]types.Datum, e.Schema().Len())
+		for _, col := range p.Columns {
+			d, err := e.extractColumn(col, value, i+1)
+			if err != nil {
+				return nil, err
+			}
+			row[col.Offset] = d
+		}
+		numRows := len(rows)
+		for _, nested := range p.Nested {
+			nestedRows, err := e.extractRows(nested, value)
+			if err != nil {
+				return nil, err
+			}
+			offsets := nested.ColumnOffsets()
+			for _, nestedRow := range nestedRows {
+				newRow := make([]types.Datum, len(row))
+				copy(newRow, row)
+				for _, offset := range offsets {
+					newRow[offset] = nestedRow[offset]
+				}
+				rows = append(rows, newRow)
+			}
+		}
+		if len(rows) == numRows {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func (e *JSONTableExec) extractColumn(col *plannercore.JSONTableColumn, value types.BinaryJSON, ordinality int) (types.Datum, error) {
+	ft := e.Schema().Columns[col.Offset].RetType
+	switch col.Tp {
+	case ast.JSONTableColumnOrdinality:
+		return types.NewUintDatum(uint64(ordinality)), nil
+	case ast.JSONTableColumnExists:
+		var exists int64
+		if len(value.ExtractAll(col.Path)) > 0 {
+			exists = 1
+		}
+		d, err := e.convertJSONValue(types.CreateBinaryJSON(exists), ft)
+		if err != nil {
+			return e.onError(col, err)
+		}
+		return d, nil
+	}
+
+	values := value.ExtractAll(col.Path)
+	if len(values) == 0 {
+		if col.OnEmpty == nil || col.OnEmpty.Tp == ast.JSONTableResponseNull {
+			return types.Datum{}, nil
+		}
+		if col.OnEmpty.Tp == ast.JSONTableResponseError {
+			return types.Datum{}, exeerrors.ErrMissingJSONTableValue.GenWithStackByArgs(col.Name.O)
+		}
+		d, err := e.convertJSONValue(col.DefaultOnEmpty, ft)
+		if err != nil {
+			return e.onError(col, err)
+		}
+		return d, nil
+	}
+	if len(values) > 1 {
+		return e.onError(col, exeerrors.ErrWrongJSONTableValue.GenWithStackByArgs(col.Name.O, e.name.O))
+	}
+	if ft.GetType() != mysql.TypeJSON &&
+		(values[0].TypeCode == types.JSONTypeCodeArray || values[0].TypeCode == types.JSONTypeCodeObject) {
+		return e.onError(col, exeerrors.ErrWrongJSONTableValue.GenWithStackByArgs(col.Name.O, e.name.O))
+	}
+	d, err := e.convertJSONValue(values[0], ft)
+	if err != nil {
+		return e.onError(col, err)
+	}
+	return d, nil
+}
+
+// onError applies the ON ERROR response of the column on the error.
+func (e *JSONTableExec) onError(col *plannercore.JSONTableColumn, err error) (types.Datum, error) {
+	if col.OnError == nil || col.OnError.Tp == ast.JSONTableResponseNull {
+		return types.Datum{}, nil
+	}
+	if col.OnError.Tp == ast.JSONTableResponseError {
+		return types.Datum{}, err
+	}
+	return e.convertJSONValue(col.DefaultOnError, e.Schema().Columns[col.Offset].RetType)
+}
+
+// convertJSONValue converts a JSON value to the type of the column. Any truncation is an error.
+func (e *JSONTableExec) convertJSONValue(value types.BinaryJSON, ft *types.FieldType) (types.Datum, error) {
+	if ft.GetType() == mysql.TypeJSON {
+		return types.NewJSONDatum(value), nil
+	}
+	var d types.Datum
+	switch value.TypeCode {
+	case types.JSONTypeCodeLiteral:
+		switch value.Value[0] {
+		case types.JSONLiteralNil:
+			return types.Datum{}, nil
+		case types.JSONLiteralTrue:
+			d.SetInt64(1)
+		default:
+			d.SetInt64(0)
+		}
+	case types.JSONTypeCodeInt64:
+		d.SetInt64(value.GetInt64())
+	case types.JSONTypeCodeUint64:
+		d.SetUint64(value.GetUint64())
+	case types.JSONTypeCodeFloat64:
+		d.SetFloat64(value.GetFloat64())
+	default:
+		str, err := value.Unquote()
+		if err != nil {
+			return types.Datum{}, err
+		}
+		d.SetString(str, mysql.DefaultCollationName)
+	}
+	tc := e.Ctx().GetExprCtx().GetEvalCtx().TypeCtx()
+	return d.ConvertTo(tc.WithFlags(types.StrictFlags), ft)
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
+)
+
+func (e *DDLExec) executeCreateMaterializedView(ctx context.Context, s *ast.CreateMaterializedViewStmt) error {
+	if s.IfNotExists && e.is.TableExists(s.ViewName.Schema, s.ViewName.Name) {
+		return domain.GetDomain(e.Ctx()).DDL().CreateMaterializedView(e.Ctx(), s)
+	}
+	if err := domain.GetDomain(e.Ctx()).DDL().CreateMaterializedView(e.Ctx(), s); err != nil {
+		return err
+	}
+
+	// Populate the view, so it's usable once it's created.
+	is := domain.GetDomain(e.Ctx()).InfoSchema()
+	tbl, err := is.TableByName(s.ViewName.Schema, s.ViewName.Name)
+	if err != nil {
+		return err
+	}
+	sysCtx, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	defer e.ReleaseSysSession(ctx, sysCtx)
+	return refreshMaterializedView(ctx, sysCtx, s.ViewName.Schema, tbl.Meta())
+}
+
+func (e *DDLExec) executeDropMaterializedView(s *ast.DropTableStmt) error {
+	return domain.GetDomain(e.Ctx()).DDL().DropMaterializedView(e.Ctx(), s)
+}
+
+func (e *SimpleExec) executeRefreshMaterializedView(ctx context.Context, s *ast.RefreshMaterializedViewStmt) error {
+	tbl, err := e.is.TableByName(s.ViewName.Schema, s.ViewName.Name)
+	if err != nil {
+		return err
+	}
+	if !tbl.Meta().IsMaterializedView() {
+		return dbterror.ErrWrongObject.GenWithStackByArgs(s.ViewName.Schema, s.ViewName.Name, "MATERIALIZED VIEW")
+	}
+
+	sysCtx, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	defer e.ReleaseSysSession(ctx, sysCtx)
+	return refreshMaterializedView(ctx, sysCtx, s.ViewName.Schema, tbl.Meta())
+}
+
+// refreshMaterializedView replaces all the rows of the materialized view with the result of
+// its query in one transaction, so readers never see a partially refreshed view.
+func refreshMaterializedView(ctx context.Context, sctx sessionctx.Context, schema model.CIStr, tblInfo *model.TableInfo) (err error) {
+	if tblInfo.MaterializedView == nil {
+		return infoschema.ErrTableNotExists.GenWithStackByArgs(schema.O, tblInfo.Name.O)
+	}
+	sqlExecutor := sctx.GetSQLExecutor()
+	if _, err = sqlExecutor.ExecuteInternal(ctx, "begin"); err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			_, _ = sqlExecutor.ExecuteInternal(ctx, "rollback")
+		}
+	}()
+
+	if _, err = sqlExecutor.ExecuteInternal(ctx, "DELETE FROM %n.%n", schema.O, tblInfo.Name.O); err != nil {
+		return errors.Trace(err)
+	}
+	// The query is restored from the AST, so it's passed without any argument to keep the
+	// `%` in it from being parsed as a placeholder.
+	insertSQL := sqlescape.MustEscapeSQL("INSERT INTO %n.%n ", schema.O, tblInfo.Name.O) + tblInfo.MaterializedView.Query
+	if _, err = sqlExecutor.ExecuteInternal(ctx, insertSQL); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = sqlExecutor.ExecuteInternal(ctx, "commit")
+	return errors.Trace(err)
+}
@@ -0,0 +1,301 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/kv"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/memory"
+)
+
+// MergeExec represents a MERGE executor. The rows of its child are the rows of the source left
+// joined with the target table, for every row it executes the action of the first WHEN clause
+// which matches the row.
+type MergeExec struct {
+	exec.BaseExecutor
+
+	tbl           table.Table
+	tblColPosInfo plannercore.TblColPosInfo
+	clauses       []*mergeClause
+
+	// modifiedHandles records the target rows which are updated or deleted by the statement.
+	modifiedHandles *kv.MemAwareHandleMap[struct{}]
+	memTracker      *memory.Tracker
+	drained         bool
+}
+
+// mergeClause is a WHEN clause of the MERGE statement, exactly one of the action executors is set.
+// The action executors have no child, the rows are fed by MergeExec. They are opened and closed
+// along with MergeExec.
+type mergeClause struct {
+	matched   bool
+	condition expression.Expression
+
+	update *UpdateExec
+	delete *DeleteExec
+	insert *InsertExec
+}
+
+// mergeActionExec is the executor of the action of a WHEN clause.
+type mergeActionExec interface {
+	exec.Executor
+	WithForeignKeyTrigger
+	WithTrigger
+}
+
+func (c *mergeClause) actionExec() mergeActionExec {
+	switch {
+	case c.update != nil:
+		return c.update
+	case c.delete != nil:
+		return c.delete
+	}
+	return c.insert
+}
+
+func (b *executorBuilder) buildMerge(v *plannercore.Merge) exec.Executor {
+	b.inUpdateStmt = true
+	if b.err = b.updateForUpdateTS(); b.err != nil {
+		return nil
+	}
+
+	selExec := b.build(v.SelectPlan)
+	if b.err != nil {
+		return nil
+	}
+	base := exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID(), selExec)
+	base.SetInitCap(chunk.ZeroCapacity)
+	e := &MergeExec{
+		BaseExecutor:    base,
+		tblColPosInfo:   v.TblColPosInfo,
+		modifiedHandles: kv.NewMemAwareHandleMap[struct{}](),
+	}
+	e.tbl, _ = b.is.TableByID(v.TblColPosInfo.TblID)
+	fields := exec.RetTypes(selExec)
+	for _, c := range v.Clauses {
+		clause := &mergeClause{
+			matched:   c.Matched,
+			condition: c.Condition,
+		}
+		switch {
+		case c.Update != nil:
+			clause.update = b.newUpdateExec(c.Update, exec.NewBaseExecutor(b.ctx, nil, c.Update.ID()), len(fields))
+			if b.err != nil {
+				return nil
+			}
+			clause.update.evalBuffer = chunk.MutRowFromTypes(fields)
+		case c.Delete != nil:
+			clause.delete = b.newDeleteExec(c.Delete, exec.NewBaseExecutor(b.ctx, nil, c.Delete.ID()))
+			if b.err != nil {
+				return nil
+			}
+		case c.Insert != nil:
+			insertExec := b.build(c.Insert)
+			if b.err != nil {
+				return nil
+			}
+			clause.insert = insertExec.(*InsertExec)
+		}
+		e.clauses = append(e.clauses, clause)
+	}
+	return e
+}
+
+// Open implements the Executor Open interface.
+func (e *MergeExec) Open(ctx context.Context) error {
+	e.memTracker = memory.NewTracker(e.ID(), -1)
+	e.memTracker.AttachTo(e.Ctx().GetSessionVars().StmtCtx.MemTracker)
+	if err := exec.Open(ctx, e.Children(0)); err != nil {
+		return err
+	}
+	for _, c := range e.clauses {
+		if err := exec.Open(ctx, c.actionExec()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Next implements the Executor Next interface.
+func (e *MergeExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if !e.drained {
+		if err := e.mergeRows(ctx); err != nil {
+			return err
+		}
+		e.drained = true
+	}
+	return nil
+}
+
+func (e *MergeExec) mergeRows(ctx context.Context) error {
+	fields := exec.RetTypes(e.Children(0))
+	tblID2Table := map[int64]table.Table{e.tblColPosInfo.TblID: e.tbl}
+	colsInfo := plannercore.GetUpdateColumnsInfo(tblID2Table, plannercore.TblColPosInfoSlice{e.tblColPosInfo}, len(fields))
+	chk := exec.TryNewCacheChunk(e.Children(0))
+	memUsageOfChk := int64(0)
+	rowIdx := 0
+	for {
+		e.memTracker.Consume(-memUsageOfChk)
+		err := exec.Next(ctx, e.Children(0), chk)
+		if err != nil {
+			return err
+		}
+		if chk.NumRows() == 0 {
+			break
+		}
+		memUsageOfChk = chk.MemoryUsage()
+		e.memTracker.Consume(memUsageOfChk)
+		for i := 0; i < chk.NumRows(); i++ {
+			chunkRow := chk.GetRow(i)
+			if err := e.mergeRow(ctx, rowIdx, chunkRow, chunkRow.GetDatumRow(fields), colsInfo); err != nil {
+				return err
+			}
+			rowIdx++
+		}
+		chk = chunk.Renew(chk, e.MaxChunkSize())
+	}
+	return nil
+}
+
+func (e *MergeExec) mergeRow(ctx context.Context, rowIdx int, chunkRow chunk.Row, row []types.Datum, colsInfo []*table.Column) error {
+	matched := !unmatchedOuterRow(e.tblColPosInfo, row)
+	evalCtx := e.Ctx().GetExprCtx().GetEvalCtx()
+	var clause *mergeClause
+	for _, c := range e.clauses {
+		if c.matched != matched {
+			continue
+		}
+		if c.condition != nil {
+			ok, _, err := expression.EvalBool(evalCtx, expression.CNFExprs{c.condition}, chunkRow)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+		}
+		clause = c
+		break
+	}
+	if clause == nil {
+		return nil
+	}
+
+	if clause.insert != nil {
+		ins := clause.insert
+		vals := make([]types.Datum, 0, len(ins.Lists[0]))
+		for _, expr := range ins.Lists[0] {
+			val, err := expr.Eval(evalCtx, chunkRow)
+			if err != nil {
+				return err
+			}
+			vals = append(vals, val)
+		}
+		ins.rowCount++
+		newRow, err := ins.getRow(ctx, vals)
+		if err != nil {
+			return err
+		}
+		return ins.exec(ctx, [][]types.Datum{newRow})
+	}
+
+	// A target row can be modified only once, otherwise the result depends on the order of the source rows.
+	handle, err := e.tblColPosInfo.HandleCols.BuildHandleByDatums(row)
+	if err != nil {
+		return err
+	}
+	if _, ok := e.modifiedHandles.Get(handle); ok {
+		return exeerrors.ErrMergeTargetRowMatchedTwice.GenWithStackByArgs(handle.String(), e.tbl.Meta().Name.O)
+	}
+	memDelta := e.modifiedHandles.Set(handle, struct{}{})
+	e.memTracker.Consume(memDelta + int64(handle.ExtraMemSize()))
+
+	if clause.delete != nil {
+		return clause.delete.removeRow(e.Ctx(), e.tbl, handle, row[e.tblColPosInfo.Start:e.tblColPosInfo.End])
+	}
+	upd := clause.update
+	if err := upd.prepare(row); err != nil {
+		return err
+	}
+	newRow, err := upd.composeNewRow(rowIdx, row, colsInfo)
+	if err != nil {
+		return err
+	}
+	if upd.virtualAssignmentsOffset < len(upd.OrderedList) {
+		newRow, err = upd.composeGeneratedColumns(rowIdx, newRow, colsInfo)
+		if err != nil {
+			return err
+		}
+	}
+	return upd.exec(ctx, e.Children(0).Schema(), row, newRow)
+}
+
+// Close implements the Executor Close interface.
+func (e *MergeExec) Close() error {
+	defer e.memTracker.ReplaceBytesUsed(0)
+	firstErr := exec.Close(e.Children(0))
+	for _, c := range e.clauses {
+		if err := exec.Close(c.actionExec()); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// GetFKChecks implements WithForeignKeyTrigger interface.
+func (e *MergeExec) GetFKChecks() []*FKCheckExec {
+	var fkChecks []*FKCheckExec
+	for _, c := range e.clauses {
+		fkChecks = append(fkChecks, c.actionExec().GetFKChecks()...)
+	}
+	return fkChecks
+}
+
+// GetFKCascades implements WithForeignKeyTrigger interface.
+func (e *MergeExec) GetFKCascades() []*FKCascadeExec {
+	var fkCascades []*FKCascadeExec
+	for _, c := range e.clauses {
+		fkCascades = append(fkCascades, c.actionExec().GetFKCascades()...)
+	}
+	return fkCascades
+}
+
+// HasFKCascades implements WithForeignKeyTrigger interface.
+func (e *MergeExec) HasFKCascades() bool {
+	for _, c := range e.clauses {
+		if c.actionExec().HasFKCascades() {
+			return true
+		}
+	}
+	return false
+}
+
+// GetAfterTriggers implements WithTrigger interface.
+func (e *MergeExec) GetAfterTriggers() []*TriggerExec {
+	var triggers []*TriggerExec
+	for _, c := range e.clauses {
+		triggers = append(triggers, c.actionExec().GetAfterTriggers()...)
+	}
+	return triggers
+}
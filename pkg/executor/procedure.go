@@ -0,0 +1,597 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"github.com/pingcap/tidb/pkg/util/stringutil"
+)
+
+// The types of the routines in `mysql.routines`.
+const (
+	routineTypeProcedure = "PROCEDURE"
+	routineTypeFunction  = "FUNCTION"
+)
+
+// selectRoutinesSQL reads the stored routines of a type, the column offsets are used by routineRow.
+const selectRoutinesSQL = `SELECT db, name, definer, param_list, returns, body, sql_mode, character_set_client,
+	collation_connection, db_collation, comment, created, modified, security_type FROM mysql.routines WHERE type = %?`
+
+// routineRow is a stored procedure or function read from `mysql.routines`.
+type routineRow struct {
+	tp                                          string
+	db, name, definer, paramList, body, sqlMode string
+	// returns is the return type of a function.
+	returns                            string
+	charsetClient, collationConnection string
+	dbCollation, comment               string
+	created, modified                  types.Time
+	// security is DEFINER or INVOKER.
+	security string
+}
+
+func newRoutineRow(tp string, row chunk.Row) *routineRow {
+	return &routineRow{
+		tp:                  tp,
+		db:                  row.GetString(0),
+		name:                row.GetString(1),
+		definer:             row.GetString(2),
+		paramList:           row.GetString(3),
+		returns:             row.GetString(4),
+		body:                row.GetString(5),
+		sqlMode:             row.GetString(6),
+		charsetClient:       row.GetString(7),
+		collationConnection: row.GetString(8),
+		dbCollation:         row.GetString(9),
+		comment:             row.GetString(10),
+		created:             row.GetTime(11),
+		modified:            row.GetTime(12),
+		security:            row.GetEnum(13).String(),
+	}
+}
+
+// createSQL returns the statement shown by SHOW CREATE PROCEDURE or SHOW CREATE FUNCTION.
+func (r *routineRow) createSQL(sqlMode mysql.SQLMode) string {
+	var sb strings.Builder
+	sb.WriteString("CREATE ")
+	if idx := strings.LastIndexByte(r.definer, '@'); idx >= 0 {
+		fmt.Fprintf(&sb, "DEFINER=%s@%s ", stringutil.Escape(r.definer[:idx], sqlMode), stringutil.Escape(r.definer[idx+1:], sqlMode))
+	}
+	fmt.Fprintf(&sb, "%s %s(%s)", r.tp, stringutil.Escape(r.name, sqlMode), r.paramList)
+	if r.tp == routineTypeFunction {
+		fmt.Fprintf(&sb, " RETURNS %s", r.returns)
+	}
+	fmt.Fprintf(&sb, "\n    SQL SECURITY %s\n", r.security)
+	if r.comment != "" {
+		fmt.Fprintf(&sb, "    COMMENT '%s'\n", format.OutputFormat(r.comment))
+	}
+	sb.WriteString(r.body)
+	return sb.String()
+}
+
+// loadRoutines reads the stored routines of a type in the schema, or in all schemas if the schema is empty.
+func loadRoutines(ctx context.Context, sctx sessionctx.Context, tp, schema string) ([]*routineRow, error) {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	sql := selectRoutinesSQL
+	args := []any{tp}
+	if schema != "" {
+		sql += " AND db = %?"
+		args = append(args, strings.ToLower(schema))
+	}
+	rows, _, err := sctx.GetRestrictedSQLExecutor().ExecRestrictedSQL(ctx, nil, sql+" ORDER BY db, name", args...)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	routines := make([]*routineRow, 0, len(rows))
+	checker := privilege.GetPrivilegeManager(sctx)
+	for _, row := range rows {
+		r := newRoutineRow(tp, row)
+		if checker != nil && !checker.DBIsVisible(sctx.GetSessionVars().ActiveRoles, r.db) {
+			continue
+		}
+		routines = append(routines, r)
+	}
+	return routines, nil
+}
+
+func (e *SimpleExec) executeCreateProcedure(ctx context.Context, s *ast.ProcedureInfo) error {
+	if err := checkProcedure(s); err != nil {
+		return err
+	}
+	r := &routineRow{
+		tp:        routineTypeProcedure,
+		paramList: s.ProcedureParamStr,
+		body:      s.ProcedureBody.Text(),
+		comment:   s.Comment,
+		security:  s.Security.String(),
+	}
+	return e.createRoutine(ctx, r, s.ProcedureName, s.IfNotExists, s.Definer)
+}
+
+func (e *SimpleExec) executeCreateFunction(ctx context.Context, s *ast.CreateFunctionStmt) error {
+	if err := checkFunction(s); err != nil {
+		return err
+	}
+	r := &routineRow{
+		tp:        routineTypeFunction,
+		paramList: s.ParamStr,
+		returns:   s.ReturnsStr,
+		body:      s.BodyStr,
+		comment:   s.Comment,
+		security:  s.Security.String(),
+	}
+	return e.createRoutine(ctx, r, s.FunctionName, s.IfNotExists, s.Definer)
+}
+
+// createRoutine stores the routine in `mysql.routines`, the session settings used by the routine are filled in.
+func (e *SimpleExec) createRoutine(ctx context.Context, r *routineRow, name *ast.TableName, ifNotExists bool, definer *auth.UserIdentity) error {
+	dbInfo, ok := e.is.SchemaByName(name.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(name.Schema.O)
+	}
+
+	sessVars := e.Ctx().GetSessionVars()
+	if definer != nil && !definer.CurrentUser {
+		r.definer = definer.Username + "@" + definer.Hostname
+	} else if user := sessVars.User; user != nil {
+		r.definer = user.AuthUsername + "@" + user.AuthHostname
+	}
+	r.dbCollation = dbInfo.Collate
+	if r.dbCollation == "" {
+		r.dbCollation = mysql.DefaultCollationName
+	}
+	var err error
+	if r.charsetClient, err = sessVars.GetSessionOrGlobalSystemVar(ctx, variable.CharacterSetClient); err != nil {
+		return err
+	}
+	if r.collationConnection, err = sessVars.GetSessionOrGlobalSystemVar(ctx, variable.CollationConnection); err != nil {
+		return err
+	}
+	if r.sqlMode, err = sessVars.GetSessionOrGlobalSystemVar(ctx, variable.SQLModeVar); err != nil {
+		return err
+	}
+
+	sysSession, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	defer e.ReleaseSysSession(ctx, sysSession)
+	sqlExecutor := sysSession.GetSQLExecutor()
+	internalCtx := kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	if _, err = sqlExecutor.ExecuteInternal(internalCtx, "BEGIN PESSIMISTIC"); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_, _ = sqlExecutor.ExecuteInternal(internalCtx, "ROLLBACK")
+		}
+	}()
+
+	exists, err := routineExists(internalCtx, sysSession, r.tp, name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		err = exeerrors.ErrSpAlreadyExists.GenWithStackByArgs(r.tp, name.Name.O)
+		if ifNotExists {
+			sessVars.StmtCtx.AppendNote(err)
+			_, err = sqlExecutor.ExecuteInternal(internalCtx, "COMMIT")
+		}
+		return err
+	}
+	_, err = sqlExecutor.ExecuteInternal(internalCtx, `INSERT INTO mysql.routines (db, name, type, definer, security_type, param_list, returns,
+		body, sql_mode, character_set_client, collation_connection, db_collation, comment) VALUES (%?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?)`,
+		name.Schema.L, name.Name.O, r.tp, r.definer, r.security, r.paramList, r.returns,
+		r.body, r.sqlMode, r.charsetClient, r.collationConnection, r.dbCollation, r.comment)
+	if err != nil {
+		return err
+	}
+	_, err = sqlExecutor.ExecuteInternal(internalCtx, "COMMIT")
+	return err
+}
+
+func (e *SimpleExec) executeDropProcedure(ctx context.Context, s *ast.DropProcedureStmt) error {
+	return e.dropRoutine(ctx, routineTypeProcedure, s.ProcedureName, s.IfExists)
+}
+
+func (e *SimpleExec) executeDropFunction(ctx context.Context, s *ast.DropFunctionStmt) error {
+	return e.dropRoutine(ctx, routineTypeFunction, s.FunctionName, s.IfExists)
+}
+
+func (e *SimpleExec) dropRoutine(ctx context.Context, tp string, name *ast.TableName, ifExists bool) error {
+	sysSession, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	defer e.ReleaseSysSession(ctx, sysSession)
+	sqlExecutor := sysSession.GetSQLExecutor()
+	internalCtx := kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	if _, err = sqlExecutor.ExecuteInternal(internalCtx, "BEGIN PESSIMISTIC"); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_, _ = sqlExecutor.ExecuteInternal(internalCtx, "ROLLBACK")
+		}
+	}()
+
+	exists, err := routineExists(internalCtx, sysSession, tp, name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		err = exeerrors.ErrSpDoesNotExist.GenWithStackByArgs(tp, name.Schema.O+"."+name.Name.O)
+		if ifExists {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(err)
+			_, err = sqlExecutor.ExecuteInternal(internalCtx, "COMMIT")
+		}
+		return err
+	}
+	_, err = sqlExecutor.ExecuteInternal(internalCtx, "DELETE FROM mysql.routines WHERE db = %? AND LOWER(name) = %? AND type = %?",
+		name.Schema.L, name.Name.L, tp)
+	if err != nil {
+		return err
+	}
+	_, err = sqlExecutor.ExecuteInternal(internalCtx, "COMMIT")
+	return err
+}
+
+// routineExists checks whether the routine exists and locks it in the current transaction.
+// The names of the routines are case-insensitive.
+func routineExists(ctx context.Context, sctx sessionctx.Context, tp string, name *ast.TableName) (bool, error) {
+	rs, err := sctx.GetSQLExecutor().ExecuteInternal(ctx, "SELECT 1 FROM mysql.routines WHERE db = %? AND LOWER(name) = %? AND type = %? FOR UPDATE",
+		name.Schema.L, name.Name.L, tp)
+	if err != nil {
+		return false, err
+	}
+	defer terror.Call(rs.Close)
+	rows, err := sqlexec.DrainRecordSet(ctx, rs, 1)
+	if err != nil {
+		return false, err
+	}
+	return len(rows) > 0, nil
+}
+
+// dropProceduresInSchema deletes the stored procedures and functions of a dropped schema.
+func dropProceduresInSchema(ctx context.Context, sctx sessionctx.Context, schema model.CIStr) error {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	_, _, err := sctx.GetRestrictedSQLExecutor().ExecRestrictedSQL(ctx, nil, "DELETE FROM mysql.routines WHERE db = %?", schema.L)
+	if infoschema.ErrTableNotExists.Equal(err) {
+		// The table doesn't exist before the cluster is upgraded.
+		return nil
+	}
+	return err
+}
+
+func (e *ShowExec) fetchShowCreateRoutine(ctx context.Context, tp string) error {
+	routines, err := loadRoutines(ctx, e.Ctx(), tp, e.Procedure.Schema.L)
+	if err != nil {
+		return err
+	}
+	for _, r := range routines {
+		if strings.EqualFold(r.name, e.Procedure.Name.L) {
+			sqlMode, err := mysql.GetSQLMode(r.sqlMode)
+			if err != nil {
+				return err
+			}
+			e.appendRow([]any{r.name, r.sqlMode, r.createSQL(sqlMode), r.charsetClient, r.collationConnection, r.dbCollation})
+			return nil
+		}
+	}
+	return exeerrors.ErrSpDoesNotExist.GenWithStackByArgs(tp, e.Procedure.Name.O)
+}
+
+func (e *ShowExec) fetchShowRoutineStatus(ctx context.Context, tp string) error {
+	routines, err := loadRoutines(ctx, e.Ctx(), tp, "")
+	if err != nil {
+		return err
+	}
+	for _, r := range routines {
+		e.appendRow([]any{r.db, r.name, tp, r.definer, r.modified, r.created,
+			r.security, r.comment, r.charsetClient, r.collationConnection, r.dbCollation})
+	}
+	return nil
+}
+
+func (e *memtableRetriever) setDataForRoutines(ctx context.Context, sctx sessionctx.Context) error {
+	var routines []*routineRow
+	for _, tp := range []string{routineTypeFunction, routineTypeProcedure} {
+		rs, err := loadRoutines(ctx, sctx, tp, "")
+		if err != nil {
+			return err
+		}
+		routines = append(routines, rs...)
+	}
+	rows := make([][]types.Datum, 0, len(routines))
+	for _, r := range routines {
+		// The DATA_TYPE of a function is the name of its return type, it's empty for a procedure.
+		dataType, dtdIdentifier := "", any(nil)
+		if r.tp == routineTypeFunction {
+			dataType = strings.ToLower(strings.FieldsFunc(r.returns, func(c rune) bool { return c == '(' || c == ' ' })[0])
+			dtdIdentifier = r.returns
+		}
+		rows = append(rows, types.MakeDatums(
+			r.name,                // SPECIFIC_NAME
+			infoschema.CatalogVal, // ROUTINE_CATALOG
+			r.db,                  // ROUTINE_SCHEMA
+			r.name,                // ROUTINE_NAME
+			r.tp,                  // ROUTINE_TYPE
+			dataType,              // DATA_TYPE
+			nil,                   // CHARACTER_MAXIMUM_LENGTH
+			nil,                   // CHARACTER_OCTET_LENGTH
+			nil,                   // NUMERIC_PRECISION
+			nil,                   // NUMERIC_SCALE
+			nil,                   // DATETIME_PRECISION
+			nil,                   // CHARACTER_SET_NAME
+			nil,                   // COLLATION_NAME
+			dtdIdentifier,         // DTD_IDENTIFIER
+			"SQL",                 // ROUTINE_BODY
+			r.body,                // ROUTINE_DEFINITION
+			nil,                   // EXTERNAL_NAME
+			"SQL",                 // EXTERNAL_LANGUAGE
+			"SQL",                 // PARAMETER_STYLE
+			"NO",                  // IS_DETERMINISTIC
+			"CONTAINS SQL",        // SQL_DATA_ACCESS
+			nil,                   // SQL_PATH
+			r.security,            // SECURITY_TYPE
+			r.created,             // CREATED
+			r.modified,            // LAST_ALTERED
+			r.sqlMode,             // SQL_MODE
+			r.comment,             // ROUTINE_COMMENT
+			r.definer,             // DEFINER
+			r.charsetClient,       // CHARACTER_SET_CLIENT
+			r.collationConnection, // COLLATION_CONNECTION
+			r.dbCollation,         // DATABASE_COLLATION
+		))
+	}
+	e.rows = rows
+	return nil
+}
+
+// checkFunction returns an error if the parameters of the function are duplicated, or its body contains
+// a subquery or an aggregate function. The body is inlined into the calling query as a scalar expression.
+func checkFunction(s *ast.CreateFunctionStmt) error {
+	params := make(map[string]struct{}, len(s.Params))
+	for _, param := range s.Params {
+		name := strings.ToLower(param.ParamName)
+		if _, ok := params[name]; ok {
+			return exeerrors.ErrSpDupParam.GenWithStackByArgs(param.ParamName)
+		}
+		params[name] = struct{}{}
+	}
+	checker := &functionBodyChecker{}
+	s.Body.Accept(checker)
+	return checker.err
+}
+
+type functionBodyChecker struct {
+	err error
+}
+
+func (c *functionBodyChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch in.(type) {
+	case *ast.SubqueryExpr, *ast.ExistsSubqueryExpr, *ast.CompareSubqueryExpr:
+		c.err = plannererrors.ErrNotSupportedYet.GenWithStackByArgs("subqueries in stored functions")
+		return in, true
+	case *ast.AggregateFuncExpr, *ast.WindowFuncExpr:
+		c.err = plannererrors.ErrInvalidGroupFuncUse
+		return in, true
+	}
+	return in, false
+}
+
+func (c *functionBodyChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, c.err == nil
+}
+
+// procedureChecker checks the labels, variables and cursors of a procedure when it's created.
+type procedureChecker struct {
+	labels []procedureLabel
+	// scopes are the names of the variables and cursors declared in the enclosing blocks.
+	scopes []procedureScope
+}
+
+type procedureLabel struct {
+	name   string
+	isLoop bool
+}
+
+type procedureScope struct {
+	vars    map[string]struct{}
+	cursors map[string]struct{}
+}
+
+// checkProcedure returns an error if the procedure refers to an undefined label, variable or cursor.
+func checkProcedure(s *ast.ProcedureInfo) error {
+	params := procedureScope{vars: make(map[string]struct{}, len(s.ProcedureParam))}
+	for _, param := range s.ProcedureParam {
+		name := strings.ToLower(param.ParamName)
+		if _, ok := params.vars[name]; ok {
+			return exeerrors.ErrSpDupParam.GenWithStackByArgs(param.ParamName)
+		}
+		params.vars[name] = struct{}{}
+	}
+	c := &procedureChecker{scopes: []procedureScope{params}}
+	return c.checkStmt(s.ProcedureBody)
+}
+
+func (c *procedureChecker) checkStmts(stmts []ast.StmtNode) error {
+	for _, stmt := range stmts {
+		if err := c.checkStmt(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *procedureChecker) checkStmt(stmt ast.StmtNode) error {
+	switch x := stmt.(type) {
+	case *ast.ProcedureBlock:
+		return c.checkBlock(x)
+	case *ast.ProcedureLabelBlock:
+		if x.LabelError {
+			return exeerrors.ErrSpLabelMismatch.GenWithStackByArgs(x.LabelEnd)
+		}
+		return c.withLabel(x.LabelName, false, func() error { return c.checkBlock(x.Block) })
+	case *ast.ProcedureLabelLoop:
+		if x.LabelError {
+			return exeerrors.ErrSpLabelMismatch.GenWithStackByArgs(x.LabelEnd)
+		}
+		return c.withLabel(x.LabelName, true, func() error { return c.checkStmt(x.Block) })
+	case *ast.ProcedureJump:
+		for i := len(c.labels) - 1; i >= 0; i-- {
+			if strings.EqualFold(c.labels[i].name, x.Name) && (x.IsLeave || c.labels[i].isLoop) {
+				return nil
+			}
+		}
+		keyword := "ITERATE"
+		if x.IsLeave {
+			keyword = "LEAVE"
+		}
+		return exeerrors.ErrSpLilabelMismatch.GenWithStackByArgs(keyword, x.Name)
+	case *ast.ProcedureIfInfo:
+		return c.checkStmt(x.IfBody)
+	case *ast.ProcedureIfBlock:
+		if err := c.checkStmts(x.ProcedureIfStmts); err != nil {
+			return err
+		}
+		if x.ProcedureElseStmt != nil {
+			return c.checkStmt(x.ProcedureElseStmt)
+		}
+	case *ast.ProcedureElseIfBlock:
+		return c.checkStmt(x.ProcedureIfStmt)
+	case *ast.ProcedureElseBlock:
+		return c.checkStmts(x.ProcedureIfStmts)
+	case *ast.SimpleCaseStmt:
+		for _, when := range x.WhenCases {
+			if err := c.checkStmts(when.ProcedureStmts); err != nil {
+				return err
+			}
+		}
+		return c.checkStmts(x.ElseCases)
+	case *ast.SearchCaseStmt:
+		for _, when := range x.WhenCases {
+			if err := c.checkStmts(when.ProcedureStmts); err != nil {
+				return err
+			}
+		}
+		return c.checkStmts(x.ElseCases)
+	case *ast.ProcedureWhileStmt:
+		return c.checkStmts(x.Body)
+	case *ast.ProcedureRepeatStmt:
+		return c.checkStmts(x.Body)
+	case *ast.ProcedureLoopStmt:
+		return c.checkStmts(x.Body)
+	case *ast.ProcedureOpenCur:
+		return c.checkCursor(x.CurName)
+	case *ast.ProcedureCloseCur:
+		return c.checkCursor(x.CurName)
+	case *ast.ProcedureFetchInto:
+		if err := c.checkCursor(x.CurName); err != nil {
+			return err
+		}
+		for _, name := range x.Variables {
+			if !c.varDeclared(name) {
+				return exeerrors.ErrSpUndeclaredVar.GenWithStackByArgs(name)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *procedureChecker) checkBlock(block *ast.ProcedureBlock) error {
+	scope := procedureScope{vars: make(map[string]struct{}), cursors: make(map[string]struct{})}
+	c.scopes = append(c.scopes, scope)
+	defer func() {
+		c.scopes = c.scopes[:len(c.scopes)-1]
+	}()
+	for _, decl := range block.ProcedureVars {
+		switch x := decl.(type) {
+		case *ast.ProcedureDecl:
+			for _, name := range x.DeclNames {
+				if _, ok := scope.vars[strings.ToLower(name)]; ok {
+					return exeerrors.ErrSpDupVar.GenWithStackByArgs(name)
+				}
+				scope.vars[strings.ToLower(name)] = struct{}{}
+			}
+		case *ast.ProcedureCursor:
+			if _, ok := scope.cursors[strings.ToLower(x.CurName)]; ok {
+				return exeerrors.ErrSpDupCurs.GenWithStackByArgs(x.CurName)
+			}
+			scope.cursors[strings.ToLower(x.CurName)] = struct{}{}
+		case *ast.ProcedureErrorControl:
+			// The labels of the enclosing blocks are invisible in a handler.
+			labels := c.labels
+			c.labels = nil
+			err := c.checkStmt(x.Operate)
+			c.labels = labels
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return c.checkStmts(block.ProcedureProcStmts)
+}
+
+func (c *procedureChecker) withLabel(name string, isLoop bool, check func() error) error {
+	for _, label := range c.labels {
+		if strings.EqualFold(label.name, name) {
+			return exeerrors.ErrSpLabelRedefine.GenWithStackByArgs(name)
+		}
+	}
+	c.labels = append(c.labels, procedureLabel{name: name, isLoop: isLoop})
+	defer func() {
+		c.labels = c.labels[:len(c.labels)-1]
+	}()
+	return check()
+}
+
+func (c *procedureChecker) checkCursor(name string) error {
+	for i := len(c.scopes) - 1; i >= 0; i-- {
+		if _, ok := c.scopes[i].cursors[strings.ToLower(name)]; ok {
+			return nil
+		}
+	}
+	return exeerrors.ErrSpCursorMismatch.GenWithStackByArgs(name)
+}
+
+func (c *procedureChecker) varDeclared(name string) bool {
+	for i := len(c.scopes) - 1; i >= 0; i-- {
+		if _, ok := c.scopes[i].vars[strings.ToLower(name)]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/planner/indexadvisor"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+)
+
+// RecommendIndexExec represents a `RECOMMEND INDEX` executor.
+type RecommendIndexExec struct {
+	exec.BaseExecutor
+
+	sql     string
+	options *indexadvisor.Options
+
+	recommendations []*indexadvisor.Recommendation
+	cursor          int
+	done            bool
+}
+
+// Next implements the Executor Next interface.
+func (e *RecommendIndexExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if !e.done {
+		if err := e.advise(ctx); err != nil {
+			return err
+		}
+		e.done = true
+	}
+	for ; e.cursor < len(e.recommendations) && !req.IsFull(); e.cursor++ {
+		r := e.recommendations[e.cursor]
+		req.AppendString(0, r.Schema)
+		req.AppendString(1, r.Table)
+		req.AppendString(2, r.IndexName)
+		req.AppendString(3, strings.Join(r.Columns, ","))
+		req.AppendUint64(4, r.EstIndexSize)
+		req.AppendFloat64(5, math.Round(r.EstBenefit*100)/100)
+		req.AppendString(6, strings.Join(r.Digests, ","))
+		req.AppendString(7, r.CreateIndexStmt())
+	}
+	return nil
+}
+
+func (e *RecommendIndexExec) advise(ctx context.Context) error {
+	var queries []*indexadvisor.Query
+	var err error
+	if e.sql != "" {
+		_, digest := parser.NormalizeDigest(e.sql)
+		queries = []*indexadvisor.Query{{
+			SchemaName: e.Ctx().GetSessionVars().CurrentDB,
+			Text:       e.sql,
+			Digest:     digest.String(),
+			Frequency:  1,
+		}}
+	} else {
+		queries, err = indexadvisor.LoadWorkload(ctx, e.Ctx().GetRestrictedSQLExecutor(), e.options.MaxNumQuery)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The queries are planned by a system session, since the hypothetical indexes of the session are
+	// changed during the advise.
+	sysSession, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	defer e.ReleaseSysSession(kv.WithInternalSourceType(ctx, kv.InternalTxnOthers), sysSession)
+	recommendations, warnings, err := indexadvisor.AdviseIndexes(ctx, indexadvisor.NewOptimizer(sysSession), queries, e.options)
+	if err != nil {
+		return err
+	}
+	for _, warn := range warnings {
+		e.Ctx().GetSessionVars().StmtCtx.AppendWarning(warn)
+	}
+	e.recommendations = recommendations
+	return nil
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/meta/autoid"
+	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/table/tables"
 	"github.com/pingcap/tidb/pkg/tablecodec"
@@ -151,6 +152,11 @@ func (e *ReplaceExec) exec(ctx context.Context, newRows [][]types.Datum) error {
 	 */
 
 	defer trace.StartRegion(ctx, "ReplaceExec").End()
+	for _, row := range newRows {
+		if err := runBeforeTriggers(ctx, e.triggers, model.TriggerInsert, nil, row); err != nil {
+			return err
+		}
+	}
 	// Get keys need to be checked.
 	toBeCheckedRows, err := getKeysNeedCheck(e.Ctx(), e.Table, newRows)
 	if err != nil {
@@ -230,3 +236,8 @@ func (e *ReplaceExec) GetFKCascades() []*FKCascadeExec {
 func (e *ReplaceExec) HasFKCascades() bool {
 	return len(e.fkCascades) > 0
 }
+
+// GetAfterTriggers implements WithTrigger interface.
+func (e *ReplaceExec) GetAfterTriggers() []*TriggerExec {
+	return getAfterTriggers(e.triggers)
+}
@@ -0,0 +1,92 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+)
+
+// withReturning indicates the write executor may have a RETURNING clause.
+type withReturning interface {
+	getReturning() *returningExec
+}
+
+// returningExec returns the rows of the RETURNING clause of a write statement. The write executor
+// evaluates the clause on every written row and buffers the result, the rows are returned to the
+// client after the statement is executed.
+type returningExec struct {
+	exec.BaseExecutor
+
+	exprs   []expression.Expression
+	evalRow chunk.MutRow
+	result  *chunk.List
+	chkIdx  int
+}
+
+func (b *executorBuilder) buildReturning(exprs []expression.Expression, schema *expression.Schema) *returningExec {
+	if len(exprs) == 0 {
+		return nil
+	}
+	e := &returningExec{
+		BaseExecutor: exec.NewBaseExecutor(b.ctx, schema, 0),
+		exprs:        exprs,
+	}
+	e.evalRow = chunk.MutRowFromTypes(e.RetFieldTypes())
+	e.result = chunk.NewList(e.RetFieldTypes(), e.InitCap(), e.MaxChunkSize())
+	e.result.GetMemTracker().AttachTo(b.ctx.GetSessionVars().StmtCtx.MemTracker)
+	return e
+}
+
+// appendRow evaluates the RETURNING clause on a written row, it does nothing if the statement
+// has no RETURNING clause.
+func (e *returningExec) appendRow(row []types.Datum) error {
+	if e == nil {
+		return nil
+	}
+	evalCtx := e.Ctx().GetExprCtx().GetEvalCtx()
+	input := chunk.MutRowFromDatums(row).ToRow()
+	for i, expr := range e.exprs {
+		val, err := expr.Eval(evalCtx, input)
+		if err != nil {
+			return err
+		}
+		e.evalRow.SetDatum(i, val)
+	}
+	e.result.AppendRow(e.evalRow.ToRow())
+	return nil
+}
+
+// Next implements the Executor Next interface.
+func (e *returningExec) Next(_ context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if e.chkIdx >= e.result.NumChunks() {
+		return nil
+	}
+	chk := e.result.GetChunk(e.chkIdx)
+	e.chkIdx++
+	req.Append(chk, 0, chk.NumRows())
+	return nil
+}
+
+// Close implements the Executor Close interface.
+func (e *returningExec) Close() error {
+	e.result.Clear()
+	return e.BaseExecutor.Close()
+}
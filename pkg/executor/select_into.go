@@ -18,51 +18,130 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"fmt"
 	"math"
 	"os"
+	"path"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/executor/importer"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/lightning/config"
 	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/charset"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/xitongsys/parquet-go/parquet"
+	parquettypes "github.com/xitongsys/parquet-go/types"
+	parquetwriter "github.com/xitongsys/parquet-go/writer"
 )
 
+const (
+	selectIntoCompressOption    = "compress"
+	selectIntoMaxFileSizeOption = "max_file_size"
+
+	// selectIntoTarget is used in the error messages of SELECT INTO OUTFILE.
+	selectIntoTarget = "outfile"
+	// parquetMaxRowGroupSize is the row group size of the parquet outfile,
+	// it's the same as the default value of parquet-go.
+	parquetMaxRowGroupSize = 128 * 1024 * 1024
+)
+
+// selectIntoSupportedOptions is the options supported by SELECT INTO OUTFILE,
+// the value is whether the option needs a value.
+var selectIntoSupportedOptions = map[string]bool{
+	selectIntoCompressOption:    true,
+	selectIntoMaxFileSizeOption: true,
+}
+
 // SelectIntoExec represents a SelectInto executor.
 type SelectIntoExec struct {
 	exec.BaseExecutor
 	intoOpt *ast.SelectIntoOption
+	options []*core.LoadDataOpt
+	// outputNames is the output names of the target plan, used as the parquet column names.
+	outputNames types.NameSlice
 	core.LineFieldsInfo
 
+	format      string
+	compressTp  storage.CompressType
+	compressSet bool
+	// maxFileSize is the max size of each outfile before compression, 0 means no limit.
+	maxFileSize int64
+
+	store    storage.ExternalStorage
+	isLocal  bool
+	localDir string
+	// fileStem and fileExt make up the name of the split outfiles, such as "result.000000001.csv".
+	fileName string
+	fileStem string
+	fileExt  string
+	fileIdx  int
+	// fileRows is the number of rows written to the current outfile.
+	fileRows int
+
 	lineBuf   []byte
 	realBuf   []byte
 	fieldBuf  []byte
 	escapeBuf []byte
 	enclosed  bool
+	dstFile   *outfileWriter
 	writer    *bufio.Writer
-	dstFile   *os.File
-	chk       *chunk.Chunk
-	started   bool
+
+	parquetSchema []string
+	parquetWriter *parquetwriter.CSVWriter
+
+	chk     *chunk.Chunk
+	started bool
+}
+
+// outfileWriter adapts storage.ExternalFileWriter to io.Writer, and counts the
+// bytes written to it.
+type outfileWriter struct {
+	ctx     context.Context
+	w       storage.ExternalFileWriter
+	written int64
+}
+
+// Write implements the io.Writer interface.
+func (w *outfileWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(w.ctx, p)
+	w.written += int64(n)
+	return n, err
 }
 
 // Open implements the Executor Open interface.
 func (s *SelectIntoExec) Open(ctx context.Context) error {
-	// only 'select ... into outfile' is supported now
+	if s.intoOpt.Tp == ast.SelectIntoVars {
+		s.chk = exec.TryNewCacheChunk(s.Children(0))
+		return s.BaseExecutor.Open(ctx)
+	}
+	// only 'select ... into outfile' and 'select ... into @var' are supported now
 	if s.intoOpt.Tp != ast.SelectIntoOutfile {
 		return errors.New("unsupported SelectInto type")
 	}
-
-	// MySQL-compatible behavior: allow files to be group-readable
-	f, err := os.OpenFile(s.intoOpt.FileName, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0640) // #nosec G302
-	if err != nil {
-		return errors.Trace(err)
+	if err := s.initOptions(); err != nil {
+		return err
+	}
+	if s.format == importer.DataFormatParquet {
+		s.parquetSchema = buildParquetSchema(s.Children(0).Schema().Columns, s.outputNames, s.Ctx().GetExprCtx().GetEvalCtx())
+	}
+	if err := s.initStore(ctx); err != nil {
+		return err
 	}
 	s.started = true
-	s.dstFile = f
-	s.writer = bufio.NewWriter(s.dstFile)
+	if err := s.openNextFile(ctx); err != nil {
+		return err
+	}
 	s.chk = exec.TryNewCacheChunk(s.Children(0))
 	s.lineBuf = make([]byte, 0, 1024)
 	s.fieldBuf = make([]byte, 0, 64)
@@ -70,8 +149,234 @@ func (s *SelectIntoExec) Open(ctx context.Context) error {
 	return s.BaseExecutor.Open(ctx)
 }
 
+func (s *SelectIntoExec) initOptions() error {
+	s.format = importer.DataFormatDelimitedData
+	if s.intoOpt.Format != nil {
+		s.format = strings.ToLower(*s.intoOpt.Format)
+	}
+	switch s.format {
+	case importer.DataFormatDelimitedData, importer.DataFormatCSV, importer.DataFormatParquet:
+	default:
+		return exeerrors.ErrLoadDataUnsupportedFormat.GenWithStackByArgs(s.format)
+	}
+
+	specifiedOptions := make(map[string]*core.LoadDataOpt, len(s.options))
+	for _, opt := range s.options {
+		hasValue, ok := selectIntoSupportedOptions[opt.Name]
+		if !ok {
+			return exeerrors.ErrUnknownOption.FastGenByArgs(opt.Name)
+		}
+		if hasValue && opt.Value == nil || !hasValue && opt.Value != nil {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		if _, ok = specifiedOptions[opt.Name]; ok {
+			return exeerrors.ErrDuplicateOption.FastGenByArgs(opt.Name)
+		}
+		specifiedOptions[opt.Name] = opt
+	}
+
+	evalCtx := s.Ctx().GetExprCtx().GetEvalCtx()
+	if opt, ok := specifiedOptions[selectIntoCompressOption]; ok {
+		if opt.Value.GetType(evalCtx).GetType() != mysql.TypeVarString {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		v, isNull, err := opt.Value.EvalString(evalCtx, chunk.Row{})
+		if err != nil || isNull {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		switch strings.ToLower(v) {
+		case "", "none", "no-compression":
+			s.compressTp = storage.NoCompression
+		case "gzip", "gz":
+			s.compressTp = storage.Gzip
+		case "snappy":
+			s.compressTp = storage.Snappy
+		case "zstd", "zst":
+			s.compressTp = storage.Zstd
+		default:
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		s.compressSet = true
+	}
+	if opt, ok := specifiedOptions[selectIntoMaxFileSizeOption]; ok {
+		tp := opt.Value.GetType(evalCtx)
+		switch {
+		case tp.GetType() == mysql.TypeVarString:
+			v, isNull, err := opt.Value.EvalString(evalCtx, chunk.Row{})
+			if err != nil || isNull {
+				return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+			}
+			var size config.ByteSize
+			if err = size.UnmarshalText([]byte(v)); err != nil {
+				return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+			}
+			s.maxFileSize = int64(size)
+		case tp.GetType() == mysql.TypeLonglong && !mysql.HasIsBooleanFlag(tp.GetFlag()):
+			v, isNull, err := opt.Value.EvalInt(evalCtx, chunk.Row{})
+			if err != nil || isNull {
+				return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+			}
+			s.maxFileSize = v
+		default:
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		if s.maxFileSize <= 0 {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+	}
+	return nil
+}
+
+// initStore initializes the external storage of the outfile, the file name may
+// be a local path or any URI supported by br/pkg/storage.
+func (s *SelectIntoExec) initStore(ctx context.Context) error {
+	u, err := storage.ParseRawURL(s.intoOpt.FileName)
+	if err != nil || storage.IsLocal(u) {
+		localPath := s.intoOpt.FileName
+		if err == nil && u.Scheme != "" {
+			localPath = u.Path
+		}
+		s.isLocal = true
+		s.localDir, s.fileName = filepath.Split(localPath)
+		if s.localDir == "" {
+			s.localDir = "."
+		}
+		s.store, err = storage.NewLocalStorage(s.localDir)
+		if err != nil {
+			return exeerrors.ErrLoadDataCantAccess.GenWithStackByArgs(selectIntoTarget, importer.GetMsgFromBRError(err))
+		}
+	} else {
+		s.fileName = path.Base(u.Path)
+		u.Path = path.Dir(u.Path)
+		b, err2 := storage.ParseBackendFromURL(u, nil)
+		if err2 != nil {
+			return exeerrors.ErrLoadDataInvalidURI.GenWithStackByArgs(selectIntoTarget, importer.GetMsgFromBRError(err2))
+		}
+		s.store, err = storage.NewWithDefaultOpt(ctx, b)
+		if err != nil {
+			return exeerrors.ErrLoadDataCantAccess.GenWithStackByArgs(selectIntoTarget, importer.GetMsgFromBRError(err))
+		}
+	}
+	if s.fileName == "" || s.fileName == "." || s.fileName == "/" {
+		return exeerrors.ErrLoadDataInvalidURI.GenWithStackByArgs(selectIntoTarget, "file name is empty")
+	}
+	s.fileStem, s.fileExt = s.fileName, ""
+	if idx := strings.IndexByte(s.fileName, '.'); idx > 0 {
+		s.fileStem, s.fileExt = s.fileName[:idx], s.fileName[idx:]
+	}
+	// the parquet format compresses the data pages by itself.
+	if s.format != importer.DataFormatParquet {
+		s.store = storage.WithCompression(s.store, s.compressTp, storage.DecompressConfig{})
+	}
+	return nil
+}
+
+// nextFileName returns the name of the next outfile. The name is kept as it is
+// when the output is not split by size.
+func (s *SelectIntoExec) nextFileName() string {
+	if s.maxFileSize == 0 {
+		return s.fileName
+	}
+	return fmt.Sprintf("%s.%09d%s", s.fileStem, s.fileIdx+1, s.fileExt)
+}
+
+func (s *SelectIntoExec) openNextFile(ctx context.Context) error {
+	name := s.nextFileName()
+	if s.isLocal {
+		// MySQL-compatible behavior: allow files to be group-readable
+		f, err := os.OpenFile(filepath.Join(s.localDir, name), os.O_RDWR|os.O_CREATE|os.O_EXCL, 0640) // #nosec G302
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err = f.Close(); err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		exists, err := s.store.FileExists(ctx, name)
+		if err != nil {
+			return exeerrors.ErrLoadDataCantAccess.GenWithStackByArgs(selectIntoTarget, importer.GetMsgFromBRError(err))
+		}
+		if exists {
+			return exeerrors.ErrFileExists.GenWithStackByArgs(name)
+		}
+	}
+	w, err := s.store.Create(ctx, name, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s.fileIdx++
+	s.fileRows = 0
+	s.dstFile = &outfileWriter{ctx: ctx, w: w}
+	if s.format != importer.DataFormatParquet {
+		s.writer = bufio.NewWriter(s.dstFile)
+		return nil
+	}
+	s.parquetWriter, err = parquetwriter.NewCSVWriterFromWriter(s.parquetSchema, s.dstFile, 1)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s.parquetWriter.CompressionType = parquetCompressionCodec(s.compressTp, s.compressSet)
+	if s.maxFileSize > 0 && s.maxFileSize < parquetMaxRowGroupSize {
+		s.parquetWriter.RowGroupSize = s.maxFileSize
+	}
+	return nil
+}
+
+// closeFile flushes and closes the current outfile.
+func (s *SelectIntoExec) closeFile(ctx context.Context) error {
+	if s.dstFile == nil {
+		return nil
+	}
+	var err error
+	if s.parquetWriter != nil {
+		err = s.parquetWriter.WriteStop()
+		s.parquetWriter = nil
+	} else if s.writer != nil {
+		err = s.writer.Flush()
+		s.writer = nil
+	}
+	err2 := s.dstFile.w.Close(ctx)
+	s.dstFile = nil
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(err2)
+}
+
+// currentFileSize returns the approximate size of the current outfile, the
+// data buffered in memory is also counted.
+func (s *SelectIntoExec) currentFileSize() int64 {
+	size := s.dstFile.written
+	if s.parquetWriter != nil {
+		size += s.parquetWriter.Size + s.parquetWriter.ObjsSize
+	} else {
+		size += int64(s.writer.Buffered())
+	}
+	return size
+}
+
+// prepareWrite makes sure there is an outfile to write the next row, and
+// switches to the next outfile if the current one reaches the max file size.
+func (s *SelectIntoExec) prepareWrite(ctx context.Context) error {
+	if s.dstFile != nil && s.maxFileSize > 0 && s.fileRows > 0 && s.currentFileSize() >= s.maxFileSize {
+		if err := s.closeFile(ctx); err != nil {
+			return err
+		}
+	}
+	if s.dstFile == nil {
+		if err := s.openNextFile(ctx); err != nil {
+			return err
+		}
+	}
+	s.fileRows++
+	return nil
+}
+
 // Next implements the Executor Next interface.
 func (s *SelectIntoExec) Next(ctx context.Context, _ *chunk.Chunk) error {
+	if s.intoOpt.Tp == ast.SelectIntoVars {
+		return s.assignToVars(ctx)
+	}
 	for {
 		if err := exec.Next(ctx, s.Children(0), s.chk); err != nil {
 			return err
@@ -79,9 +384,49 @@ func (s *SelectIntoExec) Next(ctx context.Context, _ *chunk.Chunk) error {
 		if s.chk.NumRows() == 0 {
 			break
 		}
-		if err := s.dumpToOutfile(); err != nil {
+		var err error
+		if s.format == importer.DataFormatParquet {
+			err = s.dumpToParquet(ctx)
+		} else {
+			err = s.dumpToOutfile(ctx)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// assignToVars stores the only row of the result in the user variables. The variables are kept
+// unchanged if the result is empty, which is the same as MySQL.
+func (s *SelectIntoExec) assignToVars(ctx context.Context) error {
+	fieldTypes := exec.RetTypes(s.Children(0))
+	var row []types.Datum
+	for {
+		if err := exec.Next(ctx, s.Children(0), s.chk); err != nil {
 			return err
 		}
+		if s.chk.NumRows() == 0 {
+			break
+		}
+		if row != nil || s.chk.NumRows() > 1 {
+			return exeerrors.ErrTooManyRows
+		}
+		row = types.CloneRow(s.chk.GetRow(0).GetDatumRow(fieldTypes))
+	}
+	sessVars := s.Ctx().GetSessionVars()
+	if row == nil {
+		sessVars.StmtCtx.AppendWarning(exeerrors.ErrSpFetchNoData)
+		return nil
+	}
+	for i, v := range s.intoOpt.Variables {
+		name := strings.ToLower(v.(*ast.VariableExpr).Name)
+		if row[i].IsNull() {
+			sessVars.UnsetUserVar(name)
+			continue
+		}
+		sessVars.SetUserVarVal(name, row[i])
+		sessVars.SetUserVarType(name, fieldTypes[i])
 	}
 	return nil
 }
@@ -121,7 +466,7 @@ func (s *SelectIntoExec) escapeField(f []byte) []byte {
 	return s.escapeBuf
 }
 
-func (s *SelectIntoExec) dumpToOutfile() error {
+func (s *SelectIntoExec) dumpToOutfile(ctx context.Context) error {
 	encloseFlag := false
 	var encloseByte byte
 	encloseOpt := false
@@ -203,6 +548,9 @@ func (s *SelectIntoExec) dumpToOutfile() error {
 			}
 		}
 		s.lineBuf = append(s.lineBuf, s.LinesTerminatedBy...)
+		if err := s.prepareWrite(ctx); err != nil {
+			return err
+		}
 		if _, err := s.writer.Write(s.lineBuf); err != nil {
 			return errors.Trace(err)
 		}
@@ -213,18 +561,207 @@ func (s *SelectIntoExec) dumpToOutfile() error {
 
 // Close implements the Executor Close interface.
 func (s *SelectIntoExec) Close() error {
+	if s.intoOpt.Tp == ast.SelectIntoVars {
+		return s.BaseExecutor.Close()
+	}
 	if !s.started {
 		return nil
 	}
-	err1 := s.writer.Flush()
-	err2 := s.dstFile.Close()
-	err3 := s.BaseExecutor.Close()
+	err1 := s.closeFile(context.Background())
+	s.store.Close()
+	err2 := s.BaseExecutor.Close()
 	if err1 != nil {
-		return errors.Trace(err1)
-	} else if err2 != nil {
-		return errors.Trace(err2)
+		return err1
+	}
+	return err2
+}
+
+func (s *SelectIntoExec) dumpToParquet(ctx context.Context) error {
+	evalCtx := s.Ctx().GetExprCtx().GetEvalCtx()
+	loc := s.Ctx().GetSessionVars().Location()
+	cols := s.Children(0).Schema().Columns
+	for i := 0; i < s.chk.NumRows(); i++ {
+		row := s.chk.GetRow(i)
+		// the parquet writer buffers the records until a page is flushed, so
+		// the record can't be reused.
+		record := make([]any, len(cols))
+		for j, col := range cols {
+			v, err := parquetValue(row, j, col.GetType(evalCtx), loc)
+			if err != nil {
+				return err
+			}
+			record[j] = v
+		}
+		if err := s.prepareWrite(ctx); err != nil {
+			return err
+		}
+		if err := s.parquetWriter.Write(record); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	s.Ctx().GetSessionVars().StmtCtx.AddAffectedRows(uint64(s.chk.NumRows()))
+	return nil
+}
+
+func parquetCompressionCodec(tp storage.CompressType, specified bool) parquet.CompressionCodec {
+	if !specified {
+		return parquet.CompressionCodec_SNAPPY
+	}
+	switch tp {
+	case storage.Gzip:
+		return parquet.CompressionCodec_GZIP
+	case storage.Snappy:
+		return parquet.CompressionCodec_SNAPPY
+	case storage.Zstd:
+		return parquet.CompressionCodec_ZSTD
+	default:
+		return parquet.CompressionCodec_UNCOMPRESSED
+	}
+}
+
+// parquetDecimalType returns whether the decimal type can be stored as the
+// parquet DECIMAL type, and its precision and scale.
+func parquetDecimalType(tp *types.FieldType) (precision, scale int, ok bool) {
+	precision, scale = tp.GetFlen(), tp.GetDecimal()
+	if precision <= 0 || precision > mysql.MaxDecimalWidth || scale < 0 || scale > mysql.MaxDecimalScale || scale > precision {
+		return 0, 0, false
+	}
+	return precision, scale, true
+}
+
+// buildParquetSchema builds the parquet-go CSV writer metadata of the outfile.
+// All the columns are nullable, and the column names are sanitized to be unique
+// case-insensitively, since parquet-go uses them as the field names.
+func buildParquetSchema(cols []*expression.Column, names types.NameSlice, evalCtx expression.EvalContext) []string {
+	md := make([]string, 0, len(cols))
+	used := make(map[string]struct{}, len(cols))
+	for i, col := range cols {
+		var name string
+		if i < len(names) {
+			name = names[i].ColName.O
+		}
+		name = strings.Map(func(r rune) rune {
+			if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+				return r
+			}
+			return '_'
+		}, name)
+		if name == "" {
+			name = fmt.Sprintf("col_%d", i+1)
+		}
+		uniqueName := name
+		for j := 1; ; j++ {
+			if _, ok := used[strings.ToLower(uniqueName)]; !ok {
+				break
+			}
+			uniqueName = fmt.Sprintf("%s_%d", name, j)
+		}
+		used[strings.ToLower(uniqueName)] = struct{}{}
+
+		tp := col.GetType(evalCtx)
+		var typeDesc string
+		switch tp.GetType() {
+		case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong, mysql.TypeYear:
+			typeDesc = "type=INT64"
+			if mysql.HasUnsignedFlag(tp.GetFlag()) {
+				typeDesc = "type=INT64, convertedtype=UINT_64"
+			}
+		case mysql.TypeBit:
+			typeDesc = "type=INT64, convertedtype=UINT_64"
+		case mysql.TypeFloat:
+			typeDesc = "type=FLOAT"
+		case mysql.TypeDouble:
+			typeDesc = "type=DOUBLE"
+		case mysql.TypeNewDecimal:
+			typeDesc = "type=BYTE_ARRAY, convertedtype=UTF8"
+			if precision, scale, ok := parquetDecimalType(tp); ok {
+				typeDesc = fmt.Sprintf("type=BYTE_ARRAY, convertedtype=DECIMAL, precision=%d, scale=%d", precision, scale)
+			}
+		case mysql.TypeDate:
+			typeDesc = "type=INT32, convertedtype=DATE"
+		case mysql.TypeDatetime, mysql.TypeTimestamp:
+			typeDesc = "type=INT64, convertedtype=TIMESTAMP_MICROS"
+		case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
+			mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+			typeDesc = "type=BYTE_ARRAY, convertedtype=UTF8"
+			if tp.GetCharset() == charset.CharsetBin {
+				typeDesc = "type=BYTE_ARRAY"
+			}
+		default:
+			typeDesc = "type=BYTE_ARRAY, convertedtype=UTF8"
+		}
+		md = append(md, fmt.Sprintf("name=%s, %s, repetitiontype=OPTIONAL", uniqueName, typeDesc))
+	}
+	return md
+}
+
+// parquetValue converts the value of the row to the go type accepted by the
+// parquet-go CSV writer, it must be consistent with buildParquetSchema.
+func parquetValue(row chunk.Row, idx int, tp *types.FieldType, loc *time.Location) (any, error) {
+	if row.IsNull(idx) {
+		return nil, nil
+	}
+	switch tp.GetType() {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong, mysql.TypeYear:
+		return row.GetInt64(idx), nil
+	case mysql.TypeBit:
+		var v uint64
+		for _, b := range row.GetBytes(idx) {
+			v = v<<8 | uint64(b)
+		}
+		return int64(v), nil
+	case mysql.TypeFloat:
+		return row.GetFloat32(idx), nil
+	case mysql.TypeDouble:
+		return row.GetFloat64(idx), nil
+	case mysql.TypeNewDecimal:
+		d := row.GetMyDecimal(idx)
+		_, scale, ok := parquetDecimalType(tp)
+		if !ok {
+			return d.String(), nil
+		}
+		var rounded types.MyDecimal
+		if err := d.Round(&rounded, scale, types.ModeHalfUp); err != nil {
+			return nil, errors.Trace(err)
+		}
+		unscaled := strings.Replace(rounded.String(), ".", "", 1)
+		return parquettypes.StrIntToBinary(unscaled, "BigEndian", 0, true), nil
+	case mysql.TypeDate:
+		t, err := row.GetTime(idx).GoTime(time.UTC)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		days := t.Unix() / 86400
+		if t.Unix()%86400 < 0 {
+			days--
+		}
+		return int32(days), nil
+	case mysql.TypeDatetime, mysql.TypeTimestamp:
+		// the datetime is stored as it is in UTC, and the timestamp is converted
+		// from the session time zone.
+		tzLoc := time.UTC
+		if tp.GetType() == mysql.TypeTimestamp {
+			tzLoc = loc
+		}
+		t, err := row.GetTime(idx).GoTime(tzLoc)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return t.UnixMicro(), nil
+	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
+		mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		return string(row.GetBytes(idx)), nil
+	case mysql.TypeDuration:
+		return row.GetDuration(idx, tp.GetDecimal()).String(), nil
+	case mysql.TypeEnum:
+		return row.GetEnum(idx).String(), nil
+	case mysql.TypeSet:
+		return row.GetSet(idx).String(), nil
+	case mysql.TypeJSON:
+		return row.GetJSON(idx).String(), nil
 	}
-	return err3
+	d := row.GetDatum(idx, tp)
+	return d.ToString()
 }
 
 const (
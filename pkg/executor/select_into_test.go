@@ -15,14 +15,20 @@
 package executor_test
 
 import (
+	"compress/gzip"
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tidb/pkg/executor"
+	"github.com/pingcap/tidb/pkg/lightning/mydump"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/testkit"
 	"github.com/pingcap/tidb/pkg/types"
@@ -302,3 +308,110 @@ func TestYearType(t *testing.T) {
 	tk.MustExec(fmt.Sprintf("select * from t into outfile '%v' fields terminated by ',' optionally enclosed by '\"' lines terminated by '\\n';", outfile))
 	cmpAndRm("2010\n2011\n2012\n2030\n", outfile, t)
 }
+
+func TestSelectIntoOutfileCompressAndSplit(t *testing.T) {
+	dir := t.TempDir()
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b varchar(10))")
+	tk.MustExec("insert into t values (1, 'a'), (2, 'b'), (3, 'c'), (4, 'd'), (5, 'e')")
+
+	// compress the outfile
+	outfile := filepath.Join(dir, "compressed.csv.gz")
+	tk.MustExec(fmt.Sprintf("select * from t order by a into outfile %q format 'csv' fields terminated by ',' with compress='gzip'", outfile))
+	f, err := os.Open(outfile)
+	require.NoError(t, err)
+	gzReader, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	content, err := io.ReadAll(gzReader)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	require.Equal(t, "1,a\n2,b\n3,c\n4,d\n5,e\n", string(content))
+
+	// split the outfile by size
+	outfile = filepath.Join(dir, "split.txt")
+	tk.MustExec(fmt.Sprintf("select * from t order by a into outfile %q with max_file_size=8", outfile))
+	require.Equal(t, uint64(5), tk.Session().GetSessionVars().StmtCtx.AffectedRows())
+	require.NoFileExists(t, outfile)
+	cmpAndRm("1\ta\n2\tb\n", filepath.Join(dir, "split.000000001.txt"), t)
+	cmpAndRm("3\tc\n4\td\n", filepath.Join(dir, "split.000000002.txt"), t)
+	cmpAndRm("5\te\n", filepath.Join(dir, "split.000000003.txt"), t)
+	tk.MustExec(fmt.Sprintf("select * from t order by a into outfile 'file://%s' with max_file_size='1KiB'", outfile))
+	cmpAndRm("1\ta\n2\tb\n3\tc\n4\td\n5\te\n", filepath.Join(dir, "split.000000001.txt"), t)
+	require.NoFileExists(t, filepath.Join(dir, "split.000000002.txt"))
+
+	// write to the external storage
+	tk.MustExec("select * from t into outfile 'noop://bucket/prefix/result.csv' format 'csv' with compress='zstd'")
+	require.Equal(t, uint64(5), tk.Session().GetSessionVars().StmtCtx.AffectedRows())
+
+	outfile = filepath.Join(dir, "error.txt")
+	tk.MustGetErrMsg(fmt.Sprintf("select * from t into outfile %q format 'sql'", outfile),
+		"[executor:8157]The FORMAT 'sql' is not supported")
+	tk.MustGetErrMsg(fmt.Sprintf("select * from t into outfile %q with xx=1", outfile),
+		"[executor:8163]Unknown option xx")
+	tk.MustGetErrMsg(fmt.Sprintf("select * from t into outfile %q with compress='lz4'", outfile),
+		"[executor:8164]Invalid option value for compress")
+	tk.MustGetErrMsg(fmt.Sprintf("select * from t into outfile %q with max_file_size=0", outfile),
+		"[executor:8164]Invalid option value for max_file_size")
+	tk.MustGetErrMsg(fmt.Sprintf("select * from t into outfile %q with max_file_size='1xx'", outfile),
+		"[executor:8164]Invalid option value for max_file_size")
+	tk.MustGetErrMsg(fmt.Sprintf("select * from t into outfile %q with max_file_size=1, max_file_size=2", outfile),
+		"[executor:8165]Option max_file_size specified more than once")
+	require.NoFileExists(t, outfile)
+}
+
+func TestSelectIntoOutfileParquet(t *testing.T) {
+	dir := t.TempDir()
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(id bigint, `Name` varchar(20), price decimal(10, 2), d date, dt datetime, f double, u bigint unsigned, e enum('x', 'y'))")
+	tk.MustExec(`insert into t values
+		(1, 'apple', 12.34, '2024-01-02', '2024-01-02 03:04:05', 1.5, 18446744073709551615, 'x'),
+		(2, null, -0.5, '1969-12-31', null, null, 0, 'y')`)
+
+	outfile := filepath.Join(dir, "result.parquet")
+	tk.MustExec(fmt.Sprintf("select *, id + 1 as `name` from t order by id into outfile %q format 'parquet' with compress='gzip'", outfile))
+
+	localStore, err := storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+	r, err := localStore.Open(context.Background(), "result.parquet", nil)
+	require.NoError(t, err)
+	parser, err := mydump.NewParquetParser(context.Background(), localStore, r, "result.parquet")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, parser.Close())
+	}()
+	require.Equal(t, []string{"id", "name", "price", "d", "dt", "f", "u", "e", "name_1"}, parser.Columns())
+
+	rows := make([][]string, 0, 2)
+	for {
+		err = parser.ReadRow()
+		if errors.Cause(err) == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		row := make([]string, 0, len(parser.LastRow().Row))
+		for _, d := range parser.LastRow().Row {
+			if d.IsNull() {
+				row = append(row, "<nil>")
+				continue
+			}
+			s, err := d.ToString()
+			require.NoError(t, err)
+			row = append(row, s)
+		}
+		rows = append(rows, row)
+	}
+	require.Equal(t, [][]string{
+		{"1", "apple", "12.34", "2024-01-02", "2024-01-02 03:04:05", "1.5", "18446744073709551615", "x", "2"},
+		{"2", "<nil>", "-0.50", "1969-12-31", "<nil>", "<nil>", "0", "y", "3"},
+	}, rows)
+
+	// the parquet outfile can also be split by size
+	tk.MustExec(fmt.Sprintf("select * from t order by id into outfile %q format 'parquet' with max_file_size=1", filepath.Join(dir, "split.parquet")))
+	require.FileExists(t, filepath.Join(dir, "split.000000001.parquet"))
+	require.FileExists(t, filepath.Join(dir, "split.000000002.parquet"))
+	require.NoFileExists(t, filepath.Join(dir, "split.000000003.parquet"))
+}
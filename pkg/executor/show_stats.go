@@ -94,6 +94,11 @@ func (e *ShowExec) appendTableForStatsExtended(dbName string, tbl *model.TableIn
 		case ast.StatsTypeCardinality:
 			statsType = "cardinality"
 			statsVal = item.StringVals
+		default:
+			if statistics.IsMultiColumnStatsType(item.Tp) {
+				statsType = statistics.MultiColumnStatsKinds(item.Tp)
+				statsVal = item.StringVals
+			}
 		}
 		e.appendRow([]any{
 			dbName,
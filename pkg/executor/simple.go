@@ -54,6 +54,7 @@ import (
 	"github.com/pingcap/tidb/pkg/sessionctx/sessionstates"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/sessiontxn"
+	"github.com/pingcap/tidb/pkg/statistics/feedback"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util"
 	"github.com/pingcap/tidb/pkg/util/chunk"
@@ -184,6 +185,8 @@ func (e *SimpleExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 		return nil
 	case *ast.DropStatsStmt:
 		err = e.executeDropStats(x)
+	case *ast.CreateStatisticsStmt:
+		err = e.executeCreateStatistics(ctx, x)
 	case *ast.SetRoleStmt:
 		err = e.executeSetRole(x)
 	case *ast.RevokeRoleStmt:
@@ -200,6 +203,30 @@ func (e *SimpleExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 		err = e.executeAlterRange(x)
 	case *ast.DropQueryWatchStmt:
 		err = e.executeDropQueryWatch(x)
+	case *ast.RefreshMaterializedViewStmt:
+		err = e.executeRefreshMaterializedView(ctx, x)
+	case *ast.ProcedureInfo:
+		err = e.executeCreateProcedure(ctx, x)
+	case *ast.DropProcedureStmt:
+		err = e.executeDropProcedure(ctx, x)
+	case *ast.CreateFunctionStmt:
+		err = e.executeCreateFunction(ctx, x)
+	case *ast.DropFunctionStmt:
+		err = e.executeDropFunction(ctx, x)
+	case *ast.XAStmt:
+		err = e.executeXA(ctx, x)
+	case *ast.CreateEventStmt:
+		err = e.executeCreateEvent(ctx, x)
+	case *ast.AlterEventStmt:
+		err = e.executeAlterEvent(ctx, x)
+	case *ast.DropEventStmt:
+		err = e.executeDropEvent(ctx, x)
+	case *ast.CreateChangefeedStmt:
+		err = e.executeCreateChangefeed(ctx, x)
+	case *ast.DropChangefeedStmt:
+		err = e.executeDropChangefeed(ctx, x)
+	case *ast.ChangefeedActionStmt:
+		err = e.executeChangefeedAction(ctx, x)
 	}
 	e.done = true
 	return err
@@ -2659,6 +2686,8 @@ func (e *SimpleExec) executeFlush(s *ast.FlushStmt) error {
 		}
 	case ast.FlushClientErrorsSummary:
 		errno.FlushStats()
+	case ast.FlushCardinalityCorrections:
+		feedback.Flush()
 	}
 	return nil
 }
@@ -2685,6 +2714,23 @@ func (e *SimpleExec) executeAlterInstance(s *ast.AlterInstanceStmt) error {
 	return nil
 }
 
+// executeCreateStatistics registers the extended statistics, which is the same as `ALTER TABLE ... ADD STATS_EXTENDED`.
+func (e *SimpleExec) executeCreateStatistics(ctx context.Context, s *ast.CreateStatisticsStmt) error {
+	stmt := &ast.AlterTableStmt{
+		Table: s.Table,
+		Specs: []*ast.AlterTableSpec{{
+			Tp:          ast.AlterTableAddStatistics,
+			IfNotExists: s.IfNotExists,
+			Statistics: &ast.StatisticsSpec{
+				StatsName: s.StatsName,
+				StatsType: s.StatsType,
+				Columns:   s.Columns,
+			},
+		}},
+	}
+	return domain.GetDomain(e.Ctx()).DDL().AlterTable(ctx, e.Ctx(), stmt)
+}
+
 func (e *SimpleExec) executeDropStats(s *ast.DropStatsStmt) (err error) {
 	h := domain.GetDomain(e.Ctx()).StatsHandle()
 	var statsIDs []int64
@@ -2728,7 +2774,9 @@ func (e *SimpleExec) autoNewTxn() bool {
 	// Data loading statements. LOAD DATA
 	// (handled in other place)
 	// Administrative statements. TODO: ANALYZE TABLE, CACHE INDEX, CHECK TABLE, FLUSH, LOAD INDEX INTO CACHE, OPTIMIZE TABLE, REPAIR TABLE, RESET (but not RESET PERSIST).
-	case *ast.FlushStmt:
+	case *ast.FlushStmt, *ast.RefreshMaterializedViewStmt, *ast.ProcedureInfo, *ast.DropProcedureStmt,
+		*ast.CreateFunctionStmt, *ast.DropFunctionStmt, *ast.CreateEventStmt, *ast.AlterEventStmt, *ast.DropEventStmt, *ast.CreateChangefeedStmt,
+		*ast.DropChangefeedStmt, *ast.ChangefeedActionStmt:
 		return true
 	}
 	return false
@@ -0,0 +1,577 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/planner"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	plannerutil "github.com/pingcap/tidb/pkg/planner/util"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+)
+
+// WithTrigger indicates the executor modifies a table which may have triggers.
+type WithTrigger interface {
+	GetAfterTriggers() []*TriggerExec
+}
+
+// TriggerExec executes a trigger of the table modified by a write executor. A BEFORE trigger is
+// executed when a row is written, and may change the NEW row. The rows of an AFTER trigger are
+// collected, and the trigger is executed after the statement like a foreign key cascade.
+type TriggerExec struct {
+	b      *executorBuilder
+	tbl    table.Table
+	schema model.CIStr
+	info   *model.TriggerInfo
+	body   ast.StmtNode
+	parser *parser.Parser
+	// sqls caches the SQL of the expressions and statements of the body, they are parsed again
+	// for every row because the NEW and OLD columns are replaced by the values of the row.
+	sqls map[ast.Node]string
+	// rows are the OLD and NEW rows collected for an AFTER trigger.
+	rows []triggerRow
+	// rowSeq is shared by the triggers of a table, it orders the rows collected by the triggers, so
+	// the AFTER triggers are executed in the order the rows are written, e.g. DELETE before INSERT in REPLACE.
+	rowSeq *uint64
+}
+
+type triggerRow struct {
+	seq    uint64
+	oldRow []types.Datum
+	newRow []types.Datum
+}
+
+func (b *executorBuilder) buildTriggerExecs(tbl table.Table) ([]*TriggerExec, error) {
+	tblInfo := tbl.Meta()
+	if len(tblInfo.Triggers) == 0 {
+		return nil, nil
+	}
+	dbInfo, ok := infoschema.SchemaByTable(b.is, tblInfo)
+	if !ok {
+		return nil, errors.Errorf("schema of table %s not found", tblInfo.Name.O)
+	}
+	charset, collation := b.ctx.GetSessionVars().GetCharsetInfo()
+	triggers := make([]*TriggerExec, 0, len(tblInfo.Triggers))
+	rowSeq := new(uint64)
+	for _, info := range tblInfo.Triggers {
+		p := parser.New()
+		p.SetSQLMode(info.SQLMode)
+		// Only the body is stored, so a statement with the body is built to parse it.
+		sql := "CREATE TRIGGER t " + info.Timing.String() + " " + info.Event.String() + " ON t FOR EACH ROW " + info.Statement
+		stmt, err := p.ParseOneStmt(sql, charset, collation)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		triggers = append(triggers, &TriggerExec{
+			b:      b,
+			tbl:    tbl,
+			schema: dbInfo.Name,
+			info:   info,
+			body:   stmt.(*ast.CreateTriggerStmt).Body,
+			parser: p,
+			sqls:   make(map[ast.Node]string),
+			rowSeq: rowSeq,
+		})
+	}
+	return triggers, nil
+}
+
+func (b *executorBuilder) buildTblID2TriggerExecs(tblID2Table map[int64]table.Table) (map[int64][]*TriggerExec, error) {
+	triggersMap := make(map[int64][]*TriggerExec)
+	for tid, tbl := range tblID2Table {
+		triggers, err := b.buildTriggerExecs(tbl)
+		if err != nil {
+			return nil, err
+		}
+		if len(triggers) > 0 {
+			triggersMap[tid] = triggers
+		}
+	}
+	return triggersMap, nil
+}
+
+func getAfterTriggers(triggers []*TriggerExec) []*TriggerExec {
+	var afterTriggers []*TriggerExec
+	for _, t := range triggers {
+		if t.info.Timing == model.TriggerAfter {
+			afterTriggers = append(afterTriggers, t)
+		}
+	}
+	return afterTriggers
+}
+
+// runBeforeTriggers executes the BEFORE triggers of the event for the row, the NEW columns
+// assigned by the triggers are written to newRow.
+func runBeforeTriggers(ctx context.Context, triggers []*TriggerExec, event model.TriggerEvent, oldRow, newRow []types.Datum) error {
+	for _, t := range triggers {
+		if t.info.Timing != model.TriggerBefore || t.info.Event != event {
+			continue
+		}
+		// The data-modifying statements are rejected when a BEFORE trigger is created.
+		if err := t.execRow(ctx, triggerRow{oldRow: oldRow, newRow: newRow}, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectAfterTriggerRows collects the row for the AFTER triggers of the event.
+func collectAfterTriggerRows(triggers []*TriggerExec, event model.TriggerEvent, oldRow, newRow []types.Datum) {
+	for _, t := range triggers {
+		if t.info.Timing != model.TriggerAfter || t.info.Event != event {
+			continue
+		}
+		*t.rowSeq++
+		row := triggerRow{seq: *t.rowSeq}
+		if oldRow != nil {
+			row.oldRow = types.CloneRow(oldRow)
+		}
+		if newRow != nil {
+			row.newRow = types.CloneRow(newRow)
+		}
+		t.rows = append(t.rows, row)
+	}
+}
+
+// execRow executes the body of the trigger for the row, the statements are resolved in the
+// schema of the trigger.
+func (t *TriggerExec) execRow(ctx context.Context, row triggerRow, execDML func(context.Context, ast.StmtNode) error) error {
+	sessVars := t.b.ctx.GetSessionVars()
+	if sessVars.CurrentDB != t.schema.O {
+		defer func(db string) {
+			sessVars.CurrentDB = db
+		}(sessVars.CurrentDB)
+		sessVars.CurrentDB = t.schema.O
+	}
+	r := &triggerRun{TriggerExec: t, row: row, execDML: execDML}
+	return r.execStmt(ctx, t.body)
+}
+
+// triggerRun executes the body of a trigger for a row.
+type triggerRun struct {
+	*TriggerExec
+	row     triggerRow
+	execDML func(context.Context, ast.StmtNode) error
+}
+
+func (r *triggerRun) execStmts(ctx context.Context, stmts []ast.StmtNode) error {
+	for _, stmt := range stmts {
+		if err := r.execStmt(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *triggerRun) execStmt(ctx context.Context, stmt ast.StmtNode) error {
+	switch x := stmt.(type) {
+	case *ast.ProcedureBlock:
+		return r.execStmts(ctx, x.ProcedureProcStmts)
+	case *ast.ProcedureIfInfo:
+		return r.execIf(ctx, x.IfBody)
+	case *ast.SetStmt:
+		return r.execSet(x)
+	case *ast.InsertStmt, *ast.UpdateStmt, *ast.DeleteStmt:
+		sql, err := r.restore(x, "")
+		if err != nil {
+			return err
+		}
+		stmt, err := r.parse(sql)
+		if err != nil {
+			return err
+		}
+		stmt.Accept(&triggerRowResolver{r: r})
+		return r.execDML(ctx, stmt)
+	default:
+		return errors.Errorf("unsupported statement in trigger %s", r.info.Name.O)
+	}
+}
+
+func (r *triggerRun) execIf(ctx context.Context, block *ast.ProcedureIfBlock) error {
+	expr, err := r.rewriteExpr(block.IfExpr)
+	if err != nil {
+		return err
+	}
+	evalCtx := r.b.ctx.GetExprCtx().GetEvalCtx()
+	val, err := expr.Eval(evalCtx, chunk.Row{})
+	if err != nil {
+		return err
+	}
+	if !val.IsNull() {
+		cond, err := val.ToBool(evalCtx.TypeCtx())
+		if err != nil {
+			return err
+		}
+		if cond != 0 {
+			return r.execStmts(ctx, block.ProcedureIfStmts)
+		}
+	}
+	switch x := block.ProcedureElseStmt.(type) {
+	case *ast.ProcedureElseIfBlock:
+		return r.execIf(ctx, x.ProcedureIfStmt)
+	case *ast.ProcedureElseBlock:
+		return r.execStmts(ctx, x.ProcedureIfStmts)
+	}
+	return nil
+}
+
+// execSet assigns the NEW columns and the user variables.
+func (r *triggerRun) execSet(stmt *ast.SetStmt) error {
+	sctx := r.b.ctx
+	sessVars := sctx.GetSessionVars()
+	evalCtx := sctx.GetExprCtx().GetEvalCtx()
+	for _, v := range stmt.Variables {
+		expr, err := r.rewriteExpr(v.Value)
+		if err != nil {
+			return err
+		}
+		val, err := expr.Eval(evalCtx, chunk.Row{})
+		if err != nil {
+			return err
+		}
+		name := strings.ToLower(v.Name)
+		if !v.IsSystem {
+			if val.IsNull() {
+				sessVars.UnsetUserVar(name)
+			} else {
+				sessVars.SetUserVarVal(name, val)
+				sessVars.SetUserVarType(name, expr.GetType(evalCtx))
+			}
+			continue
+		}
+		_, colName, _ := strings.Cut(name, ".")
+		col := table.FindColLowerCase(r.tbl.Cols(), colName)
+		if col == nil {
+			return plannererrors.ErrUnknownColumn.GenWithStackByArgs(colName, "NEW")
+		}
+		r.row.newRow[col.Offset], err = table.CastValue(sctx, val, col.ToInfo(), false, false)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rewriteExpr rewrites the expression of the body after the NEW and OLD columns are replaced.
+func (r *triggerRun) rewriteExpr(expr ast.ExprNode) (expression.Expression, error) {
+	sql, err := r.restore(expr, "SELECT ")
+	if err != nil {
+		return nil, err
+	}
+	stmt, err := r.parse(sql)
+	if err != nil {
+		return nil, err
+	}
+	node, _ := stmt.(*ast.SelectStmt).Fields.Fields[0].Expr.Accept(&triggerRowResolver{r: r})
+	return plannerutil.RewriteAstExprWithPlanCtx(r.b.ctx.GetPlanCtx(), node.(ast.ExprNode), nil, nil, false)
+}
+
+// restore returns the SQL of the node with the prefix, the SQL is cached by the node.
+func (r *triggerRun) restore(node ast.Node, prefix string) (string, error) {
+	if sql, ok := r.sqls[node]; ok {
+		return sql, nil
+	}
+	var sb strings.Builder
+	sb.WriteString(prefix)
+	if err := node.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return "", errors.Trace(err)
+	}
+	r.sqls[node] = sb.String()
+	return sb.String(), nil
+}
+
+func (r *triggerRun) parse(sql string) (ast.StmtNode, error) {
+	charset, collation := r.b.ctx.GetSessionVars().GetCharsetInfo()
+	return r.parser.ParseOneStmt(sql, charset, collation)
+}
+
+// triggerRowResolver replaces the NEW and OLD columns in a statement with their values.
+type triggerRowResolver struct {
+	r *triggerRun
+}
+
+// Enter implements ast.Visitor interface.
+func (*triggerRowResolver) Enter(n ast.Node) (ast.Node, bool) {
+	return n, false
+}
+
+// Leave implements ast.Visitor interface.
+func (v *triggerRowResolver) Leave(n ast.Node) (ast.Node, bool) {
+	col, ok := n.(*ast.ColumnNameExpr)
+	if !ok || col.Name.Schema.L != "" {
+		return n, true
+	}
+	var row []types.Datum
+	switch col.Name.Table.L {
+	case "new":
+		row = v.r.row.newRow
+	case "old":
+		row = v.r.row.oldRow
+	}
+	c := table.FindColLowerCase(v.r.tbl.Cols(), col.Name.Name.L)
+	if row == nil || c == nil || c.Offset >= len(row) {
+		return n, true
+	}
+	d := row[c.Offset]
+	expr := ast.NewValueExpr(d.GetValue(), c.GetCharset(), c.GetCollate())
+	if !d.IsNull() {
+		expr.SetType(c.FieldType.Clone())
+	}
+	return expr, true
+}
+
+// handleTriggers executes the AFTER triggers for the rows collected by the write executor, in the order the
+// rows are written. The data-modifying statements of the triggers are built and executed like foreign key cascades.
+func (a *ExecStmt) handleTriggers(ctx context.Context, triggers []*TriggerExec, depth int) error {
+	type pendingRow struct {
+		t   *TriggerExec
+		row triggerRow
+	}
+	var rows []pendingRow
+	for _, t := range triggers {
+		for _, row := range t.rows {
+			rows = append(rows, pendingRow{t: t, row: row})
+		}
+		t.rows = nil
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	if depth > maxForeignKeyCascadeDepth {
+		return exeerrors.ErrSpNoRecursion.GenWithStackByArgs()
+	}
+	slices.SortStableFunc(rows, func(a, b pendingRow) int {
+		return cmp.Compare(a.row.seq, b.row.seq)
+	})
+	sc := a.Ctx.GetSessionVars().StmtCtx
+	defer func(inTrigger bool) {
+		sc.InHandleForeignKeyTrigger = inTrigger
+	}(sc.InHandleForeignKeyTrigger)
+	sc.InHandleForeignKeyTrigger = true
+	for _, r := range rows {
+		t := r.t
+		execDML := func(ctx context.Context, stmt ast.StmtNode) error {
+			return a.execTriggerStmt(ctx, t, stmt, depth)
+		}
+		a.triggerTables = append(a.triggerTables, t.tbl.Meta().ID)
+		err := t.execRow(ctx, r.row, execDML)
+		a.triggerTables = a.triggerTables[:len(a.triggerTables)-1]
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *ExecStmt) execTriggerStmt(ctx context.Context, t *TriggerExec, stmt ast.StmtNode, depth int) error {
+	sctx := a.Ctx
+	if err := plannercore.Preprocess(ctx, sctx, stmt); err != nil {
+		return err
+	}
+	// A trigger can't modify the tables which are being modified by the statement activating it.
+	for _, tn := range triggerTargetTables(stmt) {
+		tbl, err := t.b.is.TableByName(tn.Schema, tn.Name)
+		if err == nil && slices.Contains(a.triggerTables, tbl.Meta().ID) {
+			return exeerrors.ErrCantUpdateUsedTableInSfOrTrg.GenWithStackByArgs(tn.Name.O)
+		}
+	}
+	p, err := planner.OptimizeForForeignKeyCascade(ctx, sctx.GetPlanCtx(), stmt, t.b.is)
+	if err != nil {
+		return err
+	}
+	e := t.b.build(p)
+	if t.b.err != nil {
+		return t.b.err
+	}
+	if err := exec.Open(ctx, e); err != nil {
+		terror.Log(exec.Close(e))
+		return err
+	}
+	err = exec.Next(ctx, e, exec.NewFirstChunk(e))
+	closeErr := exec.Close(e)
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	sctx.StmtCommit(ctx)
+	return a.handleForeignKeyTrigger(ctx, e, depth+1)
+}
+
+// triggerTargetTables returns the tables modified by a data-modifying statement of a trigger.
+func triggerTargetTables(stmt ast.StmtNode) []*ast.TableName {
+	var refs *ast.TableRefsClause
+	switch x := stmt.(type) {
+	case *ast.InsertStmt:
+		refs = x.Table
+	case *ast.UpdateStmt:
+		refs = x.TableRefs
+	case *ast.DeleteStmt:
+		if x.IsMultiTable {
+			return x.Tables.Tables
+		}
+		refs = x.TableRefs
+	}
+	if refs == nil || refs.TableRefs == nil {
+		return nil
+	}
+	var tables []*ast.TableName
+	collectTableNames(refs.TableRefs, &tables)
+	return tables
+}
+
+func collectTableNames(node ast.ResultSetNode, tables *[]*ast.TableName) {
+	switch x := node.(type) {
+	case *ast.Join:
+		collectTableNames(x.Left, tables)
+		if x.Right != nil {
+			collectTableNames(x.Right, tables)
+		}
+	case *ast.TableSource:
+		if tn, ok := x.Source.(*ast.TableName); ok {
+			*tables = append(*tables, tn)
+		}
+	}
+}
+
+func (e *DDLExec) executeCreateTrigger(s *ast.CreateTriggerStmt) error {
+	return domain.GetDomain(e.Ctx()).DDL().CreateTrigger(e.Ctx(), s)
+}
+
+func (e *DDLExec) executeDropTrigger(s *ast.DropTriggerStmt) error {
+	return domain.GetDomain(e.Ctx()).DDL().DropTrigger(e.Ctx(), s)
+}
+
+func (e *ShowExec) fetchShowTriggers() error {
+	dbInfo, ok := e.is.SchemaByName(e.DBName)
+	if !ok {
+		return exeerrors.ErrBadDB.GenWithStackByArgs(e.DBName)
+	}
+	tblInfos := e.is.SchemaTableInfos(e.DBName)
+	slices.SortFunc(tblInfos, func(a, b *model.TableInfo) int {
+		return strings.Compare(a.Name.L, b.Name.L)
+	})
+	checker := privilege.GetPrivilegeManager(e.Ctx())
+	for _, tblInfo := range tblInfos {
+		if checker != nil && !checker.RequestVerification(e.Ctx().GetSessionVars().ActiveRoles, dbInfo.Name.L, tblInfo.Name.L, "", mysql.TriggerPriv) {
+			continue
+		}
+		for _, trigger := range tblInfo.Triggers {
+			e.appendRow([]any{trigger.Name.O, trigger.Event.String(), tblInfo.Name.O, trigger.Statement, trigger.Timing.String(),
+				triggerCreated(e.Ctx(), trigger), triggerSQLMode(trigger), triggerDefiner(trigger), trigger.CharsetClient,
+				trigger.CollationConnection, dbInfo.Collate})
+		}
+	}
+	return nil
+}
+
+func (e *memtableRetriever) setDataForTriggers(sctx sessionctx.Context, schemas []model.CIStr) {
+	checker := privilege.GetPrivilegeManager(sctx)
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		dbInfo, ok := e.is.SchemaByName(schema)
+		if !ok {
+			continue
+		}
+		for _, tblInfo := range e.is.SchemaTableInfos(schema) {
+			if len(tblInfo.Triggers) == 0 {
+				continue
+			}
+			if checker != nil && !checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, schema.L, tblInfo.Name.L, "", mysql.TriggerPriv) {
+				continue
+			}
+			// The triggers with the same timing and event are executed in the order they're created.
+			orders := make(map[[2]int]int)
+			for _, trigger := range tblInfo.Triggers {
+				key := [2]int{int(trigger.Timing), int(trigger.Event)}
+				orders[key]++
+				rows = append(rows, types.MakeDatums(
+					infoschema.CatalogVal,         // TRIGGER_CATALOG
+					dbInfo.Name.O,                 // TRIGGER_SCHEMA
+					trigger.Name.O,                // TRIGGER_NAME
+					trigger.Event.String(),        // EVENT_MANIPULATION
+					infoschema.CatalogVal,         // EVENT_OBJECT_CATALOG
+					dbInfo.Name.O,                 // EVENT_OBJECT_SCHEMA
+					tblInfo.Name.O,                // EVENT_OBJECT_TABLE
+					orders[key],                   // ACTION_ORDER
+					nil,                           // ACTION_CONDITION
+					trigger.Statement,             // ACTION_STATEMENT
+					"ROW",                         // ACTION_ORIENTATION
+					trigger.Timing.String(),       // ACTION_TIMING
+					nil,                           // ACTION_REFERENCE_OLD_TABLE
+					nil,                           // ACTION_REFERENCE_NEW_TABLE
+					"OLD",                         // ACTION_REFERENCE_OLD_ROW
+					"NEW",                         // ACTION_REFERENCE_NEW_ROW
+					triggerCreated(sctx, trigger), // CREATED
+					triggerSQLMode(trigger),       // SQL_MODE
+					triggerDefiner(trigger),       // DEFINER
+					trigger.CharsetClient,         // CHARACTER_SET_CLIENT
+					trigger.CollationConnection,   // COLLATION_CONNECTION
+					dbInfo.Collate,                // DATABASE_COLLATION
+				))
+			}
+		}
+	}
+	e.rows = rows
+}
+
+func triggerCreated(sctx sessionctx.Context, trigger *model.TriggerInfo) types.Time {
+	created := trigger.Created.In(sctx.GetSessionVars().Location())
+	return types.NewTime(types.FromGoTime(created), mysql.TypeDatetime, 2)
+}
+
+// triggerSQLMode returns the sql_mode of the trigger, the modes are listed in the order of their bits.
+func triggerSQLMode(trigger *model.TriggerInfo) string {
+	var modes []string
+	for name, mode := range mysql.Str2SQLMode {
+		// Skip the combination modes like ANSI and TRADITIONAL.
+		if mode != 0 && mode&(mode-1) == 0 && trigger.SQLMode&mode != 0 {
+			modes = append(modes, name)
+		}
+	}
+	slices.SortFunc(modes, func(a, b string) int {
+		return cmp.Compare(mysql.Str2SQLMode[a], mysql.Str2SQLMode[b])
+	})
+	return strings.Join(modes, ",")
+}
+
+func triggerDefiner(trigger *model.TriggerInfo) string {
+	if trigger.Definer == nil {
+		return ""
+	}
+	return trigger.Definer.Username + "@" + trigger.Definer.Hostname
+}
@@ -0,0 +1,73 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+)
+
+var _ exec.Executor = &UnpivotExec{}
+
+// UnpivotExec represents the UNPIVOT executor. It evaluates each level projection on
+// every row of the child, so each row of the child produces a row for each unpivoted
+// column. Every call of Next outputs one level of a chunk of the child.
+type UnpivotExec struct {
+	exec.BaseExecutor
+
+	levelEvaluators []*expression.EvaluatorSuite
+
+	childResult *chunk.Chunk
+	// level is the next level to be evaluated on childResult.
+	level int
+}
+
+// Open implements the Executor Open interface.
+func (e *UnpivotExec) Open(ctx context.Context) error {
+	if err := e.BaseExecutor.Open(ctx); err != nil {
+		return err
+	}
+	e.childResult = exec.TryNewCacheChunk(e.Children(0))
+	e.level = 0
+	return nil
+}
+
+// Next implements the Executor Next interface.
+func (e *UnpivotExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if e.level == 0 {
+		if err := exec.Next(ctx, e.Children(0), e.childResult); err != nil {
+			return err
+		}
+		if e.childResult.NumRows() == 0 {
+			return nil
+		}
+	}
+	err := e.levelEvaluators[e.level].Run(e.Ctx().GetExprCtx().GetEvalCtx(), e.Ctx().GetSessionVars().EnableVectorizedExpression, e.childResult, req)
+	if err != nil {
+		return err
+	}
+	e.level = (e.level + 1) % len(e.levelEvaluators)
+	return nil
+}
+
+// Close implements the Executor Close interface.
+func (e *UnpivotExec) Close() error {
+	e.childResult = nil
+	return e.BaseExecutor.Close()
+}
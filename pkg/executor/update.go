@@ -72,6 +72,10 @@ type UpdateExec struct {
 	fkChecks map[int64][]*FKCheckExec
 	// fkCascades contains the foreign key cascade. the map is tableID -> []*FKCascadeExec
 	fkCascades map[int64][]*FKCascadeExec
+	// triggers contains the triggers of the updated tables. the map is tableID -> []*TriggerExec
+	triggers map[int64][]*TriggerExec
+	// returning evaluates the RETURNING clause on the updated rows.
+	returning *returningExec
 }
 
 // prepare `handles`, `tableUpdatable`, `changed` to avoid re-computations.
@@ -176,6 +180,8 @@ func (e *UpdateExec) exec(ctx context.Context, _ *expression.Schema, row, newDat
 	for i, flag := range e.assignFlag {
 		bAssignFlag[i] = flag >= 0
 	}
+	// updated is whether any table is updated by the row, the row is returned once even if it updates multiple tables.
+	updated := false
 	for i, content := range e.tblColPosInfos {
 		if !e.tableUpdatable[i] {
 			// If there's nothing to update, we can just skip current row
@@ -199,7 +205,8 @@ func (e *UpdateExec) exec(ctx context.Context, _ *expression.Schema, row, newDat
 		// Update row
 		fkChecks := e.fkChecks[content.TblID]
 		fkCascades := e.fkCascades[content.TblID]
-		changed, err1 := updateRecord(ctx, e.Ctx(), handle, oldData, newTableData, flags, tbl, false, e.memTracker, fkChecks, fkCascades)
+		triggers := e.triggers[content.TblID]
+		changed, err1 := updateRecord(ctx, e.Ctx(), handle, oldData, newTableData, flags, tbl, false, e.memTracker, fkChecks, fkCascades, triggers)
 		if err1 == nil {
 			_, exist := e.updatedRowKeys[content.Start].Get(handle)
 			memDelta := e.updatedRowKeys[content.Start].Set(handle, changed)
@@ -207,6 +214,7 @@ func (e *UpdateExec) exec(ctx context.Context, _ *expression.Schema, row, newDat
 				memDelta += int64(handle.ExtraMemSize())
 			}
 			e.memTracker.Consume(memDelta)
+			updated = true
 			continue
 		}
 
@@ -219,6 +227,11 @@ func (e *UpdateExec) exec(ctx context.Context, _ *expression.Schema, row, newDat
 		}
 		return err1
 	}
+	if updated {
+		if err := e.returning.appendRow(newData); err != nil {
+			return err
+		}
+	}
 	if txn, _ := e.Ctx().Txn(false); txn != nil {
 		return txn.MayFlush()
 	}
@@ -444,6 +457,10 @@ func (e *UpdateExec) Close() error {
 		}
 		defer e.Ctx().GetSessionVars().StmtCtx.RuntimeStatsColl.RegisterStats(e.ID(), e.stats)
 	}
+	// The UpdateExec of a MERGE clause has no child, the rows are fed by MergeExec.
+	if e.EmptyChildren() {
+		return nil
+	}
 	return exec.Close(e.Children(0))
 }
 
@@ -452,6 +469,9 @@ func (e *UpdateExec) Open(ctx context.Context) error {
 	e.memTracker = memory.NewTracker(e.ID(), -1)
 	e.memTracker.AttachTo(e.Ctx().GetSessionVars().StmtCtx.MemTracker)
 
+	if e.EmptyChildren() {
+		return nil
+	}
 	return exec.Open(ctx, e.Children(0))
 }
 
@@ -568,3 +588,16 @@ func (e *UpdateExec) GetFKCascades() []*FKCascadeExec {
 func (e *UpdateExec) HasFKCascades() bool {
 	return len(e.fkCascades) > 0
 }
+
+// GetAfterTriggers implements WithTrigger interface.
+func (e *UpdateExec) GetAfterTriggers() []*TriggerExec {
+	var triggers []*TriggerExec
+	for _, ts := range e.triggers {
+		triggers = append(triggers, getAfterTriggers(ts)...)
+	}
+	return triggers
+}
+
+func (e *UpdateExec) getReturning() *returningExec {
+	return e.returning
+}
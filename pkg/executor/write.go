@@ -56,7 +56,7 @@ var (
 func updateRecord(
 	ctx context.Context, sctx sessionctx.Context, h kv.Handle, oldData, newData []types.Datum, modified []bool,
 	t table.Table,
-	onDup bool, _ *memory.Tracker, fkChecks []*FKCheckExec, fkCascades []*FKCascadeExec,
+	onDup bool, _ *memory.Tracker, fkChecks []*FKCheckExec, fkCascades []*FKCascadeExec, triggers []*TriggerExec,
 ) (bool, error) {
 	r, ctx := tracing.StartRegionEx(ctx, "executor.updateRecord")
 	defer r.End()
@@ -71,6 +71,10 @@ func updateRecord(
 	// because all of them are sorted by their `Offset`, which
 	// causes all writable columns are after public columns.
 
+	if err := runBeforeTriggers(ctx, triggers, model.TriggerUpdate, oldData, newData); err != nil {
+		return false, err
+	}
+
 	// Handle the bad null error.
 	for i, col := range t.Cols() {
 		var err error
@@ -138,6 +142,9 @@ func updateRecord(
 			keySet |= lockUniqueKeys
 		}
 		_, err := addUnchangedKeysForLockByRow(sctx, t, h, oldData, keySet)
+		if err == nil {
+			collectAfterTriggerRows(triggers, model.TriggerUpdate, oldData, newData)
+		}
 		return false, err
 	}
 
@@ -220,6 +227,7 @@ func updateRecord(
 			return false, err
 		}
 	}
+	collectAfterTriggerRows(triggers, model.TriggerUpdate, oldData, newData)
 	if onDup {
 		sc.AddAffectedRows(2)
 	} else {
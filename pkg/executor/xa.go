@@ -0,0 +1,83 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/sessiontxn"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+)
+
+func (e *SimpleExec) executeXA(ctx context.Context, s *ast.XAStmt) error {
+	// Only a single branch of a transaction is supported by a session, so joining, resuming and
+	// suspending a branch are not supported.
+	if s.Join || s.Resume || s.Suspend {
+		return exeerrors.ErrXAERInval
+	}
+	manager := sessiontxn.GetXATxnManager(e.Ctx())
+	switch s.Tp {
+	case ast.XAStart:
+		return manager.Start(ctx, s.XID)
+	case ast.XAEnd:
+		return manager.End(s.XID)
+	case ast.XAPrepare:
+		return manager.Prepare(s.XID)
+	case ast.XACommit:
+		return manager.Commit(ctx, s.XID, s.OnePhase)
+	case ast.XARollback:
+		return manager.Rollback(ctx, s.XID)
+	}
+	return nil
+}
+
+// XARecoverExec represents an `XA RECOVER` executor.
+type XARecoverExec struct {
+	exec.BaseExecutor
+
+	convertXID bool
+	branches   []sessiontxn.XABranch
+	cursor     int
+	fetched    bool
+}
+
+// Next implements the Executor Next interface.
+func (e *XARecoverExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if !e.fetched {
+		branches, err := sessiontxn.GetXATxnManager(e.Ctx()).Recover(ctx)
+		if err != nil {
+			return err
+		}
+		e.branches = branches
+		e.fetched = true
+	}
+	for ; e.cursor < len(e.branches) && !req.IsFull(); e.cursor++ {
+		xid := e.branches[e.cursor].XID
+		data := xid.Gtrid + xid.Bqual
+		if e.convertXID {
+			data = "0x" + hex.EncodeToString([]byte(data))
+		}
+		req.AppendUint64(0, xid.FormatID)
+		req.AppendInt64(1, int64(len(xid.Gtrid)))
+		req.AppendInt64(2, int64(len(xid.Bqual)))
+		req.AppendString(3, data)
+	}
+	return nil
+}
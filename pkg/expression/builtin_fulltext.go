@@ -0,0 +1,111 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/fulltext"
+)
+
+var (
+	_ functionClass = &matchAgainstFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinMatchAgainstSig{}
+)
+
+// matchAgainstFunctionClass is the class of `fts_match_against(against, modifier, parser, col1, col2, ...)`,
+// the modifier and the parser of the FULLTEXT index must be constants.
+type matchAgainstFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *matchAgainstFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	modifierArg, ok1 := args[1].(*Constant)
+	parserArg, ok2 := args[2].(*Constant)
+	if !ok1 || !ok2 {
+		return nil, ErrIncorrectParameterCount.GenWithStackByArgs(c.funcName)
+	}
+	argTps := make([]types.EvalType, 0, len(args))
+	argTps = append(argTps, types.ETString, types.ETInt, types.ETString)
+	for range args[3:] {
+		argTps = append(argTps, types.ETString)
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(mysql.MaxRealWidth)
+	bf.tp.SetDecimal(types.UnspecifiedLength)
+	bf.tp.AddFlag(mysql.NotNullFlag)
+	modifier, _, err := modifierArg.EvalInt(ctx.GetEvalCtx(), chunk.Row{})
+	if err != nil {
+		return nil, err
+	}
+	parser, _, err := parserArg.EvalString(ctx.GetEvalCtx(), chunk.Row{})
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinMatchAgainstSig{
+		baseBuiltinFunc: bf,
+		booleanMode:     ast.FulltextSearchModifier(modifier).IsBooleanMode(),
+		parser:          parser,
+	}
+	return sig, nil
+}
+
+type builtinMatchAgainstSig struct {
+	baseBuiltinFunc
+
+	booleanMode bool
+	parser      string
+}
+
+func (b *builtinMatchAgainstSig) Clone() builtinFunc {
+	newSig := &builtinMatchAgainstSig{booleanMode: b.booleanMode, parser: b.parser}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals the relevance of the texts of the columns for the query, 0 means the texts don't
+// match the query.
+func (b *builtinMatchAgainstSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	query, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return 0, err != nil, err
+	}
+	texts := make([]string, 0, len(b.args)-3)
+	for _, arg := range b.args[3:] {
+		text, isNull, err := arg.EvalString(ctx, row)
+		if err != nil {
+			return 0, true, err
+		}
+		if !isNull {
+			texts = append(texts, text)
+		}
+	}
+	doc := fulltext.NewDocument(b.parser, texts...)
+	if b.booleanMode {
+		return doc.BooleanScore(fulltext.ParseBooleanQuery(b.parser, query)), false, nil
+	}
+	return doc.NaturalLanguageScore(fulltext.Tokenize(b.parser, query)), false, nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchAgainst(t *testing.T) {
+	ctx := createContext(t)
+	tbl := []struct {
+		against  any
+		modifier int64
+		parser   string
+		texts    []any
+		matched  bool
+	}{
+		{"database", ast.FulltextSearchModifierNaturalLanguageMode, "", []any{"TiDB is a distributed database", nil}, true},
+		{"oracle", ast.FulltextSearchModifierNaturalLanguageMode, "", []any{"TiDB is a distributed database", "MySQL"}, false},
+		{"mysql", ast.FulltextSearchModifierNaturalLanguageMode, "", []any{"TiDB is a distributed database", "MySQL"}, true},
+		{"+tidb -mysql", ast.FulltextSearchModifierBooleanMode, "", []any{"TiDB is a distributed database", "MySQL"}, false},
+		{"+tidb +dist*", ast.FulltextSearchModifierBooleanMode, "", []any{"TiDB is a distributed database", "MySQL"}, true},
+		{"数据库", ast.FulltextSearchModifierNaturalLanguageMode, "", []any{"分布式数据库"}, true},
+		{"idb", ast.FulltextSearchModifierNaturalLanguageMode, "ngram", []any{"TiDB"}, true},
+		{nil, ast.FulltextSearchModifierNaturalLanguageMode, "", []any{"TiDB"}, false},
+	}
+	for _, tt := range tbl {
+		datums := types.MakeDatums(tt.against, tt.modifier, tt.parser)
+		datums = append(datums, types.MakeDatums(tt.texts...)...)
+		f, err := funcs[ast.FTSMatchAgainst].getFunction(ctx, datumsToConstants(datums))
+		require.NoError(t, err)
+		d, err := evalBuiltinFunc(f, ctx, chunk.Row{})
+		require.NoError(t, err)
+		require.False(t, d.IsNull())
+		require.Equal(t, tt.matched, d.GetFloat64() > 0, tt.against)
+	}
+
+	f, err := funcs[ast.FTSMatchAgainst].getFunction(ctx, datumsToConstants(types.MakeDatums("mysql", 0, "", "MySQL tutorial")))
+	require.NoError(t, err)
+	once, err := evalBuiltinFunc(f, ctx, chunk.Row{})
+	require.NoError(t, err)
+	f, err = funcs[ast.FTSMatchAgainst].getFunction(ctx, datumsToConstants(types.MakeDatums("mysql", 0, "", "MySQL vs MySQL")))
+	require.NoError(t, err)
+	twice, err := evalBuiltinFunc(f, ctx, chunk.Row{})
+	require.NoError(t, err)
+	require.Greater(t, twice.GetFloat64(), once.GetFloat64())
+}
@@ -64,6 +64,7 @@ var (
 	_ functionClass = &setValFunctionClass{}
 	_ functionClass = &formatBytesFunctionClass{}
 	_ functionClass = &formatNanoTimeFunctionClass{}
+	_ functionClass = &queryAttrStringFunctionClass{}
 )
 
 var (
@@ -85,6 +86,7 @@ var (
 	_ builtinFunc = &builtinSetValSig{}
 	_ builtinFunc = &builtinFormatBytesSig{}
 	_ builtinFunc = &builtinFormatNanoTimeSig{}
+	_ builtinFunc = &builtinQueryAttrStringSig{}
 )
 
 type databaseFunctionClass struct {
@@ -317,6 +319,56 @@ func (b *builtinCurrentResourceGroupSig) evalString(ctx EvalContext, row chunk.R
 	return getHintResourceGroupName(data), false, nil
 }
 
+type queryAttrStringFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *queryAttrStringFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(mysql.MaxBlobWidth)
+	sig := &builtinQueryAttrStringSig{baseBuiltinFunc: bf}
+	return sig, nil
+}
+
+type builtinQueryAttrStringSig struct {
+	baseBuiltinFunc
+	contextopt.SessionVarsPropReader
+}
+
+func (b *builtinQueryAttrStringSig) Clone() builtinFunc {
+	newSig := &builtinQueryAttrStringSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinQueryAttrStringSig) RequiredOptionalEvalProps() OptionalEvalPropKeySet {
+	return b.SessionVarsPropReader.RequiredOptionalEvalProps()
+}
+
+// evalString evals MYSQL_QUERY_ATTRIBUTE_STRING(name).
+// See https://dev.mysql.com/doc/refman/8.0/en/query-attributes.html#function_mysql-query-attribute-string
+func (b *builtinQueryAttrStringSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	data, err := b.GetSessionVars(ctx)
+	if err != nil {
+		return "", true, err
+	}
+	if data == nil {
+		return "", true, errors.Errorf("Missing session variable when eval builtin")
+	}
+	name, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	value, ok := data.QueryAttributes[name]
+	return value, !ok, nil
+}
+
 // get statement resource group name with hint in consideration
 // NOTE: because function `CURRENT_RESOURCE_GROUP()` maybe evaluated in optimizer
 // before we assign the hint value to StmtCtx.ResourceGroupName, so we have to
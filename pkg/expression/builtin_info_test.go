@@ -149,6 +149,28 @@ func TestConnectionID(t *testing.T) {
 	require.Equal(t, f.PbCode(), f.Clone().PbCode())
 }
 
+func TestQueryAttrString(t *testing.T) {
+	ctx := mock.NewContext()
+	ctx.GetSessionVars().QueryAttributes = map[string]string{"trace_id": "abc"}
+
+	fc := funcs[ast.QueryAttrString]
+	for _, tt := range []struct {
+		name any
+		ret  any
+	}{
+		{"trace_id", "abc"},
+		{"TRACE_ID", nil},
+		{"tenant", nil},
+		{nil, nil},
+	} {
+		f, err := fc.getFunction(ctx, datumsToConstants(types.MakeDatums(tt.name)))
+		require.NoError(t, err)
+		d, err := evalBuiltinFunc(f, ctx, chunk.Row{})
+		require.NoError(t, err)
+		testutil.DatumEqual(t, types.NewDatum(tt.ret), d)
+	}
+}
+
 func TestVersion(t *testing.T) {
 	ctx := createContext(t)
 	fc := funcs[ast.Version]
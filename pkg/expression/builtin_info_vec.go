@@ -421,3 +421,40 @@ func (b *builtinTiDBDecodeKeySig) vecEvalString(ctx EvalContext, input *chunk.Ch
 	}
 	return nil
 }
+
+func (b *builtinQueryAttrStringSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinQueryAttrStringSig) vecEvalString(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	data, err := b.GetSessionVars(ctx)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		return errors.Errorf("Missing session variable when eval builtin")
+	}
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get()
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalString(ctx, input, buf); err != nil {
+		return err
+	}
+	result.ReserveString(n)
+	for i := 0; i < n; i++ {
+		if buf.IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		value, ok := data.QueryAttributes[buf.GetString(i)]
+		if !ok {
+			result.AppendNull()
+			continue
+		}
+		result.AppendString(value)
+	}
+	return nil
+}
@@ -88,6 +88,9 @@ var vecBuiltinInfoCases = map[string][]vecExprBenchCase{
 	ast.ConnectionID: {
 		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{}},
 	},
+	ast.QueryAttrString: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString}},
+	},
 	ast.LastInsertId: {
 		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{}},
 		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETInt}},
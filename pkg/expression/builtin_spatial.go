@@ -0,0 +1,755 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"math"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/hack"
+)
+
+var (
+	_ functionClass = &stGeomFromTextFunctionClass{}
+	_ functionClass = &stGeomFromWKBFunctionClass{}
+	_ functionClass = &stAsTextFunctionClass{}
+	_ functionClass = &stAsBinaryFunctionClass{}
+	_ functionClass = &stSRIDFunctionClass{}
+	_ functionClass = &stXFunctionClass{}
+	_ functionClass = &stYFunctionClass{}
+	_ functionClass = &stGeometryTypeFunctionClass{}
+	_ functionClass = &stDistanceFunctionClass{}
+	_ functionClass = &stDistanceSphereFunctionClass{}
+	_ functionClass = &stContainsFunctionClass{}
+	_ functionClass = &stWithinFunctionClass{}
+	_ functionClass = &stIntersectsFunctionClass{}
+	_ functionClass = &pointFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinSTGeomFromTextSig{}
+	_ builtinFunc = &builtinSTGeomFromWKBSig{}
+	_ builtinFunc = &builtinSTAsTextSig{}
+	_ builtinFunc = &builtinSTAsBinarySig{}
+	_ builtinFunc = &builtinSTSRIDSig{}
+	_ builtinFunc = &builtinSTSetSRIDSig{}
+	_ builtinFunc = &builtinSTXSig{}
+	_ builtinFunc = &builtinSTYSig{}
+	_ builtinFunc = &builtinSTGeometryTypeSig{}
+	_ builtinFunc = &builtinSTDistanceSig{}
+	_ builtinFunc = &builtinSTDistanceSphereSig{}
+	_ builtinFunc = &builtinSTContainsSig{}
+	_ builtinFunc = &builtinSTWithinSig{}
+	_ builtinFunc = &builtinSTIntersectsSig{}
+	_ builtinFunc = &builtinPointSig{}
+)
+
+// defaultSphereRadius is the default radius of ST_Distance_Sphere, it's the same as MySQL.
+const defaultSphereRadius = 6370986
+
+// setGeometryRetType sets the return type of a function returning a geometry.
+func setGeometryRetType(bf *baseBuiltinFunc) {
+	bf.tp.SetType(mysql.TypeGeometry)
+	bf.tp.SetFlen(types.UnspecifiedLength)
+	bf.tp.SetCharset(charset.CharsetBin)
+	bf.tp.SetCollate(charset.CollationBin)
+	bf.tp.AddFlag(mysql.BinaryFlag)
+}
+
+// evalGeometry evaluates a geometry argument of the function funcName.
+func evalGeometry(ctx EvalContext, row chunk.Row, arg Expression, funcName string) (*types.Geometry, bool, error) {
+	s, isNull, err := arg.EvalString(ctx, row)
+	if isNull || err != nil {
+		return nil, isNull, err
+	}
+	g, err := types.DecodeGeometry(hack.Slice(s))
+	if err != nil {
+		return nil, false, errGISInvalidData.GenWithStackByArgs(funcName)
+	}
+	return g, false, nil
+}
+
+// evalSRID evaluates a SRID argument, the SRID must be a supported spatial reference system.
+func evalSRID(ctx EvalContext, row chunk.Row, arg Expression) (*types.SpatialReferenceSystem, bool, error) {
+	srid, isNull, err := arg.EvalInt(ctx, row)
+	if isNull || err != nil {
+		return nil, isNull, err
+	}
+	if srid < 0 || srid > math.MaxUint32 {
+		return nil, false, errSRSNotFound.GenWithStackByArgs(srid)
+	}
+	srs, ok := types.GetSpatialReferenceSystem(uint32(srid))
+	if !ok {
+		return nil, false, errSRSNotFound.GenWithStackByArgs(srid)
+	}
+	return srs, false, nil
+}
+
+// evalGeometryPair evaluates the two geometry arguments of a binary spatial function, they must
+// be in the same spatial reference system.
+func evalGeometryPair(ctx EvalContext, row chunk.Row, args []Expression, funcName string) (g1, g2 *types.Geometry, isNull bool, err error) {
+	if g1, isNull, err = evalGeometry(ctx, row, args[0], funcName); isNull || err != nil {
+		return nil, nil, isNull, err
+	}
+	if g2, isNull, err = evalGeometry(ctx, row, args[1], funcName); isNull || err != nil {
+		return nil, nil, isNull, err
+	}
+	if g1.SRID != g2.SRID {
+		return nil, nil, false, errGISDifferentSRIDs.GenWithStackByArgs(funcName, g1.SRID, g2.SRID)
+	}
+	return g1, g2, false, nil
+}
+
+type stGeomFromTextFunctionClass struct {
+	baseFunctionClass
+
+	// geomType is the type the geometry must be of, mysql.GeometryTypeGeometry means any type.
+	geomType byte
+}
+
+func (c *stGeomFromTextFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETInt}[:len(args)]
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(&bf)
+	return &builtinSTGeomFromTextSig{bf, c.funcName, c.geomType}, nil
+}
+
+type builtinSTGeomFromTextSig struct {
+	baseBuiltinFunc
+
+	funcName string
+	geomType byte
+}
+
+func (b *builtinSTGeomFromTextSig) Clone() builtinFunc {
+	newSig := &builtinSTGeomFromTextSig{funcName: b.funcName, geomType: b.geomType}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals ST_GeomFromText(wkt[, srid]).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-wkt-functions.html#function_st-geomfromtext
+func (b *builtinSTGeomFromTextSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	wkt, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	srs, _ := types.GetSpatialReferenceSystem(0)
+	if len(b.args) > 1 {
+		if srs, isNull, err = evalSRID(ctx, row, b.args[1]); isNull || err != nil {
+			return "", isNull, err
+		}
+	}
+	g, err := types.ParseGeometryFromWKT(wkt, srs.SRID)
+	if err != nil || (b.geomType != mysql.GeometryTypeGeometry && g.Type != b.geomType) {
+		return "", false, errGISInvalidData.GenWithStackByArgs(b.funcName)
+	}
+	if srs.Geographic {
+		if err = g.CheckGeographicRange(b.funcName); err != nil {
+			return "", false, err
+		}
+	}
+	return string(g.Encode()), false, nil
+}
+
+type stGeomFromWKBFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stGeomFromWKBFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETInt}[:len(args)]
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(&bf)
+	return &builtinSTGeomFromWKBSig{bf, c.funcName}, nil
+}
+
+type builtinSTGeomFromWKBSig struct {
+	baseBuiltinFunc
+
+	funcName string
+}
+
+func (b *builtinSTGeomFromWKBSig) Clone() builtinFunc {
+	newSig := &builtinSTGeomFromWKBSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals ST_GeomFromWKB(wkb[, srid]).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-wkb-functions.html#function_st-geomfromwkb
+func (b *builtinSTGeomFromWKBSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	wkb, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	srs, _ := types.GetSpatialReferenceSystem(0)
+	if len(b.args) > 1 {
+		if srs, isNull, err = evalSRID(ctx, row, b.args[1]); isNull || err != nil {
+			return "", isNull, err
+		}
+	}
+	g, err := types.ParseGeometryFromWKB(hack.Slice(wkb), srs.SRID)
+	if err != nil {
+		return "", false, errGISInvalidData.GenWithStackByArgs(b.funcName)
+	}
+	if srs.Geographic {
+		if err = g.CheckGeographicRange(b.funcName); err != nil {
+			return "", false, err
+		}
+	}
+	return string(g.Encode()), false, nil
+}
+
+type stAsTextFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stAsTextFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	return &builtinSTAsTextSig{bf, c.funcName}, nil
+}
+
+type builtinSTAsTextSig struct {
+	baseBuiltinFunc
+
+	funcName string
+}
+
+func (b *builtinSTAsTextSig) Clone() builtinFunc {
+	newSig := &builtinSTAsTextSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals ST_AsText(g).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-format-conversion-functions.html#function_st-astext
+func (b *builtinSTAsTextSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	g, isNull, err := evalGeometry(ctx, row, b.args[0], b.funcName)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return g.WKT(), false, nil
+}
+
+type stAsBinaryFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stAsBinaryFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	types.SetBinChsClnFlag(bf.tp)
+	return &builtinSTAsBinarySig{bf, c.funcName}, nil
+}
+
+type builtinSTAsBinarySig struct {
+	baseBuiltinFunc
+
+	funcName string
+}
+
+func (b *builtinSTAsBinarySig) Clone() builtinFunc {
+	newSig := &builtinSTAsBinarySig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals ST_AsBinary(g).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-format-conversion-functions.html#function_st-asbinary
+func (b *builtinSTAsBinarySig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	g, isNull, err := evalGeometry(ctx, row, b.args[0], b.funcName)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return string(g.WKB()), false, nil
+}
+
+type stSRIDFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stSRIDFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	if len(args) == 1 {
+		bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString)
+		if err != nil {
+			return nil, err
+		}
+		bf.tp.AddFlag(mysql.UnsignedFlag)
+		return &builtinSTSRIDSig{bf}, nil
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString, types.ETInt)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(&bf)
+	return &builtinSTSetSRIDSig{bf}, nil
+}
+
+type builtinSTSRIDSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTSRIDSig) Clone() builtinFunc {
+	newSig := &builtinSTSRIDSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals ST_SRID(g).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-general-property-functions.html#function_st-srid
+func (b *builtinSTSRIDSig) evalInt(ctx EvalContext, row chunk.Row) (int64, bool, error) {
+	g, isNull, err := evalGeometry(ctx, row, b.args[0], ast.STSRID)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	return int64(g.SRID), false, nil
+}
+
+type builtinSTSetSRIDSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTSetSRIDSig) Clone() builtinFunc {
+	newSig := &builtinSTSetSRIDSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals ST_SRID(g, srid), it returns the geometry with the new SRID, the coordinates
+// are not transformed.
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-general-property-functions.html#function_st-srid
+func (b *builtinSTSetSRIDSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	g, isNull, err := evalGeometry(ctx, row, b.args[0], ast.STSRID)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	srs, isNull, err := evalSRID(ctx, row, b.args[1])
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	g.SetSRID(srs.SRID)
+	if srs.Geographic {
+		if err = g.CheckGeographicRange(ast.STSRID); err != nil {
+			return "", false, err
+		}
+	}
+	return string(g.Encode()), false, nil
+}
+
+// evalPointCoordinate evaluates the coordinate of a point, the first coordinate in the axis order
+// of the spatial reference system is returned if first is true, otherwise the second one.
+func evalPointCoordinate(ctx EvalContext, row chunk.Row, arg Expression, funcName string, first bool) (float64, bool, error) {
+	g, isNull, err := evalGeometry(ctx, row, arg, funcName)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	if g.Type != mysql.GeometryTypePoint {
+		return 0, false, errGISInvalidData.GenWithStackByArgs(funcName)
+	}
+	srs, ok := types.GetSpatialReferenceSystem(g.SRID)
+	// The axis order of a geographic SRS is latitude-longitude.
+	if ok && srs.Geographic {
+		first = !first
+	}
+	if first {
+		return g.Points[0].X, false, nil
+	}
+	return g.Points[0].Y, false, nil
+}
+
+type stXFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stXFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	return &builtinSTXSig{bf}, nil
+}
+
+type builtinSTXSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTXSig) Clone() builtinFunc {
+	newSig := &builtinSTXSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals ST_X(p).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-point-property-functions.html#function_st-x
+func (b *builtinSTXSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	return evalPointCoordinate(ctx, row, b.args[0], ast.STX, true)
+}
+
+type stYFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stYFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	return &builtinSTYSig{bf}, nil
+}
+
+type builtinSTYSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTYSig) Clone() builtinFunc {
+	newSig := &builtinSTYSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals ST_Y(p).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-point-property-functions.html#function_st-y
+func (b *builtinSTYSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	return evalPointCoordinate(ctx, row, b.args[0], ast.STY, false)
+}
+
+type stGeometryTypeFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stGeometryTypeFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(len("GEOMETRYCOLLECTION"))
+	return &builtinSTGeometryTypeSig{bf}, nil
+}
+
+type builtinSTGeometryTypeSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTGeometryTypeSig) Clone() builtinFunc {
+	newSig := &builtinSTGeometryTypeSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals ST_GeometryType(g).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-general-property-functions.html#function_st-geometrytype
+func (b *builtinSTGeometryTypeSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	g, isNull, err := evalGeometry(ctx, row, b.args[0], ast.STGeometryType)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return g.TypeName(), false, nil
+}
+
+type stDistanceFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stDistanceFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	return &builtinSTDistanceSig{bf}, nil
+}
+
+type builtinSTDistanceSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTDistanceSig) Clone() builtinFunc {
+	newSig := &builtinSTDistanceSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals ST_Distance(g1, g2). The distance of a geographic SRS is in meters, it's only
+// implemented for points and multipoints.
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-relation-functions-object-shapes.html#function_st-distance
+func (b *builtinSTDistanceSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	g1, g2, isNull, err := evalGeometryPair(ctx, row, b.args, ast.STDistance)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	if g1.IsEmpty() || g2.IsEmpty() {
+		return 0, true, nil
+	}
+	srs, ok := types.GetSpatialReferenceSystem(g1.SRID)
+	if !ok || !srs.Geographic {
+		return g1.Distance(g2), false, nil
+	}
+	for _, g := range []*types.Geometry{g1, g2} {
+		if !g.OnlyPoints() {
+			return 0, false, errNotImplementedForGeographicSRS.GenWithStackByArgs(ast.STDistance, g.TypeName())
+		}
+		if err = g.CheckGeographicRange(ast.STDistance); err != nil {
+			return 0, false, err
+		}
+	}
+	return g1.GeographicDistance(g2, srs), false, nil
+}
+
+type stDistanceSphereFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stDistanceSphereFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETString, types.ETReal}[:len(args)]
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	return &builtinSTDistanceSphereSig{bf}, nil
+}
+
+type builtinSTDistanceSphereSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTDistanceSphereSig) Clone() builtinFunc {
+	newSig := &builtinSTDistanceSphereSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals ST_Distance_Sphere(g1, g2[, radius]), the geometries must be points or multipoints
+// whose coordinates are longitudes and latitudes in degrees.
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-convenience-functions.html#function_st-distance-sphere
+func (b *builtinSTDistanceSphereSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	g1, g2, isNull, err := evalGeometryPair(ctx, row, b.args, ast.STDistanceSphere)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	radius := float64(defaultSphereRadius)
+	if len(b.args) > 2 {
+		if radius, isNull, err = b.args[2].EvalReal(ctx, row); isNull || err != nil {
+			return 0, isNull, err
+		}
+		if radius <= 0 {
+			return 0, false, errNonPositiveRadius.GenWithStackByArgs(ast.STDistanceSphere)
+		}
+	}
+	srs, ok := types.GetSpatialReferenceSystem(g1.SRID)
+	for _, g := range []*types.Geometry{g1, g2} {
+		if !g.OnlyPoints() {
+			if ok && srs.Geographic {
+				return 0, false, errNotImplementedForGeographicSRS.GenWithStackByArgs(ast.STDistanceSphere, g.TypeName())
+			}
+			return 0, false, errNotImplementedForCartesianSRS.GenWithStackByArgs(ast.STDistanceSphere, g.TypeName())
+		}
+		if err = g.CheckGeographicRange(ast.STDistanceSphere); err != nil {
+			return 0, false, err
+		}
+	}
+	return g1.SphereDistance(g2, radius), false, nil
+}
+
+// newSpatialRelationFunc builds the base of the functions testing the spatial relation between
+// two geometries.
+func newSpatialRelationFunc(ctx BuildContext, c *baseFunctionClass, args []Expression) (baseBuiltinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return baseBuiltinFunc{}, err
+	}
+	return newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString, types.ETString)
+}
+
+// evalSpatialRelation evaluates the spatial relation between the two geometry arguments.
+func evalSpatialRelation(ctx EvalContext, row chunk.Row, args []Expression, funcName string,
+	relation func(g1, g2 *types.Geometry) bool) (int64, bool, error) {
+	g1, g2, isNull, err := evalGeometryPair(ctx, row, args, funcName)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	if relation(g1, g2) {
+		return 1, false, nil
+	}
+	return 0, false, nil
+}
+
+type stContainsFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stContainsFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	bf, err := newSpatialRelationFunc(ctx, &c.baseFunctionClass, args)
+	if err != nil {
+		return nil, err
+	}
+	return &builtinSTContainsSig{bf}, nil
+}
+
+type builtinSTContainsSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTContainsSig) Clone() builtinFunc {
+	newSig := &builtinSTContainsSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals ST_Contains(g1, g2).
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-relation-functions-object-shapes.html#function_st-contains
+func (b *builtinSTContainsSig) evalInt(ctx EvalContext, row chunk.Row) (int64, bool, error) {
+	return evalSpatialRelation(ctx, row, b.args, ast.STContains, (*types.Geometry).Contains)
+}
+
+type stWithinFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stWithinFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	bf, err := newSpatialRelationFunc(ctx, &c.baseFunctionClass, args)
+	if err != nil {
+		return nil, err
+	}
+	return &builtinSTWithinSig{bf}, nil
+}
+
+type builtinSTWithinSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTWithinSig) Clone() builtinFunc {
+	newSig := &builtinSTWithinSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals ST_Within(g1, g2).
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-relation-functions-object-shapes.html#function_st-within
+func (b *builtinSTWithinSig) evalInt(ctx EvalContext, row chunk.Row) (int64, bool, error) {
+	return evalSpatialRelation(ctx, row, b.args, ast.STWithin, func(g1, g2 *types.Geometry) bool {
+		return g2.Contains(g1)
+	})
+}
+
+type stIntersectsFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stIntersectsFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	bf, err := newSpatialRelationFunc(ctx, &c.baseFunctionClass, args)
+	if err != nil {
+		return nil, err
+	}
+	return &builtinSTIntersectsSig{bf}, nil
+}
+
+type builtinSTIntersectsSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTIntersectsSig) Clone() builtinFunc {
+	newSig := &builtinSTIntersectsSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals ST_Intersects(g1, g2).
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-relation-functions-object-shapes.html#function_st-intersects
+func (b *builtinSTIntersectsSig) evalInt(ctx EvalContext, row chunk.Row) (int64, bool, error) {
+	return evalSpatialRelation(ctx, row, b.args, ast.STIntersects, (*types.Geometry).Intersects)
+}
+
+type pointFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *pointFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETReal, types.ETReal)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(&bf)
+	return &builtinPointSig{bf}, nil
+}
+
+type builtinPointSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinPointSig) Clone() builtinFunc {
+	newSig := &builtinPointSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals Point(x, y), the SRID of the point is 0.
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-mysql-specific-functions.html#function_point
+func (b *builtinPointSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	x, isNull, err := b.args[0].EvalReal(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	y, isNull, err := b.args[1].EvalReal(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return string(types.NewGeometryPoint(0, x, y).Encode()), false, nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpatialFunctions(t *testing.T) {
+	ctx := createContext(t)
+	eval := func(fn string, args ...any) (types.Datum, error) {
+		f, err := funcs[fn].getFunction(ctx, datumsToConstants(types.MakeDatums(args...)))
+		require.NoError(t, err)
+		return evalBuiltinFunc(f, ctx, chunk.Row{})
+	}
+	geom := func(wkt string, srid int64) []byte {
+		d, err := eval(ast.STGeomFromText, wkt, srid)
+		require.NoError(t, err)
+		return d.GetBytes()
+	}
+
+	square := geom("POLYGON((0 0,4 0,4 4,0 4,0 0))", 0)
+	point := geom("POINT(1 2)", 0)
+	tbl := []struct {
+		fn       string
+		args     []any
+		expected any
+	}{
+		{ast.STAsText, []any{square}, "POLYGON((0 0,4 0,4 4,0 4,0 0))"},
+		{ast.STAsText, []any{geom("POINT(30 120)", 4326)}, "POINT(30 120)"},
+		{ast.STGeometryType, []any{square}, "POLYGON"},
+		{ast.STSRID, []any{geom("POINT(1 2)", 3857)}, int64(3857)},
+		{ast.STX, []any{point}, float64(1)},
+		{ast.STY, []any{point}, float64(2)},
+		{ast.STContains, []any{square, point}, int64(1)},
+		{ast.STWithin, []any{square, point}, int64(0)},
+		{ast.STIntersects, []any{square, geom("LINESTRING(2 2,6 6)", 0)}, int64(1)},
+		{ast.STDistance, []any{point, geom("POINT(4 6)", 0)}, float64(5)},
+		{ast.STAsText, []any{nil}, nil},
+	}
+	for _, tt := range tbl {
+		d, err := eval(tt.fn, tt.args...)
+		require.NoError(t, err, tt.fn)
+		if tt.expected == nil {
+			require.True(t, d.IsNull(), tt.fn)
+			continue
+		}
+		switch v := tt.expected.(type) {
+		case string:
+			require.Equal(t, v, d.GetString(), tt.fn)
+		case int64:
+			require.Equal(t, v, d.GetInt64(), tt.fn)
+		case float64:
+			require.InDelta(t, v, d.GetFloat64(), 1e-9, tt.fn)
+		}
+	}
+
+	// The WKB round trip keeps the geometry.
+	d, err := eval(ast.STAsBinary, square)
+	require.NoError(t, err)
+	d, err = eval(ast.STGeomFromWKB, d.GetBytes(), 0)
+	require.NoError(t, err)
+	require.Equal(t, square, d.GetBytes())
+
+	// The distance between Paris and London in meters.
+	d, err = eval(ast.STDistanceSphere, geom("POINT(2.3522 48.8566)", 0), geom("POINT(-0.1276 51.5072)", 0))
+	require.NoError(t, err)
+	require.InDelta(t, 343500, d.GetFloat64(), 1000)
+
+	_, err = eval(ast.STGeomFromText, "POINT(1)", 0)
+	require.True(t, errGISInvalidData.Equal(err))
+	_, err = eval(ast.STPointFromText, "LINESTRING(0 0,1 1)", 0)
+	require.True(t, errGISInvalidData.Equal(err))
+	_, err = eval(ast.STGeomFromText, "POINT(1 2)", 1234)
+	require.True(t, errSRSNotFound.Equal(err))
+	_, err = eval(ast.STGeomFromText, "POINT(100 20)", 4326)
+	require.True(t, types.ErrLatitudeOutOfRange.Equal(err))
+	_, err = eval(ast.STContains, square, geom("POINT(1 2)", 3857))
+	require.True(t, errGISDifferentSRIDs.Equal(err))
+	_, err = eval(ast.STDistanceSphere, point, point, 0)
+	require.True(t, errNonPositiveRadius.Equal(err))
+	_, err = eval(ast.STDistanceSphere, square, point)
+	require.True(t, errNotImplementedForCartesianSRS.Equal(err))
+}
@@ -76,6 +76,8 @@ func evalBuiltinFunc(f builtinFunc, ctx EvalContext, row chunk.Row) (d types.Dat
 		res, isNull, err = f.evalDuration(ctx, row)
 	case types.ETJson:
 		res, isNull, err = f.evalJSON(ctx, row)
+	case types.ETVectorFloat32:
+		res, isNull, err = f.evalVectorFloat32(ctx, row)
 	case types.ETString:
 		res, isNull, err = f.evalString(ctx, row)
 	}
@@ -0,0 +1,336 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"math"
+
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tipb/go-tipb"
+)
+
+var (
+	_ functionClass = &vecDimsFunctionClass{}
+	_ functionClass = &vecL1DistanceFunctionClass{}
+	_ functionClass = &vecL2DistanceFunctionClass{}
+	_ functionClass = &vecNegativeInnerProductFunctionClass{}
+	_ functionClass = &vecCosineDistanceFunctionClass{}
+	_ functionClass = &vecL2NormFunctionClass{}
+	_ functionClass = &vecFromTextFunctionClass{}
+	_ functionClass = &vecAsTextFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinVecDimsSig{}
+	_ builtinFunc = &builtinVecL1DistanceSig{}
+	_ builtinFunc = &builtinVecL2DistanceSig{}
+	_ builtinFunc = &builtinVecNegativeInnerProductSig{}
+	_ builtinFunc = &builtinVecCosineDistanceSig{}
+	_ builtinFunc = &builtinVecL2NormSig{}
+	_ builtinFunc = &builtinVecFromTextSig{}
+	_ builtinFunc = &builtinVecAsTextSig{}
+)
+
+type vecDimsFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *vecDimsFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETVectorFloat32)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinVecDimsSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_VecDimsSig)
+	return sig, nil
+}
+
+type builtinVecDimsSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinVecDimsSig) Clone() builtinFunc {
+	newSig := &builtinVecDimsSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinVecDimsSig) evalInt(ctx EvalContext, row chunk.Row) (int64, bool, error) {
+	v, isNull, err := b.args[0].EvalVectorFloat32(ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	return int64(v.Len()), false, nil
+}
+
+// newVecDistanceFunc builds the base of the functions computing the distance between two vectors.
+func newVecDistanceFunc(ctx BuildContext, c *baseFunctionClass, args []Expression) (baseBuiltinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return baseBuiltinFunc{}, err
+	}
+	return newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETVectorFloat32, types.ETVectorFloat32)
+}
+
+// evalVecDistance evaluates the distance between the two vector arguments. NULL is returned
+// if the distance is not a number, e.g. the cosine distance with a zero vector.
+func evalVecDistance(ctx EvalContext, row chunk.Row, args []Expression,
+	distance func(a, b types.VectorFloat32) (float64, error)) (float64, bool, error) {
+	a, isNull, err := args[0].EvalVectorFloat32(ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	b, isNull, err := args[1].EvalVectorFloat32(ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	d, err := distance(a, b)
+	if err != nil {
+		return 0, false, err
+	}
+	if math.IsNaN(d) {
+		return 0, true, nil
+	}
+	return d, false, nil
+}
+
+type vecL1DistanceFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *vecL1DistanceFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	bf, err := newVecDistanceFunc(ctx, &c.baseFunctionClass, args)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinVecL1DistanceSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_VecL1DistanceSig)
+	return sig, nil
+}
+
+type builtinVecL1DistanceSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinVecL1DistanceSig) Clone() builtinFunc {
+	newSig := &builtinVecL1DistanceSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinVecL1DistanceSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	return evalVecDistance(ctx, row, b.args, types.VectorFloat32.L1Distance)
+}
+
+type vecL2DistanceFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *vecL2DistanceFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	bf, err := newVecDistanceFunc(ctx, &c.baseFunctionClass, args)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinVecL2DistanceSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_VecL2DistanceSig)
+	return sig, nil
+}
+
+type builtinVecL2DistanceSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinVecL2DistanceSig) Clone() builtinFunc {
+	newSig := &builtinVecL2DistanceSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinVecL2DistanceSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	return evalVecDistance(ctx, row, b.args, types.VectorFloat32.L2Distance)
+}
+
+type vecNegativeInnerProductFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *vecNegativeInnerProductFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	bf, err := newVecDistanceFunc(ctx, &c.baseFunctionClass, args)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinVecNegativeInnerProductSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_VecNegativeInnerProductSig)
+	return sig, nil
+}
+
+type builtinVecNegativeInnerProductSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinVecNegativeInnerProductSig) Clone() builtinFunc {
+	newSig := &builtinVecNegativeInnerProductSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinVecNegativeInnerProductSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	return evalVecDistance(ctx, row, b.args, types.VectorFloat32.NegativeInnerProduct)
+}
+
+type vecCosineDistanceFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *vecCosineDistanceFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	bf, err := newVecDistanceFunc(ctx, &c.baseFunctionClass, args)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinVecCosineDistanceSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_VecCosineDistanceSig)
+	return sig, nil
+}
+
+type builtinVecCosineDistanceSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinVecCosineDistanceSig) Clone() builtinFunc {
+	newSig := &builtinVecCosineDistanceSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinVecCosineDistanceSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	return evalVecDistance(ctx, row, b.args, types.VectorFloat32.CosineDistance)
+}
+
+type vecL2NormFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *vecL2NormFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETVectorFloat32)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinVecL2NormSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_VecL2NormSig)
+	return sig, nil
+}
+
+type builtinVecL2NormSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinVecL2NormSig) Clone() builtinFunc {
+	newSig := &builtinVecL2NormSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinVecL2NormSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	v, isNull, err := b.args[0].EvalVectorFloat32(ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	return v.L2Norm(), false, nil
+}
+
+type vecFromTextFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *vecFromTextFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETVectorFloat32, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinVecFromTextSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_VecFromTextSig)
+	return sig, nil
+}
+
+type builtinVecFromTextSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinVecFromTextSig) Clone() builtinFunc {
+	newSig := &builtinVecFromTextSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinVecFromTextSig) evalVectorFloat32(ctx EvalContext, row chunk.Row) (types.VectorFloat32, bool, error) {
+	s, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return types.ZeroVectorFloat32, isNull, err
+	}
+	v, err := types.ParseVectorFloat32(s)
+	if err != nil {
+		return types.ZeroVectorFloat32, false, err
+	}
+	return v, false, nil
+}
+
+type vecAsTextFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *vecAsTextFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETVectorFloat32)
+	if err != nil {
+		return nil, err
+	}
+	charset, collate := ctx.GetCharsetInfo()
+	bf.tp.SetCharset(charset)
+	bf.tp.SetCollate(collate)
+	bf.tp.SetFlen(mysql.MaxBlobWidth)
+	sig := &builtinVecAsTextSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_VecAsTextSig)
+	return sig, nil
+}
+
+type builtinVecAsTextSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinVecAsTextSig) Clone() builtinFunc {
+	newSig := &builtinVecAsTextSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinVecAsTextSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	v, isNull, err := b.args[0].EvalVectorFloat32(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return v.String(), false, nil
+}
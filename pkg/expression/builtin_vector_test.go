@@ -0,0 +1,125 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVecDistance(t *testing.T) {
+	ctx := createContext(t)
+	tbl := []struct {
+		fn       string
+		a, b     any
+		expected any
+	}{
+		{ast.VecL2Distance, "[1,2,3]", "[4,6,3]", float64(5)},
+		{ast.VecL1Distance, "[1,2,3]", "[4,6,3]", float64(7)},
+		{ast.VecNegativeInnerProduct, "[1,2,3]", "[4,6,3]", float64(-25)},
+		{ast.VecCosineDistance, "[1,0]", "[0,1]", float64(1)},
+		{ast.VecCosineDistance, "[1,1]", "[2,2]", float64(0)},
+		{ast.VecCosineDistance, "[0,0]", "[1,1]", nil},
+		{ast.VecL2Distance, nil, "[1]", nil},
+	}
+	for _, tt := range tbl {
+		f, err := funcs[tt.fn].getFunction(ctx, datumsToConstants(types.MakeDatums(tt.a, tt.b)))
+		require.NoError(t, err)
+		d, err := evalBuiltinFunc(f, ctx, chunk.Row{})
+		require.NoError(t, err)
+		if tt.expected == nil {
+			require.True(t, d.IsNull(), tt.fn)
+			continue
+		}
+		require.InDelta(t, tt.expected, d.GetFloat64(), 1e-6, tt.fn)
+	}
+
+	f, err := funcs[ast.VecL2Distance].getFunction(ctx, datumsToConstants(types.MakeDatums("[1,2]", "[1]")))
+	require.NoError(t, err)
+	_, err = evalBuiltinFunc(f, ctx, chunk.Row{})
+	require.True(t, types.ErrVectorDimensionMismatch.Equal(err))
+}
+
+func TestVecFunctions(t *testing.T) {
+	ctx := createContext(t)
+	vec, err := types.ParseVectorFloat32("[3,4]")
+	require.NoError(t, err)
+
+	f, err := funcs[ast.VecFromText].getFunction(ctx, datumsToConstants(types.MakeDatums("[3, 4]")))
+	require.NoError(t, err)
+	d, err := evalBuiltinFunc(f, ctx, chunk.Row{})
+	require.NoError(t, err)
+	require.Equal(t, 0, vec.Compare(d.GetVectorFloat32()))
+
+	f, err = funcs[ast.VecFromText].getFunction(ctx, datumsToConstants(types.MakeDatums("[3, 4")))
+	require.NoError(t, err)
+	_, err = evalBuiltinFunc(f, ctx, chunk.Row{})
+	require.Error(t, err)
+
+	args := []Expression{&Constant{Value: types.NewVectorFloat32Datum(vec), RetType: types.NewFieldType(mysql.TypeTiDBVectorFloat32)}}
+	f, err = funcs[ast.VecAsText].getFunction(ctx, args)
+	require.NoError(t, err)
+	d, err = evalBuiltinFunc(f, ctx, chunk.Row{})
+	require.NoError(t, err)
+	require.Equal(t, "[3,4]", d.GetString())
+
+	f, err = funcs[ast.VecDims].getFunction(ctx, args)
+	require.NoError(t, err)
+	d, err = evalBuiltinFunc(f, ctx, chunk.Row{})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), d.GetInt64())
+
+	f, err = funcs[ast.VecL2Norm].getFunction(ctx, args)
+	require.NoError(t, err)
+	d, err = evalBuiltinFunc(f, ctx, chunk.Row{})
+	require.NoError(t, err)
+	require.InDelta(t, 5, d.GetFloat64(), 1e-6)
+}
+
+func TestCastAsVectorFloat32(t *testing.T) {
+	ctx := createContext(t)
+	tp := types.NewFieldType(mysql.TypeTiDBVectorFloat32)
+	tp.SetFlen(2)
+
+	expr, err := BuildCastFunctionWithCheck(ctx, &Constant{Value: types.NewStringDatum("[1,2]"), RetType: types.NewFieldType(mysql.TypeVarString)}, tp, false)
+	require.NoError(t, err)
+	v, isNull, err := expr.EvalVectorFloat32(ctx, chunk.Row{})
+	require.NoError(t, err)
+	require.False(t, isNull)
+	require.Equal(t, "[1,2]", v.String())
+
+	expr = BuildCastFunction(ctx, &Constant{Value: types.NewStringDatum("[1,2,3]"), RetType: types.NewFieldType(mysql.TypeVarString)}, tp.Clone())
+	_, _, err = expr.EvalVectorFloat32(ctx, chunk.Row{})
+	require.True(t, types.ErrVectorDimensionNotFit.Equal(err))
+
+	expr = BuildCastFunction(ctx, &Constant{Value: types.NewIntDatum(1), RetType: types.NewFieldType(mysql.TypeLonglong)}, tp.Clone())
+	_, _, err = expr.EvalVectorFloat32(ctx, chunk.Row{})
+	require.ErrorContains(t, err, "cannot cast from bigint to vector")
+
+	vecExpr := &Constant{Value: types.NewVectorFloat32Datum(v), RetType: types.NewFieldType(mysql.TypeTiDBVectorFloat32)}
+	expr = WrapWithCastAsString(ctx, vecExpr)
+	s, _, err := expr.EvalString(ctx, chunk.Row{})
+	require.NoError(t, err)
+	require.Equal(t, "[1,2]", s)
+
+	expr = WrapWithCastAsInt(ctx, vecExpr)
+	_, _, err = expr.EvalInt(ctx, chunk.Row{})
+	require.ErrorContains(t, err, "cannot cast from vector to bigint")
+}
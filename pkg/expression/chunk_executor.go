@@ -168,6 +168,8 @@ func evalOneVec(ctx EvalContext, expr Expression, input *chunk.Chunk, output *ch
 		return expr.VecEvalDuration(ctx, input, result)
 	case types.ETJson:
 		return expr.VecEvalJSON(ctx, input, result)
+	case types.ETVectorFloat32:
+		return expr.VecEvalVectorFloat32(ctx, input, result)
 	case types.ETString:
 		if err := expr.VecEvalString(ctx, input, result); err != nil {
 			return err
@@ -243,6 +245,10 @@ func evalOneColumn(ctx EvalContext, expr Expression, iterator *chunk.Iterator4Ch
 		for row := iterator.Begin(); err == nil && row != iterator.End(); row = iterator.Next() {
 			err = executeToJSON(ctx, expr, fieldType, row, output, colID)
 		}
+	case types.ETVectorFloat32:
+		for row := iterator.Begin(); err == nil && row != iterator.End(); row = iterator.Next() {
+			err = executeToVectorFloat32(ctx, expr, fieldType, row, output, colID)
+		}
 	case types.ETString:
 		for row := iterator.Begin(); err == nil && row != iterator.End(); row = iterator.Next() {
 			err = executeToString(ctx, expr, fieldType, row, output, colID)
@@ -265,6 +271,8 @@ func evalOneCell(ctx EvalContext, expr Expression, row chunk.Row, output *chunk.
 		err = executeToDuration(ctx, expr, fieldType, row, output, colID)
 	case types.ETJson:
 		err = executeToJSON(ctx, expr, fieldType, row, output, colID)
+	case types.ETVectorFloat32:
+		err = executeToVectorFloat32(ctx, expr, fieldType, row, output, colID)
 	case types.ETString:
 		err = executeToString(ctx, expr, fieldType, row, output, colID)
 	}
@@ -370,6 +378,19 @@ func executeToJSON(ctx EvalContext, expr Expression, fieldType *types.FieldType,
 	return nil
 }
 
+func executeToVectorFloat32(ctx EvalContext, expr Expression, _ *types.FieldType, row chunk.Row, output *chunk.Chunk, colID int) error {
+	res, isNull, err := expr.EvalVectorFloat32(ctx, row)
+	if err != nil {
+		return err
+	}
+	if isNull {
+		output.AppendNull(colID)
+	} else {
+		output.AppendVectorFloat32(colID, res)
+	}
+	return nil
+}
+
 func executeToString(ctx EvalContext, expr Expression, fieldType *types.FieldType, row chunk.Row, output *chunk.Chunk, colID int) error {
 	res, isNull, err := expr.EvalString(ctx, row)
 	if err != nil {
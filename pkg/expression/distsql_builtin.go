@@ -141,6 +141,12 @@ func getSignatureByPB(ctx BuildContext, sigCode tipb.ScalarFuncSig, tp *tipb.Fie
 		f = &builtinCastJSONAsDurationSig{base}
 	case tipb.ScalarFuncSig_CastJsonAsJson:
 		f = &builtinCastJSONAsJSONSig{base}
+	case tipb.ScalarFuncSig_CastStringAsVectorFloat32:
+		f = &builtinCastStringAsVectorFloat32Sig{base}
+	case tipb.ScalarFuncSig_CastVectorFloat32AsVectorFloat32:
+		f = &builtinCastVectorFloat32AsVectorFloat32Sig{base}
+	case tipb.ScalarFuncSig_CastVectorFloat32AsString:
+		f = &builtinCastVectorFloat32AsStringSig{base}
 	case tipb.ScalarFuncSig_CoalesceInt:
 		f = &builtinCoalesceIntSig{base}
 	case tipb.ScalarFuncSig_CoalesceReal:
@@ -670,6 +676,22 @@ func getSignatureByPB(ctx BuildContext, sigCode tipb.ScalarFuncSig, tp *tipb.Fie
 		f = &builtinJSONExtractSig{base}
 	case tipb.ScalarFuncSig_JsonUnquoteSig:
 		f = &builtinJSONUnquoteSig{base}
+	case tipb.ScalarFuncSig_VecDimsSig:
+		f = &builtinVecDimsSig{base}
+	case tipb.ScalarFuncSig_VecL1DistanceSig:
+		f = &builtinVecL1DistanceSig{base}
+	case tipb.ScalarFuncSig_VecL2DistanceSig:
+		f = &builtinVecL2DistanceSig{base}
+	case tipb.ScalarFuncSig_VecNegativeInnerProductSig:
+		f = &builtinVecNegativeInnerProductSig{base}
+	case tipb.ScalarFuncSig_VecCosineDistanceSig:
+		f = &builtinVecCosineDistanceSig{base}
+	case tipb.ScalarFuncSig_VecL2NormSig:
+		f = &builtinVecL2NormSig{base}
+	case tipb.ScalarFuncSig_VecFromTextSig:
+		f = &builtinVecFromTextSig{base}
+	case tipb.ScalarFuncSig_VecAsTextSig:
+		f = &builtinVecAsTextSig{base}
 	case tipb.ScalarFuncSig_JsonTypeSig:
 		f = &builtinJSONTypeSig{base}
 	case tipb.ScalarFuncSig_JsonSetSig:
@@ -1149,6 +1171,8 @@ func PBToExpr(ctx BuildContext, expr *tipb.Expr, tps []*types.FieldType) (Expres
 		return convertJSON(expr.Val)
 	case tipb.ExprType_MysqlEnum:
 		return convertEnum(expr.Val, expr.FieldType)
+	case tipb.ExprType_TiDBVectorFloat32:
+		return convertVectorFloat32(expr.Val)
 	}
 	if expr.Tp != tipb.ExprType_ScalarFunc {
 		panic("should be a tipb.ExprType_ScalarFunc")
@@ -1293,6 +1317,14 @@ func convertJSON(val []byte) (*Constant, error) {
 	return &Constant{Value: d, RetType: types.NewFieldType(mysql.TypeJSON)}, nil
 }
 
+func convertVectorFloat32(val []byte) (*Constant, error) {
+	v, _, err := types.ZeroCopyDeserializeVectorFloat32(val)
+	if err != nil {
+		return nil, errors.Errorf("invalid VectorFloat32 % x", val)
+	}
+	return &Constant{Value: types.NewVectorFloat32Datum(v), RetType: types.NewFieldType(mysql.TypeTiDBVectorFloat32)}, nil
+}
+
 func convertEnum(val []byte, tp *tipb.FieldType) (*Constant, error) {
 	_, uVal, err := codec.DecodeUint(val)
 	if err != nil {
@@ -61,6 +61,14 @@ var (
 	errJSONInBooleanContext          = dbterror.ClassExpression.NewStd(mysql.ErrJSONInBooleanContext)
 	errBadNull                       = dbterror.ClassExpression.NewStd(mysql.ErrBadNull)
 
+	// Spatial functions.
+	errGISInvalidData                 = dbterror.ClassExpression.NewStd(mysql.ErrGISInvalidData)
+	errGISDifferentSRIDs              = dbterror.ClassExpression.NewStd(mysql.ErrGISDifferentSRIDs)
+	errSRSNotFound                    = dbterror.ClassExpression.NewStd(mysql.ErrSRSNotFound)
+	errNotImplementedForGeographicSRS = dbterror.ClassExpression.NewStd(mysql.ErrNotImplementedForGeographicSRS)
+	errNotImplementedForCartesianSRS  = dbterror.ClassExpression.NewStd(mysql.ErrNotImplementedForCartesianSRS)
+	errNonPositiveRadius              = dbterror.ClassExpression.NewStd(mysql.ErrNonPositiveRadius)
+
 	// Sequence usage privilege check.
 	errSequenceAccessDenied      = dbterror.ClassExpression.NewStd(mysql.ErrTableaccessDenied)
 	errUnsupportedJSONComparison = dbterror.ClassExpression.NewStdErr(mysql.ErrNotSupportedYet,
@@ -31,6 +31,7 @@ var UnCacheableFunctions = map[string]struct{}{
 	ast.RowCount:             {},
 	ast.Version:              {},
 	ast.Like:                 {},
+	ast.QueryAttrString:      {},
 
 	// functions below are incompatible with (non-prep) plan cache, we'll fix them one by one later.
 	ast.JSONExtract:      {}, // cannot pass TestFuncJSON
@@ -63,6 +64,8 @@ var unFoldableFunctions = map[string]struct{}{
 	ast.LastVal:   {},
 	ast.SetVal:    {},
 	ast.AnyValue:  {},
+
+	ast.QueryAttrString: {},
 }
 
 // DisableFoldFunctions stores functions which prevent child scope functions from being constant folded.
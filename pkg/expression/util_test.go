@@ -520,6 +520,9 @@ func (m *MockExpr) VecEvalDuration(ctx EvalContext, input *chunk.Chunk, result *
 func (m *MockExpr) VecEvalJSON(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
 	return nil
 }
+func (m *MockExpr) VecEvalVectorFloat32(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	return nil
+}
 
 func (m *MockExpr) String() string               { return "" }
 func (m *MockExpr) MarshalJSON() ([]byte, error) { return nil, nil }
@@ -568,6 +571,12 @@ func (m *MockExpr) EvalJSON(ctx EvalContext, row chunk.Row) (val types.BinaryJSO
 	}
 	return types.BinaryJSON{}, m.i == nil, m.err
 }
+func (m *MockExpr) EvalVectorFloat32(ctx EvalContext, row chunk.Row) (val types.VectorFloat32, isNull bool, err error) {
+	if x, ok := m.i.(types.VectorFloat32); ok {
+		return x, false, m.err
+	}
+	return types.ZeroVectorFloat32, m.i == nil, m.err
+}
 func (m *MockExpr) GetType(_ EvalContext) *types.FieldType            { return m.t }
 func (m *MockExpr) Clone() Expression                                 { return nil }
 func (m *MockExpr) Equal(ctx EvalContext, e Expression) bool          { return false }
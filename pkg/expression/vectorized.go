@@ -115,6 +115,21 @@ func genVecFromConstExpr(ctx EvalContext, expr Expression, targetType types.Eval
 				result.AppendJSON(v)
 			}
 		}
+	case types.ETVectorFloat32:
+		result.ReserveVectorFloat32(n)
+		v, isNull, err := expr.EvalVectorFloat32(ctx, chunk.Row{})
+		if err != nil {
+			return err
+		}
+		if isNull {
+			for i := 0; i < n; i++ {
+				result.AppendNull()
+			}
+		} else {
+			for i := 0; i < n; i++ {
+				result.AppendVectorFloat32(v)
+			}
+		}
 	case types.ETString:
 		result.ReserveString(n)
 		v, isNull, err := expr.EvalString(ctx, chunk.Row{})
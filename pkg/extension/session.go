@@ -69,6 +69,8 @@ type StmtEventInfo interface {
 	ConnectionInfo() *variable.ConnectionInfo
 	// SessionAlias returns the session alias value set by user
 	SessionAlias() string
+	// QueryAttributes returns the query attributes sent by the client with the statement
+	QueryAttributes() map[string]string
 	// StmtNode returns the parsed ast of the statement
 	// When parse error, this method will return a nil value
 	StmtNode() ast.StmtNode
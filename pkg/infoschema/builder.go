@@ -324,7 +324,7 @@ func (b *Builder) getTableIDs(diff *model.SchemaDiff) (oldTableID, newTableID in
 	switch diff.Type {
 	case model.ActionCreateSequence, model.ActionRecoverTable:
 		newTableID = diff.TableID
-	case model.ActionCreateTable:
+	case model.ActionCreateTable, model.ActionCreateMaterializedView:
 		// WARN: when support create table with foreign key in https://github.com/pingcap/tidb/pull/37148,
 		// create table with foreign key requires a multi-step state change(none -> write-only -> public),
 		// when the table's state changes from write-only to public, infoSchema need to drop the old table
@@ -353,7 +353,7 @@ func (b *Builder) getTableIDs(diff *model.SchemaDiff) (oldTableID, newTableID in
 func (b *Builder) updateBundleForTableUpdate(diff *model.SchemaDiff, newTableID, oldTableID int64) {
 	// handle placement rule cache
 	switch diff.Type {
-	case model.ActionCreateTable:
+	case model.ActionCreateTable, model.ActionCreateMaterializedView:
 		b.markTableBundleShouldUpdate(newTableID)
 	case model.ActionDropTable:
 		b.deleteBundle(b.infoSchema, oldTableID)
@@ -702,6 +702,9 @@ func applyCreateTable(b *Builder, m *meta.Meta, dbInfo *model.DBInfo, tableID in
 	if tblInfo.TempTableType != model.TempTableNone {
 		b.addTemporaryTable(tableID)
 	}
+	if tblInfo.IsMaterializedView() {
+		b.addMaterializedView(tblInfo)
+	}
 
 	newTbl, ok := b.infoSchema.TableByID(tableID)
 	if ok {
@@ -760,6 +763,7 @@ func (b *Builder) applyDropTable(diff *model.SchemaDiff, dbInfo *model.DBInfo, t
 	if b.infoSchema.temporaryTableIDs != nil {
 		delete(b.infoSchema.temporaryTableIDs, tableID)
 	}
+	b.deleteMaterializedView(tableID)
 	// The old DBInfo still holds a reference to old table info, we need to remove it.
 	b.deleteReferredForeignKeys(dbInfo, tableID)
 	return affected
@@ -810,6 +814,7 @@ func (b *Builder) InitWithOldInfoSchema(oldSchema InfoSchema) (*Builder, error)
 	b.copyPoliciesMap(oldIS)
 	b.copyResourceGroupMap(oldIS)
 	b.copyTemporaryTableIDsMap(oldIS)
+	b.copyMaterializedViewIDsMap(oldIS)
 	b.copyReferredForeignKeyMap(oldIS)
 
 	copy(b.infoSchema.sortedTablesBuckets, oldIS.sortedTablesBuckets)
@@ -929,6 +934,8 @@ func (b *Builder) createSchemaTablesForDB(di *model.DBInfo, tableFromMeta tableF
 
 		if tblInfo := tbl.Meta(); tblInfo.TempTableType != model.TempTableNone {
 			b.addTemporaryTable(tblInfo.ID)
+		} else if tblInfo.IsMaterializedView() {
+			b.addMaterializedView(tblInfo)
 		}
 	}
 	b.addDB(schemaVersion, di, schTbls)
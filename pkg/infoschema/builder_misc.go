@@ -16,6 +16,7 @@ package infoschema
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/ddl/placement"
@@ -102,6 +103,30 @@ func (b *Builder) addTemporaryTable(tblID int64) {
 	b.infoSchema.temporaryTableIDs[tblID] = struct{}{}
 }
 
+func (b *Builder) addMaterializedView(tblInfo *model.TableInfo) {
+	if b.infoSchema.materializedViewIDs == nil {
+		b.infoSchema.materializedViewIDs = make(map[string][]int64)
+	}
+	// The id slices may be shared with the old infoschema, so they're never modified in place.
+	digest := tblInfo.MaterializedView.Digest
+	b.infoSchema.materializedViewIDs[digest] = append(slices.Clip(b.infoSchema.materializedViewIDs[digest]), tblInfo.ID)
+}
+
+func (b *Builder) deleteMaterializedView(tblID int64) {
+	for digest, ids := range b.infoSchema.materializedViewIDs {
+		idx := slices.Index(ids, tblID)
+		if idx == -1 {
+			continue
+		}
+		if len(ids) == 1 {
+			delete(b.infoSchema.materializedViewIDs, digest)
+		} else {
+			b.infoSchema.materializedViewIDs[digest] = slices.Delete(slices.Clone(ids), idx, idx+1)
+		}
+		return
+	}
+}
+
 func (b *Builder) copyBundlesMap(oldIS *infoSchema) {
 	b.infoSchema.ruleBundleMap = make(map[int64]*placement.Bundle)
 	for id, v := range oldIS.ruleBundleMap {
@@ -136,6 +161,18 @@ func (b *Builder) copyTemporaryTableIDsMap(oldIS *infoSchema) {
 	}
 }
 
+func (b *Builder) copyMaterializedViewIDsMap(oldIS *infoSchema) {
+	if len(oldIS.materializedViewIDs) == 0 {
+		b.infoSchema.materializedViewIDs = nil
+		return
+	}
+
+	b.infoSchema.materializedViewIDs = make(map[string][]int64, len(oldIS.materializedViewIDs))
+	for digest, ids := range oldIS.materializedViewIDs {
+		b.infoSchema.materializedViewIDs[digest] = ids
+	}
+}
+
 func (b *Builder) copyReferredForeignKeyMap(oldIS *infoSchema) {
 	for k, v := range oldIS.referredForeignKeyMap {
 		b.infoSchema.referredForeignKeyMap[k] = v
@@ -51,6 +51,8 @@ type Misc interface {
 	AllResourceGroups() []*model.ResourceGroupInfo
 	// HasTemporaryTable returns whether information schema has temporary table
 	HasTemporaryTable() bool
+	// MaterializedViewIDsByDigest returns the ids of the materialized views whose queries have the digest.
+	MaterializedViewIDsByDigest(digest string) []int64
 	// GetTableReferredForeignKeys gets the table's ReferredFKInfo by lowercase schema and table name.
 	GetTableReferredForeignKeys(schema, table string) []*model.ReferredFKInfo
 }
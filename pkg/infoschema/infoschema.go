@@ -83,6 +83,9 @@ type infoSchemaMisc struct {
 	// temporaryTables stores the temporary table ids
 	temporaryTableIDs map[int64]struct{}
 
+	// materializedViewIDs stores the materialized view ids by the digest of their queries.
+	materializedViewIDs map[string][]int64
+
 	// referredForeignKeyMap records all table's ReferredFKInfo.
 	// referredSchemaAndTableName => child SchemaAndTableAndForeignKeyName => *model.ReferredFKInfo
 	referredForeignKeyMap map[SchemaAndTableName][]*model.ReferredFKInfo
@@ -391,6 +394,11 @@ func (is *infoSchemaMisc) HasTemporaryTable() bool {
 	return len(is.temporaryTableIDs) != 0
 }
 
+// MaterializedViewIDsByDigest returns the ids of the materialized views whose queries have the digest.
+func (is *infoSchemaMisc) MaterializedViewIDsByDigest(digest string) []int64 {
+	return is.materializedViewIDs[digest]
+}
+
 func (is *infoSchemaMisc) SchemaMetaVersion() int64 {
 	return is.schemaMetaVersion
 }
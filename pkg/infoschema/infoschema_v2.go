@@ -883,6 +883,7 @@ func (b *Builder) applyDropTableV2(diff *model.SchemaDiff, dbInfo *model.DBInfo,
 	if b.infoSchemaMisc.temporaryTableIDs != nil {
 		delete(b.infoSchemaMisc.temporaryTableIDs, tableID)
 	}
+	b.deleteMaterializedView(tableID)
 
 	table, ok := b.infoschemaV2.TableByID(tableID)
 	if !ok {
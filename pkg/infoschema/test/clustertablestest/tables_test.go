@@ -439,6 +439,7 @@ func TestSlowQuery(t *testing.T) {
 			"60e9378c746d9a2be1c791047e008967cf252eb6de9167ad3aa6098fa2d523f4",
 			"",
 			"update t set i = 2;",
+			"",
 			"select * from t_slim;",
 		},
 		{"2021-09-08 14:39:54.506967",
@@ -519,6 +520,7 @@ func TestSlowQuery(t *testing.T) {
 			"",
 			"",
 			"",
+			"",
 			"INSERT INTO ...;",
 		},
 	}
@@ -0,0 +1,825 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"hash/crc32"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/lightning/log"
+	"github.com/pingcap/tidb/pkg/types"
+)
+
+// An avro object container file is made of a header and a list of blocks:
+//
+//	header: "Obj\x01" | metadata map | 16 bytes sync marker
+//	block:  row count | data size | (compressed) data | 16 bytes sync marker
+//
+// See https://avro.apache.org/docs/1.11.1/specification/#object-container-files
+const (
+	avroMagic    = "Obj\x01"
+	avroSyncSize = 16
+
+	avroSchemaKey = "avro.schema"
+	avroCodecKey  = "avro.codec"
+)
+
+type avroKind int
+
+const (
+	avroNull avroKind = iota
+	avroBoolean
+	avroInt
+	avroLong
+	avroFloat
+	avroDouble
+	avroBytes
+	avroString
+	avroRecord
+	avroEnum
+	avroArray
+	avroMap
+	avroUnion
+	avroFixed
+)
+
+var avroPrimitiveKinds = map[string]avroKind{
+	"null":    avroNull,
+	"boolean": avroBoolean,
+	"int":     avroInt,
+	"long":    avroLong,
+	"float":   avroFloat,
+	"double":  avroDouble,
+	"bytes":   avroBytes,
+	"string":  avroString,
+}
+
+type avroField struct {
+	name   string
+	schema *avroSchema
+}
+
+// avroSchema is a parsed avro schema. Only the attributes needed to decode
+// the binary encoding and to convert logical types are kept.
+type avroSchema struct {
+	kind        avroKind
+	logicalType string
+	scale       int
+	// size of a fixed.
+	size int
+	// symbols of an enum.
+	symbols []string
+	// fields of a record.
+	fields []avroField
+	// items of an array or values of a map.
+	items *avroSchema
+	// branches of an union.
+	branches []*avroSchema
+}
+
+// parseAvroSchema parses the JSON form of an avro schema. named holds the
+// named types defined so far, by both the full name and the short name.
+func parseAvroSchema(v any, namespace string, named map[string]*avroSchema) (*avroSchema, error) {
+	switch v := v.(type) {
+	case string:
+		if kind, ok := avroPrimitiveKinds[v]; ok {
+			return &avroSchema{kind: kind}, nil
+		}
+		if s, ok := named[v]; ok {
+			return s, nil
+		}
+		if s, ok := named[namespace+"."+v]; ok {
+			return s, nil
+		}
+		return nil, errors.Errorf("unknown avro type %q", v)
+	case []any:
+		s := &avroSchema{kind: avroUnion}
+		for _, b := range v {
+			branch, err := parseAvroSchema(b, namespace, named)
+			if err != nil {
+				return nil, err
+			}
+			s.branches = append(s.branches, branch)
+		}
+		return s, nil
+	case map[string]any:
+		return parseAvroComplexSchema(v, namespace, named)
+	default:
+		return nil, errors.Errorf("invalid avro schema %v", v)
+	}
+}
+
+func parseAvroComplexSchema(v map[string]any, namespace string, named map[string]*avroSchema) (*avroSchema, error) {
+	tp, ok := v["type"].(string)
+	if !ok {
+		return parseAvroSchema(v["type"], namespace, named)
+	}
+	logicalType, _ := v["logicalType"].(string)
+	scale, _ := v["scale"].(float64)
+	if kind, ok := avroPrimitiveKinds[tp]; ok {
+		return &avroSchema{kind: kind, logicalType: logicalType, scale: int(scale)}, nil
+	}
+
+	s := &avroSchema{logicalType: logicalType, scale: int(scale)}
+	switch tp {
+	case "record", "error", "enum", "fixed":
+		name, _ := v["name"].(string)
+		if ns, ok := v["namespace"].(string); ok {
+			namespace = ns
+		}
+		fullName := name
+		if idx := strings.LastIndexByte(name, '.'); idx >= 0 {
+			namespace, name = name[:idx], name[idx+1:]
+		} else if namespace != "" {
+			fullName = namespace + "." + name
+		}
+		// register the type before parsing the fields, a record may refer to
+		// itself.
+		named[fullName] = s
+		named[name] = s
+	}
+	switch tp {
+	case "record", "error":
+		s.kind = avroRecord
+		fields, _ := v["fields"].([]any)
+		for _, f := range fields {
+			fm, ok := f.(map[string]any)
+			if !ok {
+				return nil, errors.Errorf("invalid avro record field %v", f)
+			}
+			name, _ := fm["name"].(string)
+			fs, err := parseAvroSchema(fm["type"], namespace, named)
+			if err != nil {
+				return nil, err
+			}
+			s.fields = append(s.fields, avroField{name: name, schema: fs})
+		}
+	case "enum":
+		s.kind = avroEnum
+		symbols, _ := v["symbols"].([]any)
+		for _, sym := range symbols {
+			str, _ := sym.(string)
+			s.symbols = append(s.symbols, str)
+		}
+	case "fixed":
+		s.kind = avroFixed
+		size, _ := v["size"].(float64)
+		s.size = int(size)
+	case "array", "map":
+		s.kind = avroArray
+		key := "items"
+		if tp == "map" {
+			s.kind = avroMap
+			key = "values"
+		}
+		items, err := parseAvroSchema(v[key], namespace, named)
+		if err != nil {
+			return nil, err
+		}
+		s.items = items
+	default:
+		return nil, errors.Errorf("unknown avro type %q", tp)
+	}
+	return s, nil
+}
+
+// avroDecoder decodes values in the avro binary encoding from a decoded block.
+type avroDecoder struct {
+	buf []byte
+	pos int
+}
+
+var errAvroShortBuffer = errors.New("avro block data is truncated")
+
+func (d *avroDecoder) readLong() (int64, error) {
+	v, n := binary.Varint(d.buf[d.pos:])
+	if n <= 0 {
+		return 0, errAvroShortBuffer
+	}
+	d.pos += n
+	return v, nil
+}
+
+func (d *avroDecoder) readFixed(n int) ([]byte, error) {
+	if n < 0 || d.pos+n > len(d.buf) {
+		return nil, errAvroShortBuffer
+	}
+	b := d.buf[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *avroDecoder) readBytes() ([]byte, error) {
+	n, err := d.readLong()
+	if err != nil {
+		return nil, err
+	}
+	return d.readFixed(int(n))
+}
+
+// readBlockCount reads the item count of a block of an array or a map. A
+// negative count is followed by the byte size of the block.
+func (d *avroDecoder) readBlockCount() (int64, error) {
+	n, err := d.readLong()
+	if err != nil || n >= 0 {
+		return n, err
+	}
+	if _, err = d.readLong(); err != nil {
+		return 0, err
+	}
+	return -n, nil
+}
+
+// readValue reads a value of the schema. Unions are resolved to the schema of
+// the chosen branch, which is returned with the value. Strings and bytes are
+// copied out of the block, nested values have their logical types converted.
+func (d *avroDecoder) readValue(s *avroSchema) (any, *avroSchema, error) {
+	switch s.kind {
+	case avroNull:
+		return nil, s, nil
+	case avroBoolean:
+		b, err := d.readFixed(1)
+		if err != nil {
+			return nil, s, err
+		}
+		return b[0] != 0, s, nil
+	case avroInt, avroLong:
+		v, err := d.readLong()
+		return v, s, err
+	case avroFloat:
+		b, err := d.readFixed(4)
+		if err != nil {
+			return nil, s, err
+		}
+		return math.Float32frombits(binary.LittleEndian.Uint32(b)), s, nil
+	case avroDouble:
+		b, err := d.readFixed(8)
+		if err != nil {
+			return nil, s, err
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(b)), s, nil
+	case avroBytes:
+		b, err := d.readBytes()
+		return bytes.Clone(b), s, err
+	case avroString:
+		b, err := d.readBytes()
+		return string(b), s, err
+	case avroFixed:
+		b, err := d.readFixed(s.size)
+		return bytes.Clone(b), s, err
+	case avroEnum:
+		idx, err := d.readLong()
+		if err != nil {
+			return nil, s, err
+		}
+		if idx < 0 || idx >= int64(len(s.symbols)) {
+			return nil, s, errors.Errorf("avro enum index %d out of range", idx)
+		}
+		return s.symbols[idx], s, nil
+	case avroUnion:
+		idx, err := d.readLong()
+		if err != nil {
+			return nil, s, err
+		}
+		if idx < 0 || idx >= int64(len(s.branches)) {
+			return nil, s, errors.Errorf("avro union index %d out of range", idx)
+		}
+		return d.readValue(s.branches[idx])
+	case avroRecord:
+		m := make(map[string]any, len(s.fields))
+		for _, f := range s.fields {
+			v, vs, err := d.readValue(f.schema)
+			if err != nil {
+				return nil, s, err
+			}
+			m[f.name] = avroLogicalValue(vs, v)
+		}
+		return m, s, nil
+	case avroArray:
+		items := []any{}
+		for {
+			n, err := d.readBlockCount()
+			if err != nil {
+				return nil, s, err
+			}
+			if n == 0 {
+				return items, s, nil
+			}
+			for i := int64(0); i < n; i++ {
+				v, vs, err := d.readValue(s.items)
+				if err != nil {
+					return nil, s, err
+				}
+				items = append(items, avroLogicalValue(vs, v))
+			}
+		}
+	case avroMap:
+		m := map[string]any{}
+		for {
+			n, err := d.readBlockCount()
+			if err != nil {
+				return nil, s, err
+			}
+			if n == 0 {
+				return m, s, nil
+			}
+			for i := int64(0); i < n; i++ {
+				k, err := d.readBytes()
+				if err != nil {
+					return nil, s, err
+				}
+				v, vs, err := d.readValue(s.items)
+				if err != nil {
+					return nil, s, err
+				}
+				m[string(k)] = avroLogicalValue(vs, v)
+			}
+		}
+	}
+	return nil, s, errors.Errorf("unknown avro type %d", s.kind)
+}
+
+// avroLogicalValue converts a value of a logical type to its string form,
+// other values are returned as is.
+func avroLogicalValue(s *avroSchema, v any) any {
+	switch v := v.(type) {
+	case int64:
+		switch s.logicalType {
+		case "date":
+			return time.Unix(v*secPerDay, 0).UTC().Format(time.DateOnly)
+		case "time-millis":
+			return time.UnixMilli(v).UTC().Format("15:04:05.999")
+		case "time-micros":
+			return time.UnixMicro(v).UTC().Format("15:04:05.999999")
+		case "timestamp-millis":
+			return time.UnixMilli(v).UTC().Format(utcTimeLayout)
+		case "timestamp-micros":
+			return time.UnixMicro(v).UTC().Format(utcTimeLayout)
+		case "local-timestamp-millis":
+			return time.UnixMilli(v).UTC().Format(timeLayout)
+		case "local-timestamp-micros":
+			return time.UnixMicro(v).UTC().Format(timeLayout)
+		}
+	case []byte:
+		if s.logicalType == "decimal" {
+			if len(v) == 0 {
+				return "0"
+			}
+			return binaryToDecimalStr(v, s.scale)
+		}
+	}
+	return v
+}
+
+// setDatumByAvro converts a top-level field value to a datum. Records, arrays
+// and maps are converted to JSON.
+func setDatumByAvro(d *types.Datum, s *avroSchema, v any) error {
+	switch v := avroLogicalValue(s, v).(type) {
+	case nil:
+		d.SetNull()
+	case bool:
+		if v {
+			d.SetInt64(1)
+		} else {
+			d.SetInt64(0)
+		}
+	case int64:
+		d.SetInt64(v)
+	case float32:
+		d.SetFloat32(v)
+	case float64:
+		d.SetFloat64(v)
+	case string:
+		d.SetString(v, "utf8mb4_bin")
+	case []byte:
+		d.SetBytes(v)
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		d.SetString(string(b), "utf8mb4_bin")
+	}
+	return nil
+}
+
+// avroStreamReader reads an avro file sequentially and tracks the offset, so
+// the data of a block can be skipped by seeking.
+type avroStreamReader struct {
+	r      ReadSeekCloser
+	buf    *bufio.Reader
+	offset int64
+}
+
+func newAvroStreamReader(r ReadSeekCloser) *avroStreamReader {
+	return &avroStreamReader{r: r, buf: bufio.NewReader(r)}
+}
+
+func (s *avroStreamReader) ReadByte() (byte, error) {
+	b, err := s.buf.ReadByte()
+	if err == nil {
+		s.offset++
+	}
+	return b, err
+}
+
+func (s *avroStreamReader) Read(p []byte) (int, error) {
+	n, err := s.buf.Read(p)
+	s.offset += int64(n)
+	return n, err
+}
+
+func (s *avroStreamReader) readLong() (int64, error) {
+	return binary.ReadVarint(s)
+}
+
+func (s *avroStreamReader) readBytes() ([]byte, error) {
+	n, err := s.readLong()
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 {
+		return nil, errors.Errorf("invalid avro bytes length %d", n)
+	}
+	b := make([]byte, n)
+	_, err = io.ReadFull(s, b)
+	return b, err
+}
+
+func (s *avroStreamReader) skip(n int64) error {
+	if n <= int64(s.buf.Buffered()) {
+		_, err := s.buf.Discard(int(n))
+		s.offset += n
+		return err
+	}
+	return s.seek(s.offset + n)
+}
+
+func (s *avroStreamReader) seek(offset int64) error {
+	if _, err := s.r.Seek(offset, io.SeekStart); err != nil {
+		return errors.Trace(err)
+	}
+	s.buf.Reset(s.r)
+	s.offset = offset
+	return nil
+}
+
+// readAvroHeader reads the header of an avro object container file.
+func readAvroHeader(s *avroStreamReader) (meta map[string][]byte, sync []byte, err error) {
+	magic := make([]byte, len(avroMagic))
+	if _, err = io.ReadFull(s, magic); err != nil || string(magic) != avroMagic {
+		return nil, nil, errors.New("not an avro object container file")
+	}
+	meta = make(map[string][]byte)
+	for {
+		n, err := s.readLong()
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		if n == 0 {
+			break
+		}
+		if n < 0 {
+			n = -n
+			if _, err = s.readLong(); err != nil {
+				return nil, nil, errors.Trace(err)
+			}
+		}
+		for i := int64(0); i < n; i++ {
+			k, err := s.readBytes()
+			if err != nil {
+				return nil, nil, errors.Trace(err)
+			}
+			v, err := s.readBytes()
+			if err != nil {
+				return nil, nil, errors.Trace(err)
+			}
+			meta[string(k)] = v
+		}
+	}
+	sync = make([]byte, avroSyncSize)
+	if _, err = io.ReadFull(s, sync); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return meta, sync, nil
+}
+
+// ReadAvroFileRowCount reads the row count of an avro file from the headers of
+// its blocks, the data of the blocks is skipped.
+func ReadAvroFileRowCount(
+	ctx context.Context,
+	store storage.ExternalStorage,
+	fileMeta SourceFileMeta,
+) (int64, error) {
+	r, err := store.Open(ctx, fileMeta.Path, nil)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	//nolint: errcheck
+	defer r.Close()
+
+	s := newAvroStreamReader(r)
+	if _, _, err = readAvroHeader(s); err != nil {
+		return 0, errors.Annotatef(err, "failed to read avro file %s", fileMeta.Path)
+	}
+	var rows int64
+	for {
+		count, err := s.readLong()
+		if err != nil {
+			if errors.Cause(err) == io.EOF {
+				return rows, nil
+			}
+			return 0, errors.Trace(err)
+		}
+		size, err := s.readLong()
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		if err = s.skip(size + avroSyncSize); err != nil {
+			return 0, errors.Trace(err)
+		}
+		rows += count
+	}
+}
+
+// AvroParser parses an avro object container file for import. The schema of
+// the file must be a record, and its fields are mapped to columns by name.
+// It implements the Parser interface.
+type AvroParser struct {
+	stream *avroStreamReader
+	schema *avroSchema
+	codec  string
+	sync   []byte
+
+	block     avroDecoder
+	blockRows int64
+	// pos is the total size of the decoded rows we have read. It is not an
+	// offset of the file, as the blocks might be compressed.
+	pos int64
+
+	columns []string
+	// fieldIdx is the index in columns of each field of the record, -1 if the
+	// field is not imported.
+	fieldIdx []int
+
+	zstdDecoder *zstd.Decoder
+	lastRow     Row
+	logger      log.Logger
+}
+
+// NewAvroParser creates a parser for an avro object container file.
+func NewAvroParser(
+	ctx context.Context,
+	reader ReadSeekCloser,
+	path string,
+) (*AvroParser, error) {
+	p := &AvroParser{
+		stream: newAvroStreamReader(reader),
+		logger: log.FromContext(ctx),
+	}
+	meta, err := p.readHeader()
+	if err != nil {
+		return nil, errors.Annotatef(err, "failed to read avro file %s", path)
+	}
+
+	var v any
+	if err = json.Unmarshal(meta[avroSchemaKey], &v); err != nil {
+		return nil, errors.Annotatef(err, "invalid schema of avro file %s", path)
+	}
+	schema, err := parseAvroSchema(v, "", map[string]*avroSchema{})
+	if err != nil {
+		return nil, errors.Annotatef(err, "invalid schema of avro file %s", path)
+	}
+	if schema.kind != avroRecord {
+		return nil, errors.Errorf("the schema of avro file %s is not a record", path)
+	}
+	p.schema = schema
+	p.codec = string(meta[avroCodecKey])
+	switch p.codec {
+	case "", "null", "deflate", "snappy":
+	case "zstandard":
+		if p.zstdDecoder, err = zstd.NewReader(nil); err != nil {
+			return nil, errors.Trace(err)
+		}
+	default:
+		return nil, errors.Errorf("unsupported codec %q of avro file %s", p.codec, path)
+	}
+
+	p.columns = make([]string, 0, len(schema.fields))
+	p.fieldIdx = make([]int, 0, len(schema.fields))
+	for i, f := range schema.fields {
+		p.columns = append(p.columns, strings.ToLower(f.name))
+		p.fieldIdx = append(p.fieldIdx, i)
+	}
+	return p, nil
+}
+
+func (p *AvroParser) readHeader() (map[string][]byte, error) {
+	meta, sync, err := readAvroHeader(p.stream)
+	if err != nil {
+		return nil, err
+	}
+	p.sync = sync
+	return meta, nil
+}
+
+// readBlock reads and decompresses the next block of the file.
+func (p *AvroParser) readBlock() error {
+	count, err := p.stream.readLong()
+	if err != nil {
+		if errors.Cause(err) == io.EOF {
+			return io.EOF
+		}
+		return errors.Trace(err)
+	}
+	size, err := p.stream.readLong()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if count < 0 || size < 0 {
+		return errors.Errorf("invalid avro block header at offset %d", p.stream.offset)
+	}
+	data := make([]byte, size+avroSyncSize)
+	if _, err = io.ReadFull(p.stream, data); err != nil {
+		return errors.Trace(err)
+	}
+	if !bytes.Equal(data[size:], p.sync) {
+		return errors.Errorf("invalid avro sync marker at offset %d", p.stream.offset-avroSyncSize)
+	}
+	data = data[:size]
+
+	switch p.codec {
+	case "deflate":
+		fr := flate.NewReader(bytes.NewReader(data))
+		data, err = io.ReadAll(fr)
+		_ = fr.Close()
+	case "snappy":
+		// the compressed data is followed by the CRC32 of the uncompressed data.
+		if len(data) < 4 {
+			return errAvroShortBuffer
+		}
+		checksum := binary.BigEndian.Uint32(data[len(data)-4:])
+		data, err = snappy.Decode(nil, data[:len(data)-4])
+		if err == nil && crc32.ChecksumIEEE(data) != checksum {
+			err = errors.New("avro block checksum mismatch")
+		}
+	case "zstandard":
+		data, err = p.zstdDecoder.DecodeAll(data, nil)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	p.block = avroDecoder{buf: data}
+	p.blockRows = count
+	return nil
+}
+
+// Pos returns the total size of the decoded rows the parser has read.
+// It implements the Parser interface.
+func (p *AvroParser) Pos() (pos int64, rowID int64) {
+	return p.pos, p.lastRow.RowID
+}
+
+// SetPos sets the reading position of the parser, pos must be returned by Pos.
+// As the blocks might be compressed, the parser reads from the first block
+// until it reaches pos.
+// It implements the Parser interface.
+func (p *AvroParser) SetPos(pos int64, rowID int64) error {
+	if pos < p.pos {
+		if err := p.stream.seek(0); err != nil {
+			return err
+		}
+		if _, err := p.readHeader(); err != nil {
+			return err
+		}
+		p.pos = 0
+		p.blockRows = 0
+	}
+	if err := ReadUntil(p, pos); err != nil {
+		return err
+	}
+	p.lastRow.RowID = rowID
+	return nil
+}
+
+// ScannedPos implements the Parser interface.
+// For avro it's the offset of the file we have read.
+func (p *AvroParser) ScannedPos() (int64, error) {
+	return p.stream.offset, nil
+}
+
+// Close closes the underlying reader.
+// It implements the Parser interface.
+func (p *AvroParser) Close() error {
+	if p.zstdDecoder != nil {
+		p.zstdDecoder.Close()
+	}
+	return p.stream.r.Close()
+}
+
+// ReadRow reads the next row of the avro file.
+// It implements the Parser interface.
+func (p *AvroParser) ReadRow() error {
+	for p.blockRows == 0 {
+		if err := p.readBlock(); err != nil {
+			return err
+		}
+	}
+	p.lastRow.RowID++
+	start := p.block.pos
+
+	row := p.lastRow.Row[:0]
+	for range p.columns {
+		row = append(row, types.Datum{})
+	}
+	for i, f := range p.schema.fields {
+		v, vs, err := p.block.readValue(f.schema)
+		if err != nil {
+			return errors.Annotatef(err, "failed to read field %s", f.name)
+		}
+		if idx := p.fieldIdx[i]; idx >= 0 {
+			if err = setDatumByAvro(&row[idx], vs, v); err != nil {
+				return err
+			}
+		}
+	}
+	p.lastRow.Row = row
+	p.blockRows--
+	p.lastRow.Length = p.block.pos - start
+	p.pos += int64(p.lastRow.Length)
+	return nil
+}
+
+// LastRow gets the last row parsed by the parser.
+// It implements the Parser interface.
+func (p *AvroParser) LastRow() Row {
+	return p.lastRow
+}
+
+// RecycleRow implements the Parser interface.
+func (*AvroParser) RecycleRow(_ Row) {
+}
+
+// Columns returns the _lower-case_ column names corresponding to values in
+// the LastRow.
+func (p *AvroParser) Columns() []string {
+	return p.columns
+}
+
+// SetColumns sets the columns the fields of the record are mapped to. Fields
+// not in the columns are skipped, and missing fields are read as NULL.
+func (p *AvroParser) SetColumns(columns []string) {
+	p.columns = make([]string, 0, len(columns))
+	colIdx := make(map[string]int, len(columns))
+	for i, c := range columns {
+		c = strings.ToLower(c)
+		if _, ok := colIdx[c]; !ok {
+			colIdx[c] = i
+		}
+		p.columns = append(p.columns, c)
+	}
+	for i, f := range p.schema.fields {
+		idx, ok := colIdx[strings.ToLower(f.name)]
+		if !ok {
+			idx = -1
+		}
+		p.fieldIdx[i] = idx
+	}
+}
+
+// SetLogger sets the logger used in the parser.
+// It implements the Parser interface.
+func (p *AvroParser) SetLogger(l log.Logger) {
+	p.logger = l
+}
+
+// SetRowID sets the rowID in an avro file when we start a compressed file.
+// It implements the Parser interface.
+func (p *AvroParser) SetRowID(rowID int64) {
+	p.lastRow.RowID = rowID
+}
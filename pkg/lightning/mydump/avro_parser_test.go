@@ -0,0 +1,217 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"hash/crc32"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+const testAvroSchema = `{"type": "record", "name": "t", "namespace": "test", "fields": [
+	{"name": "ID", "type": "long"},
+	{"name": "name", "type": ["null", "string"]},
+	{"name": "price", "type": {"type": "bytes", "logicalType": "decimal", "precision": 10, "scale": 2}},
+	{"name": "d", "type": {"type": "int", "logicalType": "date"}},
+	{"name": "ts", "type": {"type": "long", "logicalType": "timestamp-micros"}},
+	{"name": "color", "type": {"type": "enum", "name": "color", "symbols": ["RED", "GREEN"]}},
+	{"name": "tags", "type": {"type": "array", "items": "string"}},
+	{"name": "ok", "type": "boolean"},
+	{"name": "f", "type": "double"},
+	{"name": "c2", "type": ["null", "test.color"]}
+]}`
+
+func appendAvroBytes(buf []byte, b []byte) []byte {
+	buf = binary.AppendVarint(buf, int64(len(b)))
+	return append(buf, b...)
+}
+
+func encodeTestAvroRow(id int64, name *string, price int64, tags []string) []byte {
+	var buf []byte
+	buf = binary.AppendVarint(buf, id)
+	if name == nil {
+		buf = binary.AppendVarint(buf, 0)
+	} else {
+		buf = binary.AppendVarint(buf, 1)
+		buf = appendAvroBytes(buf, []byte(*name))
+	}
+	priceBytes := binary.BigEndian.AppendUint64(nil, uint64(price))
+	buf = appendAvroBytes(buf, priceBytes)
+	// 2024-01-02 03:04:05.000006 UTC
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC)
+	buf = binary.AppendVarint(buf, ts.Unix()/secPerDay)
+	buf = binary.AppendVarint(buf, ts.UnixMicro())
+	buf = binary.AppendVarint(buf, id%2)
+	if len(tags) > 0 {
+		buf = binary.AppendVarint(buf, int64(len(tags)))
+		for _, tag := range tags {
+			buf = appendAvroBytes(buf, []byte(tag))
+		}
+	}
+	buf = binary.AppendVarint(buf, 0)
+	buf = append(buf, byte(id%2))
+	buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(float64(id)/2))
+	buf = binary.AppendVarint(buf, 0)
+	return buf
+}
+
+func writeTestAvroFile(t *testing.T, codec string, blocks [][][]byte) []byte {
+	sync := []byte("0123456789abcdef")
+	var buf []byte
+	buf = append(buf, avroMagic...)
+	buf = binary.AppendVarint(buf, 2)
+	buf = appendAvroBytes(buf, []byte(avroSchemaKey))
+	buf = appendAvroBytes(buf, []byte(testAvroSchema))
+	buf = appendAvroBytes(buf, []byte(avroCodecKey))
+	buf = appendAvroBytes(buf, []byte(codec))
+	buf = binary.AppendVarint(buf, 0)
+	buf = append(buf, sync...)
+	for _, rows := range blocks {
+		data := bytes.Join(rows, nil)
+		switch codec {
+		case "deflate":
+			var b bytes.Buffer
+			w, err := flate.NewWriter(&b, flate.DefaultCompression)
+			require.NoError(t, err)
+			_, err = w.Write(data)
+			require.NoError(t, err)
+			require.NoError(t, w.Close())
+			data = b.Bytes()
+		case "snappy":
+			checksum := crc32.ChecksumIEEE(data)
+			data = binary.BigEndian.AppendUint32(snappy.Encode(nil, data), checksum)
+		}
+		buf = binary.AppendVarint(buf, int64(len(rows)))
+		buf = appendAvroBytes(buf, data)
+		buf = append(buf, sync...)
+	}
+	return buf
+}
+
+func TestAvroParser(t *testing.T) {
+	name := "abc"
+	rows := [][]byte{
+		encodeTestAvroRow(1, &name, 12345, []string{"x", "y"}),
+		encodeTestAvroRow(2, nil, -5, nil),
+		encodeTestAvroRow(3, &name, 0, []string{"z"}),
+	}
+	blocks := [][][]byte{rows[:2], rows[2:]}
+
+	for _, codec := range []string{"null", "deflate", "snappy"} {
+		content := writeTestAvroFile(t, codec, blocks)
+		parser, err := NewAvroParser(context.Background(), NewStringReader(string(content)), "test.avro")
+		require.NoError(t, err, codec)
+		require.Equal(t, []string{"id", "name", "price", "d", "ts", "color", "tags", "ok", "f", "c2"}, parser.Columns())
+
+		require.NoError(t, parser.ReadRow())
+		require.Equal(t, int64(1), parser.LastRow().RowID)
+		require.Equal(t, []types.Datum{
+			types.NewIntDatum(1),
+			types.NewCollationStringDatum("abc", "utf8mb4_bin"),
+			types.NewCollationStringDatum("123.45", "utf8mb4_bin"),
+			types.NewCollationStringDatum("2024-01-02", "utf8mb4_bin"),
+			types.NewCollationStringDatum("2024-01-02 03:04:05.000006Z", "utf8mb4_bin"),
+			types.NewCollationStringDatum("GREEN", "utf8mb4_bin"),
+			types.NewCollationStringDatum(`["x","y"]`, "utf8mb4_bin"),
+			types.NewIntDatum(1),
+			types.NewFloat64Datum(0.5),
+			types.NewDatum(nil),
+		}, parser.LastRow().Row, codec)
+		pos, _ := parser.Pos()
+		require.Equal(t, int64(len(rows[0])), pos)
+
+		require.NoError(t, parser.ReadRow())
+		row := parser.LastRow().Row
+		require.True(t, row[1].IsNull())
+		require.Equal(t, "-0.05", row[2].GetString())
+		require.Equal(t, "RED", row[5].GetString())
+		require.Equal(t, "[]", row[6].GetString())
+
+		// the second block.
+		require.NoError(t, parser.ReadRow())
+		require.Equal(t, int64(3), parser.LastRow().Row[0].GetInt64())
+		require.Equal(t, "0.00", parser.LastRow().Row[2].GetString())
+		pos, _ = parser.Pos()
+		require.Equal(t, int64(len(rows[0])+len(rows[1])+len(rows[2])), pos)
+		require.ErrorIs(t, errors.Cause(parser.ReadRow()), io.EOF)
+
+		// seek back to the second row.
+		require.NoError(t, parser.SetPos(int64(len(rows[0])), 10))
+		require.NoError(t, parser.ReadRow())
+		require.Equal(t, int64(11), parser.LastRow().RowID)
+		require.Equal(t, int64(2), parser.LastRow().Row[0].GetInt64())
+
+		// map fields to columns by name.
+		parser.SetColumns([]string{"ok", "Id", "missing"})
+		require.NoError(t, parser.ReadRow())
+		require.Equal(t, []types.Datum{
+			types.NewIntDatum(1),
+			types.NewIntDatum(3),
+			types.NewDatum(nil),
+		}, parser.LastRow().Row)
+		require.NoError(t, parser.Close())
+	}
+}
+
+func TestAvroParserError(t *testing.T) {
+	_, err := NewAvroParser(context.Background(), NewStringReader("a,b,c\n"), "test.avro")
+	require.ErrorContains(t, err, "not an avro object container file")
+
+	content := writeTestAvroFile(t, "bzip2", nil)
+	_, err = NewAvroParser(context.Background(), NewStringReader(string(content)), "test.avro")
+	require.ErrorContains(t, err, `unsupported codec "bzip2"`)
+
+	// corrupt the sync marker of the first block.
+	content = writeTestAvroFile(t, "null", [][][]byte{{encodeTestAvroRow(1, nil, 0, nil)}})
+	content[len(content)-1] = 'x'
+	parser, err := NewAvroParser(context.Background(), NewStringReader(string(content)), "test.avro")
+	require.NoError(t, err)
+	require.ErrorContains(t, parser.ReadRow(), "invalid avro sync marker")
+}
+
+func TestReadAvroFileRowCount(t *testing.T) {
+	rows := make([][]byte, 0, 10)
+	for i := 0; i < 10; i++ {
+		rows = append(rows, encodeTestAvroRow(int64(i), nil, 0, nil))
+	}
+	content := writeTestAvroFile(t, "null", [][][]byte{rows[:3], rows[3:4], rows[4:]})
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "db.t.avro"), content, 0o644))
+	store, err := storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+	cnt, err := ReadAvroFileRowCount(context.Background(), store, SourceFileMeta{Path: "db.t.avro"})
+	require.NoError(t, err)
+	require.Equal(t, int64(10), cnt)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bad.avro"), []byte(hex.EncodeToString(content)), 0o644))
+	_, err = ReadAvroFileRowCount(context.Background(), store, SourceFileMeta{Path: "bad.avro"})
+	require.ErrorContains(t, err, "not an avro object container file")
+}
@@ -0,0 +1,246 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/lightning/log"
+	"github.com/pingcap/tidb/pkg/types"
+)
+
+// NDJSONParser parses a newline-delimited JSON file for import. Every non-blank
+// line is a JSON object, and its top-level keys are mapped to columns by name.
+// It implements the Parser interface.
+type NDJSONParser struct {
+	reader ReadSeekCloser
+	buf    *bufio.Reader
+	// pos is the offset of the end of the last line we have read.
+	pos int64
+
+	columns []string
+	colIdx  map[string]int
+	// columnsFixed is set once the columns are known, either from SetColumns or
+	// from the keys of the first object. fromCaller is set in the former case,
+	// where keys not in the columns are ignored instead of reported.
+	columnsFixed bool
+	fromCaller   bool
+
+	lastRow Row
+	logger  log.Logger
+}
+
+// NewNDJSONParser creates a parser for a newline-delimited JSON file.
+func NewNDJSONParser(
+	ctx context.Context,
+	reader ReadSeekCloser,
+	blockBufSize int64,
+) *NDJSONParser {
+	return &NDJSONParser{
+		reader: reader,
+		buf:    bufio.NewReaderSize(reader, int(blockBufSize)),
+		logger: log.FromContext(ctx),
+	}
+}
+
+// Pos returns the offset of the end of the last line the parser has read.
+// It implements the Parser interface.
+func (p *NDJSONParser) Pos() (pos int64, rowID int64) {
+	return p.pos, p.lastRow.RowID
+}
+
+// SetPos sets the reading position of the parser, pos must be the start of a
+// line. It implements the Parser interface.
+func (p *NDJSONParser) SetPos(pos int64, rowID int64) error {
+	if _, err := p.reader.Seek(pos, io.SeekStart); err != nil {
+		return errors.Trace(err)
+	}
+	p.buf.Reset(p.reader)
+	p.pos = pos
+	p.lastRow.RowID = rowID
+	return nil
+}
+
+// ScannedPos implements the Parser interface.
+func (p *NDJSONParser) ScannedPos() (int64, error) {
+	return p.reader.Seek(0, io.SeekCurrent)
+}
+
+// Close closes the underlying reader.
+// It implements the Parser interface.
+func (p *NDJSONParser) Close() error {
+	return p.reader.Close()
+}
+
+// ReadRow reads the next JSON object of the file.
+// It implements the Parser interface.
+func (p *NDJSONParser) ReadRow() error {
+	for {
+		line, err := p.buf.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return errors.Trace(err)
+		}
+		if len(line) == 0 {
+			return io.EOF
+		}
+		start := p.pos
+		p.pos += int64(len(line))
+		data := bytes.TrimSpace(line)
+		if len(data) == 0 {
+			continue
+		}
+		p.lastRow.RowID++
+		p.lastRow.Length = len(line)
+		if err2 := p.parseObject(data); err2 != nil {
+			return errors.Annotatef(err2, "invalid JSON object at offset %d", start)
+		}
+		return nil
+	}
+}
+
+func (p *NDJSONParser) parseObject(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return errors.New("the line is not a JSON object")
+	}
+
+	var (
+		keys   []string
+		values []json.RawMessage
+	)
+	for dec.More() {
+		if tok, err = dec.Token(); err != nil {
+			return errors.Trace(err)
+		}
+		//nolint: forcetypeassert
+		key := strings.ToLower(tok.(string))
+		var raw json.RawMessage
+		if err = dec.Decode(&raw); err != nil {
+			return errors.Trace(err)
+		}
+		keys = append(keys, key)
+		values = append(values, raw)
+	}
+	// consume the closing '}' and make sure nothing follows it.
+	if _, err = dec.Token(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err = dec.Token(); err != io.EOF {
+		return errors.New("unexpected data after the JSON object")
+	}
+
+	if !p.columnsFixed {
+		p.setColumns(keys)
+	}
+	// missing keys are read as NULL, which is the zero value of Datum.
+	row := p.lastRow.Row[:0]
+	for range p.columns {
+		row = append(row, types.Datum{})
+	}
+	for i, key := range keys {
+		idx, ok := p.colIdx[key]
+		if !ok {
+			if p.fromCaller {
+				continue
+			}
+			return errors.Errorf("key %q does not appear in the first row", key)
+		}
+		setDatumByJSON(&row[idx], values[i])
+	}
+	p.lastRow.Row = row
+	return nil
+}
+
+func (p *NDJSONParser) setColumns(columns []string) {
+	p.columns = make([]string, 0, len(columns))
+	p.colIdx = make(map[string]int, len(columns))
+	for i, c := range columns {
+		c = strings.ToLower(c)
+		if _, ok := p.colIdx[c]; !ok {
+			p.colIdx[c] = i
+		}
+		p.columns = append(p.columns, c)
+	}
+	p.columnsFixed = true
+}
+
+// setDatumByJSON converts a JSON value to a datum. Scalars are kept in their
+// textual form and cast by the encoder, objects and arrays are kept as JSON.
+func setDatumByJSON(d *types.Datum, raw json.RawMessage) {
+	switch raw[0] {
+	case 'n':
+		d.SetNull()
+	case 't':
+		d.SetInt64(1)
+	case 'f':
+		d.SetInt64(0)
+	case '"':
+		var s string
+		// the value has been validated by the decoder.
+		_ = json.Unmarshal(raw, &s)
+		d.SetString(s, "utf8mb4_bin")
+	case '{', '[':
+		var buf bytes.Buffer
+		_ = json.Compact(&buf, raw)
+		d.SetString(buf.String(), "utf8mb4_bin")
+	default:
+		d.SetString(string(raw), "utf8mb4_bin")
+	}
+}
+
+// LastRow gets the last row parsed by the parser.
+// It implements the Parser interface.
+func (p *NDJSONParser) LastRow() Row {
+	return p.lastRow
+}
+
+// RecycleRow implements the Parser interface.
+func (*NDJSONParser) RecycleRow(_ Row) {
+}
+
+// Columns returns the _lower-case_ column names corresponding to values in
+// the LastRow.
+func (p *NDJSONParser) Columns() []string {
+	return p.columns
+}
+
+// SetColumns sets the columns the keys of the objects are mapped to. Keys not
+// in the columns are ignored, and missing keys are read as NULL.
+func (p *NDJSONParser) SetColumns(columns []string) {
+	p.setColumns(columns)
+	p.fromCaller = true
+}
+
+// SetLogger sets the logger used in the parser.
+// It implements the Parser interface.
+func (p *NDJSONParser) SetLogger(l log.Logger) {
+	p.logger = l
+}
+
+// SetRowID sets the rowID in a NDJSON file when we start a compressed file.
+// It implements the Parser interface.
+func (p *NDJSONParser) SetRowID(rowID int64) {
+	p.lastRow.RowID = rowID
+}
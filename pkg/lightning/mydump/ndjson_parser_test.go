@@ -0,0 +1,123 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/lightning/config"
+	"github.com/pingcap/tidb/pkg/lightning/mydump"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNDJSONParser(t *testing.T) {
+	input := `{"ID": 1, "name": "a\"b", "score": 1.50, "ok": true, "tags": [1, "x"]}` + "\n" +
+		"\n" +
+		`{"name": "c", "id": 2, "ok": false, "score": null, "tags": {"k": 1}}` + "\r\n" +
+		`{"id": 3}`
+	parser := mydump.NewNDJSONParser(context.Background(), mydump.NewStringReader(input), int64(config.ReadBlockSize))
+	defer parser.Close()
+
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []string{"id", "name", "score", "ok", "tags"}, parser.Columns())
+	require.Equal(t, mydump.Row{
+		RowID: 1,
+		Row: []types.Datum{
+			types.NewCollationStringDatum("1", "utf8mb4_bin"),
+			types.NewCollationStringDatum(`a"b`, "utf8mb4_bin"),
+			types.NewCollationStringDatum("1.50", "utf8mb4_bin"),
+			types.NewIntDatum(1),
+			types.NewCollationStringDatum(`[1,"x"]`, "utf8mb4_bin"),
+		},
+		Length: 71,
+	}, parser.LastRow())
+	pos, rowID := parser.Pos()
+	require.Equal(t, int64(71), pos)
+	require.Equal(t, int64(1), rowID)
+
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{
+		types.NewCollationStringDatum("2", "utf8mb4_bin"),
+		types.NewCollationStringDatum("c", "utf8mb4_bin"),
+		types.NewDatum(nil),
+		types.NewIntDatum(0),
+		types.NewCollationStringDatum(`{"k":1}`, "utf8mb4_bin"),
+	}, parser.LastRow().Row)
+	require.Equal(t, int64(2), parser.LastRow().RowID)
+	pos, _ = parser.Pos()
+	require.Equal(t, int64(142), pos)
+
+	// missing keys are read as NULL.
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{
+		types.NewCollationStringDatum("3", "utf8mb4_bin"),
+		types.NewDatum(nil),
+		types.NewDatum(nil),
+		types.NewDatum(nil),
+		types.NewDatum(nil),
+	}, parser.LastRow().Row)
+	require.ErrorIs(t, errors.Cause(parser.ReadRow()), io.EOF)
+
+	// seek to the second row.
+	require.NoError(t, parser.SetPos(71, 10))
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, int64(11), parser.LastRow().RowID)
+	require.Equal(t, types.NewCollationStringDatum("2", "utf8mb4_bin"), parser.LastRow().Row[0])
+}
+
+func TestNDJSONParserSetColumns(t *testing.T) {
+	input := `{"a": 1, "b": 2, "c": 3}` + "\n" + `{"c": 6, "d": 7}` + "\n"
+	parser := mydump.NewNDJSONParser(context.Background(), mydump.NewStringReader(input), int64(config.ReadBlockSize))
+	defer parser.Close()
+	parser.SetColumns([]string{"C", "a"})
+
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []string{"c", "a"}, parser.Columns())
+	require.Equal(t, []types.Datum{
+		types.NewCollationStringDatum("3", "utf8mb4_bin"),
+		types.NewCollationStringDatum("1", "utf8mb4_bin"),
+	}, parser.LastRow().Row)
+	// unknown keys are ignored when the columns are set.
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{
+		types.NewCollationStringDatum("6", "utf8mb4_bin"),
+		types.NewDatum(nil),
+	}, parser.LastRow().Row)
+}
+
+func TestNDJSONParserError(t *testing.T) {
+	cases := []struct {
+		input string
+		err   string
+	}{
+		{`[1, 2]`, "the line is not a JSON object"},
+		{`{"a": 1`, "unexpected end of JSON input"},
+		{`{"a": 1} {"a": 2}`, "unexpected data after the JSON object"},
+		{`{"a": 1}` + "\n" + `{"b": 1}`, `key "b" does not appear in the first row`},
+	}
+	for _, c := range cases {
+		parser := mydump.NewNDJSONParser(context.Background(), mydump.NewStringReader(c.input), int64(config.ReadBlockSize))
+		var err error
+		for err == nil {
+			err = parser.ReadRow()
+		}
+		require.ErrorContains(t, err, c.err, c.input)
+		require.NoError(t, parser.Close())
+	}
+}
@@ -568,6 +568,13 @@ func setDatumByInt(d *types.Datum, v int64, meta *parquet.SchemaElement) error {
 		timeStr := formatTime(v, logicalType.TIME.Unit, "15:04:05.999999", "15:04:05.999999Z",
 			logicalType.TIME.IsAdjustedToUTC)
 		d.SetString(timeStr, "utf8mb4_bin")
+	case logicalType.INTEGER != nil && !logicalType.INTEGER.IsSigned:
+		// unsigned integers are stored in the signed physical types
+		if bits := logicalType.INTEGER.BitWidth; bits < 64 {
+			d.SetUint64(uint64(v) & (1<<uint(bits) - 1))
+		} else {
+			d.SetUint64(uint64(v))
+		}
 	default:
 		d.SetInt64(v)
 	}
@@ -240,6 +240,8 @@ func MakeTableRegions(
 			dataFileSize := info.FileMeta.FileSize
 			if info.FileMeta.Type == SourceTypeParquet {
 				regions, sizes, err = makeParquetFileRegion(egCtx, cfg, info)
+			} else if info.FileMeta.Type == SourceTypeAvro {
+				regions, sizes, err = makeAvroFileRegion(egCtx, cfg, info)
 			} else if info.FileMeta.Type == SourceTypeCSV && cfg.StrictFormat &&
 				info.FileMeta.Compression == CompressionNone &&
 				dataFileSize > cfg.MaxChunkSize+cfg.MaxChunkSize/largeCSVLowerThresholdRation {
@@ -318,8 +320,12 @@ func MakeSourceFileRegion(
 	fi FileInfo,
 ) ([]*TableRegion, []float64, error) {
 	divisor := int64(cfg.ColumnCnt)
-	isCsvFile := fi.FileMeta.Type == SourceTypeCSV
-	if !isCsvFile {
+	switch fi.FileMeta.Type {
+	case SourceTypeCSV:
+	case SourceTypeNDJSON:
+		// the shortest row is "{}\n", as a key may be missing in a row.
+		divisor = 3
+	default:
 		divisor += 2
 	}
 
@@ -391,6 +397,33 @@ func makeParquetFileRegion(
 	return []*TableRegion{region}, []float64{float64(dataFile.FileMeta.FileSize)}, nil
 }
 
+// avro files are split into blocks which might be compressed, and the position
+// of AvroParser is the size of the decoded rows, so an avro file is a single
+// region which is read until EOF, and its row count is read from the block headers.
+func makeAvroFileRegion(
+	ctx context.Context,
+	cfg *DataDivideConfig,
+	dataFile FileInfo,
+) ([]*TableRegion, []float64, error) {
+	numberRows, err := ReadAvroFileRowCount(ctx, cfg.Store, dataFile.FileMeta)
+	if err != nil {
+		return nil, nil, err
+	}
+	region := &TableRegion{
+		DB:       cfg.TableMeta.DB,
+		Table:    cfg.TableMeta.Name,
+		FileMeta: dataFile.FileMeta,
+		Chunk: Chunk{
+			Offset:       0,
+			EndOffset:    TableFileSizeINF,
+			RealOffset:   0,
+			PrevRowIDMax: 0,
+			RowIDMax:     numberRows,
+		},
+	}
+	return []*TableRegion{region}, []float64{float64(dataFile.FileMeta.RealSize)}, nil
+}
+
 // SplitLargeCSV splits a large csv file into multiple regions, the size of
 // each regions is specified by `config.MaxRegionSize`.
 // Note: We split the file coarsely, thus the format of csv file is needed to be
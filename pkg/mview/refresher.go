@@ -0,0 +1,124 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mview
+
+import (
+	"context"
+	"time"
+
+	"github.com/ngaut/pools"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	timerrt "github.com/pingcap/tidb/pkg/timer/runtime"
+	"github.com/pingcap/tidb/pkg/timer/tablestore"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const (
+	refreshTickInterval = time.Second
+	// syncTimersInterval is the max interval to sync the timers when the schema is not changed.
+	syncTimersInterval = 2 * time.Minute
+)
+
+type sessionPool interface {
+	Get() (pools.Resource, error)
+	Put(pools.Resource)
+}
+
+// Refresher refreshes the materialized views with `REFRESH ... EVERY interval` on schedule.
+// Each of these views has a timer in `mysql.tidb_timers`, and only the DDL owner syncs the
+// timers and runs the refreshes.
+type Refresher struct {
+	pool       sessionPool
+	etcd       *clientv3.Client
+	isOwner    func() bool
+	infoSchema func() infoschema.InfoSchema
+
+	rt       *timerrt.TimerGroupRuntime
+	syncer   *TimersSyncer
+	syncTime time.Time
+	syncVer  int64
+}
+
+// NewRefresher creates a new Refresher.
+func NewRefresher(pool sessionPool, etcd *clientv3.Client, isOwner func() bool, infoSchema func() infoschema.InfoSchema) *Refresher {
+	return &Refresher{
+		pool:       pool,
+		etcd:       etcd,
+		isOwner:    isOwner,
+		infoSchema: infoSchema,
+	}
+}
+
+// Run runs the refresher until the exit channel is closed.
+func (r *Refresher) Run(exit <-chan struct{}) {
+	store := tablestore.NewTableTimerStore(1, r.pool, "mysql", "tidb_timers", r.etcd)
+	r.syncer = NewTimersSyncer(timerapi.NewDefaultTimerClient(store))
+	defer func() {
+		r.pause()
+		store.Close()
+		logutil.BgLogger().Info("materialized view refresher exited")
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ticker := time.NewTicker(refreshTickInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-exit:
+			return
+		case <-ticker.C:
+			r.onTick(ctx, store)
+		}
+	}
+}
+
+func (r *Refresher) onTick(ctx context.Context, store *timerapi.TimerStore) {
+	if !r.isOwner() {
+		r.pause()
+		r.syncTime, r.syncVer = time.Time{}, 0
+		return
+	}
+
+	r.resume(store)
+	is := r.infoSchema()
+	if is.SchemaMetaVersion() > r.syncVer || time.Since(r.syncTime) > syncTimersInterval {
+		r.syncer.SyncTimers(ctx, is)
+		r.syncTime, r.syncVer = time.Now(), is.SchemaMetaVersion()
+	}
+}
+
+func (r *Refresher) resume(store *timerapi.TimerStore) {
+	if r.rt != nil {
+		return
+	}
+
+	r.rt = timerrt.NewTimerRuntimeBuilder("mview", store).
+		SetCond(&timerapi.TimerCond{Key: timerapi.NewOptionalVal(timerKeyPrefix), KeyPrefix: true}).
+		RegisterHookFactory(timerHookClass, func(_ string, cli timerapi.TimerClient) timerapi.Hook {
+			return newRefreshHook(r.pool, cli)
+		}).
+		Build()
+	r.rt.Start()
+}
+
+func (r *Refresher) pause() {
+	if rt := r.rt; rt != nil {
+		r.rt = nil
+		rt.Stop()
+	}
+}
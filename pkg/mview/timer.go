@@ -0,0 +1,145 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mview
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+// refreshTimeout is the max time of refreshing a view on schedule.
+const refreshTimeout = time.Hour
+
+type refreshHook struct {
+	pool   sessionPool
+	cli    timerapi.TimerClient
+	ctx    context.Context
+	cancel func()
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	running map[string]struct{}
+}
+
+func newRefreshHook(pool sessionPool, cli timerapi.TimerClient) *refreshHook {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &refreshHook{
+		pool:    pool,
+		cli:     cli,
+		ctx:     ctx,
+		cancel:  cancel,
+		running: make(map[string]struct{}),
+	}
+}
+
+func (*refreshHook) Start() {}
+
+func (h *refreshHook) Stop() {
+	h.cancel()
+	h.wg.Wait()
+}
+
+func (*refreshHook) OnPreSchedEvent(context.Context, timerapi.TimerShedEvent) (r timerapi.PreSchedEventResult, err error) {
+	return
+}
+
+func (h *refreshHook) OnSchedEvent(_ context.Context, event timerapi.TimerShedEvent) error {
+	timer := event.Timer()
+	eventID := event.EventID()
+	if err := h.ctx.Err(); err != nil {
+		return err
+	}
+
+	var data TimerData
+	if err := json.Unmarshal(timer.Data, &data); err != nil {
+		logutil.BgLogger().Error("invalid materialized view timer data",
+			zap.String("timerID", timer.ID),
+			zap.String("timerKey", timer.Key),
+			zap.ByteString("data", timer.Data),
+		)
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.running[eventID]; ok {
+		return nil
+	}
+	h.running[eventID] = struct{}{}
+	h.wg.Add(1)
+	go h.refresh(data.TableID, timer.ID, eventID, timer.EventStart)
+	return nil
+}
+
+// refresh refreshes the view and closes the timer event. A failed refresh is not retried
+// until the next event, so the timer is not stuck on a view which can't be refreshed.
+func (h *refreshHook) refresh(tableID int64, timerID, eventID string, eventStart time.Time) {
+	logger := logutil.BgLogger().With(
+		zap.Int64("tableID", tableID),
+		zap.String("timerID", timerID),
+		zap.String("eventID", eventID),
+	)
+	defer func() {
+		h.mu.Lock()
+		delete(h.running, eventID)
+		h.mu.Unlock()
+		h.wg.Done()
+	}()
+
+	ctx, cancel := context.WithTimeout(h.ctx, refreshTimeout)
+	defer cancel()
+	if err := h.refreshView(ctx, tableID); err != nil {
+		logger.Warn("failed to refresh materialized view", zap.Error(err))
+	}
+	if err := h.cli.CloseTimerEvent(h.ctx, timerID, eventID, timerapi.WithSetWatermark(eventStart)); err != nil {
+		logger.Error("CloseTimerEvent error", zap.Error(err))
+	}
+}
+
+func (h *refreshHook) refreshView(ctx context.Context, tableID int64) error {
+	resource, err := h.pool.Get()
+	if err != nil {
+		return err
+	}
+	defer h.pool.Put(resource)
+	sctx, ok := resource.(sessionctx.Context)
+	if !ok {
+		return errors.Errorf("%T is not sessionctx.Context", resource)
+	}
+
+	is := sctx.GetDomainInfoSchema().(infoschema.InfoSchema)
+	tbl, ok := is.TableByID(tableID)
+	if !ok || !tbl.Meta().IsMaterializedView() {
+		return nil
+	}
+	schema, ok := infoschema.SchemaByTable(is, tbl.Meta())
+	if !ok {
+		return nil
+	}
+
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	_, err = sctx.GetSQLExecutor().ExecuteInternal(ctx, "REFRESH MATERIALIZED VIEW %n.%n", schema.Name.O, tbl.Meta().Name.O)
+	return err
+}
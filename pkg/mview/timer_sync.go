@@ -0,0 +1,144 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mview
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+const (
+	timerKeyPrefix           = "/tidb/mview/table/"
+	timerHookClass           = "tidb.mview.refresh"
+	timerDelayDeleteInterval = 10 * time.Minute
+)
+
+// TimerData is the data stored in each timer refreshing a materialized view.
+type TimerData struct {
+	TableID int64 `json:"table_id"`
+}
+
+// TimersSyncer is used to sync the timers with the materialized views refreshed on schedule.
+type TimersSyncer struct {
+	cli         timerapi.TimerClient
+	delayDelete time.Duration
+}
+
+// NewTimersSyncer creates a new TimersSyncer.
+func NewTimersSyncer(cli timerapi.TimerClient) *TimersSyncer {
+	return &TimersSyncer{
+		cli:         cli,
+		delayDelete: timerDelayDeleteInterval,
+	}
+}
+
+// SetDelayDeleteInterval sets interval for delay delete a timer. The timer of a view which
+// doesn't exist is not deleted immediately, because the information schema is synced
+// asynchronously, and the meta of a new created view may not be synced to this node yet.
+func (g *TimersSyncer) SetDelayDeleteInterval(interval time.Duration) {
+	g.delayDelete = interval
+}
+
+// SyncTimers syncs the timers with the materialized views in the information schema.
+func (g *TimersSyncer) SyncTimers(ctx context.Context, is infoschema.InfoSchema) {
+	timers, err := g.cli.GetTimers(ctx, timerapi.WithKeyPrefix(timerKeyPrefix))
+	if err != nil {
+		logutil.BgLogger().Error("failed to pull materialized view timers", zap.Error(err))
+		return
+	}
+	key2Timers := make(map[string]*timerapi.TimerRecord, len(timers))
+	for _, timer := range timers {
+		key2Timers[timer.Key] = timer
+	}
+
+	currentTimerKeys := make(map[string]struct{})
+	for _, dbName := range is.AllSchemaNames() {
+		for _, tbl := range is.SchemaTables(dbName) {
+			tblInfo := tbl.Meta()
+			if tblInfo.State != model.StatePublic || !tblInfo.IsMaterializedView() ||
+				tblInfo.MaterializedView.RefreshInterval == "" {
+				continue
+			}
+			key := buildTimerKey(tblInfo.ID)
+			currentTimerKeys[key] = struct{}{}
+			if err := g.syncOneTimer(ctx, key2Timers[key], dbName, tblInfo); err != nil {
+				logutil.BgLogger().Error("failed to sync materialized view timer", zap.Error(err), zap.String("key", key))
+			}
+		}
+	}
+
+	for key, timer := range key2Timers {
+		if _, ok := currentTimerKeys[key]; ok {
+			continue
+		}
+		if time.Since(timer.CreateTime) <= g.delayDelete {
+			continue
+		}
+		if _, err = g.cli.DeleteTimer(ctx, timer.ID); err != nil {
+			logutil.BgLogger().Error("failed to delete timer", zap.Error(err), zap.String("timerID", timer.ID))
+		}
+	}
+}
+
+func (g *TimersSyncer) syncOneTimer(ctx context.Context, timer *timerapi.TimerRecord, schema model.CIStr, tblInfo *model.TableInfo) error {
+	tags := getTimerTags(schema, tblInfo)
+	interval := tblInfo.MaterializedView.RefreshInterval
+	if timer == nil {
+		data, err := json.Marshal(&TimerData{TableID: tblInfo.ID})
+		if err != nil {
+			return err
+		}
+		_, err = g.cli.CreateTimer(ctx, timerapi.TimerSpec{
+			Key:             buildTimerKey(tblInfo.ID),
+			Tags:            tags,
+			Data:            data,
+			SchedPolicyType: timerapi.SchedEventInterval,
+			SchedPolicyExpr: interval,
+			HookClass:       timerHookClass,
+			// The view is populated when it's created, so the first refresh is one interval later.
+			Watermark: time.Now(),
+			Enable:    true,
+		})
+		return err
+	}
+
+	if slices.Equal(timer.Tags, tags) && timer.SchedPolicyExpr == interval {
+		return nil
+	}
+	return g.cli.UpdateTimer(ctx, timer.ID,
+		timerapi.WithSetTags(tags),
+		timerapi.WithSetSchedExpr(timerapi.SchedEventInterval, interval),
+	)
+}
+
+func getTimerTags(schema model.CIStr, tblInfo *model.TableInfo) []string {
+	return []string{
+		fmt.Sprintf("db=%s", schema.O),
+		fmt.Sprintf("table=%s", tblInfo.Name.O),
+	}
+}
+
+func buildTimerKey(tableID int64) string {
+	return fmt.Sprintf("%s%d", timerKeyPrefix, tableID)
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mview_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/mview"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/testkit"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/pingcap/tidb/pkg/timer/tablestore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaterializedViewTimerSync(t *testing.T) {
+	store, do := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec(tablestore.CreateTimerTableSQL("test", "test_timers"))
+	timerStore := tablestore.NewTableTimerStore(1, do.SysSessionPool(), "test", "test_timers", nil)
+	defer timerStore.Close()
+
+	tk.MustExec("create table t(a int, b int)")
+	tk.MustExec("create materialized view mv1 refresh complete every 2 hour as select a, sum(b) from t group by a")
+	tk.MustExec("create materialized view mv2 refresh complete on demand as select a, count(*) from t group by a")
+	tk.MustExec("create materialized view mv3 as select a from t")
+
+	cli := timerapi.NewDefaultTimerClient(timerStore)
+	syncer := mview.NewTimersSyncer(cli)
+	syncer.SyncTimers(context.TODO(), do.InfoSchema())
+	timers, err := cli.GetTimers(context.TODO())
+	require.NoError(t, err)
+	require.Len(t, timers, 1)
+	timer := timers[0]
+	tbl, err := do.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("mv1"))
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("/tidb/mview/table/%d", tbl.Meta().ID), timer.Key)
+	require.Equal(t, []string{"db=test", "table=mv1"}, timer.Tags)
+	require.Equal(t, timerapi.SchedEventInterval, timer.SchedPolicyType)
+	require.Equal(t, "2h", timer.SchedPolicyExpr)
+	require.True(t, timer.Enable)
+	require.False(t, timer.Watermark.IsZero())
+	var data mview.TimerData
+	require.NoError(t, json.Unmarshal(timer.Data, &data))
+	require.Equal(t, tbl.Meta().ID, data.TableID)
+
+	// the timer is not changed if the view is not changed
+	syncer.SyncTimers(context.TODO(), do.InfoSchema())
+	timer2, err := cli.GetTimerByID(context.TODO(), timer.ID)
+	require.NoError(t, err)
+	require.Equal(t, timer.Version, timer2.Version)
+
+	// the tags are updated after the view is renamed
+	tk.MustExec("rename table mv1 to mv4")
+	syncer.SyncTimers(context.TODO(), do.InfoSchema())
+	timer2, err = cli.GetTimerByID(context.TODO(), timer.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"db=test", "table=mv4"}, timer2.Tags)
+	require.Equal(t, "2h", timer2.SchedPolicyExpr)
+
+	// the timer is deleted after the view is dropped
+	tk.MustExec("drop materialized view mv4")
+	syncer.SyncTimers(context.TODO(), do.InfoSchema())
+	timers, err = cli.GetTimers(context.TODO())
+	require.NoError(t, err)
+	require.Len(t, timers, 1)
+	syncer.SetDelayDeleteInterval(0)
+	syncer.SyncTimers(context.TODO(), do.InfoSchema())
+	timers, err = cli.GetTimers(context.TODO())
+	require.NoError(t, err)
+	require.Len(t, timers, 0)
+}
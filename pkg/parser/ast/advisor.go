@@ -83,3 +83,48 @@ func (n *MaxIndexNumClause) Restore(ctx *format.RestoreCtx) error {
 	}
 	return nil
 }
+
+var _ StmtNode = &RecommendIndexStmt{}
+
+// RecommendIndexStmt is used to recommend indexes for a query, or for the top queries in the
+// statements summary if the query is empty.
+type RecommendIndexStmt struct {
+	stmtNode
+
+	SQL     string
+	Options []RecommendIndexOption
+}
+
+// RecommendIndexOption is an option of the RECOMMEND INDEX statement, like `max_num_index = 5`.
+type RecommendIndexOption struct {
+	Option string
+	Value  uint64
+}
+
+// Restore implements Node interface.
+func (n *RecommendIndexStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("RECOMMEND INDEX RUN")
+	if n.SQL != "" {
+		ctx.WriteKeyWord(" FOR ")
+		ctx.WriteString(n.SQL)
+	}
+	for i, opt := range n.Options {
+		if i == 0 {
+			ctx.WriteKeyWord(" WITH ")
+		} else {
+			ctx.WritePlain(", ")
+		}
+		ctx.WritePlainf("%s = %d", opt.Option, opt.Value)
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *RecommendIndexStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*RecommendIndexStmt)
+	return v.Leave(n)
+}
@@ -167,6 +167,8 @@ type Visitor interface {
 // GetStmtLabel generates a label for a statement.
 func GetStmtLabel(stmtNode StmtNode) string {
 	switch x := stmtNode.(type) {
+	case *AlterEventStmt:
+		return "AlterEvent"
 	case *AlterTableStmt:
 		return "AlterTable"
 	case *AnalyzeTableStmt:
@@ -179,27 +181,46 @@ func GetStmtLabel(stmtNode StmtNode) string {
 		return "Commit"
 	case *CompactTableStmt:
 		return "CompactTable"
+	case *ChangefeedActionStmt:
+		return "ChangefeedAction"
+	case *CreateChangefeedStmt:
+		return "CreateChangefeed"
 	case *CreateDatabaseStmt:
 		return "CreateDatabase"
+	case *CreateEventStmt:
+		return "CreateEvent"
 	case *CreateIndexStmt:
 		return "CreateIndex"
 	case *CreateTableStmt:
 		return "CreateTable"
+	case *CreateTriggerStmt:
+		return "CreateTrigger"
 	case *CreateViewStmt:
 		return "CreateView"
+	case *CreateMaterializedViewStmt:
+		return "CreateMaterializedView"
 	case *CreateUserStmt:
 		return "CreateUser"
 	case *DeleteStmt:
 		return "Delete"
+	case *DropChangefeedStmt:
+		return "DropChangefeed"
 	case *DropDatabaseStmt:
 		return "DropDatabase"
+	case *DropEventStmt:
+		return "DropEvent"
 	case *DropIndexStmt:
 		return "DropIndex"
 	case *DropTableStmt:
 		if x.IsView {
 			return "DropView"
 		}
+		if x.IsMaterializedView {
+			return "DropMaterializedView"
+		}
 		return "DropTable"
+	case *DropTriggerStmt:
+		return "DropTrigger"
 	case *ExplainStmt:
 		if _, ok := x.Stmt.(*ShowStmt); ok {
 			return "DescTable"
@@ -217,6 +238,8 @@ func GetStmtLabel(stmtNode StmtNode) string {
 		return "ImportInto"
 	case *LoadDataStmt:
 		return "LoadData"
+	case *MergeStmt:
+		return "Merge"
 	case *RollbackStmt:
 		return "Rollback"
 	case *SelectStmt:
@@ -245,6 +268,8 @@ func GetStmtLabel(stmtNode StmtNode) string {
 		return "CreateBinding"
 	case *IndexAdviseStmt:
 		return "IndexAdvise"
+	case *RecommendIndexStmt:
+		return "RecommendIndex"
 	case *DropBindingStmt:
 		return "DropBinding"
 	case *TraceStmt:
@@ -255,6 +280,8 @@ func GetStmtLabel(stmtNode StmtNode) string {
 		return "Savepoint"
 	case *OptimizeTableStmt:
 		return "Optimize"
+	case *RefreshMaterializedViewStmt:
+		return "RefreshMaterializedView"
 	}
 	return "other"
 }
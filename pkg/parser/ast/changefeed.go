@@ -0,0 +1,152 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/model"
+)
+
+var (
+	_ StmtNode = &CreateChangefeedStmt{}
+	_ StmtNode = &DropChangefeedStmt{}
+	_ StmtNode = &ChangefeedActionStmt{}
+)
+
+// CreateChangefeedStmt is a statement to create a changefeed, which writes the row changes of
+// the committed transactions to a sink.
+type CreateChangefeedStmt struct {
+	stmtNode
+
+	IfNotExists bool
+	Name        model.CIStr
+	// Tables and Schema are the filter of the changefeed, both are empty if the changefeed
+	// captures the changes of all the tables.
+	Tables []*TableName
+	Schema model.CIStr
+	// SinkURI is the URI of the sink, e.g. `file:///tmp/cdc.log`.
+	SinkURI string
+	// Format is the format of the events, it's empty if not specified.
+	Format string
+}
+
+// Restore implements Node interface.
+func (n *CreateChangefeedStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE CHANGEFEED ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	ctx.WriteName(n.Name.O)
+	if len(n.Tables) > 0 {
+		ctx.WriteKeyWord(" FOR TABLE ")
+		for i, tbl := range n.Tables {
+			if i > 0 {
+				ctx.WritePlain(", ")
+			}
+			if err := tbl.Restore(ctx); err != nil {
+				return errors.Annotatef(err, "An error occurred while restore CreateChangefeedStmt.Tables[%d]", i)
+			}
+		}
+	} else if n.Schema.O != "" {
+		ctx.WriteKeyWord(" FOR DATABASE ")
+		ctx.WriteName(n.Schema.O)
+	}
+	ctx.WriteKeyWord(" INTO ")
+	ctx.WriteString(n.SinkURI)
+	if n.Format != "" {
+		ctx.WriteKeyWord(" FORMAT ")
+		ctx.WriteString(n.Format)
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateChangefeedStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateChangefeedStmt)
+	for i, tbl := range n.Tables {
+		node, ok := tbl.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Tables[i] = node.(*TableName)
+	}
+	return v.Leave(n)
+}
+
+// DropChangefeedStmt is a statement to drop a changefeed.
+type DropChangefeedStmt struct {
+	stmtNode
+
+	IfExists bool
+	Name     model.CIStr
+}
+
+// Restore implements Node interface.
+func (n *DropChangefeedStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DROP CHANGEFEED ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	ctx.WriteName(n.Name.O)
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *DropChangefeedStmt) Accept(v Visitor) (Node, bool) {
+	newNode, _ := v.Enter(n)
+	return v.Leave(newNode)
+}
+
+// ChangefeedActionTp is the type of ChangefeedActionStmt.
+type ChangefeedActionTp int
+
+// ChangefeedActionTp types.
+const (
+	ChangefeedPause ChangefeedActionTp = iota
+	ChangefeedResume
+)
+
+// ChangefeedActionStmt is a statement to pause or resume a changefeed.
+type ChangefeedActionStmt struct {
+	stmtNode
+
+	Tp   ChangefeedActionTp
+	Name model.CIStr
+}
+
+// Restore implements Node interface.
+func (n *ChangefeedActionStmt) Restore(ctx *format.RestoreCtx) error {
+	switch n.Tp {
+	case ChangefeedPause:
+		ctx.WriteKeyWord("PAUSE CHANGEFEED ")
+	case ChangefeedResume:
+		ctx.WriteKeyWord("RESUME CHANGEFEED ")
+	default:
+		return errors.Errorf("invalid changefeed action type: %d", n.Tp)
+	}
+	ctx.WriteName(n.Name.O)
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *ChangefeedActionStmt) Accept(v Visitor) (Node, bool) {
+	newNode, _ := v.Enter(n)
+	return v.Leave(newNode)
+}
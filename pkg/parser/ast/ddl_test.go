@@ -45,6 +45,9 @@ func TestDDLVisitorCover(t *testing.T) {
 		{&CreateIndexStmt{Table: &TableName{}}, 0, 0},
 		{&CreateTableStmt{Table: &TableName{}, ReferTable: &TableName{}}, 0, 0},
 		{&CreateViewStmt{ViewName: &TableName{}, Select: &SelectStmt{}}, 0, 0},
+		{&CreateMaterializedViewStmt{ViewName: &TableName{}, Select: &SelectStmt{}, RefreshInterval: ce}, 1, 1},
+		{&CreateTriggerStmt{TriggerName: &TableName{}, Table: &TableName{}, Body: &SetStmt{Variables: []*VariableAssignment{{Value: ce}}}}, 1, 1},
+		{&DropTriggerStmt{TriggerName: &TableName{}}, 0, 0},
 		{&AlterTableSpec{}, 0, 0},
 		{&ColumnDef{Name: &ColumnName{}, Options: []*ColumnOption{{Expr: ce}}}, 1, 1},
 		{&ColumnOption{Expr: ce}, 1, 1},
@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/format"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/types"
 )
 
 var (
@@ -531,6 +532,10 @@ type TableSource struct {
 
 	// AsName is the alias name of the table source.
 	AsName model.CIStr
+
+	// Lateral indicates the derived table is preceded by LATERAL, so it can
+	// refer to the columns of the tables preceding it in the FROM clause.
+	Lateral bool
 }
 
 func (*TableSource) resultSet() {}
@@ -576,6 +581,9 @@ func (n *TableSource) Restore(ctx *format.RestoreCtx) error {
 			ctx.WritePlain(")")
 		}
 	} else {
+		if n.Lateral {
+			ctx.WriteKeyWord("LATERAL ")
+		}
 		if needParen {
 			ctx.WritePlain("(")
 		}
@@ -976,6 +984,438 @@ func (n *OrderByClause) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// JSONTableColumnType is the type of a column definition in the JSON_TABLE COLUMNS clause.
+type JSONTableColumnType int8
+
+const (
+	// JSONTableColumnPath is `name type PATH path [on_empty] [on_error]`.
+	JSONTableColumnPath JSONTableColumnType = iota
+	// JSONTableColumnExists is `name type EXISTS PATH path`.
+	JSONTableColumnExists
+	// JSONTableColumnOrdinality is `name FOR ORDINALITY`.
+	JSONTableColumnOrdinality
+	// JSONTableColumnNested is `NESTED [PATH] path COLUMNS (...)`.
+	JSONTableColumnNested
+)
+
+// JSONTableResponseType is the behavior of a JSON_TABLE column when the path
+// matches nothing (ON EMPTY) or the value can't be converted (ON ERROR).
+type JSONTableResponseType int8
+
+const (
+	// JSONTableResponseNull sets the column to NULL.
+	JSONTableResponseNull JSONTableResponseType = iota
+	// JSONTableResponseError raises an error.
+	JSONTableResponseError
+	// JSONTableResponseDefault sets the column to the default value.
+	JSONTableResponseDefault
+)
+
+// JSONTableOnResponse represents the `{NULL | ERROR | DEFAULT json_string} ON {EMPTY | ERROR}` clause.
+type JSONTableOnResponse struct {
+	Tp JSONTableResponseType
+	// Default is only valid when Tp is JSONTableResponseDefault.
+	Default string
+}
+
+// Restore implements Node interface.
+func (n *JSONTableOnResponse) Restore(ctx *format.RestoreCtx) error {
+	switch n.Tp {
+	case JSONTableResponseNull:
+		ctx.WriteKeyWord("NULL")
+	case JSONTableResponseError:
+		ctx.WriteKeyWord("ERROR")
+	case JSONTableResponseDefault:
+		ctx.WriteKeyWord("DEFAULT ")
+		ctx.WriteString(n.Default)
+	default:
+		return errors.Errorf("invalid JSONTableResponseType: %d", n.Tp)
+	}
+	return nil
+}
+
+// JSONTableColumn represents a column definition in the JSON_TABLE COLUMNS clause.
+type JSONTableColumn struct {
+	node
+
+	Tp JSONTableColumnType
+	// Name is empty for the NESTED PATH column.
+	Name      model.CIStr
+	FieldType *types.FieldType
+	Path      string
+	OnEmpty   *JSONTableOnResponse
+	OnError   *JSONTableOnResponse
+	// NestedColumns is only valid when Tp is JSONTableColumnNested.
+	NestedColumns []*JSONTableColumn
+}
+
+// Restore implements Node interface.
+func (n *JSONTableColumn) Restore(ctx *format.RestoreCtx) error {
+	if n.Tp == JSONTableColumnNested {
+		ctx.WriteKeyWord("NESTED PATH ")
+		ctx.WriteString(n.Path)
+		ctx.WriteKeyWord(" COLUMNS ")
+		return restoreJSONTableColumns(ctx, n.NestedColumns)
+	}
+	ctx.WriteName(n.Name.O)
+	switch n.Tp {
+	case JSONTableColumnOrdinality:
+		ctx.WriteKeyWord(" FOR ORDINALITY")
+		return nil
+	case JSONTableColumnExists:
+		ctx.WritePlain(" ")
+		if err := n.FieldType.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore JSONTableColumn.FieldType")
+		}
+		ctx.WriteKeyWord(" EXISTS PATH ")
+		ctx.WriteString(n.Path)
+		return nil
+	}
+	ctx.WritePlain(" ")
+	if err := n.FieldType.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore JSONTableColumn.FieldType")
+	}
+	ctx.WriteKeyWord(" PATH ")
+	ctx.WriteString(n.Path)
+	if n.OnEmpty != nil {
+		ctx.WritePlain(" ")
+		if err := n.OnEmpty.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore JSONTableColumn.OnEmpty")
+		}
+		ctx.WriteKeyWord(" ON EMPTY")
+	}
+	if n.OnError != nil {
+		ctx.WritePlain(" ")
+		if err := n.OnError.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore JSONTableColumn.OnError")
+		}
+		ctx.WriteKeyWord(" ON ERROR")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *JSONTableColumn) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*JSONTableColumn)
+	for i, col := range n.NestedColumns {
+		node, ok := col.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.NestedColumns[i] = node.(*JSONTableColumn)
+	}
+	return v.Leave(n)
+}
+
+func restoreJSONTableColumns(ctx *format.RestoreCtx, cols []*JSONTableColumn) error {
+	ctx.WritePlain("(")
+	for i, col := range cols {
+		if i != 0 {
+			ctx.WritePlain(", ")
+		}
+		if err := col.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore JSONTable.Columns[%d]", i)
+		}
+	}
+	ctx.WritePlain(")")
+	return nil
+}
+
+// JSONTable represents the JSON_TABLE table function, which extracts data from
+// a JSON document and returns it as a relational table.
+// See https://dev.mysql.com/doc/refman/8.0/en/json-table-functions.html
+type JSONTable struct {
+	node
+
+	// Expr is the JSON document. It may refer to columns of the tables
+	// preceding the JSON_TABLE in the same FROM clause.
+	Expr    ExprNode
+	Path    string
+	Columns []*JSONTableColumn
+}
+
+func (*JSONTable) resultSet() {}
+
+// Restore implements Node interface.
+func (n *JSONTable) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("JSON_TABLE")
+	ctx.WritePlain("(")
+	if err := n.Expr.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore JSONTable.Expr")
+	}
+	ctx.WritePlain(", ")
+	ctx.WriteString(n.Path)
+	ctx.WriteKeyWord(" COLUMNS ")
+	if err := restoreJSONTableColumns(ctx, n.Columns); err != nil {
+		return err
+	}
+	ctx.WritePlain(")")
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *JSONTable) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*JSONTable)
+	node, ok := n.Expr.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Expr = node.(ExprNode)
+	for i, col := range n.Columns {
+		node, ok := col.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Columns[i] = node.(*JSONTableColumn)
+	}
+	return v.Leave(n)
+}
+
+func restorePivotSource(ctx *format.RestoreCtx, source ResultSetNode) error {
+	_, isJoin := source.(*Join)
+	if isJoin {
+		ctx.WritePlain("(")
+	}
+	if err := source.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore the source of PIVOT or UNPIVOT")
+	}
+	if isJoin {
+		ctx.WritePlain(")")
+	}
+	return nil
+}
+
+// PivotItem is an aggregate function or a value with an optional alias in the PIVOT clause.
+type PivotItem struct {
+	node
+
+	Expr   ExprNode
+	AsName model.CIStr
+}
+
+// Restore implements Node interface.
+func (n *PivotItem) Restore(ctx *format.RestoreCtx) error {
+	if err := n.Expr.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore PivotItem.Expr")
+	}
+	if asName := n.AsName.String(); asName != "" {
+		ctx.WriteKeyWord(" AS ")
+		ctx.WriteName(asName)
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *PivotItem) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*PivotItem)
+	node, ok := n.Expr.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Expr = node.(ExprNode)
+	return v.Leave(n)
+}
+
+func restorePivotItems(ctx *format.RestoreCtx, items []*PivotItem) error {
+	for i, item := range items {
+		if i != 0 {
+			ctx.WritePlain(", ")
+		}
+		if err := item.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore PivotItem[%d]", i)
+		}
+	}
+	return nil
+}
+
+func acceptPivotItems(v Visitor, items []*PivotItem) bool {
+	for i, item := range items {
+		node, ok := item.Accept(v)
+		if !ok {
+			return false
+		}
+		items[i] = node.(*PivotItem)
+	}
+	return true
+}
+
+// PivotTable represents `source PIVOT (agg [AS alias], ... FOR column IN (value [AS alias], ...))`,
+// which rotates the rows of the source into columns. Each pair of an aggregate function and a value
+// produces a column aggregating the rows whose column is equal to the value, grouped by the other
+// columns of the source.
+type PivotTable struct {
+	node
+
+	Source     ResultSetNode
+	Aggregates []*PivotItem
+	ForColumn  *ColumnName
+	Values     []*PivotItem
+}
+
+func (*PivotTable) resultSet() {}
+
+// Restore implements Node interface.
+func (n *PivotTable) Restore(ctx *format.RestoreCtx) error {
+	if err := restorePivotSource(ctx, n.Source); err != nil {
+		return err
+	}
+	ctx.WriteKeyWord(" PIVOT ")
+	ctx.WritePlain("(")
+	if err := restorePivotItems(ctx, n.Aggregates); err != nil {
+		return err
+	}
+	ctx.WriteKeyWord(" FOR ")
+	if err := n.ForColumn.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore PivotTable.ForColumn")
+	}
+	ctx.WriteKeyWord(" IN ")
+	ctx.WritePlain("(")
+	if err := restorePivotItems(ctx, n.Values); err != nil {
+		return err
+	}
+	ctx.WritePlain("))")
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *PivotTable) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*PivotTable)
+	node, ok := n.Source.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Source = node.(ResultSetNode)
+	if !acceptPivotItems(v, n.Aggregates) {
+		return n, false
+	}
+	node, ok = n.ForColumn.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.ForColumn = node.(*ColumnName)
+	if !acceptPivotItems(v, n.Values) {
+		return n, false
+	}
+	return v.Leave(n)
+}
+
+// UnpivotColumn is a column with an optional alias in the UNPIVOT clause. The alias is used
+// as the value of the name column instead of the column name.
+type UnpivotColumn struct {
+	node
+
+	Column *ColumnName
+	AsName model.CIStr
+}
+
+// Restore implements Node interface.
+func (n *UnpivotColumn) Restore(ctx *format.RestoreCtx) error {
+	if err := n.Column.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore UnpivotColumn.Column")
+	}
+	if asName := n.AsName.String(); asName != "" {
+		ctx.WriteKeyWord(" AS ")
+		ctx.WriteName(asName)
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *UnpivotColumn) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*UnpivotColumn)
+	node, ok := n.Column.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Column = node.(*ColumnName)
+	return v.Leave(n)
+}
+
+// UnpivotTable represents `source UNPIVOT [{INCLUDE | EXCLUDE} NULLS] (value FOR name IN (column [AS alias], ...))`,
+// which rotates the columns of the source into rows. Each row of the source produces a row for each
+// column, the name column is the name of the column and the value column is the value of it. The rows
+// whose values are NULL are excluded unless INCLUDE NULLS is specified.
+type UnpivotTable struct {
+	node
+
+	Source       ResultSetNode
+	IncludeNulls bool
+	ValueColumn  model.CIStr
+	NameColumn   model.CIStr
+	Columns      []*UnpivotColumn
+}
+
+func (*UnpivotTable) resultSet() {}
+
+// Restore implements Node interface.
+func (n *UnpivotTable) Restore(ctx *format.RestoreCtx) error {
+	if err := restorePivotSource(ctx, n.Source); err != nil {
+		return err
+	}
+	ctx.WriteKeyWord(" UNPIVOT ")
+	if n.IncludeNulls {
+		ctx.WriteKeyWord("INCLUDE NULLS ")
+	}
+	ctx.WritePlain("(")
+	ctx.WriteName(n.ValueColumn.O)
+	ctx.WriteKeyWord(" FOR ")
+	ctx.WriteName(n.NameColumn.O)
+	ctx.WriteKeyWord(" IN ")
+	ctx.WritePlain("(")
+	for i, col := range n.Columns {
+		if i != 0 {
+			ctx.WritePlain(", ")
+		}
+		if err := col.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore UnpivotTable.Columns[%d]", i)
+		}
+	}
+	ctx.WritePlain("))")
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *UnpivotTable) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*UnpivotTable)
+	node, ok := n.Source.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Source = node.(ResultSetNode)
+	for i, col := range n.Columns {
+		node, ok := col.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Columns[i] = node.(*UnpivotColumn)
+	}
+	return v.Leave(n)
+}
+
 type SampleMethodType int8
 
 const (
@@ -1163,6 +1603,8 @@ type SelectStmt struct {
 	Having *HavingClause
 	// WindowSpecs is the window specification list.
 	WindowSpecs []WindowSpec
+	// Qualify is the condition on the window function results.
+	Qualify ExprNode
 	// OrderBy is the ordering expression list.
 	OrderBy *OrderByClause
 	// Limit is the limit clause.
@@ -1359,6 +1801,13 @@ func (n *SelectStmt) Restore(ctx *format.RestoreCtx) error {
 				}
 			}
 		}
+
+		if n.Qualify != nil {
+			ctx.WriteKeyWord(" QUALIFY ")
+			if err := n.Qualify.Restore(ctx); err != nil {
+				return errors.Annotate(err, "An error occurred while restore SelectStmt.Qualify")
+			}
+		}
 	case SelectStmtKindTable:
 		if err := n.From.Restore(ctx); err != nil {
 			return errors.Annotate(err, "An error occurred while restore SelectStmt.From")
@@ -1543,6 +1992,14 @@ func (n *SelectStmt) Accept(v Visitor) (Node, bool) {
 		n.WindowSpecs[i] = *node.(*WindowSpec)
 	}
 
+	if n.Qualify != nil {
+		node, ok := n.Qualify.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Qualify = node.(ExprNode)
+	}
+
 	if n.OrderBy != nil {
 		node, ok := n.OrderBy.Accept(v)
 		if !ok {
@@ -1569,6 +2026,14 @@ func (n *SelectStmt) Accept(v Visitor) (Node, bool) {
 		}
 	}
 
+	if n.SelectIntoOpt != nil {
+		node, ok := n.SelectIntoOpt.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.SelectIntoOpt = node.(*SelectIntoOption)
+	}
+
 	return v.Leave(n)
 }
 
@@ -2302,6 +2767,8 @@ type InsertStmt struct {
 	// TableHints represents the table level Optimizer Hint for join type.
 	TableHints     []*TableOptimizerHint
 	PartitionNames []model.CIStr
+	// Returning is the field list of the RETURNING clause, the fields are evaluated on the written rows.
+	Returning *FieldList
 }
 
 // Restore implements Node interface.
@@ -2427,6 +2894,12 @@ func (n *InsertStmt) Restore(ctx *format.RestoreCtx) error {
 			}
 		}
 	}
+	if n.Returning != nil {
+		ctx.WriteKeyWord(" RETURNING ")
+		if err := n.Returning.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore InsertStmt.Returning")
+		}
+	}
 
 	return nil
 }
@@ -2476,6 +2949,13 @@ func (n *InsertStmt) Accept(v Visitor) (Node, bool) {
 		}
 		n.OnDuplicate[i] = node.(*Assignment)
 	}
+	if n.Returning != nil {
+		node, ok := n.Returning.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Returning = node.(*FieldList)
+	}
 	return v.Leave(n)
 }
 
@@ -2535,6 +3015,8 @@ type DeleteStmt struct {
 	// TableHints represents the table level Optimizer Hint for join type.
 	TableHints []*TableOptimizerHint
 	With       *WithClause
+	// Returning is the field list of the RETURNING clause, the fields are evaluated on the deleted rows.
+	Returning *FieldList
 }
 
 // Restore implements Node interface.
@@ -2625,6 +3107,13 @@ func (n *DeleteStmt) Restore(ctx *format.RestoreCtx) error {
 		}
 	}
 
+	if n.Returning != nil {
+		ctx.WriteKeyWord(" RETURNING ")
+		if err := n.Returning.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore DeleteStmt.Returning")
+		}
+	}
+
 	return nil
 }
 
@@ -2678,6 +3167,13 @@ func (n *DeleteStmt) Accept(v Visitor) (Node, bool) {
 		}
 		n.Limit = node.(*Limit)
 	}
+	if n.Returning != nil {
+		node, ok = n.Returning.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Returning = node.(*FieldList)
+	}
 	return v.Leave(n)
 }
 
@@ -2787,6 +3283,8 @@ type UpdateStmt struct {
 	MultipleTable bool
 	TableHints    []*TableOptimizerHint
 	With          *WithClause
+	// Returning is the field list of the RETURNING clause, the fields are evaluated on the updated rows.
+	Returning *FieldList
 }
 
 // Restore implements Node interface.
@@ -2866,6 +3364,13 @@ func (n *UpdateStmt) Restore(ctx *format.RestoreCtx) error {
 		}
 	}
 
+	if n.Returning != nil {
+		ctx.WriteKeyWord(" RETURNING ")
+		if err := n.Returning.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occur while restore UpdateStmt.Returning")
+		}
+	}
+
 	return nil
 }
 
@@ -2916,6 +3421,13 @@ func (n *UpdateStmt) Accept(v Visitor) (Node, bool) {
 		}
 		n.Limit = node.(*Limit)
 	}
+	if n.Returning != nil {
+		node, ok = n.Returning.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Returning = node.(*FieldList)
+	}
 	return v.Leave(n)
 }
 
@@ -2934,6 +3446,211 @@ func (n *UpdateStmt) TableRefsJoin() (*Join, bool) {
 	return n.TableRefs.TableRefs, true
 }
 
+// MergeStmt is a statement to merge the rows of a source into a target table.
+// See https://en.wikipedia.org/wiki/Merge_(SQL)
+type MergeStmt struct {
+	dmlNode
+
+	TableHints []*TableOptimizerHint
+	// Target is the table which the rows are merged into.
+	Target *TableSource
+	// Source is the table, the subquery or the join which the rows are merged from.
+	Source ResultSetNode
+	// On is the condition to match a source row with the target rows.
+	On ExprNode
+	// WhenClauses are the WHEN [NOT] MATCHED clauses, the first clause whose condition is
+	// satisfied decides the action on a row.
+	WhenClauses []*MergeWhenClause
+}
+
+// Restore implements Node interface.
+func (n *MergeStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("MERGE ")
+	if len(n.TableHints) != 0 {
+		ctx.WritePlain("/*+ ")
+		for i, tableHint := range n.TableHints {
+			if i != 0 {
+				ctx.WritePlain(" ")
+			}
+			if err := tableHint.Restore(ctx); err != nil {
+				return errors.Annotatef(err, "An error occurred while restore MergeStmt.TableHints[%d]", i)
+			}
+		}
+		ctx.WritePlain("*/ ")
+	}
+	ctx.WriteKeyWord("INTO ")
+	if err := n.Target.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore MergeStmt.Target")
+	}
+	ctx.WriteKeyWord(" USING ")
+	if err := n.Source.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore MergeStmt.Source")
+	}
+	ctx.WriteKeyWord(" ON ")
+	if err := n.On.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore MergeStmt.On")
+	}
+	for i, clause := range n.WhenClauses {
+		ctx.WritePlain(" ")
+		if err := clause.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore MergeStmt.WhenClauses[%d]", i)
+		}
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *MergeStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*MergeStmt)
+	node, ok := n.Target.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Target = node.(*TableSource)
+	node, ok = n.Source.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Source = node.(ResultSetNode)
+	node, ok = n.On.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.On = node.(ExprNode)
+	for i, clause := range n.WhenClauses {
+		node, ok = clause.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.WhenClauses[i] = node.(*MergeWhenClause)
+	}
+	return v.Leave(n)
+}
+
+// MergeActionType is the action of a WHEN clause of the MERGE statement.
+type MergeActionType int
+
+// MergeActionType types.
+const (
+	MergeActionUpdate MergeActionType = iota
+	MergeActionDelete
+	MergeActionInsert
+)
+
+// MergeWhenClause is a WHEN [NOT] MATCHED clause of the MERGE statement.
+type MergeWhenClause struct {
+	node
+
+	// Matched indicates the clause is applied to the source rows which match a target row.
+	Matched bool
+	// Condition is the optional AND condition of the clause.
+	Condition ExprNode
+	Action    MergeActionType
+	// Assignments is the SET list of the UPDATE action.
+	Assignments []*Assignment
+	// Columns and Values are the column list and the value list of the INSERT action.
+	Columns []*ColumnName
+	Values  []ExprNode
+}
+
+// Restore implements Node interface.
+func (n *MergeWhenClause) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("WHEN ")
+	if !n.Matched {
+		ctx.WriteKeyWord("NOT ")
+	}
+	ctx.WriteKeyWord("MATCHED")
+	if n.Condition != nil {
+		ctx.WriteKeyWord(" AND ")
+		if err := n.Condition.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore MergeWhenClause.Condition")
+		}
+	}
+	ctx.WriteKeyWord(" THEN ")
+	switch n.Action {
+	case MergeActionUpdate:
+		ctx.WriteKeyWord("UPDATE SET ")
+		for i, assignment := range n.Assignments {
+			if i != 0 {
+				ctx.WritePlain(", ")
+			}
+			if err := assignment.Restore(ctx); err != nil {
+				return errors.Annotatef(err, "An error occurred while restore MergeWhenClause.Assignments[%d]", i)
+			}
+		}
+	case MergeActionDelete:
+		ctx.WriteKeyWord("DELETE")
+	case MergeActionInsert:
+		ctx.WriteKeyWord("INSERT ")
+		if n.Columns != nil {
+			ctx.WritePlain("(")
+			for i, column := range n.Columns {
+				if i != 0 {
+					ctx.WritePlain(",")
+				}
+				if err := column.Restore(ctx); err != nil {
+					return errors.Annotatef(err, "An error occurred while restore MergeWhenClause.Columns[%d]", i)
+				}
+			}
+			ctx.WritePlain(") ")
+		}
+		ctx.WriteKeyWord("VALUES ")
+		ctx.WritePlain("(")
+		for i, value := range n.Values {
+			if i != 0 {
+				ctx.WritePlain(",")
+			}
+			if err := value.Restore(ctx); err != nil {
+				return errors.Annotatef(err, "An error occurred while restore MergeWhenClause.Values[%d]", i)
+			}
+		}
+		ctx.WritePlain(")")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *MergeWhenClause) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*MergeWhenClause)
+	if n.Condition != nil {
+		node, ok := n.Condition.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Condition = node.(ExprNode)
+	}
+	for i, assignment := range n.Assignments {
+		node, ok := assignment.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Assignments[i] = node.(*Assignment)
+	}
+	for i, column := range n.Columns {
+		node, ok := column.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Columns[i] = node.(*ColumnName)
+	}
+	for i, value := range n.Values {
+		node, ok := value.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Values[i] = node.(ExprNode)
+	}
+	return v.Leave(n)
+}
+
 // Limit is the limit clause.
 type Limit struct {
 	node
@@ -3051,6 +3768,9 @@ const (
 	ShowCreateProcedure
 	ShowBinlogStatus
 	ShowReplicaStatus
+	ShowCreateEvent
+	ShowChangefeeds
+	ShowCreateFunction
 )
 
 const (
@@ -3074,7 +3794,8 @@ type ShowStmt struct {
 	Tp     ShowStmtType // Databases/Tables/Columns/....
 	DBName string
 	Table  *TableName // Used for showing columns.
-	// Procedure's naming method is consistent with the table name
+	// Procedure's naming method is consistent with the table name, it's also the name of
+	// the event of `SHOW CREATE EVENT`.
 	Procedure         *TableName
 	Partition         model.CIStr // Used for showing partition.
 	Column            *ColumnName // Used for `desc table column`.
@@ -3152,6 +3873,16 @@ func (n *ShowStmt) Restore(ctx *format.RestoreCtx) error {
 		if err := n.Procedure.Restore(ctx); err != nil {
 			return errors.Annotate(err, "An error occurred while restore ShowStmt.Procedure")
 		}
+	case ShowCreateFunction:
+		ctx.WriteKeyWord("CREATE FUNCTION ")
+		if err := n.Procedure.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore ShowStmt.Procedure")
+		}
+	case ShowCreateEvent:
+		ctx.WriteKeyWord("CREATE EVENT ")
+		if err := n.Procedure.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore ShowStmt.Procedure")
+		}
 	case ShowCreateView:
 		ctx.WriteKeyWord("CREATE VIEW ")
 		if err := n.Table.Restore(ctx); err != nil {
@@ -3382,6 +4113,8 @@ func (n *ShowStmt) Restore(ctx *format.RestoreCtx) error {
 		case ShowEvents:
 			ctx.WriteKeyWord("EVENTS")
 			restoreShowDatabaseNameOpt()
+		case ShowChangefeeds:
+			ctx.WriteKeyWord("CHANGEFEEDS")
 		case ShowPlugins:
 			ctx.WriteKeyWord("PLUGINS")
 		case ShowBindings:
@@ -3622,19 +4355,40 @@ type SelectIntoOption struct {
 
 	Tp         SelectIntoType
 	FileName   string
+	Format     *string
 	FieldsInfo *FieldsClause
 	LinesInfo  *LinesClause
+	Options    []*LoadDataOpt
+	// Variables are the targets of `SELECT ... INTO var_list`. A user variable is a *VariableExpr,
+	// and a local variable of the stored procedure is a *ColumnNameExpr.
+	Variables []ExprNode
 }
 
 // Restore implements Node interface.
 func (n *SelectIntoOption) Restore(ctx *format.RestoreCtx) error {
+	if n.Tp == SelectIntoVars {
+		ctx.WriteKeyWord("INTO ")
+		for i, v := range n.Variables {
+			if i != 0 {
+				ctx.WritePlain(",")
+			}
+			if err := v.Restore(ctx); err != nil {
+				return errors.Annotatef(err, "An error occurred while restore SelectInto.Variables[%d]", i)
+			}
+		}
+		return nil
+	}
 	if n.Tp != SelectIntoOutfile {
-		// only support SELECT/TABLE/VALUES ... INTO OUTFILE statement now
+		// only support SELECT/TABLE/VALUES ... INTO OUTFILE and INTO var_list statement now
 		return errors.New("Unsupported SelectionInto type")
 	}
 
 	ctx.WriteKeyWord("INTO OUTFILE ")
 	ctx.WriteString(n.FileName)
+	if n.Format != nil {
+		ctx.WriteKeyWord(" FORMAT ")
+		ctx.WriteString(*n.Format)
+	}
 	if n.FieldsInfo != nil {
 		if err := n.FieldsInfo.Restore(ctx); err != nil {
 			return errors.Annotate(err, "An error occurred while restore SelectInto.FieldsInfo")
@@ -3645,6 +4399,18 @@ func (n *SelectIntoOption) Restore(ctx *format.RestoreCtx) error {
 			return errors.Annotate(err, "An error occurred while restore SelectInto.LinesInfo")
 		}
 	}
+	if len(n.Options) > 0 {
+		ctx.WriteKeyWord(" WITH")
+		for i, option := range n.Options {
+			if i != 0 {
+				ctx.WritePlain(",")
+			}
+			ctx.WritePlain(" ")
+			if err := option.Restore(ctx); err != nil {
+				return errors.Annotatef(err, "An error occurred while restore SelectInto.Options")
+			}
+		}
+	}
 	return nil
 }
 
@@ -3654,6 +4420,14 @@ func (n *SelectIntoOption) Accept(v Visitor) (Node, bool) {
 	if skipChildren {
 		return v.Leave(newNode)
 	}
+	n = newNode.(*SelectIntoOption)
+	for i, val := range n.Variables {
+		node, ok := val.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Variables[i] = node.(ExprNode)
+	}
 	return v.Leave(n)
 }
 
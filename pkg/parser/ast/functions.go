@@ -270,6 +270,7 @@ const (
 	FormatBytes          = "format_bytes"
 	FormatNanoTime       = "format_nano_time"
 	CurrentResourceGroup = "current_resource_group"
+	QueryAttrString      = "mysql_query_attribute_string"
 
 	// control functions
 	If     = "if"
@@ -356,6 +357,40 @@ const (
 	JSONKeys          = "json_keys"
 	JSONLength        = "json_length"
 
+	// vector functions (tidb extension)
+	VecDims                 = "vec_dims"
+	VecL1Distance           = "vec_l1_distance"
+	VecL2Distance           = "vec_l2_distance"
+	VecNegativeInnerProduct = "vec_negative_inner_product"
+	VecCosineDistance       = "vec_cosine_distance"
+	VecL2Norm               = "vec_l2_norm"
+	VecFromText             = "vec_from_text"
+	VecAsText               = "vec_as_text"
+
+	// spatial functions.
+	GeomPoint          = "point"
+	STAsBinary         = "st_asbinary"
+	STAsText           = "st_astext"
+	STAsWKB            = "st_aswkb"
+	STAsWKT            = "st_aswkt"
+	STContains         = "st_contains"
+	STDistance         = "st_distance"
+	STDistanceSphere   = "st_distance_sphere"
+	STGeomFromText     = "st_geomfromtext"
+	STGeomFromWKB      = "st_geomfromwkb"
+	STGeometryFromText = "st_geometryfromtext"
+	STGeometryFromWKB  = "st_geometryfromwkb"
+	STGeometryType     = "st_geometrytype"
+	STIntersects       = "st_intersects"
+	STPointFromText    = "st_pointfromtext"
+	STSRID             = "st_srid"
+	STWithin           = "st_within"
+	STX                = "st_x"
+	STY                = "st_y"
+
+	// fulltext functions (tidb extension), MATCH ... AGAINST is rewritten to it.
+	FTSMatchAgainst = "fts_match_against"
+
 	// TiDB internal function.
 	TiDBDecodeKey       = "tidb_decode_key"
 	TiDBDecodeBase64Key = "tidb_decode_base64_key"
@@ -765,6 +765,120 @@ func (n *RollbackStmt) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// XAStmtType is the type of XA statement.
+type XAStmtType int
+
+// XA statement types.
+const (
+	XAStart XAStmtType = iota + 1
+	XAEnd
+	XAPrepare
+	XACommit
+	XARollback
+	XARecover
+)
+
+// DefaultXIDFormatID is the formatID of an XID when it is not specified.
+const DefaultXIDFormatID = 1
+
+// XID is the identifier of an XA transaction branch.
+type XID struct {
+	// Gtrid is the global transaction identifier.
+	Gtrid string
+	// Bqual is the branch qualifier.
+	Bqual string
+	// FormatID identifies the format of Gtrid and Bqual.
+	FormatID uint64
+}
+
+// Restore writes the XID as `gtrid[, bqual[, formatID]]`.
+func (x *XID) Restore(ctx *format.RestoreCtx) {
+	ctx.WriteString(x.Gtrid)
+	if x.Bqual != "" || x.FormatID != DefaultXIDFormatID {
+		ctx.WritePlain(",")
+		ctx.WriteString(x.Bqual)
+	}
+	if x.FormatID != DefaultXIDFormatID {
+		ctx.WritePlainf(",%d", x.FormatID)
+	}
+}
+
+// String implements fmt.Stringer interface.
+func (x *XID) String() string {
+	var sb strings.Builder
+	x.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb))
+	return sb.String()
+}
+
+// XAStmt is a statement for XA transactions.
+// See https://dev.mysql.com/doc/refman/8.0/en/xa-statements.html
+type XAStmt struct {
+	stmtNode
+
+	Tp  XAStmtType
+	XID *XID
+	// Join and Resume are the options of XA START.
+	Join   bool
+	Resume bool
+	// Suspend and ForMigrate are the options of XA END.
+	Suspend    bool
+	ForMigrate bool
+	// OnePhase is the option of XA COMMIT.
+	OnePhase bool
+	// ConvertXID is the option of XA RECOVER.
+	ConvertXID bool
+}
+
+// Restore implements Node interface.
+func (n *XAStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("XA ")
+	switch n.Tp {
+	case XAStart:
+		ctx.WriteKeyWord("START ")
+	case XAEnd:
+		ctx.WriteKeyWord("END ")
+	case XAPrepare:
+		ctx.WriteKeyWord("PREPARE ")
+	case XACommit:
+		ctx.WriteKeyWord("COMMIT ")
+	case XARollback:
+		ctx.WriteKeyWord("ROLLBACK ")
+	case XARecover:
+		ctx.WriteKeyWord("RECOVER")
+		if n.ConvertXID {
+			ctx.WriteKeyWord(" CONVERT XID")
+		}
+		return nil
+	default:
+		return errors.Errorf("invalid XA statement type: %d", n.Tp)
+	}
+	n.XID.Restore(ctx)
+	switch {
+	case n.Join:
+		ctx.WriteKeyWord(" JOIN")
+	case n.Resume:
+		ctx.WriteKeyWord(" RESUME")
+	case n.Suspend:
+		ctx.WriteKeyWord(" SUSPEND")
+		if n.ForMigrate {
+			ctx.WriteKeyWord(" FOR MIGRATE")
+		}
+	case n.OnePhase:
+		ctx.WriteKeyWord(" ONE PHASE")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *XAStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*XAStmt)
+	return v.Leave(n)
+}
+
 // UseStmt is a statement to use the DBName database as the current database.
 // See https://dev.mysql.com/doc/refman/5.7/en/use.html
 type UseStmt struct {
@@ -879,6 +993,7 @@ const (
 	FlushHosts
 	FlushLogs
 	FlushClientErrorsSummary
+	FlushCardinalityCorrections
 )
 
 // LogType is the log type used in FLUSH statement.
@@ -962,6 +1077,8 @@ func (n *FlushStmt) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord(logType)
 	case FlushClientErrorsSummary:
 		ctx.WriteKeyWord("CLIENT_ERRORS_SUMMARY")
+	case FlushCardinalityCorrections:
+		ctx.WriteKeyWord("CARDINALITY_CORRECTIONS")
 	default:
 		return errors.New("Unsupported type of FlushStmt")
 	}
@@ -2161,6 +2278,16 @@ const (
 	StatsTypeCorrelation
 )
 
+// The type of the multi-column statistics is StatsTypeMultiColumn combined with the flags of their kinds.
+const (
+	StatsTypeMultiColumn uint8 = 1 << (iota + 2)
+	StatsKindNDistinct
+	StatsKindDependencies
+	StatsKindMCV
+
+	StatsKindAll = StatsKindNDistinct | StatsKindDependencies | StatsKindMCV
+)
+
 // StatisticsSpec is the specification for ADD /DROP STATISTICS.
 type StatisticsSpec struct {
 	StatsName string
@@ -2174,6 +2301,8 @@ type StatisticsSpec struct {
 //	CREATE STATISTICS stats1 (cardinality) ON t(a, b, c);
 //	CREATE STATISTICS stats2 (dependency) ON t(a, b);
 //	CREATE STATISTICS stats3 (correlation) ON t(a, b);
+//	CREATE STATISTICS stats4 (ndistinct, dependencies, mcv) ON a, b FROM t;
+//	CREATE STATISTICS stats5 ON a, b, c FROM t;
 type CreateStatisticsStmt struct {
 	stmtNode
 
@@ -2191,6 +2320,9 @@ func (n *CreateStatisticsStmt) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("IF NOT EXISTS ")
 	}
 	ctx.WriteName(n.StatsName)
+	if n.StatsType&StatsTypeMultiColumn != 0 {
+		return n.restoreMultiColumn(ctx)
+	}
 	switch n.StatsType {
 	case StatsTypeCardinality:
 		ctx.WriteKeyWord(" (cardinality) ")
@@ -2217,6 +2349,39 @@ func (n *CreateStatisticsStmt) Restore(ctx *format.RestoreCtx) error {
 	return nil
 }
 
+func (n *CreateStatisticsStmt) restoreMultiColumn(ctx *format.RestoreCtx) error {
+	ctx.WritePlain(" (")
+	first := true
+	for _, kind := range []struct {
+		flag uint8
+		name string
+	}{{StatsKindNDistinct, "NDISTINCT"}, {StatsKindDependencies, "DEPENDENCIES"}, {StatsKindMCV, "MCV"}} {
+		if n.StatsType&kind.flag == 0 {
+			continue
+		}
+		if !first {
+			ctx.WritePlain(", ")
+		}
+		first = false
+		ctx.WriteKeyWord(kind.name)
+	}
+	ctx.WritePlain(") ")
+	ctx.WriteKeyWord("ON ")
+	for i, col := range n.Columns {
+		if i != 0 {
+			ctx.WritePlain(", ")
+		}
+		if err := col.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore CreateStatisticsStmt.Columns: [%v]", i)
+		}
+	}
+	ctx.WriteKeyWord(" FROM ")
+	if err := n.Table.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateStatisticsStmt.Table")
+	}
+	return nil
+}
+
 // Accept implements Node Accept interface.
 func (n *CreateStatisticsStmt) Accept(v Visitor) (Node, bool) {
 	newNode, skipChildren := v.Enter(n)
@@ -767,6 +767,11 @@ func TestDeniedByBDR(t *testing.T) {
 		{ast.BDRRolePrimary, model.ActionRemovePartitioning, true},
 		{ast.BDRRoleSecondary, model.ActionRemovePartitioning, true},
 		{ast.BDRRoleNone, model.ActionRemovePartitioning, false},
+
+		// Roles for ActionCreateMaterializedView
+		{ast.BDRRolePrimary, model.ActionCreateMaterializedView, true},
+		{ast.BDRRoleSecondary, model.ActionCreateMaterializedView, true},
+		{ast.BDRRoleNone, model.ActionCreateMaterializedView, false},
 	}
 
 	for _, tc := range testCases {
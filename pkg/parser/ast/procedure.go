@@ -17,7 +17,9 @@ import (
 	"strconv"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/types"
 )
 
@@ -28,6 +30,8 @@ var (
 	_ StmtNode = &ProcedureBlock{}
 	_ StmtNode = &ProcedureInfo{}
 	_ StmtNode = &DropProcedureStmt{}
+	_ StmtNode = &CreateFunctionStmt{}
+	_ StmtNode = &DropFunctionStmt{}
 	_ StmtNode = &ProcedureElseIfBlock{}
 	_ StmtNode = &ProcedureElseBlock{}
 	_ StmtNode = &ProcedureIfBlock{}
@@ -36,6 +40,7 @@ var (
 	_ StmtNode = &ProcedureLabelBlock{}
 	_ StmtNode = &ProcedureLabelLoop{}
 	_ StmtNode = &ProcedureJump{}
+	_ StmtNode = &ProcedureLoopStmt{}
 
 	_ DeclNode = &ProcedureErrorControl{}
 	_ DeclNode = &ProcedureCursor{}
@@ -237,11 +242,23 @@ type ProcedureInfo struct {
 	ProcedureParam    []*StoreParameter //procedure param
 	ProcedureBody     StmtNode          //procedure body statement
 	ProcedureParamStr string            //procedure parameter string
+	Definer           *auth.UserIdentity
+	Security          model.ViewSecurity
+	Comment           string
 }
 
 // Restore implements Node interface.
 func (n *ProcedureInfo) Restore(ctx *format.RestoreCtx) error {
-	ctx.WriteKeyWord("CREATE PROCEDURE ")
+	ctx.WriteKeyWord("CREATE ")
+	if n.Definer != nil && !n.Definer.CurrentUser {
+		ctx.WriteKeyWord("DEFINER")
+		ctx.WritePlain(" = ")
+		if err := n.Definer.Restore(ctx); err != nil {
+			return err
+		}
+		ctx.WritePlain(" ")
+	}
+	ctx.WriteKeyWord("PROCEDURE ")
 	if n.IfNotExists {
 		ctx.WriteKeyWord("IF NOT EXISTS ")
 	}
@@ -260,6 +277,14 @@ func (n *ProcedureInfo) Restore(ctx *format.RestoreCtx) error {
 		}
 	}
 	ctx.WritePlain(") ")
+	if n.Comment != "" {
+		ctx.WriteKeyWord("COMMENT ")
+		ctx.WriteString(n.Comment)
+		ctx.WritePlain(" ")
+	}
+	if n.Security == model.SecurityInvoker {
+		ctx.WriteKeyWord("SQL SECURITY INVOKER ")
+	}
 	err = (n.ProcedureBody).Restore(ctx)
 	if err != nil {
 		return err
@@ -320,6 +345,118 @@ func (n *DropProcedureStmt) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// CreateFunctionStmt is a statement to create a stored function, the body of the function is an expression.
+type CreateFunctionStmt struct {
+	stmtNode
+
+	IfNotExists  bool
+	Definer      *auth.UserIdentity
+	FunctionName *TableName
+	Params       []*StoreParameter
+	Returns      *types.FieldType
+	Security     model.ViewSecurity
+	Comment      string
+	Body         ExprNode
+	// ParamStr, ReturnsStr and BodyStr are the original text of the parameters, the return type and the body.
+	// BodyStr starts with RETURN.
+	ParamStr   string
+	ReturnsStr string
+	BodyStr    string
+}
+
+// Restore implements Node interface.
+func (n *CreateFunctionStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE ")
+	if n.Definer != nil && !n.Definer.CurrentUser {
+		ctx.WriteKeyWord("DEFINER")
+		ctx.WritePlain(" = ")
+		if err := n.Definer.Restore(ctx); err != nil {
+			return err
+		}
+		ctx.WritePlain(" ")
+	}
+	ctx.WriteKeyWord("FUNCTION ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	if err := n.FunctionName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateFunctionStmt.FunctionName")
+	}
+	ctx.WritePlain("(")
+	for i, param := range n.Params {
+		if i > 0 {
+			ctx.WritePlain(",")
+		}
+		ctx.WriteName(param.ParamName)
+		ctx.WritePlain(" ")
+		ctx.WriteKeyWord(param.ParamType.CompactStr())
+	}
+	ctx.WritePlain(") ")
+	ctx.WriteKeyWord("RETURNS ")
+	ctx.WriteKeyWord(n.Returns.CompactStr())
+	if n.Comment != "" {
+		ctx.WriteKeyWord(" COMMENT ")
+		ctx.WriteString(n.Comment)
+	}
+	if n.Security == model.SecurityInvoker {
+		ctx.WriteKeyWord(" SQL SECURITY INVOKER")
+	}
+	ctx.WriteKeyWord(" RETURN ")
+	if err := n.Body.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateFunctionStmt.Body")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateFunctionStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateFunctionStmt)
+	for i, param := range n.Params {
+		node, ok := param.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Params[i] = node.(*StoreParameter)
+	}
+	node, ok := n.Body.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Body = node.(ExprNode)
+	return v.Leave(n)
+}
+
+// DropFunctionStmt is a statement to drop a stored function.
+type DropFunctionStmt struct {
+	stmtNode
+
+	IfExists     bool
+	FunctionName *TableName
+}
+
+// Restore implements Node interface.
+func (n *DropFunctionStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DROP FUNCTION ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	return n.FunctionName.Restore(ctx)
+}
+
+// Accept implements Node Accept interface.
+func (n *DropFunctionStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropFunctionStmt)
+	return v.Leave(n)
+}
+
 // ProcedureIfInfo stores the `if statement` of procedure.
 type ProcedureIfInfo struct {
 	stmtNode
@@ -731,6 +868,45 @@ func (n *ProcedureRepeatStmt) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// ProcedureLoopStmt stores `loop ... end loop` statement.
+type ProcedureLoopStmt struct {
+	stmtNode
+
+	Body []StmtNode
+}
+
+// Restore implements ProcedureLoopStmt interface.
+func (n *ProcedureLoopStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("LOOP ")
+	for _, stmt := range n.Body {
+		err := stmt.Restore(ctx)
+		if err != nil {
+			return err
+		}
+		ctx.WriteKeyWord(";")
+	}
+	ctx.WriteKeyWord("END LOOP")
+	return nil
+}
+
+// Accept implements ProcedureLoopStmt Accept interface.
+func (n *ProcedureLoopStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ProcedureLoopStmt)
+
+	for i, stmt := range n.Body {
+		node, ok := stmt.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Body[i] = node.(StmtNode)
+	}
+	return v.Leave(n)
+}
+
 // ProcedureWhileStmt stores `while expr do ... end while` statement.
 type ProcedureWhileStmt struct {
 	stmtNode
@@ -1161,7 +1337,7 @@ func (n *ProcedureJump) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("ITERATE ")
 	}
 
-	ctx.WriteString(n.Name)
+	ctx.WriteName(n.Name)
 	return nil
 }
 
@@ -19,6 +19,7 @@ import (
 
 	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/stretchr/testify/require"
 )
 
@@ -99,6 +100,10 @@ func TestProcedure(t *testing.T) {
 		`create procedure proc_2() begin labelname: while id < 10 do set id = id + 1; select 1; end while; end`,
 		`create procedure proc_2() begin labelname: while id < 10 do set id = id + 1; select 1; end while labelname; end`,
 		`create procedure proc_2(id int) begin labelname: REPEAT set id = id + 1; select 1; UNTIL id < 10 end REPEAT labelname; end`,
+		`create procedure proc_2(id int) begin labelname: loop set id = id + 1; if id > 10 then leave labelname; end if; end loop labelname; end`,
+		`create procedure proc_2(out id int) begin declare a int; select count(*) into a from t1; select a, 1 into id, @b; end`,
+		`create definer = 'root'@'%' procedure proc_2() comment 'test' sql security invoker select 1`,
+		`create definer = current_user procedure proc_2() sql security definer select 1`,
 	}
 	for _, testcase := range testcases {
 		stmt, _, err := p.Parse(testcase, "", "")
@@ -111,6 +116,95 @@ func TestProcedure(t *testing.T) {
 	}
 }
 
+func TestProcedureCharacteristic(t *testing.T) {
+	p := parser.New()
+	stmt, err := p.ParseOneStmt("create definer = 'u'@'%' procedure proc_2() comment 'test' sql security definer select 1", "", "")
+	require.NoError(t, err)
+	info := stmt.(*ast.ProcedureInfo)
+	require.Equal(t, "u", info.Definer.Username)
+	require.Equal(t, "%", info.Definer.Hostname)
+	require.Equal(t, "test", info.Comment)
+	require.Equal(t, model.SecurityDefiner, info.Security)
+
+	stmt, err = p.ParseOneStmt("create procedure proc_2() select 1", "", "")
+	require.NoError(t, err)
+	info = stmt.(*ast.ProcedureInfo)
+	require.True(t, info.Definer.CurrentUser)
+	require.Equal(t, model.SecurityDefiner, info.Security)
+
+	_, err = p.ParseOneStmt("create or replace procedure proc_2() select 1", "", "")
+	require.Error(t, err)
+	_, err = p.ParseOneStmt("create algorithm = merge procedure proc_2() select 1", "", "")
+	require.Error(t, err)
+}
+
+func TestFunction(t *testing.T) {
+	p := parser.New()
+	stmt, err := p.ParseOneStmt("create definer = 'u'@'%' function if not exists db.f(a int, b varchar(10)) returns decimal(10, 2) comment 'test' return a * 2 + length(b);", "", "")
+	require.NoError(t, err)
+	fn := stmt.(*ast.CreateFunctionStmt)
+	require.True(t, fn.IfNotExists)
+	require.Equal(t, "u", fn.Definer.Username)
+	require.Equal(t, "db", fn.FunctionName.Schema.O)
+	require.Equal(t, "f", fn.FunctionName.Name.O)
+	require.Len(t, fn.Params, 2)
+	require.Equal(t, "a int, b varchar(10)", fn.ParamStr)
+	require.Equal(t, "decimal(10, 2)", fn.ReturnsStr)
+	require.Equal(t, "return a * 2 + length(b)", fn.BodyStr)
+	require.Equal(t, "test", fn.Comment)
+	require.Equal(t, model.SecurityDefiner, fn.Security)
+
+	stmt, err = p.ParseOneStmt("create function f() returns int return 1", "", "")
+	require.NoError(t, err)
+	fn = stmt.(*ast.CreateFunctionStmt)
+	require.True(t, fn.Definer.CurrentUser)
+	require.Empty(t, fn.Params)
+	require.Equal(t, "int", fn.ReturnsStr)
+	require.Equal(t, "return 1", fn.BodyStr)
+
+	for _, sql := range []string{
+		"create function f(in a int) returns int return a",
+		"create function f() return 1",
+		"create function f() returns int begin return 1; end",
+		"create or replace function f() returns int return 1",
+	} {
+		_, err = p.ParseOneStmt(sql, "", "")
+		require.Error(t, err, sql)
+	}
+
+	stmt, err = p.ParseOneStmt("drop function if exists db.f", "", "")
+	require.NoError(t, err)
+	drop := stmt.(*ast.DropFunctionStmt)
+	require.True(t, drop.IfExists)
+	require.Equal(t, "f", drop.FunctionName.Name.O)
+
+	stmt, err = p.ParseOneStmt("show create function db.f", "", "")
+	require.NoError(t, err)
+	require.Equal(t, ast.ShowStmtType(ast.ShowCreateFunction), stmt.(*ast.ShowStmt).Tp)
+}
+
+func TestFunctionRestore(t *testing.T) {
+	testCases := []NodeRestoreTestCase{
+		{
+			"CREATE FUNCTION `f`(`a` INT(11),`b` VARCHAR(10)) RETURNS DECIMAL(10,2) RETURN `a`*2+LENGTH(`b`)",
+			"CREATE FUNCTION `f`(`a` INT(11),`b` VARCHAR(10)) RETURNS DECIMAL(10,2) RETURN `a`*2+LENGTH(`b`)",
+		},
+		{
+			"CREATE DEFINER = `u`@`%` FUNCTION IF NOT EXISTS `db`.`f`() RETURNS INT(11) COMMENT 'test' SQL SECURITY INVOKER RETURN 1",
+			"CREATE DEFINER = `u`@`%` FUNCTION IF NOT EXISTS `db`.`f`() RETURNS INT(11) COMMENT 'test' SQL SECURITY INVOKER RETURN 1",
+		},
+		{
+			"CREATE FUNCTION `f`() RETURNS INT(11) SQL SECURITY DEFINER RETURN 1",
+			"CREATE FUNCTION `f`() RETURNS INT(11) RETURN 1",
+		},
+		{"DROP FUNCTION IF EXISTS `db`.`f`", "DROP FUNCTION IF EXISTS `db`.`f`"},
+	}
+	extractNodeFunc := func(node ast.Node) ast.Node {
+		return node
+	}
+	runNodeRestoreTest(t, testCases, "%s", extractNodeFunc)
+}
+
 func TestShowCreateProcedure(t *testing.T) {
 	p := parser.New()
 	stmt, _, err := p.Parse("show create procedure proc_2", "", "")
@@ -217,6 +311,22 @@ func TestProcedureRestore(t *testing.T) {
 			"CREATE PROCEDURE `proc_2`( IN `id` INT(11)) BEGIN `labelname`: REPEAT SET @@SESSION.`id`=`id`+1;SELECT 1;UNTIL `id`<10 END REPEAT `labelname`; END",
 			"CREATE PROCEDURE `proc_2`( IN `id` INT(11)) BEGIN `labelname`: REPEAT SET @@SESSION.`id`=`id`+1;SELECT 1;UNTIL `id`<10 END REPEAT `labelname`; END",
 		},
+		{
+			"CREATE PROCEDURE `proc_2`( IN `id` INT(11)) BEGIN `labelname`: LOOP SET @@SESSION.`id`=`id`+1;ITERATE `labelname`;END LOOP `labelname`; END",
+			"CREATE PROCEDURE `proc_2`( IN `id` INT(11)) BEGIN `labelname`: LOOP SET @@SESSION.`id`=`id`+1;ITERATE `labelname`;END LOOP `labelname`; END",
+		},
+		{
+			"CREATE PROCEDURE `proc_2`( OUT `id` INT(11)) BEGIN DECLARE `a` INT(11);SELECT COUNT(1) FROM `t1` INTO `a`;SELECT `a`,1 INTO `id`,@`b`; END",
+			"CREATE PROCEDURE `proc_2`( OUT `id` INT(11)) BEGIN DECLARE `a` INT(11);SELECT COUNT(1) FROM `t1` INTO `a`;SELECT `a`,1 INTO `id`,@`b`; END",
+		},
+		{
+			"CREATE DEFINER = `u`@`%` PROCEDURE `proc_2`() COMMENT 'test' SQL SECURITY INVOKER SELECT 1",
+			"CREATE DEFINER = `u`@`%` PROCEDURE `proc_2`() COMMENT 'test' SQL SECURITY INVOKER SELECT 1",
+		},
+		{
+			"CREATE PROCEDURE `proc_2`() SQL SECURITY DEFINER SELECT 1",
+			"CREATE PROCEDURE `proc_2`() SELECT 1",
+		},
 	}
 	extractNodeFunc := func(node ast.Node) ast.Node {
 		return node.(*ast.ProcedureInfo)
@@ -133,6 +133,7 @@ var Keywords = []KeywordsType{
 	{"KILL", true, "reserved"},
 	{"LAG", true, "reserved"},
 	{"LAST_VALUE", true, "reserved"},
+	{"LATERAL", true, "reserved"},
 	{"LEAD", true, "reserved"},
 	{"LEADING", true, "reserved"},
 	{"LEAVE", true, "reserved"},
@@ -148,6 +149,7 @@ var Keywords = []KeywordsType{
 	{"LONG", true, "reserved"},
 	{"LONGBLOB", true, "reserved"},
 	{"LONGTEXT", true, "reserved"},
+	{"LOOP", true, "reserved"},
 	{"LOW_PRIORITY", true, "reserved"},
 	{"MATCH", true, "reserved"},
 	{"MAXVALUE", true, "reserved"},
@@ -279,6 +281,7 @@ var Keywords = []KeywordsType{
 	{"BACKUP", false, "unreserved"},
 	{"BACKUPS", false, "unreserved"},
 	{"BDR", false, "unreserved"},
+	{"BEFORE", false, "unreserved"},
 	{"BEGIN", false, "unreserved"},
 	{"BERNOULLI", false, "unreserved"},
 	{"BINDING", false, "unreserved"},
@@ -294,9 +297,12 @@ var Keywords = []KeywordsType{
 	{"CACHE", false, "unreserved"},
 	{"CALIBRATE", false, "unreserved"},
 	{"CAPTURE", false, "unreserved"},
+	{"CARDINALITY_CORRECTIONS", false, "unreserved"},
 	{"CASCADED", false, "unreserved"},
 	{"CAUSAL", false, "unreserved"},
 	{"CHAIN", false, "unreserved"},
+	{"CHANGEFEED", false, "unreserved"},
+	{"CHANGEFEEDS", false, "unreserved"},
 	{"CHARSET", false, "unreserved"},
 	{"CHECKPOINT", false, "unreserved"},
 	{"CHECKSUM", false, "unreserved"},
@@ -316,6 +322,8 @@ var Keywords = []KeywordsType{
 	{"COMMIT", false, "unreserved"},
 	{"COMMITTED", false, "unreserved"},
 	{"COMPACT", false, "unreserved"},
+	{"COMPLETE", false, "unreserved"},
+	{"COMPLETION", false, "unreserved"},
 	{"COMPRESSED", false, "unreserved"},
 	{"COMPRESSION", false, "unreserved"},
 	{"COMPRESSION_LEVEL", false, "unreserved"},
@@ -344,6 +352,7 @@ var Keywords = []KeywordsType{
 	{"DECLARE", false, "unreserved"},
 	{"DEFINER", false, "unreserved"},
 	{"DELAY_KEY_WRITE", false, "unreserved"},
+	{"DEMAND", false, "unreserved"},
 	{"DIGEST", false, "unreserved"},
 	{"DIRECTORY", false, "unreserved"},
 	{"DISABLE", false, "unreserved"},
@@ -353,12 +362,15 @@ var Keywords = []KeywordsType{
 	{"DO", false, "unreserved"},
 	{"DUPLICATE", false, "unreserved"},
 	{"DYNAMIC", false, "unreserved"},
+	{"EACH", false, "unreserved"},
+	{"EMPTY", false, "unreserved"},
 	{"ENABLE", false, "unreserved"},
 	{"ENABLED", false, "unreserved"},
 	{"ENCRYPTION", false, "unreserved"},
 	{"ENCRYPTION_KEYFILE", false, "unreserved"},
 	{"ENCRYPTION_METHOD", false, "unreserved"},
 	{"END", false, "unreserved"},
+	{"ENDS", false, "unreserved"},
 	{"ENFORCED", false, "unreserved"},
 	{"ENGINE", false, "unreserved"},
 	{"ENGINES", false, "unreserved"},
@@ -368,14 +380,17 @@ var Keywords = []KeywordsType{
 	{"ESCAPE", false, "unreserved"},
 	{"EVENT", false, "unreserved"},
 	{"EVENTS", false, "unreserved"},
+	{"EVERY", false, "unreserved"},
 	{"EVOLVE", false, "unreserved"},
 	{"EXCHANGE", false, "unreserved"},
+	{"EXCLUDE", false, "unreserved"},
 	{"EXCLUSIVE", false, "unreserved"},
 	{"EXECUTE", false, "unreserved"},
 	{"EXPANSION", false, "unreserved"},
 	{"EXPIRE", false, "unreserved"},
 	{"EXTENDED", false, "unreserved"},
 	{"FAILED_LOGIN_ATTEMPTS", false, "unreserved"},
+	{"FAST", false, "unreserved"},
 	{"FAULTS", false, "unreserved"},
 	{"FIELDS", false, "unreserved"},
 	{"FILE", false, "unreserved"},
@@ -388,6 +403,9 @@ var Keywords = []KeywordsType{
 	{"FULL", false, "unreserved"},
 	{"FUNCTION", false, "unreserved"},
 	{"GENERAL", false, "unreserved"},
+	{"GEOMCOLLECTION", false, "unreserved"},
+	{"GEOMETRY", false, "unreserved"},
+	{"GEOMETRYCOLLECTION", false, "unreserved"},
 	{"GLOBAL", false, "unreserved"},
 	{"GRANTS", false, "unreserved"},
 	{"HANDLER", false, "unreserved"},
@@ -402,6 +420,7 @@ var Keywords = []KeywordsType{
 	{"IGNORE_STATS", false, "unreserved"},
 	{"IMPORT", false, "unreserved"},
 	{"IMPORTS", false, "unreserved"},
+	{"INCLUDE", false, "unreserved"},
 	{"INCREMENT", false, "unreserved"},
 	{"INCREMENTAL", false, "unreserved"},
 	{"INDEXES", false, "unreserved"},
@@ -422,6 +441,7 @@ var Keywords = []KeywordsType{
 	{"LAST_BACKUP", false, "unreserved"},
 	{"LESS", false, "unreserved"},
 	{"LEVEL", false, "unreserved"},
+	{"LINESTRING", false, "unreserved"},
 	{"LIST", false, "unreserved"},
 	{"LOAD_STATS", false, "unreserved"},
 	{"LOCAL", false, "unreserved"},
@@ -429,6 +449,8 @@ var Keywords = []KeywordsType{
 	{"LOCKED", false, "unreserved"},
 	{"LOGS", false, "unreserved"},
 	{"MASTER", false, "unreserved"},
+	{"MATCHED", false, "unreserved"},
+	{"MATERIALIZED", false, "unreserved"},
 	{"MAX_CONNECTIONS_PER_HOUR", false, "unreserved"},
 	{"MAX_IDXNUM", false, "unreserved"},
 	{"MAX_MINUTES", false, "unreserved"},
@@ -441,15 +463,20 @@ var Keywords = []KeywordsType{
 	{"MEMORY", false, "unreserved"},
 	{"MERGE", false, "unreserved"},
 	{"MICROSECOND", false, "unreserved"},
+	{"MIGRATE", false, "unreserved"},
 	{"MINUTE", false, "unreserved"},
 	{"MINVALUE", false, "unreserved"},
 	{"MIN_ROWS", false, "unreserved"},
 	{"MODE", false, "unreserved"},
 	{"MODIFY", false, "unreserved"},
 	{"MONTH", false, "unreserved"},
+	{"MULTILINESTRING", false, "unreserved"},
+	{"MULTIPOINT", false, "unreserved"},
+	{"MULTIPOLYGON", false, "unreserved"},
 	{"NAMES", false, "unreserved"},
 	{"NATIONAL", false, "unreserved"},
 	{"NCHAR", false, "unreserved"},
+	{"NESTED", false, "unreserved"},
 	{"NEVER", false, "unreserved"},
 	{"NEXT", false, "unreserved"},
 	{"NEXTVAL", false, "unreserved"},
@@ -469,11 +496,13 @@ var Keywords = []KeywordsType{
 	{"OLTP_READ_ONLY", false, "unreserved"},
 	{"OLTP_READ_WRITE", false, "unreserved"},
 	{"OLTP_WRITE_ONLY", false, "unreserved"},
+	{"ONE", false, "unreserved"},
 	{"ONLINE", false, "unreserved"},
 	{"ONLY", false, "unreserved"},
 	{"ON_DUPLICATE", false, "unreserved"},
 	{"OPEN", false, "unreserved"},
 	{"OPTIONAL", false, "unreserved"},
+	{"ORDINALITY", false, "unreserved"},
 	{"PACK_KEYS", false, "unreserved"},
 	{"PAGE", false, "unreserved"},
 	{"PARSER", false, "unreserved"},
@@ -482,13 +511,17 @@ var Keywords = []KeywordsType{
 	{"PARTITIONS", false, "unreserved"},
 	{"PASSWORD", false, "unreserved"},
 	{"PASSWORD_LOCK_TIME", false, "unreserved"},
+	{"PATH", false, "unreserved"},
 	{"PAUSE", false, "unreserved"},
 	{"PERCENT", false, "unreserved"},
 	{"PER_DB", false, "unreserved"},
 	{"PER_TABLE", false, "unreserved"},
+	{"PHASE", false, "unreserved"},
+	{"PIVOT", false, "unreserved"},
 	{"PLUGINS", false, "unreserved"},
 	{"POINT", false, "unreserved"},
 	{"POLICY", false, "unreserved"},
+	{"POLYGON", false, "unreserved"},
 	{"PRECEDING", false, "unreserved"},
 	{"PREPARE", false, "unreserved"},
 	{"PRESERVE", false, "unreserved"},
@@ -500,14 +533,17 @@ var Keywords = []KeywordsType{
 	{"PROFILES", false, "unreserved"},
 	{"PROXY", false, "unreserved"},
 	{"PURGE", false, "unreserved"},
+	{"QUALIFY", false, "unreserved"},
 	{"QUARTER", false, "unreserved"},
 	{"QUERIES", false, "unreserved"},
 	{"QUERY", false, "unreserved"},
 	{"QUICK", false, "unreserved"},
 	{"RATE_LIMIT", false, "unreserved"},
 	{"REBUILD", false, "unreserved"},
+	{"RECOMMEND", false, "unreserved"},
 	{"RECOVER", false, "unreserved"},
 	{"REDUNDANT", false, "unreserved"},
+	{"REFRESH", false, "unreserved"},
 	{"RELOAD", false, "unreserved"},
 	{"REMOVE", false, "unreserved"},
 	{"REORGANIZE", false, "unreserved"},
@@ -523,6 +559,9 @@ var Keywords = []KeywordsType{
 	{"RESTORE", false, "unreserved"},
 	{"RESTORES", false, "unreserved"},
 	{"RESUME", false, "unreserved"},
+	{"RETURN", false, "unreserved"},
+	{"RETURNING", false, "unreserved"},
+	{"RETURNS", false, "unreserved"},
 	{"REUSE", false, "unreserved"},
 	{"REVERSE", false, "unreserved"},
 	{"ROLE", false, "unreserved"},
@@ -571,7 +610,9 @@ var Keywords = []KeywordsType{
 	{"SQL_TSI_SECOND", false, "unreserved"},
 	{"SQL_TSI_WEEK", false, "unreserved"},
 	{"SQL_TSI_YEAR", false, "unreserved"},
+	{"SRID", false, "unreserved"},
 	{"START", false, "unreserved"},
+	{"STARTS", false, "unreserved"},
 	{"STATS_AUTO_RECALC", false, "unreserved"},
 	{"STATS_COL_CHOICE", false, "unreserved"},
 	{"STATS_COL_LIST", false, "unreserved"},
@@ -586,6 +627,7 @@ var Keywords = []KeywordsType{
 	{"SUBPARTITION", false, "unreserved"},
 	{"SUBPARTITIONS", false, "unreserved"},
 	{"SUPER", false, "unreserved"},
+	{"SUSPEND", false, "unreserved"},
 	{"SWAPS", false, "unreserved"},
 	{"SWITCHES", false, "unreserved"},
 	{"SYSTEM", false, "unreserved"},
@@ -618,11 +660,13 @@ var Keywords = []KeywordsType{
 	{"UNDEFINED", false, "unreserved"},
 	{"UNICODE", false, "unreserved"},
 	{"UNKNOWN", false, "unreserved"},
+	{"UNPIVOT", false, "unreserved"},
 	{"UNSET", false, "unreserved"},
 	{"USER", false, "unreserved"},
 	{"VALIDATION", false, "unreserved"},
 	{"VALUE", false, "unreserved"},
 	{"VARIABLES", false, "unreserved"},
+	{"VECTOR", false, "unreserved"},
 	{"VIEW", false, "unreserved"},
 	{"VISIBLE", false, "unreserved"},
 	{"WAIT", false, "unreserved"},
@@ -634,6 +678,8 @@ var Keywords = []KeywordsType{
 	{"WITH_SYS_TABLE", false, "unreserved"},
 	{"WORKLOAD", false, "unreserved"},
 	{"X509", false, "unreserved"},
+	{"XA", false, "unreserved"},
+	{"XID", false, "unreserved"},
 	{"YEAR", false, "unreserved"},
 	{"ADMIN", false, "tidb"},
 	{"BATCH", false, "tidb"},
@@ -645,6 +691,7 @@ var Keywords = []KeywordsType{
 	{"COLUMN_STATS_USAGE", false, "tidb"},
 	{"CORRELATION", false, "tidb"},
 	{"DDL", false, "tidb"},
+	{"DEPENDENCIES", false, "tidb"},
 	{"DEPENDENCY", false, "tidb"},
 	{"DEPTH", false, "tidb"},
 	{"DRAINER", false, "tidb"},
@@ -652,6 +699,8 @@ var Keywords = []KeywordsType{
 	{"HISTOGRAMS_IN_FLIGHT", false, "tidb"},
 	{"JOB", false, "tidb"},
 	{"JOBS", false, "tidb"},
+	{"MCV", false, "tidb"},
+	{"NDISTINCT", false, "tidb"},
 	{"NODE_ID", false, "tidb"},
 	{"NODE_STATE", false, "tidb"},
 	{"OPTIMISTIC", false, "tidb"},
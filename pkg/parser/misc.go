@@ -182,6 +182,18 @@ var tokenMap = map[string]int{
 	"ATTRIBUTES":               attributes,
 	"BATCH":                    batch,
 	"BACKGROUND":               background,
+	"EXCLUDE":                  exclude,
+	"GEOMCOLLECTION":           geomCollection,
+	"GEOMETRY":                 geometry,
+	"GEOMETRYCOLLECTION":       geometryCollection,
+	"INCLUDE":                  include,
+	"LINESTRING":               lineString,
+	"MULTILINESTRING":          multiLineString,
+	"MULTIPOINT":               multiPoint,
+	"MULTIPOLYGON":             multiPolygon,
+	"PIVOT":                    pivot,
+	"POLYGON":                  polygon,
+	"SRID":                     srid,
 	"STATS_OPTIONS":            statsOptions,
 	"STATS_SAMPLE_RATE":        statsSampleRate,
 	"STATS_COL_CHOICE":         statsColChoice,
@@ -196,6 +208,7 @@ var tokenMap = map[string]int{
 	"BACKUP":                   backup,
 	"BACKUPS":                  backups,
 	"BDR":                      bdr,
+	"BEFORE":                   before,
 	"BEGIN":                    begin,
 	"BETWEEN":                  between,
 	"BERNOULLI":                bernoulli,
@@ -228,6 +241,7 @@ var tokenMap = map[string]int{
 	"CALL":                     call,
 	"CANCEL":                   cancel,
 	"CAPTURE":                  capture,
+	"CARDINALITY_CORRECTIONS":  cardinalityCorrections,
 	"CARDINALITY":              cardinality,
 	"CASCADE":                  cascade,
 	"CASCADED":                 cascaded,
@@ -235,6 +249,8 @@ var tokenMap = map[string]int{
 	"CAST":                     cast,
 	"CAUSAL":                   causal,
 	"CHAIN":                    chain,
+	"CHANGEFEED":               changefeed,
+	"CHANGEFEEDS":              changefeeds,
 	"CHANGE":                   change,
 	"CHAR":                     charType,
 	"CHARACTER":                character,
@@ -261,6 +277,8 @@ var tokenMap = map[string]int{
 	"COMMIT":                   commit,
 	"COMMITTED":                committed,
 	"COMPACT":                  compact,
+	"COMPLETE":                 complete,
+	"COMPLETION":               completion,
 	"COMPRESSED":               compressed,
 	"COMPRESSION":              compression,
 	"CONCURRENCY":              concurrency,
@@ -286,6 +304,7 @@ var tokenMap = map[string]int{
 	"CSV_NULL":                 csvNull,
 	"CSV_SEPARATOR":            csvSeparator,
 	"CSV_TRIM_LAST_SEPARATORS": csvTrimLastSeparators,
+	"UNPIVOT":                  unpivot,
 	"WAIT_TIFLASH_READY":       waitTiflashReady,
 	"WITH_SYS_TABLE":           withSysTable,
 	"IGNORE_STATS":             ignoreStats,
@@ -326,8 +345,10 @@ var tokenMap = map[string]int{
 	"DEFINED":                  defined,
 	"DEFINER":                  definer,
 	"DELAY_KEY_WRITE":          delayKeyWrite,
+	"DEMAND":                   demand,
 	"DELAYED":                  delayed,
 	"DELETE":                   deleteKwd,
+	"DEPENDENCIES":             dependencies,
 	"DEPENDENCY":               dependency,
 	"DEPTH":                    depth,
 	"DESC":                     desc,
@@ -353,14 +374,17 @@ var tokenMap = map[string]int{
 	"DUPLICATE":                duplicate,
 	"DURATION":                 timeDuration,
 	"DYNAMIC":                  dynamic,
+	"EACH":                     each,
 	"ELSE":                     elseKwd,
 	"ELSEIF":                   elseIfKwd,
+	"EMPTY":                    emptyKwd,
 	"ENABLE":                   enable,
 	"ENABLED":                  enabled,
 	"ENCLOSED":                 enclosed,
 	"ENCRYPTION":               encryption,
 	"END":                      end,
 	"END_TIME":                 endTime,
+	"ENDS":                     ends,
 	"ENFORCED":                 enforced,
 	"ENGINE":                   engine,
 	"ENGINES":                  engines,
@@ -371,6 +395,7 @@ var tokenMap = map[string]int{
 	"ESCAPED":                  escaped,
 	"EVENT":                    event,
 	"EVENTS":                   events,
+	"EVERY":                    every,
 	"EVOLVE":                   evolve,
 	"EXACT":                    exact,
 	"EXEC_ELAPSED":             execElapsed,
@@ -385,6 +410,7 @@ var tokenMap = map[string]int{
 	"EXPLAIN":                  explain,
 	"EXPR_PUSHDOWN_BLACKLIST":  exprPushdownBlacklist,
 	"EXTENDED":                 extended,
+	"FAST":                     fast,
 	"EXTRACT":                  extract,
 	"FALSE":                    falseKwd,
 	"FAULTS":                   faultsSym,
@@ -483,6 +509,7 @@ var tokenMap = map[string]int{
 	"JOIN":                     join,
 	"JSON_ARRAYAGG":            jsonArrayagg,
 	"JSON_OBJECTAGG":           jsonObjectAgg,
+	"JSON_TABLE":               jsonTable,
 	"JSON":                     jsonType,
 	"KEY_BLOCK_SIZE":           keyBlockSize,
 	"KEY":                      key,
@@ -493,6 +520,7 @@ var tokenMap = map[string]int{
 	"LAST_BACKUP":              lastBackup,
 	"LAST":                     last,
 	"LASTVAL":                  lastval,
+	"LATERAL":                  lateral,
 	"LEADER":                   leader,
 	"LEADER_CONSTRAINTS":       leaderConstraints,
 	"LEADING":                  leading,
@@ -520,8 +548,11 @@ var tokenMap = map[string]int{
 	"LONG":                     long,
 	"LONGBLOB":                 longblobType,
 	"LONGTEXT":                 longtextType,
+	"LOOP":                     loop,
 	"LOW_PRIORITY":             lowPriority,
 	"MASTER":                   master,
+	"MATCHED":                  matched,
+	"MATERIALIZED":             materialized,
 	"MATCH":                    match,
 	"MAX_CONNECTIONS_PER_HOUR": maxConnectionsPerHour,
 	"MAX_IDXNUM":               max_idxnum,
@@ -533,6 +564,7 @@ var tokenMap = map[string]int{
 	"MAX":                      max,
 	"MAXVALUE":                 maxValue,
 	"MB":                       mb,
+	"MCV":                      mcv,
 	"MEDIUMBLOB":               mediumblobType,
 	"MEDIUMINT":                mediumIntType,
 	"MEDIUMTEXT":               mediumtextType,
@@ -542,6 +574,7 @@ var tokenMap = map[string]int{
 	"METADATA":                 metadata,
 	"MICROSECOND":              microsecond,
 	"MIDDLEINT":                middleIntType,
+	"MIGRATE":                  migrate,
 	"MIN_ROWS":                 minRows,
 	"MIN":                      min,
 	"MINUTE_MICROSECOND":       minuteMicrosecond,
@@ -556,6 +589,8 @@ var tokenMap = map[string]int{
 	"NATIONAL":                 national,
 	"NATURAL":                  natural,
 	"NCHAR":                    ncharType,
+	"NDISTINCT":                ndistinct,
+	"NESTED":                   nested,
 	"NEVER":                    never,
 	"NEXT_ROW_ID":              next_row_id,
 	"NEXT":                     next,
@@ -587,6 +622,7 @@ var tokenMap = map[string]int{
 	"TPCH_10":                  tpch10,
 	"ON_DUPLICATE":             onDuplicate,
 	"ON":                       on,
+	"ONE":                      one,
 	"ONLINE":                   online,
 	"ONLY":                     only,
 	"OPEN":                     open,
@@ -598,6 +634,7 @@ var tokenMap = map[string]int{
 	"OPTIONALLY":               optionally,
 	"OR":                       or,
 	"ORDER":                    order,
+	"ORDINALITY":               ordinality,
 	"OUT":                      out,
 	"OUTER":                    outer,
 	"OUTFILE":                  outfile,
@@ -609,11 +646,13 @@ var tokenMap = map[string]int{
 	"PARTITIONING":             partitioning,
 	"PARTITIONS":               partitions,
 	"PASSWORD":                 password,
+	"PATH":                     path,
 	"PAUSE":                    pause,
 	"PERCENT":                  percent,
 	"PER_DB":                   per_db,
 	"PER_TABLE":                per_table,
 	"PESSIMISTIC":              pessimistic,
+	"PHASE":                    phase,
 	"PLACEMENT":                placement,
 	"PLAN":                     plan,
 	"PLAN_CACHE":               planCache,
@@ -638,6 +677,7 @@ var tokenMap = map[string]int{
 	"PROXY":                    proxy,
 	"PUMP":                     pump,
 	"PURGE":                    purge,
+	"QUALIFY":                  qualify,
 	"QUARTER":                  quarter,
 	"QUERIES":                  queries,
 	"QUERY":                    query,
@@ -649,9 +689,11 @@ var tokenMap = map[string]int{
 	"REAL":                     realType,
 	"REBUILD":                  rebuild,
 	"RECENT":                   recent,
+	"RECOMMEND":                recommend,
 	"RECOVER":                  recover,
 	"RECURSIVE":                recursive,
 	"REDUNDANT":                redundant,
+	"REFRESH":                  refresh,
 	"REFERENCES":               references,
 	"REGEXP":                   regexpKwd,
 	"REGION":                   region,
@@ -679,6 +721,9 @@ var tokenMap = map[string]int{
 	"RESTORES":                 restores,
 	"RESTORED_TS":              restoredTS,
 	"RESTRICT":                 restrict,
+	"RETURN":                   returnKwd,
+	"RETURNING":                returning,
+	"RETURNS":                  returns,
 	"REVERSE":                  reverse,
 	"REVOKE":                   revoke,
 	"RIGHT":                    right,
@@ -763,6 +808,7 @@ var tokenMap = map[string]int{
 	"START_TIME":               startTime,
 	"START_TS":                 startTS,
 	"STARTING":                 starting,
+	"STARTS":                   starts,
 	"STATISTICS":               statistics,
 	"STATS_AUTO_RECALC":        statsAutoRecalc,
 	"STATS_BUCKETS":            statsBuckets,
@@ -797,6 +843,7 @@ var tokenMap = map[string]int{
 	"SUM":                      sum,
 	"SUPER":                    super,
 	"SURVIVAL_PREFERENCES":     survivalPreferences,
+	"SUSPEND":                  suspend,
 	"SWAPS":                    swaps,
 	"SWITCHES":                 switchesSym,
 	"SYSTEM":                   system,
@@ -888,6 +935,7 @@ var tokenMap = map[string]int{
 	"VARIABLES":                variables,
 	"VARIANCE":                 varPop,
 	"VARYING":                  varying,
+	"VECTOR":                   vectorType,
 	"VERBOSE":                  verboseType,
 	"VOTER":                    voter,
 	"VOTER_CONSTRAINTS":        voterConstraints,
@@ -908,6 +956,8 @@ var tokenMap = map[string]int{
 	"WRITE":                    write,
 	"WORKLOAD":                 workload,
 	"X509":                     x509,
+	"XA":                       xa,
+	"XID":                      xid,
 	"XOR":                      xor,
 	"YEAR_MONTH":               yearMonth,
 	"YEAR":                     yearType,
@@ -990,6 +1040,7 @@ var hintedTokens = map[int]struct{}{
 	replace:   {},
 	update:    {},
 	deleteKwd: {},
+	merge:     {},
 	create:    {},
 	partition: {},
 }
@@ -106,6 +106,9 @@ const (
 	ActionDropResourceGroup      ActionType = 70
 	ActionAlterTablePartitioning ActionType = 71
 	ActionRemovePartitioning     ActionType = 72
+	ActionCreateMaterializedView ActionType = 73
+	ActionCreateTrigger          ActionType = 74
+	ActionDropTrigger            ActionType = 75
 )
 
 // ActionMap is the map of DDL ActionType to string.
@@ -177,6 +180,9 @@ var ActionMap = map[ActionType]string{
 	ActionDropResourceGroup:             "drop resource group",
 	ActionAlterTablePartitioning:        "alter table partition by",
 	ActionRemovePartitioning:            "alter table remove partitioning",
+	ActionCreateMaterializedView:        "create materialized view",
+	ActionCreateTrigger:                 "create trigger",
+	ActionDropTrigger:                   "drop trigger",
 
 	// `ActionAlterTableAlterPartition` is removed and will never be used.
 	// Just left a tombstone here for compatibility.
@@ -266,6 +272,9 @@ var BDRActionMap = map[DDLBDRType][]ActionType{
 		ActionReorganizePartition,
 		ActionAlterTablePartitioning,
 		ActionRemovePartitioning,
+		ActionCreateMaterializedView,
+		ActionCreateTrigger,
+		ActionDropTrigger,
 	},
 	UnmanagementDDL: {
 		ActionCreatePlacementPolicy,
@@ -126,6 +126,9 @@ type ColumnInfo struct {
 	// Version = 1: For OriginDefaultValue and DefaultValue of timestamp column will stores the default time in UTC time zone.
 	//              This will fix bug in version 0. For compatibility with version 0, we add version field in column info struct.
 	Version uint64 `json:"version"`
+	// SRID is the spatial reference system ID of a geometry column, nil means the column can store
+	// geometries of any SRID.
+	SRID *uint32 `json:"srid,omitempty"`
 }
 
 // IsVirtualGenerated checks the column if it is virtual.
@@ -363,7 +366,7 @@ func FindIndexByColumns(tbInfo *TableInfo, indices []*IndexInfo, cols ...CIStr)
 
 // IsIndexPrefixCovered checks the index's columns beginning with the cols.
 func IsIndexPrefixCovered(tbInfo *TableInfo, index *IndexInfo, cols ...CIStr) bool {
-	if len(index.Columns) < len(cols) {
+	if len(index.Columns) < len(cols) || index.Tp == IndexTypeFulltext {
 		return false
 	}
 	for i := range cols {
@@ -542,6 +545,13 @@ type TableInfo struct {
 
 	TTLInfo *TTLInfo `json:"ttl_info"`
 
+	// MaterializedView is set when the table is a materialized view, whose rows are the
+	// result of the view query at the time of the last refresh.
+	MaterializedView *MaterializedViewInfo `json:"materialized_view,omitempty"`
+
+	// Triggers are the row-level triggers of the table, in the order of creation.
+	Triggers []*TriggerInfo `json:"triggers,omitempty"`
+
 	// Revision is per table schema's version, it will be increased when the schema changed.
 	Revision uint64 `json:"revision"`
 
@@ -762,6 +772,15 @@ func (t *TableInfo) Clone() *TableInfo {
 	if t.TTLInfo != nil {
 		nt.TTLInfo = t.TTLInfo.Clone()
 	}
+	if t.MaterializedView != nil {
+		nt.MaterializedView = t.MaterializedView.Clone()
+	}
+	if t.Triggers != nil {
+		nt.Triggers = make([]*TriggerInfo, len(t.Triggers))
+		for i := range t.Triggers {
+			nt.Triggers[i] = t.Triggers[i].Clone()
+		}
+	}
 
 	return &nt
 }
@@ -1028,6 +1047,11 @@ func (t *TableInfo) IsSequence() bool {
 	return t.Sequence != nil
 }
 
+// IsMaterializedView checks if TableInfo is a materialized view.
+func (t *TableInfo) IsMaterializedView() bool {
+	return t.MaterializedView != nil
+}
+
 // IsBaseTable checks to see the table is neither a view or a sequence.
 func (t *TableInfo) IsBaseTable() bool {
 	return t.Sequence == nil && t.View == nil
@@ -1105,6 +1129,127 @@ func (v *ViewCheckOption) String() string {
 	}
 }
 
+// MaterializedViewRefreshMethod is the REFRESH method of a materialized view.
+type MaterializedViewRefreshMethod int
+
+//revive:disable:exported
+const (
+	RefreshComplete MaterializedViewRefreshMethod = iota
+	RefreshFast
+)
+
+//revive:enable:exported
+
+func (m MaterializedViewRefreshMethod) String() string {
+	switch m {
+	case RefreshComplete:
+		return "COMPLETE"
+	case RefreshFast:
+		return "FAST"
+	default:
+		return fmt.Sprintf("UNKNOWN(%d)", int(m))
+	}
+}
+
+// MaterializedViewInfo provides meta data describing a materialized view.
+type MaterializedViewInfo struct {
+	// Query is the SELECT statement of the view, with all the table names qualified.
+	Query string `json:"query"`
+	// Digest is the normalized digest of Query, queries are matched to the view by it.
+	Digest        string                        `json:"digest"`
+	RefreshMethod MaterializedViewRefreshMethod `json:"refresh_method"`
+	// RefreshInterval is the interval between two automatic refreshes. It's empty if
+	// the view is only refreshed on demand.
+	// It's suggested to get a duration with `(*MaterializedViewInfo).GetRefreshInterval`
+	RefreshInterval string `json:"refresh_interval,omitempty"`
+}
+
+// Clone clones MaterializedViewInfo.
+func (m *MaterializedViewInfo) Clone() *MaterializedViewInfo {
+	cloned := *m
+	return &cloned
+}
+
+// GetRefreshInterval parses the refresh interval. It returns 0 if the view is only
+// refreshed on demand.
+func (m *MaterializedViewInfo) GetRefreshInterval() (time.Duration, error) {
+	if len(m.RefreshInterval) == 0 {
+		return 0, nil
+	}
+	return duration.ParseDuration(m.RefreshInterval)
+}
+
+// TriggerTiming is the action time of a trigger.
+type TriggerTiming int
+
+//revive:disable:exported
+const (
+	TriggerBefore TriggerTiming = iota
+	TriggerAfter
+)
+
+//revive:enable:exported
+
+func (t TriggerTiming) String() string {
+	if t == TriggerAfter {
+		return "AFTER"
+	}
+	return "BEFORE"
+}
+
+// TriggerEvent is the kind of the row operation that activates a trigger.
+type TriggerEvent int
+
+//revive:disable:exported
+const (
+	TriggerInsert TriggerEvent = iota
+	TriggerUpdate
+	TriggerDelete
+)
+
+//revive:enable:exported
+
+func (e TriggerEvent) String() string {
+	switch e {
+	case TriggerUpdate:
+		return "UPDATE"
+	case TriggerDelete:
+		return "DELETE"
+	default:
+		return "INSERT"
+	}
+}
+
+// TriggerInfo provides meta data describing a row-level trigger.
+type TriggerInfo struct {
+	Name   CIStr         `json:"name"`
+	Timing TriggerTiming `json:"timing"`
+	Event  TriggerEvent  `json:"event"`
+	// Statement is the text of the trigger body.
+	Statement           string             `json:"statement"`
+	Definer             *auth.UserIdentity `json:"definer"`
+	SQLMode             mysql.SQLMode      `json:"sql_mode"`
+	CharsetClient       string             `json:"charset_client"`
+	CollationConnection string             `json:"collation_connection"`
+	Created             time.Time          `json:"created"`
+}
+
+// Clone clones TriggerInfo.
+func (t *TriggerInfo) Clone() *TriggerInfo {
+	cloned := *t
+	return &cloned
+}
+
+// FindTrigger finds the trigger by name, it returns nil if the table has no such trigger.
+func (t *TableInfo) FindTrigger(name string) *TriggerInfo {
+	for _, trigger := range t.Triggers {
+		if trigger.Name.L == strings.ToLower(name) {
+			return trigger
+		}
+	}
+	return nil
+}
+
 // ViewInfo provides meta data describing a DB view.
 //
 //revive:disable:exported
@@ -1455,6 +1600,8 @@ func (t IndexType) String() string {
 		return "RTREE"
 	case IndexTypeHypo:
 		return "HYPO"
+	case IndexTypeFulltext:
+		return "FULLTEXT"
 	default:
 		return ""
 	}
@@ -1467,6 +1614,7 @@ const (
 	IndexTypeHash
 	IndexTypeRtree
 	IndexTypeHypo
+	IndexTypeFulltext
 )
 
 // IndexInfo provides meta data describing a DB index.
@@ -1486,6 +1634,9 @@ type IndexInfo struct {
 	Invisible     bool           `json:"is_invisible"` // Whether the index is invisible.
 	Global        bool           `json:"is_global"`    // Whether the index is global.
 	MVIndex       bool           `json:"mv_index"`     // Whether the index is multivalued index.
+	// ParserName is the parser which splits the texts of a FULLTEXT index into tokens, empty means
+	// the built-in standard parser.
+	ParserName CIStr `json:"parser_name,omitempty"`
 }
 
 // Clone clones IndexInfo.
@@ -162,7 +162,7 @@ const (
 	ClientDeprecateEOF                                  // CLIENT_DEPRECATE_EOF
 	ClientOptionalResultsetMetadata                     // CLIENT_OPTIONAL_RESULTSET_METADATA, Not supported: https://dev.mysql.com/doc/c-api/8.0/en/c-api-optional-metadata.html
 	ClientZstdCompressionAlgorithm                      // CLIENT_ZSTD_COMPRESSION_ALGORITHM
-	// 1 << 27 == CLIENT_QUERY_ATTRIBUTES
+	ClientQueryAttributes                               // CLIENT_QUERY_ATTRIBUTES
 	// 1 << 28 == MULTI_FACTOR_AUTHENTICATION
 	// 1 << 29 == CLIENT_CAPABILITY_EXTENSION
 	// 1 << 30 == CLIENT_SSL_VERIFY_SERVER_CERT
@@ -643,6 +643,9 @@ const (
 	CursorTypeReadOnly = 1 << iota
 	CursorTypeForUpdate
 	CursorTypeScrollable
+	// ParameterCountAvailable is not a cursor type, it tells that the parameter count is sent in COM_STMT_EXECUTE
+	// when the client has CLIENT_QUERY_ATTRIBUTES.
+	ParameterCountAvailable
 )
 
 // ZlibCompressDefaultLevel is the zlib compression level for the compressed protocol
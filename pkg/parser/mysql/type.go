@@ -34,6 +34,9 @@ const (
 	TypeVarchar  byte = 15
 	TypeBit      byte = 16
 
+	// TypeTiDBVectorFloat32 is a TiDB specific type for vectors of float32.
+	TypeTiDBVectorFloat32 byte = 0xe1
+
 	TypeJSON       byte = 0xf5
 	TypeNewDecimal byte = 0xf6
 	TypeEnum       byte = 0xf7
@@ -47,6 +50,39 @@ const (
 	TypeGeometry   byte = 0xff
 )
 
+// Geometry types are the subtypes of TypeGeometry, the values are the same as the WKB geometry types.
+const (
+	GeometryTypeGeometry           byte = 0
+	GeometryTypePoint              byte = 1
+	GeometryTypeLineString         byte = 2
+	GeometryTypePolygon            byte = 3
+	GeometryTypeMultiPoint         byte = 4
+	GeometryTypeMultiLineString    byte = 5
+	GeometryTypeMultiPolygon       byte = 6
+	GeometryTypeGeometryCollection byte = 7
+)
+
+// GeometryTypeName returns the name of a geometry type, e.g. "point".
+func GeometryTypeName(tp byte) string {
+	switch tp {
+	case GeometryTypePoint:
+		return "point"
+	case GeometryTypeLineString:
+		return "linestring"
+	case GeometryTypePolygon:
+		return "polygon"
+	case GeometryTypeMultiPoint:
+		return "multipoint"
+	case GeometryTypeMultiLineString:
+		return "multilinestring"
+	case GeometryTypeMultiPolygon:
+		return "multipolygon"
+	case GeometryTypeGeometryCollection:
+		return "geomcollection"
+	}
+	return "geometry"
+}
+
 // Flag information.
 const (
 	NotNullFlag        uint = 1 << 0  /* Field can't be NULL */
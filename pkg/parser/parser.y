@@ -186,6 +186,7 @@ import (
 	kill              "KILL"
 	lag               "LAG"
 	lastValue         "LAST_VALUE"
+	lateral           "LATERAL"
 	lead              "LEAD"
 	leading           "LEADING"
 	leave             "LEAVE"
@@ -201,6 +202,7 @@ import (
 	long              "LONG"
 	longblobType      "LONGBLOB"
 	longtextType      "LONGTEXT"
+	loop              "LOOP"
 	lowPriority       "LOW_PRIORITY"
 	match             "MATCH"
 	maxValue          "MAXVALUE"
@@ -312,385 +314,429 @@ import (
 	zerofill          "ZEROFILL"
 
 	/* The following tokens belong to UnReservedKeyword. Notice: make sure these tokens are contained in UnReservedKeyword. */
-	account               "ACCOUNT"
-	action                "ACTION"
-	advise                "ADVISE"
-	after                 "AFTER"
-	against               "AGAINST"
-	ago                   "AGO"
-	algorithm             "ALGORITHM"
-	always                "ALWAYS"
-	any                   "ANY"
-	ascii                 "ASCII"
-	attribute             "ATTRIBUTE"
-	attributes            "ATTRIBUTES"
-	autoIdCache           "AUTO_ID_CACHE"
-	autoIncrement         "AUTO_INCREMENT"
-	autoRandom            "AUTO_RANDOM"
-	autoRandomBase        "AUTO_RANDOM_BASE"
-	avg                   "AVG"
-	avgRowLength          "AVG_ROW_LENGTH"
-	backend               "BACKEND"
-	backup                "BACKUP"
-	backups               "BACKUPS"
-	bdr                   "BDR"
-	begin                 "BEGIN"
-	bernoulli             "BERNOULLI"
-	binding               "BINDING"
-	bindings              "BINDINGS"
-	bindingCache          "BINDING_CACHE"
-	binlog                "BINLOG"
-	bitType               "BIT"
-	block                 "BLOCK"
-	boolType              "BOOL"
-	booleanType           "BOOLEAN"
-	btree                 "BTREE"
-	byteType              "BYTE"
-	cache                 "CACHE"
-	calibrate             "CALIBRATE"
-	capture               "CAPTURE"
-	cascaded              "CASCADED"
-	causal                "CAUSAL"
-	chain                 "CHAIN"
-	charsetKwd            "CHARSET"
-	checkpoint            "CHECKPOINT"
-	checksum              "CHECKSUM"
-	cipher                "CIPHER"
-	cleanup               "CLEANUP"
-	client                "CLIENT"
-	clientErrorsSummary   "CLIENT_ERRORS_SUMMARY"
-	close                 "CLOSE"
-	cluster               "CLUSTER"
-	clustered             "CLUSTERED"
-	coalesce              "COALESCE"
-	collation             "COLLATION"
-	columns               "COLUMNS"
-	columnFormat          "COLUMN_FORMAT"
-	comment               "COMMENT"
-	commit                "COMMIT"
-	committed             "COMMITTED"
-	compact               "COMPACT"
-	compressed            "COMPRESSED"
-	compression           "COMPRESSION"
-	concurrency           "CONCURRENCY"
-	config                "CONFIG"
-	connection            "CONNECTION"
-	consistency           "CONSISTENCY"
-	consistent            "CONSISTENT"
-	context               "CONTEXT"
-	cpu                   "CPU"
-	csvBackslashEscape    "CSV_BACKSLASH_ESCAPE"
-	csvDelimiter          "CSV_DELIMITER"
-	csvHeader             "CSV_HEADER"
-	csvNotNull            "CSV_NOT_NULL"
-	csvNull               "CSV_NULL"
-	csvSeparator          "CSV_SEPARATOR"
-	csvTrimLastSeparators "CSV_TRIM_LAST_SEPARATORS"
-	current               "CURRENT"
-	cycle                 "CYCLE"
-	data                  "DATA"
-	dateType              "DATE"
-	datetimeType          "DATETIME"
-	day                   "DAY"
-	deallocate            "DEALLOCATE"
-	declare               "DECLARE"
-	definer               "DEFINER"
-	delayKeyWrite         "DELAY_KEY_WRITE"
-	digest                "DIGEST"
-	directory             "DIRECTORY"
-	disable               "DISABLE"
-	disabled              "DISABLED"
-	discard               "DISCARD"
-	disk                  "DISK"
-	do                    "DO"
-	duplicate             "DUPLICATE"
-	dynamic               "DYNAMIC"
-	enable                "ENABLE"
-	enabled               "ENABLED"
-	encryption            "ENCRYPTION"
-	end                   "END"
-	enforced              "ENFORCED"
-	engine                "ENGINE"
-	engines               "ENGINES"
-	enum                  "ENUM"
-	errorKwd              "ERROR"
-	identSQLErrors        "ERRORS"
-	escape                "ESCAPE"
-	event                 "EVENT"
-	events                "EVENTS"
-	evolve                "EVOLVE"
-	exchange              "EXCHANGE"
-	exclusive             "EXCLUSIVE"
-	execute               "EXECUTE"
-	expansion             "EXPANSION"
-	expire                "EXPIRE"
-	extended              "EXTENDED"
-	failedLoginAttempts   "FAILED_LOGIN_ATTEMPTS"
-	faultsSym             "FAULTS"
-	fields                "FIELDS"
-	file                  "FILE"
-	first                 "FIRST"
-	fixed                 "FIXED"
-	flush                 "FLUSH"
-	following             "FOLLOWING"
-	format                "FORMAT"
-	found                 "FOUND"
-	full                  "FULL"
-	function              "FUNCTION"
-	general               "GENERAL"
-	global                "GLOBAL"
-	grants                "GRANTS"
-	handler               "HANDLER"
-	hash                  "HASH"
-	help                  "HELP"
-	histogram             "HISTOGRAM"
-	history               "HISTORY"
-	hosts                 "HOSTS"
-	hour                  "HOUR"
-	hypo                  "HYPO"
-	identified            "IDENTIFIED"
-	importKwd             "IMPORT"
-	imports               "IMPORTS"
-	increment             "INCREMENT"
-	incremental           "INCREMENTAL"
-	indexes               "INDEXES"
-	insertMethod          "INSERT_METHOD"
-	instance              "INSTANCE"
-	invisible             "INVISIBLE"
-	invoker               "INVOKER"
-	io                    "IO"
-	ipc                   "IPC"
-	isolation             "ISOLATION"
-	issuer                "ISSUER"
-	jsonType              "JSON"
-	keyBlockSize          "KEY_BLOCK_SIZE"
-	labels                "LABELS"
-	language              "LANGUAGE"
-	last                  "LAST"
-	lastval               "LASTVAL"
-	lastBackup            "LAST_BACKUP"
-	less                  "LESS"
-	level                 "LEVEL"
-	list                  "LIST"
-	local                 "LOCAL"
-	location              "LOCATION"
-	locked                "LOCKED"
-	logs                  "LOGS"
-	master                "MASTER"
-	maxConnectionsPerHour "MAX_CONNECTIONS_PER_HOUR"
-	max_idxnum            "MAX_IDXNUM"
-	max_minutes           "MAX_MINUTES"
-	maxQueriesPerHour     "MAX_QUERIES_PER_HOUR"
-	maxRows               "MAX_ROWS"
-	maxUpdatesPerHour     "MAX_UPDATES_PER_HOUR"
-	maxUserConnections    "MAX_USER_CONNECTIONS"
-	mb                    "MB"
-	member                "MEMBER"
-	memory                "MEMORY"
-	merge                 "MERGE"
-	microsecond           "MICROSECOND"
-	minute                "MINUTE"
-	minValue              "MINVALUE"
-	minRows               "MIN_ROWS"
-	mode                  "MODE"
-	modify                "MODIFY"
-	month                 "MONTH"
-	names                 "NAMES"
-	national              "NATIONAL"
-	ncharType             "NCHAR"
-	never                 "NEVER"
-	next                  "NEXT"
-	nextval               "NEXTVAL"
-	no                    "NO"
-	nocache               "NOCACHE"
-	nocycle               "NOCYCLE"
-	nodegroup             "NODEGROUP"
-	nomaxvalue            "NOMAXVALUE"
-	nominvalue            "NOMINVALUE"
-	nonclustered          "NONCLUSTERED"
-	none                  "NONE"
-	nowait                "NOWAIT"
-	nulls                 "NULLS"
-	nvarcharType          "NVARCHAR"
-	off                   "OFF"
-	offset                "OFFSET"
-	oltpReadOnly          "OLTP_READ_ONLY"
-	oltpReadWrite         "OLTP_READ_WRITE"
-	oltpWriteOnly         "OLTP_WRITE_ONLY"
-	online                "ONLINE"
-	only                  "ONLY"
-	onDuplicate           "ON_DUPLICATE"
-	open                  "OPEN"
-	optional              "OPTIONAL"
-	packKeys              "PACK_KEYS"
-	pageSym               "PAGE"
-	parser                "PARSER"
-	partial               "PARTIAL"
-	partitioning          "PARTITIONING"
-	partitions            "PARTITIONS"
-	password              "PASSWORD"
-	passwordLockTime      "PASSWORD_LOCK_TIME"
-	pause                 "PAUSE"
-	percent               "PERCENT"
-	per_db                "PER_DB"
-	per_table             "PER_TABLE"
+	account                "ACCOUNT"
+	action                 "ACTION"
+	advise                 "ADVISE"
+	after                  "AFTER"
+	against                "AGAINST"
+	ago                    "AGO"
+	algorithm              "ALGORITHM"
+	always                 "ALWAYS"
+	any                    "ANY"
+	ascii                  "ASCII"
+	attribute              "ATTRIBUTE"
+	attributes             "ATTRIBUTES"
+	autoIdCache            "AUTO_ID_CACHE"
+	autoIncrement          "AUTO_INCREMENT"
+	autoRandom             "AUTO_RANDOM"
+	autoRandomBase         "AUTO_RANDOM_BASE"
+	avg                    "AVG"
+	avgRowLength           "AVG_ROW_LENGTH"
+	backend                "BACKEND"
+	backup                 "BACKUP"
+	backups                "BACKUPS"
+	bdr                    "BDR"
+	before                 "BEFORE"
+	begin                  "BEGIN"
+	bernoulli              "BERNOULLI"
+	binding                "BINDING"
+	bindings               "BINDINGS"
+	bindingCache           "BINDING_CACHE"
+	binlog                 "BINLOG"
+	bitType                "BIT"
+	block                  "BLOCK"
+	boolType               "BOOL"
+	booleanType            "BOOLEAN"
+	btree                  "BTREE"
+	byteType               "BYTE"
+	cache                  "CACHE"
+	calibrate              "CALIBRATE"
+	capture                "CAPTURE"
+	cardinalityCorrections "CARDINALITY_CORRECTIONS"
+	cascaded               "CASCADED"
+	causal                 "CAUSAL"
+	chain                  "CHAIN"
+	changefeed             "CHANGEFEED"
+	changefeeds            "CHANGEFEEDS"
+	charsetKwd             "CHARSET"
+	checkpoint             "CHECKPOINT"
+	checksum               "CHECKSUM"
+	cipher                 "CIPHER"
+	cleanup                "CLEANUP"
+	client                 "CLIENT"
+	clientErrorsSummary    "CLIENT_ERRORS_SUMMARY"
+	close                  "CLOSE"
+	cluster                "CLUSTER"
+	clustered              "CLUSTERED"
+	coalesce               "COALESCE"
+	collation              "COLLATION"
+	columns                "COLUMNS"
+	columnFormat           "COLUMN_FORMAT"
+	comment                "COMMENT"
+	commit                 "COMMIT"
+	committed              "COMMITTED"
+	compact                "COMPACT"
+	complete               "COMPLETE"
+	completion             "COMPLETION"
+	compressed             "COMPRESSED"
+	compression            "COMPRESSION"
+	concurrency            "CONCURRENCY"
+	config                 "CONFIG"
+	connection             "CONNECTION"
+	consistency            "CONSISTENCY"
+	consistent             "CONSISTENT"
+	context                "CONTEXT"
+	cpu                    "CPU"
+	csvBackslashEscape     "CSV_BACKSLASH_ESCAPE"
+	csvDelimiter           "CSV_DELIMITER"
+	csvHeader              "CSV_HEADER"
+	csvNotNull             "CSV_NOT_NULL"
+	csvNull                "CSV_NULL"
+	csvSeparator           "CSV_SEPARATOR"
+	csvTrimLastSeparators  "CSV_TRIM_LAST_SEPARATORS"
+	current                "CURRENT"
+	cycle                  "CYCLE"
+	data                   "DATA"
+	dateType               "DATE"
+	datetimeType           "DATETIME"
+	day                    "DAY"
+	deallocate             "DEALLOCATE"
+	declare                "DECLARE"
+	definer                "DEFINER"
+	delayKeyWrite          "DELAY_KEY_WRITE"
+	demand                 "DEMAND"
+	digest                 "DIGEST"
+	directory              "DIRECTORY"
+	disable                "DISABLE"
+	disabled               "DISABLED"
+	discard                "DISCARD"
+	disk                   "DISK"
+	do                     "DO"
+	duplicate              "DUPLICATE"
+	dynamic                "DYNAMIC"
+	each                   "EACH"
+	emptyKwd               "EMPTY"
+	enable                 "ENABLE"
+	enabled                "ENABLED"
+	encryption             "ENCRYPTION"
+	end                    "END"
+	ends                   "ENDS"
+	enforced               "ENFORCED"
+	engine                 "ENGINE"
+	engines                "ENGINES"
+	enum                   "ENUM"
+	errorKwd               "ERROR"
+	identSQLErrors         "ERRORS"
+	escape                 "ESCAPE"
+	event                  "EVENT"
+	events                 "EVENTS"
+	every                  "EVERY"
+	evolve                 "EVOLVE"
+	exchange               "EXCHANGE"
+	exclude                "EXCLUDE"
+	exclusive              "EXCLUSIVE"
+	execute                "EXECUTE"
+	expansion              "EXPANSION"
+	expire                 "EXPIRE"
+	extended               "EXTENDED"
+	failedLoginAttempts    "FAILED_LOGIN_ATTEMPTS"
+	fast                   "FAST"
+	faultsSym              "FAULTS"
+	fields                 "FIELDS"
+	file                   "FILE"
+	first                  "FIRST"
+	fixed                  "FIXED"
+	flush                  "FLUSH"
+	following              "FOLLOWING"
+	format                 "FORMAT"
+	found                  "FOUND"
+	full                   "FULL"
+	function               "FUNCTION"
+	general                "GENERAL"
+	geomCollection         "GEOMCOLLECTION"
+	geometry               "GEOMETRY"
+	geometryCollection     "GEOMETRYCOLLECTION"
+	global                 "GLOBAL"
+	grants                 "GRANTS"
+	handler                "HANDLER"
+	hash                   "HASH"
+	help                   "HELP"
+	histogram              "HISTOGRAM"
+	history                "HISTORY"
+	hosts                  "HOSTS"
+	hour                   "HOUR"
+	hypo                   "HYPO"
+	identified             "IDENTIFIED"
+	importKwd              "IMPORT"
+	imports                "IMPORTS"
+	include                "INCLUDE"
+	increment              "INCREMENT"
+	incremental            "INCREMENTAL"
+	indexes                "INDEXES"
+	insertMethod           "INSERT_METHOD"
+	instance               "INSTANCE"
+	invisible              "INVISIBLE"
+	invoker                "INVOKER"
+	io                     "IO"
+	ipc                    "IPC"
+	isolation              "ISOLATION"
+	issuer                 "ISSUER"
+	jsonType               "JSON"
+	keyBlockSize           "KEY_BLOCK_SIZE"
+	labels                 "LABELS"
+	language               "LANGUAGE"
+	last                   "LAST"
+	lastval                "LASTVAL"
+	lastBackup             "LAST_BACKUP"
+	less                   "LESS"
+	level                  "LEVEL"
+	lineString             "LINESTRING"
+	list                   "LIST"
+	local                  "LOCAL"
+	location               "LOCATION"
+	locked                 "LOCKED"
+	logs                   "LOGS"
+	master                 "MASTER"
+	matched                "MATCHED"
+	materialized           "MATERIALIZED"
+	maxConnectionsPerHour  "MAX_CONNECTIONS_PER_HOUR"
+	max_idxnum             "MAX_IDXNUM"
+	max_minutes            "MAX_MINUTES"
+	maxQueriesPerHour      "MAX_QUERIES_PER_HOUR"
+	maxRows                "MAX_ROWS"
+	maxUpdatesPerHour      "MAX_UPDATES_PER_HOUR"
+	maxUserConnections     "MAX_USER_CONNECTIONS"
+	mb                     "MB"
+	member                 "MEMBER"
+	memory                 "MEMORY"
+	merge                  "MERGE"
+	microsecond            "MICROSECOND"
+	migrate                "MIGRATE"
+	minute                 "MINUTE"
+	minValue               "MINVALUE"
+	minRows                "MIN_ROWS"
+	mode                   "MODE"
+	modify                 "MODIFY"
+	month                  "MONTH"
+	multiLineString        "MULTILINESTRING"
+	multiPoint             "MULTIPOINT"
+	multiPolygon           "MULTIPOLYGON"
+	names                  "NAMES"
+	national               "NATIONAL"
+	ncharType              "NCHAR"
+	nested                 "NESTED"
+	never                  "NEVER"
+	next                   "NEXT"
+	nextval                "NEXTVAL"
+	no                     "NO"
+	nocache                "NOCACHE"
+	nocycle                "NOCYCLE"
+	nodegroup              "NODEGROUP"
+	nomaxvalue             "NOMAXVALUE"
+	nominvalue             "NOMINVALUE"
+	nonclustered           "NONCLUSTERED"
+	none                   "NONE"
+	nowait                 "NOWAIT"
+	nulls                  "NULLS"
+	nvarcharType           "NVARCHAR"
+	off                    "OFF"
+	offset                 "OFFSET"
+	oltpReadOnly           "OLTP_READ_ONLY"
+	oltpReadWrite          "OLTP_READ_WRITE"
+	oltpWriteOnly          "OLTP_WRITE_ONLY"
+	one                    "ONE"
+	online                 "ONLINE"
+	only                   "ONLY"
+	onDuplicate            "ON_DUPLICATE"
+	open                   "OPEN"
+	optional               "OPTIONAL"
+	ordinality             "ORDINALITY"
+	packKeys               "PACK_KEYS"
+	pageSym                "PAGE"
+	parser                 "PARSER"
+	partial                "PARTIAL"
+	partitioning           "PARTITIONING"
+	partitions             "PARTITIONS"
+	password               "PASSWORD"
+	passwordLockTime       "PASSWORD_LOCK_TIME"
+	path                   "PATH"
+	pause                  "PAUSE"
+	percent                "PERCENT"
+	per_db                 "PER_DB"
+	per_table              "PER_TABLE"
+	phase                  "PHASE"
 	pipesAsOr
-	plugins               "PLUGINS"
-	point                 "POINT"
-	policy                "POLICY"
-	preceding             "PRECEDING"
-	prepare               "PREPARE"
-	preserve              "PRESERVE"
-	preSplitRegions       "PRE_SPLIT_REGIONS"
-	privileges            "PRIVILEGES"
-	process               "PROCESS"
-	processlist           "PROCESSLIST"
-	profile               "PROFILE"
-	profiles              "PROFILES"
-	proxy                 "PROXY"
-	purge                 "PURGE"
-	quarter               "QUARTER"
-	queries               "QUERIES"
-	query                 "QUERY"
-	quick                 "QUICK"
-	rateLimit             "RATE_LIMIT"
-	rebuild               "REBUILD"
-	recover               "RECOVER"
-	redundant             "REDUNDANT"
-	reload                "RELOAD"
-	remove                "REMOVE"
-	reorganize            "REORGANIZE"
-	repair                "REPAIR"
-	repeatable            "REPEATABLE"
-	replica               "REPLICA"
-	replicas              "REPLICAS"
-	replication           "REPLICATION"
-	required              "REQUIRED"
-	resource              "RESOURCE"
-	respect               "RESPECT"
-	restart               "RESTART"
-	restore               "RESTORE"
-	restores              "RESTORES"
-	resume                "RESUME"
-	reuse                 "REUSE"
-	reverse               "REVERSE"
-	role                  "ROLE"
-	rollback              "ROLLBACK"
-	rollup                "ROLLUP"
-	routine               "ROUTINE"
-	rowCount              "ROW_COUNT"
-	rowFormat             "ROW_FORMAT"
-	rtree                 "RTREE"
-	san                   "SAN"
-	savepoint             "SAVEPOINT"
-	second                "SECOND"
-	secondary             "SECONDARY"
-	secondaryEngine       "SECONDARY_ENGINE"
-	secondaryLoad         "SECONDARY_LOAD"
-	secondaryUnload       "SECONDARY_UNLOAD"
-	security              "SECURITY"
-	sendCredentialsToTiKV "SEND_CREDENTIALS_TO_TIKV"
-	separator             "SEPARATOR"
-	sequence              "SEQUENCE"
-	serial                "SERIAL"
-	serializable          "SERIALIZABLE"
-	session               "SESSION"
-	setval                "SETVAL"
-	shardRowIDBits        "SHARD_ROW_ID_BITS"
-	share                 "SHARE"
-	shared                "SHARED"
-	shutdown              "SHUTDOWN"
-	signed                "SIGNED"
-	simple                "SIMPLE"
-	skip                  "SKIP"
-	skipSchemaFiles       "SKIP_SCHEMA_FILES"
-	slave                 "SLAVE"
-	slow                  "SLOW"
-	snapshot              "SNAPSHOT"
-	some                  "SOME"
-	source                "SOURCE"
-	sqlBufferResult       "SQL_BUFFER_RESULT"
-	sqlCache              "SQL_CACHE"
-	sqlNoCache            "SQL_NO_CACHE"
-	sqlTsiDay             "SQL_TSI_DAY"
-	sqlTsiHour            "SQL_TSI_HOUR"
-	sqlTsiMinute          "SQL_TSI_MINUTE"
-	sqlTsiMonth           "SQL_TSI_MONTH"
-	sqlTsiQuarter         "SQL_TSI_QUARTER"
-	sqlTsiSecond          "SQL_TSI_SECOND"
-	sqlTsiWeek            "SQL_TSI_WEEK"
-	sqlTsiYear            "SQL_TSI_YEAR"
-	start                 "START"
-	statsAutoRecalc       "STATS_AUTO_RECALC"
-	statsColChoice        "STATS_COL_CHOICE"
-	statsColList          "STATS_COL_LIST"
-	statsOptions          "STATS_OPTIONS"
-	statsPersistent       "STATS_PERSISTENT"
-	statsSamplePages      "STATS_SAMPLE_PAGES"
-	statsSampleRate       "STATS_SAMPLE_RATE"
-	status                "STATUS"
-	storage               "STORAGE"
-	strictFormat          "STRICT_FORMAT"
-	subject               "SUBJECT"
-	subpartition          "SUBPARTITION"
-	subpartitions         "SUBPARTITIONS"
-	super                 "SUPER"
-	swaps                 "SWAPS"
-	switchesSym           "SWITCHES"
-	system                "SYSTEM"
-	systemTime            "SYSTEM_TIME"
-	tables                "TABLES"
-	tablespace            "TABLESPACE"
-	tableChecksum         "TABLE_CHECKSUM"
-	temporary             "TEMPORARY"
-	temptable             "TEMPTABLE"
-	textType              "TEXT"
-	than                  "THAN"
-	tikvImporter          "TIKV_IMPORTER"
-	timeType              "TIME"
-	timestampType         "TIMESTAMP"
-	tokenIssuer           "TOKEN_ISSUER"
-	tpcc                  "TPCC"
-	tpch10                "TPCH_10"
-	trace                 "TRACE"
-	traditional           "TRADITIONAL"
-	transaction           "TRANSACTION"
-	triggers              "TRIGGERS"
-	truncate              "TRUNCATE"
-	tsoType               "TSO"
-	ttl                   "TTL"
-	ttlEnable             "TTL_ENABLE"
-	ttlJobInterval        "TTL_JOB_INTERVAL"
-	tp                    "TYPE"
-	unbounded             "UNBOUNDED"
-	uncommitted           "UNCOMMITTED"
-	undefined             "UNDEFINED"
-	unicodeSym            "UNICODE"
-	unknown               "UNKNOWN"
-	unset                 "UNSET"
-	user                  "USER"
-	validation            "VALIDATION"
-	value                 "VALUE"
-	variables             "VARIABLES"
-	view                  "VIEW"
-	visible               "VISIBLE"
-	wait                  "WAIT"
-	warnings              "WARNINGS"
-	week                  "WEEK"
-	weightString          "WEIGHT_STRING"
-	without               "WITHOUT"
-	workload              "WORKLOAD"
-	x509                  "X509"
-	yearType              "YEAR"
-	withSysTable          "WITH_SYS_TABLE"
-	waitTiflashReady      "WAIT_TIFLASH_READY"
-	ignoreStats           "IGNORE_STATS"
-	loadStats             "LOAD_STATS"
-	checksumConcurrency   "CHECKSUM_CONCURRENCY"
-	compressionLevel      "COMPRESSION_LEVEL"
-	compressionType       "COMPRESSION_TYPE"
-	encryptionMethod      "ENCRYPTION_METHOD"
-	encryptionKeyFile     "ENCRYPTION_KEYFILE"
+	pivot                  "PIVOT"
+	plugins                "PLUGINS"
+	point                  "POINT"
+	policy                 "POLICY"
+	polygon                "POLYGON"
+	preceding              "PRECEDING"
+	prepare                "PREPARE"
+	preserve               "PRESERVE"
+	preSplitRegions        "PRE_SPLIT_REGIONS"
+	privileges             "PRIVILEGES"
+	process                "PROCESS"
+	processlist            "PROCESSLIST"
+	profile                "PROFILE"
+	profiles               "PROFILES"
+	proxy                  "PROXY"
+	purge                  "PURGE"
+	qualify                "QUALIFY"
+	quarter                "QUARTER"
+	queries                "QUERIES"
+	query                  "QUERY"
+	quick                  "QUICK"
+	rateLimit              "RATE_LIMIT"
+	rebuild                "REBUILD"
+	recommend              "RECOMMEND"
+	recover                "RECOVER"
+	redundant              "REDUNDANT"
+	refresh                "REFRESH"
+	reload                 "RELOAD"
+	remove                 "REMOVE"
+	reorganize             "REORGANIZE"
+	repair                 "REPAIR"
+	repeatable             "REPEATABLE"
+	replica                "REPLICA"
+	replicas               "REPLICAS"
+	replication            "REPLICATION"
+	required               "REQUIRED"
+	resource               "RESOURCE"
+	respect                "RESPECT"
+	restart                "RESTART"
+	restore                "RESTORE"
+	restores               "RESTORES"
+	resume                 "RESUME"
+	returnKwd              "RETURN"
+	returning              "RETURNING"
+	returns                "RETURNS"
+	reuse                  "REUSE"
+	reverse                "REVERSE"
+	role                   "ROLE"
+	rollback               "ROLLBACK"
+	rollup                 "ROLLUP"
+	routine                "ROUTINE"
+	rowCount               "ROW_COUNT"
+	rowFormat              "ROW_FORMAT"
+	rtree                  "RTREE"
+	san                    "SAN"
+	savepoint              "SAVEPOINT"
+	second                 "SECOND"
+	secondary              "SECONDARY"
+	secondaryEngine        "SECONDARY_ENGINE"
+	secondaryLoad          "SECONDARY_LOAD"
+	secondaryUnload        "SECONDARY_UNLOAD"
+	security               "SECURITY"
+	sendCredentialsToTiKV  "SEND_CREDENTIALS_TO_TIKV"
+	separator              "SEPARATOR"
+	sequence               "SEQUENCE"
+	serial                 "SERIAL"
+	serializable           "SERIALIZABLE"
+	session                "SESSION"
+	setval                 "SETVAL"
+	shardRowIDBits         "SHARD_ROW_ID_BITS"
+	share                  "SHARE"
+	shared                 "SHARED"
+	shutdown               "SHUTDOWN"
+	signed                 "SIGNED"
+	simple                 "SIMPLE"
+	skip                   "SKIP"
+	skipSchemaFiles        "SKIP_SCHEMA_FILES"
+	slave                  "SLAVE"
+	slow                   "SLOW"
+	snapshot               "SNAPSHOT"
+	some                   "SOME"
+	source                 "SOURCE"
+	sqlBufferResult        "SQL_BUFFER_RESULT"
+	sqlCache               "SQL_CACHE"
+	sqlNoCache             "SQL_NO_CACHE"
+	sqlTsiDay              "SQL_TSI_DAY"
+	sqlTsiHour             "SQL_TSI_HOUR"
+	sqlTsiMinute           "SQL_TSI_MINUTE"
+	sqlTsiMonth            "SQL_TSI_MONTH"
+	sqlTsiQuarter          "SQL_TSI_QUARTER"
+	sqlTsiSecond           "SQL_TSI_SECOND"
+	sqlTsiWeek             "SQL_TSI_WEEK"
+	sqlTsiYear             "SQL_TSI_YEAR"
+	srid                   "SRID"
+	start                  "START"
+	starts                 "STARTS"
+	statsAutoRecalc        "STATS_AUTO_RECALC"
+	statsColChoice         "STATS_COL_CHOICE"
+	statsColList           "STATS_COL_LIST"
+	statsOptions           "STATS_OPTIONS"
+	statsPersistent        "STATS_PERSISTENT"
+	statsSamplePages       "STATS_SAMPLE_PAGES"
+	statsSampleRate        "STATS_SAMPLE_RATE"
+	status                 "STATUS"
+	storage                "STORAGE"
+	strictFormat           "STRICT_FORMAT"
+	subject                "SUBJECT"
+	subpartition           "SUBPARTITION"
+	subpartitions          "SUBPARTITIONS"
+	super                  "SUPER"
+	suspend                "SUSPEND"
+	swaps                  "SWAPS"
+	switchesSym            "SWITCHES"
+	system                 "SYSTEM"
+	systemTime             "SYSTEM_TIME"
+	tables                 "TABLES"
+	tablespace             "TABLESPACE"
+	tableChecksum          "TABLE_CHECKSUM"
+	temporary              "TEMPORARY"
+	temptable              "TEMPTABLE"
+	textType               "TEXT"
+	than                   "THAN"
+	tikvImporter           "TIKV_IMPORTER"
+	timeType               "TIME"
+	timestampType          "TIMESTAMP"
+	tokenIssuer            "TOKEN_ISSUER"
+	tpcc                   "TPCC"
+	tpch10                 "TPCH_10"
+	trace                  "TRACE"
+	traditional            "TRADITIONAL"
+	transaction            "TRANSACTION"
+	triggers               "TRIGGERS"
+	truncate               "TRUNCATE"
+	tsoType                "TSO"
+	ttl                    "TTL"
+	ttlEnable              "TTL_ENABLE"
+	ttlJobInterval         "TTL_JOB_INTERVAL"
+	tp                     "TYPE"
+	unbounded              "UNBOUNDED"
+	uncommitted            "UNCOMMITTED"
+	undefined              "UNDEFINED"
+	unicodeSym             "UNICODE"
+	unknown                "UNKNOWN"
+	unpivot                "UNPIVOT"
+	unset                  "UNSET"
+	user                   "USER"
+	validation             "VALIDATION"
+	value                  "VALUE"
+	variables              "VARIABLES"
+	vectorType             "VECTOR"
+	view                   "VIEW"
+	visible                "VISIBLE"
+	wait                   "WAIT"
+	warnings               "WARNINGS"
+	week                   "WEEK"
+	weightString           "WEIGHT_STRING"
+	without                "WITHOUT"
+	workload               "WORKLOAD"
+	x509                   "X509"
+	xa                     "XA"
+	xid                    "XID"
+	yearType               "YEAR"
+	withSysTable           "WITH_SYS_TABLE"
+	waitTiflashReady       "WAIT_TIFLASH_READY"
+	ignoreStats            "IGNORE_STATS"
+	loadStats              "LOAD_STATS"
+	checksumConcurrency    "CHECKSUM_CONCURRENCY"
+	compressionLevel       "COMPRESSION_LEVEL"
+	compressionType        "COMPRESSION_TYPE"
+	encryptionMethod       "ENCRYPTION_METHOD"
+	encryptionKeyFile      "ENCRYPTION_KEYFILE"
 
 	/* The following tokens belong to NotKeywordToken. Notice: make sure these tokens are contained in NotKeywordToken. */
 	addDate               "ADDDATE"
@@ -737,6 +783,7 @@ import (
 	ioWriteBandwidth      "IO_WRITE_BANDWIDTH"
 	jsonArrayagg          "JSON_ARRAYAGG"
 	jsonObjectAgg         "JSON_OBJECTAGG"
+	jsonTable             "JSON_TABLE"
 	leader                "LEADER"
 	leaderConstraints     "LEADER_CONSTRAINTS"
 	learner               "LEARNER"
@@ -850,12 +897,15 @@ import (
 	correlation                "CORRELATION"
 	ddl                        "DDL"
 	dependency                 "DEPENDENCY"
+	dependencies               "DEPENDENCIES"
 	depth                      "DEPTH"
 	drainer                    "DRAINER"
 	dry                        "DRY"
 	histogramsInFlight         "HISTOGRAMS_IN_FLIGHT"
 	job                        "JOB"
 	jobs                       "JOBS"
+	mcv                        "MCV"
+	ndistinct                  "NDISTINCT"
 	nodeID                     "NODE_ID"
 	nodeState                  "NODE_STATE"
 	optimistic                 "OPTIMISTIC"
@@ -932,6 +982,7 @@ import (
 	Variable                        "User or system variable"
 	SystemVariable                  "System defined variable name"
 	UserVariable                    "User defined variable name"
+	SelectIntoVar                   "Variable of the SELECT statement into clause"
 	SubSelect                       "Sub Select"
 	StringLiteral                   "text literal"
 	ExpressionOpt                   "Optional expression"
@@ -958,6 +1009,7 @@ import (
 	AlterPolicyStmt            "Alter Placement Policy statement"
 	AlterResourceGroupStmt     "Alter Resource Group statement"
 	AlterSequenceStmt          "Alter sequence statement"
+	AlterEventStmt             "ALTER EVENT statement"
 	AnalyzeTableStmt           "Analyze table statement"
 	BeginTransactionStmt       "BEGIN TRANSACTION statement"
 	BinlogStmt                 "Binlog base64 statement"
@@ -966,13 +1018,19 @@ import (
 	CommitStmt                 "COMMIT statement"
 	CreateTableStmt            "CREATE TABLE statement"
 	CreateViewStmt             "CREATE VIEW  statement"
+	CreateMaterializedViewStmt "CREATE MATERIALIZED VIEW statement"
 	CreateUserStmt             "CREATE User statement"
 	CreateRoleStmt             "CREATE Role statement"
 	CreateDatabaseStmt         "Create Database Statement"
 	CreateIndexStmt            "CREATE INDEX statement"
 	CreateBindingStmt          "CREATE BINDING statement"
 	CreatePolicyStmt           "CREATE PLACEMENT POLICY statement"
+	CreateFunctionStmt         "CREATE FUNCTION statement"
 	CreateProcedureStmt        "CREATE PROCEDURE statement"
+	CreateEventStmt            "CREATE EVENT statement"
+	CreateChangefeedStmt       "CREATE CHANGEFEED statement"
+	ChangefeedActionStmt       "PAUSE or RESUME CHANGEFEED statement"
+	CreateTriggerStmt          "CREATE TRIGGER statement"
 	AddQueryWatchStmt          "ADD QUERY WATCH statement"
 	CreateResourceGroupStmt    "CREATE RESOURCE GROUP statement"
 	CreateSequenceStmt         "CREATE SEQUENCE statement"
@@ -980,7 +1038,11 @@ import (
 	DoStmt                     "Do statement"
 	DropDatabaseStmt           "DROP DATABASE statement"
 	DropIndexStmt              "DROP INDEX statement"
+	DropFunctionStmt           "DROP FUNCTION statement"
 	DropProcedureStmt          "DROP PROCEDURE statement"
+	DropEventStmt              "DROP EVENT statement"
+	DropChangefeedStmt         "DROP CHANGEFEED statement"
+	DropTriggerStmt            "DROP TRIGGER statement"
 	DropQueryWatchStmt         "DROP QUERY WATCH statement"
 	DropResourceGroupStmt      "DROP RESOURCE GROUP statement"
 	DropStatisticsStmt         "DROP STATISTICS statement"
@@ -990,6 +1052,7 @@ import (
 	DropUserStmt               "DROP USER"
 	DropRoleStmt               "DROP ROLE"
 	DropViewStmt               "DROP VIEW statement"
+	DropMaterializedViewStmt   "DROP MATERIALIZED VIEW statement"
 	DropBindingStmt            "DROP BINDING  statement"
 	DropPolicyStmt             "DROP PLACEMENT POLICY statement"
 	DeallocateStmt             "Deallocate prepared statement"
@@ -1010,6 +1073,7 @@ import (
 	InsertIntoStmt             "INSERT INTO statement"
 	CallStmt                   "CALL statement"
 	IndexAdviseStmt            "INDEX ADVISE statement"
+	RecommendIndexStmt         "RECOMMEND INDEX statement"
 	ImportIntoStmt             "IMPORT INTO statement"
 	ImportFromSelectStmt       "SELECT statement of IMPORT INTO"
 	KillStmt                   "Kill statement"
@@ -1018,6 +1082,7 @@ import (
 	LockStatsStmt              "Lock statistic statement"
 	UnlockStatsStmt            "Unlock statistic statement"
 	LockTablesStmt             "Lock tables statement"
+	MergeStmt                  "MERGE statement"
 	NonTransactionalDMLStmt    "Non-transactional DML statement"
 	OptimizeTableStmt          "OPTIMIZE statement"
 	PlanReplayerStmt           "Plan replayer statement"
@@ -1030,6 +1095,7 @@ import (
 	RenameUserStmt             "rename user statement"
 	ReplaceIntoStmt            "REPLACE INTO statement"
 	RecoverTableStmt           "recover table statement"
+	RefreshMatViewStmt         "REFRESH MATERIALIZED VIEW statement"
 	RevokeStmt                 "Revoke statement"
 	RevokeRoleStmt             "Revoke role statement"
 	RollbackStmt               "ROLLBACK statement"
@@ -1052,6 +1118,7 @@ import (
 	SetOprStmtWithLimitOrderBy "Union/Except/Intersect select statement with limit and order by"
 	SetOprStmtWoutLimitOrderBy "Union/Except/Intersect select statement without limit and order by"
 	UseStmt                    "USE statement"
+	XAStmt                     "XA transaction statement"
 	ShutdownStmt               "SHUTDOWN statement"
 	RestartStmt                "RESTART statement"
 	CreateViewSelectOpt        "Select/Union/Except/Intersect statement in CREATE VIEW ... AS SELECT"
@@ -1152,6 +1219,9 @@ import (
 	ExpressionList                         "expression list"
 	ExtendedPriv                           "Extended privileges like LOAD FROM S3 or dynamic privileges"
 	MaxValueOrExpressionList               "maxvalue or expression list"
+	MaterializedViewRefreshOpt             "materialized view refresh option"
+	MaterializedViewRefreshMethod          "materialized view refresh method"
+	MaterializedViewRefreshSchedule        "materialized view refresh schedule"
 	DefaultOrExpressionList                "default or expression list"
 	ExpressionListOpt                      "expression list opt"
 	FetchFirstOpt                          "Fetch First/Next Option"
@@ -1204,6 +1274,11 @@ import (
 	InsertValues                           "Rest part of INSERT/REPLACE INTO statement"
 	IntervalExpr                           "Interval expression"
 	JoinTable                              "join table"
+	JSONTable                              "JSON_TABLE table function"
+	JSONTableColumn                        "JSON_TABLE column definition"
+	JSONTableColumnList                    "JSON_TABLE column definition list"
+	JSONTableOnEmptyOnErrorOpt             "optional JSON_TABLE ON EMPTY and ON ERROR clauses"
+	JSONTableOnResponse                    "JSON_TABLE ON EMPTY or ON ERROR response"
 	JoinType                               "join type"
 	KillOrKillTiDB                         "Kill or Kill TiDB"
 	LocationLabelList                      "location label name list"
@@ -1228,6 +1303,10 @@ import (
 	NoWriteToBinLogAliasOpt                "NO_WRITE_TO_BINLOG alias LOCAL or empty"
 	ObjectType                             "Grant statement object type"
 	OnDuplicateKeyUpdate                   "ON DUPLICATE KEY UPDATE value list"
+	ReturningOpt                           "RETURNING clause"
+	MergeWhenClauseList                    "MERGE WHEN clause list"
+	MergeWhenClause                        "MERGE WHEN clause"
+	MergeConditionOpt                      "MERGE WHEN clause condition"
 	OnCommitOpt                            "ON COMMIT DELETE |PRESERVE ROWS"
 	DuplicateOpt                           "[IGNORE|REPLACE] in CREATE TABLE ... SELECT statement or LOAD DATA statement"
 	FormatOpt                              "FORMAT 'SQL FILE'..."
@@ -1261,6 +1340,8 @@ import (
 	PasswordOrLockOption                   "Single password or lock option for create user statement"
 	PasswordOrLockOptionList               "Password or lock options for create user statement"
 	PasswordOrLockOptions                  "Optional password or lock options for create user statement"
+	PivotItem                              "aggregate function or value with an optional alias in PIVOT"
+	PivotItemList                          "PIVOT aggregate function or value list"
 	PlanReplayerDumpOpt                    "Plan Replayer Dump option"
 	CommentOrAttributeOption               "Optional comment or attribute option for CREATE/ALTER USER statements"
 	ColumnPosition                         "Column position [First|After ColumnName]"
@@ -1303,7 +1384,29 @@ import (
 	SelectStmtFromDualTable                "SELECT statement from dual table"
 	SelectStmtFromTable                    "SELECT statement from table"
 	SelectStmtGroup                        "SELECT statement optional GROUP BY clause"
+	SelectStmtIntoClause                   "SELECT statement into clause which is not empty"
+	TriggerTiming                          "Trigger action time"
+	TriggerEvent                           "Trigger event"
+	EventSchedule                          "Event schedule"
+	EventStartsOpt                         "Event schedule optional STARTS clause"
+	EventEndsOpt                           "Event schedule optional ENDS clause"
+	EventCompletion                        "Event ON COMPLETION clause"
+	EventCompletionOpt                     "Event optional ON COMPLETION clause"
+	EventStatus                            "Event ENABLE or DISABLE clause"
+	EventStatusOpt                         "Event optional ENABLE or DISABLE clause"
+	EventCommentOpt                        "Event optional COMMENT clause"
+	AlterEventScheduleOpt                  "ALTER EVENT optional ON SCHEDULE and ON COMPLETION clauses"
+	AlterEventRenameOpt                    "ALTER EVENT optional RENAME TO clause"
+	AlterEventBodyOpt                      "ALTER EVENT optional DO clause"
+	ChangefeedFilterOpt                    "CREATE CHANGEFEED optional FOR clause"
+	ChangefeedFormatOpt                    "CREATE CHANGEFEED optional FORMAT clause"
+	RecommendIndexForOpt                   "RECOMMEND INDEX optional FOR clause"
+	RecommendIndexOption                   "RECOMMEND INDEX option"
+	RecommendIndexOptionList               "RECOMMEND INDEX option list"
+	RecommendIndexOptionListOpt            "RECOMMEND INDEX optional WITH clause"
 	SelectStmtIntoOption                   "SELECT statement into clause"
+	SelectIntoOptionListOpt                "Optional option list of the SELECT statement into outfile clause"
+	SelectIntoVarList                      "Variable list of the SELECT statement into clause"
 	SequenceOption                         "Create sequence option"
 	SequenceOptionList                     "Create sequence option list"
 	SetRoleOpt                             "Set role options"
@@ -1325,6 +1428,9 @@ import (
 	StatementList                          "statement list"
 	StatsPersistentVal                     "stats_persistent value"
 	StatsType                              "stats type value"
+	StatsKind                              "multi-column stats kind"
+	StatsKindList                          "multi-column stats kind list"
+	StatsKindListOpt                       "optional multi-column stats kind list"
 	BindingStatusType                      "binding status type value"
 	StringList                             "string list"
 	SubPartDefinition                      "SubPartition definition"
@@ -1365,6 +1471,9 @@ import (
 	TransactionChars                       "Transaction characteristic list"
 	TrimDirection                          "Trim string direction"
 	SetOprOpt                              "Union/Except/Intersect Option(empty/ALL/DISTINCT)"
+	UnpivotColumn                          "UNPIVOT column with an optional alias"
+	UnpivotColumnList                      "UNPIVOT column list"
+	UnpivotNullsOpt                        "optional UNPIVOT {INCLUDE | EXCLUDE} NULLS"
 	Username                               "Username"
 	UsernameList                           "UsernameList"
 	UserSpec                               "Username and auth option"
@@ -1377,6 +1486,7 @@ import (
 	ValuesList                             "values list"
 	ValuesOpt                              "values optional"
 	ValuesStmtList                         "VALUES statement field list"
+	XIDValue                               "XA transaction identifier"
 	VariableAssignment                     "set variable value"
 	VariableAssignmentList                 "set variable value list"
 	ViewAlgorithm                          "view algorithm"
@@ -1411,6 +1521,7 @@ import (
 	OptWindowingClause                     "Optional OVER clause"
 	WindowingClause                        "OVER clause"
 	WindowClauseOptional                   "Optional WINDOW clause"
+	QualifyClauseOptional                  "Optional QUALIFY clause"
 	WindowDefinitionList                   "WINDOW definition list"
 	WindowDefinition                       "WINDOW definition"
 	WindowFrameUnits                       "WINDOW frame units"
@@ -1436,6 +1547,9 @@ import (
 	FloatingPointType                      "Approximate value types"
 	BitValueType                           "bit value types"
 	StringType                             "String types"
+	VectorType                             "Vector types"
+	SpatialType                            "Spatial types"
+	SpatialTypeName                        "Spatial type name"
 	BlobType                               "Blob types"
 	TextType                               "Text types"
 	DateAndTimeType                        "Date and Time types"
@@ -1500,6 +1614,11 @@ import (
 	OptionalShardColumn                    "Optional shard column"
 	SpOptInout                             "Optional procedure param type"
 	OptSpPdparams                          "Optional procedure param list"
+	ProcedureCharacteristicListOpt         "Optional procedure characteristic list"
+	FunctionReturns                        "Return type of function"
+	OptSpFuncParams                        "Optional function param list"
+	SpFuncParams                           "Function param list"
+	SpFuncParam                            "Function param"
 	SpPdparams                             "Procedure params"
 	SpPdparam                              "Procedure param"
 	ProcedureOptDefault                    "Optional procedure variable default value"
@@ -1521,6 +1640,7 @@ import (
 	KeyOrIndex        "{KEY|INDEX}"
 	ColumnKeywordOpt  "Column keyword or empty"
 	PrimaryOpt        "Optional primary keyword"
+	BeginOrStart      "{BEGIN|START}"
 	NowSym            "CURRENT_TIMESTAMP/LOCALTIME/LOCALTIMESTAMP"
 	NowSymFunc        "CURRENT_TIMESTAMP/LOCALTIME/LOCALTIMESTAMP/NOW"
 	CurdateSym        "CURDATE or CURRENT_DATE"
@@ -1584,6 +1704,7 @@ import (
 	FieldTerminator                 "Field terminator"
 	FlashbackToNewName              "Flashback to new name"
 	HashString                      "Hashed string"
+	XIDPart                         "gtrid or bqual of XA transaction identifier"
 	LikeOrIlikeEscapeOpt            "like or ilike escape option"
 	OptCharset                      "Optional Character setting"
 	OptCollate                      "Optional Collate setting"
@@ -1595,7 +1716,9 @@ import (
 	Symbol                          "Constraint Symbol"
 	ProcedurceLabelOpt              "Optional Procedure label name"
 
+%precedence returning qualify pivot unpivot
 %precedence empty
+%precedence into
 %precedence as
 %precedence placement
 %precedence lowerThanSelectOpt
@@ -2950,6 +3073,20 @@ UserToUser:
 		}
 	}
 
+/*******************************************************************
+ *
+ *  Refresh Materialized View Statement
+ *
+ *  Example:
+ *      REFRESH MATERIALIZED VIEW mv;
+ *
+ *******************************************************************/
+RefreshMatViewStmt:
+	"REFRESH" "MATERIALIZED" "VIEW" TableName
+	{
+		$$ = &ast.RefreshMaterializedViewStmt{ViewName: $4.(*ast.TableName)}
+	}
+
 /*******************************************************************
  *
  *  Recover Table Statement
@@ -3509,6 +3646,92 @@ CommitStmt:
 		$$ = &ast.CommitStmt{CompletionType: $2.(ast.CompletionType)}
 	}
 
+/*******************************************************************
+ *
+ *  XA Transaction Statements
+ *
+ *  See https://dev.mysql.com/doc/refman/8.0/en/xa-statements.html
+ *
+ *******************************************************************/
+XAStmt:
+	"XA" BeginOrStart XIDValue
+	{
+		$$ = &ast.XAStmt{Tp: ast.XAStart, XID: $3.(*ast.XID)}
+	}
+|	"XA" BeginOrStart XIDValue "JOIN"
+	{
+		$$ = &ast.XAStmt{Tp: ast.XAStart, XID: $3.(*ast.XID), Join: true}
+	}
+|	"XA" BeginOrStart XIDValue "RESUME"
+	{
+		$$ = &ast.XAStmt{Tp: ast.XAStart, XID: $3.(*ast.XID), Resume: true}
+	}
+|	"XA" "END" XIDValue
+	{
+		$$ = &ast.XAStmt{Tp: ast.XAEnd, XID: $3.(*ast.XID)}
+	}
+|	"XA" "END" XIDValue "SUSPEND"
+	{
+		$$ = &ast.XAStmt{Tp: ast.XAEnd, XID: $3.(*ast.XID), Suspend: true}
+	}
+|	"XA" "END" XIDValue "SUSPEND" "FOR" "MIGRATE"
+	{
+		$$ = &ast.XAStmt{Tp: ast.XAEnd, XID: $3.(*ast.XID), Suspend: true, ForMigrate: true}
+	}
+|	"XA" "PREPARE" XIDValue
+	{
+		$$ = &ast.XAStmt{Tp: ast.XAPrepare, XID: $3.(*ast.XID)}
+	}
+|	"XA" "COMMIT" XIDValue
+	{
+		$$ = &ast.XAStmt{Tp: ast.XACommit, XID: $3.(*ast.XID)}
+	}
+|	"XA" "COMMIT" XIDValue "ONE" "PHASE"
+	{
+		$$ = &ast.XAStmt{Tp: ast.XACommit, XID: $3.(*ast.XID), OnePhase: true}
+	}
+|	"XA" "ROLLBACK" XIDValue
+	{
+		$$ = &ast.XAStmt{Tp: ast.XARollback, XID: $3.(*ast.XID)}
+	}
+|	"XA" "RECOVER"
+	{
+		$$ = &ast.XAStmt{Tp: ast.XARecover}
+	}
+|	"XA" "RECOVER" "CONVERT" "XID"
+	{
+		$$ = &ast.XAStmt{Tp: ast.XARecover, ConvertXID: true}
+	}
+
+BeginOrStart:
+	"BEGIN"
+|	"START"
+
+XIDValue:
+	XIDPart
+	{
+		$$ = &ast.XID{Gtrid: $1, FormatID: ast.DefaultXIDFormatID}
+	}
+|	XIDPart ',' XIDPart
+	{
+		$$ = &ast.XID{Gtrid: $1, Bqual: $3, FormatID: ast.DefaultXIDFormatID}
+	}
+|	XIDPart ',' XIDPart ',' LengthNum
+	{
+		$$ = &ast.XID{Gtrid: $1, Bqual: $3, FormatID: $5.(uint64)}
+	}
+
+XIDPart:
+	stringLit
+|	hexLit
+	{
+		$$ = $1.(ast.BinaryLiteral).ToString()
+	}
+|	bitLit
+	{
+		$$ = $1.(ast.BinaryLiteral).ToString()
+	}
+
 PrimaryOpt:
 	{}
 |	"PRIMARY"
@@ -3666,6 +3889,10 @@ ColumnOption:
 		yylex.AppendError(yylex.Errorf("The STORAGE clause is parsed but ignored by all storage engines."))
 		parser.lastErrorAsWarn()
 	}
+|	"SRID" LengthNum
+	{
+		$$ = &ast.ColumnOption{Tp: ast.ColumnOptionSRID, Expr: ast.NewValueExpr($2, "", "")}
+	}
 |	"AUTO_RANDOM" AutoRandomOpt
 	{
 		$$ = &ast.ColumnOption{Tp: ast.ColumnOptionAutoRandom, AutoRandOpt: $2.(ast.AutoRandomOption)}
@@ -4107,6 +4334,46 @@ CreateStatisticsStmt:
 			Columns:     $11.([]*ast.ColumnName),
 		}
 	}
+|	"CREATE" "STATISTICS" IfNotExists Identifier StatsKindListOpt "ON" ColumnNameList "FROM" TableName
+	{
+		$$ = &ast.CreateStatisticsStmt{
+			IfNotExists: $3.(bool),
+			StatsName:   $4,
+			StatsType:   ast.StatsTypeMultiColumn | $5.(uint8),
+			Table:       $9.(*ast.TableName),
+			Columns:     $7.([]*ast.ColumnName),
+		}
+	}
+
+StatsKindListOpt:
+	{
+		$$ = ast.StatsKindAll
+	}
+|	'(' StatsKindList ')'
+	{
+		$$ = $2
+	}
+
+StatsKindList:
+	StatsKind
+|	StatsKindList ',' StatsKind
+	{
+		$$ = $1.(uint8) | $3.(uint8)
+	}
+
+StatsKind:
+	"NDISTINCT"
+	{
+		$$ = ast.StatsKindNDistinct
+	}
+|	"DEPENDENCIES"
+	{
+		$$ = ast.StatsKindDependencies
+	}
+|	"MCV"
+	{
+		$$ = ast.StatsKindMCV
+	}
 
 DropStatisticsStmt:
 	"DROP" "STATISTICS" Identifier
@@ -4955,6 +5222,69 @@ CreateViewStmt:
 		$$ = x
 	}
 
+/*******************************************************************
+ *
+ *  Create Materialized View Statement
+ *
+ *  Example:
+ *      CREATE MATERIALIZED VIEW mv (a, cnt) REFRESH COMPLETE EVERY 1 HOUR
+ *          AS SELECT a, COUNT(*) FROM t GROUP BY a
+ *******************************************************************/
+CreateMaterializedViewStmt:
+	"CREATE" "MATERIALIZED" "VIEW" IfNotExists ViewName ViewFieldList MaterializedViewRefreshOpt "AS" CreateViewSelectOpt
+	{
+		startOffset := parser.startOffset(&yyS[yypt])
+		selStmt := $9.(ast.StmtNode)
+		selStmt.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:]))
+		x := $7.(*ast.CreateMaterializedViewStmt)
+		x.IfNotExists = $4.(bool)
+		x.ViewName = $5.(*ast.TableName)
+		x.Select = selStmt
+		if $6 != nil {
+			x.Cols = $6.([]model.CIStr)
+		}
+		$$ = x
+	}
+
+MaterializedViewRefreshOpt:
+	/* EMPTY */
+	{
+		$$ = &ast.CreateMaterializedViewStmt{RefreshMethod: model.RefreshComplete}
+	}
+|	"REFRESH" MaterializedViewRefreshMethod MaterializedViewRefreshSchedule
+	{
+		x := $3.(*ast.CreateMaterializedViewStmt)
+		x.RefreshMethod = $2.(model.MaterializedViewRefreshMethod)
+		$$ = x
+	}
+
+MaterializedViewRefreshMethod:
+	"COMPLETE"
+	{
+		$$ = model.RefreshComplete
+	}
+|	"FAST"
+	{
+		$$ = model.RefreshFast
+	}
+
+MaterializedViewRefreshSchedule:
+	/* EMPTY */
+	{
+		$$ = &ast.CreateMaterializedViewStmt{}
+	}
+|	"ON" "DEMAND"
+	{
+		$$ = &ast.CreateMaterializedViewStmt{}
+	}
+|	"EVERY" Literal TimeUnit
+	{
+		$$ = &ast.CreateMaterializedViewStmt{
+			RefreshInterval:     ast.NewValueExpr($2, parser.charset, parser.collation),
+			RefreshIntervalUnit: &ast.TimeUnitExpr{Unit: $3.(ast.TimeUnitType)},
+		}
+	}
+
 OrReplace:
 	/* EMPTY */
 	{
@@ -5062,7 +5392,7 @@ DoStmt:
  *
  *******************************************************************/
 DeleteWithoutUsingStmt:
-	"DELETE" TableOptimizerHintsOpt PriorityOpt QuickOptional IgnoreOptional "FROM" TableName PartitionNameListOpt TableAsNameOpt IndexHintListOpt WhereClauseOptional OrderByOptional LimitClause
+	"DELETE" TableOptimizerHintsOpt PriorityOpt QuickOptional IgnoreOptional "FROM" TableName PartitionNameListOpt TableAsNameOpt IndexHintListOpt WhereClauseOptional OrderByOptional LimitClause ReturningOpt
 	{
 		// Single Table
 		tn := $7.(*ast.TableName)
@@ -5087,6 +5417,9 @@ DeleteWithoutUsingStmt:
 		if $13 != nil {
 			x.Limit = $13.(*ast.Limit)
 		}
+		if $14 != nil {
+			x.Returning = $14.(*ast.FieldList)
+		}
 
 		$$ = x
 	}
@@ -5217,6 +5550,16 @@ DropViewStmt:
 		$$ = &ast.DropTableStmt{IfExists: true, Tables: $5.([]*ast.TableName), IsView: true}
 	}
 
+DropMaterializedViewStmt:
+	"DROP" "MATERIALIZED" "VIEW" TableNameList
+	{
+		$$ = &ast.DropTableStmt{Tables: $4.([]*ast.TableName), IsMaterializedView: true}
+	}
+|	"DROP" "MATERIALIZED" "VIEW" "IF" "EXISTS" TableNameList
+	{
+		$$ = &ast.DropTableStmt{IfExists: true, Tables: $6.([]*ast.TableName), IsMaterializedView: true}
+	}
+
 DropUserStmt:
 	"DROP" "USER" UsernameList
 	{
@@ -6316,7 +6659,7 @@ Field:
 	}
 
 FieldAsNameOpt:
-	/* EMPTY */
+	/* EMPTY */ %prec empty
 	{
 		$$ = ""
 	}
@@ -6606,6 +6949,13 @@ UnReservedKeyword:
 |	"AUTO_ID_CACHE"
 |	"AUTO_INCREMENT"
 |	"AFTER"
+|	"BEFORE"
+|	"EACH"
+|	"COMPLETION"
+|	"ENDS"
+|	"STARTS"
+|	"CHANGEFEED"
+|	"CHANGEFEEDS"
 |	"ALWAYS"
 |	"AVG"
 |	"BDR"
@@ -6678,6 +7028,7 @@ UnReservedKeyword:
 |	"PROXY"
 |	"QUICK"
 |	"REBUILD"
+|	"RECOMMEND"
 |	"REDUNDANT"
 |	"REORGANIZE"
 |	"RESOURCE"
@@ -6858,6 +7209,8 @@ UnReservedKeyword:
 |	"REPAIR"
 |	"IMPORT"
 |	"IMPORTS"
+|	"INCLUDE"
+|	"EXCLUDE"
 |	"DISCARD"
 |	"TABLE_CHECKSUM"
 |	"UNICODE"
@@ -6929,6 +7282,7 @@ UnReservedKeyword:
 |	"POLICY"
 |	"WAIT"
 |	"CLIENT_ERRORS_SUMMARY"
+|	"CARDINALITY_CORRECTIONS"
 |	"BERNOULLI"
 |	"SYSTEM"
 |	"PERCENT"
@@ -6971,6 +7325,39 @@ UnReservedKeyword:
 |	"COMPRESSION_TYPE"
 |	"ENCRYPTION_METHOD"
 |	"ENCRYPTION_KEYFILE"
+|	"EMPTY"
+|	"NESTED"
+|	"ORDINALITY"
+|	"PATH"
+|	"MATCHED"
+|	"MATERIALIZED"
+|	"REFRESH"
+|	"COMPLETE"
+|	"FAST"
+|	"DEMAND"
+|	"EVERY"
+|	"VECTOR"
+|	"GEOMETRY"
+|	"POLYGON"
+|	"LINESTRING"
+|	"MULTIPOINT"
+|	"MULTILINESTRING"
+|	"MULTIPOLYGON"
+|	"GEOMETRYCOLLECTION"
+|	"GEOMCOLLECTION"
+|	"SRID"
+|	"XA"
+|	"SUSPEND"
+|	"MIGRATE"
+|	"ONE"
+|	"PHASE"
+|	"XID"
+|	"RETURNING"
+|	"RETURN"
+|	"RETURNS"
+|	"QUALIFY"
+|	"PIVOT"
+|	"UNPIVOT"
 
 TiDBKeyword:
 	"ADMIN"
@@ -6984,10 +7371,13 @@ TiDBKeyword:
 |	"CORRELATION"
 |	"DDL"
 |	"DEPENDENCY"
+|	"DEPENDENCIES"
 |	"DEPTH"
 |	"DRAINER"
 |	"JOBS"
 |	"JOB"
+|	"MCV"
+|	"NDISTINCT"
 |	"NODE_ID"
 |	"NODE_STATE"
 |	"PUMP"
@@ -7091,6 +7481,7 @@ NotKeywordToken:
 |	"FLASHBACK"
 |	"JSON_OBJECTAGG"
 |	"JSON_ARRAYAGG"
+|	"JSON_TABLE"
 |	"TLS"
 |	"FOLLOWER"
 |	"FOLLOWERS"
@@ -7190,7 +7581,7 @@ ProcedureCall:
  *
  **********************************************************************************/
 InsertIntoStmt:
-	"INSERT" TableOptimizerHintsOpt PriorityOpt IgnoreOptional IntoOpt TableName PartitionNameListOpt InsertValues OnDuplicateKeyUpdate
+	"INSERT" TableOptimizerHintsOpt PriorityOpt IgnoreOptional IntoOpt TableName PartitionNameListOpt InsertValues OnDuplicateKeyUpdate ReturningOpt
 	{
 		x := $8.(*ast.InsertStmt)
 		x.Priority = $3.(mysql.PriorityEnum)
@@ -7205,6 +7596,9 @@ InsertIntoStmt:
 			x.TableHints = $2.([]*ast.TableOptimizerHint)
 		}
 		x.PartitionNames = $7.([]model.CIStr)
+		if $10 != nil {
+			x.Returning = $10.(*ast.FieldList)
+		}
 		$$ = x
 	}
 
@@ -7352,13 +7746,22 @@ OnDuplicateKeyUpdate:
 		$$ = $5
 	}
 
+ReturningOpt:
+	{
+		$$ = nil
+	}
+|	"RETURNING" FieldList
+	{
+		$$ = &ast.FieldList{Fields: $2.([]*ast.SelectField)}
+	}
+
 /************************************************************************************
  *  Replace Statements
  *  See https://dev.mysql.com/doc/refman/5.7/en/replace.html
  *
  **********************************************************************************/
 ReplaceIntoStmt:
-	"REPLACE" TableOptimizerHintsOpt PriorityOpt IntoOpt TableName PartitionNameListOpt InsertValues
+	"REPLACE" TableOptimizerHintsOpt PriorityOpt IntoOpt TableName PartitionNameListOpt InsertValues ReturningOpt
 	{
 		x := $7.(*ast.InsertStmt)
 		if $2 != nil {
@@ -7369,6 +7772,9 @@ ReplaceIntoStmt:
 		ts := &ast.TableSource{Source: $5.(*ast.TableName)}
 		x.Table = &ast.TableRefsClause{TableRefs: &ast.Join{Left: ts}}
 		x.PartitionNames = $6.([]model.CIStr)
+		if $8 != nil {
+			x.Returning = $8.(*ast.FieldList)
+		}
 		$$ = x
 	}
 
@@ -8787,6 +9193,7 @@ CastType:
 		tp.SetCollate(mysql.DefaultCollationName)
 		$$ = tp
 	}
+|	VectorType
 |	"DOUBLE"
 	{
 		tp := types.NewFieldType(mysql.TypeDouble)
@@ -9094,13 +9501,13 @@ SelectStmtFromDualTable:
 	}
 
 SelectStmtFromTable:
-	SelectStmtBasic "FROM" TableRefsClause WhereClauseOptional SelectStmtGroup HavingClause WindowClauseOptional
+	SelectStmtBasic "FROM" TableRefsClause WhereClauseOptional SelectStmtGroup HavingClause WindowClauseOptional QualifyClauseOptional
 	{
 		st := $1.(*ast.SelectStmt)
 		st.From = $3.(*ast.TableRefsClause)
 		lastField := st.Fields.Fields[len(st.Fields.Fields)-1]
 		if lastField.Expr != nil && lastField.AsName.O == "" {
-			lastEnd := parser.endOffset(&yyS[yypt-5])
+			lastEnd := parser.endOffset(&yyS[yypt-6])
 			lastField.SetText(parser.lexer.client, parser.src[lastField.Offset:lastEnd])
 		}
 		if $4 != nil {
@@ -9115,6 +9522,36 @@ SelectStmtFromTable:
 		if $7 != nil {
 			st.WindowSpecs = ($7.([]ast.WindowSpec))
 		}
+		if $8 != nil {
+			st.Qualify = $8.(ast.ExprNode)
+		}
+		$$ = st
+	}
+|	SelectStmtBasic SelectStmtIntoClause "FROM" TableRefsClause WhereClauseOptional SelectStmtGroup HavingClause WindowClauseOptional QualifyClauseOptional
+	{
+		st := $1.(*ast.SelectStmt)
+		st.SelectIntoOpt = $2.(*ast.SelectIntoOption)
+		st.From = $4.(*ast.TableRefsClause)
+		lastField := st.Fields.Fields[len(st.Fields.Fields)-1]
+		if lastField.Expr != nil && lastField.AsName.O == "" {
+			lastEnd := parser.endOffset(&yyS[yypt-7])
+			lastField.SetText(parser.lexer.client, parser.src[lastField.Offset:lastEnd])
+		}
+		if $5 != nil {
+			st.Where = $5.(ast.ExprNode)
+		}
+		if $6 != nil {
+			st.GroupBy = $6.(*ast.GroupByClause)
+		}
+		if $7 != nil {
+			st.Having = $7.(*ast.HavingClause)
+		}
+		if $8 != nil {
+			st.WindowSpecs = ($8.([]ast.WindowSpec))
+		}
+		if $9 != nil {
+			st.Qualify = $9.(ast.ExprNode)
+		}
 		$$ = st
 	}
 
@@ -9214,6 +9651,12 @@ SelectStmt:
 		}
 		$$ = st
 	}
+|	SelectStmtBasic SelectStmtIntoClause
+	{
+		st := $1.(*ast.SelectStmt)
+		st.SelectIntoOpt = $2.(*ast.SelectIntoOption)
+		$$ = st
+	}
 |	SelectStmtFromDualTable SelectStmtGroup OrderByOptional SelectStmtLimitOpt SelectLockOpt SelectStmtIntoOption
 	{
 		st := $1.(*ast.SelectStmt)
@@ -9237,6 +9680,10 @@ SelectStmt:
 |	SelectStmtFromTable OrderByOptional SelectStmtLimitOpt SelectLockOpt SelectStmtIntoOption
 	{
 		st := $1.(*ast.SelectStmt)
+		if st.SelectIntoOpt != nil && $5 != nil {
+			yylex.AppendError(yylex.Errorf("Multiple INTO clauses in one query block"))
+			return 1
+		}
 		if $4 != nil {
 			st.LockInfo = $4.(*ast.SelectLockInfo)
 		}
@@ -9371,6 +9818,15 @@ WindowClauseOptional:
 		$$ = $2.([]ast.WindowSpec)
 	}
 
+QualifyClauseOptional:
+	{
+		$$ = nil
+	}
+|	"QUALIFY" Expression
+	{
+		$$ = $2
+	}
+
 WindowDefinitionList:
 	WindowDefinition
 	{
@@ -9713,12 +10169,189 @@ TableFactor:
 		resultNode := $1.(*ast.SubqueryExpr).Query
 		$$ = &ast.TableSource{Source: resultNode, AsName: $2.(model.CIStr)}
 	}
+|	"LATERAL" SubSelect TableAsName
+	{
+		resultNode := $2.(*ast.SubqueryExpr).Query
+		$$ = &ast.TableSource{Source: resultNode, AsName: $3.(model.CIStr), Lateral: true}
+	}
 |	'(' TableRefs ')'
 	{
 		j := $2.(*ast.Join)
 		j.ExplicitParens = true
 		$$ = $2
 	}
+|	JSONTable TableAsNameOpt
+	{
+		$$ = &ast.TableSource{Source: $1.(*ast.JSONTable), AsName: $2.(model.CIStr)}
+	}
+|	TableFactor "PIVOT" '(' PivotItemList "FOR" ColumnName "IN" '(' PivotItemList ')' ')' TableAsNameOpt
+	{
+		$$ = &ast.TableSource{
+			Source: &ast.PivotTable{
+				Source:     $1.(ast.ResultSetNode),
+				Aggregates: $4.([]*ast.PivotItem),
+				ForColumn:  $6.(*ast.ColumnName),
+				Values:     $9.([]*ast.PivotItem),
+			},
+			AsName: $12.(model.CIStr),
+		}
+	}
+|	TableFactor "UNPIVOT" UnpivotNullsOpt '(' Identifier "FOR" Identifier "IN" '(' UnpivotColumnList ')' ')' TableAsNameOpt
+	{
+		$$ = &ast.TableSource{
+			Source: &ast.UnpivotTable{
+				Source:       $1.(ast.ResultSetNode),
+				IncludeNulls: $3.(bool),
+				ValueColumn:  model.NewCIStr($5),
+				NameColumn:   model.NewCIStr($7),
+				Columns:      $10.([]*ast.UnpivotColumn),
+			},
+			AsName: $13.(model.CIStr),
+		}
+	}
+
+PivotItemList:
+	PivotItem
+	{
+		$$ = []*ast.PivotItem{$1.(*ast.PivotItem)}
+	}
+|	PivotItemList ',' PivotItem
+	{
+		$$ = append($1.([]*ast.PivotItem), $3.(*ast.PivotItem))
+	}
+
+PivotItem:
+	Expression FieldAsNameOpt
+	{
+		$$ = &ast.PivotItem{Expr: $1, AsName: model.NewCIStr($2)}
+	}
+
+UnpivotNullsOpt:
+	{
+		$$ = false
+	}
+|	"INCLUDE" "NULLS"
+	{
+		$$ = true
+	}
+|	"EXCLUDE" "NULLS"
+	{
+		$$ = false
+	}
+
+UnpivotColumnList:
+	UnpivotColumn
+	{
+		$$ = []*ast.UnpivotColumn{$1.(*ast.UnpivotColumn)}
+	}
+|	UnpivotColumnList ',' UnpivotColumn
+	{
+		$$ = append($1.([]*ast.UnpivotColumn), $3.(*ast.UnpivotColumn))
+	}
+
+UnpivotColumn:
+	ColumnName FieldAsNameOpt
+	{
+		$$ = &ast.UnpivotColumn{Column: $1.(*ast.ColumnName), AsName: model.NewCIStr($2)}
+	}
+
+JSONTable:
+	"JSON_TABLE" '(' Expression ',' stringLit "COLUMNS" '(' JSONTableColumnList ')' ')'
+	{
+		$$ = &ast.JSONTable{
+			Expr:    $3,
+			Path:    $5,
+			Columns: $8.([]*ast.JSONTableColumn),
+		}
+	}
+
+JSONTableColumnList:
+	JSONTableColumn
+	{
+		$$ = []*ast.JSONTableColumn{$1.(*ast.JSONTableColumn)}
+	}
+|	JSONTableColumnList ',' JSONTableColumn
+	{
+		$$ = append($1.([]*ast.JSONTableColumn), $3.(*ast.JSONTableColumn))
+	}
+
+JSONTableColumn:
+	Identifier "FOR" "ORDINALITY"
+	{
+		$$ = &ast.JSONTableColumn{
+			Tp:   ast.JSONTableColumnOrdinality,
+			Name: model.NewCIStr($1),
+		}
+	}
+|	Identifier Type "PATH" stringLit JSONTableOnEmptyOnErrorOpt
+	{
+		responses := $5.([]*ast.JSONTableOnResponse)
+		$$ = &ast.JSONTableColumn{
+			Tp:        ast.JSONTableColumnPath,
+			Name:      model.NewCIStr($1),
+			FieldType: $2.(*types.FieldType),
+			Path:      $4,
+			OnEmpty:   responses[0],
+			OnError:   responses[1],
+		}
+	}
+|	Identifier Type "EXISTS" "PATH" stringLit
+	{
+		$$ = &ast.JSONTableColumn{
+			Tp:        ast.JSONTableColumnExists,
+			Name:      model.NewCIStr($1),
+			FieldType: $2.(*types.FieldType),
+			Path:      $5,
+		}
+	}
+|	"NESTED" "PATH" stringLit "COLUMNS" '(' JSONTableColumnList ')'
+	{
+		$$ = &ast.JSONTableColumn{
+			Tp:            ast.JSONTableColumnNested,
+			Path:          $3,
+			NestedColumns: $6.([]*ast.JSONTableColumn),
+		}
+	}
+|	"NESTED" stringLit "COLUMNS" '(' JSONTableColumnList ')'
+	{
+		$$ = &ast.JSONTableColumn{
+			Tp:            ast.JSONTableColumnNested,
+			Path:          $2,
+			NestedColumns: $5.([]*ast.JSONTableColumn),
+		}
+	}
+
+JSONTableOnEmptyOnErrorOpt:
+	/* empty */
+	{
+		$$ = []*ast.JSONTableOnResponse{nil, nil}
+	}
+|	JSONTableOnResponse "ON" "EMPTY"
+	{
+		$$ = []*ast.JSONTableOnResponse{$1.(*ast.JSONTableOnResponse), nil}
+	}
+|	JSONTableOnResponse "ON" "ERROR"
+	{
+		$$ = []*ast.JSONTableOnResponse{nil, $1.(*ast.JSONTableOnResponse)}
+	}
+|	JSONTableOnResponse "ON" "EMPTY" JSONTableOnResponse "ON" "ERROR"
+	{
+		$$ = []*ast.JSONTableOnResponse{$1.(*ast.JSONTableOnResponse), $4.(*ast.JSONTableOnResponse)}
+	}
+
+JSONTableOnResponse:
+	"NULL"
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableResponseNull}
+	}
+|	"ERROR"
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableResponseError}
+	}
+|	"DEFAULT" stringLit
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableResponseDefault, Default: $2}
+	}
 
 PartitionNameListOpt:
 	/* empty */
@@ -10115,22 +10748,61 @@ SelectStmtIntoOption:
 	{
 		$$ = nil
 	}
-|	"INTO" "OUTFILE" stringLit Fields Lines
+|	SelectStmtIntoClause
+
+SelectStmtIntoClause:
+	"INTO" "OUTFILE" stringLit FormatOpt Fields Lines SelectIntoOptionListOpt
 	{
 		x := &ast.SelectIntoOption{
 			Tp:       ast.SelectIntoOutfile,
 			FileName: $3,
-		}
-		if $4 != nil {
-			x.FieldsInfo = $4.(*ast.FieldsClause)
+			Format:   $4.(*string),
+			Options:  $7.([]*ast.LoadDataOpt),
 		}
 		if $5 != nil {
-			x.LinesInfo = $5.(*ast.LinesClause)
+			x.FieldsInfo = $5.(*ast.FieldsClause)
+		}
+		if $6 != nil {
+			x.LinesInfo = $6.(*ast.LinesClause)
 		}
 
 		$$ = x
 	}
-
+|	"INTO" SelectIntoVarList
+	{
+		$$ = &ast.SelectIntoOption{
+			Tp:        ast.SelectIntoVars,
+			Variables: $2.([]ast.ExprNode),
+		}
+	}
+
+SelectIntoOptionListOpt:
+	%prec lowerThanWith
+	{
+		$$ = []*ast.LoadDataOpt{}
+	}
+|	"WITH" LoadDataOptionList
+	{
+		$$ = $2.([]*ast.LoadDataOpt)
+	}
+
+SelectIntoVarList:
+	SelectIntoVar
+	{
+		$$ = []ast.ExprNode{$1}
+	}
+|	SelectIntoVarList ',' SelectIntoVar
+	{
+		$$ = append($1.([]ast.ExprNode), $3)
+	}
+
+SelectIntoVar:
+	Identifier
+	{
+		$$ = &ast.ColumnNameExpr{Name: &ast.ColumnName{Name: model.NewCIStr($1)}}
+	}
+|	UserVariable
+
 // See https://dev.mysql.com/doc/refman/5.7/en/subqueries.html
 SubSelect:
 	'(' SelectStmt ')'
@@ -11489,6 +12161,20 @@ ShowStmt:
 			Procedure: $4.(*ast.TableName),
 		}
 	}
+|	"SHOW" "CREATE" "FUNCTION" TableName
+	{
+		$$ = &ast.ShowStmt{
+			Tp:        ast.ShowCreateFunction,
+			Procedure: $4.(*ast.TableName),
+		}
+	}
+|	"SHOW" "CREATE" "EVENT" TableName
+	{
+		$$ = &ast.ShowStmt{
+			Tp:        ast.ShowCreateEvent,
+			Procedure: $4.(*ast.TableName),
+		}
+	}
 
 ShowPlacementTarget:
 	DatabaseSym DBName
@@ -11832,6 +12518,10 @@ ShowTargetFilterable:
 	{
 		$$ = &ast.ShowStmt{Tp: ast.ShowImportJobs}
 	}
+|	"CHANGEFEEDS"
+	{
+		$$ = &ast.ShowStmt{Tp: ast.ShowChangefeeds}
+	}
 
 ShowLikeOrWhereOpt:
 	{
@@ -11973,6 +12663,12 @@ FlushOption:
 			Tp: ast.FlushClientErrorsSummary,
 		}
 	}
+|	"CARDINALITY_CORRECTIONS"
+	{
+		$$ = &ast.FlushStmt{
+			Tp: ast.FlushCardinalityCorrections,
+		}
+	}
 
 LogTypeOpt:
 	/* empty */
@@ -12049,6 +12745,7 @@ Statement:
 |	AlterInstanceStmt
 |	AlterRangeStmt
 |	AlterSequenceStmt
+|	AlterEventStmt
 |	AlterPolicyStmt
 |	AlterResourceGroupStmt
 |	AnalyzeTableStmt
@@ -12066,11 +12763,17 @@ Statement:
 |	CreateIndexStmt
 |	CreateTableStmt
 |	CreateViewStmt
+|	CreateMaterializedViewStmt
 |	CreateUserStmt
 |	CreateRoleStmt
 |	CreateBindingStmt
 |	CreatePolicyStmt
+|	CreateFunctionStmt
 |	CreateProcedureStmt
+|	CreateTriggerStmt
+|	CreateEventStmt
+|	CreateChangefeedStmt
+|	ChangefeedActionStmt
 |	CreateResourceGroupStmt
 |	AddQueryWatchStmt
 |	CreateSequenceStmt
@@ -12079,10 +12782,15 @@ Statement:
 |	DropDatabaseStmt
 |	DropIndexStmt
 |	DropTableStmt
+|	DropFunctionStmt
 |	DropProcedureStmt
+|	DropTriggerStmt
+|	DropEventStmt
+|	DropChangefeedStmt
 |	DropPolicyStmt
 |	DropSequenceStmt
 |	DropViewStmt
+|	DropMaterializedViewStmt
 |	DropUserStmt
 |	DropResourceGroupStmt
 |	DropQueryWatchStmt
@@ -12101,6 +12809,7 @@ Statement:
 |	ImportIntoStmt
 |	InsertIntoStmt
 |	IndexAdviseStmt
+|	RecommendIndexStmt
 |	KillStmt
 |	LoadDataStmt
 |	LoadStatsStmt
@@ -12113,6 +12822,7 @@ Statement:
 |	RenameUserStmt
 |	ReplaceIntoStmt
 |	RecoverTableStmt
+|	RefreshMatViewStmt
 |	ReleaseSavepointStmt
 |	RevokeStmt
 |	RevokeRoleStmt
@@ -12142,8 +12852,10 @@ Statement:
 |	TraceStmt
 |	TruncateTableStmt
 |	UpdateStmt
+|	MergeStmt
 |	UseStmt
 |	UnlockTablesStmt
+|	XAStmt
 |	LockTablesStmt
 |	ShutdownStmt
 |	RestartStmt
@@ -12185,6 +12897,7 @@ TraceableStmt:
 ExplainableStmt:
 	DeleteFromStmt
 |	UpdateStmt
+|	MergeStmt
 |	InsertIntoStmt
 |	ReplaceIntoStmt
 |	SetOprStmt
@@ -12615,6 +13328,7 @@ Type:
 	NumericType
 |	StringType
 |	DateAndTimeType
+|	SpatialType
 
 NumericType:
 	IntegerType OptFieldLen FieldOpts
@@ -12817,6 +13531,75 @@ BitValueType:
 		$$ = mysql.TypeBit
 	}
 
+VectorType:
+	"VECTOR" OptFieldLen
+	{
+		tp := types.NewFieldType(mysql.TypeTiDBVectorFloat32)
+		tp.SetFlen($2.(int))
+		tp.SetDecimal(0)
+		tp.SetCharset(charset.CharsetBin)
+		tp.SetCollate(charset.CollationBin)
+		$$ = tp
+	}
+|	"VECTOR" '<' "FLOAT" '>' OptFieldLen
+	{
+		tp := types.NewFieldType(mysql.TypeTiDBVectorFloat32)
+		tp.SetFlen($5.(int))
+		tp.SetDecimal(0)
+		tp.SetCharset(charset.CharsetBin)
+		tp.SetCollate(charset.CollationBin)
+		$$ = tp
+	}
+
+SpatialType:
+	SpatialTypeName
+	{
+		tp := types.NewFieldType(mysql.TypeGeometry)
+		tp.SetGeometryType($1.(byte))
+		tp.SetCharset(charset.CharsetBin)
+		tp.SetCollate(charset.CollationBin)
+		tp.AddFlag(mysql.BinaryFlag)
+		$$ = tp
+	}
+
+SpatialTypeName:
+	"GEOMETRY"
+	{
+		$$ = mysql.GeometryTypeGeometry
+	}
+|	"POINT"
+	{
+		$$ = mysql.GeometryTypePoint
+	}
+|	"LINESTRING"
+	{
+		$$ = mysql.GeometryTypeLineString
+	}
+|	"POLYGON"
+	{
+		$$ = mysql.GeometryTypePolygon
+	}
+|	"MULTIPOINT"
+	{
+		$$ = mysql.GeometryTypeMultiPoint
+	}
+|	"MULTILINESTRING"
+	{
+		$$ = mysql.GeometryTypeMultiLineString
+	}
+|	"MULTIPOLYGON"
+	{
+		$$ = mysql.GeometryTypeMultiPolygon
+	}
+|	"GEOMETRYCOLLECTION"
+	{
+		$$ = mysql.GeometryTypeGeometryCollection
+	}
+|	"GEOMCOLLECTION"
+	{
+		$$ = mysql.GeometryTypeGeometryCollection
+	}
+
 StringType:
 	Char FieldLen OptBinary
 	{
@@ -12963,6 +13746,7 @@ StringType:
 		tp.SetCollate(charset.CollationBin)
 		$$ = tp
 	}
+|	VectorType
 |	"LONG" Varchar OptCharsetWithOptBinary
 	{
 		tp := types.NewFieldType(mysql.TypeMediumBlob)
@@ -13308,7 +14092,7 @@ UpdateStmt:
 	}
 
 UpdateStmtNoWith:
-	"UPDATE" TableOptimizerHintsOpt PriorityOpt IgnoreOptional TableRef "SET" AssignmentList WhereClauseOptional OrderByOptional LimitClause
+	"UPDATE" TableOptimizerHintsOpt PriorityOpt IgnoreOptional TableRef "SET" AssignmentList WhereClauseOptional OrderByOptional LimitClause ReturningOpt
 	{
 		var refs *ast.Join
 		if x, ok := $5.(*ast.Join); ok {
@@ -13334,6 +14118,9 @@ UpdateStmtNoWith:
 		if $10 != nil {
 			st.Limit = $10.(*ast.Limit)
 		}
+		if $11 != nil {
+			st.Returning = $11.(*ast.FieldList)
+		}
 		$$ = st
 	}
 |	"UPDATE" TableOptimizerHintsOpt PriorityOpt IgnoreOptional TableRefs "SET" AssignmentList WhereClauseOptional
@@ -13353,6 +14140,82 @@ UpdateStmtNoWith:
 		$$ = st
 	}
 
+/*******************************************************************
+ *
+ *  Merge Statement
+ *
+ *  MERGE INTO target [AS alias] USING source ON condition
+ *      WHEN MATCHED [AND condition] THEN UPDATE SET assignment_list | DELETE
+ *      WHEN NOT MATCHED [AND condition] THEN INSERT [(column_list)] VALUES (value_list)
+ *******************************************************************/
+MergeStmt:
+	"MERGE" TableOptimizerHintsOpt "INTO" TableName TableAsNameOpt "USING" TableFactor "ON" Expression MergeWhenClauseList
+	{
+		st := &ast.MergeStmt{
+			Target:      &ast.TableSource{Source: $4.(*ast.TableName), AsName: $5.(model.CIStr)},
+			Source:      $7.(ast.ResultSetNode),
+			On:          $9.(ast.ExprNode),
+			WhenClauses: $10.([]*ast.MergeWhenClause),
+		}
+		if $2 != nil {
+			st.TableHints = $2.([]*ast.TableOptimizerHint)
+		}
+		$$ = st
+	}
+
+MergeWhenClauseList:
+	MergeWhenClause
+	{
+		$$ = []*ast.MergeWhenClause{$1.(*ast.MergeWhenClause)}
+	}
+|	MergeWhenClauseList MergeWhenClause
+	{
+		$$ = append($1.([]*ast.MergeWhenClause), $2.(*ast.MergeWhenClause))
+	}
+
+MergeWhenClause:
+	"WHEN" "MATCHED" MergeConditionOpt "THEN" "UPDATE" "SET" AssignmentList
+	{
+		clause := &ast.MergeWhenClause{Matched: true, Action: ast.MergeActionUpdate, Assignments: $7.([]*ast.Assignment)}
+		if $3 != nil {
+			clause.Condition = $3.(ast.ExprNode)
+		}
+		$$ = clause
+	}
+|	"WHEN" "MATCHED" MergeConditionOpt "THEN" "DELETE"
+	{
+		clause := &ast.MergeWhenClause{Matched: true, Action: ast.MergeActionDelete}
+		if $3 != nil {
+			clause.Condition = $3.(ast.ExprNode)
+		}
+		$$ = clause
+	}
+|	"WHEN" "NOT" "MATCHED" MergeConditionOpt "THEN" "INSERT" ValueSym '(' ValuesOpt ')'
+	{
+		clause := &ast.MergeWhenClause{Action: ast.MergeActionInsert, Values: $9.([]ast.ExprNode)}
+		if $4 != nil {
+			clause.Condition = $4.(ast.ExprNode)
+		}
+		$$ = clause
+	}
+|	"WHEN" "NOT" "MATCHED" MergeConditionOpt "THEN" "INSERT" '(' ColumnNameListOpt ')' ValueSym '(' ValuesOpt ')'
+	{
+		clause := &ast.MergeWhenClause{Action: ast.MergeActionInsert, Columns: $8.([]*ast.ColumnName), Values: $12.([]ast.ExprNode)}
+		if $4 != nil {
+			clause.Condition = $4.(ast.ExprNode)
+		}
+		$$ = clause
+	}
+
+MergeConditionOpt:
+	{
+		$$ = nil
+	}
+|	"AND" Expression
+	{
+		$$ = $2
+	}
+
 UseStmt:
 	"USE" DBName
 	{
@@ -13366,6 +14229,7 @@ WhereClause:
 	}
 
 WhereClauseOptional:
+	%prec empty
 	{
 		$$ = nil
 	}
@@ -15250,6 +16114,63 @@ IndexAdviseStmt:
 		$$ = x
 	}
 
+/********************************************************************************************
+*  RECOMMEND INDEX RUN [FOR 'sql'] [WITH option = value [, option = value] ...]
+*  RECOMMEND INDEX FOR 'sql' [WITH option = value [, option = value] ...]
+********************************************************************************************/
+RecommendIndexStmt:
+	"RECOMMEND" "INDEX" "RUN" RecommendIndexForOpt RecommendIndexOptionListOpt
+	{
+		$$ = &ast.RecommendIndexStmt{
+			SQL:     $4.(string),
+			Options: $5.([]ast.RecommendIndexOption),
+		}
+	}
+|	"RECOMMEND" "INDEX" "FOR" stringLit RecommendIndexOptionListOpt
+	{
+		$$ = &ast.RecommendIndexStmt{
+			SQL:     $4,
+			Options: $5.([]ast.RecommendIndexOption),
+		}
+	}
+
+RecommendIndexForOpt:
+	{
+		$$ = ""
+	}
+|	"FOR" stringLit
+	{
+		$$ = $2
+	}
+
+RecommendIndexOptionListOpt:
+	{
+		$$ = []ast.RecommendIndexOption(nil)
+	}
+|	"WITH" RecommendIndexOptionList
+	{
+		$$ = $2
+	}
+
+RecommendIndexOptionList:
+	RecommendIndexOption
+	{
+		$$ = []ast.RecommendIndexOption{$1.(ast.RecommendIndexOption)}
+	}
+|	RecommendIndexOptionList ',' RecommendIndexOption
+	{
+		$$ = append($1.([]ast.RecommendIndexOption), $3.(ast.RecommendIndexOption))
+	}
+
+RecommendIndexOption:
+	Identifier eq LengthNum
+	{
+		$$ = ast.RecommendIndexOption{
+			Option: strings.ToLower($1),
+			Value:  $3.(uint64),
+		}
+	}
+
 MaxMinutesOpt:
 	{
 		$$ = uint64(ast.UnspecifiedSize)
@@ -15973,6 +16894,12 @@ ProcedureUnlabelLoopStmt:
 			Condition: $4.(ast.ExprNode),
 		}
 	}
+|	"LOOP" ProcedureProcStmt1s "END" "LOOP"
+	{
+		$$ = &ast.ProcedureLoopStmt{
+			Body: $2.([]ast.StmtNode),
+		}
+	}
 
 ProcedureLabeledBlock:
 	identifier ':' ProcedureBlockContent ProcedurceLabelOpt
@@ -16052,9 +16979,11 @@ ProcedureProcStmt:
  *	CREATE
  *  [DEFINER = user]
  *  PROCEDURE [IF NOT EXISTS] sp_name ([proc_parameter[,...]])
- *  routine_body
+ *  [characteristic ...] routine_body
  *  proc_parameter:
  *  [ IN | OUT | INOUT ] param_name type
+ *  characteristic:
+ *  COMMENT 'string' | SQL SECURITY { DEFINER | INVOKER }
  *  func_parameter:
  *  param_name type
  *  type:
@@ -16063,26 +16992,55 @@ ProcedureProcStmt:
  *  Valid SQL routine statement
  ********************************************************************************************/
 CreateProcedureStmt:
-	"CREATE" "PROCEDURE" IfNotExists TableName '(' OptSpPdparams ')' ProcedureProcStmt
+	"CREATE" OrReplace ViewAlgorithm ViewDefiner "PROCEDURE" IfNotExists TableName '(' OptSpPdparams ')' ProcedureCharacteristicListOpt ProcedureProcStmt
 	{
-		x := &ast.ProcedureInfo{
-			IfNotExists:    $3.(bool),
-			ProcedureName:  $4.(*ast.TableName),
-			ProcedureParam: $6.([]*ast.StoreParameter),
-			ProcedureBody:  $8,
+		// OR REPLACE and ALGORITHM are shared with CREATE VIEW to avoid a conflict on DEFINER, they are not allowed here.
+		if $2.(bool) || $3.(model.ViewAlgorithm) != model.AlgorithmUndefined {
+			yylex.AppendError(ErrSyntax)
+			return 1
 		}
+		x := $11.(*ast.ProcedureInfo)
+		x.IfNotExists = $6.(bool)
+		x.Definer = $4.(*auth.UserIdentity)
+		x.ProcedureName = $7.(*ast.TableName)
+		x.ProcedureParam = $9.([]*ast.StoreParameter)
+		x.ProcedureBody = $12
 		startOffset := parser.startOffset(&yyS[yypt])
-		originStmt := $8
+		originStmt := $12
 		originStmt.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
-		startOffset = parser.startOffset(&yyS[yypt-3])
+		startOffset = parser.startOffset(&yyS[yypt-4])
 		if parser.src[startOffset] == '(' {
 			startOffset++
 		}
-		endOffset := parser.startOffset(&yyS[yypt-1])
+		endOffset := parser.startOffset(&yyS[yypt-2])
 		x.ProcedureParamStr = strings.TrimSpace(parser.src[startOffset:endOffset])
 		$$ = x
 	}
 
+ProcedureCharacteristicListOpt:
+	/* EMPTY */
+	{
+		$$ = &ast.ProcedureInfo{Security: model.SecurityDefiner}
+	}
+|	ProcedureCharacteristicListOpt "COMMENT" stringLit
+	{
+		x := $1.(*ast.ProcedureInfo)
+		x.Comment = $3
+		$$ = x
+	}
+|	ProcedureCharacteristicListOpt "SQL" "SECURITY" "DEFINER"
+	{
+		x := $1.(*ast.ProcedureInfo)
+		x.Security = model.SecurityDefiner
+		$$ = x
+	}
+|	ProcedureCharacteristicListOpt "SQL" "SECURITY" "INVOKER"
+	{
+		x := $1.(*ast.ProcedureInfo)
+		x.Security = model.SecurityInvoker
+		$$ = x
+	}
+
 /********************************************************************************************
 *  DROP PROCEDURE  [IF EXISTS] sp_name
 ********************************************************************************************/
@@ -16095,6 +17053,432 @@ DropProcedureStmt:
 		}
 	}
 
+/********************************************************************************************
+ *
+ *  Create Function Statement
+ *
+ *  Example:
+ *	CREATE
+ *  [DEFINER = user]
+ *  FUNCTION [IF NOT EXISTS] sp_name ([func_parameter[,...]])
+ *  RETURNS type
+ *  [characteristic ...] RETURN expr
+ *  func_parameter:
+ *  param_name type
+ *  characteristic:
+ *  COMMENT 'string' | SQL SECURITY { DEFINER | INVOKER }
+ *
+ *  Only functions whose body is a single RETURN statement are supported.
+ ********************************************************************************************/
+CreateFunctionStmt:
+	"CREATE" OrReplace ViewAlgorithm ViewDefiner "FUNCTION" IfNotExists TableName '(' OptSpFuncParams ')' FunctionReturns ProcedureCharacteristicListOpt "RETURN" Expression
+	{
+		// OR REPLACE and ALGORITHM are shared with CREATE VIEW to avoid a conflict on DEFINER, they are not allowed here.
+		if $2.(bool) || $3.(model.ViewAlgorithm) != model.AlgorithmUndefined {
+			yylex.AppendError(ErrSyntax)
+			return 1
+		}
+		characteristics := $12.(*ast.ProcedureInfo)
+		x := $11.(*ast.CreateFunctionStmt)
+		x.IfNotExists = $6.(bool)
+		x.Definer = $4.(*auth.UserIdentity)
+		x.FunctionName = $7.(*ast.TableName)
+		x.Params = $9.([]*ast.StoreParameter)
+		x.Security = characteristics.Security
+		x.Comment = characteristics.Comment
+		x.Body = $14
+		startOffset := parser.startOffset(&yyS[yypt-1])
+		x.BodyStr = strings.TrimSpace(parser.src[startOffset:parser.yylval.offset])
+		startOffset = parser.startOffset(&yyS[yypt-6])
+		if parser.src[startOffset] == '(' {
+			startOffset++
+		}
+		endOffset := parser.startOffset(&yyS[yypt-4])
+		x.ParamStr = strings.TrimSpace(parser.src[startOffset:endOffset])
+		$$ = x
+	}
+
+FunctionReturns:
+	"RETURNS" Type
+	{
+		startOffset := parser.startOffset(&yyS[yypt])
+		$$ = &ast.CreateFunctionStmt{
+			Returns:    $2.(*types.FieldType),
+			ReturnsStr: strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]),
+		}
+	}
+
+OptSpFuncParams:
+	/* Empty */
+	{
+		$$ = []*ast.StoreParameter{}
+	}
+|	SpFuncParams
+
+SpFuncParams:
+	SpFuncParams ',' SpFuncParam
+	{
+		$$ = append($1.([]*ast.StoreParameter), $3.(*ast.StoreParameter))
+	}
+|	SpFuncParam
+	{
+		$$ = []*ast.StoreParameter{$1.(*ast.StoreParameter)}
+	}
+
+SpFuncParam:
+	Identifier Type
+	{
+		$$ = &ast.StoreParameter{
+			Paramstatus: ast.MODE_IN,
+			ParamType:   $2.(*types.FieldType),
+			ParamName:   $1,
+		}
+	}
+
+DropFunctionStmt:
+	"DROP" "FUNCTION" IfExists TableName
+	{
+		$$ = &ast.DropFunctionStmt{
+			IfExists:     $3.(bool),
+			FunctionName: $4.(*ast.TableName),
+		}
+	}
+
+/********************************************************************************************
+*  CREATE TRIGGER [IF NOT EXISTS] trigger_name trigger_time trigger_event
+*  ON tbl_name FOR EACH ROW trigger_body
+********************************************************************************************/
+CreateTriggerStmt:
+	"CREATE" "TRIGGER" IfNotExists TableName TriggerTiming TriggerEvent "ON" TableName "FOR" "EACH" "ROW" ProcedureProcStmt
+	{
+		startOffset := parser.startOffset(&yyS[yypt])
+		body := $12
+		body.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		$$ = &ast.CreateTriggerStmt{
+			IfNotExists: $3.(bool),
+			TriggerName: $4.(*ast.TableName),
+			Timing:      $5.(model.TriggerTiming),
+			Event:       $6.(model.TriggerEvent),
+			Table:       $8.(*ast.TableName),
+			Body:        body,
+		}
+	}
+
+TriggerTiming:
+	"BEFORE"
+	{
+		$$ = model.TriggerBefore
+	}
+|	"AFTER"
+	{
+		$$ = model.TriggerAfter
+	}
+
+TriggerEvent:
+	"INSERT"
+	{
+		$$ = model.TriggerInsert
+	}
+|	"UPDATE"
+	{
+		$$ = model.TriggerUpdate
+	}
+|	"DELETE"
+	{
+		$$ = model.TriggerDelete
+	}
+
+/********************************************************************************************
+*  DROP TRIGGER [IF EXISTS] [schema_name.]trigger_name
+********************************************************************************************/
+DropTriggerStmt:
+	"DROP" "TRIGGER" IfExists TableName
+	{
+		$$ = &ast.DropTriggerStmt{
+			IfExists:    $3.(bool),
+			TriggerName: $4.(*ast.TableName),
+		}
+	}
+
+/********************************************************************************************
+*  CREATE EVENT [IF NOT EXISTS] event_name
+*  ON SCHEDULE schedule
+*  [ON COMPLETION [NOT] PRESERVE]
+*  [ENABLE | DISABLE | DISABLE ON SLAVE]
+*  [COMMENT 'string']
+*  DO event_body
+*
+*  schedule:
+*  AT timestamp [+ INTERVAL interval] ...
+*  | EVERY interval
+*  [STARTS timestamp [+ INTERVAL interval] ...]
+*  [ENDS timestamp [+ INTERVAL interval] ...]
+********************************************************************************************/
+CreateEventStmt:
+	"CREATE" "EVENT" IfNotExists TableName "ON" "SCHEDULE" EventSchedule EventCompletionOpt EventStatusOpt EventCommentOpt "DO" ProcedureProcStmt
+	{
+		startOffset := parser.startOffset(&yyS[yypt])
+		body := $12
+		body.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		x := &ast.CreateEventStmt{
+			IfNotExists: $3.(bool),
+			EventName:   $4.(*ast.TableName),
+			Schedule:    $7.(*ast.EventSchedule),
+			Completion:  $8.(ast.EventCompletion),
+			Status:      $9.(ast.EventStatus),
+			Body:        body,
+		}
+		if $10 != nil {
+			comment := $10.(string)
+			x.Comment = &comment
+		}
+		$$ = x
+	}
+
+EventSchedule:
+	identifier Expression
+	{
+		// AT is not a keyword, so that it can still be used as an identifier anywhere else.
+		if !strings.EqualFold($1, "AT") {
+			yylex.AppendError(yylex.Errorf("Unknown event schedule %s", $1))
+			return 1
+		}
+		$$ = &ast.EventSchedule{At: $2}
+	}
+|	"EVERY" Expression TimeUnit EventStartsOpt EventEndsOpt
+	{
+		x := &ast.EventSchedule{
+			Every: $2,
+			Unit:  &ast.TimeUnitExpr{Unit: $3.(ast.TimeUnitType)},
+		}
+		if $4 != nil {
+			x.Starts = $4.(ast.ExprNode)
+		}
+		if $5 != nil {
+			x.Ends = $5.(ast.ExprNode)
+		}
+		$$ = x
+	}
+
+EventStartsOpt:
+	{
+		$$ = nil
+	}
+|	"STARTS" Expression
+	{
+		$$ = $2
+	}
+
+EventEndsOpt:
+	{
+		$$ = nil
+	}
+|	"ENDS" Expression
+	{
+		$$ = $2
+	}
+
+EventCompletionOpt:
+	{
+		$$ = ast.EventCompletionDefault
+	}
+|	"ON" EventCompletion
+	{
+		$$ = $2
+	}
+
+EventCompletion:
+	"COMPLETION" "PRESERVE"
+	{
+		$$ = ast.EventCompletionPreserve
+	}
+|	"COMPLETION" "NOT" "PRESERVE"
+	{
+		$$ = ast.EventCompletionNotPreserve
+	}
+
+EventStatusOpt:
+	{
+		$$ = ast.EventStatusDefault
+	}
+|	EventStatus
+
+EventStatus:
+	"ENABLE"
+	{
+		$$ = ast.EventStatusEnable
+	}
+|	"DISABLE"
+	{
+		$$ = ast.EventStatusDisable
+	}
+|	"DISABLE" "ON" "SLAVE"
+	{
+		$$ = ast.EventStatusDisableOnSlave
+	}
+
+EventCommentOpt:
+	{
+		$$ = nil
+	}
+|	"COMMENT" stringLit
+	{
+		$$ = $2
+	}
+
+/********************************************************************************************
+*  ALTER EVENT event_name
+*  [ON SCHEDULE schedule]
+*  [ON COMPLETION [NOT] PRESERVE]
+*  [RENAME TO new_event_name]
+*  [ENABLE | DISABLE | DISABLE ON SLAVE]
+*  [COMMENT 'string']
+*  [DO event_body]
+********************************************************************************************/
+AlterEventStmt:
+	"ALTER" "EVENT" TableName AlterEventScheduleOpt AlterEventRenameOpt EventStatusOpt EventCommentOpt AlterEventBodyOpt
+	{
+		x := $4.(*ast.AlterEventStmt)
+		x.EventName = $3.(*ast.TableName)
+		if $5 != nil {
+			x.NewName = $5.(*ast.TableName)
+		}
+		x.Status = $6.(ast.EventStatus)
+		if $7 != nil {
+			comment := $7.(string)
+			x.Comment = &comment
+		}
+		if $8 != nil {
+			x.Body = $8.(ast.StmtNode)
+		}
+		if x.Schedule == nil && x.Completion == ast.EventCompletionDefault && x.NewName == nil &&
+			x.Status == ast.EventStatusDefault && x.Comment == nil && x.Body == nil {
+			yylex.AppendError(yylex.Errorf("ALTER EVENT requires at least one clause"))
+			return 1
+		}
+		$$ = x
+	}
+
+AlterEventScheduleOpt:
+	{
+		$$ = &ast.AlterEventStmt{}
+	}
+|	"ON" "SCHEDULE" EventSchedule EventCompletionOpt
+	{
+		$$ = &ast.AlterEventStmt{
+			Schedule:   $3.(*ast.EventSchedule),
+			Completion: $4.(ast.EventCompletion),
+		}
+	}
+|	"ON" EventCompletion
+	{
+		$$ = &ast.AlterEventStmt{Completion: $2.(ast.EventCompletion)}
+	}
+
+AlterEventRenameOpt:
+	{
+		$$ = nil
+	}
+|	"RENAME" "TO" TableName
+	{
+		$$ = $3
+	}
+
+AlterEventBodyOpt:
+	{
+		$$ = nil
+	}
+|	"DO" ProcedureProcStmt
+	{
+		startOffset := parser.startOffset(&yyS[yypt])
+		body := $2
+		body.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		$$ = body
+	}
+
+/********************************************************************************************
+*  DROP EVENT [IF EXISTS] event_name
+********************************************************************************************/
+DropEventStmt:
+	"DROP" "EVENT" IfExists TableName
+	{
+		$$ = &ast.DropEventStmt{
+			IfExists:  $3.(bool),
+			EventName: $4.(*ast.TableName),
+		}
+	}
+
+/********************************************************************************************
+*  CREATE CHANGEFEED [IF NOT EXISTS] changefeed_name
+*  [FOR TABLE tbl_name [, tbl_name] ... | FOR DATABASE db_name]
+*  INTO 'sink_uri'
+*  [FORMAT [=] 'format']
+********************************************************************************************/
+CreateChangefeedStmt:
+	"CREATE" "CHANGEFEED" IfNotExists Identifier ChangefeedFilterOpt "INTO" stringLit ChangefeedFormatOpt
+	{
+		x := $5.(*ast.CreateChangefeedStmt)
+		x.IfNotExists = $3.(bool)
+		x.Name = model.NewCIStr($4)
+		x.SinkURI = $7
+		x.Format = $8.(string)
+		$$ = x
+	}
+
+ChangefeedFilterOpt:
+	{
+		$$ = &ast.CreateChangefeedStmt{}
+	}
+|	"FOR" "TABLE" TableNameList
+	{
+		$$ = &ast.CreateChangefeedStmt{Tables: $3.([]*ast.TableName)}
+	}
+|	"FOR" "DATABASE" DBName
+	{
+		$$ = &ast.CreateChangefeedStmt{Schema: model.NewCIStr($3)}
+	}
+
+ChangefeedFormatOpt:
+	{
+		$$ = ""
+	}
+|	"FORMAT" EqOpt stringLit
+	{
+		$$ = $3
+	}
+
+/********************************************************************************************
+*  DROP CHANGEFEED [IF EXISTS] changefeed_name
+********************************************************************************************/
+DropChangefeedStmt:
+	"DROP" "CHANGEFEED" IfExists Identifier
+	{
+		$$ = &ast.DropChangefeedStmt{
+			IfExists: $3.(bool),
+			Name:     model.NewCIStr($4),
+		}
+	}
+
+/********************************************************************************************
+*  PAUSE CHANGEFEED changefeed_name
+*  RESUME CHANGEFEED changefeed_name
+********************************************************************************************/
+ChangefeedActionStmt:
+	"PAUSE" "CHANGEFEED" Identifier
+	{
+		$$ = &ast.ChangefeedActionStmt{
+			Tp:   ast.ChangefeedPause,
+			Name: model.NewCIStr($3),
+		}
+	}
+|	"RESUME" "CHANGEFEED" Identifier
+	{
+		$$ = &ast.ChangefeedActionStmt{
+			Tp:   ast.ChangefeedResume,
+			Name: model.NewCIStr($3),
+		}
+	}
+
 /********************************************************************
  *
  * Calibrate Resource Statement
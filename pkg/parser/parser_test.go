@@ -779,6 +779,12 @@ func TestDMLStmt(t *testing.T) {
 
 		// select into outfile
 		{"select a, b from t into outfile '/tmp/result.txt'", true, "SELECT `a`,`b` FROM `t` INTO OUTFILE '/tmp/result.txt'"},
+		{"select a, b from t into @a, @b", true, "SELECT `a`,`b` FROM `t` INTO @`a`,@`b`"},
+		{"select a from t limit 1 into x", true, "SELECT `a` FROM `t` LIMIT 1 INTO `x`"},
+		{"select a from t into", false, ""},
+		{"select a, b into @a, @b from t where c > 1", true, "SELECT `a`,`b` FROM `t` WHERE `c`>1 INTO @`a`,@`b`"},
+		{"select 1, @c into x, @y", true, "SELECT 1,@`c` INTO `x`,@`y`"},
+		{"select a into @a from t into @b", false, ""},
 		{"select a from t order by a into outfile '/tmp/abc'", true, "SELECT `a` FROM `t` ORDER BY `a` INTO OUTFILE '/tmp/abc'"},
 		{"select 1 into outfile '/tmp/1.csv'", true, "SELECT 1 INTO OUTFILE '/tmp/1.csv'"},
 		{"select 1 for update into outfile '/tmp/1.csv'", true, "SELECT 1 FOR UPDATE INTO OUTFILE '/tmp/1.csv'"},
@@ -790,6 +796,11 @@ func TestDMLStmt(t *testing.T) {
 		{"select a,b,a+b from t into outfile '/tmp/result.txt' fields terminated BY ',' enclosed BY '\"' lines terminated BY '\r'", true, "SELECT `a`,`b`,`a`+`b` FROM `t` INTO OUTFILE '/tmp/result.txt' FIELDS TERMINATED BY ',' ENCLOSED BY '\"' LINES TERMINATED BY '\r'"},
 		{"select a,b,a+b from t into outfile '/tmp/result.txt' fields terminated BY ',' optionally enclosed BY '\"' lines starting by 'xy' terminated BY '\r'", true, "SELECT `a`,`b`,`a`+`b` FROM `t` INTO OUTFILE '/tmp/result.txt' FIELDS TERMINATED BY ',' OPTIONALLY ENCLOSED BY '\"' LINES STARTING BY 'xy' TERMINATED BY '\r'"},
 		{"select a,b,a+b from t into outfile '/tmp/result.txt' fields terminated BY ',' enclosed BY '\"' lines starting by 'xy' terminated BY '\r'", true, "SELECT `a`,`b`,`a`+`b` FROM `t` INTO OUTFILE '/tmp/result.txt' FIELDS TERMINATED BY ',' ENCLOSED BY '\"' LINES STARTING BY 'xy' TERMINATED BY '\r'"},
+		{"select a from t into outfile 's3://bucket/prefix/result.parquet' format 'parquet'", true, "SELECT `a` FROM `t` INTO OUTFILE 's3://bucket/prefix/result.parquet' FORMAT 'parquet'"},
+		{"select `a` from `t` into outfile 's3://bucket/result.csv' format 'csv' fields terminated by ',' with max_file_size=67108864", true, "SELECT `a` FROM `t` INTO OUTFILE 's3://bucket/result.csv' FORMAT 'csv' FIELDS TERMINATED BY ',' WITH max_file_size=67108864"},
+		{"select `a` from `t` into outfile '/tmp/result.txt' lines terminated by '\n' with max_file_size=1024, __test", true, "SELECT `a` FROM `t` INTO OUTFILE '/tmp/result.txt' LINES TERMINATED BY '\n' WITH max_file_size=1024, __test"},
+		{"select a from t into outfile '/tmp/result.txt' with", false, ""},
+		{"select a from t into outfile '/tmp/result.txt' with compress='gzip' format 'csv'", false, ""},
 
 		// from join
 		{"SELECT * from t1, t2, t3", true, "SELECT * FROM ((`t1`) JOIN `t2`) JOIN `t3`"},
@@ -885,6 +896,36 @@ func TestDMLStmt(t *testing.T) {
 		{"INSERT INTO t SET a=1,b=2", true, "INSERT INTO `t` SET `a`=1,`b`=2"},
 		{"INSERT INTO t (a) SET a=1", false, ""},
 
+		// for returning
+		{"INSERT INTO t VALUES (1, 2) RETURNING *", true, "INSERT INTO `t` VALUES (1,2) RETURNING *"},
+		{"INSERT INTO t (a) VALUES (1) RETURNING id, a + 1 AS b", true, "INSERT INTO `t` (`a`) VALUES (1) RETURNING `id`, `a`+1 AS `b`"},
+		{"INSERT INTO t SELECT * FROM s ON DUPLICATE KEY UPDATE a = 1 RETURNING t.a", true, "INSERT INTO `t` SELECT * FROM `s` ON DUPLICATE KEY UPDATE `a`=1 RETURNING `t`.`a`"},
+		{"INSERT INTO t SET a=1 RETURNING a", true, "INSERT INTO `t` SET `a`=1 RETURNING `a`"},
+		{"REPLACE INTO t VALUES (1) RETURNING a", true, "REPLACE INTO `t` VALUES (1) RETURNING `a`"},
+		{"UPDATE t SET a = a + 1 WHERE id = 1 RETURNING id, a", true, "UPDATE `t` SET `a`=`a`+1 WHERE `id`=1 RETURNING `id`, `a`"},
+		{"UPDATE t SET a = 1 ORDER BY id LIMIT 1 RETURNING *", true, "UPDATE `t` SET `a`=1 ORDER BY `id` LIMIT 1 RETURNING *"},
+		{"DELETE FROM t WHERE id > 1 ORDER BY id LIMIT 2 RETURNING id", true, "DELETE FROM `t` WHERE `id`>1 ORDER BY `id` LIMIT 2 RETURNING `id`"},
+		{"DELETE FROM t AS x RETURNING x.a", true, "DELETE FROM `t` AS `x` RETURNING `x`.`a`"},
+		{"UPDATE t, s SET t.a = s.a RETURNING t.a", false, ""},
+		{"DELETE t FROM t, s RETURNING t.a", false, ""},
+		{"INSERT INTO t VALUES (1) RETURNING", false, ""},
+		{"CREATE TABLE `returning` (a int)", true, "CREATE TABLE `returning` (`a` INT)"},
+		// RETURNING is not reserved
+		{"CREATE TABLE returning (returning int)", true, "CREATE TABLE `returning` (`returning` INT)"},
+		{"SELECT returning AS returning FROM returning", true, "SELECT `returning` AS `returning` FROM `returning`"},
+		{"INSERT INTO t SELECT a FROM s RETURNING a", true, "INSERT INTO `t` SELECT `a` FROM `s` RETURNING `a`"},
+		{"DELETE FROM returning WHERE returning = 1 RETURNING returning", true, "DELETE FROM `returning` WHERE `returning`=1 RETURNING `returning`"},
+
+		// for merge statement
+		{"MERGE INTO t USING s ON t.id = s.id WHEN MATCHED THEN UPDATE SET a = s.a", true, "MERGE INTO `t` USING `s` ON `t`.`id`=`s`.`id` WHEN MATCHED THEN UPDATE SET `a`=`s`.`a`"},
+		{"MERGE INTO t AS x USING (SELECT * FROM s) AS y ON x.id = y.id WHEN MATCHED AND y.d = 1 THEN DELETE WHEN MATCHED THEN UPDATE SET x.a = y.a, b = 2 WHEN NOT MATCHED THEN INSERT VALUES (y.id, y.a, DEFAULT)", true, "MERGE INTO `t` AS `x` USING (SELECT * FROM `s`) AS `y` ON `x`.`id`=`y`.`id` WHEN MATCHED AND `y`.`d`=1 THEN DELETE WHEN MATCHED THEN UPDATE SET `x`.`a`=`y`.`a`, `b`=2 WHEN NOT MATCHED THEN INSERT VALUES (`y`.`id`,`y`.`a`,DEFAULT)"},
+		{"MERGE /*+ HASH_JOIN(t, s) */ INTO t USING s ON t.id = s.id WHEN NOT MATCHED AND s.a > 0 THEN INSERT (id, a) VALUE (s.id, s.a)", true, "MERGE /*+ HASH_JOIN(`t`, `s`)*/ INTO `t` USING `s` ON `t`.`id`=`s`.`id` WHEN NOT MATCHED AND `s`.`a`>0 THEN INSERT (`id`,`a`) VALUES (`s`.`id`,`s`.`a`)"},
+		{"MERGE INTO t USING s ON t.id = s.id", false, ""},
+		{"MERGE INTO t USING s WHEN MATCHED THEN DELETE", false, ""},
+		{"MERGE INTO t USING s ON t.id = s.id WHEN NOT MATCHED THEN UPDATE SET a = 1", false, ""},
+		{"MERGE INTO t USING s ON t.id = s.id WHEN MATCHED THEN INSERT VALUES (1)", false, ""},
+		{"CREATE TABLE matched (a int)", true, "CREATE TABLE `matched` (`a` INT)"},
+
 		// for update statement
 		{"UPDATE LOW_PRIORITY IGNORE t SET id = id + 1 ORDER BY id DESC;", true, "UPDATE LOW_PRIORITY IGNORE `t` SET `id`=`id`+1 ORDER BY `id` DESC"},
 		{"UPDATE t SET id = id + 1 ORDER BY id DESC;", true, "UPDATE `t` SET `id`=`id`+1 ORDER BY `id` DESC"},
@@ -1402,6 +1443,7 @@ func TestDBAStmt(t *testing.T) {
 		{"flush general logs", true, "FLUSH GENERAL LOGS"},
 		{"flush slow logs", true, "FLUSH SLOW LOGS"},
 		{"flush client_errors_summary", true, "FLUSH CLIENT_ERRORS_SUMMARY"},
+		{"flush cardinality_corrections", true, "FLUSH CARDINALITY_CORRECTIONS"},
 
 		// for change statement
 		{"change pump to node_state ='paused' for node_id '127.0.0.1:8250'", true, "CHANGE PUMP TO NODE_STATE ='paused' FOR NODE_ID '127.0.0.1:8250'"},
@@ -5681,6 +5723,182 @@ func TestView(t *testing.T) {
 	require.Equal(t, model.CheckOptionCascaded, v.CheckOption)
 }
 
+func TestMaterializedView(t *testing.T) {
+	table := []testCase{
+		{"create materialized view mv as select a, count(*) from t group by a", true, "CREATE MATERIALIZED VIEW `mv` REFRESH COMPLETE ON DEMAND AS SELECT `a`,COUNT(1) FROM `t` GROUP BY `a`"},
+		{"create materialized view if not exists mv (a, cnt) refresh complete on demand as select a, count(*) from t group by a", true, "CREATE MATERIALIZED VIEW IF NOT EXISTS `mv` (`a`,`cnt`) REFRESH COMPLETE ON DEMAND AS SELECT `a`,COUNT(1) FROM `t` GROUP BY `a`"},
+		{"create materialized view test.mv refresh complete as select * from t", true, "CREATE MATERIALIZED VIEW `test`.`mv` REFRESH COMPLETE ON DEMAND AS SELECT * FROM `t`"},
+		{"create materialized view mv refresh fast on demand as select a, sum(b) from t group by a", true, "CREATE MATERIALIZED VIEW `mv` REFRESH FAST ON DEMAND AS SELECT `a`,SUM(`b`) FROM `t` GROUP BY `a`"},
+		{"create materialized view mv refresh complete every 1 hour as select * from t", true, "CREATE MATERIALIZED VIEW `mv` REFRESH COMPLETE EVERY 1 HOUR AS SELECT * FROM `t`"},
+		{"create materialized view mv refresh complete every '30' minute as (select * from t union all select * from t)", true, "CREATE MATERIALIZED VIEW `mv` REFRESH COMPLETE EVERY _UTF8MB4'30' MINUTE AS (SELECT * FROM `t` UNION ALL SELECT * FROM `t`)"},
+		{"create materialized view mv refresh every 1 hour as select * from t", false, ""},
+		{"create materialized view mv refresh complete every hour as select * from t", false, ""},
+		{"create or replace materialized view mv as select * from t", false, ""},
+		{"drop materialized view mv", true, "DROP MATERIALIZED VIEW `mv`"},
+		{"drop materialized view if exists mv1, test.mv2", true, "DROP MATERIALIZED VIEW IF EXISTS `mv1`, `test`.`mv2`"},
+		{"refresh materialized view mv", true, "REFRESH MATERIALIZED VIEW `mv`"},
+		{"refresh materialized view test.mv", true, "REFRESH MATERIALIZED VIEW `test`.`mv`"},
+		{"refresh materialized view mv1, mv2", false, ""},
+
+		// the new keywords are not reserved
+		{"create table refresh (complete int, fast int, demand int, every int, materialized int)", true, "CREATE TABLE `refresh` (`complete` INT,`fast` INT,`demand` INT,`every` INT,`materialized` INT)"},
+	}
+	RunTest(t, table, false)
+
+	p := parser.New()
+	st, err := p.ParseOneStmt("create materialized view mv refresh complete every 2 day as select a from t", "", "")
+	require.NoError(t, err)
+	v, ok := st.(*ast.CreateMaterializedViewStmt)
+	require.True(t, ok)
+	require.Equal(t, model.RefreshComplete, v.RefreshMethod)
+	require.Equal(t, int64(2), v.RefreshInterval.(ast.ValueExpr).GetValue())
+	require.Equal(t, ast.TimeUnitDay, v.RefreshIntervalUnit.Unit)
+	require.Equal(t, "select a from t", v.Select.Text())
+}
+
+func TestTrigger(t *testing.T) {
+	table := []testCase{
+		{"create trigger trg before insert on t for each row set new.a = new.a + 1", true, "CREATE TRIGGER `trg` BEFORE INSERT ON `t` FOR EACH ROW SET @@SESSION.`new.a`=`new`.`a`+1"},
+		{"create trigger if not exists test.trg after update on test.t for each row insert into log values (old.a, new.a)", true, "CREATE TRIGGER IF NOT EXISTS `test`.`trg` AFTER UPDATE ON `test`.`t` FOR EACH ROW INSERT INTO `log` VALUES (`old`.`a`,`new`.`a`)"},
+		{"create trigger trg before insert on t set new.a = 1", false, ""},
+		{"create trigger trg before select on t for each row set new.a = 1", false, ""},
+		{"create trigger trg on t for each row set new.a = 1", false, ""},
+		{"drop trigger trg", true, "DROP TRIGGER `trg`"},
+		{"drop trigger if exists test.trg", true, "DROP TRIGGER IF EXISTS `test`.`trg`"},
+		{"drop trigger trg1, trg2", false, ""},
+
+		// the new keywords are not reserved
+		{"create table before (each int)", true, "CREATE TABLE `before` (`each` INT)"},
+	}
+	RunTest(t, table, false)
+
+	p := parser.New()
+	st, err := p.ParseOneStmt("create trigger trg after insert on t for each row insert into log values (new.a)", "", "")
+	require.NoError(t, err)
+	v, ok := st.(*ast.CreateTriggerStmt)
+	require.True(t, ok)
+	require.Equal(t, model.TriggerAfter, v.Timing)
+	require.Equal(t, model.TriggerInsert, v.Event)
+	require.Equal(t, "insert into log values (new.a)", v.Body.Text())
+
+	// the statements nested in a compound body aren't visited, so check the restored text only.
+	for _, tc := range [][2]string{
+		{"create trigger trg after delete on t for each row begin delete from t2 where a = old.a; insert into log values (old.a); end", "CREATE TRIGGER `trg` AFTER DELETE ON `t` FOR EACH ROW BEGIN DELETE FROM `t2` WHERE `a`=`old`.`a`;INSERT INTO `log` VALUES (`old`.`a`); END"},
+		{"create trigger trg before update on t for each row if new.a < 0 then set new.a = 0; end if", "CREATE TRIGGER `trg` BEFORE UPDATE ON `t` FOR EACH ROW IF `new`.`a`<0 THEN SET @@SESSION.`new.a`=0;END IF"},
+	} {
+		st, err = p.ParseOneStmt(tc[0], "", "")
+		require.NoError(t, err, tc[0])
+		var sb strings.Builder
+		require.NoError(t, st.Restore(NewRestoreCtx(DefaultRestoreFlags, &sb)))
+		require.Equal(t, tc[1], sb.String())
+		_, err = p.ParseOneStmt(sb.String(), "", "")
+		require.NoError(t, err, sb.String())
+	}
+}
+
+func TestEvent(t *testing.T) {
+	table := []testCase{
+		{"create event e on schedule at '2024-01-01 00:00:00' do insert into t values (1)", true, "CREATE EVENT `e` ON SCHEDULE AT _UTF8MB4'2024-01-01 00:00:00' DO INSERT INTO `t` VALUES (1)"},
+		{"create event if not exists test.e on schedule at current_timestamp + interval 1 hour on completion preserve disable comment 'one shot' do delete from t", true, "CREATE EVENT IF NOT EXISTS `test`.`e` ON SCHEDULE AT DATE_ADD(CURRENT_TIMESTAMP(), INTERVAL 1 HOUR) ON COMPLETION PRESERVE DISABLE COMMENT 'one shot' DO DELETE FROM `t`"},
+		{"create event e on schedule every 1 day starts '2024-01-01' ends '2025-01-01' on completion not preserve enable do delete from t", true, "CREATE EVENT `e` ON SCHEDULE EVERY 1 DAY STARTS _UTF8MB4'2024-01-01' ENDS _UTF8MB4'2025-01-01' ON COMPLETION NOT PRESERVE ENABLE DO DELETE FROM `t`"},
+		{"create event e on schedule every '1:30' hour_minute disable on slave do delete from t", true, "CREATE EVENT `e` ON SCHEDULE EVERY _UTF8MB4'1:30' HOUR_MINUTE DISABLE ON SLAVE DO DELETE FROM `t`"},
+		{"create event e on schedule every 1 day", false, ""},
+		{"create event e do delete from t", false, ""},
+		{"create event e on schedule at now() starts now() do delete from t", false, ""},
+		{"create event e on schedule after now() do delete from t", false, ""},
+		{"alter event e on schedule every 2 hour", true, "ALTER EVENT `e` ON SCHEDULE EVERY 2 HOUR"},
+		{"alter event test.e on completion preserve rename to test.e2 disable comment 'x' do delete from t", true, "ALTER EVENT `test`.`e` ON COMPLETION PRESERVE RENAME TO `test`.`e2` DISABLE COMMENT 'x' DO DELETE FROM `t`"},
+		{"alter event e enable", true, "ALTER EVENT `e` ENABLE"},
+		{"alter event e", false, ""},
+		{"drop event e", true, "DROP EVENT `e`"},
+		{"drop event if exists test.e", true, "DROP EVENT IF EXISTS `test`.`e`"},
+		{"show create event e", true, "SHOW CREATE EVENT `e`"},
+		{"show create event test.e", true, "SHOW CREATE EVENT `test`.`e`"},
+
+		// the new keywords are not reserved
+		{"create table at (starts int, ends int, completion int)", true, "CREATE TABLE `at` (`starts` INT,`ends` INT,`completion` INT)"},
+	}
+	RunTest(t, table, false)
+
+	p := parser.New()
+	st, err := p.ParseOneStmt("create event e on schedule every 10 minute starts '2024-01-01' do begin delete from t; insert into t values (1); end", "", "")
+	require.NoError(t, err)
+	v, ok := st.(*ast.CreateEventStmt)
+	require.True(t, ok)
+	require.Nil(t, v.Schedule.At)
+	require.Equal(t, ast.TimeUnitMinute, v.Schedule.Unit.Unit)
+	require.NotNil(t, v.Schedule.Starts)
+	require.Nil(t, v.Schedule.Ends)
+	require.Equal(t, ast.EventCompletionDefault, v.Completion)
+	require.Equal(t, ast.EventStatusDefault, v.Status)
+	require.Equal(t, "begin delete from t; insert into t values (1); end", v.Body.Text())
+}
+
+func TestChangefeed(t *testing.T) {
+	table := []testCase{
+		{"create changefeed cf into 'file:///tmp/cdc.log'", true, "CREATE CHANGEFEED `cf` INTO 'file:///tmp/cdc.log'"},
+		{"create changefeed if not exists cf for table t1, test.t2 into 'file:///tmp/cdc.log' format 'debezium'", true, "CREATE CHANGEFEED IF NOT EXISTS `cf` FOR TABLE `t1`, `test`.`t2` INTO 'file:///tmp/cdc.log' FORMAT 'debezium'"},
+		{"create changefeed cf for database test into 'file:///tmp/cdc.log' format = 'canal-json'", true, "CREATE CHANGEFEED `cf` FOR DATABASE `test` INTO 'file:///tmp/cdc.log' FORMAT 'canal-json'"},
+		{"create changefeed cf", false, ""},
+		{"create changefeed cf for table into 'file:///tmp/cdc.log'", false, ""},
+		{"drop changefeed cf", true, "DROP CHANGEFEED `cf`"},
+		{"drop changefeed if exists cf", true, "DROP CHANGEFEED IF EXISTS `cf`"},
+		{"pause changefeed cf", true, "PAUSE CHANGEFEED `cf`"},
+		{"resume changefeed cf", true, "RESUME CHANGEFEED `cf`"},
+		{"show changefeeds", true, "SHOW CHANGEFEEDS"},
+		{"show changefeeds like 'c%'", true, "SHOW CHANGEFEEDS LIKE _UTF8MB4'c%'"},
+
+		// the new keywords are not reserved
+		{"create table changefeed (changefeeds int)", true, "CREATE TABLE `changefeed` (`changefeeds` INT)"},
+	}
+	RunTest(t, table, false)
+}
+
+func TestRecommendIndex(t *testing.T) {
+	table := []testCase{
+		{"recommend index run", true, "RECOMMEND INDEX RUN"},
+		{"recommend index run for 'select * from t where a = 1'", true, "RECOMMEND INDEX RUN FOR 'select * from t where a = 1'"},
+		{"recommend index for 'select * from t where a = 1'", true, "RECOMMEND INDEX RUN FOR 'select * from t where a = 1'"},
+		{"recommend index run with max_num_index = 3", true, "RECOMMEND INDEX RUN WITH max_num_index = 3"},
+		{"recommend index for \"select * from t where b like 'x%'\" with MAX_NUM_INDEX = 3, max_index_columns = 2", true, "RECOMMEND INDEX RUN FOR 'select * from t where b like ''x%''' WITH max_num_index = 3, max_index_columns = 2"},
+		{"recommend index", false, ""},
+		{"recommend index for select * from t", false, ""},
+		{"recommend index run with max_num_index = -1", false, ""},
+
+		// the new keyword is not reserved
+		{"create table recommend (recommend int)", true, "CREATE TABLE `recommend` (`recommend` INT)"},
+	}
+	RunTest(t, table, false)
+}
+
+func TestVectorType(t *testing.T) {
+	table := []testCase{
+		{"create table t (a int, v vector(3))", true, "CREATE TABLE `t` (`a` INT,`v` VECTOR(3))"},
+		{"create table t (v vector)", true, "CREATE TABLE `t` (`v` VECTOR)"},
+		{"create table t (v vector<float>(5) not null)", true, "CREATE TABLE `t` (`v` VECTOR(5) NOT NULL)"},
+		{"create table t (v vector<double>(5))", false, ""},
+		{"alter table t add column v vector(128)", true, "ALTER TABLE `t` ADD COLUMN `v` VECTOR(128)"},
+		{"select cast('[1,2]' as vector)", true, "SELECT CAST(_UTF8MB4'[1,2]' AS VECTOR)"},
+		{"select cast('[1,2]' as vector(2))", true, "SELECT CAST(_UTF8MB4'[1,2]' AS VECTOR(2))"},
+		{"select vec_l2_distance(v, '[1,2,3]') from t order by vec_l2_distance(v, '[1,2,3]') limit 3", true, "SELECT VEC_L2_DISTANCE(`v`, _UTF8MB4'[1,2,3]') FROM `t` ORDER BY VEC_L2_DISTANCE(`v`, _UTF8MB4'[1,2,3]') LIMIT 3"},
+		{"select vector from vector", true, "SELECT `vector` FROM `vector`"},
+	}
+	RunTest(t, table, false)
+}
+
+func TestSpatialType(t *testing.T) {
+	table := []testCase{
+		{"create table t (g geometry, p point not null srid 4326)", true, "CREATE TABLE `t` (`g` GEOMETRY,`p` POINT NOT NULL SRID 4326)"},
+		{"create table t (a linestring, b polygon, c multipoint, d multilinestring, e multipolygon)", true, "CREATE TABLE `t` (`a` LINESTRING,`b` POLYGON,`c` MULTIPOINT,`d` MULTILINESTRING,`e` MULTIPOLYGON)"},
+		{"create table t (a geometrycollection, b geomcollection)", true, "CREATE TABLE `t` (`a` GEOMCOLLECTION,`b` GEOMCOLLECTION)"},
+		{"create table t (p point /*!80003 SRID 0 */)", true, "CREATE TABLE `t` (`p` POINT SRID 0)"},
+		{"create table t (p point srid -1)", false, ""},
+		{"alter table t add column p point srid 4326", true, "ALTER TABLE `t` ADD COLUMN `p` POINT SRID 4326"},
+		{"select st_astext(point(1, 2)), polygon from geometry", true, "SELECT ST_ASTEXT(POINT(1, 2)),`polygon` FROM `geometry`"},
+	}
+	RunTest(t, table, false)
+}
+
 func TestTimestampDiffUnit(t *testing.T) {
 	// Test case for timestampdiff unit.
 	// TimeUnit should be unified to upper case.
@@ -5980,6 +6198,79 @@ func TestTableSample(t *testing.T) {
 	}
 }
 
+func TestJSONTable(t *testing.T) {
+	table := []testCase{
+		// positive test cases
+		{"select * from json_table('[1,2]', '$[*]' columns (a int path '$')) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[1,2]', '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt`"},
+		{"select * from json_table('[1,2]', '$[*]' columns (a int path '$')) jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[1,2]', '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt`"},
+		{"select * from json_table('[]', '$[*]' columns (a int path '$'))", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$'))"},
+		{"select * from json_table('[]', '$[*]' columns (id for ordinality, b varchar(10) exists path '$.b')) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`id` FOR ORDINALITY, `b` VARCHAR(10) EXISTS PATH '$.b')) AS `jt`"},
+		{"select * from json_table('[]', '$[*]' columns (a int path '$.a' default '1' on empty)) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a' DEFAULT '1' ON EMPTY)) AS `jt`"},
+		{"select * from json_table('[]', '$[*]' columns (a int path '$.a' error on error)) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a' ERROR ON ERROR)) AS `jt`"},
+		{"select * from json_table('[]', '$[*]' columns (a int path '$.a' null on empty default '0' on error)) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a' NULL ON EMPTY DEFAULT '0' ON ERROR)) AS `jt`"},
+		{"select * from json_table('[]', '$[*]' columns (a json path '$.a', nested path '$.b[*]' columns (b int path '$'), nested '$.c' columns (c int path '$'))) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` JSON PATH '$.a', NESTED PATH '$.b[*]' COLUMNS (`b` INT PATH '$'), NESTED PATH '$.c' COLUMNS (`c` INT PATH '$'))) AS `jt`"},
+		{"select t.a, jt.b from t, json_table(t.doc, '$[*]' columns (b int path '$')) as jt", true, "SELECT `t`.`a`,`jt`.`b` FROM (`t`) JOIN JSON_TABLE(`t`.`doc`, '$[*]' COLUMNS (`b` INT PATH '$')) AS `jt`"},
+		{"select * from t left join json_table(t.doc, '$[*]' columns (b int path '$')) as jt on true", true, "SELECT * FROM `t` LEFT JOIN JSON_TABLE(`t`.`doc`, '$[*]' COLUMNS (`b` INT PATH '$')) AS `jt` ON TRUE"},
+		{"select nested, ordinality, path, empty from json_table", true, "SELECT `nested`,`ordinality`,`path`,`empty` FROM `json_table`"},
+		{"select json_table from json_table.json_table", true, "SELECT `json_table` FROM `json_table`.`json_table`"},
+
+		// negative test cases
+		{"select * from json_table('[]', '$[*]' columns ()) as jt", false, ""},
+		{"select * from json_table('[]', '$[*]') as jt", false, ""},
+		{"select * from json_table('[]', '$[*]' columns (a int)) as jt", false, ""},
+		{"select * from json_table('[]', '$[*]' columns (a int path '$' error on empty error on empty)) as jt", false, ""},
+		{"select * from json_table('[]', '$[*]' columns (a int path '$' error on error default '1' on empty)) as jt", false, ""},
+	}
+	RunTest(t, table, false)
+}
+
+func TestPivotUnpivot(t *testing.T) {
+	table := []testCase{
+		// positive test cases
+		{"select * from t pivot (sum(amount) for quarter in ('Q1', 'Q2'))", true, "SELECT * FROM `t` PIVOT (SUM(`amount`) FOR `quarter` IN (_UTF8MB4'Q1', _UTF8MB4'Q2'))"},
+		{"select * from t pivot (sum(amount) as s, count(*) c for quarter in (1 as q1, 2 q2)) as p", true, "SELECT * FROM `t` PIVOT (SUM(`amount`) AS `s`, COUNT(1) AS `c` FOR `quarter` IN (1 AS `q1`, 2 AS `q2`)) AS `p`"},
+		{"select * from (select a, b, c from t) as s pivot (max(c) for s.b in (1)) p", true, "SELECT * FROM (SELECT `a`,`b`,`c` FROM `t`) AS `s` PIVOT (MAX(`c`) FOR `s`.`b` IN (1)) AS `p`"},
+		{"select * from t1 join t2 pivot (sum(a) for b in (1)) on true", true, "SELECT * FROM `t1` JOIN `t2` PIVOT (SUM(`a`) FOR `b` IN (1)) ON TRUE"},
+		{"select * from (t1 join t2) pivot (sum(a) for b in (1))", true, "SELECT * FROM (`t1` JOIN `t2`) PIVOT (SUM(`a`) FOR `b` IN (1))"},
+		{"select * from t unpivot (amount for quarter in (q1, q2))", true, "SELECT * FROM `t` UNPIVOT (`amount` FOR `quarter` IN (`q1`, `q2`))"},
+		{"select * from t unpivot include nulls (amount for quarter in (q1 as 'first', q2 as second)) as u", true, "SELECT * FROM `t` UNPIVOT INCLUDE NULLS (`amount` FOR `quarter` IN (`q1` AS `first`, `q2` AS `second`)) AS `u`"},
+		{"select * from t unpivot exclude nulls (amount for quarter in (t.q1))", true, "SELECT * FROM `t` UNPIVOT (`amount` FOR `quarter` IN (`t`.`q1`))"},
+		{"select * from t pivot (sum(a) for b in (1)) unpivot (v for n in (`1`))", true, "SELECT * FROM `t` PIVOT (SUM(`a`) FOR `b` IN (1)) UNPIVOT (`v` FOR `n` IN (`1`))"},
+		{"select include, exclude from include.exclude", true, "SELECT `include`,`exclude` FROM `include`.`exclude`"},
+		// PIVOT and UNPIVOT are not reserved
+		{"select pivot, unpivot from pivot.unpivot", true, "SELECT `pivot`,`unpivot` FROM `pivot`.`unpivot`"},
+		{"select * from pivot as pivot pivot (sum(pivot) for unpivot in (1))", true, "SELECT * FROM `pivot` AS `pivot` PIVOT (SUM(`pivot`) FOR `unpivot` IN (1))"},
+		{"select * from unpivot unpivot (pivot for unpivot in (pivot))", true, "SELECT * FROM `unpivot` UNPIVOT (`pivot` FOR `unpivot` IN (`pivot`))"},
+		{"create table pivot (unpivot int)", true, "CREATE TABLE `pivot` (`unpivot` INT)"},
+
+		// negative test cases
+		{"select * from t pivot (sum(a) for b in ())", false, ""},
+		{"select * from t pivot (for b in (1))", false, ""},
+		{"select * from t pivot sum(a) for b in (1)", false, ""},
+		{"select * from t unpivot (v for n in ())", false, ""},
+		{"select * from t unpivot (v for n in (1))", false, ""},
+		{"select * from t unpivot include (v for n in (a))", false, ""},
+		{"select * from t pivot pivot (sum(a) for b in (1))", false, ""},
+	}
+	RunTest(t, table, false)
+}
+
+func TestLateralDerivedTable(t *testing.T) {
+	table := []testCase{
+		{"select * from t, lateral (select t.a) as d", true, "SELECT * FROM (`t`) JOIN LATERAL (SELECT `t`.`a`) AS `d`"},
+		{"select * from t, lateral (select t.a) d", true, "SELECT * FROM (`t`) JOIN LATERAL (SELECT `t`.`a`) AS `d`"},
+		{"select * from t join lateral (select * from t1 where t1.a = t.a) as d on true", true, "SELECT * FROM `t` JOIN LATERAL (SELECT * FROM `t1` WHERE `t1`.`a`=`t`.`a`) AS `d` ON TRUE"},
+		{"select * from t left join lateral (select count(*) c from t1 where t1.a = t.a) as d on d.c > 0", true, "SELECT * FROM `t` LEFT JOIN LATERAL (SELECT COUNT(1) AS `c` FROM `t1` WHERE `t1`.`a`=`t`.`a`) AS `d` ON `d`.`c`>0"},
+		{"select * from t, lateral (select t.a union select t.b) as d", true, "SELECT * FROM (`t`) JOIN LATERAL (SELECT `t`.`a` UNION SELECT `t`.`b`) AS `d`"},
+		{"select * from t, lateral t1", false, ""},
+		// A LATERAL derived table must have an alias.
+		{"select * from t, lateral (select t.a)", false, ""},
+		{"select * from t join lateral (select * from t1 where t1.a = t.a) on true", false, ""},
+		{"create table lateral (a int)", false, ""},
+	}
+	RunTest(t, table, false)
+}
+
 func TestGeneratedColumn(t *testing.T) {
 	tests := []struct {
 		input string
@@ -6377,6 +6668,16 @@ func TestWindowFunctions(t *testing.T) {
 		{`SELECT RANK() OVER (w1) FROM t WINDOW w1 AS (w2), w2 AS (), w3 AS (w1);`, true, "SELECT RANK() OVER (`w1`) FROM `t` WINDOW `w1` AS (`w2`),`w2` AS (),`w3` AS (`w1`)"},
 		{`SELECT RANK() OVER w1 FROM t WINDOW w1 AS (w2), w2 AS (w3), w3 AS (w1);`, true, "SELECT RANK() OVER `w1` FROM `t` WINDOW `w1` AS (`w2`),`w2` AS (`w3`),`w3` AS (`w1`)"},
 
+		// For QUALIFY clause.
+		{`SELECT a, ROW_NUMBER() OVER (PARTITION BY b ORDER BY c) AS rn FROM t QUALIFY rn <= 3;`, true, "SELECT `a`,ROW_NUMBER() OVER (PARTITION BY `b` ORDER BY `c`) AS `rn` FROM `t` QUALIFY `rn`<=3"},
+		{`SELECT a FROM t WHERE a > 1 QUALIFY ROW_NUMBER() OVER (PARTITION BY b ORDER BY c DESC) = 1 ORDER BY a LIMIT 10;`, true, "SELECT `a` FROM `t` WHERE `a`>1 QUALIFY ROW_NUMBER() OVER (PARTITION BY `b` ORDER BY `c` DESC)=1 ORDER BY `a` LIMIT 10"},
+		{`SELECT b, SUM(a) FROM t GROUP BY b HAVING SUM(a) > 1 WINDOW w AS (ORDER BY b) QUALIFY RANK() OVER w < 2;`, true, "SELECT `b`,SUM(`a`) FROM `t` GROUP BY `b` HAVING SUM(`a`)>1 WINDOW `w` AS (ORDER BY `b`) QUALIFY RANK() OVER `w`<2"},
+		{`SELECT a FROM t QUALIFY;`, false, ""},
+		{`SELECT a FROM t qualify QUALIFY a > 1;`, false, ""},
+		// QUALIFY is not reserved
+		{`SELECT qualify AS qualify FROM qualify QUALIFY qualify > 1;`, true, "SELECT `qualify` AS `qualify` FROM `qualify` QUALIFY `qualify`>1"},
+		{`CREATE TABLE qualify (qualify INT);`, true, "CREATE TABLE `qualify` (`qualify` INT)"},
+
 		// For TSO functions
 		{`select tidb_parse_tso(1)`, true, "SELECT TIDB_PARSE_TSO(1)"},
 		{`select tidb_parse_tso_logical(1)`, true, "SELECT TIDB_PARSE_TSO_LOGICAL(1)"},
@@ -6662,6 +6963,32 @@ func TestStartTransaction(t *testing.T) {
 	RunTest(t, cases, false)
 }
 
+func TestXAStmt(t *testing.T) {
+	cases := []testCase{
+		{"XA START 'xid1'", true, "XA START 'xid1'"},
+		{"XA BEGIN 'xid1', 'b1'", true, "XA START 'xid1','b1'"},
+		{"xa start 'xid1', 'b1', 3", true, "XA START 'xid1','b1',3"},
+		{"XA START X'6162', B'01100011' JOIN", true, "XA START 'ab','c' JOIN"},
+		{"XA START 'xid1' RESUME", true, "XA START 'xid1' RESUME"},
+		{"XA START", false, ""},
+		{"XA START xid1", false, ""},
+		{"XA START 'xid1', 'b1', -1", false, ""},
+		{"XA END 'xid1'", true, "XA END 'xid1'"},
+		{"XA END 'xid1' SUSPEND", true, "XA END 'xid1' SUSPEND"},
+		{"XA END 'xid1' SUSPEND FOR MIGRATE", true, "XA END 'xid1' SUSPEND FOR MIGRATE"},
+		{"XA PREPARE 'xid1', '', 1", true, "XA PREPARE 'xid1'"},
+		{"XA COMMIT 'xid1'", true, "XA COMMIT 'xid1'"},
+		{"XA COMMIT 'xid1' ONE PHASE", true, "XA COMMIT 'xid1' ONE PHASE"},
+		{"XA ROLLBACK 'xid1', '', 2", true, "XA ROLLBACK 'xid1','',2"},
+		{"XA RECOVER", true, "XA RECOVER"},
+		{"XA RECOVER CONVERT XID", true, "XA RECOVER CONVERT XID"},
+		{"XA RECOVER 'xid1'", false, ""},
+		{"create table xa (xa int, xid int, one int, phase int, suspend int, migrate int)", true, "CREATE TABLE `xa` (`xa` INT,`xid` INT,`one` INT,`phase` INT,`suspend` INT,`migrate` INT)"},
+	}
+
+	RunTest(t, cases, false)
+}
+
 func TestSignedInt64OutOfRange(t *testing.T) {
 	p := parser.New()
 	cases := []string{
@@ -6932,6 +7259,12 @@ func TestStatisticsOps(t *testing.T) {
 		{"create statistics if not exists stats3 (correlation) on t(a,b)", true, "CREATE STATISTICS IF NOT EXISTS `stats3` (CORRELATION) ON `t`(`a`, `b`)"},
 		{"create statistics if not exists stats3 on t(a,b)", false, ""},
 		{"create statistics stats1(cardinality) on t(a,b,c)", true, "CREATE STATISTICS `stats1` (CARDINALITY) ON `t`(`a`, `b`, `c`)"},
+		{"create statistics stats4 (ndistinct, dependencies, mcv) on a, b from t", true, "CREATE STATISTICS `stats4` (NDISTINCT, DEPENDENCIES, MCV) ON `a`, `b` FROM `t`"},
+		{"create statistics if not exists stats4 (mcv, ndistinct) on a, b, c from test.t", true, "CREATE STATISTICS IF NOT EXISTS `stats4` (NDISTINCT, MCV) ON `a`, `b`, `c` FROM `test`.`t`"},
+		{"create statistics stats4 on a, b from t", true, "CREATE STATISTICS `stats4` (NDISTINCT, DEPENDENCIES, MCV) ON `a`, `b` FROM `t`"},
+		{"create statistics stats4 () on a, b from t", false, ""},
+		{"create statistics stats4 (cardinality) on a, b from t", false, ""},
+		{"create statistics stats4 (ndistinct) on t(a, b)", false, ""},
 		{"drop statistics stats1", true, "DROP STATISTICS `stats1`"},
 	}
 	RunTest(t, table, false)
@@ -6949,6 +7282,14 @@ func TestStatisticsOps(t *testing.T) {
 	require.Equal(t, model.CIStr{O: "a", L: "a"}, v.Columns[0].Name)
 	require.Equal(t, model.CIStr{O: "b", L: "b"}, v.Columns[1].Name)
 	require.Equal(t, model.CIStr{O: "c", L: "c"}, v.Columns[2].Name)
+
+	sms, _, err = p.Parse("create statistics stats4 (dependencies, mcv) on a, b from t", "", "")
+	require.NoError(t, err)
+	v, ok = sms[0].(*ast.CreateStatisticsStmt)
+	require.True(t, ok)
+	require.Equal(t, ast.StatsTypeMultiColumn|ast.StatsKindDependencies|ast.StatsKindMCV, v.StatsType)
+	require.Equal(t, model.CIStr{O: "t", L: "t"}, v.Table.Name)
+	require.Len(t, v.Columns, 2)
 }
 
 func TestHighNotPrecedenceMode(t *testing.T) {
@@ -68,6 +68,8 @@ var type2Str = map[byte]string{
 	mysql.TypeVarchar:     "varchar",
 	mysql.TypeVarString:   "var_string",
 	mysql.TypeYear:        "year",
+
+	mysql.TypeTiDBVectorFloat32: "vector",
 }
 
 var str2Type = map[string]byte{
@@ -97,6 +99,7 @@ var str2Type = map[string]byte{
 	"tinytext":    mysql.TypeTinyBlob,
 	"varchar":     mysql.TypeVarchar,
 	"var_string":  mysql.TypeVarString,
+	"vector":      mysql.TypeTiDBVectorFloat32,
 	"year":        mysql.TypeYear,
 }
 
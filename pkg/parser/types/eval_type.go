@@ -33,10 +33,12 @@ const (
 	ETDuration
 	// ETJson represents type JSON in evaluation.
 	ETJson
+	// ETVectorFloat32 represents type VectorFloat32 in evaluation.
+	ETVectorFloat32
 )
 
-// IsStringKind returns true for ETString, ETDatetime, ETTimestamp, ETDuration, ETJson EvalTypes.
+// IsStringKind returns true for ETString, ETDatetime, ETTimestamp, ETDuration, ETJson, ETVectorFloat32 EvalTypes.
 func (et EvalType) IsStringKind() bool {
 	return et == ETString || et == ETDatetime ||
-		et == ETTimestamp || et == ETDuration || et == ETJson
+		et == ETTimestamp || et == ETDuration || et == ETJson || et == ETVectorFloat32
 }
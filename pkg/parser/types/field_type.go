@@ -57,6 +57,8 @@ type FieldType struct {
 	elems            []string
 	elemsIsBinaryLit []bool
 	array            bool
+	// geometryType is the subtype of the geometry type, e.g. mysql.GeometryTypePoint.
+	geometryType byte
 	// Please keep in mind that jsonFieldType should be updated if you add a new field here.
 }
 
@@ -81,7 +83,8 @@ func (ft *FieldType) IsDecimalValid() bool {
 // IsVarLengthType Determine whether the column type is a variable-length type
 func (ft *FieldType) IsVarLengthType() bool {
 	switch ft.GetType() {
-	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeJSON, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeJSON, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob,
+		mysql.TypeTiDBVectorFloat32:
 		return true
 	default:
 		return false
@@ -233,6 +236,16 @@ func (ft *FieldType) IsArray() bool {
 	return ft.array
 }
 
+// SetGeometryType sets the subtype of the geometry type.
+func (ft *FieldType) SetGeometryType(tp byte) {
+	ft.geometryType = tp
+}
+
+// GetGeometryType returns the subtype of the geometry type.
+func (ft *FieldType) GetGeometryType() byte {
+	return ft.geometryType
+}
+
 // ArrayType return the type of the array.
 func (ft *FieldType) ArrayType() *FieldType {
 	if !ft.array {
@@ -346,6 +359,8 @@ func (ft *FieldType) EvalType() EvalType {
 		return ETDuration
 	case mysql.TypeJSON:
 		return ETJson
+	case mysql.TypeTiDBVectorFloat32:
+		return ETVectorFloat32
 	case mysql.TypeEnum, mysql.TypeSet:
 		if ft.flag&mysql.EnumSetAsIntFlag > 0 {
 			return ETInt
@@ -369,7 +384,7 @@ func (ft *FieldType) Init(tp byte) {
 // CompactStr only considers tp/CharsetBin/flen/Deimal.
 // This is used for showing column type in infoschema.
 func (ft *FieldType) CompactStr() string {
-	ts := TypeToStr(ft.GetType(), ft.charset)
+	ts := ft.typeStr()
 	suffix := ""
 
 	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(ft.GetType())
@@ -429,10 +444,23 @@ func (ft *FieldType) CompactStr() string {
 		suffix = fmt.Sprintf("(%d)", ft.flen)
 	case mysql.TypeNull:
 		suffix = "(0)"
+	case mysql.TypeTiDBVectorFloat32:
+		// Dimension is unspecified for a vector of any dimension.
+		if ft.flen != UnspecifiedLength {
+			suffix = fmt.Sprintf("(%d)", ft.flen)
+		}
 	}
 	return ts + suffix
 }
 
+// typeStr returns the name of the type, a geometry type is named by its subtype.
+func (ft *FieldType) typeStr() string {
+	if ft.GetType() == mysql.TypeGeometry {
+		return mysql.GeometryTypeName(ft.geometryType)
+	}
+	return TypeToStr(ft.GetType(), ft.charset)
+}
+
 // InfoSchemaStr joins the CompactStr with unsigned flag and
 // returns a string.
 func (ft *FieldType) InfoSchemaStr() string {
@@ -473,7 +501,7 @@ func (ft *FieldType) String() string {
 
 // Restore implements Node interface.
 func (ft *FieldType) Restore(ctx *format.RestoreCtx) error {
-	ctx.WriteKeyWord(TypeToStr(ft.GetType(), ft.charset))
+	ctx.WriteKeyWord(ft.typeStr())
 
 	precision := UnspecifiedLength
 	scale := UnspecifiedLength
@@ -579,6 +607,11 @@ func (ft *FieldType) RestoreAsCastType(ctx *format.RestoreCtx, explicitCharset b
 		}
 	case mysql.TypeJSON:
 		ctx.WriteKeyWord("JSON")
+	case mysql.TypeTiDBVectorFloat32:
+		ctx.WriteKeyWord("VECTOR")
+		if ft.flen != UnspecifiedLength {
+			ctx.WritePlainf("(%d)", ft.flen)
+		}
 	case mysql.TypeDouble:
 		ctx.WriteKeyWord("DOUBLE")
 	case mysql.TypeFloat:
@@ -644,6 +677,7 @@ type jsonFieldType struct {
 	Elems            []string
 	ElemsIsBinaryLit []bool
 	Array            bool
+	GeometryType     byte `json:",omitempty"`
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
@@ -660,6 +694,7 @@ func (ft *FieldType) UnmarshalJSON(data []byte) error {
 		ft.elems = r.Elems
 		ft.elemsIsBinaryLit = r.ElemsIsBinaryLit
 		ft.array = r.Array
+		ft.geometryType = r.GeometryType
 	}
 	return err
 }
@@ -676,6 +711,7 @@ func (ft *FieldType) MarshalJSON() ([]byte, error) {
 	r.Elems = ft.elems
 	r.ElemsIsBinaryLit = ft.elemsIsBinaryLit
 	r.Array = ft.array
+	r.GeometryType = ft.geometryType
 	return json.Marshal(r)
 }
 
@@ -0,0 +1,60 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinality
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/planner/context"
+	"github.com/pingcap/tidb/pkg/planner/util/debugtrace"
+	"github.com/pingcap/tidb/pkg/statistics"
+	"github.com/pingcap/tidb/pkg/statistics/feedback"
+)
+
+// PredicateShape returns the shape of the CNF predicates, which is the normalized predicates without the constants.
+// The predicates with the same shape are estimated in the same way, so the corrections learned from the executions
+// of some of them can be applied to the others.
+func PredicateShape(exprs []expression.Expression) string {
+	shapes := make([]string, 0, len(exprs))
+	for _, expr := range exprs {
+		shapes = append(shapes, expr.ExplainNormalizedInfo())
+	}
+	slices.Sort(shapes)
+	// The duplicated predicates make no difference to the selectivity, e.g. the predicates on the prefix index columns
+	// are kept in both the access conditions and the filters.
+	return strings.Join(slices.Compact(shapes), ", ")
+}
+
+// correctSelectivity applies the correction learned from the executions to the estimated selectivity of the
+// predicates, see `feedback.Record`. The applied factor is recorded to learn the correction from the estimation of
+// this statement.
+func correctSelectivity(sctx context.PlanContext, coll *statistics.HistColl, exprs []expression.Expression, sel float64) float64 {
+	vars := sctx.GetSessionVars()
+	if !vars.EnableCardinalityFeedback {
+		return sel
+	}
+	key := feedback.Key{TableID: coll.PhysicalID, Shape: PredicateShape(exprs)}
+	factor, ok := feedback.Factor(key)
+	if !ok {
+		return sel
+	}
+	vars.StmtCtx.RecordCardinalityCorrection(key, factor)
+	if vars.StmtCtx.EnableOptimizerDebugTrace {
+		debugtrace.RecordAnyValuesWithNames(sctx, "Learned correction factor", factor)
+	}
+	return min(sel*factor, 1)
+}
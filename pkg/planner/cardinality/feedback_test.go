@@ -0,0 +1,68 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinality_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/statistics/feedback"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardinalityFeedback(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	feedback.Flush()
+	defer feedback.Flush()
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int)")
+	values := make([]string, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		values = append(values, fmt.Sprintf("(%d, %d)", i, i))
+	}
+	// a and b are correlated, so the selectivity of the predicates on both of them is under-estimated.
+	tk.MustExec("insert into t values " + strings.Join(values, ","))
+	tk.MustExec("analyze table t all columns")
+	checkEstRows := func(sql string, estRows string) {
+		rows := tk.MustQuery(sql).Rows()
+		require.Equal(t, estRows, rows[0][1], sql)
+	}
+	corrections := "select table_schema, table_name, partition_name, predicate_shape, round(correction_factor, 2), " +
+		"exec_count, round(last_estimated_rows, 2), last_actual_rows from information_schema.cardinality_corrections"
+
+	// The feedback is not collected when it's disabled.
+	checkEstRows("explain analyze select * from t where a < 100 and b < 100", "10.00")
+	tk.MustQuery(corrections).Check(testkit.Rows())
+
+	tk.MustExec("set @@tidb_opt_enable_cardinality_feedback = 1")
+	checkEstRows("explain analyze select * from t where a < 100 and b < 100", "10.00")
+	tk.MustQuery(corrections).Check(testkit.Rows("test t <nil> lt(test.t.a, ?), lt(test.t.b, ?) 10 1 10 100"))
+	// The correction is applied to the predicates of the same shape.
+	checkEstRows("explain select * from t where a < 200 and b < 200", "400.00")
+	checkEstRows("explain select * from t where a < 200", "200.00")
+	// The feedback is compared with the estimation without the correction.
+	checkEstRows("explain analyze select * from t where b < 100 and a < 100", "100.00")
+	tk.MustQuery(corrections).Check(testkit.Rows("test t <nil> lt(test.t.a, ?), lt(test.t.b, ?) 10 2 10 100"))
+
+	tk.MustExec("set @@tidb_opt_enable_cardinality_feedback = 0")
+	checkEstRows("explain select * from t where a < 200 and b < 200", "40.00")
+	tk.MustExec("set @@tidb_opt_enable_cardinality_feedback = 1")
+	tk.MustExec("flush cardinality_corrections")
+	tk.MustQuery(corrections).Check(testkit.Rows())
+	checkEstRows("explain select * from t where a < 200 and b < 200", "40.00")
+}
@@ -0,0 +1,172 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinality
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/pingcap/tidb/pkg/planner/context"
+	"github.com/pingcap/tidb/pkg/statistics"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/codec"
+	"github.com/pingcap/tidb/pkg/util/collate"
+)
+
+// getMultiColumnStatsNodes builds the StatsNodes of the multi-column statistics from the StatsNodes of the columns.
+// The multi-column statistics only estimate the equal conditions on the columns, so a column is matched only if its
+// ranges are a single point. A StatsNode covers the expressions of the matched columns, and it is built only if 2
+// or more columns are matched.
+func getMultiColumnStatsNodes(sctx context.PlanContext, coll *statistics.HistColl, nodes []*StatsNode) ([]*StatsNode, error) {
+	if len(coll.MultiColumnStats) == 0 {
+		return nil, nil
+	}
+	tc := sctx.GetSessionVars().StmtCtx.TypeCtx()
+	colNodes := make(map[int64]*StatsNode, len(nodes))
+	for _, node := range nodes {
+		if node.Tp != ColType && node.Tp != PkType {
+			continue
+		}
+		if len(node.Ranges) == 1 && node.Ranges[0].IsPointNonNullable(tc) {
+			colNodes[node.ID] = node
+		}
+	}
+	var multiColNodes []*StatsNode
+	for i, stats := range coll.MultiColumnStats {
+		matched := make([]*StatsNode, 0, len(stats.ColIDs))
+		for _, id := range stats.ColIDs {
+			if node, ok := colNodes[id]; ok {
+				matched = append(matched, node)
+			}
+		}
+		if len(matched) < 2 {
+			continue
+		}
+		var sel float64
+		var ok bool
+		var err error
+		if len(matched) == len(stats.ColIDs) {
+			sel, ok, err = selectivityByMCV(sctx, stats, matched)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if !ok {
+			sel, ok = selectivityByDependencies(stats, matched)
+		}
+		if !ok {
+			continue
+		}
+		node := &StatsNode{Tp: MultiColType, ID: int64(i), Selectivity: sel, numCols: len(matched)}
+		for _, colNode := range matched {
+			node.mask |= colNode.mask
+		}
+		multiColNodes = append(multiColNodes, node)
+	}
+	return multiColNodes, nil
+}
+
+// selectivityByMCV estimates the selectivity of the equal conditions on all the columns of the statistics. The
+// frequency in the MCV list is used if the values are common, otherwise the rows not in the MCV list are assumed to be
+// distributed evenly over the other combinations. The result is never larger than the selectivity of any column.
+func selectivityByMCV(sctx context.PlanContext, stats *statistics.MultiColumnStats, matched []*StatsNode) (float64, bool, error) {
+	ndv, hasNDV := stats.NDV(sortedColIDs(stats.ColIDs))
+	if len(stats.MCV) == 0 && !hasNDV {
+		return 0, false, nil
+	}
+	sc := sctx.GetSessionVars().StmtCtx
+	var encoded []byte
+	minSel, prodSel := 1.0, 1.0
+	for _, node := range matched {
+		// The strings in the MCV list are encoded by their collation keys, see `GetColumnRowCount`.
+		val := *node.Ranges[0].LowVal[0].Clone()
+		if val.Kind() == types.KindString {
+			val.SetBytes(collate.GetCollator(val.Collation()).Key(val.GetString()))
+		}
+		var err error
+		encoded, err = codec.EncodeKey(sc.TimeZone(), encoded, val)
+		if err = sc.HandleError(err); err != nil {
+			return 0, false, err
+		}
+		minSel = min(minSel, node.Selectivity)
+		prodSel *= node.Selectivity
+	}
+	if freq, ok := stats.MCVFreq(encoded); ok {
+		return min(freq, minSel), true, nil
+	}
+	restFreq := max(1-stats.MCVTotalFreq(), 0)
+	if hasNDV {
+		return min(restFreq/max(ndv-float64(len(stats.MCV)), 1), minSel), true, nil
+	}
+	return min(prodSel, restFreq, minSel), true, nil
+}
+
+// selectivityByDependencies estimates the selectivity of the equal conditions on the matched columns by the
+// functional dependencies between them. For a dependency `a -> b` with degree f, the selectivity of `a = x and b = y`
+// is sel(a) * (f + (1-f) * sel(b)). The dependencies are applied in the descending order of their degrees, and a
+// column implied by a dependency can't determine other columns.
+func selectivityByDependencies(stats *statistics.MultiColumnStats, matched []*StatsNode) (float64, bool) {
+	if len(stats.Dependencies) == 0 {
+		return 0, false
+	}
+	sels := make(map[int64]float64, len(matched))
+	for _, node := range matched {
+		sels[node.ID] = node.Selectivity
+	}
+	deps := make([]*statistics.ColumnDependency, 0, len(stats.Dependencies))
+	for _, dep := range stats.Dependencies {
+		_, fromOK := sels[dep.From]
+		_, toOK := sels[dep.To]
+		if fromOK && toOK {
+			deps = append(deps, dep)
+		}
+	}
+	if len(deps) == 0 {
+		return 0, false
+	}
+	slices.SortStableFunc(deps, func(a, b *statistics.ColumnDependency) int {
+		return cmp.Compare(b.Degree, a.Degree)
+	})
+	implied := make(map[int64]float64, len(deps))
+	determinants := make(map[int64]struct{}, len(deps))
+	for _, dep := range deps {
+		if _, ok := implied[dep.From]; ok {
+			continue
+		}
+		if _, ok := implied[dep.To]; ok {
+			continue
+		}
+		if _, ok := determinants[dep.To]; ok {
+			continue
+		}
+		implied[dep.To] = dep.Degree
+		determinants[dep.From] = struct{}{}
+	}
+	sel := 1.0
+	for _, node := range matched {
+		if degree, ok := implied[node.ID]; ok {
+			sel *= degree + (1-degree)*node.Selectivity
+		} else {
+			sel *= node.Selectivity
+		}
+	}
+	return sel, true
+}
+
+func sortedColIDs(colIDs []int64) []int64 {
+	ids := slices.Clone(colIDs)
+	slices.Sort(ids)
+	return ids
+}
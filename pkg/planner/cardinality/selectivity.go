@@ -83,7 +83,7 @@ func Selectivity(
 			ceTraceExpr(ctx, tableID, "Table Stats-Pseudo-Expression",
 				expression.ComposeCNFCondition(ctx.GetExprCtx(), exprs...), ret*float64(coll.RealtimeCount))
 		}
-		return ret, nil, nil
+		return correctSelectivity(ctx, coll, exprs, ret), nil, nil
 	}
 
 	var nodes []*StatsNode
@@ -168,6 +168,10 @@ func Selectivity(
 	for _, id := range idxIDs {
 		idxStats := coll.Indices[id]
 		idxInfo := idxStats.Info
+		if idxInfo.Tp == model.IndexTypeFulltext {
+			// The entries of a FULLTEXT index are the tokens of the texts, they can't estimate the filters on the columns.
+			continue
+		}
 		if idxInfo.MVIndex {
 			totalSelectivity, mask, ok := getMaskAndSelectivityForMVIndex(ctx, coll, id, remainedExprs)
 			if !ok {
@@ -214,6 +218,11 @@ func Selectivity(
 			})
 		}
 	}
+	multiColNodes, err := getMultiColumnStatsNodes(ctx, coll, nodes)
+	if err != nil {
+		return 0, nil, errors.Trace(err)
+	}
+	nodes = append(nodes, multiColNodes...)
 	usedSets := GetUsableSetsByGreedy(nodes)
 	// Initialize the mask with the full set.
 	mask := (int64(1) << uint(len(remainedExprs))) - 1
@@ -439,7 +448,7 @@ OUTER:
 		totalExpr := expression.ComposeCNFCondition(ctx.GetExprCtx(), remainedExprs...)
 		ceTraceExpr(ctx, tableID, "Table Stats-Expression-CNF", totalExpr, ret*float64(coll.RealtimeCount))
 	}
-	return ret, nodes, nil
+	return correctSelectivity(ctx, coll, exprs, ret), nodes, nil
 }
 
 // CalcTotalSelectivityForMVIdxPath calculates the total selectivity for the given partial paths of an MV index merge path.
@@ -550,22 +559,15 @@ const (
 	IndexType = iota
 	PkType
 	ColType
+	// MultiColType is the type of the multi-column statistics, see `getMultiColumnStatsNodes`.
+	MultiColType
 )
 
+// typeOrder is the order of the types of the StatsNode when they are sorted.
+var typeOrder = [...]int{ColType: 0, IndexType: 1, MultiColType: 2, PkType: 3}
+
 func compareType(l, r int) int {
-	if l == r {
-		return 0
-	}
-	if l == ColType {
-		return -1
-	}
-	if l == PkType {
-		return 1
-	}
-	if r == ColType {
-		return 1
-	}
-	return -1
+	return cmp.Compare(typeOrder[l], typeOrder[r])
 }
 
 const unknownColumnID = math.MinInt64
@@ -0,0 +1,145 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/planner/cardinality"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/pkg/statistics/feedback"
+	"github.com/pingcap/tidb/pkg/util/execdetails"
+)
+
+// CollectCardinalityFeedback compares the estimated row counts of the table readers in the executed plan with the
+// actual ones in the runtime stats, and learns the corrections of the estimations of their predicates.
+// It should be called after the statement is executed successfully.
+func CollectCardinalityFeedback(sctx base.PlanContext, p base.Plan) {
+	sc := sctx.GetSessionVars().StmtCtx
+	if sc.RuntimeStatsColl == nil {
+		return
+	}
+	switch x := p.(type) {
+	case *Explain:
+		if x.Analyze {
+			CollectCardinalityFeedback(sctx, x.TargetPlan)
+		}
+	case *Insert:
+		collectCardinalityFeedback(sc, x.SelectPlan)
+	case *Update:
+		collectCardinalityFeedback(sc, x.SelectPlan)
+	case *Delete:
+		collectCardinalityFeedback(sc, x.SelectPlan)
+	case base.PhysicalPlan:
+		collectCardinalityFeedback(sc, x)
+	}
+}
+
+func collectCardinalityFeedback(sc *stmtctx.StatementContext, p base.PhysicalPlan) {
+	if p == nil {
+		return
+	}
+	switch x := p.(type) {
+	case *PhysicalTableReader:
+		if x.StoreType == kv.TiKV && x.ReadReqType == Cop {
+			recordReaderFeedback(sc, x, x.TablePlans)
+		}
+		return
+	case *PhysicalIndexReader:
+		recordReaderFeedback(sc, x, x.IndexPlans)
+		return
+	case *PhysicalIndexLookUpReader:
+		// The table side stops once the pushed limit is reached.
+		if x.PushedLimit == nil {
+			recordReaderFeedback(sc, x, x.IndexPlans, x.TablePlans[1:]...)
+		}
+		return
+	case *PhysicalLimit, *PhysicalTopN, *PhysicalMergeJoin, *PhysicalApply:
+		// The children may be not fully read, or be read repeatedly.
+		return
+	case *PhysicalIndexJoin:
+		collectCardinalityFeedback(sc, x.Children()[1-x.InnerChildIdx])
+		return
+	case *PhysicalIndexHashJoin:
+		collectCardinalityFeedback(sc, x.Children()[1-x.InnerChildIdx])
+		return
+	case *PhysicalIndexMergeJoin:
+		collectCardinalityFeedback(sc, x.Children()[1-x.InnerChildIdx])
+		return
+	case *PhysicalHashJoin:
+		build, probe := x.Children()[0], x.Children()[1]
+		if x.RightIsBuildSide() {
+			build, probe = probe, build
+		}
+		collectCardinalityFeedback(sc, build)
+		// The probe side may be skipped when the build side is empty.
+		if actRows, ok := getActRows(sc.RuntimeStatsColl, build.ID()); ok && actRows > 0 {
+			collectCardinalityFeedback(sc, probe)
+		}
+		return
+	}
+	for _, child := range p.Children() {
+		collectCardinalityFeedback(sc, child)
+	}
+}
+
+// recordReaderFeedback records the feedback of a reader whose cop plans are a scan with the selections. The
+// extraPlans are the table side plans of an IndexLookUpReader except the table scan.
+func recordReaderFeedback(sc *stmtctx.StatementContext, reader base.PhysicalPlan, copPlans []base.PhysicalPlan, extraPlans ...base.PhysicalPlan) {
+	var (
+		tableID int64
+		conds   []expression.Expression
+	)
+	switch scan := copPlans[0].(type) {
+	case *PhysicalTableScan:
+		tableID = scan.physicalTableID
+		conds = append(conds, scan.AccessCondition...)
+	case *PhysicalIndexScan:
+		tableID = scan.physicalTableID
+		conds = append(conds, scan.AccessCondition...)
+	default:
+		return
+	}
+	for _, p := range append(copPlans[1:], extraPlans...) {
+		sel, ok := p.(*PhysicalSelection)
+		if !ok {
+			// The row count of the reader is not decided by the predicates only, e.g. a pushed down aggregation.
+			return
+		}
+		conds = append(conds, sel.Conditions...)
+	}
+	if len(conds) == 0 {
+		return
+	}
+	actRows, ok := getActRows(sc.RuntimeStatsColl, reader.ID())
+	if !ok {
+		return
+	}
+	key := feedback.Key{TableID: tableID, Shape: cardinality.PredicateShape(conds)}
+	estRows := reader.StatsInfo().RowCount
+	// The estimation has been corrected by the learned factor, so the feedback is compared with the original one.
+	if factor, ok := sc.AppliedCardinalityCorrection(key); ok {
+		estRows /= factor
+	}
+	feedback.Record(key, estRows, float64(actRows))
+}
+
+func getActRows(coll *execdetails.RuntimeStatsColl, planID int) (int64, bool) {
+	if !coll.ExistsRootStats(planID) {
+		return 0, false
+	}
+	return coll.GetRootStats(planID).GetActRows(), true
+}
@@ -16,11 +16,13 @@ package index
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/pingcap/tidb/pkg/testkit"
 	"github.com/pingcap/tidb/pkg/testkit/testdata"
 	"github.com/pingcap/tidb/pkg/util"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNullConditionForPrefixIndex(t *testing.T) {
@@ -95,3 +97,59 @@ func TestInvisibleIndex(t *testing.T) {
 			`IndexReader_7 10000.00 root  index:IndexFullScan_6`,
 			`└─IndexFullScan_6 10000.00 cop[tikv] table:t1, index:a(a) keep order:false, stats:pseudo`))
 }
+
+func TestHypoIndexStats(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, c varchar(10))")
+	vals := make([]string, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		vals = append(vals, fmt.Sprintf("(%d, %d, 'v%d')", i, i%10, i%100))
+	}
+	tk.MustExec("insert into t values " + strings.Join(vals, ","))
+	tk.MustExec("analyze table t")
+	tk.MustExec("create index ia type hypo on t(a)")
+	tk.MustExec("create index ib_a type hypo on t(b, a)")
+	tk.MustExec("create index ic type hypo on t(c)")
+
+	// The statistics of the hypothetical indexes are derived from the statistics of the columns, the
+	// pseudo estimation of `a < 100` is 333.33.
+	tk.MustQuery("explain format='brief' select * from t where a = 10").CheckAt([]int{0, 1, 3}, [][]any{
+		{"IndexLookUp", "1.00", ""},
+		{"├─IndexRangeScan(Build)", "1.00", "table:t, index:ia(a)"},
+		{"└─TableRowIDScan(Probe)", "1.00", "table:t"},
+	})
+	tk.MustQuery("explain format='brief' select * from t use index(ia) where a < 100").CheckAt([]int{0, 1}, [][]any{
+		{"IndexLookUp", "100.00"},
+		{"├─IndexRangeScan(Build)", "100.00"},
+		{"└─TableRowIDScan(Probe)", "100.00"},
+	})
+	tk.MustQuery("explain format='brief' select * from t use index(ic) where c = 'v1'").CheckAt([]int{0, 1}, [][]any{
+		{"IndexLookUp", "10.00"},
+		{"├─IndexRangeScan(Build)", "10.00"},
+		{"└─TableRowIDScan(Probe)", "10.00"},
+	})
+	tk.MustQuery("explain format='brief' select * from t use index(ib_a) where b = 1 and a < 100").CheckAt([]int{0, 1}, [][]any{
+		{"IndexLookUp", "31.62"},
+		{"├─IndexRangeScan(Build)", "31.62"},
+		{"└─TableRowIDScan(Probe)", "31.62"},
+	})
+
+	// The hypothetical indexes are never used to execute the queries.
+	for _, row := range tk.MustQuery("explain analyze select * from t where a = 10").Rows() {
+		require.NotContains(t, row[4], "index:")
+	}
+	tk.MustGetErrMsg("select * from t use index(ia) where a = 10", "[planner:1176]Key 'ia' doesn't exist in table 't'")
+	tk.MustQuery("select * from t where a = 10").Check(testkit.Rows("10 0 v10"))
+	tk.MustExec("set tidb_enable_non_prepared_plan_cache = 1")
+	tk.MustQuery("explain format='plan_cache' select * from t where a = 10")
+	tk.MustQuery("show warnings").Check(testkit.RowsWithSep("|",
+		"Warning|1105|skip non-prepared plan-cache: hypothetical indexes are used"))
+	tk.MustQuery("select * from t where a = 10").Check(testkit.Rows("10 0 v10"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+
+	// The hypothetical indexes on the dropped columns are ignored.
+	tk.MustExec("alter table t drop column c")
+	tk.MustGetErrMsg("explain select * from t use index(ic) where a = 10", "[planner:1176]Key 'ic' doesn't exist in table 't'")
+}
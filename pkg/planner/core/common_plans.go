@@ -374,6 +374,10 @@ type Insert struct {
 
 	FKChecks   []*FKCheck
 	FKCascades []*FKCascade
+
+	// Returning is the RETURNING clause, it's evaluated on the rows written by the statement and its columns
+	// are resolved against the table schema.
+	Returning []expression.Expression
 }
 
 // MemoryUsage return the memory usage of Insert
@@ -439,6 +443,10 @@ type Update struct {
 
 	FKChecks   map[int64][]*FKCheck
 	FKCascades map[int64][]*FKCascade
+
+	// Returning is the RETURNING clause, it's evaluated on the updated rows and its columns are resolved
+	// against the schema of the select plan.
+	Returning []expression.Expression
 }
 
 // MemoryUsage return the memory usage of Update
@@ -481,6 +489,10 @@ type Delete struct {
 
 	FKChecks   map[int64][]*FKCheck
 	FKCascades map[int64][]*FKCascade
+
+	// Returning is the RETURNING clause, it's evaluated on the deleted rows and its columns are resolved
+	// against the schema of the select plan.
+	Returning []expression.Expression
 }
 
 // MemoryUsage return the memory usage of Delete
@@ -499,6 +511,48 @@ func (p *Delete) MemoryUsage() (sum int64) {
 	return
 }
 
+// Merge represents a MERGE plan. The rows of SelectPlan are the rows of the source left joined with
+// the target table, a row is matched if the target side is not null.
+type Merge struct {
+	baseSchemaProducer
+
+	SelectPlan base.PhysicalPlan
+
+	// TblColPosInfo is the position of the target table columns in the rows of SelectPlan.
+	TblColPosInfo TblColPosInfo
+
+	Clauses []*MergeClause
+}
+
+// MemoryUsage return the memory usage of Merge
+func (p *Merge) MemoryUsage() (sum int64) {
+	if p == nil {
+		return
+	}
+
+	sum = p.baseSchemaProducer.MemoryUsage() + size.SizeOfInterface + size.SizeOfSlice + p.TblColPosInfo.MemoryUsage()
+	if p.SelectPlan != nil {
+		sum += p.SelectPlan.MemoryUsage()
+	}
+	return
+}
+
+// MergeClause is a WHEN clause of the MERGE statement. The plan of its action shares SelectPlan
+// with the MERGE plan, and all its expressions are resolved against the schema of SelectPlan.
+type MergeClause struct {
+	Matched bool
+	Action  ast.MergeActionType
+	// Condition is nil if the clause has no AND condition.
+	Condition expression.Expression
+
+	// Update is the plan of WHEN MATCHED THEN UPDATE.
+	Update *Update
+	// Delete is the plan of WHEN MATCHED THEN DELETE.
+	Delete *Delete
+	// Insert is the plan of WHEN NOT MATCHED THEN INSERT, Lists has exactly one row.
+	Insert *Insert
+}
+
 // AnalyzeInfo is used to store the database name, table name and partition name of analyze task.
 type AnalyzeInfo struct {
 	DBName        string
@@ -682,7 +736,11 @@ type SelectInto struct {
 	baseSchemaProducer
 
 	TargetPlan base.Plan
-	IntoOpt    *ast.SelectIntoOption
+	// TargetNames is the output names of the target plan.
+	TargetNames types.NameSlice
+	IntoOpt     *ast.SelectIntoOption
+	// Options is the WITH options of the INTO OUTFILE clause.
+	Options []*LoadDataOpt
 	LineFieldsInfo
 }
 
@@ -378,6 +378,8 @@ func getSelectPlan(p base.Plan) base.PhysicalPlan {
 			selectPlan = x.SelectPlan
 		case *Insert:
 			selectPlan = x.SelectPlan
+		case *Merge:
+			selectPlan = x.SelectPlan
 		case *Explain:
 			selectPlan = getSelectPlan(x.TargetPlan)
 		}
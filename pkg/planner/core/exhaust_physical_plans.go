@@ -579,6 +579,50 @@ func (p *LogicalJoin) constructIndexJoin(
 	return []base.PhysicalPlan{join}
 }
 
+// attachAdaptiveHashJoin attaches a hash join which scans the whole inner table to the index joins. The executor
+// switches to it when the outer side produces more rows than tidb_adaptive_join_threshold.
+func (p *LogicalJoin) attachAdaptiveHashJoin(prop *property.PhysicalProperty, outerIdx int, wrapper *indexJoinInnerChildWrapper, indexJoins []base.PhysicalPlan) {
+	if len(indexJoins) == 0 || p.SCtx().GetSessionVars().AdaptiveJoinThreshold <= 0 {
+		return
+	}
+	// The hash join can't keep the order of the outer side.
+	if !prop.IsSortItemEmpty() || len(p.EqualConditions) == 0 || len(p.NAEQConditions) > 0 {
+		return
+	}
+	ds := wrapper.ds
+	if ds.tableInfo.GetPartitionInfo() != nil {
+		return
+	}
+	for _, child := range wrapper.zippedChildren {
+		if _, ok := child.(*LogicalAggregation); ok {
+			return
+		}
+	}
+	hasTiKVTablePath := false
+	for _, path := range ds.possibleAccessPaths {
+		if path.IsTablePath() && path.StoreType == kv.TiKV {
+			hasTiKVTablePath = true
+			break
+		}
+	}
+	if !hasTiKVTablePath {
+		return
+	}
+	innerTask := p.constructInnerFullTableScanTask(wrapper)
+	for _, plan := range indexJoins {
+		join := plan.(*PhysicalIndexJoin)
+		hashJoin := NewPhysicalHashJoin(p, 1-outerIdx, false, join.StatsInfo())
+		hashJoin.SetSchema(p.schema)
+		// The hash join shares the id of the index join, so its runtime stats are shown in the same row of
+		// EXPLAIN ANALYZE.
+		hashJoin.SetID(join.ID())
+		children := make([]base.PhysicalPlan, 2)
+		children[1-outerIdx] = innerTask.Plan()
+		hashJoin.SetChildren(children...)
+		join.AdaptiveHashJoin = hashJoin
+	}
+}
+
 func (p *LogicalJoin) constructIndexMergeJoin(
 	prop *property.PhysicalProperty,
 	outerIdx int,
@@ -922,7 +966,9 @@ func (p *LogicalJoin) buildIndexJoinInner2TableScan(
 			failpoint.Return(p.constructIndexHashJoin(prop, outerIdx, innerTask, nil, keyOff2IdxOff, path, lastColMng))
 		}
 	})
-	joins = append(joins, p.constructIndexJoin(prop, outerIdx, innerTask, ranges, keyOff2IdxOff, path, lastColMng, true)...)
+	indexJoins := p.constructIndexJoin(prop, outerIdx, innerTask, ranges, keyOff2IdxOff, path, lastColMng, true)
+	p.attachAdaptiveHashJoin(prop, outerIdx, wrapper, indexJoins)
+	joins = append(joins, indexJoins...)
 	// We can reuse the `innerTask` here since index nested loop hash join
 	// do not need the inner child to promise the order.
 	joins = append(joins, p.constructIndexHashJoin(prop, outerIdx, innerTask, ranges, keyOff2IdxOff, path, lastColMng)...)
@@ -973,7 +1019,9 @@ func (p *LogicalJoin) buildIndexJoinInner2IndexScan(
 		}
 	})
 	if innerTask != nil {
-		joins = append(joins, p.constructIndexJoin(prop, outerIdx, innerTask, helper.chosenRanges, keyOff2IdxOff, helper.chosenPath, helper.lastColManager, true)...)
+		indexJoins := p.constructIndexJoin(prop, outerIdx, innerTask, helper.chosenRanges, keyOff2IdxOff, helper.chosenPath, helper.lastColManager, true)
+		p.attachAdaptiveHashJoin(prop, outerIdx, wrapper, indexJoins)
+		joins = append(joins, indexJoins...)
 		// We can reuse the `innerTask` here since index nested loop hash join
 		// do not need the inner child to promise the order.
 		joins = append(joins, p.constructIndexHashJoin(prop, outerIdx, innerTask, helper.chosenRanges, keyOff2IdxOff, helper.chosenPath, helper.lastColManager)...)
@@ -1114,6 +1162,51 @@ func (p *LogicalJoin) constructInnerTableScanTask(
 	return p.constructIndexJoinInnerSideTask(copTask, ds, nil, wrapper)
 }
 
+// constructInnerFullTableScanTask constructs the inner plan which scans the whole table for the hash join that an
+// adaptive index join may switch to. All the pushed down conditions are kept as filters, so the plan doesn't depend
+// on the ranges which might be rebuilt by the plan cache.
+func (p *LogicalJoin) constructInnerFullTableScanTask(wrapper *indexJoinInnerChildWrapper) base.Task {
+	ds := wrapper.ds
+	ranges := ranger.FullIntRange(false)
+	if ds.tableInfo.IsCommonHandle {
+		ranges = ranger.FullRange()
+	} else if pkColInfo := ds.tableInfo.GetPkColInfo(); ds.tableInfo.PKIsHandle && pkColInfo != nil {
+		ranges = ranger.FullIntRange(mysql.HasUnsignedFlag(pkColInfo.GetFlag()))
+	}
+	ts := PhysicalTableScan{
+		Table:           ds.tableInfo,
+		Columns:         ds.Columns,
+		TableAsName:     ds.TableAsName,
+		DBName:          ds.DBName,
+		filterCondition: ds.pushedDownConds,
+		Ranges:          ranges,
+		physicalTableID: ds.physicalTableID,
+		isPartition:     ds.partitionDefIdx != nil,
+		tblCols:         ds.TblCols,
+		tblColHists:     ds.TblColHists,
+	}.Init(ds.SCtx(), ds.QueryBlockOffset())
+	ts.SetSchema(ds.schema.Clone())
+	ts.SetStats(ds.tableStats)
+	usedStats := p.SCtx().GetSessionVars().StmtCtx.GetUsedStatsInfo(false)
+	if usedStats != nil && usedStats.GetUsedInfo(ts.physicalTableID) != nil {
+		ts.usedStatsInfo = usedStats.GetUsedInfo(ts.physicalTableID)
+	}
+	copTask := &CopTask{
+		tablePlan:         ts,
+		indexPlanFinished: true,
+		tblColHists:       ds.TblColHists,
+	}
+	copTask.physPlanPartInfo = PhysPlanPartInfo{
+		PruningConds:   ds.allConds,
+		PartitionNames: ds.partitionNames,
+		Columns:        ds.TblCols,
+		ColumnNames:    ds.names,
+	}
+	ts.PlanPartInfo = copTask.physPlanPartInfo
+	ts.addPushedDownSelection(copTask, ds.StatsInfo())
+	return p.constructIndexJoinInnerSideTask(copTask, ds, nil, wrapper)
+}
+
 func (p *LogicalJoin) constructInnerByZippedChildren(zippedChildren []base.LogicalPlan, child base.PhysicalPlan) base.PhysicalPlan {
 	for i := len(zippedChildren) - 1; i >= 0; i-- {
 		switch x := zippedChildren[i].(type) {
@@ -2750,6 +2843,20 @@ func (p *LogicalExpand) ExhaustPhysicalPlans(prop *property.PhysicalProperty) ([
 	return nil, true, nil
 }
 
+// ExhaustPhysicalPlans implements LogicalPlan interface.
+// Unpivot can only be executed in TiDB, and the order of the rows isn't kept.
+func (p *LogicalUnpivot) ExhaustPhysicalPlans(prop *property.PhysicalProperty) ([]base.PhysicalPlan, bool, error) {
+	if !prop.IsSortItemEmpty() || prop.IsFlashProp() {
+		p.SCtx().GetSessionVars().RaiseWarningWhenMPPEnforced("MPP mode may be blocked because operator `Unpivot` is not supported now.")
+		return nil, true, nil
+	}
+	unpivot := PhysicalUnpivot{
+		LevelExprs: p.LevelExprs,
+	}.Init(p.SCtx(), p.StatsInfo().ScaleByExpectCnt(prop.ExpectedCnt), p.QueryBlockOffset(), &property.PhysicalProperty{ExpectedCnt: math.MaxFloat64, CTEProducerStatus: prop.CTEProducerStatus})
+	unpivot.SetSchema(p.Schema())
+	return []base.PhysicalPlan{unpivot}, true, nil
+}
+
 // ExhaustPhysicalPlans implements LogicalPlan interface.
 func (p *LogicalProjection) ExhaustPhysicalPlans(prop *property.PhysicalProperty) ([]base.PhysicalPlan, bool, error) {
 	newProp, ok := p.TryToGetChildProp(prop)
@@ -2814,9 +2921,17 @@ func pushLimitOrTopNForcibly(p base.LogicalPlan) bool {
 }
 
 func (lt *LogicalTopN) getPhysTopN(prop *property.PhysicalProperty) []base.PhysicalPlan {
-	allTaskTypes := []property.TaskType{property.CopSingleReadTaskType, property.CopMultiReadTaskType}
-	if !pushLimitOrTopNForcibly(lt) {
+	var allTaskTypes []property.TaskType
+	if lt.MppOnly {
+		// TiKV can't compute the partitioned TopN, so no cop task is tried. The root TopN is kept to make sure
+		// a plan can be found when MPP isn't chosen, it's skipped in Attach2Task since the window function
+		// takes care of the filter.
 		allTaskTypes = append(allTaskTypes, property.RootTaskType)
+	} else {
+		allTaskTypes = append(allTaskTypes, property.CopSingleReadTaskType, property.CopMultiReadTaskType)
+		if !pushLimitOrTopNForcibly(lt) {
+			allTaskTypes = append(allTaskTypes, property.RootTaskType)
+		}
 	}
 	if lt.SCtx().GetSessionVars().IsMPPAllowed() {
 		allTaskTypes = append(allTaskTypes, property.MppTaskType)
@@ -2837,7 +2952,7 @@ func (lt *LogicalTopN) getPhysTopN(prop *property.PhysicalProperty) []base.Physi
 
 func (lt *LogicalTopN) getPhysLimits(prop *property.PhysicalProperty) []base.PhysicalPlan {
 	p, canPass := GetPropByOrderByItems(lt.ByItems)
-	if !canPass {
+	if !canPass || lt.MppOnly {
 		return nil
 	}
 
@@ -3157,8 +3272,14 @@ func canPushToCopImpl(lp base.LogicalPlan, storeTp kv.StoreType, considerDual bo
 				return false
 			}
 			ret = ret && c.CanPushToCop(storeTp)
+		case *LogicalTopN:
+			// The partitioned TopN derived from the window function is computed by TiFlash completely.
+			if storeTp != kv.TiFlash || len(c.PartitionBy) == 0 {
+				return false
+			}
+			ret = ret && canPushToCopImpl(&c.BaseLogicalPlan, storeTp, considerDual)
 		// These operators can be partially push down to TiFlash, so we don't raise warning for them.
-		case *LogicalLimit, *LogicalTopN:
+		case *LogicalLimit:
 			return false
 		case *LogicalSequence:
 			return storeTp == kv.TiFlash
@@ -233,6 +233,22 @@ func (p *PhysicalTableScan) OperatorInfo(normalized bool) string {
 	if p.Desc {
 		buffer.WriteString(", desc")
 	}
+	if p.AnnQuery != nil {
+		buffer.WriteString(", annIndex:")
+		buffer.WriteString(p.AnnQuery.DistanceMetric.String())
+		buffer.WriteString("(")
+		buffer.WriteString(p.AnnQuery.ColumnName)
+		buffer.WriteString("..")
+		refVec, _, err := types.ZeroCopyDeserializeVectorFloat32(p.AnnQuery.RefVecF32)
+		if normalized || err != nil {
+			buffer.WriteString("?")
+		} else {
+			buffer.WriteString(refVec.String())
+		}
+		buffer.WriteString(", limit:")
+		buffer.WriteString(strconv.FormatUint(uint64(p.AnnQuery.TopK), 10))
+		buffer.WriteString(")")
+	}
 	if !normalized {
 		if p.usedStatsInfo != nil {
 			str := p.usedStatsInfo.FormatForExplain()
@@ -421,6 +437,26 @@ func (p *PhysicalTableDual) ExplainInfo() string {
 	return str.String()
 }
 
+// ExplainInfo implements Plan interface.
+func (p *PhysicalJSONTable) ExplainInfo() string {
+	return explainJSONTable(p.SCtx().GetExprCtx().GetEvalCtx(), p.DocExpr, p.Root)
+}
+
+// ExplainInfo implements Plan interface.
+func (p *PhysicalUnpivot) ExplainInfo() string {
+	var str strings.Builder
+	str.WriteString("level-projection:")
+	for i, levelExprs := range p.LevelExprs {
+		if i > 0 {
+			str.WriteString(",")
+		}
+		str.WriteString("[")
+		str.WriteString(expression.ExplainExpressionList(levelExprs, p.schema))
+		str.WriteString("]")
+	}
+	return str.String()
+}
+
 // ExplainInfo implements Plan interface.
 func (p *PhysicalSort) ExplainInfo() string {
 	buffer := bytes.NewBufferString("")
@@ -939,6 +975,24 @@ func (p *LogicalTableDual) ExplainInfo() string {
 	return str.String()
 }
 
+// ExplainInfo implements Plan interface.
+func (p *LogicalJSONTable) ExplainInfo() string {
+	return explainJSONTable(p.SCtx().GetExprCtx().GetEvalCtx(), p.DocExpr, p.Root)
+}
+
+func explainJSONTable(ctx expression.EvalContext, docExpr expression.Expression, root *JSONTablePath) string {
+	var str strings.Builder
+	str.WriteString("doc:")
+	str.WriteString(docExpr.ExplainInfo(ctx))
+	str.WriteString(", path:")
+	str.WriteString(root.Path.String())
+	if len(root.Nested) > 0 {
+		str.WriteString(", nested paths:")
+		str.WriteString(strconv.Itoa(len(root.Nested)))
+	}
+	return str.String()
+}
+
 // ExplainInfo implements Plan interface.
 func (ds *DataSource) ExplainInfo() string {
 	buffer := bytes.NewBufferString("")
@@ -1519,6 +1519,10 @@ func (er *expressionRewriter) Leave(originInNode ast.Node) (retNode ast.Node, ok
 		withPlanCtx(func(planCtx *exprRewriterPlanCtx) {
 			er.positionToScalarFunc(planCtx, v)
 		})
+	case *ast.MatchAgainst:
+		withPlanCtx(func(planCtx *exprRewriterPlanCtx) {
+			er.matchAgainstToScalarFunc(planCtx, v)
+		})
 	case *ast.IsNullExpr:
 		er.isNullToExpression(v)
 	case *ast.IsTruthExpr:
@@ -1842,6 +1846,74 @@ func (er *expressionRewriter) isTrueToScalarFunc(v *ast.IsTruthExpr) {
 	er.ctxStackAppend(function, types.EmptyName)
 }
 
+// matchAgainstToScalarFunc rewrites MATCH ... AGAINST to the fts_match_against function. The columns
+// must be the columns of a FULLTEXT index, whose parser is used to split the texts and the query.
+func (er *expressionRewriter) matchAgainstToScalarFunc(planCtx *exprRewriterPlanCtx, v *ast.MatchAgainst) {
+	if v.Modifier.WithQueryExpansion() {
+		er.err = plannererrors.ErrNotSupportedYet.GenWithStackByArgs("WITH QUERY EXPANSION")
+		return
+	}
+	stkLen := len(er.ctxStack)
+	against := er.ctxStack[stkLen-1]
+	if len(expression.ExtractColumns(against)) > 0 {
+		er.err = plannererrors.ErrWrongArguments.GenWithStackByArgs("AGAINST")
+		return
+	}
+	cols := er.ctxStack[stkLen-1-len(v.ColumnNames) : stkLen-1]
+	names := er.ctxNameStk[stkLen-1-len(v.ColumnNames) : stkLen-1]
+	idxInfo := findFulltextIndex(planCtx.builder.is, cols, names)
+	if idxInfo == nil {
+		er.err = plannererrors.ErrFtMatchingKeyNotFound
+		return
+	}
+	args := make([]expression.Expression, 0, len(cols)+3)
+	args = append(args,
+		against,
+		expression.DatumToConstant(types.NewIntDatum(int64(v.Modifier)), mysql.TypeLonglong, 0),
+		expression.DatumToConstant(types.NewStringDatum(idxInfo.ParserName.L), mysql.TypeVarString, 0))
+	args = append(args, cols...)
+	function, err := er.newFunction(ast.FTSMatchAgainst, types.NewFieldType(mysql.TypeDouble), args...)
+	if err != nil {
+		er.err = err
+		return
+	}
+	er.ctxStackPop(len(cols) + 1)
+	er.ctxStackAppend(function, types.EmptyName)
+}
+
+// findFulltextIndex finds the public FULLTEXT index whose columns are exactly the columns.
+func findFulltextIndex(is infoschema.InfoSchema, cols []expression.Expression, names []*types.FieldName) *model.IndexInfo {
+	colIDs := make(map[int64]struct{}, len(cols))
+	for i, expr := range cols {
+		col, ok := expr.(*expression.Column)
+		if !ok || names[i].OrigTblName.L != names[0].OrigTblName.L || names[i].DBName.L != names[0].DBName.L {
+			return nil
+		}
+		colIDs[col.ID] = struct{}{}
+	}
+	tbl, err := is.TableByName(names[0].DBName, names[0].OrigTblName)
+	if err != nil {
+		return nil
+	}
+	tblInfo := tbl.Meta()
+	for _, idx := range tblInfo.Indices {
+		if idx.Tp != model.IndexTypeFulltext || idx.State != model.StatePublic || len(idx.Columns) != len(colIDs) {
+			continue
+		}
+		matched := true
+		for _, idxCol := range idx.Columns {
+			if _, ok := colIDs[tblInfo.Columns[idxCol.Offset].ID]; !ok {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return idx
+		}
+	}
+	return nil
+}
+
 // inToExpression converts in expression to a scalar function. The argument lLen means the length of in list.
 // The argument not means if the expression is not in. The tp stands for the expression type, which is always bool.
 // a in (b, c, d) will be rewritten as `(a = b) or (a = c) or (a = d)`.
@@ -2341,6 +2413,10 @@ func (er *expressionRewriter) funcCallToExpression(v *ast.FuncCallExpr) {
 		return
 	}
 
+	if er.planCtx != nil && isStoredFunctionCall(v) && er.storedFunctionToExpression(er.planCtx, v) {
+		return
+	}
+
 	if er.rewriteFuncCall(v) {
 		return
 	}
@@ -136,6 +136,24 @@ func (p *LogicalShowDDLJobs) FindBestTask(prop *property.PhysicalProperty, planC
 	return rt, 1, nil
 }
 
+// FindBestTask implements the LogicalPlan interface.
+func (p *LogicalJSONTable) FindBestTask(prop *property.PhysicalProperty, planCounter *base.PlanCounterTp, opt *optimizetrace.PhysicalOptimizeOp) (base.Task, int64, error) {
+	if !prop.IsSortItemEmpty() || planCounter.Empty() {
+		return base.InvalidTask, 0, nil
+	}
+	jt := PhysicalJSONTable{
+		DocExpr: p.DocExpr,
+		Root:    p.Root,
+		Name:    p.Name,
+	}.Init(p.SCtx(), p.StatsInfo(), p.QueryBlockOffset())
+	jt.SetSchema(p.schema)
+	planCounter.Dec(1)
+	utilfuncp.AppendCandidate4PhysicalOptimizeOp(opt, p, jt, prop)
+	rt := &RootTask{}
+	rt.SetPlan(jt)
+	return rt, 1, nil
+}
+
 // rebuildChildTasks rebuilds the childTasks to make the clock_th combination.
 func rebuildChildTasks(p *logicalop.BaseLogicalPlan, childTasks *[]base.Task, pp base.PhysicalPlan, childCnts []int64, planCounter int64, ts uint64, opt *optimizetrace.PhysicalOptimizeOp) error {
 	// The taskMap of children nodes should be rolled back first.
@@ -1967,6 +1985,10 @@ func (ds *DataSource) convertToIndexScan(prop *property.PhysicalProperty,
 		// TODO: make IndexReader support accessing MVIndex directly.
 		return base.InvalidTask, nil
 	}
+	if isFulltextIndexPath(candidate.path) {
+		// A FULLTEXT index can only be accessed by the IndexMerge built from MATCH ... AGAINST.
+		return base.InvalidTask, nil
+	}
 	if !candidate.path.IsSingleScan {
 		// If it's parent requires single read task, return max cost.
 		if prop.TaskTp == property.CopSingleReadTaskType {
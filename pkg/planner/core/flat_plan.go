@@ -71,7 +71,7 @@ func (e FlatPlanTree) GetSelectPlan() (FlatPlanTree, int) {
 	hasDML := false
 	for i, op := range e {
 		switch op.Origin.(type) {
-		case *Insert, *Delete, *Update:
+		case *Insert, *Delete, *Update, *Merge:
 			hasDML = true
 		default:
 			if hasDML {
@@ -412,6 +412,14 @@ func (f *FlatPhysicalPlan) flattenRecursively(p base.Plan, info *operatorCtx, ta
 			childIdxs = append(childIdxs, childIdx)
 		}
 		target, childIdxs = f.flattenForeignKeyChecksAndCascadesMap(childCtx, target, childIdxs, plan.FKChecks, plan.FKCascades)
+	case *Merge:
+		if plan.SelectPlan != nil {
+			childCtx.isRoot = true
+			childCtx.label = Empty
+			childCtx.isLastChild = true
+			target, childIdx = f.flattenRecursively(plan.SelectPlan, childCtx, target)
+			childIdxs = append(childIdxs, childIdx)
+		}
 	case *Execute:
 		f.InExecute = true
 		if plan.Plan != nil {
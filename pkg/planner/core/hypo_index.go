@@ -0,0 +1,90 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"slices"
+
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/statistics"
+)
+
+// getHypoIndexes returns the hypothetical indexes of the table, which are created by
+// `CREATE INDEX ... TYPE HYPO` in the session. They overlay the indexes of the table only in EXPLAIN,
+// and are never used by the plans to execute.
+//
+// The indexes are copied with the IDs following the max index ID of the table, so they don't conflict
+// with the real indexes and can have their own statistics, see `DataSource.addHypoIndexStats`.
+func getHypoIndexes(ctx base.PlanContext, dbName model.CIStr, tblInfo *model.TableInfo) []*model.IndexInfo {
+	vars := ctx.GetSessionVars()
+	// EXPLAIN ANALYZE executes the plan.
+	if !vars.StmtCtx.InExplainStmt || vars.StmtCtx.InExplainAnalyzeStmt {
+		return nil
+	}
+	hypoIndexes := vars.HypoIndexes[dbName.L][tblInfo.Name.L]
+	if len(hypoIndexes) == 0 {
+		return nil
+	}
+	// Sort the indexes by names, so the IDs and the plans are stable.
+	names := make([]string, 0, len(hypoIndexes))
+	for name := range hypoIndexes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	indexes := make([]*model.IndexInfo, 0, len(hypoIndexes))
+	for _, name := range names {
+		idx := hypoIndexes[name].Clone()
+		// The columns may be changed after the hypothetical index is created.
+		valid := true
+		for _, idxCol := range idx.Columns {
+			col := model.FindColumnInfo(tblInfo.Columns, idxCol.Name.L)
+			if col == nil || col.State != model.StatePublic {
+				valid = false
+				break
+			}
+			idxCol.Offset = col.Offset
+		}
+		if !valid {
+			continue
+		}
+		idx.ID = tblInfo.MaxIndexID + int64(len(indexes)) + 1
+		indexes = append(indexes, idx)
+	}
+	if len(indexes) > 0 {
+		vars.StmtCtx.SetSkipPlanCache("hypothetical indexes are used")
+	}
+	return indexes
+}
+
+// addHypoIndexStats adds the synthetic statistics of the hypothetical indexes in the access paths.
+func (ds *DataSource) addHypoIndexStats(coll *statistics.HistColl) {
+	for _, path := range ds.possibleAccessPaths {
+		if path.Index == nil || path.Index.Tp != model.IndexTypeHypo {
+			continue
+		}
+		colIDs := make([]int64, 0, len(path.Index.Columns))
+		for _, idxCol := range path.Index.Columns {
+			id := ds.tableInfo.Columns[idxCol.Offset].ID
+			i := slices.IndexFunc(ds.TblCols, func(col *expression.Column) bool { return col.ID == id })
+			if i < 0 {
+				break
+			}
+			colIDs = append(colIDs, ds.TblCols[i].UniqueID)
+		}
+		coll.AddHypoIndex(path.Index, colIDs)
+	}
+}
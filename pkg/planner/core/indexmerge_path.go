@@ -37,6 +37,7 @@ import (
 	"github.com/pingcap/tidb/pkg/statistics"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/fulltext"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/ranger"
 	"go.uber.org/zap"
@@ -44,7 +45,7 @@ import (
 )
 
 // generateIndexMergePath generates IndexMerge AccessPaths on this DataSource.
-func (ds *DataSource) generateIndexMergePath() error {
+func (ds *DataSource) generateIndexMergePath(fulltextPaths []*util.AccessPath) error {
 	if ds.SCtx().GetSessionVars().StmtCtx.EnableOptimizerDebugTrace {
 		debugtrace.EnterContextCommon(ds.SCtx())
 		defer debugtrace.LeaveContextCommon(ds.SCtx())
@@ -87,6 +88,9 @@ func (ds *DataSource) generateIndexMergePath() error {
 	if err := ds.generateIndexMerge4MVIndex(regularPathCount, indexMergeConds); err != nil {
 		return err
 	}
+	if err := ds.generateIndexMerge4FulltextIndex(fulltextPaths, indexMergeConds); err != nil {
+		return err
+	}
 	oldIndexMergeCount := len(ds.possibleAccessPaths)
 	if err := ds.generateIndexMerge4ComposedIndex(regularPathCount, indexMergeConds); err != nil {
 		return err
@@ -1631,3 +1635,114 @@ func unwrapJSONCast(expr expression.Expression) (expression.Expression, bool) {
 func isMVIndexPath(path *util.AccessPath) bool {
 	return !path.IsTablePath() && path.Index != nil && path.Index.MVIndex
 }
+
+func isFulltextIndexPath(path *util.AccessPath) bool {
+	return !path.IsTablePath() && path.Index != nil && path.Index.Tp == model.IndexTypeFulltext
+}
+
+// removeFulltextIndexPaths removes the FULLTEXT index paths from the possible access paths and returns them.
+// A FULLTEXT index has an entry for every token of the texts, so it can't be used as a regular index path.
+func (ds *DataSource) removeFulltextIndexPaths() []*util.AccessPath {
+	var fulltextPaths []*util.AccessPath
+	regularPaths := ds.possibleAccessPaths[:0]
+	for _, path := range ds.possibleAccessPaths {
+		if isFulltextIndexPath(path) {
+			fulltextPaths = append(fulltextPaths, path)
+			continue
+		}
+		regularPaths = append(regularPaths, path)
+	}
+	ds.possibleAccessPaths = regularPaths
+	return fulltextPaths
+}
+
+// generateIndexMerge4FulltextIndex generates the IndexMerge paths for the MATCH ... AGAINST in the filters.
+// Every partial path reads the posting list of a token of the query from the FULLTEXT index, the union or
+// the intersection of the posting lists contains all the rows which may match the query. The MATCH ... AGAINST
+// itself is kept as a table filter.
+func (ds *DataSource) generateIndexMerge4FulltextIndex(fulltextPaths []*util.AccessPath, filters []expression.Expression) error {
+	for _, path := range fulltextPaths {
+		if !ds.isInIndexMergeHints(path.Index.Name.L) {
+			continue
+		}
+		idxCols, ok := PrepareIdxColsAndUnwrapArrayType(ds.table.Meta(), path.Index, ds.TblCols, false)
+		if !ok {
+			continue
+		}
+		for _, filter := range filters {
+			tokens, isIntersection, ok := ds.fulltextIndexTokens(filter, path.Index, idxCols)
+			if !ok {
+				continue
+			}
+			partialPaths := make([]*util.AccessPath, 0, len(tokens))
+			for _, token := range tokens {
+				tokenExpr := &expression.Constant{
+					Value:   types.NewCollationStringDatum(token, idxCols[0].GetStaticType().GetCollate()),
+					RetType: idxCols[0].GetStaticType().Clone(),
+				}
+				accessFilter := expression.NewFunctionInternal(ds.SCtx().GetExprCtx(), ast.EQ, types.NewFieldType(mysql.TypeTiny), idxCols[0], tokenExpr)
+				partialPath, ok, err := buildPartialPath4MVIndex(ds.SCtx(), []expression.Expression{accessFilter}, idxCols, path.Index, ds.tableStats.HistColl)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					partialPaths = nil
+					break
+				}
+				partialPaths = append(partialPaths, partialPath)
+			}
+			if len(partialPaths) == 0 {
+				continue
+			}
+			indexMergePath := &util.AccessPath{PartialIndexPaths: partialPaths, IndexMergeIsIntersection: isIntersection}
+			indexMergePath.TableFilters = filters
+			indexMergePath.CountAfterAccess = float64(ds.tableStats.HistColl.RealtimeCount) *
+				cardinality.CalcTotalSelectivityForMVIdxPath(ds.tableStats.HistColl, partialPaths, isIntersection)
+			ds.possibleAccessPaths = append(ds.possibleAccessPaths, indexMergePath)
+		}
+	}
+	return nil
+}
+
+// fulltextIndexTokens returns the tokens to read from the FULLTEXT index if the filter is a MATCH ... AGAINST
+// on the columns of the index. If isIntersection is true, a matched row contains all the tokens, otherwise it
+// contains at least one of them.
+func (ds *DataSource) fulltextIndexTokens(filter expression.Expression, idxInfo *model.IndexInfo, idxCols []*expression.Column) (tokens []string, isIntersection bool, ok bool) {
+	sf, ok := filter.(*expression.ScalarFunction)
+	if !ok || sf.FuncName.L != ast.FTSMatchAgainst {
+		return nil, false, false
+	}
+	args := sf.GetArgs()
+	if len(args)-3 != len(idxCols) {
+		return nil, false, false
+	}
+	for _, arg := range args[3:] {
+		col, ok := arg.(*expression.Column)
+		if !ok || !slices.ContainsFunc(idxCols, func(idxCol *expression.Column) bool { return idxCol.ID == col.ID }) {
+			return nil, false, false
+		}
+	}
+	against, modifier := args[0], args[1]
+	exprCtx := ds.SCtx().GetExprCtx()
+	evalCtx := exprCtx.GetEvalCtx()
+	if expression.MaybeOverOptimized4PlanCache(exprCtx, []expression.Expression{against}) {
+		// skip plan cache and try to generate the best plan in this case.
+		exprCtx.SetSkipPlanCache(ast.FTSMatchAgainst + " function with immutable parameters can affect index selection")
+	}
+	if !expression.IsImmutableFunc(against) {
+		return nil, false, false
+	}
+	query, isNull, err := against.EvalString(evalCtx, chunk.Row{})
+	if isNull || err != nil {
+		return nil, false, false
+	}
+	mode, _, err := modifier.EvalInt(evalCtx, chunk.Row{})
+	if err != nil {
+		return nil, false, false
+	}
+	if ast.FulltextSearchModifier(mode).IsBooleanMode() {
+		return fulltext.IndexTokens(fulltext.ParseBooleanQuery(idxInfo.ParserName.L, query))
+	}
+	tokens = fulltext.DistinctTokens(idxInfo.ParserName.L, query)
+	return tokens, false, len(tokens) > 0
+}
@@ -191,6 +191,33 @@ func (p PhysicalTableDual) Init(ctx base.PlanContext, stats *property.StatsInfo,
 	return &p
 }
 
+// Init initializes LogicalJSONTable.
+func (p LogicalJSONTable) Init(ctx base.PlanContext, offset int) *LogicalJSONTable {
+	p.BaseLogicalPlan = logicalop.NewBaseLogicalPlan(ctx, plancodec.TypeJSONTable, &p, offset)
+	return &p
+}
+
+// Init initializes PhysicalJSONTable.
+func (p PhysicalJSONTable) Init(ctx base.PlanContext, stats *property.StatsInfo, offset int) *PhysicalJSONTable {
+	p.basePhysicalPlan = newBasePhysicalPlan(ctx, plancodec.TypeJSONTable, &p, offset)
+	p.SetStats(stats)
+	return &p
+}
+
+// Init initializes LogicalUnpivot.
+func (p LogicalUnpivot) Init(ctx base.PlanContext, offset int) *LogicalUnpivot {
+	p.BaseLogicalPlan = logicalop.NewBaseLogicalPlan(ctx, plancodec.TypeUnpivot, &p, offset)
+	return &p
+}
+
+// Init initializes PhysicalUnpivot.
+func (p PhysicalUnpivot) Init(ctx base.PlanContext, stats *property.StatsInfo, offset int, props ...*property.PhysicalProperty) *PhysicalUnpivot {
+	p.basePhysicalPlan = newBasePhysicalPlan(ctx, plancodec.TypeUnpivot, &p, offset)
+	p.childrenReqProps = props
+	p.SetStats(stats)
+	return &p
+}
+
 // Init initializes LogicalMaxOneRow.
 func (p LogicalMaxOneRow) Init(ctx base.PlanContext, offset int) *LogicalMaxOneRow {
 	p.BaseLogicalPlan = logicalop.NewBaseLogicalPlan(ctx, plancodec.TypeMaxOneRow, &p, offset)
@@ -247,6 +274,12 @@ func (p Delete) Init(ctx base.PlanContext) *Delete {
 	return &p
 }
 
+// Init initializes Merge.
+func (p Merge) Init(ctx base.PlanContext) *Merge {
+	p.Plan = baseimpl.NewBasePlan(ctx, plancodec.TypeMerge, 0)
+	return &p
+}
+
 // Init initializes Insert.
 func (p Insert) Init(ctx base.PlanContext) *Insert {
 	p.Plan = baseimpl.NewBasePlan(ctx, plancodec.TypeInsert, 0)
@@ -19,11 +19,13 @@ import (
 	"fmt"
 	"math"
 	"math/bits"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
@@ -448,6 +450,12 @@ func (b *PlanBuilder) buildResultSetNode(ctx context.Context, node ast.ResultSet
 		case *ast.TableName:
 			p, err = b.buildDataSource(ctx, v, &x.AsName)
 			isTableName = true
+		case *ast.JSONTable:
+			p, err = b.buildJSONTable(ctx, v, x.AsName)
+		case *ast.PivotTable:
+			p, err = b.buildPivotTable(ctx, v)
+		case *ast.UnpivotTable:
+			p, err = b.buildUnpivotTable(ctx, v)
 		default:
 			err = plannererrors.ErrUnsupportedType.GenWithStackByArgs(v)
 		}
@@ -945,7 +953,20 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (base.L
 		return nil, err
 	}
 
+	lateral := isLateralSource(joinNode.Right)
+	if lateral {
+		// The lateral source can refer to the columns of the left side.
+		b.outerSchemas = append(b.outerSchemas, leftPlan.Schema().Clone())
+		b.outerNames = append(b.outerNames, leftPlan.OutputNames())
+		b.outerBlockExpand = append(b.outerBlockExpand, b.currentBlockExpand)
+	}
 	rightPlan, err := b.buildResultSetNode(ctx, joinNode.Right, false)
+	if lateral {
+		b.outerSchemas = b.outerSchemas[0 : len(b.outerSchemas)-1]
+		b.outerNames = b.outerNames[0 : len(b.outerNames)-1]
+		b.currentBlockExpand = b.outerBlockExpand[len(b.outerBlockExpand)-1]
+		b.outerBlockExpand = b.outerBlockExpand[0 : len(b.outerBlockExpand)-1]
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -982,6 +1003,21 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (base.L
 		joinPlan.JoinType = InnerJoin
 	}
 
+	var resultPlan base.LogicalPlan = joinPlan
+	if lateral && len(coreusage.ExtractCorColumnsBySchema4LogicalPlan(rightPlan, leftPlan.Schema())) > 0 {
+		if joinNode.Tp == ast.RightJoin {
+			return nil, plannererrors.ErrTFForbiddenReference.GenWithStackByArgs(rightPlan.OutputNames()[0].TblName.O)
+		}
+		// The right side is evaluated once per row of the left side.
+		b.optFlag = b.optFlag | flagBuildKeyInfo | flagDecorrelate
+		setIsInApplyForCTE(rightPlan, joinPlan.Schema())
+		ap := &LogicalApply{LogicalJoin: *joinPlan}
+		ap.SetTP(plancodec.TypeApply)
+		ap.SetSelf(ap)
+		joinPlan = &ap.LogicalJoin
+		resultPlan = ap
+	}
+
 	// Merge sub-plan's fullSchema into this join plan.
 	// Please read the comment of LogicalJoin.fullSchema for the details.
 	var (
@@ -1047,11 +1083,11 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (base.L
 		}
 	} else if joinNode.On != nil {
 		b.curClause = onClause
-		onExpr, newPlan, err := b.rewrite(ctx, joinNode.On.Expr, joinPlan, nil, false)
+		onExpr, newPlan, err := b.rewrite(ctx, joinNode.On.Expr, resultPlan, nil, false)
 		if err != nil {
 			return nil, err
 		}
-		if newPlan != joinPlan {
+		if newPlan != resultPlan {
 			return nil, errors.New("ON condition doesn't support subqueries yet")
 		}
 		onCondition := expression.SplitCNFItems(onExpr)
@@ -1059,7 +1095,7 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (base.L
 		// possible decorrelate optimizations. The ON clause is actually treated as a WHERE clause now.
 		if joinPlan.JoinType == InnerJoin {
 			sel := LogicalSelection{Conditions: onCondition}.Init(b.ctx, b.getSelectOffset())
-			sel.SetChildren(joinPlan)
+			sel.SetChildren(resultPlan)
 			return sel, nil
 		}
 		joinPlan.AttachOnConds(onCondition)
@@ -1069,7 +1105,375 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (base.L
 		joinPlan.cartesianJoin = true
 	}
 
-	return joinPlan, nil
+	return resultPlan, nil
+}
+
+// isLateralSource checks whether the table source can refer to the tables preceding it in
+// the FROM clause, which means it has to be built as the inner side of an Apply.
+// Besides the LATERAL derived tables, the table functions are always lateral.
+func isLateralSource(node ast.ResultSetNode) bool {
+	ts, ok := node.(*ast.TableSource)
+	if !ok {
+		return false
+	}
+	if ts.Lateral {
+		return true
+	}
+	_, ok = ts.Source.(*ast.JSONTable)
+	return ok
+}
+
+// buildJSONTable builds the JSON_TABLE table function. The document expression is rewritten
+// against an empty plan, so the columns it refers to are all resolved from b.outerSchemas
+// as correlated columns.
+func (b *PlanBuilder) buildJSONTable(ctx context.Context, jt *ast.JSONTable, asName model.CIStr) (base.LogicalPlan, error) {
+	if asName.L == "" {
+		return nil, plannererrors.ErrTFMustHaveAlias
+	}
+	dual := LogicalTableDual{RowCount: 1}.Init(b.ctx, b.getSelectOffset())
+	dual.SetSchema(expression.NewSchema())
+	b.curClause = tableFunctionClause
+	docExpr, np, err := b.rewrite(ctx, jt.Expr, dual, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	if np != dual {
+		return nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs("subqueries in the arguments of JSON_TABLE")
+	}
+	if docExpr.GetType(b.ctx.GetExprCtx().GetEvalCtx()).GetType() != mysql.TypeJSON {
+		docExpr = expression.BuildCastFunction(b.ctx.GetExprCtx(), docExpr, types.NewFieldType(mysql.TypeJSON))
+	}
+
+	p := LogicalJSONTable{DocExpr: docExpr, Name: asName}.Init(b.ctx, b.getSelectOffset())
+	schema := expression.NewSchema()
+	names := make(types.NameSlice, 0, len(jt.Columns))
+	p.Root, err = b.buildJSONTablePath(jt.Path, jt.Columns, asName, schema, &names)
+	if err != nil {
+		return nil, err
+	}
+	p.SetSchema(schema)
+	p.names = names
+	b.handleHelper.pushMap(nil)
+	return p, nil
+}
+
+func (b *PlanBuilder) buildJSONTablePath(path string, cols []*ast.JSONTableColumn, tblName model.CIStr,
+	schema *expression.Schema, names *types.NameSlice) (*JSONTablePath, error) {
+	pathExpr, err := types.ParseJSONPathExpr(path)
+	if err != nil {
+		return nil, err
+	}
+	result := &JSONTablePath{Path: pathExpr}
+	for _, col := range cols {
+		if col.Tp == ast.JSONTableColumnNested {
+			nested, err := b.buildJSONTablePath(col.Path, col.NestedColumns, tblName, schema, names)
+			if err != nil {
+				return nil, err
+			}
+			result.Nested = append(result.Nested, nested)
+			continue
+		}
+		jtCol := &JSONTableColumn{
+			Tp:      col.Tp,
+			Name:    col.Name,
+			Offset:  schema.Len(),
+			OnEmpty: col.OnEmpty,
+			OnError: col.OnError,
+		}
+		var ft *types.FieldType
+		if col.Tp == ast.JSONTableColumnOrdinality {
+			ft = types.NewFieldType(mysql.TypeLonglong)
+			ft.AddFlag(mysql.UnsignedFlag | mysql.NotNullFlag)
+			ft.SetFlen(mysql.MaxIntWidth)
+			types.SetBinChsClnFlag(ft)
+		} else {
+			if jtCol.Path, err = types.ParseJSONPathExpr(col.Path); err != nil {
+				return nil, err
+			}
+			ft = col.FieldType.Clone()
+			b.setJSONTableColumnFieldType(ft)
+		}
+		if col.OnEmpty != nil && col.OnEmpty.Tp == ast.JSONTableResponseDefault {
+			if jtCol.DefaultOnEmpty, err = types.ParseBinaryJSONFromString(col.OnEmpty.Default); err != nil {
+				return nil, err
+			}
+		}
+		if col.OnError != nil && col.OnError.Tp == ast.JSONTableResponseDefault {
+			if jtCol.DefaultOnError, err = types.ParseBinaryJSONFromString(col.OnError.Default); err != nil {
+				return nil, err
+			}
+		}
+		schema.Append(&expression.Column{
+			UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+			RetType:  ft,
+		})
+		*names = append(*names, &types.FieldName{
+			TblName:     tblName,
+			OrigTblName: tblName,
+			ColName:     col.Name,
+			OrigColName: col.Name,
+		})
+		result.Columns = append(result.Columns, jtCol)
+	}
+	return result, nil
+}
+
+// setJSONTableColumnFieldType fills the charset, collation, length and decimal
+// which are not specified in the column definition of JSON_TABLE.
+func (b *PlanBuilder) setJSONTableColumnFieldType(ft *types.FieldType) {
+	if ft.EvalType() == types.ETString && ft.GetType() != mysql.TypeJSON && ft.GetCharset() != charset.CharsetBin {
+		if ft.GetCharset() == "" {
+			ft.SetCharset(mysql.UTF8MB4Charset)
+			ft.SetCollate(b.ctx.GetSessionVars().DefaultCollationForUTF8MB4)
+		} else if ft.GetCollate() == "" {
+			coll, err := charset.GetDefaultCollation(ft.GetCharset())
+			if err != nil {
+				coll = charset.CollationBin
+			}
+			ft.SetCollate(coll)
+		}
+	} else {
+		types.SetBinChsClnFlag(ft)
+	}
+	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(ft.GetType())
+	if ft.GetFlen() == types.UnspecifiedLength {
+		ft.SetFlen(defaultFlen)
+	}
+	if ft.GetDecimal() == types.UnspecifiedLength {
+		ft.SetDecimal(defaultDecimal)
+	}
+}
+
+// isPivotSourceCol checks whether the column of the PIVOT or UNPIVOT source is visible, the
+// hidden columns and the extra handle columns are not output by PIVOT and UNPIVOT.
+func isPivotSourceCol(col *expression.Column) bool {
+	return !col.IsHidden && col.ID != model.ExtraHandleID && col.ID != model.ExtraPidColID && col.ID != model.ExtraPhysTblID
+}
+
+// buildPivotTable builds PIVOT as an aggregation grouped by the columns of the source which are
+// referred by neither the aggregate functions nor the FOR column. Each pair of a value and an
+// aggregate function is built as a conditional aggregate function, whose argument is NULL unless
+// the FOR column is equal to the value.
+func (b *PlanBuilder) buildPivotTable(ctx context.Context, pt *ast.PivotTable) (base.LogicalPlan, error) {
+	p, err := b.buildResultSetNode(ctx, pt.Source, false)
+	if err != nil {
+		return nil, err
+	}
+	b.curClause = pivotClause
+	forCol := &ast.ColumnNameExpr{Name: pt.ForColumn}
+	referredExprs := make([]ast.ExprNode, 0, len(pt.Aggregates)+1)
+	referredExprs = append(referredExprs, forCol)
+	aggFuncs := make([]*ast.AggregateFuncExpr, 0, len(pt.Aggregates))
+	for _, item := range pt.Aggregates {
+		aggFunc, ok := item.Expr.(*ast.AggregateFuncExpr)
+		if !ok || len(aggFunc.Args) == 0 {
+			return nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs("non-aggregate expressions in PIVOT")
+		}
+		aggFuncs = append(aggFuncs, aggFunc)
+		referredExprs = append(referredExprs, aggFunc.Args...)
+	}
+	referredCols := make(map[int64]struct{})
+	for _, expr := range referredExprs {
+		newExpr, _, err := b.rewrite(ctx, expr, p, nil, true)
+		if err != nil {
+			return nil, err
+		}
+		for _, col := range expression.ExtractColumns(newExpr) {
+			referredCols[col.UniqueID] = struct{}{}
+		}
+	}
+	valueNames := make([]string, 0, len(pt.Values))
+	for _, value := range pt.Values {
+		if value.AsName.L != "" {
+			valueNames = append(valueNames, value.AsName.O)
+			continue
+		}
+		newExpr, _, err := b.rewrite(ctx, value.Expr, p, nil, true)
+		if err != nil {
+			return nil, err
+		}
+		con, ok := newExpr.(*expression.Constant)
+		if !ok {
+			return nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs("non-constant values in PIVOT")
+		}
+		if con.Value.IsNull() {
+			valueNames = append(valueNames, "NULL")
+			continue
+		}
+		name, err := con.Value.ToString()
+		if err != nil {
+			return nil, err
+		}
+		valueNames = append(valueNames, name)
+	}
+
+	gbyItems := make([]expression.Expression, 0, p.Schema().Len())
+	for _, col := range p.Schema().Columns {
+		if _, ok := referredCols[col.UniqueID]; !ok && isPivotSourceCol(col) {
+			gbyItems = append(gbyItems, col)
+		}
+	}
+	pivotAggFuncs := make([]*ast.AggregateFuncExpr, 0, len(pt.Values)*len(aggFuncs))
+	for _, value := range pt.Values {
+		for _, aggFunc := range aggFuncs {
+			args := slices.Clone(aggFunc.Args)
+			args[0] = &ast.CaseExpr{WhenClauses: []*ast.WhenClause{{
+				Expr:   &ast.BinaryOperationExpr{Op: opcode.EQ, L: forCol, R: value.Expr},
+				Result: aggFunc.Args[0],
+			}}}
+			pivotAggFuncs = append(pivotAggFuncs, &ast.AggregateFuncExpr{
+				F:        aggFunc.F,
+				Args:     args,
+				Distinct: aggFunc.Distinct,
+				Order:    aggFunc.Order,
+			})
+		}
+	}
+	agg, aggIndexMap, err := b.buildAggregation(ctx, p, pivotAggFuncs, gbyItems, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// The aggregation outputs the first_row of every column of the source, the columns referred
+	// by PIVOT are hidden by a projection.
+	proj := LogicalProjection{Exprs: make([]expression.Expression, 0, len(gbyItems)+len(pivotAggFuncs))}.Init(b.ctx, b.getSelectOffset())
+	schema := expression.NewSchema(make([]*expression.Column, 0, len(gbyItems)+len(pivotAggFuncs))...)
+	names := make(types.NameSlice, 0, len(gbyItems)+len(pivotAggFuncs))
+	for _, item := range gbyItems {
+		idx := agg.Schema().ColumnIndex(item.(*expression.Column))
+		col := agg.Schema().Columns[idx]
+		proj.Exprs = append(proj.Exprs, col)
+		schema.Append(col.Clone().(*expression.Column))
+		name := *agg.OutputNames()[idx]
+		names = append(names, &name)
+	}
+	for i, valueName := range valueNames {
+		for j, item := range pt.Aggregates {
+			col := agg.Schema().Columns[aggIndexMap[i*len(aggFuncs)+j]]
+			proj.Exprs = append(proj.Exprs, col)
+			schema.Append(&expression.Column{
+				UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+				RetType:  col.RetType,
+			})
+			colName := valueName
+			if item.AsName.L != "" {
+				colName = valueName + "_" + item.AsName.O
+			}
+			names = append(names, &types.FieldName{
+				ColName:     model.NewCIStr(colName),
+				OrigColName: model.NewCIStr(colName),
+			})
+		}
+	}
+	proj.SetChildren(agg)
+	proj.SetSchema(schema)
+	proj.names = names
+	b.handleHelper.popMap()
+	b.handleHelper.pushMap(nil)
+	return proj, nil
+}
+
+// buildUnpivotTable builds UNPIVOT as an Unpivot whose level projections are built directly.
+// Each level outputs the columns of the source which are not unpivoted, the name of a column
+// and the value of it. The rows whose values are NULL are filtered unless INCLUDE NULLS is specified.
+func (b *PlanBuilder) buildUnpivotTable(ctx context.Context, ut *ast.UnpivotTable) (base.LogicalPlan, error) {
+	p, err := b.buildResultSetNode(ctx, ut.Source, false)
+	if err != nil {
+		return nil, err
+	}
+	b.curClause = pivotClause
+	unpivotCols := make([]*expression.Column, 0, len(ut.Columns))
+	unpivotExprs := make([]expression.Expression, 0, len(ut.Columns))
+	for _, unpivotCol := range ut.Columns {
+		expr, _, err := b.rewrite(ctx, &ast.ColumnNameExpr{Name: unpivotCol.Column}, p, nil, true)
+		if err != nil {
+			return nil, err
+		}
+		col, ok := expr.(*expression.Column)
+		if !ok || !p.Schema().Contains(col) {
+			return nil, plannererrors.ErrUnknownColumn.GenWithStackByArgs(unpivotCol.Column.String(), clauseMsg[pivotClause])
+		}
+		unpivotCols = append(unpivotCols, col)
+		unpivotExprs = append(unpivotExprs, col)
+	}
+
+	valueTp := unpivotCols[0].RetType.Clone()
+	for _, col := range unpivotCols[1:] {
+		valueTp = unionJoinFieldType(valueTp, col.RetType)
+	}
+	collation, err := expression.CheckAndDeriveCollationFromExprs(b.ctx.GetExprCtx(), "UNPIVOT", valueTp.EvalType(), unpivotExprs...)
+	if err != nil || collation.Coer == expression.CoercibilityNone {
+		return nil, collate.ErrIllegalMixCollation.GenWithStackByArgs("UNPIVOT")
+	}
+	valueTp.SetCharset(collation.Charset)
+	valueTp.SetCollate(collation.Collation)
+	b.setUnionFlen(valueTp, unpivotExprs)
+	valueTp.DelFlag(mysql.NotNullFlag)
+
+	nameStrs := make([]string, 0, len(ut.Columns))
+	nameTp := types.NewFieldType(mysql.TypeVarString)
+	nameTp.SetCharset(mysql.UTF8MB4Charset)
+	nameTp.SetCollate(b.ctx.GetSessionVars().DefaultCollationForUTF8MB4)
+	nameTp.AddFlag(mysql.NotNullFlag)
+	for i, unpivotCol := range ut.Columns {
+		name := unpivotCol.AsName.O
+		if name == "" {
+			name = unpivotCol.Column.Name.O
+		}
+		nameStrs = append(nameStrs, name)
+		nameTp.SetFlen(max(nameTp.GetFlen(), utf8.RuneCountInString(nameStrs[i])))
+	}
+
+	unpivot := LogicalUnpivot{LevelExprs: make([][]expression.Expression, 0, len(ut.Columns))}.Init(b.ctx, b.getSelectOffset())
+	schema := expression.NewSchema(make([]*expression.Column, 0, p.Schema().Len()+2)...)
+	names := make(types.NameSlice, 0, p.Schema().Len()+2)
+	passCols := make([]expression.Expression, 0, p.Schema().Len())
+	unpivotSchema := expression.NewSchema(unpivotCols...)
+	for i, col := range p.Schema().Columns {
+		if !isPivotSourceCol(col) || unpivotSchema.Contains(col) {
+			continue
+		}
+		passCols = append(passCols, col)
+		schema.Append(col.Clone().(*expression.Column))
+		name := *p.OutputNames()[i]
+		names = append(names, &name)
+	}
+	unpivot.NameCol = &expression.Column{
+		UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+		RetType:  nameTp,
+	}
+	valueCol := &expression.Column{
+		UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+		RetType:  valueTp,
+	}
+	schema.Append(unpivot.NameCol, valueCol)
+	names = append(names,
+		&types.FieldName{ColName: ut.NameColumn, OrigColName: ut.NameColumn},
+		&types.FieldName{ColName: ut.ValueColumn, OrigColName: ut.ValueColumn},
+	)
+	for i, col := range unpivotCols {
+		levelExprs := slices.Clone(passCols)
+		levelExprs = append(levelExprs, &expression.Constant{Value: types.NewStringDatum(nameStrs[i]), RetType: nameTp.Clone()})
+		var value expression.Expression = col
+		if !col.RetType.Equal(valueTp) {
+			value = expression.BuildCastFunction4Union(b.ctx.GetExprCtx(), col, valueTp)
+		}
+		levelExprs = append(levelExprs, value)
+		unpivot.LevelExprs = append(unpivot.LevelExprs, levelExprs)
+	}
+	unpivot.SetChildren(p)
+	unpivot.SetSchema(schema)
+	unpivot.names = names
+	b.handleHelper.popMap()
+	b.handleHelper.pushMap(nil)
+	if ut.IncludeNulls {
+		return unpivot, nil
+	}
+	sel := LogicalSelection{
+		Conditions: []expression.Expression{expression.BuildNotNullExpr(b.ctx.GetExprCtx(), valueCol)},
+	}.Init(b.ctx, b.getSelectOffset())
+	sel.SetChildren(unpivot)
+	return sel, nil
 }
 
 // buildUsingClause eliminate the redundant columns and ordering columns based
@@ -1287,11 +1691,12 @@ func (b *PlanBuilder) coalesceCommonColumns(p *LogicalJoin, leftPlan, rightPlan
 	return nil
 }
 
-func (b *PlanBuilder) buildSelection(ctx context.Context, p base.LogicalPlan, where ast.ExprNode, aggMapper map[*ast.AggregateFuncExpr]int) (base.LogicalPlan, error) {
+func (b *PlanBuilder) buildSelection(ctx context.Context, p base.LogicalPlan, where ast.ExprNode,
+	aggMapper map[*ast.AggregateFuncExpr]int, windowMapper map[*ast.WindowFuncExpr]int) (base.LogicalPlan, error) {
 	b.optFlag |= flagPredicatePushDown
 	b.optFlag |= flagDeriveTopNFromWindow
 	b.optFlag |= flagPredicateSimplification
-	if b.curClause != havingClause {
+	if b.curClause != havingClause && b.curClause != qualifyClause {
 		b.curClause = whereClause
 	}
 
@@ -1299,7 +1704,7 @@ func (b *PlanBuilder) buildSelection(ctx context.Context, p base.LogicalPlan, wh
 	expressions := make([]expression.Expression, 0, len(conditions))
 	selection := LogicalSelection{}.Init(b.ctx, b.getSelectOffset())
 	for _, cond := range conditions {
-		expr, np, err := b.rewrite(ctx, cond, p, aggMapper, false)
+		expr, np, err := b.rewriteWithPreprocess(ctx, cond, p, aggMapper, windowMapper, false, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -2665,7 +3070,7 @@ func (a *havingWindowAndOrderbyExprResolver) Leave(n ast.Node) (node ast.Node, o
 			a.err = plannererrors.ErrWindowInvalidWindowFuncUse.GenWithStackByArgs(strings.ToLower(v.Name))
 			return node, false
 		}
-		if a.curClause == orderByClause {
+		if a.curClause == orderByClause || a.curClause == qualifyClause {
 			a.selectFields = append(a.selectFields, &ast.SelectField{
 				Auxiliary: true,
 				Expr:      v,
@@ -2682,7 +3087,8 @@ func (a *havingWindowAndOrderbyExprResolver) Leave(n ast.Node) (node ast.Node, o
 		}
 	case *ast.ColumnNameExpr:
 		resolveFieldsFirst := true
-		if a.inAggFunc || a.inWindowFunc || a.inWindowSpec || (a.curClause == orderByClause && a.inExpr) || a.curClause == fieldList {
+		if a.inAggFunc || a.inWindowFunc || a.inWindowSpec || ((a.curClause == orderByClause || a.curClause == qualifyClause) && a.inExpr) ||
+			a.curClause == fieldList {
 			resolveFieldsFirst = false
 		}
 		if !a.inAggFunc && a.curClause != orderByClause {
@@ -2705,7 +3111,7 @@ func (a *havingWindowAndOrderbyExprResolver) Leave(n ast.Node) (node ast.Node, o
 				return node, false
 			}
 			if index == -1 {
-				if a.curClause == orderByClause {
+				if a.curClause == orderByClause || a.curClause == qualifyClause {
 					index, a.err = a.resolveFromPlan(v, a.p, resolveFieldsFirst)
 				} else if a.curClause == havingClause && v.Name.Table.L != "" {
 					// For SQLs like:
@@ -2960,6 +3366,15 @@ func (b *PlanBuilder) resolveWindowFunction(sel *ast.SelectStmt, p base.LogicalP
 			item.Expr = n.(ast.ExprNode)
 		}
 	}
+	if sel.Qualify != nil {
+		extractor.curClause = qualifyClause
+		extractor.inExpr = false
+		n, ok := sel.Qualify.Accept(extractor)
+		if !ok {
+			return nil, extractor.err
+		}
+		sel.Qualify = n.(ast.ExprNode)
+	}
 	sel.Fields.Fields = extractor.selectFields
 	return extractor.aggMapper, nil
 }
@@ -4138,6 +4553,9 @@ func (b *PlanBuilder) buildSelect(ctx context.Context, sel *ast.SelectStmt) (p b
 	}
 
 	hasWindowFuncField := b.detectSelectWindow(sel)
+	if sel.Qualify != nil && !hasWindowFuncField {
+		return nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs("QUALIFY clause without window functions")
+	}
 	// Some SQL statements define WINDOW but do not use them. But we also need to check the window specification list.
 	// For example: select id from t group by id WINDOW w AS (ORDER BY uids DESC) ORDER BY id;
 	// We don't use the WINDOW w, but if the 'uids' column is not in the table t, we still need to report an error.
@@ -4178,7 +4596,7 @@ func (b *PlanBuilder) buildSelect(ctx context.Context, sel *ast.SelectStmt) (p b
 	defer func() { b.allNames = b.allNames[:len(b.allNames)-1] }()
 
 	if sel.Where != nil {
-		p, err = b.buildSelection(ctx, p, sel.Where, nil)
+		p, err = b.buildSelection(ctx, p, sel.Where, nil, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -4243,7 +4661,7 @@ func (b *PlanBuilder) buildSelect(ctx context.Context, sel *ast.SelectStmt) (p b
 
 	if sel.Having != nil {
 		b.curClause = havingClause
-		p, err = b.buildSelection(ctx, p, sel.Having.Expr, havingMap)
+		p, err = b.buildSelection(ctx, p, sel.Having.Expr, havingMap, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -4281,6 +4699,16 @@ func (b *PlanBuilder) buildSelect(ctx context.Context, sel *ast.SelectStmt) (p b
 		}
 	}
 
+	// QUALIFY filters the rows by the window function results, so it's built after the window functions,
+	// and before DISTINCT and ORDER BY.
+	if sel.Qualify != nil {
+		b.curClause = qualifyClause
+		p, err = b.buildSelection(ctx, p, sel.Qualify, windowAggMap, windowMapper)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if sel.Distinct {
 		p, err = b.buildDistinct(p, oldLen)
 		if err != nil {
@@ -4729,7 +5157,7 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 	}
 	tableInfo := tbl.Meta()
 
-	if b.isCreateView && tableInfo.TempTableType == model.TempTableLocal {
+	if (b.isCreateView || b.isCreateMaterializedView) && tableInfo.TempTableType == model.TempTableLocal {
 		return nil, plannererrors.ErrViewSelectTemporaryTable.GenWithStackByArgs(tn.Name)
 	}
 
@@ -5757,7 +6185,7 @@ func (b *PlanBuilder) buildUpdate(ctx context.Context, update *ast.UpdateStmt) (
 
 	oldSchemaLen := p.Schema().Len()
 	if update.Where != nil {
-		p, err = b.buildSelection(ctx, p, update.Where, nil)
+		p, err = b.buildSelection(ctx, p, update.Where, nil, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -5812,6 +6240,14 @@ func (b *PlanBuilder) buildUpdate(ctx context.Context, update *ast.UpdateStmt) (
 		VirtualAssignmentsOffset:  len(update.List),
 	}.Init(b.ctx)
 	updt.names = p.OutputNames()
+	var returningSchema *expression.Schema
+	var returningNames types.NameSlice
+	if update.Returning != nil {
+		updt.Returning, returningSchema, returningNames, err = b.buildReturning(ctx, p, update.Returning)
+		if err != nil {
+			return nil, err
+		}
+	}
 	// We cannot apply projection elimination when building the subplan, because
 	// columns in orderedList cannot be resolved. (^flagEliminateProjection should also be applied in postOptimize)
 	updt.SelectPlan, _, err = DoOptimize(ctx, b.ctx, b.optFlag&^flagEliminateProjection, p)
@@ -5836,6 +6272,9 @@ func (b *PlanBuilder) buildUpdate(ctx context.Context, update *ast.UpdateStmt) (
 	}
 	updt.PartitionedTable = b.partitionedTable
 	updt.tblID2Table = tblID2table
+	if returningSchema != nil {
+		updt.setSchemaAndNames(returningSchema, returningNames)
+	}
 	err = updt.buildOnUpdateFKTriggers(b.ctx, b.is, tblID2table)
 	return updt, err
 }
@@ -5945,7 +6384,8 @@ func (b *PlanBuilder) buildUpdateLists(ctx context.Context, tableList []*ast.Tab
 		foundListItem := false
 		for _, tl := range tableList {
 			if (tl.Schema.L == "" || tl.Schema.L == name.DBName.L) && (tl.Name.L == name.TblName.L) {
-				if isCTE(tl) || tl.TableInfo.IsView() || tl.TableInfo.IsSequence() {
+				if isCTE(tl) || tl.TableInfo.IsView() || tl.TableInfo.IsSequence() ||
+					(tl.TableInfo.IsMaterializedView() && !b.ctx.GetSessionVars().InRestrictedSQL) {
 					return nil, nil, false, plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(name.TblName.O, "UPDATE")
 				}
 				foundListItem = true
@@ -6156,7 +6596,7 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, ds *ast.DeleteStmt) (base
 
 	// For explicit column usage, should use the all-public columns.
 	if ds.Where != nil {
-		p, err = b.buildSelection(ctx, p, ds.Where, nil)
+		p, err = b.buildSelection(ctx, p, ds.Where, nil, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -6219,6 +6659,14 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, ds *ast.DeleteStmt) (base
 	}.Init(b.ctx)
 
 	del.names = p.OutputNames()
+	var returningSchema *expression.Schema
+	var returningNames types.NameSlice
+	if ds.Returning != nil {
+		del.Returning, returningSchema, returningNames, err = b.buildReturning(ctx, p, ds.Returning)
+		if err != nil {
+			return nil, err
+		}
+	}
 	del.SelectPlan, _, err = DoOptimize(ctx, b.ctx, b.optFlag, p)
 	if err != nil {
 		return nil, err
@@ -6267,6 +6715,9 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, ds *ast.DeleteStmt) (base
 			if tn.TableInfo.IsSequence() {
 				return nil, errors.Errorf("delete sequence %s is not supported now", tn.Name.O)
 			}
+			if tn.TableInfo.IsMaterializedView() && !sessionVars.InRestrictedSQL {
+				return nil, plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(tn.Name.O, "DELETE")
+			}
 			if sessionVars.User != nil {
 				authErr = plannererrors.ErrTableaccessDenied.FastGenByArgs("DELETE", sessionVars.User.AuthUsername, sessionVars.User.AuthHostname, tb.Name.L)
 			}
@@ -6285,6 +6736,9 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, ds *ast.DeleteStmt) (base
 			if v.TableInfo.IsSequence() {
 				return nil, errors.Errorf("delete sequence %s is not supported now", v.Name.O)
 			}
+			if v.TableInfo.IsMaterializedView() && !sessionVars.InRestrictedSQL {
+				return nil, plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(v.Name.O, "DELETE")
+			}
 			dbName := v.Schema.L
 			if dbName == "" {
 				dbName = b.ctx.GetSessionVars().CurrentDB
@@ -6314,10 +6768,247 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, ds *ast.DeleteStmt) (base
 	if err != nil {
 		return nil, err
 	}
+	if returningSchema != nil {
+		if err = del.ResolveIndices(); err != nil {
+			return nil, err
+		}
+		del.setSchemaAndNames(returningSchema, returningNames)
+	}
 	err = del.buildOnDeleteFKTriggers(b.ctx, b.is, tblID2table)
 	return del, err
 }
 
+func (b *PlanBuilder) buildMerge(ctx context.Context, merge *ast.MergeStmt) (base.Plan, error) {
+	b.pushSelectOffset(0)
+	b.pushTableHints(merge.TableHints, 0)
+	defer func() {
+		b.popSelectOffset()
+		// table hints are only visible in the current MERGE statement.
+		b.popTableHints()
+	}()
+
+	b.inUpdateStmt = true
+	b.isForUpdateRead = true
+
+	tn, ok := merge.Target.Source.(*ast.TableName)
+	if !ok {
+		return nil, infoschema.ErrTableNotExists.FastGenByArgs()
+	}
+	tableInfo := tn.TableInfo
+	if tableInfo.IsView() || tableInfo.IsSequence() ||
+		(tableInfo.IsMaterializedView() && !b.ctx.GetSessionVars().InRestrictedSQL) {
+		return nil, plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(tn.Name.O, "MERGE")
+	}
+	tbl, ok := b.is.TableByID(tableInfo.ID)
+	if !ok {
+		return nil, errors.Errorf("Can't get table %s", tableInfo.Name.O)
+	}
+
+	// The source is the outer side, so the target columns are null for the source rows which
+	// don't match any target row.
+	join := &ast.Join{
+		Left:  merge.Source,
+		Right: merge.Target,
+		Tp:    ast.LeftJoin,
+		On:    &ast.OnCondition{Expr: merge.On},
+	}
+	p, err := b.buildResultSetNode(ctx, join, false)
+	if err != nil {
+		return nil, err
+	}
+	tableList := ExtractTableList(join, false)
+	for _, t := range tableList {
+		dbName := t.Schema.L
+		if dbName == "" {
+			dbName = b.ctx.GetSessionVars().CurrentDB
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SelectPriv, dbName, t.Name.L, "", nil)
+	}
+
+	// The source may read the target table too, the handle of the target is the one on the inner side.
+	sourceLen := p.Children()[0].Schema().Len()
+	var handleCols util.HandleCols
+	for _, cols := range b.handleHelper.tailMap()[tableInfo.ID] {
+		if p.Schema().ColumnIndex(cols.GetCol(0)) >= sourceLen {
+			handleCols = cols
+		}
+	}
+	if handleCols == nil {
+		return nil, plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(tn.Name.O, "MERGE")
+	}
+
+	// Add project to freeze the order of output columns.
+	proj := LogicalProjection{Exprs: expression.Column2Exprs(p.Schema().Columns)}.Init(b.ctx, b.getSelectOffset())
+	proj.SetSchema(p.Schema().Clone())
+	proj.names = p.OutputNames()
+	proj.SetChildren(p)
+	p = proj
+
+	targetTn := tn
+	if merge.Target.AsName.L != "" {
+		newTableName := *tn
+		newTableName.Name = merge.Target.AsName
+		newTableName.Schema = model.NewCIStr("")
+		targetTn = &newTableName
+	}
+	mergePlan := Merge{}.Init(b.ctx)
+	mergePlan.names = p.OutputNames()
+	for _, when := range merge.WhenClauses {
+		clause := &MergeClause{Matched: when.Matched, Action: when.Action}
+		if when.Condition != nil {
+			b.curClause = whereClause
+			var np base.LogicalPlan
+			clause.Condition, np, err = b.rewrite(ctx, when.Condition, p, nil, true)
+			if err != nil {
+				return nil, err
+			}
+			if np != p {
+				return nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs("subqueries in the WHEN clause of MERGE")
+			}
+		}
+		switch when.Action {
+		case ast.MergeActionUpdate:
+			// The columns in the SET list always belong to the target, qualify them so that they
+			// are not ambiguous with the columns of the source.
+			assignments := make([]*ast.Assignment, 0, len(when.Assignments))
+			for _, assign := range when.Assignments {
+				if assign.Column.Table.L == "" {
+					newAssign := *assign
+					newAssign.Column = &ast.ColumnName{Schema: targetTn.Schema, Table: targetTn.Name, Name: assign.Column.Name}
+					assign = &newAssign
+				}
+				assignments = append(assignments, assign)
+			}
+			orderedList, np, _, err := b.buildUpdateLists(ctx, []*ast.TableName{targetTn}, assignments, p)
+			if err != nil {
+				return nil, err
+			}
+			if np != p {
+				return nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs("subqueries in the WHEN clause of MERGE")
+			}
+			clause.Update = Update{
+				OrderedList:              orderedList,
+				VirtualAssignmentsOffset: len(when.Assignments),
+			}.Init(b.ctx)
+			clause.Update.names = p.OutputNames()
+		case ast.MergeActionDelete:
+			var authErr error
+			if user := b.ctx.GetSessionVars().User; user != nil {
+				authErr = plannererrors.ErrTableaccessDenied.FastGenByArgs("DELETE", user.AuthUsername, user.AuthHostname, tableInfo.Name.L)
+			}
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DeletePriv, tn.DBInfo.Name.L, tableInfo.Name.L, "", authErr)
+			clause.Delete = Delete{}.Init(b.ctx)
+			clause.Delete.names = p.OutputNames()
+		case ast.MergeActionInsert:
+			clause.Insert, err = b.buildMergeInsert(ctx, tn, tbl, when, p)
+			if err != nil {
+				return nil, err
+			}
+		}
+		mergePlan.Clauses = append(mergePlan.Clauses, clause)
+	}
+
+	// We cannot apply projection elimination when building the subplan, because
+	// the columns of the clauses cannot be resolved.
+	mergePlan.SelectPlan, _, err = DoOptimize(ctx, b.ctx, b.optFlag&^flagEliminateProjection, p)
+	if err != nil {
+		return nil, err
+	}
+	tblID2Handle, err := resolveIndicesForTblID2Handle(map[int64][]util.HandleCols{tableInfo.ID: {handleCols}}, mergePlan.SelectPlan.Schema())
+	if err != nil {
+		return nil, err
+	}
+	tblID2table := map[int64]table.Table{tableInfo.ID: tbl}
+	tblColPosInfos, err := buildColumns2Handle(mergePlan.names, tblID2Handle, tblID2table, true)
+	if err != nil {
+		return nil, err
+	}
+	mergePlan.TblColPosInfo = tblColPosInfos[0]
+	for _, clause := range mergePlan.Clauses {
+		switch {
+		case clause.Update != nil:
+			clause.Update.SelectPlan = mergePlan.SelectPlan
+			clause.Update.TblColPosInfos = tblColPosInfos
+			clause.Update.tblID2Table = tblID2table
+		case clause.Delete != nil:
+			clause.Delete.SelectPlan = mergePlan.SelectPlan
+			clause.Delete.TblColPosInfos = tblColPosInfos
+		}
+	}
+	if err = mergePlan.ResolveIndices(); err != nil {
+		return nil, err
+	}
+	for _, clause := range mergePlan.Clauses {
+		switch {
+		case clause.Update != nil:
+			err = clause.Update.buildOnUpdateFKTriggers(b.ctx, b.is, tblID2table)
+		case clause.Delete != nil:
+			err = clause.Delete.buildOnDeleteFKTriggers(b.ctx, b.is, tblID2table)
+		case clause.Insert != nil:
+			err = clause.Insert.buildOnInsertFKTriggers(b.ctx, b.is, tn.DBInfo.Name.L)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return mergePlan, nil
+}
+
+// buildMergeInsert builds the plan of the INSERT action of a MERGE statement, the values are
+// evaluated on the rows of p.
+func (b *PlanBuilder) buildMergeInsert(ctx context.Context, tn *ast.TableName, tbl table.Table, when *ast.MergeWhenClause, p base.LogicalPlan) (*Insert, error) {
+	tableInfo := tn.TableInfo
+	schema, names, err := expression.TableInfo2SchemaAndNames(b.ctx.GetExprCtx(), tn.Schema, tableInfo)
+	if err != nil {
+		return nil, err
+	}
+	insertPlan := Insert{
+		Table:         tbl,
+		Columns:       when.Columns,
+		tableSchema:   schema,
+		tableColNames: names,
+	}.Init(b.ctx)
+
+	var authErr error
+	if user := b.ctx.GetSessionVars().User; user != nil {
+		authErr = plannererrors.ErrTableaccessDenied.FastGenByArgs("INSERT", user.AuthUsername, user.AuthHostname, tableInfo.Name.L)
+	}
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.InsertPriv, tn.DBInfo.Name.L, tableInfo.Name.L, "", authErr)
+
+	affectedValuesCols, err := b.getAffectCols(&ast.InsertStmt{Columns: when.Columns}, insertPlan)
+	if err != nil {
+		return nil, err
+	}
+	if (len(when.Columns) > 0 || len(when.Values) > 0) && len(when.Values) != len(affectedValuesCols) {
+		return nil, plannererrors.ErrWrongValueCountOnRow.GenWithStackByArgs(1)
+	}
+	valuesPlan := LogicalTableDual{}.Init(b.ctx, b.getSelectOffset())
+	valuesPlan.SetSchema(p.Schema())
+	valuesPlan.names = p.OutputNames()
+	exprList := make([]expression.Expression, 0, len(when.Values))
+	for i, value := range when.Values {
+		expr, err := b.getInsertColExpr(ctx, insertPlan, valuesPlan, affectedValuesCols[i], value, func(n ast.Node) ast.Node { return n })
+		if err != nil {
+			return nil, err
+		}
+		if expr == nil {
+			continue
+		}
+		exprList = append(exprList, expr)
+	}
+	insertPlan.Lists = [][]expression.Expression{exprList}
+	insertPlan.RowLen = len(exprList)
+
+	mockTablePlan := LogicalTableDual{}.Init(b.ctx, b.getSelectOffset())
+	mockTablePlan.SetSchema(insertPlan.tableSchema)
+	mockTablePlan.names = insertPlan.tableColNames
+	insertPlan.GenCols, err = b.resolveGeneratedColumns(ctx, insertPlan.Table.Cols(), nil, mockTablePlan)
+	if err != nil {
+		return nil, err
+	}
+	return insertPlan, nil
+}
+
 func resolveIndicesForTblID2Handle(tblID2Handle map[int64][]util.HandleCols, schema *expression.Schema) (map[int64][]util.HandleCols, error) {
 	newMap := make(map[int64][]util.HandleCols, len(tblID2Handle))
 	for i, cols := range tblID2Handle {
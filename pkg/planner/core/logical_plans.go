@@ -69,6 +69,8 @@ var (
 	_ base.LogicalPlan = &LogicalLimit{}
 	_ base.LogicalPlan = &LogicalWindow{}
 	_ base.LogicalPlan = &LogicalExpand{}
+	_ base.LogicalPlan = &LogicalJSONTable{}
+	_ base.LogicalPlan = &LogicalUnpivot{}
 )
 
 // JoinType contains CrossJoin, InnerJoin, LeftOuterJoin, RightOuterJoin, SemiJoin, AntiJoin.
@@ -1934,6 +1936,9 @@ type LogicalTopN struct {
 	Offset           uint64
 	Count            uint64
 	PreferLimitToCop bool
+	// MppOnly indicates the partitioned TopN can only be pushed down to TiFlash by MPP,
+	// because its partition by isn't a prefix of the data order of TiKV.
+	MppOnly bool
 }
 
 // GetPartitionBy returns partition by fields
@@ -2212,7 +2217,8 @@ type ShowContents struct {
 	Extended    bool       // Used for `show extended columns from ...`
 	Limit       *ast.Limit // Used for limit Result Set row number.
 
-	ImportJobID *int64 // Used for SHOW LOAD DATA JOB <jobID>
+	ImportJobID *int64         // Used for SHOW LOAD DATA JOB <jobID>
+	Procedure   *ast.TableName // Used for SHOW CREATE PROCEDURE
 }
 
 const emptyShowContentsSize = int64(unsafe.Sizeof(ShowContents{}))
@@ -2243,6 +2249,101 @@ type LogicalShowDDLJobs struct {
 	JobNumber int64
 }
 
+// JSONTableColumn is a column of JSON_TABLE other than the NESTED PATH ones.
+type JSONTableColumn struct {
+	Tp   ast.JSONTableColumnType
+	Name model.CIStr
+	// Offset is the offset of the column in the schema of JSON_TABLE.
+	Offset int
+	// Path is not used by the ordinality column.
+	Path    types.JSONPathExpression
+	OnEmpty *ast.JSONTableOnResponse
+	OnError *ast.JSONTableOnResponse
+	// DefaultOnEmpty and DefaultOnError are the parsed values of the DEFAULT responses.
+	DefaultOnEmpty types.BinaryJSON
+	DefaultOnError types.BinaryJSON
+}
+
+// JSONTablePath is a row path of JSON_TABLE with the columns extracted from each of the
+// values it matches. The top level COLUMNS clause and every NESTED PATH clause is a JSONTablePath.
+type JSONTablePath struct {
+	Path    types.JSONPathExpression
+	Columns []*JSONTableColumn
+	Nested  []*JSONTablePath
+}
+
+// ColumnOffsets returns the offsets of all the columns produced by the path and its nested paths.
+func (p *JSONTablePath) ColumnOffsets() []int {
+	offsets := make([]int, 0, len(p.Columns))
+	for _, col := range p.Columns {
+		offsets = append(offsets, col.Offset)
+	}
+	for _, nested := range p.Nested {
+		offsets = append(offsets, nested.ColumnOffsets()...)
+	}
+	return offsets
+}
+
+// LogicalJSONTable represents the JSON_TABLE table function, which extracts rows from a JSON document.
+type LogicalJSONTable struct {
+	logicalSchemaProducer
+
+	// DocExpr evaluates to the JSON document. It may refer to the tables preceding
+	// the JSON_TABLE in the FROM clause through correlated columns.
+	DocExpr expression.Expression
+	Root    *JSONTablePath
+	// Name is the alias of the JSON_TABLE, it is used in error messages.
+	Name model.CIStr
+}
+
+// ExtractCorrelatedCols implements LogicalPlan interface.
+func (p *LogicalJSONTable) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	return expression.ExtractCorColumns(p.DocExpr)
+}
+
+// LogicalUnpivot represents UNPIVOT, which rotates the unpivoted columns of every row of the child
+// into rows. Unlike LogicalExpand, the level projections are built in the plan building phase.
+type LogicalUnpivot struct {
+	logicalSchemaProducer
+
+	// LevelExprs has a projection for each unpivoted column, which outputs the columns of the child
+	// that are not unpivoted, the name of the unpivoted column and the value of it.
+	LevelExprs [][]expression.Expression
+	// NameCol is the column of the names of the unpivoted columns.
+	NameCol *expression.Column
+}
+
+// ExtractCorrelatedCols implements LogicalPlan interface.
+func (p *LogicalUnpivot) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	corCols := make([]*expression.CorrelatedColumn, 0, len(p.LevelExprs[0]))
+	for _, levelExprs := range p.LevelExprs {
+		for _, expr := range levelExprs {
+			corCols = append(corCols, expression.ExtractCorColumns(expr)...)
+		}
+	}
+	return corCols
+}
+
+// BuildKeyInfo implements LogicalPlan BuildKeyInfo interface.
+func (p *LogicalUnpivot) BuildKeyInfo(selfSchema *expression.Schema, childSchema []*expression.Schema) {
+	selfSchema.Keys = nil
+	p.BaseLogicalPlan.BuildKeyInfo(selfSchema, childSchema)
+	// Every row of the child is output once for each unpivoted column, so a key of the child
+	// is still a key along with the name column.
+	for _, key := range childSchema[0].Keys {
+		indices := selfSchema.ColumnsIndices(key)
+		if indices == nil {
+			continue
+		}
+		newKey := make([]*expression.Column, 0, len(key)+1)
+		for _, i := range indices {
+			newKey = append(newKey, selfSchema.Columns[i])
+		}
+		newKey = append(newKey, p.NameCol)
+		selfSchema.Keys = append(selfSchema.Keys, newKey)
+	}
+}
+
 // CTEClass holds the information and plan for a CTE. Most of the fields in this struct are the same as cteInfo.
 // But the cteInfo is used when building the plan, and CTEClass is used also for building the executor.
 type CTEClass struct {
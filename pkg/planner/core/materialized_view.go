@@ -0,0 +1,202 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+)
+
+// buildCreateMaterializedView builds the query of the materialized view, and fills the
+// names and types of the view columns into the statement.
+func (b *PlanBuilder) buildCreateMaterializedView(ctx context.Context, v *ast.CreateMaterializedViewStmt) error {
+	b.isCreateMaterializedView = true
+	defer func() {
+		b.isCreateMaterializedView = false
+	}()
+
+	plan, err := b.Build(ctx, v.Select)
+	if err != nil {
+		return err
+	}
+	schema := plan.Schema()
+	cols := v.Cols
+	if cols == nil {
+		adjustOverlongViewColname(plan.(base.LogicalPlan))
+		cols = make([]model.CIStr, 0, schema.Len())
+		for _, name := range plan.OutputNames() {
+			cols = append(cols, name.ColName)
+		}
+	}
+	if len(cols) != schema.Len() {
+		return dbterror.ErrViewWrongList
+	}
+	v.SchemaCols = cols
+	v.SchemaColTypes = make([]*types.FieldType, 0, schema.Len())
+	for _, col := range schema.Columns {
+		v.SchemaColTypes = append(v.SchemaColTypes, materializedViewColumnType(col.RetType))
+	}
+	return nil
+}
+
+// materializedViewColumnType returns the type of the materialized view column storing
+// the values of an expression, which may not be a valid column type itself.
+func materializedViewColumnType(ft *types.FieldType) *types.FieldType {
+	tp := ft.Clone()
+	tp.SetFlag(tp.GetFlag() & (mysql.UnsignedFlag | mysql.BinaryFlag))
+	switch tp.GetType() {
+	case mysql.TypeNull:
+		tp = types.NewFieldType(mysql.TypeString)
+		tp.SetFlen(0)
+		tp.SetCharset(charset.CharsetBin)
+		tp.SetCollate(charset.CollationBin)
+		tp.AddFlag(mysql.BinaryFlag)
+	case mysql.TypeString, mysql.TypeVarchar, mysql.TypeVarString:
+		if tp.GetType() == mysql.TypeString && tp.GetFlen() >= 0 && tp.GetFlen() <= mysql.MaxFieldCharLength {
+			break
+		}
+		maxLen := mysql.MaxFieldVarCharLength
+		if cs, err := charset.GetCharsetInfo(tp.GetCharset()); err == nil && cs.Maxlen > 0 {
+			maxLen /= cs.Maxlen
+		}
+		if tp.GetFlen() < 0 || tp.GetFlen() > maxLen {
+			tp.SetType(mysql.TypeLongBlob)
+			tp.SetFlen(types.UnspecifiedLength)
+		} else {
+			tp.SetType(mysql.TypeVarchar)
+		}
+	case mysql.TypeFloat, mysql.TypeDouble:
+		tp.SetFlen(types.UnspecifiedLength)
+		tp.SetDecimal(types.UnspecifiedLength)
+	case mysql.TypeNewDecimal:
+		if tp.GetFlen() > mysql.MaxDecimalWidth {
+			tp.SetFlen(mysql.MaxDecimalWidth)
+		}
+		if tp.GetDecimal() > mysql.MaxDecimalScale {
+			tp.SetDecimal(mysql.MaxDecimalScale)
+		}
+	}
+	return tp
+}
+
+// tryBuildMaterializedViewScan builds the plan reading the materialized view whose query matches
+// the statement, if `tidb_opt_enable_materialized_view_rewrite` is enabled. It returns nil if
+// there is no such view. The plan of the statement itself has been built, so the privileges on
+// the base tables are still checked.
+func (b *PlanBuilder) tryBuildMaterializedViewScan(ctx context.Context, sel *ast.SelectStmt, origin base.LogicalPlan) (base.LogicalPlan, error) {
+	vars := b.ctx.GetSessionVars()
+	if !vars.EnableMaterializedViewRewrite || vars.InRestrictedSQL ||
+		b.isCreateView || b.isCreateMaterializedView || len(b.buildingViewStack) > 0 ||
+		sel.From == nil || (sel.LockInfo != nil && sel.LockInfo.LockType != ast.SelectLockNone) {
+		return nil, nil
+	}
+
+	// The statement is restored the same way as the view query, see `ddl.BuildMaterializedViewInfo`.
+	restoreFlag := format.RestoreStringSingleQuotes | format.RestoreKeyWordUppercase | format.RestoreNameBackQuotes
+	var sb strings.Builder
+	if sel.Restore(format.NewRestoreCtx(restoreFlag, &sb)) != nil {
+		return nil, nil
+	}
+	_, digest := parser.NormalizeDigest(sb.String())
+	mvIDs := b.is.MaterializedViewIDsByDigest(digest.String())
+	if len(mvIDs) == 0 {
+		return nil, nil
+	}
+
+	// The digest ignores the literals, so they're compared to tell `a = 1` from `a = 2`.
+	literals, ok := restoreLiterals(sel)
+	if !ok {
+		return nil, nil
+	}
+	var mvInfo *model.TableInfo
+	var mvSchema model.CIStr
+	for _, id := range mvIDs {
+		info, ok := b.is.TableInfoByID(id)
+		if !ok || !info.IsMaterializedView() {
+			continue
+		}
+		db, ok := infoschema.SchemaByTable(b.is, info)
+		if !ok {
+			continue
+		}
+		stmt, err := parser.New().ParseOneStmt(info.MaterializedView.Query, "", "")
+		if err != nil {
+			continue
+		}
+		if mvLiterals, ok := restoreLiterals(stmt); ok && slices.Equal(literals, mvLiterals) {
+			mvInfo, mvSchema = info, db.Name
+			break
+		}
+	}
+	if mvInfo == nil {
+		return nil, nil
+	}
+
+	mvSel := &ast.SelectStmt{
+		Kind:   ast.SelectStmtKindSelect,
+		Fields: &ast.FieldList{Fields: []*ast.SelectField{{WildCard: &ast.WildCardField{}}}},
+		From: &ast.TableRefsClause{TableRefs: &ast.Join{Left: &ast.TableSource{
+			Source: &ast.TableName{Schema: mvSchema, Name: mvInfo.Name},
+		}}},
+	}
+	p, err := b.buildSelect(ctx, mvSel)
+	if err != nil {
+		return nil, err
+	}
+	if p.Schema().Len() != origin.Schema().Len() {
+		return nil, nil
+	}
+	p.SetOutputNames(origin.OutputNames())
+	vars.StmtCtx.SetSkipPlanCache("query is rewritten to read a materialized view")
+	return p, nil
+}
+
+// literalsCollector collects the restored literals of a statement in order.
+type literalsCollector struct {
+	literals []string
+	err      error
+}
+
+func (c *literalsCollector) Enter(n ast.Node) (ast.Node, bool) {
+	if v, ok := n.(ast.ValueExpr); ok && c.err == nil {
+		var sb strings.Builder
+		c.err = v.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb))
+		c.literals = append(c.literals, sb.String())
+		return n, true
+	}
+	return n, false
+}
+
+func (*literalsCollector) Leave(n ast.Node) (ast.Node, bool) {
+	return n, true
+}
+
+func restoreLiterals(stmt ast.Node) ([]string, bool) {
+	c := &literalsCollector{}
+	stmt.Accept(c)
+	return c.literals, c.err == nil
+}
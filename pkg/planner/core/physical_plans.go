@@ -55,6 +55,8 @@ var (
 	_ base.PhysicalPlan = &PhysicalTopN{}
 	_ base.PhysicalPlan = &PhysicalMaxOneRow{}
 	_ base.PhysicalPlan = &PhysicalTableDual{}
+	_ base.PhysicalPlan = &PhysicalJSONTable{}
+	_ base.PhysicalPlan = &PhysicalUnpivot{}
 	_ base.PhysicalPlan = &PhysicalUnionAll{}
 	_ base.PhysicalPlan = &PhysicalSort{}
 	_ base.PhysicalPlan = &NominalSort{}
@@ -920,6 +922,10 @@ type PhysicalTableScan struct {
 	// for runtime filter
 	runtimeFilterList []*RuntimeFilter
 	maxWaitTimeMs     int
+
+	// AnnQuery is the approximate nearest neighbour search pushed down from a TopN, which is
+	// ordered by the distance between a vector column and a constant vector.
+	AnnQuery *tipb.ANNQueryInfo
 }
 
 // Clone implements op.PhysicalPlan interface.
@@ -1488,6 +1494,9 @@ type PhysicalIndexJoin struct {
 	// InnerHashKeys indicates the inner keys used to build hash table during
 	// execution. InnerJoinKeys is the prefix of InnerHashKeys.
 	InnerHashKeys []*expression.Column
+	// AdaptiveHashJoin is the hash join which scans the whole inner table. The executor switches to it when the outer
+	// side produces more rows than tidb_adaptive_join_threshold. It's nil if the adaptive join is disabled.
+	AdaptiveHashJoin *PhysicalHashJoin
 }
 
 // MemoryUsage return the memory usage of PhysicalIndexJoin
@@ -1497,7 +1506,7 @@ func (p *PhysicalIndexJoin) MemoryUsage() (sum int64) {
 	}
 
 	sum = p.basePhysicalJoin.MemoryUsage() + size.SizeOfInterface*2 + size.SizeOfSlice*4 +
-		int64(cap(p.KeyOff2IdxOff)+cap(p.IdxColLens))*size.SizeOfInt + size.SizeOfPointer
+		int64(cap(p.KeyOff2IdxOff)+cap(p.IdxColLens))*size.SizeOfInt + size.SizeOfPointer*2
 	if p.innerTask != nil {
 		sum += p.innerTask.MemoryUsage()
 	}
@@ -2280,6 +2289,79 @@ func (p *PhysicalTableDual) MemoryUsage() (sum int64) {
 	return
 }
 
+// PhysicalJSONTable is the physical operator of JSON_TABLE.
+type PhysicalJSONTable struct {
+	physicalSchemaProducer
+
+	DocExpr expression.Expression
+	Root    *JSONTablePath
+	Name    model.CIStr
+}
+
+// ExtractCorrelatedCols implements op.PhysicalPlan interface.
+func (p *PhysicalJSONTable) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	return expression.ExtractCorColumns(p.DocExpr)
+}
+
+// MemoryUsage return the memory usage of PhysicalJSONTable
+func (p *PhysicalJSONTable) MemoryUsage() (sum int64) {
+	if p == nil {
+		return
+	}
+
+	sum = p.physicalSchemaProducer.MemoryUsage() + p.DocExpr.MemoryUsage() + size.SizeOfPointer + p.Name.MemoryUsage()
+	return
+}
+
+// PhysicalUnpivot is the physical operator of UNPIVOT, it outputs a row for each level projection
+// on every row of the child.
+type PhysicalUnpivot struct {
+	physicalSchemaProducer
+
+	LevelExprs [][]expression.Expression
+}
+
+// Clone implements op.PhysicalPlan interface.
+func (p *PhysicalUnpivot) Clone() (base.PhysicalPlan, error) {
+	np := new(PhysicalUnpivot)
+	base, err := p.physicalSchemaProducer.cloneWithSelf(np)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	np.physicalSchemaProducer = *base
+	for _, levelExprs := range p.LevelExprs {
+		np.LevelExprs = append(np.LevelExprs, util.CloneExprs(levelExprs))
+	}
+	return np, nil
+}
+
+// ExtractCorrelatedCols implements op.PhysicalPlan interface.
+func (p *PhysicalUnpivot) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	corCols := make([]*expression.CorrelatedColumn, 0, len(p.LevelExprs[0]))
+	for _, levelExprs := range p.LevelExprs {
+		for _, expr := range levelExprs {
+			corCols = append(corCols, expression.ExtractCorColumns(expr)...)
+		}
+	}
+	return corCols
+}
+
+// MemoryUsage return the memory usage of PhysicalUnpivot
+func (p *PhysicalUnpivot) MemoryUsage() (sum int64) {
+	if p == nil {
+		return
+	}
+
+	sum = p.physicalSchemaProducer.MemoryUsage() + size.SizeOfSlice + int64(cap(p.LevelExprs))*size.SizeOfSlice
+	for _, levelExprs := range p.LevelExprs {
+		sum += int64(cap(levelExprs)) * size.SizeOfInterface
+		for _, expr := range levelExprs {
+			sum += expr.MemoryUsage()
+		}
+	}
+	return
+}
+
 // PhysicalWindow is the physical operator of window function.
 type PhysicalWindow struct {
 	physicalSchemaProducer
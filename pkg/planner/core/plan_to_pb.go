@@ -271,6 +271,9 @@ func (p *PhysicalTableScan) ToPB(ctx *base.BuildPBContext, storeType kv.StoreTyp
 	if p.isPartition {
 		tsExec.TableId = p.physicalTableID
 	}
+	if storeType == kv.TiKV {
+		tsExec.AnnQuery = p.AnnQuery
+	}
 	executorID := ""
 	if storeType == kv.TiFlash {
 		executorID = p.ExplainID().String()
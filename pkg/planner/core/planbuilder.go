@@ -98,6 +98,9 @@ const (
 	expressionClause
 	windowOrderByClause
 	partitionByClause
+	tableFunctionClause
+	qualifyClause
+	pivotClause
 )
 
 var clauseMsg = map[clauseCode]string{
@@ -113,6 +116,9 @@ var clauseMsg = map[clauseCode]string{
 	expressionClause:    "expression",
 	windowOrderByClause: "window order by",
 	partitionByClause:   "window partition by",
+	tableFunctionClause: "a table function argument",
+	qualifyClause:       "qualify clause",
+	pivotClause:         "pivot clause",
 }
 
 type capFlagType = uint64
@@ -227,10 +233,14 @@ type PlanBuilder struct {
 	partitionedTable []table.PartitionedTable
 	// buildingViewStack is used to check whether there is a recursive view.
 	buildingViewStack set.StringSet
+	// buildingFunctionStack is used to check whether there is a recursive stored function.
+	buildingFunctionStack set.StringSet
 	// renamingViewName is the name of the view which is being renamed.
 	renamingViewName string
 	// isCreateView indicates whether the query is create view.
 	isCreateView bool
+	// isCreateMaterializedView indicates whether the query is create materialized view.
+	isCreateMaterializedView bool
 
 	// evalDefaultExpr needs this information to find the corresponding column.
 	// It stores the OutputNames before buildProjection.
@@ -512,11 +522,24 @@ func (b *PlanBuilder) Build(ctx context.Context, node ast.Node) (base.Plan, erro
 		if x.SelectIntoOpt != nil {
 			return b.buildSelectInto(ctx, x)
 		}
-		return b.buildSelect(ctx, x)
+		p, err := b.buildSelect(ctx, x)
+		if err != nil {
+			return nil, err
+		}
+		mvPlan, err := b.tryBuildMaterializedViewScan(ctx, x, p)
+		if err != nil {
+			return nil, err
+		}
+		if mvPlan != nil {
+			return mvPlan, nil
+		}
+		return p, nil
 	case *ast.SetOprStmt:
 		return b.buildSetOpr(ctx, x)
 	case *ast.UpdateStmt:
 		return b.buildUpdate(ctx, x)
+	case *ast.MergeStmt:
+		return b.buildMerge(ctx, x)
 	case *ast.ShowStmt:
 		return b.buildShow(ctx, x)
 	case *ast.DoStmt:
@@ -532,7 +555,10 @@ func (b *PlanBuilder) Build(ctx context.Context, node ast.Node) (base.Plan, erro
 		*ast.GrantStmt, *ast.DropUserStmt, *ast.AlterUserStmt, *ast.AlterRangeStmt, *ast.RevokeStmt, *ast.KillStmt, *ast.DropStatsStmt,
 		*ast.GrantRoleStmt, *ast.RevokeRoleStmt, *ast.SetRoleStmt, *ast.SetDefaultRoleStmt, *ast.ShutdownStmt,
 		*ast.RenameUserStmt, *ast.NonTransactionalDMLStmt, *ast.SetSessionStatesStmt, *ast.SetResourceGroupStmt,
-		*ast.ImportIntoActionStmt, *ast.CalibrateResourceStmt, *ast.AddQueryWatchStmt, *ast.DropQueryWatchStmt:
+		*ast.ImportIntoActionStmt, *ast.CalibrateResourceStmt, *ast.AddQueryWatchStmt, *ast.DropQueryWatchStmt,
+		*ast.RefreshMaterializedViewStmt, *ast.ProcedureInfo, *ast.DropProcedureStmt, *ast.CreateFunctionStmt,
+		*ast.DropFunctionStmt, *ast.XAStmt, *ast.CreateEventStmt, *ast.AlterEventStmt, *ast.DropEventStmt, *ast.CreateChangefeedStmt,
+		*ast.DropChangefeedStmt, *ast.ChangefeedActionStmt, *ast.RecommendIndexStmt, *ast.CreateStatisticsStmt:
 		return b.buildSimple(ctx, node.(ast.StmtNode))
 	case ast.DDLNode:
 		return b.buildDDL(ctx, x)
@@ -942,6 +968,9 @@ func (*PlanBuilder) detectSelectWindow(sel *ast.SelectStmt) bool {
 			}
 		}
 	}
+	if sel.Qualify != nil && ast.HasWindowFlag(sel.Qualify) {
+		return true
+	}
 	return false
 }
 
@@ -1094,14 +1123,8 @@ func getPossibleAccessPaths(ctx base.PlanContext, tableHints *hint.PlanHints, in
 	}
 
 	// consider hypo-indexes
-	hypoIndexes := ctx.GetSessionVars().HypoIndexes
-	if ctx.GetSessionVars().StmtCtx.InExplainStmt && hypoIndexes != nil {
-		originalTableName := tblInfo.Name.L
-		if hypoIndexes[dbName.L] != nil && hypoIndexes[dbName.L][originalTableName] != nil {
-			for _, index := range hypoIndexes[dbName.L][originalTableName] {
-				publicPaths = append(publicPaths, &util.AccessPath{Index: index})
-			}
-		}
+	for _, index := range getHypoIndexes(ctx, dbName, tblInfo) {
+		publicPaths = append(publicPaths, &util.AccessPath{Index: index})
 	}
 
 	hasScanHint, hasUseOrForce := false, false
@@ -1196,11 +1219,12 @@ func getPossibleAccessPaths(ctx base.PlanContext, tableHints *hint.PlanHints, in
 		available = append(available, tablePath)
 	}
 
-	// If all available paths are Multi-Valued Index, it's possible that the only multi-valued index is inapplicable,
-	// so that the table paths are still added here to avoid failing to find any physical plan.
+	// If all available paths are Multi-Valued Index or FULLTEXT index, it's possible that the only multi-valued
+	// or FULLTEXT index is inapplicable, so that the table paths are still added here to avoid failing to find any
+	// physical plan.
 	allMVIIndexPath := true
 	for _, availablePath := range available {
-		if !isMVIndexPath(availablePath) {
+		if !isMVIndexPath(availablePath) && !isFulltextIndexPath(availablePath) {
 			allMVIIndexPath = false
 		}
 	}
@@ -1377,7 +1401,7 @@ func (b *PlanBuilder) buildAdmin(ctx context.Context, as *ast.AdminStmt) (base.P
 		}
 		ret = p
 		if as.Where != nil {
-			ret, err = b.buildSelection(ctx, p, as.Where, nil)
+			ret, err = b.buildSelection(ctx, p, as.Where, nil, nil)
 			if err != nil {
 				return nil, err
 			}
@@ -1632,6 +1656,10 @@ func (b *PlanBuilder) buildPhysicalIndexLookUpReaders(ctx context.Context, dbNam
 			// Skip checking clustered index.
 			continue
 		}
+		if idxInfo.Tp == model.IndexTypeFulltext {
+			// A FULLTEXT index has an entry for every token, it can't be checked against the rows.
+			continue
+		}
 		if idxInfo.State != model.StatePublic {
 			logutil.Logger(ctx).Info("build physical index lookup reader, the index isn't public",
 				zap.String("index", idxInfo.Name.O),
@@ -1903,7 +1931,7 @@ func (b *PlanBuilder) getMustAnalyzedColumns(tbl *ast.TableName, cols *calcOnceM
 		}
 		virtualExprs := make([]expression.Expression, 0, len(tblInfo.Columns))
 		for _, idx := range tblInfo.Indices {
-			if idx.State != model.StatePublic || idx.MVIndex {
+			if idx.State != model.StatePublic || idx.MVIndex || idx.Tp == model.IndexTypeFulltext {
 				continue
 			}
 			for _, idxCol := range idx.Columns {
@@ -2122,7 +2150,8 @@ func getModifiedIndexesInfoForAnalyze(
 	idxsInfo := make([]*model.IndexInfo, 0, len(tblInfo.Indices))
 	independentIdxsInfo := make([]*model.IndexInfo, 0)
 	for _, originIdx := range tblInfo.Indices {
-		if originIdx.State != model.StatePublic {
+		// The entries of a FULLTEXT index are the tokens of the texts, it has no statistics.
+		if originIdx.State != model.StatePublic || originIdx.Tp == model.IndexTypeFulltext {
 			continue
 		}
 		if originIdx.MVIndex {
@@ -2502,6 +2531,10 @@ func (b *PlanBuilder) buildAnalyzeTable(as *ast.AnalyzeTableStmt, opts map[ast.A
 				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing multi-valued indexes is not supported, skip %s", idx.Name.L))
 				continue
 			}
+			if idx.Tp == model.IndexTypeFulltext {
+				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing fulltext indexes is not supported, skip %s", idx.Name.L))
+				continue
+			}
 			p.IdxTasks = append(p.IdxTasks, generateIndexTasks(idx, as, tbl.TableInfo, partitionNames, physicalIDs, version)...)
 		}
 		handleCols := BuildHandleColsForAnalyze(b.ctx, tbl.TableInfo, true, nil)
@@ -2578,6 +2611,10 @@ func (b *PlanBuilder) buildAnalyzeIndex(as *ast.AnalyzeTableStmt, opts map[ast.A
 			b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing multi-valued indexes is not supported, skip %s", idx.Name.L))
 			continue
 		}
+		if idx.Tp == model.IndexTypeFulltext {
+			b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing fulltext indexes is not supported, skip %s", idx.Name.L))
+			continue
+		}
 		p.IdxTasks = append(p.IdxTasks, generateIndexTasks(idx, as, tblInfo, names, physicalIDs, version)...)
 	}
 	return p, nil
@@ -2608,6 +2645,10 @@ func (b *PlanBuilder) buildAnalyzeAllIndex(as *ast.AnalyzeTableStmt, opts map[as
 				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing multi-valued indexes is not supported, skip %s", idx.Name.L))
 				continue
 			}
+			if idx.Tp == model.IndexTypeFulltext {
+				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing fulltext indexes is not supported, skip %s", idx.Name.L))
+				continue
+			}
 
 			p.IdxTasks = append(p.IdxTasks, generateIndexTasks(idx, as, tblInfo, names, physicalIDs, version)...)
 		}
@@ -3028,6 +3069,31 @@ func buildCalibrateResourceSchema() (*expression.Schema, types.NameSlice) {
 	return schema.col2Schema(), schema.names
 }
 
+func buildRecommendIndexSchema() (*expression.Schema, types.NameSlice) {
+	longlongSize, _ := mysql.GetDefaultFieldLengthAndDecimal(mysql.TypeLonglong)
+	doubleSize, _ := mysql.GetDefaultFieldLengthAndDecimal(mysql.TypeDouble)
+	cols := newColumnsWithNames(8)
+	cols.Append(buildColumnWithName("", "Database", mysql.TypeVarchar, mysql.MaxDatabaseNameLength))
+	cols.Append(buildColumnWithName("", "Table", mysql.TypeVarchar, mysql.MaxTableNameLength))
+	cols.Append(buildColumnWithName("", "Index_Name", mysql.TypeVarchar, mysql.MaxIndexIdentifierLen))
+	cols.Append(buildColumnWithName("", "Index_Columns", mysql.TypeVarchar, 256))
+	cols.Append(buildColumnWithName("", "Est_Index_Size", mysql.TypeLonglong, longlongSize))
+	cols.Append(buildColumnWithName("", "Est_Benefit", mysql.TypeDouble, doubleSize))
+	cols.Append(buildColumnWithName("", "Affected_Digests", mysql.TypeVarchar, 1024))
+	cols.Append(buildColumnWithName("", "Create_Index_Statement", mysql.TypeVarchar, 512))
+	return cols.col2Schema(), cols.names
+}
+
+func buildXARecoverSchema() (*expression.Schema, types.NameSlice) {
+	longlongSize, _ := mysql.GetDefaultFieldLengthAndDecimal(mysql.TypeLonglong)
+	cols := newColumnsWithNames(4)
+	cols.Append(buildColumnWithName("", "formatID", mysql.TypeLonglong, longlongSize))
+	cols.Append(buildColumnWithName("", "gtrid_length", mysql.TypeLonglong, longlongSize))
+	cols.Append(buildColumnWithName("", "bqual_length", mysql.TypeLonglong, longlongSize))
+	cols.Append(buildColumnWithName("", "data", mysql.TypeVarchar, 128))
+	return cols.col2Schema(), cols.names
+}
+
 func buildAddQueryWatchSchema() (*expression.Schema, types.NameSlice) {
 	longlongSize, _ := mysql.GetDefaultFieldLengthAndDecimal(mysql.TypeLonglong)
 	cols := newColumnsWithNames(1)
@@ -3117,6 +3183,7 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (base.P
 			Extended:              show.Extended,
 			Limit:                 show.Limit,
 			ImportJobID:           show.ImportJobID,
+			Procedure:             show.Procedure,
 		},
 	}.Init(b.ctx)
 	isView := false
@@ -3204,6 +3271,13 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (base.P
 		}
 	case ast.ShowReplicaStatus:
 		return nil, dbterror.ErrNotSupportedYet.GenWithStackByArgs("SHOW {REPLICA | SLAVE} STATUS")
+	case ast.ShowEvents:
+		if p.DBName == "" {
+			return nil, plannererrors.ErrNoDB
+		}
+	case ast.ShowChangefeeds:
+		err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", err)
 	}
 
 	schema, names := buildShowSchema(show, isView, isSequence)
@@ -3217,16 +3291,24 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (base.P
 	np = p
 	// If we have ShowPredicateExtractor, we do not buildSelection with Pattern
 	if show.Pattern != nil && buildPattern {
+		patternCol := p.OutputNames()[0].ColName
+		if show.Tp == ast.ShowProcedureStatus || show.Tp == ast.ShowFunctionStatus || show.Tp == ast.ShowEvents {
+			// The pattern of SHOW PROCEDURE STATUS, SHOW FUNCTION STATUS and SHOW EVENTS matches the `Name` column, which follows the `Db` column.
+			patternCol = p.OutputNames()[1].ColName
+		} else if show.Tp == ast.ShowTriggers {
+			// The pattern of SHOW TRIGGERS matches the `Table` column.
+			patternCol = p.OutputNames()[2].ColName
+		}
 		show.Pattern.Expr = &ast.ColumnNameExpr{
-			Name: &ast.ColumnName{Name: p.OutputNames()[0].ColName},
+			Name: &ast.ColumnName{Name: patternCol},
 		}
-		np, err = b.buildSelection(ctx, np, show.Pattern, nil)
+		np, err = b.buildSelection(ctx, np, show.Pattern, nil, nil)
 		if err != nil {
 			return nil, err
 		}
 	}
 	if show.Where != nil {
-		np, err = b.buildSelection(ctx, np, show.Where, nil)
+		np, err = b.buildSelection(ctx, np, show.Where, nil, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -3304,6 +3386,58 @@ func (b *PlanBuilder) buildSimple(ctx context.Context, node ast.StmtNode) (base.
 	case *ast.DropQueryWatchStmt:
 		err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or RESOURCE_GROUP_ADMIN")
 		b.visitInfo = appendDynamicVisitInfo(b.visitInfo, "RESOURCE_GROUP_ADMIN", false, err)
+	case *ast.RecommendIndexStmt:
+		// The queries are planned by an internal session, which can access all the tables.
+		err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", err)
+		p.setSchemaAndNames(buildRecommendIndexSchema())
+	case *ast.CreateStatisticsStmt:
+		// It's the same as `ALTER TABLE ... ADD STATS_EXTENDED`.
+		var selectErr, insertErr error
+		if user := b.ctx.GetSessionVars().User; user != nil {
+			selectErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("CREATE STATISTICS", user.AuthUsername,
+				user.AuthHostname, raw.Table.Name.L)
+			insertErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("CREATE STATISTICS", user.AuthUsername,
+				user.AuthHostname, "stats_extended")
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SelectPriv, raw.Table.Schema.L, raw.Table.Name.L, "", selectErr)
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.InsertPriv, mysql.SystemDB, "stats_extended", "", insertErr)
+	case *ast.XAStmt:
+		if raw.Tp == ast.XARecover {
+			err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("XA_RECOVER_ADMIN")
+			b.visitInfo = appendDynamicVisitInfo(b.visitInfo, "XA_RECOVER_ADMIN", false, err)
+			p.setSchemaAndNames(buildXARecoverSchema())
+		}
+	case *ast.RefreshMaterializedViewStmt:
+		// Refreshing a view deletes all its rows and inserts the result of the view query.
+		if user := b.ctx.GetSessionVars().User; user != nil {
+			for _, priv := range []mysql.PrivilegeType{mysql.DeletePriv, mysql.InsertPriv} {
+				err := plannererrors.ErrTableaccessDenied.GenWithStackByArgs(priv.String(), user.AuthUsername,
+					user.AuthHostname, raw.ViewName.Name.L)
+				b.visitInfo = appendVisitInfo(b.visitInfo, priv, raw.ViewName.Schema.L, raw.ViewName.Name.L, "", err)
+			}
+		}
+	case *ast.ProcedureInfo:
+		b.appendCreateRoutineVisitInfo(raw.ProcedureName, raw.Definer)
+	case *ast.DropProcedureStmt:
+		b.appendDropRoutineVisitInfo(raw.ProcedureName)
+	case *ast.CreateFunctionStmt:
+		// The body of a function can't read tables, so it's evaluated in the calling query whatever its SQL SECURITY is.
+		b.appendCreateRoutineVisitInfo(raw.FunctionName, raw.Definer)
+	case *ast.DropFunctionStmt:
+		b.appendDropRoutineVisitInfo(raw.FunctionName)
+	case *ast.CreateEventStmt:
+		b.appendEventVisitInfo(raw.EventName.Schema.L)
+	case *ast.AlterEventStmt:
+		b.appendEventVisitInfo(raw.EventName.Schema.L)
+		if raw.NewName != nil {
+			b.appendEventVisitInfo(raw.NewName.Schema.L)
+		}
+	case *ast.DropEventStmt:
+		b.appendEventVisitInfo(raw.EventName.Schema.L)
+	case *ast.CreateChangefeedStmt, *ast.DropChangefeedStmt, *ast.ChangefeedActionStmt:
+		err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", err)
 	case *ast.GrantRoleStmt:
 		err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or ROLE_ADMIN")
 		b.visitInfo = appendDynamicVisitInfo(b.visitInfo, "ROLE_ADMIN", false, err)
@@ -3385,6 +3519,40 @@ func (b *PlanBuilder) buildSimple(ctx context.Context, node ast.StmtNode) (base.
 	return p, nil
 }
 
+// appendEventVisitInfo requires the EVENT privilege on the schema of an event.
+func (b *PlanBuilder) appendEventVisitInfo(schema string) {
+	var err error
+	if user := b.ctx.GetSessionVars().User; user != nil {
+		err = plannererrors.ErrDBaccessDenied.GenWithStackByArgs(user.AuthUsername, user.AuthHostname, schema)
+	}
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.EventPriv, schema, "", "", err)
+}
+
+// appendCreateRoutineVisitInfo requires the CREATE ROUTINE privilege on the schema of a new routine,
+// and the SUPER privilege to create it for another definer.
+func (b *PlanBuilder) appendCreateRoutineVisitInfo(name *ast.TableName, definer *auth.UserIdentity) {
+	var err error
+	user := b.ctx.GetSessionVars().User
+	if user != nil {
+		err = plannererrors.ErrDBaccessDenied.GenWithStackByArgs(user.AuthUsername, user.AuthHostname, name.Schema.L)
+	}
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreateRoutinePriv, name.Schema.L, "", "", err)
+	if user != nil && definer != nil && !definer.CurrentUser &&
+		(definer.Username != user.AuthUsername || definer.Hostname != user.AuthHostname) {
+		err = plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", err)
+	}
+}
+
+func (b *PlanBuilder) appendDropRoutineVisitInfo(name *ast.TableName) {
+	var err error
+	if user := b.ctx.GetSessionVars().User; user != nil {
+		err = plannererrors.ErrProcaccessDenied.GenWithStackByArgs("alter routine", user.AuthUsername, user.AuthHostname,
+			name.Schema.L+"."+name.Name.O)
+	}
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.AlterRoutinePriv, name.Schema.L, "", "", err)
+}
+
 func collectVisitInfoFromRevokeStmt(sctx base.PlanContext, vi []visitInfo, stmt *ast.RevokeStmt) ([]visitInfo, error) {
 	// To use REVOKE, you must have the GRANT OPTION privilege,
 	// and you must have the privileges that you are granting.
@@ -3608,6 +3776,14 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 		}
 		return nil, err
 	}
+	// The rows of a materialized view are only written by the refresh.
+	if tableInfo.IsMaterializedView() && !b.ctx.GetSessionVars().InRestrictedSQL {
+		op := "INSERT"
+		if insert.IsReplace {
+			op = "REPLACE"
+		}
+		return nil, plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(tableInfo.Name.O, op)
+	}
 	// Build Schema with DBName otherwise ColumnRef with DBName cannot match any Column in Schema.
 	schema, names, err := expression.TableInfo2SchemaAndNames(b.ctx.GetExprCtx(), tn.Schema, tableInfo)
 	if err != nil {
@@ -3715,6 +3891,16 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 		return nil, err
 	}
 
+	if insert.Returning != nil {
+		var schema *expression.Schema
+		var names types.NameSlice
+		insertPlan.Returning, schema, names, err = b.buildReturning(ctx, mockTablePlan, insert.Returning)
+		if err != nil {
+			return nil, err
+		}
+		insertPlan.setSchemaAndNames(schema, names)
+	}
+
 	err = insertPlan.ResolveIndices()
 	if err != nil {
 		return nil, err
@@ -3723,6 +3909,39 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 	return insertPlan, err
 }
 
+// buildReturning builds the expressions of the RETURNING clause over the rows of p, and returns them
+// with the schema and the names of the result set.
+func (b *PlanBuilder) buildReturning(ctx context.Context, p base.LogicalPlan, returning *ast.FieldList) ([]expression.Expression, *expression.Schema, types.NameSlice, error) {
+	fields, err := b.unfoldWildStar(p, returning.Fields)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	b.curClause = fieldList
+	exprs := make([]expression.Expression, 0, len(fields))
+	schema := expression.NewSchema(make([]*expression.Column, 0, len(fields))...)
+	names := make(types.NameSlice, 0, len(fields))
+	for _, field := range fields {
+		expr, np, err := b.rewrite(ctx, field.Expr, p, nil, true)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if np != p {
+			return nil, nil, nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs("subqueries in the RETURNING clause")
+		}
+		col, name, err := b.buildProjectionField(ctx, p, field, expr)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		// The column referenced by the clause is shared with p, so clone it before it's used by the result set.
+		col = col.Clone().(*expression.Column)
+		col.Index = schema.Len()
+		exprs = append(exprs, expr)
+		schema.Append(col)
+		names = append(names, name)
+	}
+	return exprs, schema, names, nil
+}
+
 func (p *Insert) resolveOnDuplicate(onDup []*ast.Assignment, tblInfo *model.TableInfo, yield func(ast.ExprNode) (expression.Expression, error)) (map[string]struct{}, error) {
 	onDupColSet := make(map[string]struct{}, len(onDup))
 	colMap := make(map[string]*table.Column, len(p.Table.Cols()))
@@ -4714,6 +4933,16 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (base.Plan
 			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "",
 				"", "", err)
 		}
+	case *ast.CreateMaterializedViewStmt:
+		if err := b.buildCreateMaterializedView(ctx, v); err != nil {
+			return nil, err
+		}
+		if user := b.ctx.GetSessionVars().User; user != nil {
+			authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("CREATE", user.AuthUsername,
+				user.AuthHostname, v.ViewName.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreatePriv, v.ViewName.Schema.L,
+			v.ViewName.Name.L, "", authErr)
 	case *ast.CreateSequenceStmt:
 		if b.ctx.GetSessionVars().User != nil {
 			authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("CREATE", b.ctx.GetSessionVars().User.AuthUsername,
@@ -4744,6 +4973,19 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (base.Plan
 			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DropPriv, tableVal.Schema.L,
 				tableVal.Name.L, "", authErr)
 		}
+	case *ast.CreateTriggerStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("TRIGGER", b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.Table.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.TriggerPriv, v.Table.Schema.L,
+			v.Table.Name.L, "", authErr)
+	case *ast.DropTriggerStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = plannererrors.ErrDBaccessDenied.GenWithStackByArgs(b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.TriggerName.Schema.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.TriggerPriv, v.TriggerName.Schema.L, "", "", authErr)
 	case *ast.DropSequenceStmt:
 		for _, sequence := range v.Sequences {
 			if b.ctx.GetSessionVars().User != nil {
@@ -4990,23 +5232,51 @@ func (b *PlanBuilder) buildExplain(ctx context.Context, explain *ast.ExplainStmt
 }
 
 func (b *PlanBuilder) buildSelectInto(ctx context.Context, sel *ast.SelectStmt) (base.Plan, error) {
-	if sem.IsEnabled() {
+	selectIntoInfo := sel.SelectIntoOpt
+	if selectIntoInfo.Tp == ast.SelectIntoVars {
+		// The local variables of stored procedures are replaced by the procedure interpreter,
+		// so only user variables are accepted here.
+		for _, v := range selectIntoInfo.Variables {
+			if col, ok := v.(*ast.ColumnNameExpr); ok {
+				return nil, plannererrors.ErrSpUndeclaredVar.GenWithStackByArgs(col.Name.Name.O)
+			}
+		}
+	} else if sem.IsEnabled() {
 		return nil, plannererrors.ErrNotSupportedWithSem.GenWithStackByArgs("SELECT INTO")
 	}
-	selectIntoInfo := sel.SelectIntoOpt
 	sel.SelectIntoOpt = nil
 	sctx, err := AsSctx(b.ctx)
 	if err != nil {
 		return nil, err
 	}
-	targetPlan, _, err := OptimizeAstNode(ctx, sctx, sel, b.is)
+	targetPlan, targetNames, err := OptimizeAstNode(ctx, sctx, sel, b.is)
 	if err != nil {
 		return nil, err
 	}
+	if selectIntoInfo.Tp == ast.SelectIntoVars {
+		if targetPlan.Schema().Len() != len(selectIntoInfo.Variables) {
+			return nil, plannererrors.ErrWrongNumberOfColumnsInSelect.GenWithStackByArgs()
+		}
+		return &SelectInto{TargetPlan: targetPlan, IntoOpt: selectIntoInfo}, nil
+	}
 	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.FilePriv, "", "", "", plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("FILE"))
+	mockTablePlan := LogicalTableDual{}.Init(b.ctx, b.getSelectOffset())
+	options := make([]*LoadDataOpt, 0, len(selectIntoInfo.Options))
+	for _, opt := range selectIntoInfo.Options {
+		intoOpt := LoadDataOpt{Name: opt.Name}
+		if opt.Value != nil {
+			intoOpt.Value, _, err = b.rewrite(ctx, opt.Value, mockTablePlan, nil, true)
+			if err != nil {
+				return nil, err
+			}
+		}
+		options = append(options, &intoOpt)
+	}
 	return &SelectInto{
 		TargetPlan:     targetPlan,
+		TargetNames:    targetNames,
 		IntoOpt:        selectIntoInfo,
+		Options:        options,
 		LineFieldsInfo: NewLineFieldsInfo(selectIntoInfo.FieldsInfo, selectIntoInfo.LinesInfo),
 	}, nil
 }
@@ -5160,6 +5430,12 @@ func buildShowSchema(s *ast.ShowStmt, isView bool, isSequence bool) (schema *exp
 		names = []string{"View", "Create View", "character_set_client", "collation_connection"}
 	case ast.ShowCreateDatabase:
 		names = []string{"Database", "Create Database"}
+	case ast.ShowCreateProcedure:
+		names = []string{"Procedure", "sql_mode", "Create Procedure", "character_set_client", "collation_connection", "Database Collation"}
+	case ast.ShowCreateFunction:
+		names = []string{"Function", "sql_mode", "Create Function", "character_set_client", "collation_connection", "Database Collation"}
+	case ast.ShowCreateEvent:
+		names = []string{"Event", "sql_mode", "time_zone", "Create Event", "character_set_client", "collation_connection", "Database Collation"}
 	case ast.ShowDrainerStatus:
 		names = []string{"NodeID", "Address", "State", "Max_Commit_Ts", "Update_Time"}
 		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeLonglong, mysql.TypeVarchar}
@@ -5260,6 +5536,10 @@ func buildShowSchema(s *ast.ShowStmt, isView bool, isSequence bool) (schema *exp
 	case ast.ShowImportJobs:
 		names = importIntoSchemaNames
 		ftypes = importIntoSchemaFTypes
+	case ast.ShowChangefeeds:
+		names = []string{"Name", "Instance", "Filter", "Sink_URI", "Format", "State", "Checkpoint_TS", "Error", "Created"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar,
+			mysql.TypeVarchar, mysql.TypeLonglong, mysql.TypeVarchar, mysql.TypeDatetime}
 	}
 	return convert2OutputSchemasAndNames(names, ftypes)
 }
@@ -1889,6 +1889,10 @@ func tryUpdatePointPlan(ctx base.PlanContext, updateStmt *ast.UpdateStmt) base.P
 	if checkIfAssignmentListHasSubQuery(updateStmt.List) {
 		return nil
 	}
+	// The RETURNING clause is evaluated on the rows of the select plan, which the point_get plan doesn't keep.
+	if updateStmt.Returning != nil {
+		return nil
+	}
 
 	selStmt := &ast.SelectStmt{
 		Fields:  &ast.FieldList{},
@@ -1923,6 +1927,10 @@ func buildPointUpdatePlan(ctx base.PlanContext, pointPlan base.PhysicalPlan, dbN
 	if checkFastPlanPrivilege(ctx, dbName, tbl.Name.L, mysql.SelectPriv, mysql.UpdatePriv) != nil {
 		return nil
 	}
+	// Let the general plan builder report the error of updating a materialized view.
+	if tbl.IsMaterializedView() && !ctx.GetSessionVars().InRestrictedSQL {
+		return nil
+	}
 	orderedList, allAssignmentsAreConstant := buildOrderedList(ctx, pointPlan, updateStmt.List)
 	if orderedList == nil {
 		return nil
@@ -2012,7 +2020,7 @@ func buildOrderedList(ctx base.PlanContext, plan base.Plan, list []*ast.Assignme
 }
 
 func tryDeletePointPlan(ctx base.PlanContext, delStmt *ast.DeleteStmt) base.Plan {
-	if delStmt.IsMultiTable {
+	if delStmt.IsMultiTable || delStmt.Returning != nil {
 		return nil
 	}
 	selStmt := &ast.SelectStmt{
@@ -2046,6 +2054,10 @@ func buildPointDeletePlan(ctx base.PlanContext, pointPlan base.PhysicalPlan, dbN
 	if checkFastPlanPrivilege(ctx, dbName, tbl.Name.L, mysql.SelectPriv, mysql.DeletePriv) != nil {
 		return nil
 	}
+	// Let the general plan builder report the error of deleting from a materialized view.
+	if tbl.IsMaterializedView() && !ctx.GetSessionVars().InRestrictedSQL {
+		return nil
+	}
 	handleCols := buildHandleCols(ctx, tbl, pointPlan.Schema())
 	delPlan := Delete{
 		SelectPlan: pointPlan,
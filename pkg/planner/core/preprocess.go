@@ -267,7 +267,12 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		p.stmtTp = TypeCreate
 		p.flag |= inCreateOrDropTable
 		p.checkCreateViewGrammar(node)
-		p.checkCreateViewWithSelectGrammar(node)
+		p.checkCreateViewWithSelectGrammar(node.Select)
+	case *ast.CreateMaterializedViewStmt:
+		p.stmtTp = TypeCreate
+		p.flag |= inCreateOrDropTable
+		p.checkCreateMaterializedViewGrammar(node)
+		p.checkCreateViewWithSelectGrammar(node.Select)
 	case *ast.DropTableStmt:
 		p.flag |= inCreateOrDropTable
 		p.stmtTp = TypeDrop
@@ -325,6 +330,57 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 			}
 		}
 		return in, true
+	case *ast.ProcedureInfo:
+		p.stmtTp = TypeCreate
+		p.resolveProcedureName(node.ProcedureName)
+		// The statements of the procedure body are checked when the procedure is called.
+		return in, true
+	case *ast.DropProcedureStmt:
+		p.stmtTp = TypeDrop
+		p.resolveProcedureName(node.ProcedureName)
+		return in, true
+	case *ast.CreateFunctionStmt:
+		p.stmtTp = TypeCreate
+		p.resolveProcedureName(node.FunctionName)
+		// The body of the function is checked when the function is called.
+		return in, true
+	case *ast.DropFunctionStmt:
+		p.stmtTp = TypeDrop
+		p.resolveProcedureName(node.FunctionName)
+		return in, true
+	case *ast.CreateTriggerStmt:
+		p.stmtTp = TypeCreate
+		p.resolveProcedureName(node.TriggerName)
+		p.handleTableName(node.Table)
+		// The body refers to the NEW and OLD rows, it's checked by DDL when the trigger is created.
+		return in, true
+	case *ast.DropTriggerStmt:
+		p.stmtTp = TypeDrop
+		p.resolveProcedureName(node.TriggerName)
+		return in, true
+	case *ast.CreateEventStmt:
+		p.stmtTp = TypeCreate
+		p.resolveProcedureName(node.EventName)
+		// The schedule is evaluated when the event is created, and the body is checked when it's executed.
+		return in, true
+	case *ast.AlterEventStmt:
+		p.stmtTp = TypeAlter
+		p.resolveProcedureName(node.EventName)
+		if node.NewName != nil {
+			p.resolveProcedureName(node.NewName)
+		}
+		return in, true
+	case *ast.DropEventStmt:
+		p.stmtTp = TypeDrop
+		p.resolveProcedureName(node.EventName)
+		return in, true
+	case *ast.CreateChangefeedStmt:
+		p.stmtTp = TypeCreate
+		// The tables of the filter may be created after the changefeed.
+		for _, tbl := range node.Tables {
+			p.resolveProcedureName(tbl)
+		}
+		return in, true
 	case *ast.RecoverTableStmt:
 		// The specified table in recover table statement maybe already been dropped.
 		// So skip check table name here, otherwise, recover table [table_name] syntax will return
@@ -362,10 +418,6 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		if node.FnName.L == ast.NextVal || node.FnName.L == ast.LastVal || node.FnName.L == ast.SetVal {
 			p.flag |= inSequenceFunction
 		}
-		// not support procedure right now.
-		if node.Schema.L != "" {
-			p.err = expression.ErrFunctionNotExists.GenWithStackByArgs("FUNCTION", node.Schema.L+"."+node.FnName.L)
-		}
 	case *ast.BRIEStmt:
 		if node.Kind == ast.BRIEKindRestore {
 			p.flag |= inCreateOrDropTable
@@ -570,7 +622,7 @@ func (p *preprocessor) Leave(in ast.Node) (out ast.Node, ok bool) {
 		p.flag &= ^inCreateOrDropTable
 		p.checkAutoIncrement(x)
 		p.checkContainDotColumn(x)
-	case *ast.CreateViewStmt:
+	case *ast.CreateViewStmt, *ast.CreateMaterializedViewStmt:
 		p.flag &= ^inCreateOrDropTable
 	case *ast.DropTableStmt, *ast.AlterTableStmt, *ast.RenameTableStmt:
 		p.flag &= ^inCreateOrDropTable
@@ -967,6 +1019,20 @@ func (p *preprocessor) checkCreateViewGrammar(stmt *ast.CreateViewStmt) {
 	}
 }
 
+func (p *preprocessor) checkCreateMaterializedViewGrammar(stmt *ast.CreateMaterializedViewStmt) {
+	vName := stmt.ViewName.Name.String()
+	if util.IsInCorrectIdentifierName(vName) {
+		p.err = dbterror.ErrWrongTableName.GenWithStackByArgs(vName)
+		return
+	}
+	for _, col := range stmt.Cols {
+		if util.IsInCorrectIdentifierName(col.String()) {
+			p.err = dbterror.ErrWrongColumnName.GenWithStackByArgs(col)
+			return
+		}
+	}
+}
+
 func (p *preprocessor) checkCreateViewWithSelect(stmt ast.Node) {
 	switch s := stmt.(type) {
 	case *ast.SelectStmt:
@@ -985,8 +1051,8 @@ func (p *preprocessor) checkCreateViewWithSelect(stmt ast.Node) {
 	}
 }
 
-func (p *preprocessor) checkCreateViewWithSelectGrammar(stmt *ast.CreateViewStmt) {
-	switch stmt := stmt.Select.(type) {
+func (p *preprocessor) checkCreateViewWithSelectGrammar(sel ast.StmtNode) {
+	switch stmt := sel.(type) {
 	case *ast.SelectStmt:
 		p.checkCreateViewWithSelect(stmt)
 	case *ast.SetOprStmt:
@@ -1640,6 +1706,13 @@ func (p *preprocessor) resolveShowStmt(node *ast.ShowStmt) {
 	} else if node.Table != nil && node.Table.Schema.L == "" {
 		node.Table.Schema = model.NewCIStr(node.DBName)
 	}
+	if node.Procedure != nil && node.Procedure.Schema.L == "" {
+		if node.DBName == "" {
+			p.err = plannererrors.ErrNoDB
+			return
+		}
+		node.Procedure.Schema = model.NewCIStr(node.DBName)
+	}
 	if node.User != nil && node.User.CurrentUser {
 		// Fill the Username and Hostname with the current user.
 		currentUser := p.sctx.GetSessionVars().User
@@ -1652,6 +1725,18 @@ func (p *preprocessor) resolveShowStmt(node *ast.ShowStmt) {
 	}
 }
 
+func (p *preprocessor) resolveProcedureName(name *ast.TableName) {
+	if name.Schema.L != "" {
+		return
+	}
+	currentDB := p.sctx.GetSessionVars().CurrentDB
+	if currentDB == "" {
+		p.err = plannererrors.ErrNoDB
+		return
+	}
+	name.Schema = model.NewCIStr(currentDB)
+}
+
 func (p *preprocessor) resolveExecuteStmt(node *ast.ExecuteStmt) {
 	prepared, err := GetPreparedStmt(node, p.sctx.GetSessionVars())
 	if err != nil {
@@ -351,6 +351,15 @@ func (p *PhysicalIndexJoin) ResolveIndices() (err error) {
 		return errors.Errorf("Some columns of %v cannot find the reference from its child(ren)", p.ExplainID().String())
 	}
 
+	if p.AdaptiveHashJoin != nil {
+		// The outer child may be replaced after the task is attached, keep it the same as the index join's.
+		p.AdaptiveHashJoin.SetChild(1-p.InnerChildIdx, p.children[1-p.InnerChildIdx])
+		err = p.AdaptiveHashJoin.Children()[p.InnerChildIdx].ResolveIndices()
+		if err != nil {
+			return err
+		}
+		err = p.AdaptiveHashJoin.ResolveIndicesItself()
+	}
 	return
 }
 
@@ -560,6 +569,23 @@ func (p *PhysicalExpand) ResolveIndices() (err error) {
 	return p.ResolveIndicesItself()
 }
 
+// ResolveIndices implements Plan interface.
+func (p *PhysicalUnpivot) ResolveIndices() (err error) {
+	err = p.physicalSchemaProducer.ResolveIndices()
+	if err != nil {
+		return err
+	}
+	for _, levelExprs := range p.LevelExprs {
+		for i, expr := range levelExprs {
+			levelExprs[i], err = expr.ResolveIndices(p.children[0].Schema())
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return
+}
+
 // ResolveIndices implements Plan interface.
 func (p *basePhysicalAgg) ResolveIndices() (err error) {
 	err = p.physicalSchemaProducer.ResolveIndices()
@@ -836,6 +862,63 @@ func (p *Update) ResolveIndices() (err error) {
 			return err
 		}
 	}
+	for i, expr := range p.Returning {
+		p.Returning[i], err = expr.ResolveIndices(schema)
+		if err != nil {
+			return err
+		}
+	}
+	return
+}
+
+// ResolveIndices implements Plan interface.
+func (p *Delete) ResolveIndices() (err error) {
+	err = p.baseSchemaProducer.ResolveIndices()
+	if err != nil {
+		return err
+	}
+	schema := p.SelectPlan.Schema()
+	for i, expr := range p.Returning {
+		p.Returning[i], err = expr.ResolveIndices(schema)
+		if err != nil {
+			return err
+		}
+	}
+	return
+}
+
+// ResolveIndices implements Plan interface.
+func (p *Merge) ResolveIndices() (err error) {
+	err = p.baseSchemaProducer.ResolveIndices()
+	if err != nil {
+		return err
+	}
+	schema := p.SelectPlan.Schema()
+	for _, clause := range p.Clauses {
+		if clause.Condition != nil {
+			clause.Condition, err = clause.Condition.ResolveIndices(schema)
+			if err != nil {
+				return err
+			}
+		}
+		switch {
+		case clause.Update != nil:
+			err = clause.Update.ResolveIndices()
+		case clause.Delete != nil:
+			err = clause.Delete.ResolveIndices()
+		case clause.Insert != nil:
+			for i, expr := range clause.Insert.Lists[0] {
+				clause.Insert.Lists[0][i], err = expr.ResolveIndices(schema)
+				if err != nil {
+					return err
+				}
+			}
+			err = clause.Insert.ResolveIndices()
+		}
+		if err != nil {
+			return err
+		}
+	}
 	return
 }
 
@@ -894,6 +977,12 @@ func (p *Insert) ResolveIndices() (err error) {
 			return err
 		}
 	}
+	for i, expr := range p.Returning {
+		p.Returning[i], err = expr.ResolveIndices(p.tableSchema)
+		if err != nil {
+			return err
+		}
+	}
 	return
 }
 
@@ -101,6 +101,36 @@ func (p *LogicalExpand) PruneColumns(parentUsedCols []*expression.Column, opt *o
 	return p, nil
 }
 
+// PruneColumns implements base.LogicalPlan interface.
+// The level projections are pruned along with the schema. The name column is always kept,
+// so that every level still outputs a row.
+func (p *LogicalUnpivot) PruneColumns(parentUsedCols []*expression.Column, opt *optimizetrace.LogicalOptimizeOp) (base.LogicalPlan, error) {
+	used := expression.GetUsedList(p.SCtx().GetExprCtx().GetEvalCtx(), parentUsedCols, p.Schema())
+	prunedColumns := make([]*expression.Column, 0)
+	for i := len(used) - 1; i >= 0; i-- {
+		if !used[i] && !p.schema.Columns[i].EqualColumn(p.NameCol) {
+			prunedColumns = append(prunedColumns, p.schema.Columns[i])
+			p.schema.Columns = append(p.schema.Columns[:i], p.schema.Columns[i+1:]...)
+			p.names = append(p.names[:i], p.names[i+1:]...)
+			for j, levelExprs := range p.LevelExprs {
+				p.LevelExprs[j] = append(levelExprs[:i], levelExprs[i+1:]...)
+			}
+		}
+	}
+	appendColumnPruneTraceStep(p, prunedColumns, opt)
+	selfUsedCols := make([]*expression.Column, 0, p.schema.Len())
+	for _, levelExprs := range p.LevelExprs {
+		selfUsedCols = expression.ExtractColumnsFromExpressions(selfUsedCols, levelExprs, nil)
+	}
+	var err error
+	p.Children()[0], err = p.Children()[0].PruneColumns(selfUsedCols, opt)
+	if err != nil {
+		return nil, err
+	}
+	addConstOneForEmptyProjection(p.Children()[0])
+	return p, nil
+}
+
 // PruneColumns implements base.LogicalPlan interface.
 // If any expression has SetVar function or Sleep function, we do not prune it.
 func (p *LogicalProjection) PruneColumns(parentUsedCols []*expression.Column, opt *optimizetrace.LogicalOptimizeOp) (base.LogicalPlan, error) {
@@ -42,7 +42,7 @@ func appendDerivedTopNTrace(topN base.LogicalPlan, opt *optimizetrace.LogicalOpt
 }
 
 // checkPartitionBy mainly checks if partition by of window function is a prefix of
-// data order (clustered index) of the data source. TiFlash is allowed only for empty partition by.
+// data order (clustered index) of the data source, which is required by TiKV.
 func checkPartitionBy(p *LogicalWindow, d *DataSource) bool {
 	// No window partition by. We are OK.
 	if len(p.PartitionBy) == 0 {
@@ -72,50 +72,63 @@ func checkPartitionBy(p *LogicalWindow, d *DataSource) bool {
 	  - The window function is a simple row number
 	  - With default frame: rows between current row and current row. Check is not necessary since
 	    current row is only frame applicable to row number
-	  - Child is a data source with no tiflash option.
+	  - Child is a data source. The partition by must be a prefix of the data order, unless the data
+	    source can be read from TiFlash by MPP, then the TopN can only be pushed down to TiFlash.
 */
-func windowIsTopN(p *LogicalSelection) (bool, uint64) {
+func windowIsTopN(p *LogicalSelection) (isTopN bool, limit uint64, mppOnly bool) {
 	// Check if child is window function.
 	child, isLogicalWindow := p.Children()[0].(*LogicalWindow)
 	if !isLogicalWindow {
-		return false, 0
+		return false, 0, false
 	}
 
 	if len(p.Conditions) != 1 {
-		return false, 0
+		return false, 0, false
 	}
 
 	// Check if filter is column < constant or column <= constant. If it is in this form find column and constant.
 	column, limitValue := expression.FindUpperBound(p.Conditions[0])
 	if column == nil || limitValue <= 0 {
-		return false, 0
+		return false, 0, false
 	}
 
 	// Check if filter on window function
 	windowColumns := child.GetWindowResultColumns()
 	if len(windowColumns) != 1 || !(column.Equal(p.SCtx().GetExprCtx().GetEvalCtx(), windowColumns[0])) {
-		return false, 0
+		return false, 0, false
 	}
 
 	grandChild := child.Children()[0]
 	dataSource, isDataSource := grandChild.(*DataSource)
 	if !isDataSource {
-		return false, 0
+		return false, 0, false
 	}
 
-	// Give up if TiFlash is one possible access path. Pushing down window aggregation is good enough in this case.
+	hasTiFlashPath := false
 	for _, path := range dataSource.possibleAccessPaths {
 		if path.StoreType == kv.TiFlash {
-			return false, 0
+			hasTiFlashPath = true
+			break
 		}
 	}
+	// Give up if TiFlash is one possible access path but MPP isn't allowed. Pushing down window aggregation
+	// is good enough in this case.
+	if hasTiFlashPath && !p.SCtx().GetSessionVars().IsMPPAllowed() {
+		return false, 0, false
+	}
 
 	if len(child.WindowFuncDescs) == 1 && child.WindowFuncDescs[0].Name == "row_number" &&
-		child.Frame.Type == ast.Rows && child.Frame.Start.Type == ast.CurrentRow && child.Frame.End.Type == ast.CurrentRow &&
-		checkPartitionBy(child, dataSource) {
-		return true, uint64(limitValue)
+		child.Frame.Type == ast.Rows && child.Frame.Start.Type == ast.CurrentRow && child.Frame.End.Type == ast.CurrentRow {
+		// TiFlash computes the TopN of each partition by MPP no matter how the data is ordered.
+		// The TopN without order by is converted to a limit later, which can't be pushed down to TiFlash.
+		if hasTiFlashPath && len(child.OrderBy) > 0 {
+			return true, uint64(limitValue), !checkPartitionBy(child, dataSource)
+		}
+		if !hasTiFlashPath && checkPartitionBy(child, dataSource) {
+			return true, uint64(limitValue), false
+		}
 	}
-	return false, 0
+	return false, 0, false
 }
 
 func (*deriveTopNFromWindow) optimize(_ context.Context, p base.LogicalPlan, opt *optimizetrace.LogicalOptimizeOp) (base.LogicalPlan, bool, error) {
@@ -126,7 +139,7 @@ func (*deriveTopNFromWindow) optimize(_ context.Context, p base.LogicalPlan, opt
 // DeriveTopN implements the LogicalPlan interface.
 func (s *LogicalSelection) DeriveTopN(opt *optimizetrace.LogicalOptimizeOp) base.LogicalPlan {
 	p := s.Self().(*LogicalSelection)
-	windowIsTopN, limitValue := windowIsTopN(p)
+	windowIsTopN, limitValue, mppOnly := windowIsTopN(p)
 	if windowIsTopN {
 		child := p.Children()[0].(*LogicalWindow)
 		grandChild := child.Children()[0].(*DataSource)
@@ -136,7 +149,7 @@ func (s *LogicalSelection) DeriveTopN(opt *optimizetrace.LogicalOptimizeOp) base
 			byItems = append(byItems, &util.ByItems{Expr: col.Col, Desc: col.Desc})
 		}
 		// Build derived Limit
-		derivedTopN := LogicalTopN{Count: limitValue, ByItems: byItems, PartitionBy: child.GetPartitionBy()}.Init(grandChild.SCtx(), grandChild.QueryBlockOffset())
+		derivedTopN := LogicalTopN{Count: limitValue, ByItems: byItems, PartitionBy: child.GetPartitionBy(), MppOnly: mppOnly}.Init(grandChild.SCtx(), grandChild.QueryBlockOffset())
 		derivedTopN.SetChildren(grandChild)
 		/* return select->datasource->topN->window */
 		child.SetChildren(derivedTopN)
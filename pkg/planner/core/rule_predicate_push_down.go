@@ -402,6 +402,23 @@ func (p *LogicalExpand) PredicatePushDown(predicates []expression.Expression, op
 	return append(remained, predicates...), child
 }
 
+// PredicatePushDown implements base.LogicalPlan PredicatePushDown interface.
+func (p *LogicalUnpivot) PredicatePushDown(predicates []expression.Expression, opt *optimizetrace.LogicalOptimizeOp) ([]expression.Expression, base.LogicalPlan) {
+	canBePushed := make([]expression.Expression, 0, len(predicates))
+	canNotBePushed := make([]expression.Expression, 0, len(predicates))
+	for _, cond := range predicates {
+		// The columns which are not unpivoted are output as they are in every level, so the
+		// predicates only referring to them can be pushed down.
+		if expression.ExprFromSchema(cond, p.Children()[0].Schema()) {
+			canBePushed = append(canBePushed, cond)
+		} else {
+			canNotBePushed = append(canNotBePushed, cond)
+		}
+	}
+	p.BaseLogicalPlan.PredicatePushDown(canBePushed, opt)
+	return canNotBePushed, p
+}
+
 // PredicatePushDown implements base.LogicalPlan PredicatePushDown interface.
 func (p *LogicalProjection) PredicatePushDown(predicates []expression.Expression, opt *optimizetrace.LogicalOptimizeOp) (ret []expression.Expression, retPlan base.LogicalPlan) {
 	for _, expr := range p.Exprs {
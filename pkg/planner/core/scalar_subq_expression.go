@@ -150,6 +150,11 @@ func (*ScalarSubQueryExpr) EvalJSON(_ expression.EvalContext, _ chunk.Row) (val
 	return types.BinaryJSON{}, false, errors.Errorf("Evaluation methods is not implemented for ScalarSubQueryExpr")
 }
 
+// EvalVectorFloat32 returns the VectorFloat32 representation of expression.
+func (*ScalarSubQueryExpr) EvalVectorFloat32(_ expression.EvalContext, _ chunk.Row) (val types.VectorFloat32, isNull bool, err error) {
+	return types.ZeroVectorFloat32, false, errors.Errorf("Evaluation methods is not implemented for ScalarSubQueryExpr")
+}
+
 // GetType implements the Expression interface.
 func (s *ScalarSubQueryExpr) GetType(_ expression.EvalContext) *types.FieldType {
 	return s.RetType
@@ -306,6 +311,11 @@ func (*ScalarSubQueryExpr) VecEvalJSON(_ expression.EvalContext, _ *chunk.Chunk,
 	return errors.Errorf("ScalarSubQueryExpr doesn't implement the vec eval yet")
 }
 
+// VecEvalVectorFloat32 evaluates this expression in a vectorized manner.
+func (*ScalarSubQueryExpr) VecEvalVectorFloat32(_ expression.EvalContext, _ *chunk.Chunk, _ *chunk.Column) error {
+	return errors.Errorf("ScalarSubQueryExpr doesn't implement the vec eval yet")
+}
+
 // Vectorized returns whether the expression can be vectorized.
 func (*ScalarSubQueryExpr) Vectorized() bool {
 	return true
@@ -117,6 +117,39 @@ func (p *LogicalShowDDLJobs) DeriveStats(_ []*property.StatsInfo, selfSchema *ex
 	return p.StatsInfo(), nil
 }
 
+// DeriveStats implement LogicalPlan DeriveStats interface.
+func (p *LogicalJSONTable) DeriveStats(_ []*property.StatsInfo, selfSchema *expression.Schema, _ []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
+	if p.StatsInfo() != nil {
+		return p.StatsInfo(), nil
+	}
+	// The row count depends on the JSON document, a fake count is used here.
+	p.SetStats(getFakeStats(selfSchema))
+	return p.StatsInfo(), nil
+}
+
+// DeriveStats implement LogicalPlan DeriveStats interface.
+func (p *LogicalUnpivot) DeriveStats(childStats []*property.StatsInfo, selfSchema *expression.Schema, _ []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
+	if p.StatsInfo() != nil {
+		return p.StatsInfo(), nil
+	}
+	// Every row of the child is replicated for each level.
+	childProfile := childStats[0]
+	rowCount := childProfile.RowCount * float64(len(p.LevelExprs))
+	p.SetStats(&property.StatsInfo{
+		RowCount: rowCount,
+		ColNDVs:  make(map[int64]float64, selfSchema.Len()),
+	})
+	for _, col := range selfSchema.Columns {
+		ndv, ok := childProfile.ColNDVs[col.UniqueID]
+		if !ok {
+			ndv = rowCount
+		}
+		p.StatsInfo().ColNDVs[col.UniqueID] = ndv
+	}
+	p.StatsInfo().ColNDVs[p.NameCol.UniqueID] = float64(len(p.LevelExprs))
+	return p.StatsInfo(), nil
+}
+
 // RecursiveDeriveStats4Test is a exporter just for test.
 func RecursiveDeriveStats4Test(p base.LogicalPlan) (*property.StatsInfo, error) {
 	return p.RecursiveDeriveStats(nil)
@@ -169,6 +202,25 @@ func (ds *DataSource) getGroupNDVs(colGroups [][]*expression.Column) []property.
 			}
 		}
 	}
+	// The NDVs of the column groups not covered by the indexes can be found in the multi-column statistics.
+	for _, g := range colGroups {
+		if len(g) < 2 {
+			continue
+		}
+		ids := make([]int64, 0, len(g))
+		for _, col := range g {
+			ids = append(ids, col.UniqueID)
+		}
+		if slices.ContainsFunc(ndvs, func(ndv property.GroupNDV) bool { return slices.Equal(ndv.Cols, ids) }) {
+			continue
+		}
+		for _, stats := range tbl.MultiColumnStats {
+			if ndv, ok := stats.NDV(ids); ok {
+				ndvs = append(ndvs, property.GroupNDV{Cols: ids, NDV: ndv})
+				break
+			}
+		}
+	}
 	return ndvs
 }
 
@@ -212,6 +264,10 @@ func (ds *DataSource) initStats(colGroups [][]*expression.Column) {
 	if ds.statisticTable.Pseudo {
 		tableStats.StatsVersion = statistics.PseudoVersion
 	}
+	ds.addHypoIndexStats(tableStats.HistColl)
+	if ds.SCtx().GetSessionVars().EnableExtendedStats {
+		tableStats.HistColl.AddMultiColumnStats(ds.statisticTable.ExtendedStats)
+	}
 
 	statsRecord := ds.SCtx().GetSessionVars().StmtCtx.GetUsedStatsInfo(true)
 	name, tblInfo := getTblInfoForUsedStatsByPhysicalID(ds.SCtx(), ds.physicalTableID)
@@ -444,6 +500,7 @@ func (ds *DataSource) DeriveStats(_ []*property.StatsInfo, _ *expression.Schema,
 		ds.pushedDownConds[i] = expression.PushDownNot(exprCtx, expr)
 		ds.pushedDownConds[i] = expression.EliminateNoPrecisionLossCast(exprCtx, ds.pushedDownConds[i])
 	}
+	fulltextPaths := ds.removeFulltextIndexPaths()
 	for _, path := range ds.possibleAccessPaths {
 		if path.IsTablePath() {
 			continue
@@ -461,7 +518,7 @@ func (ds *DataSource) DeriveStats(_ []*property.StatsInfo, _ *expression.Schema,
 		return nil, err
 	}
 
-	if err := ds.generateIndexMergePath(); err != nil {
+	if err := ds.generateIndexMergePath(fulltextPaths); err != nil {
 		return nil, err
 	}
 
@@ -592,11 +649,21 @@ func (p *LogicalLimit) DeriveStats(childStats []*property.StatsInfo, _ *expressi
 }
 
 // DeriveStats implement LogicalPlan DeriveStats interface.
-func (lt *LogicalTopN) DeriveStats(childStats []*property.StatsInfo, _ *expression.Schema, _ []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
+func (lt *LogicalTopN) DeriveStats(childStats []*property.StatsInfo, _ *expression.Schema, childSchema []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
 	if lt.StatsInfo() != nil {
 		return lt.StatsInfo(), nil
 	}
-	lt.SetStats(deriveLimitStats(childStats[0], float64(lt.Count)))
+	count := float64(lt.Count)
+	if len(lt.PartitionBy) > 0 {
+		// The TopN derived from the window function keeps Count rows for each partition.
+		partitionCols := make([]*expression.Column, 0, len(lt.PartitionBy))
+		for _, item := range lt.PartitionBy {
+			partitionCols = append(partitionCols, item.Col)
+		}
+		ndv, _ := cardinality.EstimateColsNDVWithMatchedLen(partitionCols, childSchema[0], childStats[0])
+		count *= ndv
+	}
+	lt.SetStats(deriveLimitStats(childStats[0], count))
 	return lt.StatsInfo(), nil
 }
 
@@ -0,0 +1,171 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+	"github.com/pingcap/tidb/pkg/util/set"
+)
+
+// isStoredFunctionCall returns whether v may call a stored function. A function name qualified by a schema
+// always refers to a stored function, and a built-in function is preferred to a stored function of the same name.
+func isStoredFunctionCall(v *ast.FuncCallExpr) bool {
+	return v.Schema.L != "" || !expression.IsFunctionSupported(v.FnName.L)
+}
+
+// storedFunctionToExpression inlines the body of the stored function called by v. The arguments of the call are
+// cast to the types of the parameters and substituted for them, and the result is cast to the return type.
+// It returns false if the function isn't a stored function of the current schema, the caller reports the error.
+func (er *expressionRewriter) storedFunctionToExpression(planCtx *exprRewriterPlanCtx, v *ast.FuncCallExpr) bool {
+	b := planCtx.builder
+	schema := v.Schema
+	if schema.L == "" {
+		schema = model.NewCIStr(b.ctx.GetSessionVars().CurrentDB)
+		if schema.L == "" {
+			return false
+		}
+	}
+	fullName := schema.O + "." + v.FnName.O
+	fn, err := b.loadStoredFunction(er.ctx, schema, v.FnName)
+	if err != nil {
+		er.err = err
+		return true
+	}
+	if fn == nil {
+		if v.Schema.L == "" {
+			return false
+		}
+		er.err = expression.ErrFunctionNotExists.GenWithStackByArgs("FUNCTION", schema.L+"."+v.FnName.L)
+		return true
+	}
+	if len(fn.Params) != len(v.Args) {
+		er.err = exeerrors.ErrSpWrongNoOfArgs.GenWithStackByArgs("FUNCTION", fullName, len(fn.Params), len(v.Args))
+		return true
+	}
+	var accessErr error
+	if user := b.ctx.GetSessionVars().User; user != nil {
+		accessErr = plannererrors.ErrProcaccessDenied.GenWithStackByArgs("execute", user.AuthUsername, user.AuthHostname, fullName)
+	}
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.ExecutePriv, schema.L, "", "", accessErr)
+
+	if b.buildingFunctionStack == nil {
+		b.buildingFunctionStack = set.NewStringSet()
+	}
+	key := schema.L + "." + v.FnName.L
+	if b.buildingFunctionStack.Exist(key) {
+		er.err = exeerrors.ErrSpNoRecursion.GenWithStackByArgs()
+		return true
+	}
+	b.buildingFunctionStack.Insert(key)
+	defer delete(b.buildingFunctionStack, key)
+
+	// The parameters are the columns of a single row, the body can only refer to them.
+	dual := LogicalTableDual{RowCount: 1}.Init(b.ctx, b.getSelectOffset())
+	cols := make([]*expression.Column, 0, len(fn.Params))
+	names := make(types.NameSlice, 0, len(fn.Params))
+	for _, param := range fn.Params {
+		cols = append(cols, &expression.Column{
+			UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+			RetType:  storedFunctionType(param.ParamType),
+		})
+		names = append(names, &types.FieldName{ColName: model.NewCIStr(param.ParamName)})
+	}
+	dual.SetSchema(expression.NewSchema(cols...))
+	dual.SetOutputNames(names)
+	outerSchemas, outerNames := b.outerSchemas, b.outerNames
+	b.outerSchemas, b.outerNames = nil, nil
+	body, np, err := b.rewrite(er.ctx, fn.Body, dual, nil, true)
+	b.outerSchemas, b.outerNames = outerSchemas, outerNames
+	if err != nil {
+		er.err = err
+		return true
+	}
+	if np != dual {
+		er.err = plannererrors.ErrNotSupportedYet.GenWithStackByArgs("subqueries in stored functions")
+		return true
+	}
+
+	stackLen := len(er.ctxStack)
+	args := make([]expression.Expression, 0, len(v.Args))
+	for i, arg := range er.ctxStack[stackLen-len(v.Args):] {
+		args = append(args, expression.BuildCastFunction(er.sctx, arg, cols[i].RetType))
+	}
+	er.ctxStackPop(len(v.Args))
+	body = expression.ColumnSubstitute(er.sctx, body, dual.Schema(), args)
+	// The body is read from `mysql.routines` each time, so the plan can't be reused after the function is replaced.
+	er.sctx.SetSkipPlanCache(fmt.Sprintf("stored function %s is called", fullName))
+	er.ctxStackAppend(expression.BuildCastFunction(er.sctx, body, storedFunctionType(fn.Returns)), types.EmptyName)
+	return true
+}
+
+// loadStoredFunction reads the function from `mysql.routines`, and parses it with the sql_mode it's created in.
+// It returns nil if the function doesn't exist.
+func (b *PlanBuilder) loadStoredFunction(ctx context.Context, schema, name model.CIStr) (*ast.CreateFunctionStmt, error) {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	rows, _, err := b.ctx.GetRestrictedSQLExecutor().ExecRestrictedSQL(ctx, nil,
+		"SELECT param_list, returns, body, sql_mode FROM mysql.routines WHERE db = %? AND LOWER(name) = %? AND type = 'FUNCTION'",
+		schema.L, name.L)
+	if infoschema.ErrTableNotExists.Equal(err) || infoschema.ErrColumnNotExists.Equal(err) {
+		// The table or its `returns` column doesn't exist before the cluster is upgraded.
+		return nil, nil
+	}
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+	sqlMode, err := mysql.GetSQLMode(rows[0].GetString(3))
+	if err != nil {
+		return nil, err
+	}
+	sessVars := b.ctx.GetSessionVars()
+	p := parser.New()
+	p.SetSQLMode(sqlMode)
+	p.SetParserConfig(sessVars.BuildParserConfig())
+	charset, collation := sessVars.GetCharsetInfo()
+	sql := fmt.Sprintf("CREATE FUNCTION f(%s) RETURNS %s %s", rows[0].GetString(0), rows[0].GetString(1), rows[0].GetString(2))
+	stmt, err := p.ParseOneStmt(sql, charset, collation)
+	if err != nil {
+		return nil, err
+	}
+	return stmt.(*ast.CreateFunctionStmt), nil
+}
+
+// storedFunctionType fills in the default length and charset of the type of a parameter or a return value.
+func storedFunctionType(tp *types.FieldType) *types.FieldType {
+	tp = tp.Clone()
+	if tp.GetFlen() == types.UnspecifiedLength {
+		flen, decimal := mysql.GetDefaultFieldLengthAndDecimal(tp.GetType())
+		tp.SetFlen(flen)
+		if tp.GetDecimal() == types.UnspecifiedLength {
+			tp.SetDecimal(decimal)
+		}
+	}
+	if types.IsString(tp.GetType()) && tp.GetCharset() == "" {
+		tp.SetCharset(mysql.DefaultCharset)
+		tp.SetCollate(mysql.DefaultCollationName)
+	}
+	return tp
+}
@@ -234,6 +234,10 @@ func toString(in base.Plan, strs []string, idxs []int) ([]string, []int) {
 		str = fmt.Sprintf("TopN(%v,%d,%d)", x.ByItems, x.Offset, x.Count)
 	case *LogicalTableDual, *PhysicalTableDual:
 		str = "Dual"
+	case *LogicalJSONTable, *PhysicalJSONTable:
+		str = "JSONTable"
+	case *LogicalUnpivot, *PhysicalUnpivot:
+		str = "Unpivot"
 	case *PhysicalHashAgg:
 		str = "HashAgg"
 	case *PhysicalStreamAgg:
@@ -321,6 +325,8 @@ func toString(in base.Plan, strs []string, idxs []int) ([]string, []int) {
 		str = fmt.Sprintf("%s->Update", ToString(x.SelectPlan))
 	case *Delete:
 		str = fmt.Sprintf("%s->Delete", ToString(x.SelectPlan))
+	case *Merge:
+		str = fmt.Sprintf("%s->Merge", ToString(x.SelectPlan))
 	case *Insert:
 		str = "Insert"
 		if x.SelectPlan != nil {
@@ -37,6 +37,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/paging"
 	"github.com/pingcap/tidb/pkg/util/plancodec"
+	"github.com/pingcap/tipb/go-tipb"
 	"go.uber.org/zap"
 )
 
@@ -179,6 +180,9 @@ func (p *PhysicalIndexJoin) Attach2Task(tasks ...base.Task) base.Task {
 	} else {
 		p.SetChildren(innerTask.Plan(), outerTask.Plan())
 	}
+	if p.AdaptiveHashJoin != nil {
+		p.AdaptiveHashJoin.SetChild(1-p.InnerChildIdx, outerTask.Plan())
+	}
 	t := &RootTask{}
 	t.SetPlan(p)
 	return t
@@ -883,6 +887,10 @@ func (p *PhysicalTopN) getPushedDownTopN(childPlan base.PhysicalPlan) *PhysicalT
 	// Strictly speaking, for the row count of pushed down TopN, we should multiply newCount with "regionNum",
 	// but "regionNum" is unknown since the copTask can be a double read, so we ignore it now.
 	stats := deriveLimitStats(childProfile, float64(newCount))
+	if len(newPartitionBy) > 0 {
+		// The partitioned TopN keeps Count rows for each partition, see LogicalTopN.DeriveStats.
+		stats = deriveLimitStats(childProfile, p.StatsInfo().RowCount)
+	}
 	topN := PhysicalTopN{
 		ByItems:     newByItems,
 		PartitionBy: newPartitionBy,
@@ -963,6 +971,62 @@ func (p *PhysicalTopN) canPushDownToTiKV(copTask *CopTask) bool {
 	return true
 }
 
+// tryPushDownANNQuery pushes down the TopN ordered by the distance between a vector column and
+// a constant vector to the table scan as an approximate nearest neighbour search, so the storage
+// can use a vector index instead of computing the distances of all the rows.
+func (p *PhysicalTopN) tryPushDownANNQuery(ts *PhysicalTableScan) {
+	if len(p.ByItems) != 1 || p.ByItems[0].Desc || ts.StoreType != kv.TiKV || ts.SampleInfo != nil {
+		return
+	}
+	f, ok := p.ByItems[0].Expr.(*expression.ScalarFunction)
+	if !ok {
+		return
+	}
+	var metric tipb.ANNQueryDistanceMetric
+	switch f.FuncName.L {
+	case ast.VecL1Distance:
+		metric = tipb.ANNQueryDistanceMetric_L1
+	case ast.VecL2Distance:
+		metric = tipb.ANNQueryDistanceMetric_L2
+	case ast.VecCosineDistance:
+		metric = tipb.ANNQueryDistanceMetric_Cosine
+	case ast.VecNegativeInnerProduct:
+		metric = tipb.ANNQueryDistanceMetric_InnerProduct
+	default:
+		return
+	}
+	args := f.GetArgs()
+	col, ok := args[0].(*expression.Column)
+	ref, isConst := args[1].(*expression.Constant)
+	if !ok || !isConst {
+		col, ok = args[1].(*expression.Column)
+		ref, isConst = args[0].(*expression.Constant)
+		if !ok || !isConst {
+			return
+		}
+	}
+	// The constant of a cached plan may be changed, so it can't be used as the reference vector.
+	if ref.ParamMarker != nil || ref.DeferredExpr != nil || ref.Value.Kind() != types.KindVectorFloat32 {
+		return
+	}
+	if ts.Schema().ColumnIndex(col) < 0 || col.VirtualExpr != nil {
+		return
+	}
+	for _, colInfo := range ts.Columns {
+		if colInfo.ID == col.ID {
+			ts.AnnQuery = &tipb.ANNQueryInfo{
+				QueryType:      tipb.ANNQueryType_OrderBy,
+				DistanceMetric: metric,
+				TopK:           uint32(p.Offset + p.Count),
+				ColumnName:     colInfo.Name.L,
+				ColumnId:       colInfo.ID,
+				RefVecF32:      ref.Value.GetVectorFloat32().SerializeTo(nil),
+			}
+			return
+		}
+	}
+}
+
 // canPushDownToTiFlash checks whether this topN can be pushed down to TiFlash.
 func (p *PhysicalTopN) canPushDownToTiFlash(mppTask *MppTask) bool {
 	if !p.canExpressionConvertedToPB(kv.TiFlash) {
@@ -992,6 +1056,9 @@ func (p *PhysicalTopN) Attach2Task(tasks ...base.Task) base.Task {
 		} else {
 			// It works for both normal index scan and index merge scan.
 			copTask.finishIndexPlan()
+			if ts, ok := copTask.tablePlan.(*PhysicalTableScan); ok {
+				p.tryPushDownANNQuery(ts)
+			}
 			pushedDownTopN = p.getPushedDownTopN(copTask.tablePlan)
 			copTask.tablePlan = pushedDownTopN
 		}
@@ -1020,6 +1087,12 @@ func (p *PhysicalExpand) Attach2Task(tasks ...base.Task) base.Task {
 	return base.InvalidTask
 }
 
+// Attach2Task implements the PhysicalPlan interface.
+func (p *PhysicalUnpivot) Attach2Task(tasks ...base.Task) base.Task {
+	t := tasks[0].ConvertToRootTask(p.SCtx())
+	return attachPlan2Task(p, t)
+}
+
 // Attach2Task implements PhysicalPlan interface.
 func (p *PhysicalProjection) Attach2Task(tasks ...base.Task) base.Task {
 	t := tasks[0].Copy()
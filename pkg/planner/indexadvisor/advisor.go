@@ -0,0 +1,324 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package indexadvisor recommends indexes for a workload. The candidate indexes are enumerated from
+// the predicates, ORDER BY and GROUP BY of the queries, and each candidate is costed by planning the
+// queries with it as a hypothetical index, which is only visible to the optimizer. The candidates
+// are selected greedily by the reduced cost of the workload weighted by the execution count.
+package indexadvisor
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+)
+
+// The options of RECOMMEND INDEX.
+const (
+	OptionMaxNumIndex     = "max_num_index"
+	OptionMaxIndexColumns = "max_index_columns"
+	OptionMaxNumQuery     = "max_num_query"
+)
+
+// minBenefitRatio is the min ratio of the workload cost an index needs to reduce to be recommended.
+const minBenefitRatio = 0.01
+
+// Options are the options of the advisor.
+type Options struct {
+	// MaxNumIndex is the max number of the recommended indexes.
+	MaxNumIndex int
+	// MaxIndexColumns is the max number of columns in a recommended index.
+	MaxIndexColumns int
+	// MaxNumQuery is the max number of queries loaded from the statements summary.
+	MaxNumQuery int
+}
+
+// NewOptions creates the options from the options of the RECOMMEND INDEX statement.
+func NewOptions(opts []ast.RecommendIndexOption) (*Options, error) {
+	options := &Options{MaxNumIndex: 5, MaxIndexColumns: 3, MaxNumQuery: 100}
+	for _, opt := range opts {
+		var target *int
+		var maxValue uint64
+		switch opt.Option {
+		case OptionMaxNumIndex:
+			target, maxValue = &options.MaxNumIndex, 100
+		case OptionMaxIndexColumns:
+			target, maxValue = &options.MaxIndexColumns, mysql.MaxKeyParts
+		case OptionMaxNumQuery:
+			target, maxValue = &options.MaxNumQuery, 10000
+		default:
+			return nil, errors.Errorf("unknown option %s, the options are %s, %s and %s", opt.Option,
+				OptionMaxNumIndex, OptionMaxIndexColumns, OptionMaxNumQuery)
+		}
+		if opt.Value == 0 || opt.Value > maxValue {
+			return nil, errors.Errorf("the value of %s must be in the range [1, %d]", opt.Option, maxValue)
+		}
+		*target = int(opt.Value)
+	}
+	return options, nil
+}
+
+// Query is a query of the workload.
+type Query struct {
+	SchemaName string
+	Text       string
+	Digest     string
+	// Frequency is the execution count of the query, it's the weight of the query's cost.
+	Frequency int64
+
+	stmt   ast.StmtNode
+	tables []string
+}
+
+// Index is a hypothetical index.
+type Index struct {
+	Schema  string
+	Table   string
+	Columns []string
+
+	// name is unique in an advise, so the hypothetical indexes used by a plan can be found by names.
+	name string
+}
+
+func (idx *Index) tableKey() string {
+	return strings.ToLower(idx.Schema + "." + idx.Table)
+}
+
+func (idx *Index) key() string {
+	return strings.ToLower(fmt.Sprintf("%s.%s(%s)", idx.Schema, idx.Table, strings.Join(idx.Columns, ",")))
+}
+
+// Recommendation is a recommended index.
+type Recommendation struct {
+	Index
+	// IndexName is the name of the recommended index, it doesn't conflict with the existing indexes.
+	IndexName string
+	// EstIndexSize is the estimated size of the index in bytes.
+	EstIndexSize uint64
+	// EstBenefit is the percentage of the workload cost reduced by the index.
+	EstBenefit float64
+	// Digests are the digests of the queries which use the index.
+	Digests []string
+}
+
+// CreateIndexStmt returns the statement to create the recommended index.
+func (r *Recommendation) CreateIndexStmt() string {
+	var sb strings.Builder
+	sqlescape.MustFormatSQL(&sb, "CREATE INDEX %n ON %n.%n(", r.IndexName, r.Schema, r.Table)
+	for i, col := range r.Columns {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sqlescape.MustFormatSQL(&sb, "%n", col)
+	}
+	sb.WriteString(")")
+	return sb.String()
+}
+
+// LoadWorkload loads the queries which take the most time from the statements summary of the TiDB
+// instance.
+func LoadWorkload(ctx context.Context, exec sqlexec.RestrictedSQLExecutor, limit int) ([]*Query, error) {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	rows, _, err := exec.ExecRestrictedSQL(ctx, nil, `SELECT ANY_VALUE(schema_name), digest, ANY_VALUE(query_sample_text),
+		SUM(exec_count) FROM information_schema.statements_summary WHERE stmt_type IN ('Select', 'Update', 'Delete')
+		GROUP BY digest ORDER BY SUM(sum_latency) DESC LIMIT %?`, limit)
+	if err != nil {
+		return nil, err
+	}
+	queries := make([]*Query, 0, len(rows))
+	for _, row := range rows {
+		frequency, err := row.GetMyDecimal(3).ToInt()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		queries = append(queries, &Query{
+			SchemaName: strings.ToLower(row.GetString(0)),
+			Digest:     row.GetString(1),
+			Text:       row.GetString(2),
+			Frequency:  frequency,
+		})
+	}
+	return queries, nil
+}
+
+func parseQuery(text string) (ast.StmtNode, error) {
+	stmt, err := parser.New().ParseOneStmt(text, "", "")
+	if err != nil {
+		return nil, err
+	}
+	switch stmt.(type) {
+	case *ast.SelectStmt, *ast.SetOprStmt, *ast.UpdateStmt, *ast.DeleteStmt:
+		return stmt, nil
+	}
+	return nil, errors.Errorf("unsupported statement %s, only SELECT, UPDATE and DELETE are supported", ast.GetStmtLabel(stmt))
+}
+
+// AdviseIndexes recommends indexes for the queries. The queries which can't be planned are skipped,
+// and the errors are returned as warnings.
+func AdviseIndexes(ctx context.Context, opt Optimizer, queries []*Query, options *Options) (
+	recommendations []*Recommendation, warnings []error, err error) {
+	candidates := make(map[string]*Index)
+	var candidateKeys []string
+	workload := make([]*Query, 0, len(queries))
+	for _, q := range queries {
+		if q.stmt, err = parseQuery(q.Text); err != nil {
+			warnings = append(warnings, errors.Annotatef(err, "skip query %s", q.Digest))
+			continue
+		}
+		for _, idx := range collectCandidates(q.stmt, q.SchemaName, options.MaxIndexColumns, opt.TableInfo) {
+			key := idx.key()
+			if _, ok := candidates[key]; !ok {
+				idx.name = fmt.Sprintf("hypo_idx_%d", len(candidates))
+				candidates[key] = idx
+				candidateKeys = append(candidateKeys, key)
+			}
+			if !slices.Contains(q.tables, idx.tableKey()) {
+				q.tables = append(q.tables, idx.tableKey())
+			}
+		}
+		if len(q.tables) > 0 {
+			workload = append(workload, q)
+		}
+	}
+	if len(candidateKeys) == 0 {
+		return nil, warnings, nil
+	}
+
+	// The current cost of each query with the selected indexes.
+	costs := make(map[*Query]float64, len(workload))
+	var totalCost float64
+	for _, q := range workload {
+		cost, _, err := opt.Cost(ctx, q, nil)
+		if err != nil {
+			warnings = append(warnings, errors.Annotatef(err, "skip query %s", q.Digest))
+			continue
+		}
+		costs[q] = cost
+		totalCost += cost * float64(q.Frequency)
+	}
+
+	var selected []*Index
+	benefits := make(map[*Index]float64)
+	for len(selected) < options.MaxNumIndex {
+		var best *Index
+		var bestBenefit float64
+		var bestCosts map[*Query]float64
+		for _, key := range candidateKeys {
+			idx := candidates[key]
+			if _, ok := benefits[idx]; ok {
+				continue
+			}
+			indexes := append(slices.Clip(selected), idx)
+			var benefit float64
+			newCosts := make(map[*Query]float64)
+			for _, q := range workload {
+				cost, ok := costs[q]
+				if !ok || !slices.Contains(q.tables, idx.tableKey()) {
+					continue
+				}
+				newCost, _, err := opt.Cost(ctx, q, indexes)
+				if err != nil {
+					return nil, nil, err
+				}
+				newCosts[q] = newCost
+				benefit += (cost - newCost) * float64(q.Frequency)
+			}
+			if benefit > bestBenefit {
+				best, bestBenefit, bestCosts = idx, benefit, newCosts
+			}
+		}
+		if best == nil || bestBenefit < totalCost*minBenefitRatio {
+			break
+		}
+		selected = append(selected, best)
+		benefits[best] = bestBenefit
+		for q, cost := range bestCosts {
+			costs[q] = cost
+		}
+	}
+	if len(selected) == 0 {
+		return nil, warnings, nil
+	}
+
+	// Find the queries using the selected indexes, an index selected earlier may be replaced by the
+	// indexes selected later.
+	digests := make(map[string][]string)
+	for _, q := range workload {
+		if _, ok := costs[q]; !ok {
+			continue
+		}
+		_, used, err := opt.Cost(ctx, q, selected)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, name := range used {
+			if !slices.Contains(digests[name], q.Digest) {
+				digests[name] = append(digests[name], q.Digest)
+			}
+		}
+	}
+	names := make(map[string]struct{})
+	for _, idx := range selected {
+		if len(digests[idx.name]) == 0 {
+			continue
+		}
+		size, err := opt.IndexSize(idx)
+		if err != nil {
+			return nil, nil, err
+		}
+		r := &Recommendation{
+			Index:        *idx,
+			IndexName:    indexName(idx, opt, names),
+			EstIndexSize: size,
+			EstBenefit:   benefits[idx] / totalCost * 100,
+			Digests:      digests[idx.name],
+		}
+		slices.Sort(r.Digests)
+		recommendations = append(recommendations, r)
+	}
+	return recommendations, warnings, nil
+}
+
+// indexName names the index after its columns, like `idx_a_b`. A suffix is added if the name is
+// used by another index of the table.
+func indexName(idx *Index, opt Optimizer, used map[string]struct{}) string {
+	name := "idx_" + strings.ToLower(strings.Join(idx.Columns, "_"))
+	if len(name) > mysql.MaxIndexIdentifierLen-4 {
+		name = name[:mysql.MaxIndexIdentifierLen-4]
+	}
+	tbl := opt.TableInfo(idx.Schema, idx.Table)
+	for i := 0; ; i++ {
+		candidate := name
+		if i > 0 {
+			candidate = fmt.Sprintf("%s_%d", name, i)
+		}
+		if _, ok := used[idx.tableKey()+"."+candidate]; ok {
+			continue
+		}
+		if tbl != nil && tbl.FindIndexByName(candidate) != nil {
+			continue
+		}
+		used[idx.tableKey()+"."+candidate] = struct{}{}
+		return candidate
+	}
+}
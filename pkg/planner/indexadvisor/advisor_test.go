@@ -0,0 +1,171 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexadvisor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestTable creates the table `t(a int, b int, c int, d text)` with an index `idx_a_b(c)`.
+func newTestTable() *model.TableInfo {
+	var cols []*model.ColumnInfo
+	for i, name := range []string{"a", "b", "c", "d"} {
+		tp := mysql.TypeLong
+		if name == "d" {
+			tp = mysql.TypeBlob
+		}
+		cols = append(cols, &model.ColumnInfo{
+			ID:        int64(i + 1),
+			Name:      model.NewCIStr(name),
+			Offset:    i,
+			State:     model.StatePublic,
+			FieldType: *types.NewFieldType(tp),
+		})
+	}
+	return &model.TableInfo{
+		Name:    model.NewCIStr("t"),
+		Columns: cols,
+		Indices: []*model.IndexInfo{{
+			Name:    model.NewCIStr("idx_a_b"),
+			State:   model.StatePublic,
+			Columns: []*model.IndexColumn{{Name: model.NewCIStr("c"), Offset: 2, Length: types.UnspecifiedLength}},
+		}},
+	}
+}
+
+// fakeOptimizer costs a query by the cheapest index on the columns listed in costs.
+type fakeOptimizer struct {
+	tbl *model.TableInfo
+	// costs maps the query text and the columns of an index to the cost of the query using the index.
+	costs map[string]map[string]float64
+}
+
+func (o *fakeOptimizer) TableInfo(schema, table string) *model.TableInfo {
+	if schema == "test" && table == "t" {
+		return o.tbl
+	}
+	return nil
+}
+
+func (o *fakeOptimizer) Cost(_ context.Context, q *Query, indexes []*Index) (float64, []string, error) {
+	cost, used := 100.0, ""
+	for _, idx := range indexes {
+		if c, ok := o.costs[q.Text][strings.Join(idx.Columns, ",")]; ok && c < cost {
+			cost, used = c, idx.name
+		}
+	}
+	if used == "" {
+		return cost, nil, nil
+	}
+	return cost, []string{used}, nil
+}
+
+func (*fakeOptimizer) IndexSize(idx *Index) (uint64, error) {
+	return uint64(len(idx.Columns)) * 1000, nil
+}
+
+func candidateColumns(t *testing.T, sql string, maxColumns int) []string {
+	stmt, err := parseQuery(sql)
+	require.NoError(t, err)
+	opt := &fakeOptimizer{tbl: newTestTable()}
+	var result []string
+	for _, idx := range collectCandidates(stmt, "test", maxColumns, opt.TableInfo) {
+		require.Equal(t, "test", idx.Schema)
+		require.Equal(t, "t", idx.Table)
+		result = append(result, strings.Join(idx.Columns, ","))
+	}
+	return result
+}
+
+func TestCollectCandidates(t *testing.T) {
+	require.Equal(t, []string{"a", "b", "a,b"}, candidateColumns(t, "select * from t where a = 1 and b > 1", 3))
+	require.Equal(t, []string{"a", "b"}, candidateColumns(t, "select * from t where a = 1 and b > 1", 1))
+	require.Equal(t, []string{"a", "b", "a,b"}, candidateColumns(t, "select * from t where a in (1, 2) and b = 1", 3))
+	require.Equal(t, []string{"a", "b", "a,b"}, candidateColumns(t, "select * from t where a = 1 order by b", 3))
+	require.Equal(t, []string{"b", "a", "b,a"}, candidateColumns(t, "delete from t where a between 1 and 2 and b is null", 3))
+	require.Equal(t, []string{"b", "a", "b,a"}, candidateColumns(t, "update t set c = 1 where a like 'x%' and b = 1", 3))
+	// The columns of an existing index, the text columns and the columns of the unknown tables are skipped.
+	require.Empty(t, candidateColumns(t, "select * from t where c = 1 and d = 'x'", 3))
+	require.Empty(t, candidateColumns(t, "select * from t2 where a = 1", 3))
+	require.Empty(t, candidateColumns(t, "select * from mysql.user where user = 'root'", 3))
+	// The columns are grouped by the table references.
+	require.Equal(t, []string{"a", "b", "a,b", "a", "b", "a,b"},
+		candidateColumns(t, "select * from t t1 join test.t t2 on t1.a = t2.a where t2.b > 1 and t1.b is not null order by t1.b", 3))
+	// The columns without qualifiers are ambiguous in a self join.
+	require.Empty(t, candidateColumns(t, "select * from t t1, t t2 where a = 1", 3))
+}
+
+func TestAdviseIndexes(t *testing.T) {
+	opt := &fakeOptimizer{
+		tbl: newTestTable(),
+		costs: map[string]map[string]float64{
+			"select * from t where a = 1 and b > 1": {"a": 10, "b": 50, "a,b": 1},
+			"select * from t where b = 1":           {"b": 5, "b,a": 5},
+			"select * from t where c = 1":           {},
+		},
+	}
+	queries := []*Query{
+		{SchemaName: "test", Text: "select * from t where a = 1 and b > 1", Digest: "d1", Frequency: 10},
+		{SchemaName: "test", Text: "select * from t where b = 1", Digest: "d2", Frequency: 1},
+		{SchemaName: "test", Text: "select * from t where c = 1", Digest: "d3", Frequency: 100},
+		{SchemaName: "test", Text: "insert into t values (1, 1, 1, '')", Digest: "d4", Frequency: 100},
+	}
+	options, err := NewOptions(nil)
+	require.NoError(t, err)
+	recommendations, warnings, err := AdviseIndexes(context.Background(), opt, queries, options)
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	require.ErrorContains(t, warnings[0], "skip query d4: unsupported statement Insert")
+	require.Len(t, recommendations, 2)
+
+	r := recommendations[0]
+	require.Equal(t, []string{"a", "b"}, r.Columns)
+	// The name of the existing index is not used.
+	require.Equal(t, "idx_a_b_1", r.IndexName)
+	require.Equal(t, uint64(2000), r.EstIndexSize)
+	require.InDelta(t, 990.0/1100*100, r.EstBenefit, 1e-9)
+	require.Equal(t, []string{"d1"}, r.Digests)
+	require.Equal(t, "CREATE INDEX `idx_a_b_1` ON `test`.`t`(`a`, `b`)", r.CreateIndexStmt())
+
+	r = recommendations[1]
+	require.Equal(t, []string{"b"}, r.Columns)
+	require.Equal(t, "idx_b", r.IndexName)
+	require.InDelta(t, 95.0/1100*100, r.EstBenefit, 1e-9)
+	require.Equal(t, []string{"d2"}, r.Digests)
+
+	options.MaxNumIndex = 1
+	recommendations, _, err = AdviseIndexes(context.Background(), opt, queries, options)
+	require.NoError(t, err)
+	require.Len(t, recommendations, 1)
+	require.Equal(t, []string{"a", "b"}, recommendations[0].Columns)
+}
+
+func TestNewOptions(t *testing.T) {
+	options, err := NewOptions([]ast.RecommendIndexOption{{Option: "max_num_index", Value: 2}, {Option: "max_index_columns", Value: 4}})
+	require.NoError(t, err)
+	require.Equal(t, &Options{MaxNumIndex: 2, MaxIndexColumns: 4, MaxNumQuery: 100}, options)
+	_, err = NewOptions([]ast.RecommendIndexOption{{Option: "max_num_query", Value: 0}})
+	require.ErrorContains(t, err, "the value of max_num_query must be in the range [1, 10000]")
+	_, err = NewOptions([]ast.RecommendIndexOption{{Option: "timeout", Value: 1}})
+	require.ErrorContains(t, err, "unknown option timeout")
+}
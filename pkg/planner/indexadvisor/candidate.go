@@ -0,0 +1,335 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexadvisor
+
+import (
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/opcode"
+	"github.com/pingcap/tidb/pkg/util"
+)
+
+// columnUsage is how a column is used by a query, it decides the position of the column in the
+// candidate indexes.
+type columnUsage int
+
+const (
+	// usageEq is for `col = const`, `col IN (...)`, `col IS NULL` and the join keys.
+	usageEq columnUsage = iota
+	// usageRange is for `col < const`, `col BETWEEN ...` and `col LIKE ...`.
+	usageRange
+	// usageOrder is for the ORDER BY and GROUP BY items.
+	usageOrder
+)
+
+type tableRef struct {
+	schema string
+	name   string
+	alias  string
+	info   *model.TableInfo
+}
+
+type usedColumn struct {
+	name  *ast.ColumnName
+	usage columnUsage
+}
+
+// columnCollector collects the tables referenced by a query and the columns used by its predicates,
+// ORDER BY and GROUP BY. The names are not resolved by scopes, a column without qualifier is
+// resolved only if a single table of the query has it.
+type columnCollector struct {
+	defaultSchema string
+	tables        []*tableRef
+	columns       []usedColumn
+}
+
+// Enter implements the ast.Visitor interface.
+func (c *columnCollector) Enter(n ast.Node) (ast.Node, bool) {
+	switch x := n.(type) {
+	case *ast.TableSource:
+		if tn, ok := x.Source.(*ast.TableName); ok {
+			ref := &tableRef{schema: tn.Schema.L, name: tn.Name.L, alias: x.AsName.L}
+			if ref.schema == "" {
+				ref.schema = c.defaultSchema
+			}
+			if ref.alias == "" {
+				ref.alias = ref.name
+			}
+			c.tables = append(c.tables, ref)
+		}
+	case *ast.BinaryOperationExpr:
+		l, r := columnOf(x.L), columnOf(x.R)
+		switch x.Op {
+		case opcode.EQ, opcode.NullEQ:
+			switch {
+			case l != nil && r != nil:
+				c.add(l, usageEq)
+				c.add(r, usageEq)
+			case l != nil && isConstant(x.R):
+				c.add(l, usageEq)
+			case r != nil && isConstant(x.L):
+				c.add(r, usageEq)
+			}
+		case opcode.LT, opcode.LE, opcode.GT, opcode.GE:
+			if l != nil && isConstant(x.R) {
+				c.add(l, usageRange)
+			} else if r != nil && isConstant(x.L) {
+				c.add(r, usageRange)
+			}
+		}
+	case *ast.PatternInExpr:
+		if col := columnOf(x.Expr); col != nil && !x.Not && x.Sel == nil {
+			c.add(col, usageEq)
+		}
+	case *ast.IsNullExpr:
+		if col := columnOf(x.Expr); col != nil && !x.Not {
+			c.add(col, usageEq)
+		}
+	case *ast.BetweenExpr:
+		if col := columnOf(x.Expr); col != nil && !x.Not {
+			c.add(col, usageRange)
+		}
+	case *ast.PatternLikeOrIlikeExpr:
+		if col := columnOf(x.Expr); col != nil && !x.Not && x.IsLike {
+			c.add(col, usageRange)
+		}
+	case *ast.ByItem:
+		if col := columnOf(x.Expr); col != nil {
+			c.add(col, usageOrder)
+		}
+	}
+	return n, false
+}
+
+// Leave implements the ast.Visitor interface.
+func (*columnCollector) Leave(n ast.Node) (ast.Node, bool) {
+	return n, true
+}
+
+func (c *columnCollector) add(name *ast.ColumnName, usage columnUsage) {
+	c.columns = append(c.columns, usedColumn{name: name, usage: usage})
+}
+
+func columnOf(expr ast.ExprNode) *ast.ColumnName {
+	for {
+		p, ok := expr.(*ast.ParenthesesExpr)
+		if !ok {
+			break
+		}
+		expr = p.Expr
+	}
+	if col, ok := expr.(*ast.ColumnNameExpr); ok {
+		return col.Name
+	}
+	return nil
+}
+
+func isConstant(expr ast.ExprNode) bool {
+	switch x := expr.(type) {
+	case ast.ValueExpr, ast.ParamMarkerExpr:
+		return true
+	case *ast.ParenthesesExpr:
+		return isConstant(x.Expr)
+	case *ast.UnaryOperationExpr:
+		return isConstant(x.V)
+	}
+	return false
+}
+
+// resolve returns the table of the column, or nil if it's unknown or ambiguous.
+func (c *columnCollector) resolve(name *ast.ColumnName) (*tableRef, *model.ColumnInfo) {
+	var found *tableRef
+	var foundCol *model.ColumnInfo
+	for _, tbl := range c.tables {
+		if tbl.info == nil {
+			continue
+		}
+		if name.Table.L != "" && (name.Table.L != tbl.alias || (name.Schema.L != "" && name.Schema.L != tbl.schema)) {
+			continue
+		}
+		col := model.FindColumnInfo(tbl.info.Columns, name.Name.L)
+		if col == nil || col.Hidden {
+			continue
+		}
+		if found != nil {
+			return nil, nil
+		}
+		found, foundCol = tbl, col
+	}
+	return found, foundCol
+}
+
+// canBeIndexed returns whether an index can be built on the column without a prefix length.
+func canBeIndexed(col *model.ColumnInfo) bool {
+	switch col.GetType() {
+	case mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob, mysql.TypeJSON,
+		mysql.TypeGeometry, mysql.TypeTiDBVectorFloat32:
+		return false
+	}
+	return true
+}
+
+// tableColumns is the columns of a table used by a query, grouped by their usage.
+type tableColumns struct {
+	tbl    *tableRef
+	eq     []string
+	ranges []string
+	order  []string
+}
+
+func appendColumn(cols []string, col string) []string {
+	for _, c := range cols {
+		if c == col {
+			return cols
+		}
+	}
+	return append(cols, col)
+}
+
+// collectCandidates returns the candidate indexes of the query. tableInfo returns nil if the table
+// doesn't exist.
+func collectCandidates(stmt ast.StmtNode, defaultSchema string, maxColumns int,
+	tableInfo func(schema, table string) *model.TableInfo) []*Index {
+	c := &columnCollector{defaultSchema: defaultSchema}
+	stmt.Accept(c)
+	for _, tbl := range c.tables {
+		if tbl.schema == "" || util.IsMemOrSysDB(tbl.schema) {
+			continue
+		}
+		if info := tableInfo(tbl.schema, tbl.name); info != nil && !info.IsView() && !info.IsSequence() &&
+			info.TempTableType == model.TempTableNone {
+			tbl.info = info
+		}
+	}
+
+	var tables []*tableColumns
+	// The columns are grouped by the table references, so a table joined with itself has the columns
+	// of each side grouped separately.
+	tableOf := make(map[*tableRef]*tableColumns)
+	for _, used := range c.columns {
+		tbl, col := c.resolve(used.name)
+		if tbl == nil || !canBeIndexed(col) {
+			continue
+		}
+		tc, ok := tableOf[tbl]
+		if !ok {
+			tc = &tableColumns{tbl: tbl}
+			tableOf[tbl] = tc
+			tables = append(tables, tc)
+		}
+		switch used.usage {
+		case usageEq:
+			tc.eq = appendColumn(tc.eq, col.Name.O)
+		case usageRange:
+			tc.ranges = appendColumn(tc.ranges, col.Name.O)
+		case usageOrder:
+			tc.order = appendColumn(tc.order, col.Name.O)
+		}
+	}
+
+	var candidates []*Index
+	for _, tc := range tables {
+		for _, cols := range tc.candidateColumns(maxColumns) {
+			if tc.coveredByExistingIndex(cols) {
+				continue
+			}
+			candidates = append(candidates, &Index{
+				Schema:  tc.tbl.schema,
+				Table:   tc.tbl.info.Name.O,
+				Columns: cols,
+			})
+		}
+	}
+	return candidates
+}
+
+// candidateColumns enumerates the columns of the candidate indexes. The equal columns come first,
+// followed by a range column or the ordering columns, like a composite index is usually designed.
+func (tc *tableColumns) candidateColumns(maxColumns int) [][]string {
+	var result [][]string
+	seen := make(map[string]struct{})
+	add := func(cols ...string) {
+		if len(cols) == 0 || len(cols) > maxColumns {
+			return
+		}
+		key := strings.ToLower(strings.Join(cols, ","))
+		if _, ok := seen[key]; ok {
+			return
+		}
+		// The same column can't be in an index twice.
+		for i := range cols {
+			for j := i + 1; j < len(cols); j++ {
+				if strings.EqualFold(cols[i], cols[j]) {
+					return
+				}
+			}
+		}
+		seen[key] = struct{}{}
+		result = append(result, cols)
+	}
+
+	for _, cols := range [][]string{tc.eq, tc.ranges, tc.order} {
+		for _, col := range cols {
+			add(col)
+		}
+	}
+	eq := tc.eq[:min(len(tc.eq), maxColumns)]
+	for i := 2; i <= len(eq); i++ {
+		add(eq[:i]...)
+	}
+	if len(tc.eq) > 0 {
+		prefix := tc.eq[:min(len(tc.eq), maxColumns-1)]
+		for _, col := range tc.ranges {
+			add(append(append([]string(nil), prefix...), col)...)
+		}
+		if len(tc.order) > 0 && len(prefix) > 0 {
+			cols := append(append([]string(nil), prefix...), tc.order...)
+			add(cols[:min(len(cols), maxColumns)]...)
+		}
+	}
+	if len(tc.order) > 1 {
+		add(tc.order[:min(len(tc.order), maxColumns)]...)
+	}
+	return result
+}
+
+// coveredByExistingIndex returns whether the columns are a prefix of an existing index.
+func (tc *tableColumns) coveredByExistingIndex(cols []string) bool {
+	tbl := tc.tbl.info
+	if tbl.PKIsHandle && len(cols) == 1 {
+		if pk := tbl.GetPkColInfo(); pk != nil && strings.EqualFold(pk.Name.O, cols[0]) {
+			return true
+		}
+	}
+	for _, idx := range tbl.Indices {
+		if idx.State != model.StatePublic || len(idx.Columns) < len(cols) {
+			continue
+		}
+		covered := true
+		for i, col := range cols {
+			if !strings.EqualFold(idx.Columns[i].Name.O, col) || idx.Columns[i].Length != -1 {
+				covered = false
+				break
+			}
+		}
+		if covered {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,183 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexadvisor
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"strconv"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/planner/cardinality"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+)
+
+// Optimizer provides the table schemas and estimates the cost of the queries with hypothetical
+// indexes for the advisor.
+type Optimizer interface {
+	// TableInfo returns the table, or nil if the table doesn't exist.
+	TableInfo(schema, table string) *model.TableInfo
+	// Cost returns the estimated cost of the query with the hypothetical indexes, and the names of the
+	// hypothetical indexes used by the plan.
+	Cost(ctx context.Context, q *Query, indexes []*Index) (float64, []string, error)
+	// IndexSize returns the estimated size of the index in bytes.
+	IndexSize(idx *Index) (uint64, error)
+}
+
+// usedIndexPattern matches the indexes in the access objects of the plan, like `index:idx(a, b)`.
+var usedIndexPattern = regexp.MustCompile(`index:(hypo_idx_\d+)\(`)
+
+type sessionOptimizer struct {
+	sctx sessionctx.Context
+}
+
+// NewOptimizer creates an Optimizer which plans the queries with the session. The session is used
+// exclusively by the Optimizer, since its current database and hypothetical indexes are changed when
+// a query is planned.
+func NewOptimizer(sctx sessionctx.Context) Optimizer {
+	return &sessionOptimizer{sctx: sctx}
+}
+
+// TableInfo implements the Optimizer interface.
+func (o *sessionOptimizer) TableInfo(schema, table string) *model.TableInfo {
+	tbl, err := o.sctx.GetDomainInfoSchema().TableInfoByName(model.NewCIStr(schema), model.NewCIStr(table))
+	if err != nil {
+		return nil
+	}
+	return tbl
+}
+
+// Cost implements the Optimizer interface. The cost is the estimated cost of the root operator of
+// `EXPLAIN FORMAT = 'verbose'`, and the hypothetical indexes are only used by EXPLAIN.
+func (o *sessionOptimizer) Cost(ctx context.Context, q *Query, indexes []*Index) (cost float64, used []string, err error) {
+	vars := o.sctx.GetSessionVars()
+	originDB, originIndexes, originRestricted := vars.CurrentDB, vars.HypoIndexes, vars.InRestrictedSQL
+	defer func() {
+		vars.CurrentDB, vars.HypoIndexes, vars.InRestrictedSQL = originDB, originIndexes, originRestricted
+	}()
+	vars.CurrentDB = q.SchemaName
+	vars.InRestrictedSQL = true
+	vars.HypoIndexes = make(map[string]map[string]map[string]*model.IndexInfo)
+	for _, idx := range indexes {
+		info, err := o.indexInfo(idx)
+		if err != nil {
+			return 0, nil, err
+		}
+		schema, table := model.NewCIStr(idx.Schema).L, model.NewCIStr(idx.Table).L
+		if vars.HypoIndexes[schema] == nil {
+			vars.HypoIndexes[schema] = make(map[string]map[string]*model.IndexInfo)
+		}
+		if vars.HypoIndexes[schema][table] == nil {
+			vars.HypoIndexes[schema][table] = make(map[string]*model.IndexInfo)
+		}
+		vars.HypoIndexes[schema][table][info.Name.L] = info
+	}
+
+	// The statement is parsed again, since the AST is changed when it's planned.
+	stmt, err := parseQuery(q.Text)
+	if err != nil {
+		return 0, nil, err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	rs, err := o.sctx.GetSQLExecutor().ExecuteStmt(ctx, &ast.ExplainStmt{Stmt: stmt, Format: types.ExplainFormatVerbose})
+	if err != nil {
+		return 0, nil, err
+	}
+	defer terror.Call(rs.Close)
+	rows, err := sqlexec.DrainRecordSet(ctx, rs, 8)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(rows) == 0 {
+		return 0, nil, errors.New("empty plan")
+	}
+	// The columns are id, estRows, estCost, task, access object and operator info.
+	if cost, err = strconv.ParseFloat(rows[0].GetString(2), 64); err != nil {
+		return 0, nil, errors.Trace(err)
+	}
+	for _, row := range rows {
+		for _, match := range usedIndexPattern.FindAllStringSubmatch(row.GetString(4), -1) {
+			used = append(used, match[1])
+		}
+	}
+	return cost, used, nil
+}
+
+func (o *sessionOptimizer) indexInfo(idx *Index) (*model.IndexInfo, error) {
+	tbl := o.TableInfo(idx.Schema, idx.Table)
+	if tbl == nil {
+		return nil, errors.Errorf("table %s.%s doesn't exist", idx.Schema, idx.Table)
+	}
+	info := &model.IndexInfo{
+		Name:  model.NewCIStr(idx.name),
+		Table: tbl.Name,
+		State: model.StatePublic,
+		Tp:    model.IndexTypeHypo,
+	}
+	for _, name := range idx.Columns {
+		col := model.FindColumnInfo(tbl.Columns, model.NewCIStr(name).L)
+		if col == nil {
+			return nil, errors.Errorf("column %s doesn't exist in table %s.%s", name, idx.Schema, idx.Table)
+		}
+		info.Columns = append(info.Columns, &model.IndexColumn{
+			Name:   col.Name,
+			Offset: col.Offset,
+			Length: types.UnspecifiedLength,
+		})
+	}
+	return info, nil
+}
+
+// IndexSize implements the Optimizer interface. The size is the number of rows multiplied by the
+// average size of the index entries, which are estimated by the statistics of the columns.
+func (o *sessionOptimizer) IndexSize(idx *Index) (uint64, error) {
+	tbl := o.TableInfo(idx.Schema, idx.Table)
+	if tbl == nil {
+		return 0, errors.Errorf("table %s.%s doesn't exist", idx.Schema, idx.Table)
+	}
+	statsTbl := domain.GetDomain(o.sctx).StatsHandle().GetTableStats(tbl)
+	cols := make([]*expression.Column, 0, len(idx.Columns)+1)
+	for _, name := range idx.Columns {
+		col := model.FindColumnInfo(tbl.Columns, model.NewCIStr(name).L)
+		if col == nil {
+			return 0, errors.Errorf("column %s doesn't exist in table %s.%s", name, idx.Schema, idx.Table)
+		}
+		cols = append(cols, &expression.Column{ID: col.ID, UniqueID: col.ID, RetType: &col.FieldType})
+	}
+	// The index entries contain the handle.
+	if tbl.IsCommonHandle {
+		for _, idxCol := range tbl.GetPrimaryKey().Columns {
+			col := tbl.Columns[idxCol.Offset]
+			cols = append(cols, &expression.Column{ID: col.ID, UniqueID: col.ID, RetType: &col.FieldType})
+		}
+	} else if pk := tbl.GetPkColInfo(); tbl.PKIsHandle && pk != nil {
+		cols = append(cols, &expression.Column{ID: pk.ID, UniqueID: pk.ID, RetType: &pk.FieldType})
+	} else {
+		cols = append(cols, &expression.Column{ID: model.ExtraHandleID, UniqueID: model.ExtraHandleID,
+			RetType: types.NewFieldType(mysql.TypeLonglong)})
+	}
+	rowSize := cardinality.GetIndexAvgRowSize(o.sctx.GetPlanCtx(), &statsTbl.HistColl, cols, false)
+	return uint64(math.Round(rowSize * float64(statsTbl.RealtimeCount))), nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexadvisor_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecommendIndex(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, c int, key idx_c(c))")
+	for i := 0; i < 100; i++ {
+		tk.MustExec(fmt.Sprintf("insert into t values (%d, %d, %d)", i, i%10, i))
+	}
+	tk.MustExec("analyze table t")
+
+	sql := "select * from t where a = 1 and b > 2"
+	digest := parser.DigestHash(sql).String()
+	tk.MustQuery("recommend index for '"+sql+"'").CheckAt([]int{0, 1, 2, 3, 6, 7}, testkit.RowsWithSep("|",
+		"test|t|idx_a|a|"+digest+"|CREATE INDEX `idx_a` ON `test`.`t`(`a`)"))
+	// The existing index is not recommended again.
+	tk.MustQuery("recommend index for 'select * from t where c = 1'").Check(testkit.Rows())
+	tk.MustQuery("recommend index for 'insert into t values (1, 1, 1)'").Check(testkit.Rows())
+	tk.MustQuery("show warnings").CheckContain("unsupported statement Insert")
+	tk.MustGetErrMsg("recommend index for '"+sql+"' with max_num_index = 0",
+		"the value of max_num_index must be in the range [1, 100]")
+
+	// The workload is loaded from the statements summary.
+	for i := 0; i < 10; i++ {
+		tk.MustQuery("select * from t where b = 1 order by c")
+	}
+	tk.MustQuery("recommend index run with max_num_index = 2, max_num_query = 10").CheckAt([]int{0, 1, 2, 3, 6}, testkit.Rows(
+		"test t idx_b_c b,c "+parser.DigestHash("select * from t where b = 1 order by c").String()))
+
+	tk.MustExec("create user u")
+	tk2 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk2.Session().Auth(&auth.UserIdentity{Username: "u", Hostname: "%"}, nil, nil, nil))
+	tk2.MustGetErrCode("recommend index run", errno.ErrSpecificAccessDenied)
+}
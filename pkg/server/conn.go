@@ -1313,6 +1313,8 @@ func (cc *clientConn) dispatch(ctx context.Context, data []byte) error {
 	vars := cc.ctx.GetSessionVars()
 	// reset killed for each request
 	vars.SQLKiller.Reset()
+	// the query attributes are only valid for the current command
+	vars.QueryAttributes = nil
 	if cmd < mysql.ComEnd {
 		cc.ctx.SetCommandValue(cmd)
 	}
@@ -1337,6 +1339,16 @@ func (cc *clientConn) dispatch(ctx context.Context, data []byte) error {
 		}
 		return cc.writeOK(ctx)
 	case mysql.ComQuery: // Most frequently used command.
+		if cc.capability&mysql.ClientQueryAttributes > 0 {
+			cc.initInputEncoder(ctx)
+			attrs, query, err := parseQueryAttributes(data, cc.inputDecoder)
+			if err != nil {
+				return err
+			}
+			vars.QueryAttributes = attrs
+			data = query
+			dataStr = string(hack.String(data))
+		}
 		// For issue 1989
 		// Input payload may end with byte '\0', we didn't find related mysql document about it, but mysql
 		// implementation accept that case. So trim the last '\0' here as if the payload an EOF string.
@@ -1363,7 +1375,12 @@ func (cc *clientConn) dispatch(ctx context.Context, data []byte) error {
 		return cc.writeOK(ctx)
 	case mysql.ComChangeUser:
 		return cc.handleChangeUser(ctx, data)
-	// ComBinlogDump, ComTableDump, ComConnectOut, ComRegisterSlave
+	case mysql.ComBinlogDump, mysql.ComBinlogDumpGtid:
+		return cc.handleBinlogDump(ctx, cmd, data)
+	case mysql.ComRegisterSlave:
+		// The replicas are not tracked, they are shown in the process list.
+		return cc.writeOK(ctx)
+	// ComTableDump, ComConnectOut
 	case mysql.ComStmtPrepare:
 		// For issue 39132, same as ComQuery
 		if len(data) > 0 && data[len(data)-1] == 0 {
@@ -1383,7 +1400,7 @@ func (cc *clientConn) dispatch(ctx context.Context, data []byte) error {
 		return cc.handleSetOption(ctx, data)
 	case mysql.ComStmtFetch:
 		return cc.handleStmtFetch(ctx, data)
-	// ComDaemon, ComBinlogDumpGtid
+	// ComDaemon
 	case mysql.ComResetConnection:
 		return cc.handleResetConnection(ctx)
 	// ComEnd
@@ -2607,7 +2624,7 @@ func (cc getLastStmtInConn) String() string {
 	case mysql.ComFieldList:
 		return "ListFields " + string(data)
 	case mysql.ComQuery, mysql.ComStmtPrepare:
-		sql := string(hack.String(data))
+		sql := cc.query(cmd, data)
 		sql = parser.Normalize(sql, cc.ctx.GetSessionVars().EnableRedactLog)
 		return executor.FormatSQL(sql).String()
 	case mysql.ComStmtExecute, mysql.ComStmtFetch:
@@ -2624,6 +2641,16 @@ func (cc getLastStmtInConn) String() string {
 	}
 }
 
+// query returns the query of COM_QUERY or COM_STMT_PREPARE, the query attributes are stripped.
+func (cc getLastStmtInConn) query(cmd byte, data []byte) string {
+	if cmd == mysql.ComQuery && cc.capability&mysql.ClientQueryAttributes > 0 {
+		if _, query, err := parseQueryAttributes(data, nil); err == nil {
+			data = query
+		}
+	}
+	return string(hack.String(data))
+}
+
 // PProfLabel return sql label used to tag pprof.
 func (cc getLastStmtInConn) PProfLabel() string {
 	if len(cc.lastPacket) == 0 {
@@ -2640,7 +2667,7 @@ func (cc getLastStmtInConn) PProfLabel() string {
 	case mysql.ComStmtReset:
 		return "ResetStmt"
 	case mysql.ComQuery, mysql.ComStmtPrepare:
-		return parser.Normalize(executor.FormatSQL(string(hack.String(data))).String(), errors.RedactLogEnable)
+		return parser.Normalize(executor.FormatSQL(cc.query(cmd, data)).String(), errors.RedactLogEnable)
 	case mysql.ComStmtExecute, mysql.ComStmtFetch:
 		stmtID := binary.LittleEndian.Uint32(data[0:4])
 		return executor.FormatSQL(cc.preparedStmt2StringNoArgs(stmtID)).String()
@@ -0,0 +1,203 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/binary"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/ddl"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/privilege"
+	servererr "github.com/pingcap/tidb/pkg/server/err"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/tidb-binlog/binlogdump"
+	"github.com/pingcap/tidb/pkg/tidb-binlog/binlogstore"
+	"github.com/pingcap/tidb/pkg/tidb-binlog/memorypump"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+const (
+	// binlogThroughGTID is the flag of COM_BINLOG_DUMP_GTID which means the GTID set is sent.
+	binlogThroughGTID = 0x04
+	// defaultHeartbeatPeriod is the heartbeat period when the replica doesn't set it.
+	defaultHeartbeatPeriod = 30 * time.Second
+)
+
+// binlogEventWriter writes the binlog events as packets, each event is prefixed by an OK byte.
+type binlogEventWriter struct {
+	cc  *clientConn
+	ctx context.Context
+	err error
+}
+
+// WriteEvent implements the binlogdump.EventWriter interface.
+func (w *binlogEventWriter) WriteEvent(event []byte) error {
+	data := w.cc.alloc.AllocWithLen(4, 1+len(event))
+	data = append(data, mysql.OKHeader)
+	data = append(data, event...)
+	w.err = w.cc.writePacket(data)
+	return w.err
+}
+
+// Flush implements the binlogdump.EventWriter interface.
+func (w *binlogEventWriter) Flush() error {
+	w.err = w.cc.flush(w.ctx)
+	return w.err
+}
+
+// handleBinlogDump handles COM_BINLOG_DUMP and COM_BINLOG_DUMP_GTID. It streams the binlog of the
+// transactions committed by the cluster to a replica.
+func (cc *clientConn) handleBinlogDump(ctx context.Context, cmd byte, data []byte) error {
+	checker := privilege.GetPrivilegeManager(cc.ctx.Session)
+	if checker != nil && !checker.RequestVerification(cc.ctx.GetSessionVars().ActiveRoles, "", "", "", mysql.ReplicationSlavePriv) {
+		return servererr.ErrSpecificAccessDenied.GenWithStackByArgs("REPLICATION SLAVE")
+	}
+	if memorypump.GetPump() == nil {
+		return servererr.ErrNoBinaryLogging
+	}
+
+	flags, serverID, streamer, err := cc.newBinlogStreamer(ctx, cmd, data)
+	if err != nil {
+		return servererr.ErrMasterFatalErrorReadingBinlog.GenWithStackByArgs(mysql.ErrMasterFatalErrorReadingBinlog, err.Error())
+	}
+	logutil.Logger(ctx).Info("start binlog dump", zap.Uint32("replicaServerID", serverID), zap.Uint16("flags", flags))
+	w := &binlogEventWriter{cc: cc, ctx: ctx}
+	err = streamer.Run(ctx, w)
+	switch {
+	case w.err != nil:
+		return w.err
+	case ctx.Err() != nil:
+		return exeerrors.ErrQueryInterrupted
+	case err != nil:
+		logutil.Logger(ctx).Warn("binlog dump failed", zap.Error(err))
+		return servererr.ErrMasterFatalErrorReadingBinlog.GenWithStackByArgs(mysql.ErrMasterFatalErrorReadingBinlog, err.Error())
+	}
+	if err := cc.writeEOF(ctx, cc.ctx.Status()); err != nil {
+		return err
+	}
+	return cc.flush(ctx)
+}
+
+// newBinlogStreamer parses the request of the replica and creates a streamer for it.
+func (cc *clientConn) newBinlogStreamer(ctx context.Context, cmd byte, data []byte) (flags uint16, serverID uint32, _ *binlogdump.Streamer, _ error) {
+	errMalformed := errors.New("malformed binlog dump packet")
+	var (
+		file string
+		pos  uint32
+		gtid []byte
+	)
+	if cmd == mysql.ComBinlogDump {
+		if len(data) < 10 {
+			return 0, 0, nil, errMalformed
+		}
+		pos = binary.LittleEndian.Uint32(data)
+		flags = binary.LittleEndian.Uint16(data[4:])
+		serverID = binary.LittleEndian.Uint32(data[6:])
+		file = string(data[10:])
+	} else {
+		if len(data) < 10 {
+			return 0, 0, nil, errMalformed
+		}
+		flags = binary.LittleEndian.Uint16(data)
+		serverID = binary.LittleEndian.Uint32(data[2:])
+		nameSize := int(binary.LittleEndian.Uint32(data[6:]))
+		data = data[10:]
+		if len(data) < nameSize+8 {
+			return 0, 0, nil, errMalformed
+		}
+		file = string(data[:nameSize])
+		pos = uint32(binary.LittleEndian.Uint64(data[nameSize:]))
+		data = data[nameSize+8:]
+		if flags&binlogThroughGTID != 0 {
+			if len(data) < 4 || len(data[4:]) < int(binary.LittleEndian.Uint32(data)) {
+				return 0, 0, nil, errMalformed
+			}
+			gtid = data[4 : 4+binary.LittleEndian.Uint32(data)]
+		}
+	}
+
+	sessVars := cc.ctx.GetSessionVars()
+	opts := binlogdump.Options{
+		ServerVersion:   mysql.ServerVersion,
+		ServerUUID:      binlogstore.ServerUUID(cc.ctx.GetStore()),
+		NonBlock:        flags&binlogdump.DumpNonBlockFlag != 0,
+		HeartbeatPeriod: defaultHeartbeatPeriod,
+	}
+	// The replica sets the user variables to tell the source about its settings before dumping.
+	if d, ok := sessVars.GetUserVarVal("master_binlog_checksum"); ok && !d.IsNull() {
+		opts.Checksum = strings.EqualFold(d.GetString(), "CRC32")
+	}
+	if d, ok := sessVars.GetUserVarVal("master_heartbeat_period"); ok && !d.IsNull() {
+		if period, err := strconv.ParseInt(d.GetString(), 10, 64); err == nil && period > 0 {
+			opts.HeartbeatPeriod = time.Duration(period)
+		}
+	}
+	if id, err := sessVars.GlobalVarsAccessor.GetGlobalSysVar("server_id"); err == nil {
+		if id, err := strconv.ParseUint(id, 10, 32); err == nil {
+			opts.ServerID = uint32(id)
+		}
+	}
+	dom := domain.GetDomain(cc.ctx.Session)
+	opts.InfoSchema = func(ts uint64) (infoschema.InfoSchema, error) {
+		return dom.GetSnapshotInfoSchema(ts)
+	}
+	opts.DDLJob = func(id int64) (*model.Job, error) {
+		return getDDLJobByID(dom, id)
+	}
+
+	streamer := binlogdump.NewStreamer(binlogstore.NewReader(dom.SysSessionPool()), opts)
+	var err error
+	if gtid != nil {
+		err = streamer.SeekGTID(ctx, gtid)
+	} else {
+		err = streamer.SeekPosition(ctx, file, pos)
+	}
+	return flags, serverID, streamer, err
+}
+
+// getDDLJobByID returns the DDL job of the id from the history jobs or the running jobs.
+func getDDLJobByID(dom *domain.Domain, id int64) (*model.Job, error) {
+	res, err := dom.SysSessionPool().Get()
+	if err != nil {
+		return nil, err
+	}
+	defer dom.SysSessionPool().Put(res)
+	//nolint:forcetypeassert
+	sctx := res.(sessionctx.Context)
+	job, err := ddl.GetHistoryJobByID(sctx, id)
+	if err != nil || job != nil {
+		return job, err
+	}
+	jobs, err := ddl.GetAllDDLJobs(sctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		if job.ID == id {
+			return job, nil
+		}
+	}
+	return nil, nil
+}
@@ -177,12 +177,27 @@ func (cc *clientConn) handleStmtExecute(ctx context.Context, data []byte) (err e
 		nullBitmaps []byte
 		paramTypes  []byte
 		paramValues []byte
+		attrNames   []string
 	)
 	cc.initInputEncoder(ctx)
 	numParams := stmt.NumParams()
-	args := make([]param.BinaryParam, numParams)
-	if numParams > 0 {
-		nullBitmapLen := (numParams + 7) >> 3
+	// paramCount is the count of the params and the query attributes.
+	paramCount := numParams
+	withQueryAttrs := cc.capability&mysql.ClientQueryAttributes > 0
+	if withQueryAttrs && (numParams > 0 || flag&mysql.ParameterCountAvailable > 0) {
+		var n int
+		paramCount, n, err = parseParamCount(data[pos:])
+		if err != nil {
+			return err
+		}
+		if paramCount < numParams {
+			return mysql.ErrMalformPacket
+		}
+		pos += n
+	}
+	args := make([]param.BinaryParam, paramCount)
+	if paramCount > 0 {
+		nullBitmapLen := (paramCount + 7) >> 3
 		if len(data) < (pos + nullBitmapLen + 1) {
 			return mysql.ErrMalformPacket
 		}
@@ -192,21 +207,32 @@ func (cc *clientConn) handleStmtExecute(ctx context.Context, data []byte) (err e
 		// new param bound flag
 		if data[pos] == 1 {
 			pos++
-			if len(data) < (pos + (numParams << 1)) {
-				return mysql.ErrMalformPacket
+			if withQueryAttrs {
+				var n int
+				paramTypes, attrNames, n, err = parseParamTypesAndNames(data[pos:], paramCount, cc.inputDecoder)
+				if err != nil {
+					return err
+				}
+				pos += n
+			} else {
+				if len(data) < (pos + (numParams << 1)) {
+					return mysql.ErrMalformPacket
+				}
+				paramTypes = data[pos : pos+(numParams<<1)]
+				pos += numParams << 1
 			}
-
-			paramTypes = data[pos : pos+(numParams<<1)]
-			pos += numParams << 1
 			paramValues = data[pos:]
 			// Just the first StmtExecute packet contain parameters type,
 			// we need save it for further use.
-			stmt.SetParamsType(paramTypes)
+			stmt.SetParamsType(paramTypes[:numParams<<1])
 		} else {
 			paramValues = data[pos+1:]
+			paramTypes = stmt.GetParamsType()
+			// The query attributes can't be decoded without their types, so they are ignored.
+			args = args[:numParams]
 		}
 
-		err = parseBinaryParams(args, stmt.BoundParams(), nullBitmaps, stmt.GetParamsType(), paramValues, cc.inputDecoder)
+		_, err = parseBinaryParams(args, stmt.BoundParams(), nullBitmaps, paramTypes, paramValues, cc.inputDecoder)
 		// This `.Reset` resets the arguments, so it's fine to just ignore the error (and the it'll be reset again in the following routine)
 		errReset := stmt.Reset()
 		if errReset != nil {
@@ -216,6 +242,14 @@ func (cc *clientConn) handleStmtExecute(ctx context.Context, data []byte) (err e
 			return errors.Annotate(err, cc.preparedStmt2String(stmtID))
 		}
 	}
+	if len(attrNames) > 0 {
+		attrs, err := queryAttributesFromParams(attrNames[numParams:], args[numParams:])
+		if err != nil {
+			return errors.Annotate(err, cc.preparedStmt2String(stmtID))
+		}
+		cc.ctx.GetSessionVars().QueryAttributes = attrs
+		args = args[:numParams]
+	}
 
 	sessVars := cc.ctx.GetSessionVars()
 	// expiredTaskID is the task ID of the previous statement. When executing a stmt,
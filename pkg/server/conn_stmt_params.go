@@ -16,6 +16,7 @@ package server
 
 import (
 	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/param"
 	"github.com/pingcap/tidb/pkg/parser/charset"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
@@ -26,9 +27,8 @@ import (
 
 var errUnknownFieldType = dbterror.ClassServer.NewStd(errno.ErrUnknownFieldType)
 
-// parseBinaryParams decodes the binary params according to the protocol
-func parseBinaryParams(params []param.BinaryParam, boundParams [][]byte, nullBitmap, paramTypes, paramValues []byte, enc *util2.InputDecoder) (err error) {
-	pos := 0
+// parseBinaryParams decodes the binary params according to the protocol, and returns the length of the decoded values.
+func parseBinaryParams(params []param.BinaryParam, boundParams [][]byte, nullBitmap, paramTypes, paramValues []byte, enc *util2.InputDecoder) (pos int, err error) {
 	if enc == nil {
 		enc = util2.NewInputDecoder(charset.CharsetUTF8)
 	}
@@ -37,7 +37,7 @@ func parseBinaryParams(params []param.BinaryParam, boundParams [][]byte, nullBit
 		// if params had received via ComStmtSendLongData, use them directly.
 		// ref https://dev.mysql.com/doc/internals/en/com-stmt-send-long-data.html
 		// see clientConn#handleStmtSendLongData
-		if boundParams[i] != nil {
+		if i < len(boundParams) && boundParams[i] != nil {
 			params[i] = param.BinaryParam{
 				Tp:  mysql.TypeBlob,
 				Val: boundParams[i],
@@ -76,7 +76,7 @@ func parseBinaryParams(params []param.BinaryParam, boundParams [][]byte, nullBit
 		}
 
 		if (i<<1)+1 >= len(paramTypes) {
-			return mysql.ErrMalformPacket
+			return 0, mysql.ErrMalformPacket
 		}
 
 		tp := paramTypes[i<<1]
@@ -145,3 +145,119 @@ func parseBinaryParams(params []param.BinaryParam, boundParams [][]byte, nullBit
 	}
 	return
 }
+
+// parseParamCount parses the count of the params, which is sent in COM_QUERY and COM_STMT_EXECUTE when the client
+// has CLIENT_QUERY_ATTRIBUTES. It returns the count and the length of the parsed bytes.
+func parseParamCount(data []byte) (count int, n int, err error) {
+	defer func() {
+		// Check malformed packet cause out of range is disgusting, but don't panic!
+		if r := recover(); r != nil {
+			err = mysql.ErrMalformPacket
+		}
+	}()
+	num, _, n := util2.ParseLengthEncodedInt(data)
+	// Each param takes at least one byte in the packet.
+	if num > uint64(len(data)) {
+		return 0, 0, mysql.ErrMalformPacket
+	}
+	return int(num), n, nil
+}
+
+// parseParamTypesAndNames parses the types and the names of the params sent by a client with CLIENT_QUERY_ATTRIBUTES.
+// It returns the types in the same layout as the client without CLIENT_QUERY_ATTRIBUTES, and the length of the parsed bytes.
+func parseParamTypesAndNames(data []byte, count int, enc *util2.InputDecoder) (paramTypes []byte, names []string, pos int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = mysql.ErrMalformPacket
+		}
+	}()
+	paramTypes = make([]byte, 0, count<<1)
+	names = make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		paramTypes = append(paramTypes, data[pos], data[pos+1])
+		pos += 2
+		name, _, n, err := util2.ParseLengthEncodedBytes(data[pos:])
+		if err != nil {
+			return nil, nil, 0, mysql.ErrMalformPacket
+		}
+		names = append(names, string(enc.DecodeInput(name)))
+		pos += n
+	}
+	return paramTypes, names, pos, nil
+}
+
+// parseQueryAttributes parses the query attributes which prefix the query in COM_QUERY when the client has
+// CLIENT_QUERY_ATTRIBUTES, and returns the attributes and the query.
+// See https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_query.html
+func parseQueryAttributes(data []byte, enc *util2.InputDecoder) (attrs map[string]string, query []byte, err error) {
+	if enc == nil {
+		enc = util2.NewInputDecoder(charset.CharsetUTF8)
+	}
+	count, pos, err := parseParamCount(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	// skip parameter_set_count, always 1
+	if len(data) < pos+1 {
+		return nil, nil, mysql.ErrMalformPacket
+	}
+	_, n, err := parseParamCount(data[pos:])
+	if err != nil {
+		return nil, nil, err
+	}
+	pos += n
+	if count == 0 {
+		return nil, data[pos:], nil
+	}
+
+	nullBitmapLen := (count + 7) >> 3
+	if len(data) < pos+nullBitmapLen+1 {
+		return nil, nil, mysql.ErrMalformPacket
+	}
+	nullBitmap := data[pos : pos+nullBitmapLen]
+	pos += nullBitmapLen
+	// skip new_params_bind_flag, always 1
+	pos++
+	paramTypes, names, n, err := parseParamTypesAndNames(data[pos:], count, enc)
+	if err != nil {
+		return nil, nil, err
+	}
+	pos += n
+	params := make([]param.BinaryParam, count)
+	n, err = parseBinaryParams(params, nil, nullBitmap, paramTypes, data[pos:], enc)
+	if err != nil {
+		return nil, nil, err
+	}
+	pos += n
+	attrs, err = queryAttributesFromParams(names, params)
+	if err != nil {
+		return nil, nil, err
+	}
+	return attrs, data[pos:], nil
+}
+
+// queryAttributesFromParams converts the params to the query attributes. The attributes whose values are NULL are
+// omitted, so that they are the same as the ones not sent.
+func queryAttributesFromParams(names []string, params []param.BinaryParam) (map[string]string, error) {
+	if len(params) == 0 {
+		return nil, nil
+	}
+	args, err := param.ExecArgs(types.DefaultStmtNoWarningContext, params)
+	if err != nil {
+		return nil, err
+	}
+	attrs := make(map[string]string, len(args))
+	for i, arg := range args {
+		//nolint:forcetypeassert
+		d := arg.(*expression.Constant).Value
+		if d.IsNull() {
+			continue
+		}
+		value, err := d.ToString()
+		if err != nil {
+			return nil, err
+		}
+		attrs[names[i]] = value
+	}
+	return attrs, nil
+}
@@ -40,7 +40,7 @@ import (
 func decodeAndParse(typectx types.Context, args []expression.Expression, boundParams [][]byte,
 	nullBitmap, paramTypes, paramValues []byte, enc *util.InputDecoder) (err error) {
 	binParams := make([]param.BinaryParam, len(args))
-	err = parseBinaryParams(binParams, boundParams, nullBitmap, paramTypes, paramValues, enc)
+	_, err = parseBinaryParams(binParams, boundParams, nullBitmap, paramTypes, paramValues, enc)
 	if err != nil {
 		return err
 	}
@@ -304,6 +304,47 @@ func TestParseExecArgsAndEncode(t *testing.T) {
 	require.Equal(t, "测试", dt[0].(*expression.Constant).Value.GetString())
 }
 
+func TestParseQueryAttributes(t *testing.T) {
+	// no attributes
+	attrs, query, err := parseQueryAttributes([]byte{0x0, 0x1, 's', 'e', 'l', 'e', 'c', 't', ' ', '1'}, nil)
+	require.NoError(t, err)
+	require.Nil(t, attrs)
+	require.Equal(t, "select 1", string(query))
+
+	// two attributes, the second one is NULL
+	attrs, query, err = parseQueryAttributes([]byte{
+		0x2, 0x1, // param_count, parameter_set_count
+		0x2, // null bitmap
+		0x1, // new_params_bind_flag
+		mysql.TypeVarString, 0x0, 0x2, 'k', '1',
+		mysql.TypeVarString, 0x0, 0x2, 'k', '2',
+		0x2, 'v', '1',
+		's', 'e', 'l', 'e', 'c', 't', ' ', '1',
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"k1": "v1"}, attrs)
+	require.Equal(t, "select 1", string(query))
+
+	// integer attribute
+	attrs, query, err = parseQueryAttributes([]byte{
+		0x1, 0x1, 0x0, 0x1,
+		mysql.TypeLong, 0x0, 0x1, 'n',
+		0x2a, 0x0, 0x0, 0x0,
+		'd', 'o',
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"n": "42"}, attrs)
+	require.Equal(t, "do", string(query))
+
+	// malformed packets
+	_, _, err = parseQueryAttributes([]byte{}, nil)
+	require.ErrorIs(t, err, mysql.ErrMalformPacket)
+	_, _, err = parseQueryAttributes([]byte{0x1, 0x1, 0x0, 0x1, mysql.TypeVarString, 0x0, 0x5, 'k'}, nil)
+	require.ErrorIs(t, err, mysql.ErrMalformPacket)
+	_, _, err = parseQueryAttributes([]byte{0x1, 0x1, 0x0, 0x1, mysql.TypeVarString, 0x0, 0x1, 'k', 0x5, 'v'}, nil)
+	require.ErrorIs(t, err, mysql.ErrMalformPacket)
+}
+
 func buildDatetimeParam(year uint16, month uint8, day uint8, hour uint8, min uint8, sec uint8, msec uint32) []byte {
 	endian := binary.LittleEndian
 
@@ -44,4 +44,10 @@ var (
 	ErrNetPacketTooLarge = dbterror.ClassServer.NewStd(errno.ErrNetPacketTooLarge)
 	// ErrMustChangePassword is returned when the user must change the password.
 	ErrMustChangePassword = dbterror.ClassServer.NewStd(errno.ErrMustChangePassword)
+	// ErrSpecificAccessDenied is returned when the user does not have the privilege of the command.
+	ErrSpecificAccessDenied = dbterror.ClassServer.NewStd(errno.ErrSpecificAccessDenied)
+	// ErrNoBinaryLogging is returned when a replica dumps the binlog but the binlog dump source is not enabled.
+	ErrNoBinaryLogging = dbterror.ClassServer.NewStd(errno.ErrNoBinaryLogging)
+	// ErrMasterFatalErrorReadingBinlog is returned when the binlog requested by a replica can't be dumped.
+	ErrMasterFatalErrorReadingBinlog = dbterror.ClassServer.NewStd(errno.ErrMasterFatalErrorReadingBinlog)
 )
@@ -43,7 +43,10 @@ func ConvertColumnInfo(fld *ast.ResultField) (ci *Info) {
 	if fld.Column.GetFlen() != types.UnspecifiedLength {
 		ci.ColumnLength = uint32(fld.Column.GetFlen())
 	}
-	if fld.Column.GetType() == mysql.TypeNewDecimal {
+	if fld.Column.GetType() == mysql.TypeTiDBVectorFloat32 {
+		// The flen of a vector is its dimension, which can't be used as the length of its text representation.
+		ci.ColumnLength = mysql.MaxBlobWidth
+	} else if fld.Column.GetType() == mysql.TypeNewDecimal {
 		// Consider the negative sign.
 		ci.ColumnLength++
 		if fld.Column.GetDecimal() > types.DefaultFsp {
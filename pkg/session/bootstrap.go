@@ -690,6 +690,98 @@ const (
 		KEY (created_by),
 		KEY (status));`
 
+	// CreateRoutinesTable stores the definitions of the stored procedures and functions.
+	CreateRoutinesTable = `CREATE TABLE IF NOT EXISTS mysql.routines (
+		db VARCHAR(64) NOT NULL,
+		name VARCHAR(64) NOT NULL,
+		type ENUM('PROCEDURE','FUNCTION') NOT NULL,
+		definer VARCHAR(288) NOT NULL,
+		security_type ENUM('DEFINER','INVOKER') NOT NULL DEFAULT 'INVOKER',
+		param_list TEXT NOT NULL,
+		returns TEXT NOT NULL,
+		body LONGTEXT NOT NULL,
+		sql_mode VARCHAR(1024) NOT NULL,
+		character_set_client VARCHAR(32) NOT NULL,
+		collation_connection VARCHAR(32) NOT NULL,
+		db_collation VARCHAR(32) NOT NULL,
+		comment TEXT NOT NULL,
+		created TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		modified TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		PRIMARY KEY (db, name, type)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;`
+
+	// CreateXAPreparedTable stores the prepared XA transaction branches, they are listed by XA RECOVER.
+	CreateXAPreparedTable = `CREATE TABLE IF NOT EXISTS mysql.tidb_xa_prepared (
+		format_id BIGINT UNSIGNED NOT NULL,
+		gtrid VARBINARY(64) NOT NULL,
+		bqual VARBINARY(64) NOT NULL,
+		start_ts BIGINT UNSIGNED NOT NULL,
+		instance VARCHAR(64) NOT NULL,
+		prepare_time TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		PRIMARY KEY (format_id, gtrid, bqual) CLUSTERED
+	);`
+
+	// CreateEventsTable stores the definitions of the events. The times of the schedule are
+	// in the time zone of the event.
+	CreateEventsTable = `CREATE TABLE IF NOT EXISTS mysql.events (
+		db VARCHAR(64) NOT NULL,
+		name VARCHAR(64) NOT NULL,
+		definer VARCHAR(288) NOT NULL,
+		body LONGTEXT NOT NULL,
+		execute_at DATETIME DEFAULT NULL,
+		interval_value VARCHAR(256) DEFAULT NULL,
+		interval_field VARCHAR(18) DEFAULT NULL,
+		starts DATETIME DEFAULT NULL,
+		ends DATETIME DEFAULT NULL,
+		status ENUM('ENABLED','DISABLED','SLAVESIDE_DISABLED') NOT NULL DEFAULT 'ENABLED',
+		on_completion ENUM('DROP','PRESERVE') NOT NULL DEFAULT 'DROP',
+		sql_mode VARCHAR(1024) NOT NULL,
+		time_zone VARCHAR(64) NOT NULL,
+		character_set_client VARCHAR(32) NOT NULL,
+		collation_connection VARCHAR(32) NOT NULL,
+		db_collation VARCHAR(32) NOT NULL,
+		comment VARCHAR(2048) NOT NULL DEFAULT '',
+		created TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		last_altered TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		last_executed DATETIME DEFAULT NULL,
+		PRIMARY KEY (db, name)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;`
+
+	// CreateChangefeedsTable stores the changefeeds and their checkpoints.
+	CreateChangefeedsTable = `CREATE TABLE IF NOT EXISTS mysql.tidb_changefeeds (
+		name VARCHAR(64) NOT NULL,
+		instance VARCHAR(512) NOT NULL,
+		filter_schema VARCHAR(64) NOT NULL DEFAULT '',
+		filter_tables TEXT,
+		sink_uri TEXT NOT NULL,
+		format VARCHAR(32) NOT NULL,
+		state ENUM('normal','paused','failed') NOT NULL DEFAULT 'normal',
+		checkpoint_ts BIGINT UNSIGNED NOT NULL,
+		error TEXT,
+		created TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		PRIMARY KEY (name),
+		KEY (instance)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;`
+
+	// CreateBinlogTable stores the binlog of the cluster in the order of commit ts, the binlog of a
+	// transaction is split into chunks.
+	CreateBinlogTable = `CREATE TABLE IF NOT EXISTS mysql.tidb_binlog (
+		commit_ts BIGINT UNSIGNED NOT NULL,
+		start_ts BIGINT UNSIGNED NOT NULL,
+		chunk INT UNSIGNED NOT NULL,
+		chunks INT UNSIGNED NOT NULL,
+		data LONGBLOB NOT NULL,
+		PRIMARY KEY (commit_ts, start_ts, chunk) CLUSTERED
+	);`
+
+	// CreateBinlogSourcesTable stores the TiDB instances writing the binlog and their resolved ts.
+	CreateBinlogSourcesTable = `CREATE TABLE IF NOT EXISTS mysql.tidb_binlog_sources (
+		instance VARCHAR(64) NOT NULL,
+		resolved_ts BIGINT UNSIGNED NOT NULL,
+		heartbeat_ts BIGINT UNSIGNED NOT NULL,
+		PRIMARY KEY (instance)
+	);`
+
 	// DropMySQLIndexUsageTable removes the table `mysql.schema_index_usage`
 	DropMySQLIndexUsageTable = "DROP TABLE IF EXISTS mysql.schema_index_usage"
 
@@ -1097,11 +1189,39 @@ const (
 	// version 198
 	//   add column `owner_id` for `mysql.tidb_mdl_info` table
 	version198 = 198
+
+	// version 199
+	//   create `mysql.routines` table
+	version199 = 199
+
+	// version 200
+	//   create `mysql.tidb_xa_prepared` table
+	version200 = 200
+
+	// version 201
+	//   create `mysql.events` table
+	version201 = 201
+
+	// version 202
+	//   create `mysql.tidb_changefeeds` table
+	version202 = 202
+
+	// version 203
+	//   add column `returns` for `mysql.routines` table
+	version203 = 203
+
+	// version 204
+	//   create `mysql.tidb_binlog` and `mysql.tidb_binlog_sources` tables
+	version204 = 204
+
+	// version 205
+	//   add column `security_type` for `mysql.routines` table
+	version205 = 205
 )
 
 // currentBootstrapVersion is defined as a variable, so we can modify its value for testing.
 // please make sure this is the largest version
-var currentBootstrapVersion int64 = version198
+var currentBootstrapVersion int64 = version205
 
 // DDL owner key's expired time is ManagerSessionTTL seconds, we should wait the time and give more time to have a chance to finish it.
 var internalSQLTimeout = owner.ManagerSessionTTL + 15
@@ -1265,6 +1385,13 @@ var (
 		upgradeToVer196,
 		upgradeToVer197,
 		upgradeToVer198,
+		upgradeToVer199,
+		upgradeToVer200,
+		upgradeToVer201,
+		upgradeToVer202,
+		upgradeToVer203,
+		upgradeToVer204,
+		upgradeToVer205,
 	}
 )
 
@@ -3117,6 +3244,64 @@ func upgradeToVer198(s sessiontypes.Session, ver int64) {
 	doReentrantDDL(s, "ALTER TABLE mysql.tidb_mdl_info ADD COLUMN owner_id VARCHAR(64) NOT NULL DEFAULT '';", infoschema.ErrColumnExists)
 }
 
+func upgradeToVer199(s sessiontypes.Session, ver int64) {
+	if ver >= version199 {
+		return
+	}
+
+	doReentrantDDL(s, CreateRoutinesTable)
+}
+
+func upgradeToVer200(s sessiontypes.Session, ver int64) {
+	if ver >= version200 {
+		return
+	}
+
+	doReentrantDDL(s, CreateXAPreparedTable)
+}
+
+func upgradeToVer201(s sessiontypes.Session, ver int64) {
+	if ver >= version201 {
+		return
+	}
+
+	doReentrantDDL(s, CreateEventsTable)
+}
+
+func upgradeToVer202(s sessiontypes.Session, ver int64) {
+	if ver >= version202 {
+		return
+	}
+
+	doReentrantDDL(s, CreateChangefeedsTable)
+}
+
+func upgradeToVer203(s sessiontypes.Session, ver int64) {
+	if ver >= version203 {
+		return
+	}
+
+	doReentrantDDL(s, "ALTER TABLE mysql.routines ADD COLUMN IF NOT EXISTS `returns` TEXT NOT NULL AFTER `param_list`")
+}
+
+func upgradeToVer204(s sessiontypes.Session, ver int64) {
+	if ver >= version204 {
+		return
+	}
+
+	doReentrantDDL(s, CreateBinlogTable)
+	doReentrantDDL(s, CreateBinlogSourcesTable)
+}
+
+func upgradeToVer205(s sessiontypes.Session, ver int64) {
+	if ver >= version205 {
+		return
+	}
+
+	// The routines created before are executed with the privileges of the invoker.
+	doReentrantDDL(s, "ALTER TABLE mysql.routines ADD COLUMN IF NOT EXISTS `security_type` ENUM('DEFINER','INVOKER') NOT NULL DEFAULT 'INVOKER' AFTER `definer`")
+}
+
 func writeOOMAction(s sessiontypes.Session) {
 	comment := "oom-action is `log` by default in v3.0.x, `cancel` by default in v4.0.11+"
 	mustExecute(s, `INSERT HIGH_PRIORITY INTO %n.%n VALUES (%?, %?, %?) ON DUPLICATE KEY UPDATE VARIABLE_VALUE= %?`,
@@ -3244,6 +3429,18 @@ func doDDLWorks(s sessiontypes.Session) {
 	mustExecute(s, CreateDistFrameworkMeta)
 	// create request_unit_by_group
 	mustExecute(s, CreateRequestUnitByGroupTable)
+	// create routines
+	mustExecute(s, CreateRoutinesTable)
+	// create tidb_xa_prepared
+	mustExecute(s, CreateXAPreparedTable)
+	// create events
+	mustExecute(s, CreateEventsTable)
+	// create tidb_changefeeds
+	mustExecute(s, CreateChangefeedsTable)
+	// create tidb_binlog
+	mustExecute(s, CreateBinlogTable)
+	// create tidb_binlog_sources
+	mustExecute(s, CreateBinlogSourcesTable)
 	// create `sys` schema
 	mustExecute(s, CreateSysSchema)
 	// create `sys.schema_unused_indexes` view
@@ -0,0 +1,101 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/eventscheduler"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+)
+
+// newEventRunner returns the runner executing the body of an event in a new session, which is
+// authenticated as the definer and uses the session variables saved with the event. The execution is
+// claimed and committed before the body is executed, then the statements of the body are executed as
+// they are in a normal session, so the body is never executed twice for the same scheduled time.
+func newEventRunner(store kv.Storage) eventscheduler.Runner {
+	return func(ctx context.Context, event *eventscheduler.Event, scheduled time.Time) (claimed bool, err error) {
+		se, err := CreateSession(store)
+		if err != nil {
+			return false, err
+		}
+		defer se.Close()
+
+		sessVars := se.GetSessionVars()
+		sessVars.CurrentDB = event.DB
+		for name, val := range map[string]string{
+			variable.SQLModeVar:          event.SQLMode,
+			variable.TimeZone:            event.TimeZone,
+			variable.CharacterSetClient:  event.CharsetClient,
+			variable.CollationConnection: event.CollationConnection,
+		} {
+			if err = sessVars.SetSystemVar(name, val); err != nil {
+				return false, err
+			}
+		}
+
+		sqlMode, err := mysql.GetSQLMode(event.SQLMode)
+		if err != nil {
+			return false, err
+		}
+		p := parser.New()
+		p.SetSQLMode(sqlMode)
+		p.SetParserConfig(sessVars.BuildParserConfig())
+		charset, collation := sessVars.GetCharsetInfo()
+		stmt, err := p.ParseOneStmt("CREATE PROCEDURE p() "+event.Body, charset, collation)
+		if err != nil {
+			return false, err
+		}
+
+		// The execution is claimed in its own transaction before the session is authenticated as the definer,
+		// who may not be allowed to update `mysql.events`.
+		internalCtx := kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+		if claimed, err = eventscheduler.ClaimExecution(internalCtx, se, event, scheduled); err != nil || !claimed {
+			return false, err
+		}
+		if idx := strings.LastIndexByte(event.Definer, '@'); idx >= 0 {
+			user := &auth.UserIdentity{Username: event.Definer[:idx], Hostname: event.Definer[idx+1:]}
+			if !se.AuthWithoutVerification(user) {
+				return true, errors.Errorf("the definer %s of the event doesn't exist", event.Definer)
+			}
+		}
+
+		p = parser.New()
+		// The statements are restored with backslash escapes, so they are parsed without NO_BACKSLASH_ESCAPES.
+		p.SetSQLMode(mysql.DelSQLMode(sqlMode, mysql.ModeNoBackslashEscapes))
+		p.SetParserConfig(sessVars.BuildParserConfig())
+		in := newProcedureInterpreter(se, p, nil)
+		in.scopes = []*procedureScope{newProcedureScope()}
+		err = in.execStmts(ctx, []ast.StmtNode{stmt.(*ast.ProcedureInfo).ProcedureBody})
+		if procErr, ok := err.(*procedureError); ok {
+			err = procErr.err
+		}
+		if in.result != nil {
+			closeErr := in.result.Close()
+			if err == nil {
+				err = closeErr
+			}
+		}
+		return true, err
+	}
+}
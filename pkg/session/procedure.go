@@ -0,0 +1,852 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/opcode"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/privilege"
+	sessiontypes "github.com/pingcap/tidb/pkg/session/types"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+)
+
+// HandleCallProcedure is the entry point for a CALL statement. The statements of the stored procedure
+// are executed one by one by `ExecuteStmt`, and the result set of the last query is returned.
+func HandleCallProcedure(ctx context.Context, stmt *ast.CallStmt, se sessiontypes.Session) (sqlexec.RecordSet, error) {
+	caller := newProcedureInterpreter(se, parser.New(), nil)
+	return caller.call(ctx, stmt)
+}
+
+// procedureVar is a local variable or a parameter of a stored procedure.
+type procedureVar struct {
+	tp  *types.FieldType
+	val types.Datum
+}
+
+func newProcedureVar(tp *types.FieldType) *procedureVar {
+	tp = tp.Clone()
+	if tp.GetFlen() == types.UnspecifiedLength {
+		flen, decimal := mysql.GetDefaultFieldLengthAndDecimal(tp.GetType())
+		tp.SetFlen(flen)
+		if tp.GetDecimal() == types.UnspecifiedLength {
+			tp.SetDecimal(decimal)
+		}
+	}
+	if types.IsString(tp.GetType()) && tp.GetCharset() == "" {
+		tp.SetCharset(mysql.DefaultCharset)
+		tp.SetCollate(mysql.DefaultCollationName)
+	}
+	return &procedureVar{tp: tp}
+}
+
+// procedureCursor is a cursor of a stored procedure, the rows are read when the cursor is opened.
+type procedureCursor struct {
+	query ast.StmtNode
+	rows  [][]types.Datum
+	pos   int
+	open  bool
+}
+
+// procedureScope holds the local variables, cursors and handlers declared in a BEGIN ... END block.
+type procedureScope struct {
+	vars     map[string]*procedureVar
+	cursors  map[string]*procedureCursor
+	handlers []*ast.ProcedureErrorControl
+	// handling is set when a handler of the scope is running, the errors raised by
+	// the handler can only be handled by the outer scopes.
+	handling bool
+}
+
+func newProcedureScope() *procedureScope {
+	return &procedureScope{
+		vars:    make(map[string]*procedureVar),
+		cursors: make(map[string]*procedureCursor),
+	}
+}
+
+// procedureJump is returned by LEAVE and ITERATE, and is caught by the statement with the label.
+type procedureJump struct {
+	label string
+	leave bool
+}
+
+func (j *procedureJump) Error() string {
+	return fmt.Sprintf("unexpected jump to label %s", j.label)
+}
+
+// procedureExit is returned after an EXIT handler runs, and is caught by the block declaring the handler.
+type procedureExit struct {
+	scope int
+}
+
+func (*procedureExit) Error() string {
+	return "unexpected exit of stored procedure block"
+}
+
+// procedureError wraps an error that no handler of the stored procedure can handle.
+type procedureError struct {
+	err error
+}
+
+func (e *procedureError) Error() string {
+	return e.err.Error()
+}
+
+// procedureInterpreter executes the body of a stored procedure.
+type procedureInterpreter struct {
+	se     sessiontypes.Session
+	parser *parser.Parser
+	// calls is the stack of the procedures being called, it's used to detect recursive calls.
+	calls  []string
+	scopes []*procedureScope
+	// sqls caches the SQL restored from the nodes of the procedure body. The statements are
+	// parsed again every time they are executed, because the planner modifies the AST.
+	sqls   map[ast.Node]string
+	result sqlexec.RecordSet
+}
+
+func newProcedureInterpreter(se sessiontypes.Session, p *parser.Parser, calls []string) *procedureInterpreter {
+	return &procedureInterpreter{
+		se:     se,
+		parser: p,
+		calls:  calls,
+		sqls:   make(map[ast.Node]string),
+	}
+}
+
+// call executes the procedure called by the statement, the arguments are evaluated by the caller.
+func (in *procedureInterpreter) call(ctx context.Context, stmt *ast.CallStmt) (sqlexec.RecordSet, error) {
+	sessVars := in.se.GetSessionVars()
+	fn := stmt.Procedure
+	schema := fn.Schema
+	if schema.L == "" {
+		if sessVars.CurrentDB == "" {
+			return nil, plannererrors.ErrNoDB
+		}
+		schema = model.NewCIStr(sessVars.CurrentDB)
+	}
+	fullName := schema.O + "." + fn.FnName.O
+	if checker := privilege.GetPrivilegeManager(in.se); checker != nil &&
+		!checker.RequestVerification(sessVars.ActiveRoles, schema.L, "", "", mysql.ExecutePriv) {
+		var user, host string
+		if sessVars.User != nil {
+			user, host = sessVars.User.AuthUsername, sessVars.User.AuthHostname
+		}
+		return nil, plannererrors.ErrProcaccessDenied.GenWithStackByArgs("execute", user, host, fullName)
+	}
+	if slices.Contains(in.calls, strings.ToLower(fullName)) {
+		return nil, exeerrors.ErrSpRecursionLimit.GenWithStackByArgs(0, fn.FnName.O)
+	}
+
+	proc, sqlMode, err := in.loadProcedure(ctx, schema, fn.FnName)
+	if err != nil {
+		return nil, err
+	}
+	if len(fn.Args) != len(proc.ProcedureParam) {
+		return nil, exeerrors.ErrSpWrongNoOfArgs.GenWithStackByArgs("PROCEDURE", fullName, len(proc.ProcedureParam), len(fn.Args))
+	}
+	for i, param := range proc.ProcedureParam {
+		if param.Paramstatus != ast.MODE_IN && !in.isVarRef(fn.Args[i]) {
+			return nil, exeerrors.ErrSpNotVarArg.GenWithStackByArgs(i+1, fullName)
+		}
+	}
+	var args []types.Datum
+	if len(fn.Args) > 0 {
+		if args, err = in.evalExprs(ctx, fn, fn.Args); err != nil {
+			return nil, err
+		}
+	}
+
+	p := parser.New()
+	// The statements are restored with backslash escapes, so they are parsed without NO_BACKSLASH_ESCAPES.
+	p.SetSQLMode(mysql.DelSQLMode(sqlMode, mysql.ModeNoBackslashEscapes))
+	p.SetParserConfig(sessVars.BuildParserConfig())
+	callee := newProcedureInterpreter(in.se, p, append(slices.Clip(in.calls), strings.ToLower(fullName)))
+	params := newProcedureScope()
+	for i, param := range proc.ProcedureParam {
+		v := newProcedureVar(param.ParamType)
+		if param.Paramstatus != ast.MODE_OUT {
+			if err = callee.setVar(v, args[i]); err != nil {
+				return nil, err
+			}
+		}
+		params.vars[strings.ToLower(param.ParamName)] = v
+	}
+	callee.scopes = []*procedureScope{params}
+	if checker := privilege.GetPrivilegeManager(in.se); checker != nil && sessVars.User != nil &&
+		proc.Security == model.SecurityDefiner && proc.Definer != nil {
+		// The statements are executed with the privileges of the definer, the arguments have been evaluated by the caller.
+		caller, roles := sessVars.User, sessVars.ActiveRoles
+		if !in.se.AuthWithoutVerification(proc.Definer) {
+			return nil, exeerrors.ErrNoSuchUser.GenWithStackByArgs(proc.Definer.Username, proc.Definer.Hostname)
+		}
+		defer func() {
+			checker.AuthSuccess(caller.AuthUsername, caller.AuthHostname)
+			sessVars.User, sessVars.ActiveRoles = caller, roles
+		}()
+	}
+	if err = callee.execStmts(ctx, []ast.StmtNode{proc.ProcedureBody}); err != nil {
+		if procErr, ok := err.(*procedureError); ok {
+			err = procErr.err
+		}
+		return nil, err
+	}
+
+	for i, param := range proc.ProcedureParam {
+		if param.Paramstatus == ast.MODE_IN {
+			continue
+		}
+		v := params.vars[strings.ToLower(param.ParamName)]
+		if err = in.assign(fn.Args[i], v.val, v.tp); err != nil {
+			return nil, err
+		}
+	}
+	return callee.result, nil
+}
+
+// loadProcedure reads the procedure from `mysql.routines`, and parses it with the sql_mode it's created in.
+// The definer and the SQL SECURITY of the procedure are filled in.
+func (in *procedureInterpreter) loadProcedure(ctx context.Context, schema, name model.CIStr) (*ast.ProcedureInfo, mysql.SQLMode, error) {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	rows, _, err := in.se.GetRestrictedSQLExecutor().ExecRestrictedSQL(ctx, nil,
+		"SELECT param_list, body, sql_mode, definer, security_type FROM mysql.routines WHERE db = %? AND LOWER(name) = %? AND type = 'PROCEDURE'",
+		schema.L, name.L)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(rows) == 0 {
+		return nil, 0, exeerrors.ErrSpDoesNotExist.GenWithStackByArgs("PROCEDURE", schema.O+"."+name.O)
+	}
+	sqlMode, err := mysql.GetSQLMode(rows[0].GetString(2))
+	if err != nil {
+		return nil, 0, err
+	}
+	sessVars := in.se.GetSessionVars()
+	p := parser.New()
+	p.SetSQLMode(sqlMode)
+	p.SetParserConfig(sessVars.BuildParserConfig())
+	charset, collation := sessVars.GetCharsetInfo()
+	sql := fmt.Sprintf("CREATE PROCEDURE p(%s) %s", rows[0].GetString(0), rows[0].GetString(1))
+	stmt, err := p.ParseOneStmt(sql, charset, collation)
+	if err != nil {
+		return nil, 0, err
+	}
+	proc := stmt.(*ast.ProcedureInfo)
+	proc.Definer = nil
+	definer := rows[0].GetString(3)
+	if idx := strings.LastIndexByte(definer, '@'); idx >= 0 {
+		proc.Definer = &auth.UserIdentity{Username: definer[:idx], Hostname: definer[idx+1:]}
+	}
+	proc.Security = model.SecurityInvoker
+	if rows[0].GetEnum(4).String() == "DEFINER" {
+		proc.Security = model.SecurityDefiner
+	}
+	return proc, sqlMode, nil
+}
+
+// execStmts executes the statements, and runs the handler if a statement fails.
+func (in *procedureInterpreter) execStmts(ctx context.Context, stmts []ast.StmtNode) error {
+	for _, stmt := range stmts {
+		err := in.execStmt(ctx, stmt)
+		if err == nil {
+			continue
+		}
+		switch err.(type) {
+		case *procedureJump, *procedureExit, *procedureError:
+			return err
+		}
+		if err = in.handleError(ctx, err); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (in *procedureInterpreter) execStmt(ctx context.Context, stmt ast.StmtNode) error {
+	switch x := stmt.(type) {
+	case *ast.ProcedureBlock:
+		return in.execBlock(ctx, x)
+	case *ast.ProcedureLabelBlock:
+		err := in.execBlock(ctx, x.Block)
+		if jump, ok := err.(*procedureJump); ok && jump.leave && strings.EqualFold(jump.label, x.LabelName) {
+			return nil
+		}
+		return err
+	case *ast.ProcedureLabelLoop:
+		return in.execLoop(ctx, x.Block, x.LabelName)
+	case *ast.ProcedureWhileStmt, *ast.ProcedureRepeatStmt, *ast.ProcedureLoopStmt:
+		return in.execLoop(ctx, x, "")
+	case *ast.ProcedureJump:
+		return &procedureJump{label: x.Name, leave: x.IsLeave}
+	case *ast.ProcedureIfInfo:
+		return in.execStmt(ctx, x.IfBody)
+	case *ast.ProcedureIfBlock:
+		cond, err := in.evalCondition(ctx, x, x.IfExpr)
+		if err != nil {
+			return err
+		}
+		if cond {
+			return in.execStmts(ctx, x.ProcedureIfStmts)
+		}
+		if x.ProcedureElseStmt != nil {
+			return in.execStmt(ctx, x.ProcedureElseStmt)
+		}
+		return nil
+	case *ast.ProcedureElseIfBlock:
+		return in.execStmt(ctx, x.ProcedureIfStmt)
+	case *ast.ProcedureElseBlock:
+		return in.execStmts(ctx, x.ProcedureIfStmts)
+	case *ast.SimpleCaseStmt:
+		for _, when := range x.WhenCases {
+			cond, err := in.evalCondition(ctx, when, &ast.BinaryOperationExpr{Op: opcode.EQ, L: x.Condition, R: when.Expr})
+			if err != nil {
+				return err
+			}
+			if cond {
+				return in.execStmts(ctx, when.ProcedureStmts)
+			}
+		}
+		if x.ElseCases == nil {
+			return exeerrors.ErrSpCaseNotFound.GenWithStackByArgs()
+		}
+		return in.execStmts(ctx, x.ElseCases)
+	case *ast.SearchCaseStmt:
+		for _, when := range x.WhenCases {
+			cond, err := in.evalCondition(ctx, when, when.Expr)
+			if err != nil {
+				return err
+			}
+			if cond {
+				return in.execStmts(ctx, when.ProcedureStmts)
+			}
+		}
+		if x.ElseCases == nil {
+			return exeerrors.ErrSpCaseNotFound.GenWithStackByArgs()
+		}
+		return in.execStmts(ctx, x.ElseCases)
+	case *ast.ProcedureOpenCur:
+		return in.openCursor(ctx, x.CurName)
+	case *ast.ProcedureCloseCur:
+		cursor := in.lookupCursor(x.CurName)
+		if !cursor.open {
+			return exeerrors.ErrSpCursorNotOpen.GenWithStackByArgs()
+		}
+		cursor.open, cursor.rows = false, nil
+		return nil
+	case *ast.ProcedureFetchInto:
+		return in.fetchCursor(x)
+	case *ast.SetStmt:
+		return in.execSet(ctx, x)
+	case *ast.SelectStmt:
+		if x.SelectIntoOpt != nil && x.SelectIntoOpt.Tp == ast.SelectIntoVars {
+			return in.execSelectInto(ctx, x)
+		}
+	case *ast.CallStmt:
+		rs, err := in.call(ctx, x)
+		if rs != nil {
+			in.result = rs
+		}
+		return err
+	}
+	return in.execSQL(ctx, stmt)
+}
+
+func (in *procedureInterpreter) execBlock(ctx context.Context, block *ast.ProcedureBlock) error {
+	scope := newProcedureScope()
+	in.scopes = append(in.scopes, scope)
+	depth := len(in.scopes) - 1
+	defer func() {
+		in.scopes = in.scopes[:depth]
+	}()
+	for _, decl := range block.ProcedureVars {
+		switch x := decl.(type) {
+		case *ast.ProcedureDecl:
+			var val types.Datum
+			if x.DeclDefault != nil {
+				row, err := in.evalExprs(ctx, x, []ast.ExprNode{x.DeclDefault})
+				if err != nil {
+					return err
+				}
+				val = row[0]
+			}
+			for _, name := range x.DeclNames {
+				v := newProcedureVar(x.DeclType)
+				if err := in.setVar(v, val); err != nil {
+					return err
+				}
+				scope.vars[strings.ToLower(name)] = v
+			}
+		case *ast.ProcedureCursor:
+			scope.cursors[strings.ToLower(x.CurName)] = &procedureCursor{query: x.Selectstring}
+		case *ast.ProcedureErrorControl:
+			scope.handlers = append(scope.handlers, x)
+		}
+	}
+	err := in.execStmts(ctx, block.ProcedureProcStmts)
+	if exit, ok := err.(*procedureExit); ok && exit.scope == depth {
+		return nil
+	}
+	return err
+}
+
+func (in *procedureInterpreter) execLoop(ctx context.Context, stmt ast.StmtNode, label string) error {
+	for {
+		if err := in.se.GetSessionVars().SQLKiller.HandleSignal(); err != nil {
+			return err
+		}
+		var err error
+		switch x := stmt.(type) {
+		case *ast.ProcedureWhileStmt:
+			var cond bool
+			if cond, err = in.evalCondition(ctx, x, x.Condition); err != nil || !cond {
+				return err
+			}
+			err = in.execStmts(ctx, x.Body)
+		case *ast.ProcedureRepeatStmt:
+			if err = in.execStmts(ctx, x.Body); err == nil {
+				var until bool
+				if until, err = in.evalCondition(ctx, x, x.Condition); err != nil || until {
+					return err
+				}
+			}
+		case *ast.ProcedureLoopStmt:
+			err = in.execStmts(ctx, x.Body)
+		}
+		if jump, ok := err.(*procedureJump); ok && label != "" && strings.EqualFold(jump.label, label) {
+			if jump.leave {
+				return nil
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// handleError runs the handler of the error. It returns nil if the procedure continues after
+// the failed statement, or a procedureExit if the block declaring the handler should exit.
+func (in *procedureInterpreter) handleError(ctx context.Context, err error) error {
+	handler, depth := in.findHandler(err)
+	if handler == nil {
+		return &procedureError{err: err}
+	}
+	// The handler runs in the scope it's declared in.
+	scopes := in.scopes
+	in.scopes = slices.Clip(scopes[:depth+1])
+	in.scopes[depth].handling = true
+	err = in.execStmts(ctx, []ast.StmtNode{handler.Operate})
+	in.scopes[depth].handling = false
+	in.scopes = scopes
+	if err != nil {
+		return err
+	}
+	if handler.ControlHandle == ast.PROCEDUR_EXIT {
+		return &procedureExit{scope: depth}
+	}
+	return nil
+}
+
+// findHandler returns the handler of the error and the depth of its scope. In the same scope,
+// a handler for the error code is preferred to a SQLSTATE one, which is preferred to a condition one.
+func (in *procedureInterpreter) findHandler(err error) (*ast.ProcedureErrorControl, int) {
+	code, state := uint16(mysql.ErrUnknown), mysql.DefaultMySQLState
+	if tErr, ok := errors.Cause(err).(*terror.Error); ok {
+		sqlErr := terror.ToSQLError(tErr)
+		code, state = sqlErr.Code, sqlErr.State
+	}
+	class := state[:2]
+	for i := len(in.scopes) - 1; i >= 0; i-- {
+		if in.scopes[i].handling {
+			continue
+		}
+		var found *ast.ProcedureErrorControl
+		best := 0
+		for _, handler := range in.scopes[i].handlers {
+			for _, cond := range handler.ErrorCon {
+				priority := 0
+				switch c := cond.(type) {
+				case *ast.ProcedureErrorVal:
+					if c.ErrorNum == uint64(code) {
+						priority = 3
+					}
+				case *ast.ProcedureErrorState:
+					if c.CodeStatus == state {
+						priority = 2
+					}
+				case *ast.ProcedureErrorCon:
+					switch c.ErrorCon {
+					case ast.PROCEDUR_NOT_FOUND:
+						if class == "02" {
+							priority = 1
+						}
+					case ast.PROCEDUR_SQLEXCEPTION:
+						if class != "00" && class != "01" && class != "02" {
+							priority = 1
+						}
+					}
+				}
+				if priority > best {
+					found, best = handler, priority
+				}
+			}
+		}
+		if found != nil {
+			return found, i
+		}
+	}
+	return nil, -1
+}
+
+func (in *procedureInterpreter) execSet(ctx context.Context, stmt *ast.SetStmt) error {
+	for _, assign := range stmt.Variables {
+		if assign.IsSystem && !assign.IsGlobal {
+			if v := in.lookupVar(assign.Name); v != nil {
+				row, err := in.evalExprs(ctx, assign, []ast.ExprNode{assign.Value})
+				if err != nil {
+					return err
+				}
+				if err = in.setVar(v, row[0]); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		sql, err := in.restore(assign, &ast.SetStmt{Variables: []*ast.VariableAssignment{assign}})
+		if err != nil {
+			return err
+		}
+		if _, _, err = in.runSQL(ctx, sql); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (in *procedureInterpreter) execSelectInto(ctx context.Context, stmt *ast.SelectStmt) error {
+	sql, err := in.restore(stmt, stmt)
+	if err != nil {
+		return err
+	}
+	node, err := in.parse(sql)
+	if err != nil {
+		return err
+	}
+	sel := node.(*ast.SelectStmt)
+	into := sel.SelectIntoOpt
+	sel.SelectIntoOpt = nil
+	fields, rows, err := in.runStmt(ctx, sel)
+	if err != nil {
+		return err
+	}
+	switch {
+	case len(rows) == 0:
+		err = exeerrors.ErrSpFetchNoData.GenWithStackByArgs()
+		if handler, _ := in.findHandler(err); handler != nil {
+			return err
+		}
+		in.se.GetSessionVars().StmtCtx.AppendWarning(err)
+		return nil
+	case len(rows) > 1:
+		return exeerrors.ErrTooManyRows.GenWithStackByArgs()
+	case len(into.Variables) != len(fields):
+		return plannererrors.ErrWrongNumberOfColumnsInSelect.GenWithStackByArgs()
+	}
+	for i, target := range into.Variables {
+		if err = in.assign(target, rows[0][i], &fields[i].Column.FieldType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (in *procedureInterpreter) execSQL(ctx context.Context, stmt ast.StmtNode) error {
+	sql, err := in.restore(stmt, stmt)
+	if err != nil {
+		return err
+	}
+	node, err := in.parse(sql)
+	if err != nil {
+		return err
+	}
+	if sel, ok := node.(*ast.SelectStmt); ok {
+		// Name the columns after the fields in the procedure body, rather than the restored SQL,
+		// or the local variables replaced by their values.
+		for i, field := range stmt.(*ast.SelectStmt).Fields.Fields {
+			if _, isLiteral := field.Expr.(ast.ValueExpr); field.WildCard == nil && field.AsName.L == "" && !isLiteral {
+				sel.Fields.Fields[i].AsName = model.NewCIStr(field.Text())
+			}
+		}
+	}
+	fields, rows, err := in.runStmt(ctx, node)
+	if err != nil || fields == nil {
+		return err
+	}
+	values := make([][]any, 0, len(rows))
+	for _, row := range rows {
+		value := make([]any, 0, len(row))
+		for _, d := range row {
+			value = append(value, d.GetValue())
+		}
+		values = append(values, value)
+	}
+	in.result = &sqlexec.SimpleRecordSet{
+		ResultFields: fields,
+		Rows:         values,
+		MaxChunkSize: in.se.GetSessionVars().MaxChunkSize,
+	}
+	return nil
+}
+
+func (in *procedureInterpreter) openCursor(ctx context.Context, name string) error {
+	cursor := in.lookupCursor(name)
+	if cursor.open {
+		return exeerrors.ErrSpCursorAlreadyOpen.GenWithStackByArgs()
+	}
+	sql, err := in.restore(cursor.query, cursor.query)
+	if err != nil {
+		return err
+	}
+	_, rows, err := in.runSQL(ctx, sql)
+	if err != nil {
+		return err
+	}
+	cursor.rows, cursor.pos, cursor.open = rows, 0, true
+	return nil
+}
+
+func (in *procedureInterpreter) fetchCursor(stmt *ast.ProcedureFetchInto) error {
+	cursor := in.lookupCursor(stmt.CurName)
+	if !cursor.open {
+		return exeerrors.ErrSpCursorNotOpen.GenWithStackByArgs()
+	}
+	if cursor.pos >= len(cursor.rows) {
+		return exeerrors.ErrSpFetchNoData.GenWithStackByArgs()
+	}
+	row := cursor.rows[cursor.pos]
+	if len(row) != len(stmt.Variables) {
+		return exeerrors.ErrSpWrongNoOfFetchArgs.GenWithStackByArgs()
+	}
+	cursor.pos++
+	for i, name := range stmt.Variables {
+		v := in.lookupVar(name)
+		if v == nil {
+			return exeerrors.ErrSpUndeclaredVar.GenWithStackByArgs(name)
+		}
+		if err := in.setVar(v, row[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// evalExprs evaluates the expressions by a SELECT statement, the key is used to cache the statement.
+func (in *procedureInterpreter) evalExprs(ctx context.Context, key ast.Node, exprs []ast.ExprNode) ([]types.Datum, error) {
+	sql, ok := in.sqls[key]
+	if !ok {
+		var sb strings.Builder
+		restoreCtx := format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)
+		sb.WriteString("SELECT ")
+		for i, expr := range exprs {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			if err := expr.Restore(restoreCtx); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		sql = sb.String()
+		in.sqls[key] = sql
+	}
+	_, rows, err := in.runSQL(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	return rows[0], nil
+}
+
+func (in *procedureInterpreter) evalCondition(ctx context.Context, key ast.Node, expr ast.ExprNode) (bool, error) {
+	row, err := in.evalExprs(ctx, key, []ast.ExprNode{expr})
+	if err != nil || row[0].IsNull() {
+		return false, err
+	}
+	cond, err := row[0].ToBool(in.se.GetSessionVars().StmtCtx.TypeCtx())
+	return cond != 0, err
+}
+
+// restore returns the SQL of the node, the key is used to cache the SQL.
+func (in *procedureInterpreter) restore(key ast.Node, node ast.Node) (string, error) {
+	if sql, ok := in.sqls[key]; ok {
+		return sql, nil
+	}
+	var sb strings.Builder
+	if err := node.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return "", errors.Trace(err)
+	}
+	in.sqls[key] = sb.String()
+	return sb.String(), nil
+}
+
+func (in *procedureInterpreter) parse(sql string) (ast.StmtNode, error) {
+	charset, collation := in.se.GetSessionVars().GetCharsetInfo()
+	return in.parser.ParseOneStmt(sql, charset, collation)
+}
+
+func (in *procedureInterpreter) runSQL(ctx context.Context, sql string) ([]*ast.ResultField, [][]types.Datum, error) {
+	stmt, err := in.parse(sql)
+	if err != nil {
+		return nil, nil, err
+	}
+	return in.runStmt(ctx, stmt)
+}
+
+// runStmt executes the statement by `ExecuteStmt` after the local variables are replaced by their values,
+// and returns the rows if it's a query.
+func (in *procedureInterpreter) runStmt(ctx context.Context, stmt ast.StmtNode) ([]*ast.ResultField, [][]types.Datum, error) {
+	stmt.Accept(&procedureVarResolver{in: in})
+	rs, err := in.se.ExecuteStmt(ctx, stmt)
+	if err != nil || rs == nil {
+		return nil, nil, err
+	}
+	chkRows, err := sqlexec.DrainRecordSet(ctx, rs, in.se.GetSessionVars().MaxChunkSize)
+	if closeErr := rs.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	fields := rs.Fields()
+	fieldTypes := make([]*types.FieldType, 0, len(fields))
+	for _, field := range fields {
+		fieldTypes = append(fieldTypes, &field.Column.FieldType)
+	}
+	rows := make([][]types.Datum, 0, len(chkRows))
+	for _, row := range chkRows {
+		rows = append(rows, types.CloneRow(row.GetDatumRow(fieldTypes)))
+	}
+	return fields, rows, nil
+}
+
+// assign sets the value to the user variable or the local variable referred by the target.
+func (in *procedureInterpreter) assign(target ast.ExprNode, val types.Datum, tp *types.FieldType) error {
+	switch x := target.(type) {
+	case *ast.VariableExpr:
+		sessVars := in.se.GetSessionVars()
+		name := strings.ToLower(x.Name)
+		if val.IsNull() {
+			sessVars.UnsetUserVar(name)
+			return nil
+		}
+		sessVars.SetUserVarVal(name, val)
+		sessVars.SetUserVarType(name, tp)
+	case *ast.ColumnNameExpr:
+		v := in.lookupVar(x.Name.Name.L)
+		if v == nil {
+			return exeerrors.ErrSpUndeclaredVar.GenWithStackByArgs(x.Name.Name.O)
+		}
+		return in.setVar(v, val)
+	}
+	return nil
+}
+
+func (in *procedureInterpreter) setVar(v *procedureVar, val types.Datum) error {
+	sessVars := in.se.GetSessionVars()
+	tc := sessVars.StmtCtx.TypeCtx()
+	tc = tc.WithFlags(types.StrictFlags.WithTruncateAsWarning(!sessVars.SQLMode.HasStrictMode()))
+	converted, err := val.ConvertTo(tc, v.tp)
+	if err = tc.HandleTruncate(err); err != nil {
+		return err
+	}
+	v.val = converted
+	return nil
+}
+
+// isVarRef checks whether the expression refers to a user variable or a local variable,
+// which can be the argument of an OUT or INOUT parameter.
+func (in *procedureInterpreter) isVarRef(expr ast.ExprNode) bool {
+	switch x := expr.(type) {
+	case *ast.VariableExpr:
+		return !x.IsSystem
+	case *ast.ColumnNameExpr:
+		return x.Name.Table.L == "" && x.Name.Schema.L == "" && in.lookupVar(x.Name.Name.L) != nil
+	}
+	return false
+}
+
+func (in *procedureInterpreter) lookupVar(name string) *procedureVar {
+	name = strings.ToLower(name)
+	for i := len(in.scopes) - 1; i >= 0; i-- {
+		if v, ok := in.scopes[i].vars[name]; ok {
+			return v
+		}
+	}
+	return nil
+}
+
+// lookupCursor returns the cursor, the existence of the cursors is checked when the procedure is created.
+func (in *procedureInterpreter) lookupCursor(name string) *procedureCursor {
+	name = strings.ToLower(name)
+	for i := len(in.scopes) - 1; i >= 0; i-- {
+		if cursor, ok := in.scopes[i].cursors[name]; ok {
+			return cursor
+		}
+	}
+	return nil
+}
+
+// procedureVarResolver replaces the local variables in a statement with their values.
+type procedureVarResolver struct {
+	in *procedureInterpreter
+}
+
+// Enter implements ast.Visitor interface.
+func (*procedureVarResolver) Enter(n ast.Node) (ast.Node, bool) {
+	// The targets of SELECT ... INTO are assigned by the interpreter.
+	_, skip := n.(*ast.SelectIntoOption)
+	return n, skip
+}
+
+// Leave implements ast.Visitor interface.
+func (r *procedureVarResolver) Leave(n ast.Node) (ast.Node, bool) {
+	col, ok := n.(*ast.ColumnNameExpr)
+	if !ok || col.Name.Table.L != "" || col.Name.Schema.L != "" {
+		return n, true
+	}
+	v := r.in.lookupVar(col.Name.Name.L)
+	if v == nil {
+		return n, true
+	}
+	expr := ast.NewValueExpr(v.val.GetValue(), v.tp.GetCharset(), v.tp.GetCollate())
+	if !v.val.IsNull() {
+		expr.SetType(v.tp.Clone())
+	}
+	return expr, true
+}
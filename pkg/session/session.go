@@ -103,6 +103,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/collate"
 	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
 	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
 	"github.com/pingcap/tidb/pkg/util/execdetails"
 	"github.com/pingcap/tidb/pkg/util/intest"
@@ -539,6 +540,9 @@ func (s *session) doCommit(ctx context.Context) error {
 		}
 		commitTSChecker = c.commitTSCheck
 	}
+	if xa, ok := sessVars.XATxnManager.(*xaTxnManager); ok && xa.preparing {
+		return xa.prepare(ctx, s.txn.Transaction, commitTSChecker)
+	}
 	if err = sessiontxn.GetTxnManager(s).SetOptionsBeforeCommit(s.txn.Transaction, commitTSChecker); err != nil {
 		return err
 	}
@@ -2185,6 +2189,18 @@ func (s *session) validateStatementInTxn(stmtNode ast.StmtNode) error {
 	if _, ok := stmtNode.(*ast.ImportIntoStmt); ok && vars.InTxn() {
 		return errors.New("cannot run IMPORT INTO in explicit transaction")
 	}
+	if state, _ := sessiontxn.GetXATxnManager(s).State(); state != sessiontxn.XAStateNone && !vars.InRestrictedSQL {
+		switch stmtNode.(type) {
+		case *ast.XAStmt:
+		case ast.DDLNode, *ast.BeginStmt, *ast.CommitStmt, *ast.RollbackStmt:
+			// These statements end the current transaction implicitly or explicitly.
+			return exeerrors.ErrXAERRmfail.GenWithStackByArgs(state)
+		default:
+			if state == sessiontxn.XAStateIdle {
+				return exeerrors.ErrXAERRmfail.GenWithStackByArgs(state)
+			}
+		}
+	}
 	return nil
 }
 
@@ -3531,6 +3547,10 @@ func bootstrapSessionImpl(store kv.Storage, createSessionsImpl func(store kv.Sto
 		return s
 	}
 	dom.StartTTLJobManager()
+	dom.StartMaterializedViewRefresher()
+	dom.StartEventScheduler(newEventRunner(store))
+	dom.StartChangefeedManager()
+	dom.StartBinlogWriter()
 
 	analyzeCtxs, err := createSessions(store, analyzeConcurrencyQuota)
 	if err != nil {
@@ -0,0 +1,126 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txn
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/config"
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXATransaction(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v int)")
+
+	// The prepared branch survives the session and is committed by another session.
+	tk1 := testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+	tk1.MustExec("xa start 'g1', 'b1'")
+	tk1.MustExec("insert into t values (1, 1)")
+	tk1.MustGetErrCode("begin", errno.ErrXaerRmfail)
+	tk1.MustGetErrCode("create table t1 (id int)", errno.ErrXaerRmfail)
+	tk1.MustGetErrCode("xa prepare 'g1', 'b1'", errno.ErrXaerRmfail)
+	tk1.MustGetErrCode("xa end 'g2'", errno.ErrXaerNota)
+	tk1.MustExec("xa end 'g1', 'b1'")
+	tk1.MustGetErrCode("select * from t", errno.ErrXaerRmfail)
+	tk1.MustExec("xa prepare 'g1', 'b1'")
+	tk1.Session().Close()
+
+	tk.MustQuery("xa recover").Check(testkit.Rows("1 2 2 g1b1"))
+	tk.MustQuery("xa recover convert xid").Check(testkit.Rows("1 2 2 0x67316231"))
+	tk.MustGetErrCode("xa start 'g1', 'b1'", errno.ErrXaerDupid)
+	tk.MustQuery("select * from t").Check(testkit.Rows())
+	tk.MustExec("xa commit 'g1', 'b1'")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 1"))
+	tk.MustQuery("xa recover").Check(testkit.Rows())
+	tk.MustGetErrCode("xa commit 'g1', 'b1'", errno.ErrXaerNota)
+
+	// The prepared branch is not rolled back after max-txn-ttl.
+	defer config.RestoreFunc()()
+	config.UpdateGlobal(func(conf *config.Config) {
+		conf.Performance.MaxTxnTTL = 1000
+	})
+	tk.MustExec("xa start 'g5'")
+	tk.MustExec("insert into t values (5, 5)")
+	tk.MustExec("xa end 'g5'")
+	tk.MustExec("xa prepare 'g5'")
+	time.Sleep(2 * time.Second)
+	tk.MustExec("xa commit 'g5'")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 1", "5 5"))
+	tk.MustExec("delete from t where id = 5")
+
+	// The locks of a prepared branch block the writes of other transactions until the branch is rolled back.
+	tk.MustExec("xa start 'g2'")
+	tk.MustExec("update t set v = 2 where id = 1")
+	tk.MustExec("xa end 'g2'")
+	tk.MustExec("xa prepare 'g2'")
+	tk1 = testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+	tk1.MustExec("begin pessimistic")
+	tk1.MustGetErrCode("select * from t where id = 1 for update nowait", errno.ErrLockAcquireFailAndNoWaitSet)
+	tk1.MustExec("rollback")
+	tk.MustExec("xa rollback 'g2'")
+	tk1.MustExec("update t set v = 3 where id = 1")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 3"))
+
+	// One phase commit and rollback of an unprepared branch.
+	tk.MustExec("xa start x'0102', 'b', 3")
+	tk.MustExec("insert into t values (2, 2)")
+	tk.MustGetErrCode("xa commit x'0102', 'b', 3 one phase", errno.ErrXaerRmfail)
+	tk.MustExec("xa end x'0102', 'b', 3")
+	tk.MustExec("xa commit x'0102', 'b', 3 one phase")
+	tk.MustExec("xa start 'g3'")
+	tk.MustExec("insert into t values (3, 3)")
+	tk.MustExec("xa end 'g3'")
+	tk.MustExec("xa rollback 'g3'")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 3", "2 2"))
+	tk.MustQuery("xa recover").Check(testkit.Rows())
+
+	tk.MustExec("begin")
+	tk.MustGetErrCode("xa start 'g4'", errno.ErrXaerOutside)
+	tk.MustExec("rollback")
+	tk.MustGetErrCode("xa start 'g4' join", errno.ErrXaerInval)
+	tk.MustGetErrCode("xa rollback 'g4'", errno.ErrXaerNota)
+}
+
+func TestXACommitRetryResolveSecondaries(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v int, key(v))")
+
+	tk.MustExec("xa start 'g1'")
+	tk.MustExec("insert into t values (1, 1), (2, 2)")
+	tk.MustExec("xa end 'g1'")
+	tk.MustExec("xa prepare 'g1'")
+
+	// The commit waits until the secondary keys are resolved with the commit ts of the primary key.
+	fp := "github.com/pingcap/tidb/pkg/session/resolveXASecondariesErr"
+	require.NoError(t, failpoint.Enable(fp, "2*return"))
+	defer func() {
+		require.NoError(t, failpoint.Disable(fp))
+	}()
+	tk.MustExec("xa commit 'g1'")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 1", "2 2"))
+	tk.MustQuery("select v from t use index(v)").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("xa recover").Check(testkit.Rows())
+}
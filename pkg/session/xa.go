@@ -0,0 +1,758 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/kvproto/pkg/kvrpcpb"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/domain/infosync"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessiontxn"
+	storeerr "github.com/pingcap/tidb/pkg/store/driver/error"
+	"github.com/pingcap/tidb/pkg/store/helper"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/codec"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	tikverr "github.com/tikv/client-go/v2/error"
+	tikvstore "github.com/tikv/client-go/v2/kv"
+	"github.com/tikv/client-go/v2/oracle"
+	"github.com/tikv/client-go/v2/tikv"
+	"github.com/tikv/client-go/v2/tikvrpc"
+	"github.com/tikv/client-go/v2/txnkv/txnlock"
+	"go.uber.org/zap"
+)
+
+// XA transactions are mapped onto the Percolator protocol as follows:
+//
+//   - XA START begins a pessimistic transaction and locks the branch key (see xaBranchLockKey) first,
+//     so the branch key becomes the primary key of the transaction. Its TTL is kept alive by the
+//     transaction and doesn't depend on the statements or the session.
+//   - XA PREPARE commits the transaction in background. After the prewrite succeeds, the TTL of the
+//     primary lock is extended to xaPreparedLockTTL and the commit stops before committing the primary
+//     key. The branch is recorded in `mysql.tidb_xa_prepared` and detached from the session, so it
+//     survives the client disconnection and the restart of the TiDB instance.
+//   - XA COMMIT and XA ROLLBACK can be run by any TiDB instance. They commit the primary key with a
+//     fresh commit ts or roll it back, and delete the record. The secondary locks are resolved with
+//     the primary lock, by the waiting commit if the instance which prepared the branch is still alive,
+//     or by the readers and the GC otherwise.
+//
+// The GC safe point doesn't advance past the start ts of the prepared branches. A branch is rolled
+// back if it is not committed within xaPreparedLockTTL, and XA COMMIT reports XA_RBROLLBACK for it.
+
+func init() {
+	sessiontxn.GetXATxnManager = getXATxnManager
+}
+
+func getXATxnManager(sctx sessionctx.Context) sessiontxn.XATxnManager {
+	if manager, ok := sctx.GetSessionVars().XATxnManager.(sessiontxn.XATxnManager); ok {
+		return manager
+	}
+
+	manager := &xaTxnManager{sctx: sctx}
+	sctx.GetSessionVars().XATxnManager = manager
+	return manager
+}
+
+// xaTxnManager implements sessiontxn.XATxnManager
+type xaTxnManager struct {
+	sctx sessionctx.Context
+
+	state   sessiontxn.XAState
+	xid     ast.XID
+	startTS uint64
+	// primary is the branch key locked by XA START, it is the primary key of the transaction.
+	primary kv.Key
+	// preparing is set by XA PREPARE, the transaction is prepared instead of committed when the statement finishes.
+	preparing bool
+}
+
+func (m *xaTxnManager) reset() {
+	m.state = sessiontxn.XAStateNone
+	m.xid = ast.XID{}
+	m.startTS = 0
+	m.primary = nil
+	m.preparing = false
+}
+
+// State implements the sessiontxn.XATxnManager interface.
+func (m *xaTxnManager) State() (sessiontxn.XAState, *ast.XID) {
+	if m.state == sessiontxn.XAStateNone {
+		return m.state, nil
+	}
+	// The XA transaction ends with the transaction, for example, when it is rolled back because of a deadlock.
+	txn, err := m.sctx.Txn(false)
+	if err != nil || !txn.Valid() || txn.StartTS() != m.startTS {
+		m.reset()
+		return m.state, nil
+	}
+	return m.state, &m.xid
+}
+
+// Start implements the sessiontxn.XATxnManager interface.
+func (m *xaTxnManager) Start(ctx context.Context, xid *ast.XID) error {
+	if state, _ := m.State(); state != sessiontxn.XAStateNone {
+		return exeerrors.ErrXAERRmfail.GenWithStackByArgs(state)
+	}
+	if m.sctx.GetSessionVars().InTxn() {
+		return exeerrors.ErrXAEROutside
+	}
+	branch, err := loadXABranch(ctx, m.sctx, xid)
+	if err != nil {
+		return err
+	}
+	if branch != nil {
+		return exeerrors.ErrXAERDupid
+	}
+
+	txnManager := sessiontxn.GetTxnManager(m.sctx)
+	if err := txnManager.EnterNewTxn(ctx, &sessiontxn.EnterNewTxnRequest{
+		Type:    sessiontxn.EnterNewTxnWithBeginStmt,
+		TxnMode: ast.Pessimistic,
+	}); err != nil {
+		return err
+	}
+	txn, err := txnManager.ActivateTxn()
+	if err != nil {
+		return err
+	}
+	key, err := xaBranchLockKey(txnManager.GetTxnInfoSchema(), xid)
+	if err == nil {
+		// The lock context is not bound to the session, so the TTL of the primary lock is kept alive
+		// even if the statements of the session are killed.
+		lockCtx := tikvstore.NewLockCtx(txn.StartTS(), tikvstore.LockNoWait, time.Now())
+		err = txn.LockKeys(ctx, lockCtx, key)
+	}
+	if err != nil {
+		terror.Log(m.rollbackTxn(txn))
+		if storeerr.ErrLockAcquireFailAndNoWaitSet.Equal(err) {
+			return exeerrors.ErrXAERDupid
+		}
+		return err
+	}
+	m.state = sessiontxn.XAStateActive
+	m.xid = *xid
+	m.startTS = txn.StartTS()
+	m.primary = key
+	return nil
+}
+
+// End implements the sessiontxn.XATxnManager interface.
+func (m *xaTxnManager) End(xid *ast.XID) error {
+	state, cur := m.State()
+	if err := checkXAState(state, cur, xid, sessiontxn.XAStateActive); err != nil {
+		return err
+	}
+	m.state = sessiontxn.XAStateIdle
+	return nil
+}
+
+// Prepare implements the sessiontxn.XATxnManager interface.
+func (m *xaTxnManager) Prepare(xid *ast.XID) error {
+	state, cur := m.State()
+	if err := checkXAState(state, cur, xid, sessiontxn.XAStateIdle); err != nil {
+		return err
+	}
+	m.preparing = true
+	m.sctx.GetSessionVars().SetInTxn(false)
+	return nil
+}
+
+// Commit implements the sessiontxn.XATxnManager interface.
+func (m *xaTxnManager) Commit(ctx context.Context, xid *ast.XID, onePhase bool) error {
+	state, cur := m.State()
+	if onePhase {
+		if err := checkXAState(state, cur, xid, sessiontxn.XAStateIdle); err != nil {
+			return err
+		}
+		// The transaction is committed as usual when the statement finishes.
+		m.reset()
+		m.sctx.GetSessionVars().SetInTxn(false)
+		return nil
+	}
+	if state != sessiontxn.XAStateNone {
+		return exeerrors.ErrXAERRmfail.GenWithStackByArgs(state)
+	}
+	return m.finishPrepared(ctx, xid, true)
+}
+
+// Rollback implements the sessiontxn.XATxnManager interface.
+func (m *xaTxnManager) Rollback(ctx context.Context, xid *ast.XID) error {
+	state, cur := m.State()
+	if state == sessiontxn.XAStateNone {
+		return m.finishPrepared(ctx, xid, false)
+	}
+	if err := checkXAState(state, cur, xid, sessiontxn.XAStateIdle); err != nil {
+		return err
+	}
+	m.reset()
+	txn, err := m.sctx.Txn(false)
+	if err != nil {
+		return err
+	}
+	return m.rollbackTxn(txn)
+}
+
+// Recover implements the sessiontxn.XATxnManager interface.
+func (m *xaTxnManager) Recover(ctx context.Context) ([]sessiontxn.XABranch, error) {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	rows, _, err := m.sctx.GetRestrictedSQLExecutor().ExecRestrictedSQL(ctx, []sqlexec.OptionFuncAlias{sqlexec.ExecOptionUseSessionPool},
+		"SELECT format_id, gtrid, bqual, start_ts, instance FROM mysql.tidb_xa_prepared ORDER BY prepare_time, format_id, gtrid, bqual")
+	if err != nil {
+		return nil, err
+	}
+	branches := make([]sessiontxn.XABranch, 0, len(rows))
+	for _, row := range rows {
+		branches = append(branches, sessiontxn.XABranch{
+			XID: ast.XID{
+				FormatID: row.GetUint64(0),
+				Gtrid:    string(row.GetBytes(1)),
+				Bqual:    string(row.GetBytes(2)),
+			},
+			StartTS:  row.GetUint64(3),
+			Instance: row.GetString(4),
+		})
+	}
+	return branches, nil
+}
+
+func (m *xaTxnManager) rollbackTxn(txn kv.Transaction) error {
+	sessVars := m.sctx.GetSessionVars()
+	sessVars.SetInTxn(false)
+	sessVars.TxnCtx.ClearDelta()
+	return txn.Rollback()
+}
+
+// prepare is called instead of committing the transaction after XA PREPARE, it returns after the prewrite
+// of the transaction succeeds, and leaves the commit of the transaction to the prepared branch.
+func (m *xaTxnManager) prepare(ctx context.Context, txn kv.Transaction, commitTSChecker func(uint64) bool) error {
+	defer m.reset()
+	if commitTSChecker != nil {
+		// The commit ts of a prepared branch is decided by XA COMMIT, it can't be bounded by the lease of cached tables.
+		return errors.New("XA PREPARE is not supported for the transactions on cached tables")
+	}
+	store, ok := m.sctx.GetStore().(helper.Storage)
+	if !ok {
+		return errors.New("XA PREPARE is only supported on TiKV")
+	}
+	serverInfo, err := infosync.GetServerInfo()
+	if err != nil {
+		return err
+	}
+	registry := getXABranchRegistry(store)
+	b := registry.newBranch(sessiontxn.XABranch{XID: m.xid, StartTS: txn.StartTS(), Instance: serverInfo.ID}, m.primary)
+	if b == nil {
+		return exeerrors.ErrXAERDupid
+	}
+	if err := sessiontxn.GetTxnManager(m.sctx).SetOptionsBeforeCommit(txn, b.waitForDecision(store, txn)); err != nil {
+		registry.remove(b)
+		return err
+	}
+	go func() {
+		// The commit outlives the statement and the session, so it doesn't use the context of the statement.
+		b.done <- txn.Commit(context.Background())
+	}()
+	select {
+	case err = <-b.prepared:
+	case err = <-b.done:
+		if err == nil {
+			err = errors.New("XA transaction is committed without prepare")
+		}
+	}
+	if err != nil {
+		registry.remove(b)
+		return err
+	}
+
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	_, _, err = m.sctx.GetRestrictedSQLExecutor().ExecRestrictedSQL(ctx, []sqlexec.OptionFuncAlias{sqlexec.ExecOptionUseSessionPool},
+		"INSERT INTO mysql.tidb_xa_prepared (format_id, gtrid, bqual, start_ts, instance) VALUES (%?, %?, %?, %?, %?)",
+		b.XID.FormatID, b.XID.Gtrid, b.XID.Bqual, b.StartTS, b.Instance)
+	if err != nil {
+		// The branch is not recorded, so no one else can commit it.
+		_ = b.finish(false)
+		registry.remove(b)
+		return err
+	}
+	registry.ready(b)
+	return nil
+}
+
+// finishPrepared commits or rolls back a prepared branch.
+func (m *xaTxnManager) finishPrepared(ctx context.Context, xid *ast.XID, commit bool) error {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	store, ok := m.sctx.GetStore().(helper.Storage)
+	if !ok {
+		return exeerrors.ErrXAERNota
+	}
+	registry := getXABranchRegistry(store)
+	b, busy := registry.take(*xid)
+	if busy {
+		// The branch is being prepared, committed or rolled back by another session.
+		return exeerrors.ErrXAERNota
+	}
+	var (
+		startTS uint64
+		primary kv.Key
+	)
+	if b != nil {
+		startTS, primary = b.StartTS, b.primary
+	} else {
+		// The branch is prepared by another TiDB instance, or by this instance before it restarts.
+		branch, err := loadXABranch(ctx, m.sctx, xid)
+		if err != nil {
+			return err
+		}
+		if branch == nil {
+			return exeerrors.ErrXAERNota
+		}
+		primary, err = xaBranchLockKey(domain.GetDomain(m.sctx).InfoSchema(), xid)
+		if err != nil {
+			return err
+		}
+		startTS = branch.StartTS
+	}
+
+	// The decision is made on the primary key, so the branch is committed or rolled back only once even if
+	// XA COMMIT and XA ROLLBACK are run by different TiDB instances at the same time.
+	var err error
+	if commit {
+		err = commitXAPrimary(ctx, store, primary, startTS)
+	} else {
+		err = rollbackXAPrimary(ctx, store, primary, startTS)
+	}
+	rolledBack := err == errXABranchRolledBack
+	if err != nil && !rolledBack {
+		if b != nil {
+			registry.ready(b)
+		}
+		return err
+	}
+	if b != nil {
+		// The waiting commit resolves the secondary keys, its result doesn't change the decision.
+		_ = b.finish(true)
+		registry.remove(b)
+	}
+	// The record is deleted after the decision is made. If it fails, the branch is still listed by XA RECOVER,
+	// and finishing it again gets the same result from the primary key.
+	if err := deleteXABranch(ctx, m.sctx, xid); err != nil {
+		return err
+	}
+	if rolledBack {
+		return exeerrors.ErrXARBRollback
+	}
+	return nil
+}
+
+// checkXAState checks the XA transaction of the session is in the expected state and has the given XID.
+func checkXAState(state sessiontxn.XAState, cur, xid *ast.XID, expected sessiontxn.XAState) error {
+	if state == sessiontxn.XAStateNone {
+		return exeerrors.ErrXAERNota
+	}
+	if *cur != *xid {
+		return exeerrors.ErrXAERNota
+	}
+	if state != expected {
+		return exeerrors.ErrXAERRmfail.GenWithStackByArgs(state)
+	}
+	return nil
+}
+
+// xaBranchLockKey returns the key that is locked by an XA transaction as its primary key.
+// The key is in the range of `mysql.tidb_xa_prepared`, but it never belongs to a row since
+// its handle starts with a NULL.
+func xaBranchLockKey(is infoschema.InfoSchema, xid *ast.XID) (kv.Key, error) {
+	tbl, err := is.TableByName(model.NewCIStr(mysql.SystemDB), model.NewCIStr("tidb_xa_prepared"))
+	if err != nil {
+		return nil, err
+	}
+	handle, err := codec.EncodeKey(time.UTC, nil, types.NewDatum(nil), types.NewUintDatum(xid.FormatID),
+		types.NewBytesDatum([]byte(xid.Gtrid)), types.NewBytesDatum([]byte(xid.Bqual)))
+	if err != nil {
+		return nil, err
+	}
+	return tablecodec.EncodeRowKey(tbl.Meta().ID, handle), nil
+}
+
+func loadXABranch(ctx context.Context, sctx sessionctx.Context, xid *ast.XID) (*sessiontxn.XABranch, error) {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	rows, _, err := sctx.GetRestrictedSQLExecutor().ExecRestrictedSQL(ctx, []sqlexec.OptionFuncAlias{sqlexec.ExecOptionUseSessionPool},
+		"SELECT start_ts, instance FROM mysql.tidb_xa_prepared WHERE format_id = %? AND gtrid = %? AND bqual = %?",
+		xid.FormatID, xid.Gtrid, xid.Bqual)
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+	return &sessiontxn.XABranch{XID: *xid, StartTS: rows[0].GetUint64(0), Instance: rows[0].GetString(1)}, nil
+}
+
+func deleteXABranch(ctx context.Context, sctx sessionctx.Context, xid *ast.XID) error {
+	_, _, err := sctx.GetRestrictedSQLExecutor().ExecRestrictedSQL(ctx, []sqlexec.OptionFuncAlias{sqlexec.ExecOptionUseSessionPool},
+		"DELETE FROM mysql.tidb_xa_prepared WHERE format_id = %? AND gtrid = %? AND bqual = %?",
+		xid.FormatID, xid.Gtrid, xid.Bqual)
+	return err
+}
+
+// xaBranch is a prepared XA transaction branch whose commit is waiting for the decision.
+type xaBranch struct {
+	sessiontxn.XABranch
+
+	registry *xaBranchRegistry
+	primary  kv.Key
+	// busy is set when the branch is being prepared, committed or rolled back, it is protected by registry.mu.
+	busy bool
+	// prepared receives the result of the prewrite.
+	prepared chan error
+	// decision receives true after the primary key is committed or rolled back, or false if the branch
+	// should be rolled back.
+	decision chan bool
+	// done receives the result of the commit.
+	done chan error
+}
+
+// waitForDecision returns the commit ts checker that blocks the commit until the decision is made.
+// It is called after the prewrite succeeds and before the primary key is committed.
+//
+// The commit ts got by the commit is stale when the decision is made, so the primary key is committed
+// by XA COMMIT instead, and the secondary keys are resolved here with the commit ts of the primary key
+// before the commit goes on. The commit then finds nothing left to commit except the keys that are
+// only locked.
+func (b *xaBranch) waitForDecision(store helper.Storage, txn kv.Transaction) func(uint64) bool {
+	return func(uint64) bool {
+		ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnOthers)
+		if err := keepXAPrimaryLock(ctx, store, b.primary, b.StartTS); err != nil {
+			b.prepared <- err
+			return false
+		}
+		b.prepared <- nil
+
+		ticker := time.NewTicker(xaDecisionCheckInterval)
+		defer ticker.Stop()
+		var status txnlock.TxnStatus
+		for {
+			select {
+			case decided := <-b.decision:
+				if !decided {
+					return false
+				}
+			case <-ticker.C:
+				// The branch may be committed or rolled back by another TiDB instance.
+			case <-store.Closed():
+				// The locks are not cleaned up after the store is closed, the branch is recovered from its record.
+				return false
+			}
+			var err error
+			status, err = store.GetLockResolver().GetTxnStatus(b.StartTS, 0, b.primary)
+			if err == nil && status.TTL() == 0 {
+				break
+			}
+			if err != nil {
+				logutil.BgLogger().Warn("failed to get the status of XA transaction branch",
+					zap.Stringer("xid", &b.XID), zap.Uint64("startTS", b.StartTS), zap.Error(err))
+			}
+		}
+		b.registry.expire(b)
+		if !status.IsCommitted() {
+			return false
+		}
+		// The secondary keys must not be left to the commit: it commits them with its stale commit ts, and
+		// it rolls them back if the checker fails. So the resolving is retried until it succeeds, or the
+		// store is closed and the cleanup of the commit is skipped.
+		for {
+			err := resolveXASecondaries(ctx, store, txn, b.StartTS, status.CommitTS())
+			if err == nil {
+				return true
+			}
+			logutil.BgLogger().Warn("failed to resolve the secondary keys of XA transaction branch",
+				zap.Stringer("xid", &b.XID), zap.Uint64("startTS", b.StartTS), zap.Uint64("commitTS", status.CommitTS()), zap.Error(err))
+			select {
+			case <-time.After(xaResolveRetryInterval):
+			case <-store.Closed():
+				return false
+			}
+		}
+	}
+}
+
+// finish tells the branch whether its primary key is committed or rolled back, or it should be rolled back
+// since it fails to be recorded, and waits for the result of the commit.
+func (b *xaBranch) finish(decided bool) error {
+	b.decision <- decided
+	return <-b.done
+}
+
+// xaBranchRegistry holds the prepared XA transaction branches of a TiDB instance.
+type xaBranchRegistry struct {
+	mu       sync.Mutex
+	branches map[ast.XID]*xaBranch
+}
+
+var xaBranchRegistries sync.Map // store UUID -> *xaBranchRegistry
+
+func getXABranchRegistry(store kv.Storage) *xaBranchRegistry {
+	registry, _ := xaBranchRegistries.LoadOrStore(store.UUID(), &xaBranchRegistry{branches: make(map[ast.XID]*xaBranch)})
+	return registry.(*xaBranchRegistry)
+}
+
+// newBranch adds a busy branch, it returns nil if the XID is in use.
+func (r *xaBranchRegistry) newBranch(branch sessiontxn.XABranch, primary kv.Key) *xaBranch {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.branches[branch.XID]; ok {
+		return nil
+	}
+	b := &xaBranch{
+		XABranch: branch,
+		registry: r,
+		primary:  primary,
+		busy:     true,
+		prepared: make(chan error, 1),
+		decision: make(chan bool, 1),
+		done:     make(chan error, 1),
+	}
+	r.branches[branch.XID] = b
+	return b
+}
+
+// take marks the branch busy and returns it. It returns busy = true if the branch is already busy.
+func (r *xaBranchRegistry) take(xid ast.XID) (b *xaBranch, busy bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b, ok := r.branches[xid]
+	if !ok {
+		return nil, false
+	}
+	if b.busy {
+		return nil, true
+	}
+	b.busy = true
+	return b, false
+}
+
+// ready marks the branch ready to be committed or rolled back.
+func (r *xaBranchRegistry) ready(b *xaBranch) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b.busy = false
+}
+
+func (r *xaBranchRegistry) remove(b *xaBranch) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.branches[b.XID] == b {
+		delete(r.branches, b.XID)
+	}
+}
+
+// expire removes the branch if it is not being committed or rolled back.
+func (r *xaBranchRegistry) expire(b *xaBranch) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.branches[b.XID] == b && !b.busy {
+		delete(r.branches, b.XID)
+	}
+}
+
+const (
+	// xaPreparedLockTTL is the TTL of the primary lock of a prepared branch in milliseconds. The lock is
+	// not kept alive after XA PREPARE, and it expires xaPreparedLockTTL after the transaction starts.
+	xaPreparedLockTTL = math.MaxUint32
+	// xaDecisionCheckInterval is the interval to check whether a prepared branch is finished by another TiDB instance.
+	xaDecisionCheckInterval = 10 * time.Second
+	xaMaxBackoff            = 20000
+	xaRequestTimeout        = time.Minute
+
+	// xaResolveRetryInterval is the interval to retry resolving the secondary keys of a committed branch.
+	xaResolveRetryInterval = time.Second
+)
+
+// errXABranchRolledBack means the primary lock of the branch has been rolled back when it is committed.
+var errXABranchRolledBack = errors.New("XA transaction branch is rolled back")
+
+// keepXAPrimaryLock extends the TTL of the primary lock to xaPreparedLockTTL.
+func keepXAPrimaryLock(ctx context.Context, store helper.Storage, primary kv.Key, startTS uint64) error {
+	req := tikvrpc.NewRequest(tikvrpc.CmdTxnHeartBeat, &kvrpcpb.TxnHeartBeatRequest{
+		PrimaryLock:   primary,
+		StartVersion:  startTS,
+		AdviseLockTtl: xaPreparedLockTTL,
+	})
+	resp, err := sendXAPrimaryReq(tikv.NewBackoffer(ctx, xaMaxBackoff), store, primary, req)
+	if err != nil {
+		return err
+	}
+	if keyErr := resp.Resp.(*kvrpcpb.TxnHeartBeatResponse).GetError(); keyErr != nil {
+		return errors.Errorf("failed to extend the TTL of the XA transaction branch: %s", keyErr)
+	}
+	return nil
+}
+
+// commitXAPrimary commits the primary key of a prepared branch with a fresh commit ts, it returns
+// errXABranchRolledBack if the primary lock has been rolled back.
+func commitXAPrimary(ctx context.Context, store helper.Storage, primary kv.Key, startTS uint64) error {
+	bo := tikv.NewBackoffer(ctx, xaMaxBackoff)
+	for {
+		commitTS, err := store.GetOracle().GetTimestamp(ctx, &oracle.Option{TxnScope: oracle.GlobalTxnScope})
+		if err != nil {
+			return err
+		}
+		req := tikvrpc.NewRequest(tikvrpc.CmdCommit, &kvrpcpb.CommitRequest{
+			StartVersion:  startTS,
+			Keys:          [][]byte{primary},
+			CommitVersion: commitTS,
+		})
+		resp, err := sendXAPrimaryReq(bo, store, primary, req)
+		if err != nil {
+			return err
+		}
+		keyErr := resp.Resp.(*kvrpcpb.CommitResponse).GetError()
+		if keyErr == nil {
+			return nil
+		}
+		if keyErr.GetCommitTsExpired() != nil {
+			// The min commit ts of the lock has been pushed by the readers, retry with a newer commit ts.
+			continue
+		}
+		// The primary lock is not found, it may have been committed or rolled back.
+		status, err := store.GetLockResolver().GetTxnStatus(startTS, 0, primary)
+		if err != nil {
+			return err
+		}
+		if status.IsCommitted() {
+			return nil
+		}
+		if status.IsRolledBack() {
+			return errXABranchRolledBack
+		}
+		return errors.Errorf("failed to commit the XA transaction branch: %s", keyErr)
+	}
+}
+
+// rollbackXAPrimary rolls back the primary key of a prepared branch.
+func rollbackXAPrimary(ctx context.Context, store helper.Storage, primary kv.Key, startTS uint64) error {
+	req := tikvrpc.NewRequest(tikvrpc.CmdBatchRollback, &kvrpcpb.BatchRollbackRequest{
+		StartVersion: startTS,
+		Keys:         [][]byte{primary},
+	})
+	resp, err := sendXAPrimaryReq(tikv.NewBackoffer(ctx, xaMaxBackoff), store, primary, req)
+	if err != nil {
+		return err
+	}
+	if keyErr := resp.Resp.(*kvrpcpb.BatchRollbackResponse).GetError(); keyErr != nil {
+		return errors.Errorf("failed to roll back the XA transaction branch: %s", keyErr)
+	}
+	return nil
+}
+
+// resolveXASecondaries resolves the locks of the keys written by the transaction of a prepared branch
+// with the commit ts of its primary key.
+func resolveXASecondaries(ctx context.Context, store helper.Storage, txn kv.Transaction, startTS, commitTS uint64) error {
+	failpoint.Inject("resolveXASecondariesErr", func() {
+		failpoint.Return(errors.New("mock resolve XA secondaries error"))
+	})
+	var keys [][]byte
+	it, err := txn.GetMemBuffer().Iter(nil, nil)
+	if err != nil {
+		return err
+	}
+	for ; it.Valid(); err = it.Next() {
+		if err != nil {
+			it.Close()
+			return err
+		}
+		keys = append(keys, it.Key().Clone())
+	}
+	it.Close()
+
+	bo := tikv.NewBackoffer(ctx, xaMaxBackoff)
+	for len(keys) > 0 {
+		loc, err := store.GetRegionCache().LocateKey(bo, keys[0])
+		if err != nil {
+			return err
+		}
+		n := 1
+		for n < len(keys) && loc.Contains(keys[n]) {
+			n++
+		}
+		req := tikvrpc.NewRequest(tikvrpc.CmdResolveLock, &kvrpcpb.ResolveLockRequest{
+			StartVersion:  startTS,
+			CommitVersion: commitTS,
+			Keys:          keys[:n],
+		})
+		resp, err := store.SendReq(bo, req, loc.Region, xaRequestTimeout)
+		if err != nil {
+			return err
+		}
+		regionErr, err := resp.GetRegionError()
+		if err != nil {
+			return err
+		}
+		if regionErr != nil {
+			if err := bo.Backoff(tikv.BoRegionMiss(), errors.New(regionErr.String())); err != nil {
+				return err
+			}
+			continue
+		}
+		if resp.Resp == nil {
+			return errors.Trace(tikverr.ErrBodyMissing)
+		}
+		if keyErr := resp.Resp.(*kvrpcpb.ResolveLockResponse).GetError(); keyErr != nil {
+			return errors.Errorf("failed to resolve the locks of the XA transaction branch: %s", keyErr)
+		}
+		keys = keys[n:]
+	}
+	return nil
+}
+
+// sendXAPrimaryReq sends the request to the region of the primary key, and retries on region errors.
+func sendXAPrimaryReq(bo *tikv.Backoffer, store helper.Storage, primary kv.Key, req *tikvrpc.Request) (*tikvrpc.Response, error) {
+	for {
+		loc, err := store.GetRegionCache().LocateKey(bo, primary)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := store.SendReq(bo, req, loc.Region, xaRequestTimeout)
+		if err != nil {
+			return nil, err
+		}
+		regionErr, err := resp.GetRegionError()
+		if err != nil {
+			return nil, err
+		}
+		if regionErr != nil {
+			if err := bo.Backoff(tikv.BoRegionMiss(), errors.New(regionErr.String())); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if resp.Resp == nil {
+			return nil, errors.Trace(tikverr.ErrBodyMissing)
+		}
+		return resp, nil
+	}
+}
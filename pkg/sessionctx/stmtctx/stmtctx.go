@@ -32,6 +32,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/statistics/feedback"
 	"github.com/pingcap/tidb/pkg/statistics/handle/usage/indexusage"
 	"github.com/pingcap/tidb/pkg/types"
 	contextutil "github.com/pingcap/tidb/pkg/util/context"
@@ -358,6 +359,11 @@ type StatementContext struct {
 	// usedStatsInfo records version of stats of each table used in the query.
 	// It's a map of table physical id -> *UsedStatsInfoForTable
 	usedStatsInfo atomic.Pointer[UsedStatsInfo]
+	// cardinalityCorrections records the learned cardinality corrections applied in the optimization.
+	cardinalityCorrections struct {
+		sync.Mutex
+		factors map[feedback.Key]float64
+	}
 	// IsSyncStatsFailed indicates whether any failure happened during sync stats
 	IsSyncStatsFailed bool
 	// UseDynamicPruneMode indicates whether use UseDynamicPruneMode in query stmt
@@ -1101,6 +1107,24 @@ func (sc *StatementContext) GetUsedStatsInfo(initIfNil bool) *UsedStatsInfo {
 	return sc.usedStatsInfo.Load()
 }
 
+// RecordCardinalityCorrection records the factor of a learned cardinality correction applied in the optimization.
+func (sc *StatementContext) RecordCardinalityCorrection(key feedback.Key, factor float64) {
+	sc.cardinalityCorrections.Lock()
+	defer sc.cardinalityCorrections.Unlock()
+	if sc.cardinalityCorrections.factors == nil {
+		sc.cardinalityCorrections.factors = make(map[feedback.Key]float64)
+	}
+	sc.cardinalityCorrections.factors[key] = factor
+}
+
+// AppliedCardinalityCorrection returns the factor of the learned cardinality correction applied in the optimization.
+func (sc *StatementContext) AppliedCardinalityCorrection(key feedback.Key) (float64, bool) {
+	sc.cardinalityCorrections.Lock()
+	defer sc.cardinalityCorrections.Unlock()
+	factor, ok := sc.cardinalityCorrections.factors[key]
+	return factor, ok
+}
+
 // RecordedStatsLoadStatusCnt returns the total number of recorded column/index stats status, which is not full loaded.
 func (sc *StatementContext) RecordedStatsLoadStatusCnt() (cnt int) {
 	allStatus := sc.GetUsedStatsInfo(false)
@@ -746,6 +746,9 @@ type SessionVars struct {
 	// TxnManager is used to manage txn context in session
 	TxnManager any
 
+	// XATxnManager is used to manage the XA transaction in session
+	XATxnManager any
+
 	// KVVars is the variables for KV storage.
 	KVVars *tikvstore.Variables
 
@@ -1281,6 +1284,10 @@ type SessionVars struct {
 	// EnableIndexMergeJoin indicates whether to enable index merge join.
 	EnableIndexMergeJoin bool
 
+	// AdaptiveJoinThreshold is the max number of the outer rows of an index lookup join, the join switches to a hash
+	// join once the outer side exceeds it. 0 disables the adaptive join.
+	AdaptiveJoinThreshold int64
+
 	// TrackAggregateMemoryUsage indicates whether to track the memory usage of aggregate function.
 	TrackAggregateMemoryUsage bool
 
@@ -1529,6 +1536,14 @@ type SessionVars struct {
 	// Enable late materialization: push down some selection condition to tablescan.
 	EnableLateMaterialization bool
 
+	// EnableMaterializedViewRewrite indicates whether to rewrite a query to read the materialized view
+	// with the same query. The result may be stale since the view is only refreshed periodically.
+	EnableMaterializedViewRewrite bool
+
+	// EnableCardinalityFeedback indicates whether to learn the corrections of the estimated row counts from the
+	// executions, and apply them in the optimization.
+	EnableCardinalityFeedback bool
+
 	// EnableRowLevelChecksum indicates whether row level checksum is enabled.
 	EnableRowLevelChecksum bool
 
@@ -1596,6 +1611,10 @@ type SessionVars struct {
 	// SessionAlias is the identifier of the session
 	SessionAlias string
 
+	// QueryAttributes is the query attributes sent by the client with the current command.
+	// It's nil if the client doesn't send any query attribute.
+	QueryAttributes map[string]string
+
 	// OptObjective indicates whether the optimizer should be more stable, predictable or more aggressive.
 	// For now, the possible values and corresponding behaviors are:
 	// OptObjectiveModerate: The default value. The optimizer considers the real-time stats (real-time row count, modify count).
@@ -2051,6 +2070,7 @@ func NewSessionVars(hctx HookContext) *SessionVars {
 		GuaranteeLinearizability:      DefTiDBGuaranteeLinearizability,
 		AnalyzeVersion:                DefTiDBAnalyzeVersion,
 		EnableIndexMergeJoin:          DefTiDBEnableIndexMergeJoin,
+		AdaptiveJoinThreshold:         DefTiDBAdaptiveJoinThreshold,
 		AllowFallbackToTiKV:           make(map[kv.StoreType]struct{}),
 		CTEMaxRecursionDepth:          DefCTEMaxRecursionDepth,
 		TMPTableSize:                  DefTiDBTmpTableMaxSize,
@@ -2071,6 +2091,8 @@ func NewSessionVars(hctx HookContext) *SessionVars {
 		mppExchangeCompressionMode:    DefaultExchangeCompressionMode,
 		mppVersion:                    kv.MppVersionUnspecified,
 		EnableLateMaterialization:     DefTiDBOptEnableLateMaterialization,
+		EnableMaterializedViewRewrite: DefTiDBOptEnableMaterializedViewRewrite,
+		EnableCardinalityFeedback:     DefTiDBOptEnableCardinalityFeedback,
 		TiFlashComputeDispatchPolicy:  tiflashcompute.DispatchPolicyConsistentHash,
 		ResourceGroupName:             resourcegroup.DefaultResourceGroupName,
 		DefaultCollationForUTF8MB4:    mysql.DefaultCollationName,
@@ -3075,6 +3097,8 @@ const (
 	SlowLogConnIDStr = "Conn_ID"
 	// SlowLogSessAliasStr is the session alias set by user
 	SlowLogSessAliasStr = "Session_alias"
+	// SlowLogQueryAttributesStr is the query attributes sent by the client.
+	SlowLogQueryAttributesStr = "Query_attributes"
 	// SlowLogQueryTimeStr is slow log field name.
 	SlowLogQueryTimeStr = "Query_time"
 	// SlowLogParseTimeStr is the parse sql time.
@@ -3255,6 +3279,7 @@ type SlowQueryLogItems struct {
 // # Keyspace_ID: 1
 // # User@Host: root[root] @ localhost [127.0.0.1]
 // # Conn_ID: 6
+// # Query_attributes: {"trace_id":"abc"}
 // # Query_time: 4.895492
 // # Process_time: 0.161 Request_count: 1 Total_keys: 100001 Processed_keys: 100000
 // # DB: test
@@ -3292,6 +3317,16 @@ func (s *SessionVars) SlowLogFormat(logItems *SlowQueryLogItems) string {
 	if s.SessionAlias != "" {
 		writeSlowLogItem(&buf, SlowLogSessAliasStr, s.SessionAlias)
 	}
+	if len(s.QueryAttributes) > 0 {
+		buf.WriteString(SlowLogRowPrefixStr + SlowLogQueryAttributesStr + SlowLogSpaceMarkStr)
+		jsonEncoder := json.NewEncoder(&buf)
+		jsonEncoder.SetEscapeHTML(false)
+		// Note that the Encode() will append a '\n' so we don't need to add another.
+		err := jsonEncoder.Encode(s.QueryAttributes)
+		if err != nil {
+			buf.WriteString(err.Error())
+		}
+	}
 	if logItems.ExecRetryCount > 0 {
 		buf.WriteString(SlowLogRowPrefixStr)
 		buf.WriteString(SlowLogExecRetryTime)
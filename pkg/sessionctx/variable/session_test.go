@@ -151,6 +151,7 @@ func TestSlowLogFormat(t *testing.T) {
 	seVar.ConnectionInfo = &variable.ConnectionInfo{ClientIP: "192.168.0.1"}
 	seVar.ConnectionID = 1
 	seVar.SessionAlias = "aliasabc"
+	seVar.QueryAttributes = map[string]string{"trace_id": "<abc>"}
 	// the output of the logged CurrentDB should be 'test', should be to lower cased.
 	seVar.CurrentDB = "TeST"
 	seVar.InRestrictedSQL = true
@@ -225,6 +226,7 @@ func TestSlowLogFormat(t *testing.T) {
 # User@Host: root[root] @ 192.168.0.1 [192.168.0.1]
 # Conn_ID: 1
 # Session_alias: aliasabc
+# Query_attributes: {"trace_id":"<abc>"}
 # Exec_retry_time: 5.1 Exec_retry_count: 3
 # Query_time: 1
 # Parse_time: 0.00000001
@@ -684,6 +684,10 @@ const (
 	// TiDBEnableIndexMergeJoin indicates whether to enable index merge join.
 	TiDBEnableIndexMergeJoin = "tidb_enable_index_merge_join"
 
+	// TiDBAdaptiveJoinThreshold is the max number of the outer rows of an index lookup join. The join switches to a
+	// hash join which scans the whole inner table once the outer side exceeds it. 0 disables the adaptive join.
+	TiDBAdaptiveJoinThreshold = "tidb_adaptive_join_threshold"
+
 	// TiDBTrackAggregateMemoryUsage indicates whether track the memory usage of aggregate function.
 	TiDBTrackAggregateMemoryUsage = "tidb_track_aggregate_memory_usage"
 
@@ -901,6 +905,12 @@ const (
 
 	// TiDBOptEnableLateMaterialization indicates whether to enable late materialization
 	TiDBOptEnableLateMaterialization = "tidb_opt_enable_late_materialization"
+	// TiDBOptEnableMaterializedViewRewrite indicates whether to rewrite a query to read the materialized
+	// view with the same query.
+	TiDBOptEnableMaterializedViewRewrite = "tidb_opt_enable_materialized_view_rewrite"
+	// TiDBOptEnableCardinalityFeedback indicates whether to learn the corrections of the estimated row counts from
+	// the executions, and apply them in the optimization.
+	TiDBOptEnableCardinalityFeedback = "tidb_opt_enable_cardinality_feedback"
 	// TiDBLoadBasedReplicaReadThreshold is the wait duration threshold to enable replica read automatically.
 	TiDBLoadBasedReplicaReadThreshold = "tidb_load_based_replica_read_threshold"
 
@@ -1314,6 +1324,7 @@ const (
 	DefTiDBAnalyzeVersion                          = 2
 	DefTiDBAutoAnalyzePartitionBatchSize           = 128
 	DefTiDBEnableIndexMergeJoin                    = false
+	DefTiDBAdaptiveJoinThreshold                   = 0
 	DefTiDBTrackAggregateMemoryUsage               = true
 	DefCTEMaxRecursionDepth                        = 1000
 	DefTiDBTmpTableMaxSize                         = 64 << 20 // 64MB.
@@ -1454,6 +1465,8 @@ const (
 	DefTiDBEnablePlanCacheForSubquery                 = true
 	DefTiDBLoadBasedReplicaReadThreshold              = time.Second
 	DefTiDBOptEnableLateMaterialization               = true
+	DefTiDBOptEnableMaterializedViewRewrite           = false
+	DefTiDBOptEnableCardinalityFeedback               = false
 	DefTiDBOptOrderingIdxSelThresh                    = 0.0
 	DefTiDBOptOrderingIdxSelRatio                     = -1
 	DefTiDBOptEnableMPPSharedCTEExecution             = false
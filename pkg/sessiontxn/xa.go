@@ -0,0 +1,82 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sessiontxn
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+)
+
+// XAState is the state of the XA transaction of a session.
+type XAState int
+
+const (
+	// XAStateNone means the session is not in an XA transaction.
+	XAStateNone XAState = iota
+	// XAStateActive is the state after `XA START`, statements can be executed in the transaction.
+	XAStateActive
+	// XAStateIdle is the state after `XA END`, the transaction can only be prepared, committed or rolled back.
+	XAStateIdle
+)
+
+// String returns the name of the state as it is shown in the XAER_RMFAIL error.
+func (s XAState) String() string {
+	switch s {
+	case XAStateActive:
+		return "ACTIVE"
+	case XAStateIdle:
+		return "IDLE"
+	default:
+		return "NON-EXISTING"
+	}
+}
+
+// XABranch is a prepared XA transaction branch.
+type XABranch struct {
+	XID ast.XID
+	// StartTS is the start ts of the transaction of the branch.
+	StartTS uint64
+	// Instance is the ID of the TiDB instance which prepared the branch.
+	Instance string
+}
+
+// XATxnManager manages the XA transaction of a session.
+//
+// An XA transaction is a pessimistic transaction. `XA PREPARE` finishes the prewrite of the transaction
+// and leaves the primary lock alive, the branch is then detached from the session and waits for
+// `XA COMMIT` or `XA ROLLBACK` which may come from any session on any TiDB instance.
+type XATxnManager interface {
+	// State returns the state and the XID of the current XA transaction of the session.
+	State() (XAState, *ast.XID)
+	// Start starts a new XA transaction, it is called by `XA START`.
+	Start(ctx context.Context, xid *ast.XID) error
+	// End ends the statements of the current XA transaction, it is called by `XA END`.
+	End(xid *ast.XID) error
+	// Prepare marks the current XA transaction to be prepared when the statement finishes,
+	// it is called by `XA PREPARE`.
+	Prepare(xid *ast.XID) error
+	// Commit commits a prepared branch, or the current XA transaction if onePhase is true.
+	// It is called by `XA COMMIT`.
+	Commit(ctx context.Context, xid *ast.XID, onePhase bool) error
+	// Rollback rolls back a prepared branch or the current XA transaction, it is called by `XA ROLLBACK`.
+	Rollback(ctx context.Context, xid *ast.XID) error
+	// Recover returns all the prepared branches, it is called by `XA RECOVER`.
+	Recover(ctx context.Context) ([]XABranch, error)
+}
+
+// GetXATxnManager returns the XATxnManager object from session context
+var GetXATxnManager func(sctx sessionctx.Context) XATxnManager
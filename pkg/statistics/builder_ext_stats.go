@@ -27,9 +27,11 @@ import (
 	"go.uber.org/zap"
 )
 
-// BuildExtendedStats build extended stats for column groups if needed based on the column samples.
+// BuildExtendedStats build extended stats for column groups if needed based on the column samples. sampleNum is the
+// number of the sampled rows if the samples of the columns are from the same rows, the multi-column statistics are
+// only built in this case, and it's 0 otherwise.
 func BuildExtendedStats(sctx sessionctx.Context,
-	tableID int64, cols []*model.ColumnInfo, collectors []*SampleCollector) (*ExtendedStatsColl, error) {
+	tableID int64, cols []*model.ColumnInfo, collectors []*SampleCollector, sampleNum int) (*ExtendedStatsColl, error) {
 	const sql = "SELECT name, type, column_ids FROM mysql.stats_extended WHERE table_id = %? and status in (%?, %?)"
 
 	sqlExec := sctx.GetRestrictedSQLExecutor()
@@ -50,7 +52,7 @@ func BuildExtendedStats(sctx sessionctx.Context,
 			logutil.BgLogger().Error("invalid column_ids in mysql.stats_extended, skip collecting extended stats for this row", zap.String("column_ids", colIDs), zap.Error(err))
 			continue
 		}
-		item = fillExtendedStatsItemVals(sctx, item, cols, collectors)
+		item = fillExtendedStatsItemVals(sctx, item, cols, collectors, sampleNum)
 		if item != nil {
 			statsColl.Stats[name] = item
 		}
@@ -61,7 +63,10 @@ func BuildExtendedStats(sctx sessionctx.Context,
 	return statsColl, nil
 }
 
-func fillExtendedStatsItemVals(sctx sessionctx.Context, item *ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*SampleCollector) *ExtendedStatsItem {
+func fillExtendedStatsItemVals(sctx sessionctx.Context, item *ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*SampleCollector, sampleNum int) *ExtendedStatsItem {
+	if IsMultiColumnStatsType(item.Tp) {
+		return buildMultiColumnStats(sctx.GetSessionVars().StmtCtx, item, cols, collectors, sampleNum)
+	}
 	switch item.Tp {
 	case ast.StatsTypeCardinality, ast.StatsTypeDependency:
 		return nil
@@ -0,0 +1,164 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package feedback
+
+import (
+	"cmp"
+	"container/list"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// MaxCorrections is the max number of the corrections kept in a tidb-server instance. The least recently used
+	// correction is evicted when a new one is learned and the store is full.
+	MaxCorrections = 4096
+	// learningRate is the weight of the latest observation when it's merged into the correction factor.
+	learningRate = 0.5
+	// maxFactor bounds the correction factor in both directions, to avoid the extreme estimations caused by a few
+	// unusual executions.
+	maxFactor = 1e4
+)
+
+// Key identifies the predicates on a table whose estimation is corrected.
+type Key struct {
+	// TableID is the physical table ID, which is the partition ID for a partition.
+	TableID int64
+	// Shape is the normalized predicates without the constants, see `cardinality.PredicateShape`.
+	Shape string
+}
+
+// Correction is the learned correction of the estimated row count of the predicates on a table.
+type Correction struct {
+	Key
+	// Factor is the ratio of the actual row count to the estimated one, which is a moving average of the ratios in
+	// the executions. The estimated selectivity of the predicates is multiplied by it.
+	Factor float64
+	// ExecCount is the number of the executions which the factor is learned from.
+	ExecCount int64
+	// LastEstRows is the row count estimated without the correction in the last execution.
+	LastEstRows float64
+	// LastActRows is the actual row count in the last execution.
+	LastActRows float64
+	// LastUpdateTime is the time of the last execution.
+	LastUpdateTime time.Time
+}
+
+// store is a bounded LRU map of the corrections in a tidb-server instance. It is protected by a mutex for
+// simplicity, since it is only accessed when the feedback is enabled.
+type store struct {
+	sync.Mutex
+	capacity int
+	items    map[Key]*list.Element
+	lru      *list.List
+}
+
+var corrections = newStore(MaxCorrections)
+
+func newStore(capacity int) *store {
+	return &store{
+		capacity: capacity,
+		items:    make(map[Key]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+func (s *store) record(key Key, estRows, actRows float64, now time.Time) {
+	// A row count smaller than 1 makes no difference in the plans, but makes the ratios unstable.
+	ratio := max(actRows, 1) / max(estRows, 1)
+	s.Lock()
+	defer s.Unlock()
+	if elem, ok := s.items[key]; ok {
+		c := elem.Value.(*Correction)
+		// The factor is merged in the log space, so the over-estimations and the under-estimations are treated equally.
+		c.Factor = math.Exp((1-learningRate)*math.Log(c.Factor) + learningRate*math.Log(ratio))
+		c.Factor = min(max(c.Factor, 1/maxFactor), maxFactor)
+		c.ExecCount++
+		c.LastEstRows, c.LastActRows, c.LastUpdateTime = estRows, actRows, now
+		s.lru.MoveToFront(elem)
+		return
+	}
+	if s.lru.Len() >= s.capacity {
+		oldest := s.lru.Back()
+		delete(s.items, oldest.Value.(*Correction).Key)
+		s.lru.Remove(oldest)
+	}
+	s.items[key] = s.lru.PushFront(&Correction{
+		Key:            key,
+		Factor:         min(max(ratio, 1/maxFactor), maxFactor),
+		ExecCount:      1,
+		LastEstRows:    estRows,
+		LastActRows:    actRows,
+		LastUpdateTime: now,
+	})
+}
+
+func (s *store) factor(key Key) (float64, bool) {
+	s.Lock()
+	defer s.Unlock()
+	elem, ok := s.items[key]
+	if !ok {
+		return 0, false
+	}
+	s.lru.MoveToFront(elem)
+	return elem.Value.(*Correction).Factor, true
+}
+
+func (s *store) corrections() []Correction {
+	s.Lock()
+	result := make([]Correction, 0, s.lru.Len())
+	for elem := s.lru.Front(); elem != nil; elem = elem.Next() {
+		result = append(result, *elem.Value.(*Correction))
+	}
+	s.Unlock()
+	slices.SortFunc(result, func(a, b Correction) int {
+		if r := cmp.Compare(a.TableID, b.TableID); r != 0 {
+			return r
+		}
+		return strings.Compare(a.Shape, b.Shape)
+	})
+	return result
+}
+
+func (s *store) flush() {
+	s.Lock()
+	defer s.Unlock()
+	s.items = make(map[Key]*list.Element)
+	s.lru.Init()
+}
+
+// Record learns the correction of the predicates from an execution. The estimated row count must be the one
+// without the correction.
+func Record(key Key, estRows, actRows float64) {
+	corrections.record(key, estRows, actRows, time.Now())
+}
+
+// Factor returns the correction factor of the predicates.
+func Factor(key Key) (float64, bool) {
+	return corrections.factor(key)
+}
+
+// Corrections returns all the corrections, ordered by the table IDs and the shapes.
+func Corrections() []Correction {
+	return corrections.corrections()
+}
+
+// Flush removes all the corrections.
+func Flush() {
+	corrections.flush()
+}
@@ -0,0 +1,67 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package feedback
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	s := newStore(2)
+	now := time.Now()
+	k1 := Key{TableID: 1, Shape: "eq(test.t.a, ?)"}
+	k2 := Key{TableID: 1, Shape: "gt(test.t.b, ?)"}
+	k3 := Key{TableID: 2, Shape: "eq(test.t2.a, ?)"}
+
+	_, ok := s.factor(k1)
+	require.False(t, ok)
+	s.record(k1, 10, 100, now)
+	factor, ok := s.factor(k1)
+	require.True(t, ok)
+	require.InDelta(t, 10, factor, 1e-9)
+	// The factors are merged in the log space: exp((log(10) + log(1000)) / 2) = 100.
+	s.record(k1, 1, 1000, now)
+	factor, _ = s.factor(k1)
+	require.InDelta(t, 100, factor, 1e-9)
+	// The row counts smaller than 1 are treated as 1, and the factor is bounded.
+	s.record(k2, 0, 1e9, now)
+	factor, _ = s.factor(k2)
+	require.Equal(t, float64(maxFactor), factor)
+
+	cs := s.corrections()
+	require.Len(t, cs, 2)
+	require.Equal(t, k1, cs[0].Key)
+	require.Equal(t, int64(2), cs[0].ExecCount)
+	require.Equal(t, float64(1), cs[0].LastEstRows)
+	require.Equal(t, float64(1000), cs[0].LastActRows)
+	require.Equal(t, k2, cs[1].Key)
+
+	// k1 is used after k2, so k2 is evicted.
+	s.factor(k1)
+	s.record(k3, 100, 10, now)
+	_, ok = s.factor(k2)
+	require.False(t, ok)
+	factor, ok = s.factor(k3)
+	require.True(t, ok)
+	require.InDelta(t, 0.1, factor, 1e-9)
+
+	s.flush()
+	require.Empty(t, s.corrections())
+	_, ok = s.factor(k1)
+	require.False(t, ok)
+}
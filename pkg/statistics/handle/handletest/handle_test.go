@@ -641,6 +641,82 @@ func TestCorrelationStatsCompute(t *testing.T) {
 	))
 }
 
+func TestMultiColumnStats(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("set session tidb_enable_extended_stats = on")
+	tk.MustExec("set @@session.tidb_analyze_version = 2")
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, c int)")
+	values := make([]string, 0, 100)
+	for i := 0; i < 100; i++ {
+		// b is determined by a, while c is independent of a.
+		values = append(values, fmt.Sprintf("(%d,%d,%d)", i%10, i%10*10, i%7))
+	}
+	tk.MustExec("insert into t values " + strings.Join(values, ","))
+	err := tk.ExecToErr("create statistics s1 on a from t")
+	require.Equal(t, "Only support multi-column statistics on 2 to 8 columns", err.Error())
+	tk.MustExec("create statistics s1 (ndistinct, dependencies, mcv) on a, b from t")
+	tk.MustExec("create statistics if not exists s1 on a, c from t")
+	tk.MustExec("create statistics s2 (dependencies) on a, b, c from t")
+	tk.MustExec("create statistics s3 (ndistinct) on a, c from t")
+	tk.MustQuery("select name, type, column_ids, status from mysql.stats_extended").Sort().Check(testkit.Rows(
+		"s1 60 [1,2] 0",
+		"s2 20 [1,2,3] 0",
+		"s3 12 [1,3] 0",
+	))
+	tk.MustExec("analyze table t")
+	tk.MustQuery("select name, status from mysql.stats_extended").Sort().Check(testkit.Rows("s1 1", "s2 1", "s3 1"))
+	rows := tk.MustQuery("show stats_extended where table_name = 't'").Sort().Rows()
+	require.Len(t, rows, 3)
+	require.Equal(t, "ndistinct,dependencies,mcv", rows[0][4])
+	require.Equal(t, "dependencies", rows[1][4])
+	require.Equal(t, "ndistinct", rows[2][4])
+
+	is := dom.InfoSchema()
+	tbl, err := is.TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	require.NoError(t, dom.StatsHandle().Update(is))
+	statsTbl := dom.StatsHandle().GetTableStats(tbl.Meta())
+	require.Len(t, statsTbl.ExtendedStats.Stats, 3)
+	s1 := statsTbl.ExtendedStats.Stats["s1"].MultiColumn
+	require.NotNil(t, s1)
+	ndv, ok := s1.NDV([]int64{1, 2})
+	require.True(t, ok)
+	require.Equal(t, float64(10), ndv)
+	require.Len(t, s1.MCV, 10)
+	for _, dep := range s1.Dependencies {
+		require.Equal(t, float64(1), dep.Degree)
+	}
+
+	// The rows are estimated by the MCV list instead of the independence assumption.
+	tk.MustQuery("explain format = 'brief' select * from t where a = 1 and b = 10").Check(testkit.Rows(
+		"TableReader 10.00 root  data:Selection",
+		"└─Selection 10.00 cop[tikv]  eq(test.t.a, 1), eq(test.t.b, 10)",
+		"  └─TableFullScan 100.00 cop[tikv] table:t keep order:false",
+	))
+	// The rows are estimated by the dependencies, a -> b holds while c is independent.
+	tk.MustQuery("explain format = 'brief' select * from t where a = 1 and b = 10 and c = 1").Check(testkit.Rows(
+		"TableReader 1.50 root  data:Selection",
+		"└─Selection 1.50 cop[tikv]  eq(test.t.a, 1), eq(test.t.b, 10), eq(test.t.c, 1)",
+		"  └─TableFullScan 100.00 cop[tikv] table:t keep order:false",
+	))
+	// The NDV of the group is estimated by the multi-column statistics.
+	tk.MustQuery("explain format = 'brief' select a, c from t group by a, c").Check(testkit.Rows(
+		"HashAgg 70.00 root  group by:test.t.a, test.t.c, funcs:firstrow(test.t.a)->test.t.a, funcs:firstrow(test.t.c)->test.t.c",
+		"└─TableReader 70.00 root  data:HashAgg",
+		"  └─HashAgg 70.00 cop[tikv]  group by:test.t.a, test.t.c, ",
+		"    └─TableFullScan 100.00 cop[tikv] table:t keep order:false",
+	))
+
+	tk.MustExec("set session tidb_enable_extended_stats = off")
+	tk.MustQuery("explain format = 'brief' select * from t where a = 1 and b = 10").Check(testkit.Rows(
+		"TableReader 1.00 root  data:Selection",
+		"└─Selection 1.00 cop[tikv]  eq(test.t.a, 1), eq(test.t.b, 10)",
+		"  └─TableFullScan 100.00 cop[tikv] table:t keep order:false",
+	))
+}
+
 func TestSyncStatsExtendedRemoval(t *testing.T) {
 	store, dom := testkit.CreateMockStoreAndDomain(t)
 	tk := testkit.NewTestKit(t, store)
@@ -63,6 +63,14 @@ func extendedStatsFromJSON(statsColl []*util.JSONExtendedStats) *statistics.Exte
 			ScalarVals: js.ScalarVals,
 			StringVals: js.StringVals,
 		}
+		if statistics.IsMultiColumnStatsType(item.Tp) {
+			multiColumn, err := statistics.DecodeMultiColumnStats(item.ColIDs, item.StringVals)
+			if err != nil {
+				logutil.BgLogger().Warn("decode multi-column stats failed, skip loading it", zap.String("name", js.StatsName), zap.Error(err))
+				continue
+			}
+			item.MultiColumn = multiColumn
+		}
 		stats.Stats[js.StatsName] = item
 	}
 	return stats
@@ -0,0 +1,162 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistics
+
+import (
+	"bytes"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/codec"
+)
+
+// AddHypoIndex adds the synthetic statistics of a hypothetical index to the HistColl generated for a
+// query, colIDs are the UniqueIDs of the index columns. A hypothetical index has no data, so its
+// statistics are derived from the statistics of its columns:
+//  1. The histogram and the TopN are the ones of the first column, whose values are encoded as the
+//     index keys, so they are the statistics of the index prefix. For a multi-column index, the TopN
+//     is merged into the histogram.
+//  2. The NDV is the product of the NDVs of the columns, which is no larger than the row count.
+//
+// The estimation of the ranges on multiple columns relies on the statistics of the columns, see
+// `expBackoffEstimation`. It returns false if the statistics of the first column are not available.
+func (coll *HistColl) AddHypoIndex(info *model.IndexInfo, colIDs []int64) bool {
+	if coll.Pseudo || len(colIDs) == 0 {
+		return false
+	}
+	first := coll.Columns[colIDs[0]]
+	if first == nil || !first.IsEssentialStatsLoaded() || first.TotalRowCount() == 0 {
+		return false
+	}
+	rowCount := first.TotalRowCount()
+	ndv := float64(first.Histogram.NDV)
+	for _, id := range colIDs[1:] {
+		// The columns without statistics are ignored, which leads to a smaller NDV and a conservative
+		// estimation.
+		if col := coll.Columns[id]; col != nil && col.IsEssentialStatsLoaded() && col.Histogram.NDV > 0 {
+			ndv *= float64(col.Histogram.NDV)
+		}
+	}
+	if info.Unique && len(colIDs) == len(info.Columns) {
+		ndv = rowCount
+	}
+	ndv = min(ndv, rowCount)
+
+	var nullCount int64
+	var topN *TopN
+	buckets, ok := hypoIndexBuckets(first)
+	if !ok {
+		return false
+	}
+	if len(info.Columns) == 1 {
+		nullCount = first.Histogram.NullCount
+		if first.StatsVer >= Version2 {
+			topN = first.TopN.Copy()
+		}
+	} else if first.StatsVer >= Version2 {
+		// The values of the TopN of the first column are prefixes of the index keys, which never match
+		// the keys of the whole index, so they are merged into the buckets.
+		buckets = mergeTopNIntoBuckets(buckets, first.TopN)
+	}
+	hist := NewHistogram(info.ID, int64(ndv), nullCount, first.Histogram.LastUpdateVersion,
+		types.NewFieldType(mysql.TypeBlob), len(buckets), 0)
+	var count int64
+	for _, bkt := range buckets {
+		count += bkt.count
+		lower, upper := types.NewBytesDatum(bkt.lower), types.NewBytesDatum(bkt.upper)
+		hist.AppendBucketWithNDV(&lower, &upper, count, bkt.repeat, bkt.ndv)
+	}
+	hist.PreCalculateScalar()
+
+	coll.Indices[info.ID] = &Index{
+		Histogram:         *hist,
+		TopN:              topN,
+		Info:              info,
+		StatsVer:          Version2,
+		PhysicalID:        coll.PhysicalID,
+		StatsLoadedStatus: NewStatsFullLoadStatus(),
+	}
+	coll.Idx2ColUniqueIDs[info.ID] = colIDs
+	// The IDs of the hypothetical indexes are larger than the real ones, so the IDs are still sorted.
+	coll.ColUniqueID2IdxIDs[colIDs[0]] = append(coll.ColUniqueID2IdxIDs[colIDs[0]], info.ID)
+	return true
+}
+
+// hypoBucket is a bucket of the histogram of a hypothetical index, its count is not cumulative.
+type hypoBucket struct {
+	lower, upper       []byte
+	count, repeat, ndv int64
+}
+
+// hypoIndexBuckets encodes the bounds of the histogram of the column as the index keys.
+func hypoIndexBuckets(col *Column) ([]hypoBucket, bool) {
+	buckets := make([]hypoBucket, 0, col.Histogram.Len())
+	var preCount int64
+	for i := 0; i < col.Histogram.Len(); i++ {
+		// The values of the columns are stored without time zones, see `EncodeKey` of the index values.
+		lower, err := codec.EncodeKey(time.UTC, nil, *col.Histogram.GetLower(i))
+		if err != nil {
+			return nil, false
+		}
+		upper, err := codec.EncodeKey(time.UTC, nil, *col.Histogram.GetUpper(i))
+		if err != nil {
+			return nil, false
+		}
+		bkt := col.Histogram.Buckets[i]
+		buckets = append(buckets, hypoBucket{
+			lower:  lower,
+			upper:  upper,
+			count:  bkt.Count - preCount,
+			repeat: bkt.Repeat,
+			ndv:    bkt.NDV,
+		})
+		preCount = bkt.Count
+	}
+	return buckets, true
+}
+
+// mergeTopNIntoBuckets adds the values of the TopN to the buckets containing them, or to new buckets
+// if no bucket contains them.
+func mergeTopNIntoBuckets(buckets []hypoBucket, topN *TopN) []hypoBucket {
+	if topN == nil {
+		return buckets
+	}
+	for _, meta := range topN.TopN {
+		count := int64(meta.Count)
+		i := sort.Search(len(buckets), func(i int) bool {
+			return bytes.Compare(buckets[i].upper, meta.Encoded) >= 0
+		})
+		if i < len(buckets) && bytes.Compare(buckets[i].lower, meta.Encoded) <= 0 {
+			buckets[i].count += count
+			buckets[i].ndv++
+			if bytes.Equal(buckets[i].upper, meta.Encoded) {
+				buckets[i].repeat += count
+			}
+			continue
+		}
+		buckets = slices.Insert(buckets, i, hypoBucket{
+			lower:  meta.Encoded,
+			upper:  meta.Encoded,
+			count:  count,
+			repeat: count,
+			ndv:    1,
+		})
+	}
+	return buckets
+}
@@ -0,0 +1,41 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeTopNIntoBuckets(t *testing.T) {
+	buckets := []hypoBucket{
+		{lower: []byte("b"), upper: []byte("d"), count: 10, repeat: 2, ndv: 3},
+		{lower: []byte("f"), upper: []byte("h"), count: 10, repeat: 2, ndv: 3},
+	}
+	topN := NewTopN(4)
+	topN.AppendTopN([]byte("a"), 5)
+	topN.AppendTopN([]byte("c"), 5)
+	topN.AppendTopN([]byte("e"), 5)
+	topN.AppendTopN([]byte("h"), 5)
+	buckets = mergeTopNIntoBuckets(buckets, topN)
+	require.Equal(t, []hypoBucket{
+		{lower: []byte("a"), upper: []byte("a"), count: 5, repeat: 5, ndv: 1},
+		{lower: []byte("b"), upper: []byte("d"), count: 15, repeat: 2, ndv: 4},
+		{lower: []byte("e"), upper: []byte("e"), count: 5, repeat: 5, ndv: 1},
+		{lower: []byte("f"), upper: []byte("h"), count: 15, repeat: 7, ndv: 4},
+	}, buckets)
+	require.Equal(t, buckets, mergeTopNIntoBuckets(buckets, nil))
+}
@@ -0,0 +1,410 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistics
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"math/bits"
+	"slices"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/pkg/util/codec"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+const (
+	// MaxMultiColumnStatsColumns is the max number of columns of the multi-column statistics.
+	MaxMultiColumnStatsColumns = 8
+	// maxMCVItems is the max number of the items in the MCV list of the multi-column statistics.
+	maxMCVItems = 100
+	// maxMultiColumnStatsSize is the max size of the encoded multi-column statistics, which are stored in the BLOB
+	// column mysql.stats_extended.stats.
+	maxMultiColumnStatsSize = 65535
+)
+
+// IsMultiColumnStatsType returns whether the type of the extended statistics is the multi-column statistics, whose
+// kinds are the flags in the type.
+func IsMultiColumnStatsType(tp uint8) bool {
+	return tp&ast.StatsTypeMultiColumn != 0
+}
+
+// MultiColumnStatsKinds returns the names of the kinds of the multi-column statistics, like `ndistinct,mcv`.
+func MultiColumnStatsKinds(tp uint8) string {
+	var kinds []string
+	if tp&ast.StatsKindNDistinct != 0 {
+		kinds = append(kinds, "ndistinct")
+	}
+	if tp&ast.StatsKindDependencies != 0 {
+		kinds = append(kinds, "dependencies")
+	}
+	if tp&ast.StatsKindMCV != 0 {
+		kinds = append(kinds, "mcv")
+	}
+	return strings.Join(kinds, ",")
+}
+
+// MultiColumnStats is the content of the multi-column statistics created by `CREATE STATISTICS ... ON ... FROM`.
+// They describe how the values of the columns are correlated, which is lost when the statistics of the columns
+// are combined with the independence assumption.
+//
+// The column IDs are the IDs in the table info when the statistics are stored, and they are the UniqueIDs when the
+// statistics are used in a query, see `HistColl.AddMultiColumnStats`.
+type MultiColumnStats struct {
+	// ColIDs are the columns of the statistics, the values in the MCV list are in the same order.
+	ColIDs []int64 `json:"-"`
+	// NDistincts are the NDVs of the combinations of 2 or more columns.
+	NDistincts []*MultiColumnNDV `json:"ndistinct,omitempty"`
+	// Dependencies are the functional dependencies between the columns.
+	Dependencies []*ColumnDependency `json:"dependencies,omitempty"`
+	// MCV is the most common combinations of the values of all the columns.
+	MCV []*MCVItem `json:"mcv,omitempty"`
+}
+
+// MultiColumnNDV is the NDV of a combination of columns.
+type MultiColumnNDV struct {
+	// ColIDs are sorted.
+	ColIDs []int64 `json:"cols"`
+	NDV    float64 `json:"ndv"`
+}
+
+// ColumnDependency is a functional dependency `From -> To`. The degree is the fraction of rows in which the value of
+// `From` determines the value of `To`, 1 means `To` is totally determined by `From`.
+type ColumnDependency struct {
+	From   int64   `json:"from"`
+	To     int64   `json:"to"`
+	Degree float64 `json:"degree"`
+}
+
+// MCVItem is a common combination of the values. The values are encoded by `codec.EncodeKey` one by one, and the
+// strings are encoded by their collation keys, which is the same as the TopN of the columns.
+type MCVItem struct {
+	Encoded []byte  `json:"encoded"`
+	Freq    float64 `json:"freq"`
+}
+
+// DecodeMultiColumnStats decodes the multi-column statistics stored in mysql.stats_extended.
+func DecodeMultiColumnStats(colIDs []int64, data string) (*MultiColumnStats, error) {
+	stats := &MultiColumnStats{}
+	if data != "" {
+		if err := json.Unmarshal([]byte(data), stats); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	stats.ColIDs = colIDs
+	return stats, nil
+}
+
+// NDV returns the NDV of the combination of the sorted columns.
+func (s *MultiColumnStats) NDV(colIDs []int64) (float64, bool) {
+	for _, ndv := range s.NDistincts {
+		if slices.Equal(ndv.ColIDs, colIDs) {
+			return ndv.NDV, true
+		}
+	}
+	return 0, false
+}
+
+// MCVFreq returns the frequency of the combination of the values in the MCV list.
+func (s *MultiColumnStats) MCVFreq(encoded []byte) (float64, bool) {
+	for _, item := range s.MCV {
+		if bytes.Equal(item.Encoded, encoded) {
+			return item.Freq, true
+		}
+	}
+	return 0, false
+}
+
+// MCVTotalFreq returns the sum of the frequencies in the MCV list.
+func (s *MultiColumnStats) MCVTotalFreq() float64 {
+	var total float64
+	for _, item := range s.MCV {
+		total += item.Freq
+	}
+	return total
+}
+
+// remap returns a copy of the statistics whose column IDs are mapped by the given map. It returns nil if some of the
+// columns are not in the map.
+func (s *MultiColumnStats) remap(ids map[int64]int64) *MultiColumnStats {
+	mapIDs := func(colIDs []int64) []int64 {
+		mapped := make([]int64, 0, len(colIDs))
+		for _, id := range colIDs {
+			newID, ok := ids[id]
+			if !ok {
+				return nil
+			}
+			mapped = append(mapped, newID)
+		}
+		return mapped
+	}
+	newStats := &MultiColumnStats{ColIDs: mapIDs(s.ColIDs), MCV: s.MCV}
+	if newStats.ColIDs == nil {
+		return nil
+	}
+	for _, ndv := range s.NDistincts {
+		colIDs := mapIDs(ndv.ColIDs)
+		slices.Sort(colIDs)
+		newStats.NDistincts = append(newStats.NDistincts, &MultiColumnNDV{ColIDs: colIDs, NDV: ndv.NDV})
+	}
+	for _, dep := range s.Dependencies {
+		newStats.Dependencies = append(newStats.Dependencies, &ColumnDependency{From: ids[dep.From], To: ids[dep.To], Degree: dep.Degree})
+	}
+	return newStats
+}
+
+// AddMultiColumnStats adds the multi-column statistics of the table to the HistColl generated for a query, their
+// column IDs are mapped to the UniqueIDs.
+func (coll *HistColl) AddMultiColumnStats(extStats *ExtendedStatsColl) {
+	if coll.Pseudo || extStats == nil || len(extStats.Stats) == 0 {
+		return
+	}
+	ids := make(map[int64]int64, len(coll.UniqueID2colInfoID))
+	for uniqueID, id := range coll.UniqueID2colInfoID {
+		ids[id] = uniqueID
+	}
+	names := make([]string, 0, len(extStats.Stats))
+	for name, item := range extStats.Stats {
+		if item.MultiColumn != nil {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if stats := extStats.Stats[name].MultiColumn.remap(ids); stats != nil {
+			coll.MultiColumnStats = append(coll.MultiColumnStats, stats)
+		}
+	}
+}
+
+// buildMultiColumnStats builds the multi-column statistics from the samples of the columns, which must be from the
+// same sampled rows, so the samples of a row can be found by their ordinals. The rows with NULL values on the
+// columns are not counted in the NDVs, the dependencies or the MCV list, since they never match the equal conditions.
+func buildMultiColumnStats(sc *stmtctx.StatementContext, item *ExtendedStatsItem, cols []*model.ColumnInfo,
+	collectors []*SampleCollector, sampleNum int) *ExtendedStatsItem {
+	if sampleNum <= 0 || len(item.ColIDs) < 2 || len(item.ColIDs) > MaxMultiColumnStatsColumns {
+		return nil
+	}
+	// values[i][j] is the encoded value of the ith column in the jth sampled row.
+	values := make([][][]byte, 0, len(item.ColIDs))
+	var rowCount int64
+	for _, id := range item.ColIDs {
+		offset := slices.IndexFunc(cols, func(col *model.ColumnInfo) bool { return col.ID == id })
+		if offset < 0 || collectors[offset] == nil {
+			return nil
+		}
+		collector := collectors[offset]
+		rowCount = collector.Count + collector.NullCount
+		colValues := make([][]byte, sampleNum)
+		for _, sample := range collector.Samples {
+			if sample.Ordinal >= sampleNum || sample.Value.IsNull() {
+				continue
+			}
+			encoded, err := codec.EncodeKey(sc.TimeZone(), nil, sample.Value)
+			if err != nil {
+				logutil.BgLogger().Warn("encode the sample failed, skip building the multi-column statistics", zap.Error(err))
+				return nil
+			}
+			colValues[sample.Ordinal] = encoded
+		}
+		values = append(values, colValues)
+	}
+	rows := make([][][]byte, 0, sampleNum)
+	for j := 0; j < sampleNum; j++ {
+		row := make([][]byte, 0, len(values))
+		for _, colValues := range values {
+			if colValues[j] == nil {
+				break
+			}
+			row = append(row, colValues[j])
+		}
+		if len(row) == len(values) {
+			rows = append(rows, row)
+		}
+	}
+
+	stats := &MultiColumnStats{ColIDs: item.ColIDs}
+	if len(rows) > 0 {
+		kinds := item.Tp
+		if kinds&ast.StatsKindNDistinct != 0 {
+			// The NDVs are estimated on the rows without NULL values.
+			stats.NDistincts = buildMultiColumnNDVs(item.ColIDs, rows, float64(rowCount)*float64(len(rows))/float64(sampleNum))
+		}
+		if kinds&ast.StatsKindDependencies != 0 {
+			stats.Dependencies = buildColumnDependencies(item.ColIDs, rows)
+		}
+		if kinds&ast.StatsKindMCV != 0 {
+			stats.MCV = buildMCV(rows, sampleNum)
+		}
+	}
+	data, err := encodeMultiColumnStats(stats)
+	if err != nil {
+		logutil.BgLogger().Warn("encode the multi-column statistics failed, skip building them", zap.Error(err))
+		return nil
+	}
+	item.StringVals = string(data)
+	item.MultiColumn = stats
+	return item
+}
+
+// encodeMultiColumnStats encodes the statistics to be stored in mysql.stats_extended. The values in the MCV list can be
+// long, so the least common items are dropped until the encoded statistics fit in maxMultiColumnStatsSize.
+func encodeMultiColumnStats(stats *MultiColumnStats) ([]byte, error) {
+	for {
+		data, err := json.Marshal(stats)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(data) <= maxMultiColumnStatsSize {
+			return data, nil
+		}
+		if len(stats.MCV) == 0 {
+			return nil, errors.Errorf("the multi-column statistics are too large, size: %d", len(data))
+		}
+		stats.MCV = stats.MCV[:len(stats.MCV)/2]
+	}
+}
+
+// rowKey returns the key of the values of the columns in the mask.
+func rowKey(buf []byte, row [][]byte, mask int) []byte {
+	buf = buf[:0]
+	for i, val := range row {
+		if mask&(1<<i) != 0 {
+			buf = append(buf, val...)
+		}
+	}
+	return buf
+}
+
+func buildMultiColumnNDVs(colIDs []int64, rows [][][]byte, rowCount float64) []*MultiColumnNDV {
+	var ndvs []*MultiColumnNDV
+	var buf []byte
+	for mask := 1; mask < 1<<len(colIDs); mask++ {
+		if bits.OnesCount(uint(mask)) < 2 {
+			continue
+		}
+		counts := make(map[string]int)
+		for _, row := range rows {
+			buf = rowKey(buf, row, mask)
+			counts[string(buf)]++
+		}
+		var singles int
+		for _, count := range counts {
+			if count == 1 {
+				singles++
+			}
+		}
+		ndv := &MultiColumnNDV{NDV: estimateNDVBySample(len(rows), len(counts), singles, rowCount)}
+		for i, id := range colIDs {
+			if mask&(1<<i) != 0 {
+				ndv.ColIDs = append(ndv.ColIDs, id)
+			}
+		}
+		slices.Sort(ndv.ColIDs)
+		ndvs = append(ndvs, ndv)
+	}
+	return ndvs
+}
+
+// estimateNDVBySample estimates the NDV of the rows by the samples with the Duj1 estimator of Haas and Stokes:
+// n*d / (n - f1 + f1*n/N), where n is the number of samples, d is the NDV of the samples, f1 is the number of values
+// which appear once in the samples and N is the number of rows.
+func estimateNDVBySample(sampleNum, sampleNDV, singles int, rowCount float64) float64 {
+	n, d, f1 := float64(sampleNum), float64(sampleNDV), float64(singles)
+	if rowCount <= n || f1 == 0 {
+		return d
+	}
+	ndv := n * d / (n - f1 + f1*n/rowCount)
+	return min(max(ndv, d), rowCount)
+}
+
+// buildColumnDependencies builds the dependencies between each pair of the columns. A group of the rows with the same
+// value of `From` supports the dependency if the rows have the same value of `To`, and the degree is the fraction of
+// the rows in the supporting groups.
+func buildColumnDependencies(colIDs []int64, rows [][][]byte) []*ColumnDependency {
+	type group struct {
+		to         []byte
+		count      int
+		consistent bool
+	}
+	var deps []*ColumnDependency
+	for from := range colIDs {
+		for to := range colIDs {
+			if from == to {
+				continue
+			}
+			groups := make(map[string]*group)
+			for _, row := range rows {
+				g, ok := groups[string(row[from])]
+				if !ok {
+					groups[string(row[from])] = &group{to: row[to], count: 1, consistent: true}
+					continue
+				}
+				g.count++
+				g.consistent = g.consistent && bytes.Equal(g.to, row[to])
+			}
+			var supported int
+			for _, g := range groups {
+				if g.consistent {
+					supported += g.count
+				}
+			}
+			if supported > 0 {
+				deps = append(deps, &ColumnDependency{
+					From:   colIDs[from],
+					To:     colIDs[to],
+					Degree: float64(supported) / float64(len(rows)),
+				})
+			}
+		}
+	}
+	return deps
+}
+
+// buildMCV builds the MCV list by the combinations which appear more than once in the samples, the frequencies are
+// the fractions of all the sampled rows.
+func buildMCV(rows [][][]byte, sampleNum int) []*MCVItem {
+	counts := make(map[string]int)
+	var buf []byte
+	for _, row := range rows {
+		buf = rowKey(buf, row, 1<<len(row)-1)
+		counts[string(buf)]++
+	}
+	items := make([]*MCVItem, 0, min(len(counts), maxMCVItems))
+	for key, count := range counts {
+		if count > 1 {
+			items = append(items, &MCVItem{Encoded: []byte(key), Freq: float64(count)})
+		}
+	}
+	slices.SortFunc(items, func(a, b *MCVItem) int {
+		if r := cmp.Compare(b.Freq, a.Freq); r != 0 {
+			return r
+		}
+		return bytes.Compare(a.Encoded, b.Encoded)
+	})
+	if len(items) > maxMCVItems {
+		items = items[:maxMCVItems]
+	}
+	for _, item := range items {
+		item.Freq /= float64(sampleNum)
+	}
+	return items
+}
@@ -0,0 +1,102 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistics
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildMultiColumnStats(t *testing.T) {
+	// b is determined by a, while a is not determined by b in the rows whose b is "y".
+	rows := [][][]byte{
+		{[]byte("1"), []byte("x")},
+		{[]byte("1"), []byte("x")},
+		{[]byte("2"), []byte("y")},
+		{[]byte("2"), []byte("y")},
+		{[]byte("3"), []byte("y")},
+		{[]byte("4"), []byte("z")},
+	}
+	colIDs := []int64{2, 1}
+	ndvs := buildMultiColumnNDVs(colIDs, rows, 6)
+	require.Equal(t, []*MultiColumnNDV{{ColIDs: []int64{1, 2}, NDV: 4}}, ndvs)
+
+	deps := buildColumnDependencies(colIDs, rows)
+	require.Equal(t, []*ColumnDependency{
+		{From: 2, To: 1, Degree: 1},
+		{From: 1, To: 2, Degree: 0.5},
+	}, deps)
+
+	mcv := buildMCV(rows, 8)
+	require.Equal(t, []*MCVItem{
+		{Encoded: []byte("1x"), Freq: 0.25},
+		{Encoded: []byte("2y"), Freq: 0.25},
+	}, mcv)
+
+	stats := &MultiColumnStats{ColIDs: colIDs, NDistincts: ndvs, MCV: mcv}
+	ndv, ok := stats.NDV([]int64{1, 2})
+	require.True(t, ok)
+	require.Equal(t, float64(4), ndv)
+	freq, ok := stats.MCVFreq([]byte("2y"))
+	require.True(t, ok)
+	require.Equal(t, 0.25, freq)
+	_, ok = stats.MCVFreq([]byte("3y"))
+	require.False(t, ok)
+	require.Equal(t, 0.5, stats.MCVTotalFreq())
+
+	// The IDs in the table info are mapped to the UniqueIDs.
+	remapped := (&MultiColumnStats{ColIDs: colIDs, NDistincts: ndvs, Dependencies: deps}).remap(map[int64]int64{1: 20, 2: 10})
+	require.Equal(t, []int64{10, 20}, remapped.ColIDs)
+	require.Equal(t, []int64{10, 20}, remapped.NDistincts[0].ColIDs)
+	require.Equal(t, &ColumnDependency{From: 10, To: 20, Degree: 1}, remapped.Dependencies[0])
+	require.Nil(t, stats.remap(map[int64]int64{1: 20}))
+}
+
+func TestEstimateNDVBySample(t *testing.T) {
+	// All the rows are sampled.
+	require.Equal(t, float64(5), estimateNDVBySample(10, 5, 2, 10))
+	// No value appears once, so the values are all seen.
+	require.Equal(t, float64(5), estimateNDVBySample(10, 5, 0, 1000))
+	// All the values appear once, the NDV is close to the row count.
+	require.Equal(t, float64(1000), estimateNDVBySample(10, 10, 10, 1000))
+	// 10*5 / (10 - 2 + 2*10/1000)
+	require.InDelta(t, 50/8.02, estimateNDVBySample(10, 5, 2, 1000), 1e-9)
+}
+
+func TestEncodeMultiColumnStats(t *testing.T) {
+	// The MCV list is truncated until the statistics fit in the BLOB column.
+	stats := &MultiColumnStats{ColIDs: []int64{1, 2}}
+	for i := 0; i < maxMCVItems; i++ {
+		stats.MCV = append(stats.MCV, &MCVItem{Encoded: bytes.Repeat([]byte{byte(i)}, 1024), Freq: 0.01})
+	}
+	data, err := encodeMultiColumnStats(stats)
+	require.NoError(t, err)
+	require.LessOrEqual(t, len(data), maxMultiColumnStatsSize)
+	require.Len(t, stats.MCV, 25)
+	require.Equal(t, byte(24), stats.MCV[24].Encoded[0])
+	decoded, err := DecodeMultiColumnStats(stats.ColIDs, string(data))
+	require.NoError(t, err)
+	require.Equal(t, stats, decoded)
+
+	// The statistics are skipped if they are still too large without the MCV list.
+	stats = &MultiColumnStats{ColIDs: []int64{1, 2}}
+	for i := 0; i < 5000; i++ {
+		stats.NDistincts = append(stats.NDistincts, &MultiColumnNDV{ColIDs: []int64{1, 2}, NDV: float64(i)})
+	}
+	_, err = encodeMultiColumnStats(stats)
+	require.Error(t, err)
+}
@@ -185,10 +185,12 @@ func ColAndIdxExistenceMapIsEqual(m1, m2 *ColAndIdxExistenceMap) bool {
 
 // ExtendedStatsItem is the cached item of a mysql.stats_extended record.
 type ExtendedStatsItem struct {
-	StringVals string
-	ColIDs     []int64
-	ScalarVals float64
-	Tp         uint8
+	// MultiColumn is decoded from StringVals if the item is the multi-column statistics.
+	MultiColumn *MultiColumnStats
+	StringVals  string
+	ColIDs      []int64
+	ScalarVals  float64
+	Tp          uint8
 }
 
 // ExtendedStatsColl is a collection of cached items for mysql.stats_extended records.
@@ -246,6 +248,9 @@ type HistColl struct {
 	// For normal index, the column id is enough, as we already have in Idx2ColUniqueIDs. But currently, mv index needs more
 	// information to match the filter against the mv index columns, and we need this map to provide this information.
 	MVIdx2Columns map[int64][]*expression.Column
+	// MultiColumnStats are the multi-column statistics of the columns, whose column IDs are the UniqueIDs.
+	// It's used to calculate the selectivity and the NDV of the column groups in planner.
+	MultiColumnStats []*MultiColumnStats
 }
 
 // TableMemoryUsage records tbl memory usage
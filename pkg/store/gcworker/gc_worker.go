@@ -529,9 +529,42 @@ func (w *GCWorker) calcGlobalMinStartTS(ctx context.Context) (uint64, error) {
 			globalMinStartTS = minStartTS
 		}
 	}
+
+	// The prepared XA transaction branches are not bound to any session, their locks must not be
+	// resolved by the GC before they are committed or rolled back.
+	xaMinStartTS, err := w.loadXAPreparedMinStartTS(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if xaMinStartTS < globalMinStartTS {
+		globalMinStartTS = xaMinStartTS
+	}
 	return globalMinStartTS, nil
 }
 
+// loadXAPreparedMinStartTS returns the min start ts of the prepared XA transaction branches,
+// or math.MaxUint64 if there is none.
+func (w *GCWorker) loadXAPreparedMinStartTS(ctx context.Context) (uint64, error) {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnGC)
+	se := createSession(w.store)
+	defer se.Close()
+	rs, err := se.ExecuteInternal(ctx, "SELECT MIN(start_ts) FROM mysql.tidb_xa_prepared")
+	if rs != nil {
+		defer terror.Call(rs.Close)
+	}
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	req := rs.NewChunk(nil)
+	if err := rs.Next(ctx, req); err != nil {
+		return 0, errors.Trace(err)
+	}
+	if req.NumRows() == 0 || req.GetRow(0).IsNull(0) {
+		return math.MaxUint64, nil
+	}
+	return req.GetRow(0).GetUint64(0), nil
+}
+
 // calcNewSafePoint uses the current global transaction min start timestamp to calculate the new safe point.
 func (w *GCWorker) calcSafePointByMinStartTS(ctx context.Context, safePoint uint64) uint64 {
 	globalMinStartTS, err := w.calcGlobalMinStartTS(ctx)
@@ -0,0 +1,262 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cophandler
+
+import (
+	"cmp"
+	"hash/fnv"
+	"slices"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/hnsw"
+	"github.com/pingcap/tipb/go-tipb"
+)
+
+// annTopNExec executes a TopN ordered by the distance between a vector column and a constant
+// vector. Instead of sorting all the rows, it searches the in-memory HNSW index built from the
+// rows of the region, and returns the approximate nearest neighbours of the reference vector.
+// TiDB sorts the rows of all the regions by the exact distance again, so the results only need
+// to contain the nearest rows of each region.
+type annTopNExec struct {
+	baseMPPExec
+
+	ann       *tipb.ANNQueryInfo
+	key       annIndexKey
+	regionVer uint64
+	colIdx    int
+	refVec    types.VectorFloat32
+
+	recv []*chunk.Chunk
+	rows []chunk.Row
+	idx  int
+}
+
+func (b *mppExecBuilder) buildANNTopN(pb *tipb.TopN, ts *tipb.TableScan) (mppExec, error) {
+	ann := ts.AnnQuery
+	colIdx := -1
+	for i, col := range ts.Columns {
+		if col.ColumnId == ann.ColumnId {
+			colIdx = i
+			break
+		}
+	}
+	if colIdx < 0 {
+		return nil, errors.Errorf("column %s of the ANN query is not found", ann.ColumnName)
+	}
+	if distanceFuncForANN(ann.DistanceMetric) == nil {
+		return nil, errors.Errorf("unsupported distance metric %s of the ANN query", ann.DistanceMetric)
+	}
+	refVec, _, err := types.ZeroCopyDeserializeVectorFloat32(ann.RefVecF32)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	child, err := b.buildMPPExecutor(pb.Child)
+	if err != nil {
+		return nil, err
+	}
+	return &annTopNExec{
+		baseMPPExec: baseMPPExec{sctx: b.sctx, mppCtx: b.mppCtx, fieldTypes: child.getFieldTypes(), children: []mppExec{child}},
+		ann:         ann,
+		key: annIndexKey{
+			regionID: b.dagCtx.regionID,
+			tableID:  ts.TableId,
+			columnID: ann.ColumnId,
+			metric:   ann.DistanceMetric,
+		},
+		regionVer: b.dagCtx.regionVer,
+		colIdx:    colIdx,
+		refVec:    refVec,
+	}, nil
+}
+
+func distanceFuncForANN(metric tipb.ANNQueryDistanceMetric) hnsw.DistanceFunc {
+	switch metric {
+	case tipb.ANNQueryDistanceMetric_L1:
+		return hnsw.L1Distance
+	case tipb.ANNQueryDistanceMetric_L2:
+		return hnsw.L2SquaredDistance
+	case tipb.ANNQueryDistanceMetric_Cosine:
+		return hnsw.CosineDistance
+	case tipb.ANNQueryDistanceMetric_InnerProduct:
+		return hnsw.NegativeInnerProduct
+	}
+	return nil
+}
+
+func (e *annTopNExec) open() error {
+	if err := e.children[0].open(); err != nil {
+		return err
+	}
+
+	topK := int(e.ann.TopK)
+	// The distance is NULL if the vector is NULL, or the cosine distance involves a zero vector.
+	// These rows are ordered before the others, so at most k of them are returned besides the
+	// nearest neighbours.
+	allNull := e.ann.DistanceMetric == tipb.ANNQueryDistanceMetric_Cosine && e.refVec.L2Norm() == 0
+	var nullRows, indexedRows []chunk.Row
+	var vecs [][]float32
+	checksum := fnv.New64a()
+	for {
+		chk, err := e.children[0].next()
+		if err != nil {
+			return err
+		}
+		if chk == nil || chk.NumRows() == 0 {
+			break
+		}
+		e.execSummary.updateOnlyRows(chk.NumRows())
+		for i := 0; i < chk.NumRows(); i++ {
+			row := chk.GetRow(i)
+			isNull := allNull || row.IsNull(e.colIdx)
+			var vec types.VectorFloat32
+			if !isNull {
+				vec = row.GetVectorFloat32(e.colIdx)
+				if vec.Len() != e.refVec.Len() {
+					return types.ErrVectorDimensionMismatch.GenWithStackByArgs(vec.Len(), e.refVec.Len())
+				}
+				isNull = e.ann.DistanceMetric == tipb.ANNQueryDistanceMetric_Cosine && vec.L2Norm() == 0
+			}
+			if isNull {
+				if len(nullRows) < topK {
+					nullRows = append(nullRows, row)
+				}
+				continue
+			}
+			// The chunks are kept until the exec is closed, so the vector can be referenced here.
+			vecs = append(vecs, vec.Elements())
+			_, _ = checksum.Write(vec.ZeroCopySerialize())
+			indexedRows = append(indexedRows, row)
+		}
+		e.recv = append(e.recv, chk)
+	}
+
+	e.rows = nullRows
+	k := topK - len(nullRows)
+	if k <= 0 || len(vecs) == 0 {
+		return nil
+	}
+	var ids []int
+	if index := annIndexes.get(e.key, e.regionVer, checksum.Sum64()); index != nil {
+		ids = index.search(e.refVec.Elements(), k, max(int(e.ann.HnswEfSearch), hnsw.DefaultEfSearch))
+	} else {
+		// The index is built in the background for the next queries, the rows are sorted this time.
+		ids = exactTopN(distanceFuncForANN(e.ann.DistanceMetric), vecs, e.refVec.Elements(), k)
+		for i, vec := range vecs {
+			vecs[i] = slices.Clone(vec)
+		}
+		go annIndexes.build(e.key, e.regionVer, checksum.Sum64(), vecs)
+	}
+	for _, id := range ids {
+		e.rows = append(e.rows, indexedRows[id])
+	}
+	return nil
+}
+
+func (e *annTopNExec) next() (*chunk.Chunk, error) {
+	chk := chunk.NewChunkWithCapacity(e.getFieldTypes(), DefaultBatchSize)
+	for ; !chk.IsFull() && e.idx < len(e.rows); e.idx++ {
+		chk.AppendRow(e.rows[e.idx])
+	}
+	return chk, nil
+}
+
+// exactTopN returns the positions of the k vectors nearest to the query, ordered by the distance.
+func exactTopN(distance hnsw.DistanceFunc, vecs [][]float32, query []float32, k int) []int {
+	distances := make([]float32, len(vecs))
+	ids := make([]int, len(vecs))
+	for i, vec := range vecs {
+		distances[i] = distance(query, vec)
+		ids[i] = i
+	}
+	slices.SortStableFunc(ids, func(a, b int) int {
+		return cmp.Compare(distances[a], distances[b])
+	})
+	return ids[:min(k, len(ids))]
+}
+
+// annIndexCacheCapacity is the max number of the HNSW indexes cached.
+const annIndexCacheCapacity = 64
+
+// annIndexes caches the HNSW indexes built from the vectors of the regions, so they aren't built by every query.
+var annIndexes = &annIndexCache{indexes: make(map[annIndexKey]*annIndex)}
+
+// annIndexKey identifies the index of a vector column in a region, the region ID is zero if the index
+// is built from several regions, e.g. by an MPP task.
+type annIndexKey struct {
+	regionID uint64
+	tableID  int64
+	columnID int64
+	metric   tipb.ANNQueryDistanceMetric
+}
+
+// annIndex is the HNSW index built from the vectors of a region. It's only used if the region version
+// and the checksum of the vectors read by the query are the same as the ones the index is built from,
+// so it's invalidated by the changes of the region or its data.
+type annIndex struct {
+	regionVer uint64
+	checksum  uint64
+
+	// mu protects the index, whose search isn't thread-safe.
+	mu    sync.Mutex
+	index *hnsw.Index
+}
+
+func (idx *annIndex) search(query []float32, k, ef int) []int {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	results := idx.index.Search(query, k, ef)
+	ids := make([]int, 0, len(results))
+	for _, r := range results {
+		ids = append(ids, r.ID)
+	}
+	return ids
+}
+
+type annIndexCache struct {
+	mu      sync.Mutex
+	indexes map[annIndexKey]*annIndex
+}
+
+// get returns the cached index if it's built from the same vectors, or nil.
+func (c *annIndexCache) get(key annIndexKey, regionVer, checksum uint64) *annIndex {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	idx, ok := c.indexes[key]
+	if !ok || idx.regionVer != regionVer || idx.checksum != checksum {
+		return nil
+	}
+	return idx
+}
+
+// build builds the index from the vectors and caches it in place of the stale one.
+func (c *annIndexCache) build(key annIndexKey, regionVer, checksum uint64, vecs [][]float32) {
+	index := hnsw.New(distanceFuncForANN(key.metric), hnsw.DefaultM, hnsw.DefaultEfConstruction)
+	for _, vec := range vecs {
+		index.Add(vec)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.indexes[key]; !ok && len(c.indexes) >= annIndexCacheCapacity {
+		// The regions whose indexes are cached may have been merged or moved, so any index can be evicted.
+		for k := range c.indexes {
+			delete(c.indexes, k)
+			break
+		}
+	}
+	c.indexes[key] = &annIndex{regionVer: regionVer, checksum: checksum, index: index}
+}
@@ -0,0 +1,57 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cophandler
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/util/hnsw"
+	"github.com/pingcap/tipb/go-tipb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExactTopN(t *testing.T) {
+	vecs := [][]float32{{0, 0}, {3, 3}, {1, 1}, {-1, -1}, {2, 2}}
+	require.Equal(t, []int{0, 2, 3}, exactTopN(hnsw.L2SquaredDistance, vecs, []float32{0.5, 0.5}, 3))
+	require.Equal(t, []int{2, 4}, exactTopN(hnsw.L1Distance, vecs, []float32{1.4, 1.4}, 2))
+	require.Equal(t, []int{1, 4, 2, 0, 3}, exactTopN(hnsw.NegativeInnerProduct, vecs, []float32{1, 1}, 10))
+}
+
+func TestANNIndexCache(t *testing.T) {
+	cache := &annIndexCache{indexes: make(map[annIndexKey]*annIndex)}
+	key := annIndexKey{regionID: 1, tableID: 100, columnID: 2, metric: tipb.ANNQueryDistanceMetric_L2}
+	vecs := [][]float32{{0, 0}, {1, 1}, {2, 2}, {3, 3}}
+	require.Nil(t, cache.get(key, 1, 10))
+
+	cache.build(key, 1, 10, vecs)
+	idx := cache.get(key, 1, 10)
+	require.NotNil(t, idx)
+	require.Equal(t, []int{2, 1}, idx.search([]float32{1.9, 1.9}, 2, hnsw.DefaultEfSearch))
+	// The index is invalidated by the changes of the region or its data.
+	require.Nil(t, cache.get(key, 2, 10))
+	require.Nil(t, cache.get(key, 1, 11))
+	other := key
+	other.metric = tipb.ANNQueryDistanceMetric_Cosine
+	require.Nil(t, cache.get(other, 1, 10))
+	cache.build(key, 2, 11, vecs)
+	require.Nil(t, cache.get(key, 1, 10))
+	require.NotNil(t, cache.get(key, 2, 11))
+
+	for i := 0; i < annIndexCacheCapacity; i++ {
+		other.regionID = uint64(i + 2)
+		cache.build(other, 1, 10, vecs)
+	}
+	require.Len(t, cache.indexes, annIndexCacheCapacity)
+}
@@ -89,6 +89,10 @@ type dagContext struct {
 	dagReq        *tipb.DAGRequest
 	keyRanges     []*coprocessor.KeyRange
 	startTS       uint64
+	// regionID and regionVer are the ID and the version of the region read by the request, they are
+	// zero if the request reads several regions.
+	regionID  uint64
+	regionVer uint64
 }
 
 // ExecutorListsToTree converts a list of executors to a tree.
@@ -328,6 +332,8 @@ func buildDAG(reader *dbreader.DBReader, lockStore *lockstore.MemStore, req *cop
 		keyRanges:     req.Ranges,
 		startTS:       req.StartTs,
 		resolvedLocks: req.Context.ResolvedLocks,
+		regionID:      req.Context.GetRegionId(),
+		regionVer:     req.Context.GetRegionEpoch().GetVersion(),
 	}
 	return ctx, dagReq, err
 }
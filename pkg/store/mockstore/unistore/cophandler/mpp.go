@@ -261,6 +261,9 @@ func (b *mppExecBuilder) buildExpand(pb *tipb.Expand) (mppExec, error) {
 }
 
 func (b *mppExecBuilder) buildTopN(pb *tipb.TopN) (mppExec, error) {
+	if ts := pb.Child.GetTblScan(); ts != nil && ts.AnnQuery != nil && (b.paging == nil || b.pagingSize >= pb.Limit) {
+		return b.buildANNTopN(pb, ts)
+	}
 	child, err := b.buildMPPExecutor(pb.Child)
 	if err != nil {
 		return nil, err
@@ -582,6 +585,8 @@ func HandleMPPDAGReq(dbReader *dbreader.DBReader, req *coprocessor.Request, mppC
 		dbReader:  dbReader,
 		startTS:   req.StartTs,
 		keyRanges: req.Ranges,
+		regionID:  req.Context.GetRegionId(),
+		regionVer: req.Context.GetRegionEpoch().GetVersion(),
 	}
 	tz, err := timeutil.ConstructTimeZone(dagReq.TimeZoneName, int(dagReq.TimeZoneOffset))
 	builder := mppExecBuilder{
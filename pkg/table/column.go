@@ -19,6 +19,7 @@
 package table
 
 import (
+	"encoding/binary"
 	"fmt"
 	"strconv"
 	"strings"
@@ -375,6 +376,12 @@ func castColumnValue(tc types.Context, ec errctx.Context, sqlMode mysql.SQLMode,
 	if col.GetType() == mysql.TypeString && !types.IsBinaryStr(&col.FieldType) {
 		truncateTrailingSpaces(&casted)
 	}
+	if col.GetType() == mysql.TypeGeometry && col.SRID != nil && !casted.IsNull() {
+		// The geometry has been validated by ConvertTo, only its SRID is checked here.
+		if srid := binary.LittleEndian.Uint32(casted.GetBytes()); srid != *col.SRID {
+			return casted, ErrWrongSRIDForColumn.GenWithStackByArgs(col.Name.O, srid, *col.SRID)
+		}
+	}
 	return casted, err
 }
 
@@ -732,6 +739,15 @@ func GetZeroValue(col *model.ColumnInfo) types.Datum {
 		d.SetMysqlEnum(types.Enum{}, col.GetCollate())
 	case mysql.TypeJSON:
 		d.SetMysqlJSON(types.CreateBinaryJSON(nil))
+	case mysql.TypeTiDBVectorFloat32:
+		d.SetVectorFloat32(types.ZeroVectorFloat32)
+	case mysql.TypeGeometry:
+		// The zero value is an empty geometry collection.
+		g := &types.Geometry{Type: mysql.GeometryTypeGeometryCollection}
+		if col.SRID != nil {
+			g.SRID = *col.SRID
+		}
+		d.SetBytes(g.Encode())
 	}
 	return d
 }
@@ -16,6 +16,7 @@ package tables
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"time"
 
@@ -28,6 +29,7 @@ import (
 	"github.com/pingcap/tidb/pkg/tablecodec"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/fulltext"
 	"github.com/pingcap/tidb/pkg/util/rowcodec"
 	"github.com/pingcap/tidb/pkg/util/tracing"
 )
@@ -103,7 +105,12 @@ func (c *index) GenIndexValue(ec errctx.Context, loc *time.Location, distinct bo
 // 2. (i1, [m1,m2], i2, ...) ==> [(i1, m1, i2, ...), (i1, m2, i2, ...)]
 // 3. (i1, null, i2, ...) ==> [(i1, null, i2, ...)]
 // 4. (i1, [], i2, ...) ==> nothing.
+// 5. If FULLTEXT index, (t1, t2, ...) ==> [(token1, null, ...), (token2, null, ...)], the tokens are
+// the distinct tokens of all the texts, so the entries of a token are its posting list.
 func (c *index) getIndexedValue(indexedValues []types.Datum) [][]types.Datum {
+	if c.idxInfo.Tp == model.IndexTypeFulltext {
+		return c.getFulltextIndexedValue(indexedValues)
+	}
 	if !c.idxInfo.MVIndex {
 		return [][]types.Datum{indexedValues}
 	}
@@ -150,6 +157,27 @@ out:
 	return vals
 }
 
+func (c *index) getFulltextIndexedValue(indexedValues []types.Datum) [][]types.Datum {
+	var text strings.Builder
+	for _, v := range indexedValues {
+		if v.IsNull() {
+			continue
+		}
+		// Separate the texts so that the last word of a column isn't joined with the first word of the next one.
+		text.WriteString(v.GetString())
+		text.WriteByte(' ')
+	}
+	tokens := fulltext.DistinctTokens(c.idxInfo.ParserName.L, text.String())
+	collation := c.tblInfo.Columns[c.idxInfo.Columns[0].Offset].GetCollate()
+	vals := make([][]types.Datum, 0, len(tokens))
+	for _, token := range tokens {
+		val := make([]types.Datum, len(indexedValues))
+		val[0] = types.NewCollationStringDatum(token, collation)
+		vals = append(vals, val)
+	}
+	return vals
+}
+
 // Create creates a new entry in the kvIndex data.
 // If the index is unique and there is an existing entry with the same key,
 // Create will return the existing entry's handle as the first return value, ErrKeyExists as the second return value.
@@ -470,7 +498,7 @@ func (c *index) Delete(ctx table.MutateContext, txn kv.Transaction, indexedValue
 func (c *index) GenIndexKVIter(ec errctx.Context, loc *time.Location, indexedValue []types.Datum,
 	h kv.Handle, handleRestoreData []types.Datum) table.IndexKVGenerator {
 	var mvIndexValues [][]types.Datum
-	if c.Meta().MVIndex {
+	if c.Meta().MVIndex || c.Meta().Tp == model.IndexTypeFulltext {
 		mvIndexValues = c.getIndexedValue(indexedValue)
 		return table.NewMultiValueIndexKVGenerator(c, ec, loc, h, handleRestoreData, mvIndexValues)
 	}
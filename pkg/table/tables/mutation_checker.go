@@ -222,6 +222,10 @@ func checkIndexKeys(
 		if !ok {
 			return errors.New("index not found")
 		}
+		if indexInfo.Tp == model.IndexTypeFulltext {
+			// The keys of a FULLTEXT index are the tokens of the texts instead of the column values.
+			continue
+		}
 
 		var isTmpIdxValAndDeleted bool
 		// If this is temp index data, need remove last byte of index data.
@@ -379,6 +379,8 @@ func (tk *TestKit) ExecWithContext(ctx context.Context, sql string, args ...any)
 			var err error
 			if s, ok := stmt.(*ast.NonTransactionalDMLStmt); ok {
 				rs, err = session.HandleNonTransactionalDML(ctx, s, tk.Session())
+			} else if s, ok := stmt.(*ast.CallStmt); ok {
+				rs, err = session.HandleCallProcedure(ctx, s, tk.Session())
 			} else {
 				rs, err = tk.Session().ExecuteStmt(ctx, stmt)
 			}
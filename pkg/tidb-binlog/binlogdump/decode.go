@@ -0,0 +1,158 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogdump
+
+import (
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/tidb-binlog/binlogstore"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/codec"
+	"github.com/pingcap/tipb/go-binlog"
+)
+
+// RowChange is a row inserted, updated or deleted by a transaction. The rows map the column ids to
+// the values, the columns not in a row are NULL.
+type RowChange struct {
+	Tp binlog.MutationType
+	// Before is the row before the change, it's nil for an inserted row.
+	Before map[int64]types.Datum
+	// After is the row after the change, it's nil for a deleted row.
+	After map[int64]types.Datum
+}
+
+// TableChanges is the row changes of a table in a transaction.
+type TableChanges struct {
+	Schema string
+	Table  *model.TableInfo
+	Rows   []RowChange
+}
+
+// DecodeTxn decodes the row changes of a DML transaction in the order they are made. The tables
+// which are not found in the information schema are skipped.
+func DecodeTxn(is infoschema.InfoSchema, txn *binlogstore.Txn) ([]TableChanges, error) {
+	if txn.Prewrite == nil {
+		return nil, nil
+	}
+	changes := make([]TableChanges, 0, len(txn.Prewrite.Mutations))
+	for i := range txn.Prewrite.Mutations {
+		mutation := &txn.Prewrite.Mutations[i]
+		tbl, err := findTable(is, mutation.TableId)
+		if err != nil {
+			return nil, err
+		}
+		if tbl == nil || len(mutation.Sequence) == 0 {
+			continue
+		}
+		rows, err := decodeRows(tbl, mutation)
+		if err != nil {
+			return nil, errors.Annotatef(err, "decode rows of table %s.%s", tbl.schema, tbl.info.Name.O)
+		}
+		changes = append(changes, TableChanges{Schema: tbl.schema, Table: tbl.info, Rows: rows})
+	}
+	return changes, nil
+}
+
+func decodeRows(tbl *binlogTable, mutation *binlog.TableMutation) ([]RowChange, error) {
+	rows := make([]RowChange, 0, len(mutation.Sequence))
+	var inserted, updated, deleted int
+	for _, tp := range mutation.Sequence {
+		row := RowChange{Tp: tp}
+		var err error
+		switch tp {
+		case binlog.MutationType_Insert:
+			row.After, err = decodeInsertedRow(tbl, mutation.InsertedRows[inserted])
+			inserted++
+		case binlog.MutationType_Update:
+			row.Before, row.After, err = decodeUpdatedRow(tbl, mutation.UpdatedRows[updated])
+			updated++
+		case binlog.MutationType_DeleteRow:
+			row.Before, err = decodeRow(mutation.DeletedRows[deleted], tbl.fts)
+			deleted++
+		default:
+			return nil, errors.Errorf("unknown mutation type %v", tp)
+		}
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// decodeInsertedRow decodes an inserted row, which is prefixed with the handle.
+func decodeInsertedRow(tbl *binlogTable, data []byte) (map[int64]types.Datum, error) {
+	handleLen := 1
+	var pkCols []*model.ColumnInfo
+	if tbl.info.IsCommonHandle {
+		for _, idxCol := range tbl.info.GetPrimaryKey().Columns {
+			pkCols = append(pkCols, tbl.info.Columns[idxCol.Offset])
+		}
+		handleLen = len(pkCols)
+	}
+	handle := make([]types.Datum, 0, handleLen)
+	for i := 0; i < handleLen; i++ {
+		var d types.Datum
+		var err error
+		data, d, err = codec.DecodeOne(data)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		handle = append(handle, d)
+	}
+	row, err := decodeRow(data, tbl.fts)
+	if err != nil {
+		return nil, err
+	}
+	// The handle columns may be omitted in the row.
+	if tbl.info.PKIsHandle {
+		if pkCol := tbl.info.GetPkColInfo(); pkCol != nil {
+			row[pkCol.ID] = handle[0]
+		}
+	}
+	for i, col := range pkCols {
+		if _, ok := row[col.ID]; !ok {
+			d, err := tablecodec.Unflatten(handle[i], &col.FieldType, time.UTC)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			row[col.ID] = d
+		}
+	}
+	return row, nil
+}
+
+// decodeUpdatedRow decodes an updated row, which is the old row followed by the new row.
+func decodeUpdatedRow(tbl *binlogTable, data []byte) (oldRow, newRow map[int64]types.Datum, err error) {
+	datums, err := codec.Decode(data, 4*len(tbl.cols))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	// The old row and the new row have the same columns.
+	half := len(datums) / 2
+	oldRow, err = unflattenRow(datums[:half], tbl.fts)
+	if err != nil {
+		return nil, nil, err
+	}
+	newRow, err = unflattenRow(datums[half:], tbl.fts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return oldRow, newRow, nil
+}
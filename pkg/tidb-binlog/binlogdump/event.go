@@ -0,0 +1,177 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogdump
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+
+	"github.com/google/uuid"
+)
+
+// The types of the binlog events.
+// See https://dev.mysql.com/doc/dev/mysql-server/latest/namespacemysql_1_1binlog_1_1event.html
+const (
+	queryEvent             byte = 2
+	rotateEvent            byte = 4
+	formatDescriptionEvent byte = 15
+	xidEvent               byte = 16
+	tableMapEvent          byte = 19
+	heartbeatEvent         byte = 27
+	writeRowsEvent         byte = 30
+	updateRowsEvent        byte = 31
+	deleteRowsEvent        byte = 32
+	gtidEvent              byte = 33
+)
+
+const (
+	binlogVersion   = 4
+	eventHeaderSize = 19
+	checksumSize    = 4
+	// checksumAlgOff and checksumAlgCRC32 are the values of the checksum algorithm in the format
+	// description event.
+	checksumAlgOff   = 0
+	checksumAlgCRC32 = 1
+
+	// logEventArtificialFlag marks the events which are not in the binlog file.
+	logEventArtificialFlag = 0x20
+	// stmtEndFlag marks the last rows event of a statement.
+	stmtEndFlag = 0x1
+)
+
+// postHeaderLengths is the post header lengths of the event types in the format description event,
+// it is the same as MySQL 5.7.
+var postHeaderLengths = []byte{
+	56, 13, 0, 8, 0, 18, 0, 4, 4, 4, 4, 18, 0, 0, 95, 0, 4, 26, 8, 0,
+	0, 0, 8, 8, 8, 2, 0, 0, 0, 10, 10, 10, 42, 42, 0, 18, 52, 0,
+}
+
+// eventEncoder encodes the binlog events of a binlog file.
+//
+// The events are always encoded with CRC32 checksum, so the positions of the events don't depend on
+// whether the replica accepts checksum. The checksum is stripped when the events are sent to a replica
+// without checksum.
+type eventEncoder struct {
+	serverID uint32
+	// pos is the position of the next event in the binlog file.
+	pos uint32
+}
+
+// encode returns the event with the header and the checksum, and moves the position to the next event.
+func (e *eventEncoder) encode(tp byte, timestamp uint32, flags uint16, body []byte) []byte {
+	size := eventHeaderSize + len(body) + checksumSize
+	e.pos += uint32(size)
+	return e.encodeAt(tp, timestamp, flags, body, e.pos)
+}
+
+// encodeArtificial returns an event which is not in the binlog file, the position is not moved.
+func (e *eventEncoder) encodeArtificial(tp byte, body []byte) []byte {
+	return e.encodeAt(tp, 0, logEventArtificialFlag, body, 0)
+}
+
+func (e *eventEncoder) encodeAt(tp byte, timestamp uint32, flags uint16, body []byte, nextPos uint32) []byte {
+	size := eventHeaderSize + len(body) + checksumSize
+	buf := make([]byte, 0, size)
+	buf = binary.LittleEndian.AppendUint32(buf, timestamp)
+	buf = append(buf, tp)
+	buf = binary.LittleEndian.AppendUint32(buf, e.serverID)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(size))
+	buf = binary.LittleEndian.AppendUint32(buf, nextPos)
+	buf = binary.LittleEndian.AppendUint16(buf, flags)
+	buf = append(buf, body...)
+	return binary.LittleEndian.AppendUint32(buf, crc32.ChecksumIEEE(buf))
+}
+
+func formatDescriptionBody(serverVersion string, timestamp uint32) []byte {
+	buf := make([]byte, 0, 2+50+4+1+len(postHeaderLengths)+1)
+	buf = binary.LittleEndian.AppendUint16(buf, binlogVersion)
+	version := make([]byte, 50)
+	copy(version, serverVersion)
+	buf = append(buf, version...)
+	buf = binary.LittleEndian.AppendUint32(buf, timestamp)
+	buf = append(buf, eventHeaderSize)
+	buf = append(buf, postHeaderLengths...)
+	return append(buf, checksumAlgCRC32)
+}
+
+func rotateBody(file string, pos uint64) []byte {
+	buf := binary.LittleEndian.AppendUint64(nil, pos)
+	return append(buf, file...)
+}
+
+// gtidBody returns the body of a GTID event, seq is the sequence number of the transaction in the binlog file.
+func gtidBody(sid uuid.UUID, gno uint64, seq int64) []byte {
+	const (
+		commitFlag               = 1
+		logicalTimestampTypeCode = 2
+	)
+	buf := make([]byte, 0, 42)
+	buf = append(buf, commitFlag)
+	buf = append(buf, sid[:]...)
+	buf = binary.LittleEndian.AppendUint64(buf, gno)
+	buf = append(buf, logicalTimestampTypeCode)
+	// Each transaction depends on the previous one, so the replica applies them one by one.
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(seq-1))
+	return binary.LittleEndian.AppendUint64(buf, uint64(seq))
+}
+
+func queryBody(schema, query string) []byte {
+	const (
+		flags2Code  = 0
+		sqlModeCode = 1
+		charsetCode = 4
+		// utf8mb4_bin
+		collationID = 46
+	)
+	statusVars := []byte{flags2Code, 0, 0, 0, 0, sqlModeCode, 0, 0, 0, 0, 0, 0, 0, 0, charsetCode}
+	for i := 0; i < 3; i++ {
+		statusVars = binary.LittleEndian.AppendUint16(statusVars, collationID)
+	}
+	buf := make([]byte, 0, 13+len(statusVars)+len(schema)+1+len(query))
+	// slave_proxy_id and execution time.
+	buf = binary.LittleEndian.AppendUint32(buf, 0)
+	buf = binary.LittleEndian.AppendUint32(buf, 0)
+	buf = append(buf, byte(len(schema)))
+	// error code.
+	buf = binary.LittleEndian.AppendUint16(buf, 0)
+	buf = binary.LittleEndian.AppendUint16(buf, uint16(len(statusVars)))
+	buf = append(buf, statusVars...)
+	buf = append(buf, schema...)
+	buf = append(buf, 0)
+	return append(buf, query...)
+}
+
+func xidBody(xid uint64) []byte {
+	return binary.LittleEndian.AppendUint64(nil, xid)
+}
+
+func appendTableID(buf []byte, tableID int64) []byte {
+	return append(buf, byte(tableID), byte(tableID>>8), byte(tableID>>16), byte(tableID>>24), byte(tableID>>32), byte(tableID>>40))
+}
+
+// appendLengthEncodedInt appends a length encoded integer.
+// See https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_basic_dt_integers.html
+func appendLengthEncodedInt(buf []byte, n uint64) []byte {
+	switch {
+	case n < 251:
+		return append(buf, byte(n))
+	case n < 1<<16:
+		return append(buf, 0xfc, byte(n), byte(n>>8))
+	case n < 1<<24:
+		return append(buf, 0xfd, byte(n), byte(n>>8), byte(n>>16))
+	default:
+		return binary.LittleEndian.AppendUint64(append(buf, 0xfe), n)
+	}
+}
@@ -0,0 +1,341 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogdump
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+)
+
+// The column types in the binlog which are not used by TiDB.
+const (
+	typeTimestamp2 byte = 17
+	typeDatetime2  byte = 18
+	typeTime2      byte = 19
+)
+
+// columnType returns the column type and the metadata of the column in the table map event.
+// See https://dev.mysql.com/doc/dev/mysql-server/latest/classmysql_1_1binlog_1_1event_1_1Table__map__event.html
+func columnType(ft *types.FieldType) (tp byte, meta []byte) {
+	switch ft.GetType() {
+	case mysql.TypeFloat:
+		return mysql.TypeFloat, []byte{4}
+	case mysql.TypeDouble:
+		return mysql.TypeDouble, []byte{8}
+	case mysql.TypeNewDecimal:
+		precision, frac := decimalPrecision(ft)
+		return mysql.TypeNewDecimal, []byte{byte(precision), byte(frac)}
+	case mysql.TypeTimestamp:
+		return typeTimestamp2, []byte{byte(fsp(ft))}
+	case mysql.TypeDatetime:
+		return typeDatetime2, []byte{byte(fsp(ft))}
+	case mysql.TypeDuration:
+		return typeTime2, []byte{byte(fsp(ft))}
+	case mysql.TypeVarchar, mysql.TypeVarString:
+		return mysql.TypeVarchar, binary.LittleEndian.AppendUint16(nil, uint16(maxBytes(ft)))
+	case mysql.TypeString:
+		n := maxBytes(ft)
+		return mysql.TypeString, []byte{mysql.TypeString ^ byte((n&0x300)>>4), byte(n)}
+	case mysql.TypeEnum:
+		return mysql.TypeString, []byte{mysql.TypeEnum, byte(enumPackLength(ft))}
+	case mysql.TypeSet:
+		return mysql.TypeString, []byte{mysql.TypeSet, byte(setPackLength(ft))}
+	case mysql.TypeBit:
+		flen := max(ft.GetFlen(), 1)
+		return mysql.TypeBit, []byte{byte(flen % 8), byte(flen / 8)}
+	case mysql.TypeTinyBlob, mysql.TypeBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeTiDBVectorFloat32:
+		return mysql.TypeBlob, []byte{byte(blobPackLength(ft))}
+	case mysql.TypeJSON, mysql.TypeGeometry:
+		return ft.GetType(), []byte{4}
+	default:
+		return ft.GetType(), nil
+	}
+}
+
+func fsp(ft *types.FieldType) int {
+	return max(ft.GetDecimal(), 0)
+}
+
+func decimalPrecision(ft *types.FieldType) (precision, frac int) {
+	precision, frac = ft.GetFlen(), ft.GetDecimal()
+	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(mysql.TypeNewDecimal)
+	if precision == types.UnspecifiedLength {
+		precision = defaultFlen
+	}
+	if frac == types.UnspecifiedLength {
+		frac = defaultDecimal
+	}
+	return precision, frac
+}
+
+// maxBytes returns the max length in bytes of a string column.
+func maxBytes(ft *types.FieldType) int {
+	flen := max(ft.GetFlen(), 0)
+	if cs, err := charset.GetCharsetInfo(ft.GetCharset()); err == nil {
+		flen *= cs.Maxlen
+	}
+	return min(flen, math.MaxUint16)
+}
+
+func enumPackLength(ft *types.FieldType) int {
+	if len(ft.GetElems()) > math.MaxUint8 {
+		return 2
+	}
+	return 1
+}
+
+func setPackLength(ft *types.FieldType) int {
+	n := (len(ft.GetElems()) + 7) / 8
+	if n > 4 {
+		return 8
+	}
+	return n
+}
+
+func blobPackLength(ft *types.FieldType) int {
+	switch ft.GetType() {
+	case mysql.TypeTinyBlob:
+		return 1
+	case mysql.TypeBlob:
+		return 2
+	case mysql.TypeMediumBlob:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// tableMapBody returns the body of the table map event of the columns.
+func tableMapBody(tableID int64, schema string, tbl *model.TableInfo, cols []*model.ColumnInfo) []byte {
+	buf := appendTableID(nil, tableID)
+	// flags
+	buf = binary.LittleEndian.AppendUint16(buf, 0)
+	buf = append(buf, byte(len(schema)))
+	buf = append(buf, schema...)
+	buf = append(buf, 0)
+	buf = append(buf, byte(len(tbl.Name.O)))
+	buf = append(buf, tbl.Name.O...)
+	buf = append(buf, 0)
+	buf = appendLengthEncodedInt(buf, uint64(len(cols)))
+	var meta []byte
+	nullable := make([]byte, (len(cols)+7)/8)
+	for i, col := range cols {
+		tp, m := columnType(&col.FieldType)
+		buf = append(buf, tp)
+		meta = append(meta, m...)
+		if !mysql.HasNotNullFlag(col.GetFlag()) {
+			nullable[i/8] |= 1 << (i % 8)
+		}
+	}
+	buf = appendLengthEncodedInt(buf, uint64(len(meta)))
+	buf = append(buf, meta...)
+	return append(buf, nullable...)
+}
+
+// appendRow appends the row image of a rows event. All the columns are present in the row image.
+func appendRow(buf []byte, cols []*model.ColumnInfo, row map[int64]types.Datum) ([]byte, error) {
+	nulls := make([]byte, (len(cols)+7)/8)
+	for i, col := range cols {
+		if d, ok := row[col.ID]; !ok || d.IsNull() {
+			nulls[i/8] |= 1 << (i % 8)
+		}
+	}
+	buf = append(buf, nulls...)
+	var err error
+	for i, col := range cols {
+		if nulls[i/8]&(1<<(i%8)) != 0 {
+			continue
+		}
+		d := row[col.ID]
+		buf, err = appendValue(buf, &col.FieldType, &d)
+		if err != nil {
+			return nil, errors.Annotatef(err, "encode column %s", col.Name.O)
+		}
+	}
+	return buf, nil
+}
+
+// appendValue appends the binary representation of the value in a rows event.
+// See `Field::pack` and its overrides in MySQL.
+func appendValue(buf []byte, ft *types.FieldType, d *types.Datum) ([]byte, error) {
+	unsigned := mysql.HasUnsignedFlag(ft.GetFlag())
+	intValue := func() uint64 {
+		if unsigned {
+			return d.GetUint64()
+		}
+		return uint64(d.GetInt64())
+	}
+	switch ft.GetType() {
+	case mysql.TypeTiny:
+		return append(buf, byte(intValue())), nil
+	case mysql.TypeShort:
+		return binary.LittleEndian.AppendUint16(buf, uint16(intValue())), nil
+	case mysql.TypeInt24:
+		v := intValue()
+		return append(buf, byte(v), byte(v>>8), byte(v>>16)), nil
+	case mysql.TypeLong:
+		return binary.LittleEndian.AppendUint32(buf, uint32(intValue())), nil
+	case mysql.TypeLonglong:
+		return binary.LittleEndian.AppendUint64(buf, intValue()), nil
+	case mysql.TypeYear:
+		if v := d.GetInt64(); v != 0 {
+			return append(buf, byte(v-1900)), nil
+		}
+		return append(buf, 0), nil
+	case mysql.TypeFloat:
+		return binary.LittleEndian.AppendUint32(buf, math.Float32bits(float32(d.GetFloat64()))), nil
+	case mysql.TypeDouble:
+		return binary.LittleEndian.AppendUint64(buf, math.Float64bits(d.GetFloat64())), nil
+	case mysql.TypeNewDecimal:
+		precision, frac := decimalPrecision(ft)
+		bin, err := d.GetMysqlDecimal().ToBin(precision, frac)
+		if err != nil {
+			return nil, err
+		}
+		return append(buf, bin...), nil
+	case mysql.TypeDate:
+		t := d.GetMysqlTime()
+		v := t.Day() + t.Month()*32 + t.Year()*16*32
+		return append(buf, byte(v), byte(v>>8), byte(v>>16)), nil
+	case mysql.TypeDatetime:
+		return appendDatetime2(buf, d.GetMysqlTime(), fsp(ft)), nil
+	case mysql.TypeTimestamp:
+		return appendTimestamp2(buf, d.GetMysqlTime(), fsp(ft))
+	case mysql.TypeDuration:
+		return appendTime2(buf, d.GetMysqlDuration(), fsp(ft)), nil
+	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString:
+		b := d.GetBytes()
+		if maxBytes(ft) > math.MaxUint8 {
+			buf = binary.LittleEndian.AppendUint16(buf, uint16(len(b)))
+		} else {
+			buf = append(buf, byte(len(b)))
+		}
+		return append(buf, b...), nil
+	case mysql.TypeEnum:
+		v := d.GetMysqlEnum().Value
+		if enumPackLength(ft) == 2 {
+			return binary.LittleEndian.AppendUint16(buf, uint16(v)), nil
+		}
+		return append(buf, byte(v)), nil
+	case mysql.TypeSet:
+		v := binary.LittleEndian.AppendUint64(nil, d.GetMysqlSet().Value)
+		return append(buf, v[:setPackLength(ft)]...), nil
+	case mysql.TypeBit:
+		b := d.GetMysqlBit()
+		n := (max(ft.GetFlen(), 1) + 7) / 8
+		if len(b) > n {
+			b = b[len(b)-n:]
+		}
+		buf = append(buf, make([]byte, n-len(b))...)
+		return append(buf, b...), nil
+	case mysql.TypeTinyBlob, mysql.TypeBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeGeometry:
+		return appendBlob(buf, blobPackLength(ft), d.GetBytes()), nil
+	case mysql.TypeTiDBVectorFloat32:
+		return appendBlob(buf, 4, []byte(d.GetVectorFloat32().String())), nil
+	case mysql.TypeJSON:
+		j := d.GetMysqlJSON()
+		return appendBlob(buf, 4, append([]byte{byte(j.TypeCode)}, j.Value...)), nil
+	}
+	return nil, errors.Errorf("unsupported column type %s", types.TypeToStr(ft.GetType(), ft.GetCharset()))
+}
+
+func appendBlob(buf []byte, packLength int, b []byte) []byte {
+	l := binary.LittleEndian.AppendUint32(nil, uint32(len(b)))
+	buf = append(buf, l[:packLength]...)
+	return append(buf, b...)
+}
+
+// appendFrac appends the fractional part of a temporal value in big endian.
+func appendFrac(buf []byte, frac int64, fsp int) []byte {
+	switch fsp {
+	case 1, 2:
+		return append(buf, byte(frac/10000))
+	case 3, 4:
+		return binary.BigEndian.AppendUint16(buf, uint16(frac/100))
+	case 5, 6:
+		return append(buf, byte(frac>>16), byte(frac>>8), byte(frac))
+	}
+	return buf
+}
+
+// appendDatetime2 appends a DATETIME value, see `my_datetime_packed_to_binary` in MySQL.
+func appendDatetime2(buf []byte, t types.Time, fsp int) []byte {
+	const datetimeIntOffset = 0x8000000000
+	ym := int64(t.Year()*13 + t.Month())
+	ymd := ym<<5 | int64(t.Day())
+	hms := int64(t.Hour()<<12 | t.Minute()<<6 | t.Second())
+	v := uint64(ymd<<17|hms) + datetimeIntOffset
+	buf = append(buf, byte(v>>32), byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+	return appendFrac(buf, int64(t.Microsecond()), fsp)
+}
+
+// appendTimestamp2 appends a TIMESTAMP value in UTC, see `my_timestamp_to_binary` in MySQL.
+func appendTimestamp2(buf []byte, t types.Time, fsp int) ([]byte, error) {
+	var sec int64
+	if !t.IsZero() {
+		gt, err := t.GoTime(time.UTC)
+		if err != nil {
+			return nil, err
+		}
+		sec = gt.Unix()
+	}
+	buf = binary.BigEndian.AppendUint32(buf, uint32(sec))
+	return appendFrac(buf, int64(t.Microsecond()), fsp), nil
+}
+
+// appendTime2 appends a TIME value, see `my_time_packed_to_binary` in MySQL.
+func appendTime2(buf []byte, d types.Duration, fsp int) []byte {
+	const (
+		timeIntOffset = 0x800000
+		timeOffset    = 0x800000000000
+	)
+	neg := d.Duration < 0
+	dur := d.Duration
+	if neg {
+		dur = -dur
+	}
+	hour := int64(dur / time.Hour)
+	minute := int64(dur % time.Hour / time.Minute)
+	second := int64(dur % time.Minute / time.Second)
+	usec := int64(dur % time.Second / time.Microsecond)
+	packed := (hour<<12|minute<<6|second)<<24 + usec
+	if neg {
+		packed = -packed
+	}
+	if fsp >= 5 {
+		v := uint64(packed + timeOffset)
+		return append(buf, byte(v>>40), byte(v>>32), byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+	}
+	// The fractional part of a negative value is negative too, it's stored in two's complement.
+	intPart := packed >> 24
+	frac := packed % (1 << 24)
+	var fracBytes []byte
+	switch fsp {
+	case 1, 2:
+		fracBytes = []byte{byte(frac / 10000)}
+	case 3, 4:
+		fracBytes = binary.BigEndian.AppendUint16(nil, uint16(frac/100))
+	}
+	v := uint64(intPart + timeIntOffset)
+	buf = append(buf, byte(v>>16), byte(v>>8), byte(v))
+	return append(buf, fracBytes...)
+}
@@ -0,0 +1,602 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package binlogdump encodes the transactions in the binlog of the cluster into MySQL binlog events, so a
+// MySQL replica can replicate from any TiDB instance by COM_BINLOG_DUMP or COM_BINLOG_DUMP_GTID.
+//
+// The binlog files are virtual. A binlog file is named by the commit ts of the last transaction before
+// it, and the events in it are encoded from the transactions after that one, so the positions of the
+// events are always the same when a replica reconnects. The GTID of a transaction is its rank in the
+// binlog, so the GTIDs have no gaps.
+package binlogdump
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/tidb-binlog/binlogstore"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/codec"
+	"github.com/pingcap/tipb/go-binlog"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+const (
+	// FileBaseName is the base name of the binlog files.
+	FileBaseName = "tidb-binlog"
+	// FirstEventPos is the position of the first event in a binlog file, it follows the magic number.
+	FirstEventPos = 4
+
+	// DumpNonBlockFlag is the flag of COM_BINLOG_DUMP which asks the server to return EOF instead of
+	// waiting for new events when all the events are sent.
+	DumpNonBlockFlag = 0x01
+
+	readBatchSize = 64
+	// pollInterval is the interval to read the binlog when there is no new transaction.
+	pollInterval = 100 * time.Millisecond
+	// maxRowsEventSize is the size of a rows event after which the following rows are put into a new event.
+	maxRowsEventSize = 8 << 10
+)
+
+// maxFileSize is the size of a binlog file after which a new binlog file is started.
+var maxFileSize uint32 = 1 << 30
+
+// ErrInvalidPosition is returned when the position to start dumping is not the start of an event.
+var ErrInvalidPosition = errors.New("the position is not at the start of a transaction in the binlog file")
+
+// FileName returns the name of the binlog file which starts after the transaction of the commit ts.
+func FileName(ts uint64) string {
+	return fmt.Sprintf("%s.%06d", FileBaseName, ts)
+}
+
+func parseFileName(name string) (uint64, error) {
+	ext, ok := strings.CutPrefix(name, FileBaseName+".")
+	if !ok {
+		return 0, errors.Errorf("unknown binlog file %s", name)
+	}
+	ts, err := strconv.ParseUint(ext, 10, 64)
+	if err != nil {
+		return 0, errors.Errorf("unknown binlog file %s", name)
+	}
+	return ts, nil
+}
+
+// GTIDSet returns the GTID set of the transactions up to the rank.
+func GTIDSet(sid uuid.UUID, rank uint64) string {
+	if rank == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s:1-%d", sid, rank)
+}
+
+// Source is the binlog to stream.
+type Source interface {
+	// Read returns at most limit transactions whose commit ts are greater than ts in the order of commit ts.
+	Read(ctx context.Context, ts uint64, limit int) ([]*binlogstore.Txn, error)
+	// PurgedTS returns the ts at or before which the transactions are purged.
+	PurgedTS(ctx context.Context) (uint64, error)
+	// Rank returns the count of the transactions at or before ts.
+	Rank(ctx context.Context, ts uint64) (uint64, error)
+	// Seek returns the commit ts of the transaction of the rank, ok is false if it's not readable yet.
+	Seek(ctx context.Context, rank uint64) (commitTS uint64, ok bool, err error)
+}
+
+// Options is the options of a Streamer.
+type Options struct {
+	// ServerID is the server id in the events.
+	ServerID uint32
+	// ServerUUID is the source id of the GTIDs.
+	ServerUUID uuid.UUID
+	// ServerVersion is the server version in the format description event.
+	ServerVersion string
+	// Checksum is whether the replica accepts the events with CRC32 checksum.
+	Checksum bool
+	// NonBlock is whether to stop when all the events are sent.
+	NonBlock bool
+	// HeartbeatPeriod is the period to send a heartbeat event when there is no new event.
+	HeartbeatPeriod time.Duration
+	// InfoSchema returns the information schema at the ts.
+	InfoSchema func(ts uint64) (infoschema.InfoSchema, error)
+	// DDLJob returns the DDL job of the id, it returns nil if the job is not found.
+	DDLJob func(id int64) (*model.Job, error)
+}
+
+// EventWriter writes the events to a replica.
+type EventWriter interface {
+	// WriteEvent writes an event, the event may be buffered.
+	WriteEvent(event []byte) error
+	// Flush flushes the buffered events.
+	Flush() error
+}
+
+// Streamer streams the transactions in the binlog as binlog events.
+type Streamer struct {
+	source Source
+	opts   Options
+	enc    eventEncoder
+
+	// fileTS is the commit ts of the last transaction before the current binlog file.
+	fileTS uint64
+	// txnsInFile is the count of the transactions in the current binlog file.
+	txnsInFile int64
+	// commitTS is the commit ts of the last encoded transaction.
+	commitTS uint64
+	// rank is the rank of the last encoded transaction, which is the GNO of its GTID.
+	rank uint64
+	// startPos is the position to start sending events, the events before it are skipped.
+	startPos uint32
+}
+
+// NewStreamer creates a Streamer.
+func NewStreamer(source Source, opts Options) *Streamer {
+	return &Streamer{
+		source:   source,
+		opts:     opts,
+		enc:      eventEncoder{serverID: opts.ServerID},
+		startPos: FirstEventPos,
+	}
+}
+
+// SeekPosition makes the streamer start from the position of the binlog file. An empty file name
+// means the first binlog file which is not purged.
+func (s *Streamer) SeekPosition(ctx context.Context, file string, pos uint32) error {
+	var ts uint64
+	var err error
+	if file == "" {
+		ts, err = s.source.PurgedTS(ctx)
+	} else {
+		ts, err = parseFileName(file)
+		s.startPos = max(pos, FirstEventPos)
+	}
+	if err != nil {
+		return err
+	}
+	return s.setStart(ctx, ts)
+}
+
+// SeekGTID makes the streamer start from the first transaction which is not in the GTID set. The
+// GTID set is in the binary format of COM_BINLOG_DUMP_GTID.
+func (s *Streamer) SeekGTID(ctx context.Context, data []byte) error {
+	executed, err := executedRank(data, s.opts.ServerUUID)
+	if err != nil {
+		return err
+	}
+	// The replica has executed nothing from this cluster if executed is 0, it fails if some transactions are purged.
+	ts, ok, err := s.source.Seek(ctx, executed)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.Errorf("the replica has more GTIDs of %s than the source", s.opts.ServerUUID)
+	}
+	return s.setStart(ctx, ts)
+}
+
+func (s *Streamer) setStart(ctx context.Context, ts uint64) error {
+	rank, err := s.source.Rank(ctx, ts)
+	if err != nil {
+		return err
+	}
+	s.setFile(ts)
+	s.rank = rank
+	return nil
+}
+
+// executedRank returns the max GNO of the sid in the binary GTID set. The transactions of a sid are
+// always sent in order, so it's assumed that there is no gap in the GTID set.
+func executedRank(data []byte, sid uuid.UUID) (rank uint64, err error) {
+	errMalformed := errors.New("malformed GTID set")
+	if len(data) < 8 {
+		return 0, errMalformed
+	}
+	nSIDs := binary.LittleEndian.Uint64(data)
+	data = data[8:]
+	for i := uint64(0); i < nSIDs; i++ {
+		if len(data) < 24 {
+			return 0, errMalformed
+		}
+		match := uuid.UUID(data[:16]) == sid
+		nIntervals := binary.LittleEndian.Uint64(data[16:])
+		data = data[24:]
+		if uint64(len(data)) < nIntervals*16 {
+			return 0, errMalformed
+		}
+		for j := uint64(0); j < nIntervals; j++ {
+			// The interval is [start, end).
+			end := binary.LittleEndian.Uint64(data[j*16+8:])
+			if match && end > 0 {
+				rank = max(rank, end-1)
+			}
+		}
+		data = data[nIntervals*16:]
+	}
+	return rank, nil
+}
+
+func (s *Streamer) setFile(ts uint64) {
+	s.fileTS = ts
+	s.commitTS = ts
+	s.txnsInFile = 0
+	s.enc.pos = FirstEventPos
+}
+
+// Run sends the events to the replica until the context is done or an error occurs.
+func (s *Streamer) Run(ctx context.Context, w EventWriter) error {
+	send := func(event []byte) error {
+		if !s.opts.Checksum {
+			event = event[:len(event)-checksumSize]
+			binary.LittleEndian.PutUint32(event[9:], uint32(len(event)))
+			if event[4] == formatDescriptionEvent {
+				// The checksum algorithm is the last byte of the event body.
+				event[len(event)-1] = checksumAlgOff
+			}
+		}
+		return w.WriteEvent(event)
+	}
+	// The replica learns the file name from the rotate event.
+	if err := send(s.enc.encodeArtificial(rotateEvent, rotateBody(FileName(s.fileTS), uint64(s.startPos)))); err != nil {
+		return err
+	}
+	fde := s.enc.encode(formatDescriptionEvent, s.fileTimestamp(), 0, formatDescriptionBody(s.opts.ServerVersion, s.fileTimestamp()))
+	if s.startPos > FirstEventPos {
+		// The format description event is not at the position to start, the replica must not update
+		// its position by it.
+		fde = s.enc.encodeAt(formatDescriptionEvent, s.fileTimestamp(), 0, formatDescriptionBody(s.opts.ServerVersion, s.fileTimestamp()), 0)
+	}
+	if err := send(fde); err != nil {
+		return err
+	}
+	if s.startPos <= s.enc.pos {
+		if s.startPos != FirstEventPos && s.startPos != s.enc.pos {
+			return ErrInvalidPosition
+		}
+		s.startPos = 0
+	}
+
+	heartbeat := time.NewTicker(s.opts.HeartbeatPeriod)
+	defer heartbeat.Stop()
+	poll := time.NewTicker(pollInterval)
+	defer poll.Stop()
+	for {
+		txns, err := s.source.Read(ctx, s.commitTS, readBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, txn := range txns {
+			events, err := s.encodeTxn(txn)
+			if err != nil {
+				return err
+			}
+			if s.startPos > 0 {
+				// The events are before the position to start, they are encoded only to move the position.
+				if s.enc.pos > s.startPos || s.enc.pos >= maxFileSize {
+					return ErrInvalidPosition
+				}
+				if s.enc.pos == s.startPos {
+					s.startPos = 0
+				}
+				continue
+			}
+			for _, event := range events {
+				if err := send(event); err != nil {
+					return err
+				}
+			}
+			if s.enc.pos >= maxFileSize {
+				if err := s.rotate(send); err != nil {
+					return err
+				}
+			}
+			heartbeat.Reset(s.opts.HeartbeatPeriod)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		if len(txns) > 0 {
+			continue
+		}
+		if s.startPos > 0 {
+			// The position to start is beyond the end of the binlog file.
+			return ErrInvalidPosition
+		}
+		if s.opts.NonBlock {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-poll.C:
+		case <-heartbeat.C:
+			event := s.enc.encodeAt(heartbeatEvent, 0, 0, []byte(FileName(s.fileTS)), s.enc.pos)
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// rotate starts a new binlog file after the current transaction.
+func (s *Streamer) rotate(send func([]byte) error) error {
+	if err := send(s.enc.encode(rotateEvent, 0, 0, rotateBody(FileName(s.commitTS), FirstEventPos))); err != nil {
+		return err
+	}
+	s.setFile(s.commitTS)
+	return send(s.enc.encode(formatDescriptionEvent, s.fileTimestamp(), 0, formatDescriptionBody(s.opts.ServerVersion, s.fileTimestamp())))
+}
+
+// fileTimestamp returns the timestamp of the current binlog file. It's the same every time the file is
+// encoded, so the format description event doesn't change.
+func (s *Streamer) fileTimestamp() uint32 {
+	return tsTimestamp(s.fileTS)
+}
+
+func tsTimestamp(ts uint64) uint32 {
+	return uint32(oracle.ExtractPhysical(ts) / 1000)
+}
+
+// encodeTxn encodes the transaction into events and moves the position. A transaction which has nothing
+// to replicate is encoded as an empty transaction, so the GTIDs have no gaps.
+func (s *Streamer) encodeTxn(txn *binlogstore.Txn) ([][]byte, error) {
+	var body [][]byte
+	var err error
+	if txn.Prewrite != nil {
+		body, err = s.encodeRowsTxn(txn)
+	} else {
+		body, err = s.encodeDDLTxn(txn)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(body) == 0 {
+		body = [][]byte{withType(queryEvent, queryBody("", "BEGIN")), withType(queryEvent, queryBody("", "COMMIT"))}
+	}
+
+	s.commitTS = txn.CommitTS
+	s.rank++
+	s.txnsInFile++
+	timestamp := tsTimestamp(txn.CommitTS)
+	events := make([][]byte, 0, len(body)+1)
+	events = append(events, s.enc.encode(gtidEvent, timestamp, 0, gtidBody(s.opts.ServerUUID, s.rank, s.txnsInFile)))
+	for _, b := range body {
+		event := s.enc.encode(b[0], timestamp, 0, b[1:])
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func (s *Streamer) encodeDDLTxn(txn *binlogstore.Txn) ([][]byte, error) {
+	var schema string
+	if txn.DDLJobID > 0 {
+		job, err := s.opts.DDLJob(txn.DDLJobID)
+		if err != nil {
+			return nil, err
+		}
+		if job != nil {
+			if job.IsRollbackDone() {
+				return nil, nil
+			}
+			// The binlog of dropping a column is written in the delete only state instead of the done state.
+			if job.Type == model.ActionDropColumn && txn.DDLSchemaState != int32(model.StateDeleteOnly) {
+				return nil, nil
+			}
+			schema = job.SchemaName
+		}
+	}
+	return [][]byte{withType(queryEvent, queryBody(schema, txn.DDLQuery))}, nil
+}
+
+// withType prepends the event type to the event body.
+func withType(tp byte, body []byte) []byte {
+	return append([]byte{tp}, body...)
+}
+
+// binlogTable is a table in a rows transaction.
+type binlogTable struct {
+	id     int64
+	schema string
+	info   *model.TableInfo
+	cols   []*model.ColumnInfo
+	fts    map[int64]*types.FieldType
+}
+
+func (s *Streamer) encodeRowsTxn(txn *binlogstore.Txn) ([][]byte, error) {
+	is, err := s.opts.InfoSchema(txn.CommitTS)
+	if err != nil {
+		return nil, err
+	}
+	var tableMaps, rows [][]byte
+	for i := range txn.Prewrite.Mutations {
+		mutation := &txn.Prewrite.Mutations[i]
+		tbl, err := findTable(is, mutation.TableId)
+		if err != nil {
+			return nil, err
+		}
+		if tbl == nil || len(mutation.Sequence) == 0 {
+			continue
+		}
+		tableMaps = append(tableMaps, withType(tableMapEvent, tableMapBody(tbl.id, tbl.schema, tbl.info, tbl.cols)))
+		tableRows, err := encodeRows(tbl, mutation)
+		if err != nil {
+			return nil, errors.Annotatef(err, "encode rows of table %s.%s", tbl.schema, tbl.info.Name.O)
+		}
+		rows = append(rows, tableRows...)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	// Mark the last rows event of the transaction as the end of the statement, so the replica
+	// releases the tables.
+	last := rows[len(rows)-1]
+	binary.LittleEndian.PutUint16(last[1+6:], stmtEndFlag)
+
+	events := make([][]byte, 0, len(tableMaps)+len(rows)+2)
+	events = append(events, withType(queryEvent, queryBody("", "BEGIN")))
+	events = append(events, tableMaps...)
+	events = append(events, rows...)
+	return append(events, withType(xidEvent, xidBody(txn.StartTS))), nil
+}
+
+// findTable returns the table of the physical table id, it returns nil if the table is not found.
+func findTable(is infoschema.InfoSchema, physicalID int64) (*binlogTable, error) {
+	var info *model.TableInfo
+	var db *model.DBInfo
+	if tbl, ok := is.TableByID(physicalID); ok {
+		info = tbl.Meta()
+		db, ok = infoschema.SchemaByTable(is, info)
+		if !ok {
+			return nil, nil
+		}
+	} else {
+		tbl, partDB, _ := is.FindTableByPartitionID(physicalID)
+		if tbl == nil {
+			return nil, nil
+		}
+		info, db = tbl.Meta(), partDB
+	}
+	tbl := &binlogTable{
+		id:     physicalID,
+		schema: db.Name.O,
+		info:   info,
+		cols:   info.Cols(),
+		fts:    make(map[int64]*types.FieldType, len(info.Columns)),
+	}
+	for _, col := range info.Columns {
+		tbl.fts[col.ID] = &col.FieldType
+	}
+	return tbl, nil
+}
+
+// encodeRows encodes the rows of the mutation into rows events, the consecutive rows of the same
+// type are put into the same event.
+func encodeRows(tbl *binlogTable, mutation *binlog.TableMutation) ([][]byte, error) {
+	var events [][]byte
+	var event []byte
+	var inserted, updated, deleted int
+	for i, tp := range mutation.Sequence {
+		if event == nil {
+			event = rowsEventHeader(tbl, tp)
+		}
+		var err error
+		switch tp {
+		case binlog.MutationType_Insert:
+			event, err = appendInsertedRow(event, tbl, mutation.InsertedRows[inserted])
+			inserted++
+		case binlog.MutationType_Update:
+			event, err = appendUpdatedRow(event, tbl, mutation.UpdatedRows[updated])
+			updated++
+		case binlog.MutationType_DeleteRow:
+			event, err = appendDeletedRow(event, tbl, mutation.DeletedRows[deleted])
+			deleted++
+		default:
+			return nil, errors.Errorf("unknown mutation type %v", tp)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if i == len(mutation.Sequence)-1 || mutation.Sequence[i+1] != tp || len(event) >= maxRowsEventSize {
+			events = append(events, event)
+			event = nil
+		}
+	}
+	return events, nil
+}
+
+func rowsEventHeader(tbl *binlogTable, tp binlog.MutationType) []byte {
+	eventType := writeRowsEvent
+	switch tp {
+	case binlog.MutationType_Update:
+		eventType = updateRowsEvent
+	case binlog.MutationType_DeleteRow:
+		eventType = deleteRowsEvent
+	}
+	buf := appendTableID([]byte{eventType}, tbl.id)
+	// flags, it's set to stmtEndFlag for the last rows event of a transaction.
+	buf = binary.LittleEndian.AppendUint16(buf, 0)
+	// The length of the extra data, which includes the length itself.
+	buf = binary.LittleEndian.AppendUint16(buf, 2)
+	buf = appendLengthEncodedInt(buf, uint64(len(tbl.cols)))
+	present := make([]byte, (len(tbl.cols)+7)/8)
+	for i := range tbl.cols {
+		present[i/8] |= 1 << (i % 8)
+	}
+	buf = append(buf, present...)
+	if tp == binlog.MutationType_Update {
+		buf = append(buf, present...)
+	}
+	return buf
+}
+
+func appendInsertedRow(buf []byte, tbl *binlogTable, data []byte) ([]byte, error) {
+	row, err := decodeInsertedRow(tbl, data)
+	if err != nil {
+		return nil, err
+	}
+	return appendRow(buf, tbl.cols, row)
+}
+
+func appendUpdatedRow(buf []byte, tbl *binlogTable, data []byte) ([]byte, error) {
+	oldRow, newRow, err := decodeUpdatedRow(tbl, data)
+	if err != nil {
+		return nil, err
+	}
+	buf, err = appendRow(buf, tbl.cols, oldRow)
+	if err != nil {
+		return nil, err
+	}
+	return appendRow(buf, tbl.cols, newRow)
+}
+
+func appendDeletedRow(buf []byte, tbl *binlogTable, data []byte) ([]byte, error) {
+	row, err := decodeRow(data, tbl.fts)
+	if err != nil {
+		return nil, err
+	}
+	return appendRow(buf, tbl.cols, row)
+}
+
+// decodeRow decodes a row encoded by tablecodec.EncodeOldRow.
+func decodeRow(data []byte, fts map[int64]*types.FieldType) (map[int64]types.Datum, error) {
+	datums, err := codec.Decode(data, 2*len(fts))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return unflattenRow(datums, fts)
+}
+
+// unflattenRow converts the column id and value pairs into a row. The binlog is encoded in UTC.
+func unflattenRow(datums []types.Datum, fts map[int64]*types.FieldType) (map[int64]types.Datum, error) {
+	row := make(map[int64]types.Datum, len(datums)/2)
+	// An empty row is encoded as a single null.
+	for i := 0; i+1 < len(datums); i += 2 {
+		ft, ok := fts[datums[i].GetInt64()]
+		if !ok {
+			continue
+		}
+		d, err := tablecodec.Unflatten(datums[i+1], ft, time.UTC)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		row[datums[i].GetInt64()] = d
+	}
+	return row, nil
+}
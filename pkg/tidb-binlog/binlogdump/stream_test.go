@@ -0,0 +1,267 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogdump
+
+import (
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/tidb-binlog/binlogstore"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+type mockEventWriter struct {
+	events [][]byte
+}
+
+func (w *mockEventWriter) WriteEvent(event []byte) error {
+	w.events = append(w.events, event)
+	return nil
+}
+
+func (*mockEventWriter) Flush() error {
+	return nil
+}
+
+func (w *mockEventWriter) types() []byte {
+	tps := make([]byte, 0, len(w.events))
+	for _, event := range w.events {
+		tps = append(tps, event[4])
+	}
+	return tps
+}
+
+// mockSource is a binlog whose first purgedRank transactions are purged at purgedTS.
+type mockSource struct {
+	txns       []*binlogstore.Txn
+	purgedTS   uint64
+	purgedRank uint64
+}
+
+func (s *mockSource) Read(_ context.Context, ts uint64, limit int) ([]*binlogstore.Txn, error) {
+	if ts < s.purgedTS {
+		return nil, binlogstore.ErrPurged
+	}
+	var txns []*binlogstore.Txn
+	for _, txn := range s.txns {
+		if txn.CommitTS > ts && len(txns) < limit {
+			txns = append(txns, txn)
+		}
+	}
+	return txns, nil
+}
+
+func (s *mockSource) PurgedTS(context.Context) (uint64, error) {
+	return s.purgedTS, nil
+}
+
+func (s *mockSource) Rank(_ context.Context, ts uint64) (uint64, error) {
+	if ts < s.purgedTS {
+		return 0, binlogstore.ErrPurged
+	}
+	rank := s.purgedRank
+	for _, txn := range s.txns {
+		if txn.CommitTS <= ts {
+			rank++
+		}
+	}
+	return rank, nil
+}
+
+func (s *mockSource) Seek(_ context.Context, rank uint64) (uint64, bool, error) {
+	if rank < s.purgedRank {
+		return 0, false, binlogstore.ErrPurged
+	}
+	if rank == s.purgedRank {
+		return s.purgedTS, true, nil
+	}
+	if i := rank - s.purgedRank - 1; i < uint64(len(s.txns)) {
+		return s.txns[i].CommitTS, true, nil
+	}
+	return 0, false, nil
+}
+
+var testServerUUID = uuid.MustParse("2ab9e6b4-8f76-5a3c-9c1e-1f4c8a0d3b7e")
+
+func commitTS(i int) uint64 {
+	return oracle.ComposeTS(int64(i+1)*1000, 1)
+}
+
+// newTestSource returns a source of DDL transactions whose first 10 transactions are purged.
+func newTestSource(queries ...string) *mockSource {
+	s := &mockSource{purgedTS: commitTS(-1), purgedRank: 10}
+	for i, query := range queries {
+		s.txns = append(s.txns, &binlogstore.Txn{StartTS: commitTS(i) - 1, CommitTS: commitTS(i), DDLQuery: query})
+	}
+	return s
+}
+
+func newTestStreamer(source Source) *Streamer {
+	return NewStreamer(source, Options{
+		ServerID:        1,
+		ServerUUID:      testServerUUID,
+		ServerVersion:   "8.0.11-TiDB",
+		Checksum:        true,
+		NonBlock:        true,
+		HeartbeatPeriod: time.Second,
+	})
+}
+
+func TestStreamFromStart(t *testing.T) {
+	source := newTestSource("create table t1 (a int)", "create table t2 (a int)")
+	s := newTestStreamer(source)
+	require.NoError(t, s.SeekPosition(context.Background(), "", 0))
+	w := &mockEventWriter{}
+	require.NoError(t, s.Run(context.Background(), w))
+	require.Equal(t, []byte{
+		rotateEvent, formatDescriptionEvent,
+		gtidEvent, queryEvent,
+		gtidEvent, queryEvent,
+	}, w.types())
+
+	// The binlog file is named after the commit ts of the transaction before it.
+	rotate := w.events[0]
+	require.Equal(t, uint16(logEventArtificialFlag), binary.LittleEndian.Uint16(rotate[17:]))
+	require.Equal(t, FileName(source.purgedTS), string(rotate[eventHeaderSize+8:len(rotate)-checksumSize]))
+
+	// The positions of the events in the binlog file are continuous.
+	pos := uint32(FirstEventPos)
+	for _, event := range w.events[1:] {
+		size := binary.LittleEndian.Uint32(event[9:])
+		require.Equal(t, uint32(len(event)), size)
+		pos += size
+		require.Equal(t, pos, binary.LittleEndian.Uint32(event[13:]))
+		require.Equal(t, crc32.ChecksumIEEE(event[:len(event)-checksumSize]), binary.LittleEndian.Uint32(event[len(event)-checksumSize:]))
+	}
+
+	// The GNO of a transaction is its rank in the binlog of the cluster.
+	gtid := w.events[4]
+	require.Equal(t, testServerUUID, uuid.UUID(gtid[eventHeaderSize+1:eventHeaderSize+17]))
+	require.Equal(t, uint64(12), binary.LittleEndian.Uint64(gtid[eventHeaderSize+17:]))
+
+	// The streamer fails if the binlog to read is purged.
+	s = newTestStreamer(source)
+	require.NoError(t, s.SeekPosition(context.Background(), "", 0))
+	source.purgedTS, source.purgedRank = commitTS(0), 11
+	require.ErrorIs(t, s.Run(context.Background(), &mockEventWriter{}), binlogstore.ErrPurged)
+}
+
+func TestStreamEmptyTxn(t *testing.T) {
+	source := newTestSource("create table t1 (a int)")
+	source.txns[0].DDLJobID = 1
+	s := newTestStreamer(source)
+	// The DDL job is rolled back, so the transaction has nothing to replicate.
+	s.opts.DDLJob = func(int64) (*model.Job, error) {
+		return &model.Job{State: model.JobStateRollbackDone}, nil
+	}
+	require.NoError(t, s.SeekPosition(context.Background(), "", 0))
+	w := &mockEventWriter{}
+	require.NoError(t, s.Run(context.Background(), w))
+	require.Equal(t, []byte{rotateEvent, formatDescriptionEvent, gtidEvent, queryEvent, queryEvent}, w.types())
+	require.Equal(t, uint64(11), binary.LittleEndian.Uint64(w.events[2][eventHeaderSize+17:]))
+	require.Contains(t, string(w.events[3]), "BEGIN")
+	require.Contains(t, string(w.events[4]), "COMMIT")
+}
+
+func TestStreamFromPosition(t *testing.T) {
+	source := newTestSource("create table t1 (a int)", "create table t2 (a int)")
+	s := newTestStreamer(source)
+	require.NoError(t, s.SeekPosition(context.Background(), "", 0))
+	all := &mockEventWriter{}
+	require.NoError(t, s.Run(context.Background(), all))
+	afterFirstTxn := binary.LittleEndian.Uint32(all.events[3][13:])
+
+	file := FileName(source.purgedTS)
+	s = newTestStreamer(source)
+	require.NoError(t, s.SeekPosition(context.Background(), file, afterFirstTxn))
+	w := &mockEventWriter{}
+	require.NoError(t, s.Run(context.Background(), w))
+	require.Equal(t, []byte{rotateEvent, formatDescriptionEvent, gtidEvent, queryEvent}, w.types())
+	// The format description event doesn't move the position of the replica.
+	require.Equal(t, uint32(0), binary.LittleEndian.Uint32(w.events[1][13:]))
+	require.Equal(t, all.events[4:], w.events[2:])
+
+	s = newTestStreamer(source)
+	require.NoError(t, s.SeekPosition(context.Background(), file, afterFirstTxn+1))
+	require.ErrorIs(t, s.Run(context.Background(), &mockEventWriter{}), ErrInvalidPosition)
+
+	// The binlog file starting after a transaction has the same events on every streamer.
+	s = newTestStreamer(source)
+	require.NoError(t, s.SeekPosition(context.Background(), FileName(commitTS(0)), FirstEventPos))
+	w = &mockEventWriter{}
+	require.NoError(t, s.Run(context.Background(), w))
+	require.Equal(t, []byte{rotateEvent, formatDescriptionEvent, gtidEvent, queryEvent}, w.types())
+	require.Equal(t, uint64(12), binary.LittleEndian.Uint64(w.events[2][eventHeaderSize+17:]))
+
+	s = newTestStreamer(source)
+	require.Error(t, s.SeekPosition(context.Background(), "mysql-bin.000001", FirstEventPos))
+	require.ErrorIs(t, s.SeekPosition(context.Background(), FileName(source.purgedTS-1), FirstEventPos), binlogstore.ErrPurged)
+}
+
+func encodeGTIDSet(sid uuid.UUID, start, end uint64) []byte {
+	buf := binary.LittleEndian.AppendUint64(nil, 1)
+	buf = append(buf, sid[:]...)
+	buf = binary.LittleEndian.AppendUint64(buf, 1)
+	buf = binary.LittleEndian.AppendUint64(buf, start)
+	return binary.LittleEndian.AppendUint64(buf, end)
+}
+
+func TestStreamFromGTID(t *testing.T) {
+	source := newTestSource("create table t1 (a int)", "create table t2 (a int)", "create table t3 (a int)")
+
+	s := newTestStreamer(source)
+	require.NoError(t, s.SeekGTID(context.Background(), encodeGTIDSet(testServerUUID, 1, 13)))
+	w := &mockEventWriter{}
+	require.NoError(t, s.Run(context.Background(), w))
+	require.Equal(t, []byte{rotateEvent, formatDescriptionEvent, gtidEvent, queryEvent}, w.types())
+	require.Equal(t, FileName(commitTS(1)), string(w.events[0][eventHeaderSize+8:len(w.events[0])-checksumSize]))
+	require.Equal(t, uint64(13), binary.LittleEndian.Uint64(w.events[2][eventHeaderSize+17:]))
+	query := w.events[3]
+	require.Contains(t, string(query[:len(query)-checksumSize]), "create table t3 (a int)")
+
+	// The replica which has executed all the purged transactions starts from the first one which is kept.
+	s = newTestStreamer(source)
+	require.NoError(t, s.SeekGTID(context.Background(), encodeGTIDSet(testServerUUID, 1, 11)))
+	w = &mockEventWriter{}
+	require.NoError(t, s.Run(context.Background(), w))
+	require.Len(t, w.events, 2+3*2)
+
+	// The replica which has executed nothing from this cluster can't start since some transactions are purged.
+	s = newTestStreamer(source)
+	require.ErrorIs(t, s.SeekGTID(context.Background(), encodeGTIDSet(uuid.New(), 1, 100)), binlogstore.ErrPurged)
+
+	s = newTestStreamer(source)
+	require.Error(t, s.SeekGTID(context.Background(), encodeGTIDSet(testServerUUID, 1, 20)))
+	require.Error(t, s.SeekGTID(context.Background(), []byte{1}))
+}
+
+func TestStreamWithoutChecksum(t *testing.T) {
+	s := newTestStreamer(newTestSource("create table t1 (a int)"))
+	s.opts.Checksum = false
+	require.NoError(t, s.SeekPosition(context.Background(), "", 0))
+	w := &mockEventWriter{}
+	require.NoError(t, s.Run(context.Background(), w))
+	for _, event := range w.events {
+		require.Equal(t, uint32(len(event)), binary.LittleEndian.Uint32(event[9:]))
+	}
+	fde := w.events[1]
+	require.Equal(t, byte(checksumAlgOff), fde[len(fde)-1])
+}
@@ -0,0 +1,190 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package binlogstore keeps the binlog of the whole cluster in `mysql.tidb_binlog`, so the binlog dump
+// source and the changefeeds read the same history on every TiDB instance, and the history survives
+// the restart of the instances.
+//
+// Every TiDB instance with `binlog.enable-dump-source` runs a Writer. It writes the transactions resolved
+// by its memory pump into `mysql.tidb_binlog` in the order of commit ts, and then publishes the resolved ts
+// of the pump in `mysql.tidb_binlog_sources`. The readers only read the transactions at or before the min
+// resolved ts of all the sources, so no transaction is committed before the position of a reader later.
+//
+// The binlog at or before the purged ts is incomplete or removed, a reader fails if it's before the purged
+// ts. The purged ts moves forward when the binlog is older than `binlog.dump-source-retention`, or a source
+// stops writing without leaving, since its buffered transactions are lost. The history starts from the
+// purged ts set by the first source.
+package binlogstore
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/ngaut/pools"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	pb "github.com/pingcap/tipb/go-binlog"
+)
+
+const (
+	// purgedTSVariable is the variable in `mysql.tidb` which holds the purged ts.
+	purgedTSVariable = "tidb_binlog_purged_ts"
+	// purgedRankVariable is the variable in `mysql.tidb` which holds the count of the transactions at or
+	// before the purged ts.
+	purgedRankVariable = "tidb_binlog_purged_rank"
+)
+
+// ErrPurged is returned when the transactions to read are no longer kept.
+var ErrPurged = errors.New("the binlog to read has been purged")
+
+// Txn is a committed transaction in the binlog.
+type Txn struct {
+	StartTS  uint64
+	CommitTS uint64
+	// Prewrite holds the row mutations of a DML transaction, it's nil for a DDL transaction.
+	Prewrite *pb.PrewriteValue
+	// DDLJobID, DDLSchemaState and DDLQuery are set for a DDL transaction.
+	DDLJobID       int64
+	DDLSchemaState int32
+	DDLQuery       string
+}
+
+// DecodeTxn decodes a transaction from its prewrite binlog.
+func DecodeTxn(startTS, commitTS uint64, payload []byte) (*Txn, error) {
+	prewrite := &pb.Binlog{}
+	if err := prewrite.Unmarshal(payload); err != nil {
+		return nil, errors.Trace(err)
+	}
+	txn := &Txn{
+		StartTS:        startTS,
+		CommitTS:       commitTS,
+		DDLJobID:       prewrite.DdlJobId,
+		DDLSchemaState: prewrite.DdlSchemaState,
+		DDLQuery:       string(prewrite.DdlQuery),
+	}
+	if len(prewrite.PrewriteValue) > 0 {
+		txn.Prewrite = &pb.PrewriteValue{}
+		if err := txn.Prewrite.Unmarshal(prewrite.PrewriteValue); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return txn, nil
+}
+
+// ServerUUID returns the server_uuid of the binlog source. It's derived from the cluster, so all the
+// TiDB instances of the cluster have the same server_uuid, and it doesn't change when they restart.
+func ServerUUID(store kv.Storage) uuid.UUID {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte("tidb-binlog/"+store.UUID()))
+}
+
+type sessionPool interface {
+	Get() (pools.Resource, error)
+	Put(pools.Resource)
+}
+
+func withSession(pool sessionPool, fn func(sctx sessionctx.Context) error) error {
+	resource, err := pool.Get()
+	if err != nil {
+		return err
+	}
+	defer pool.Put(resource)
+	sctx, ok := resource.(sessionctx.Context)
+	if !ok {
+		return errors.Errorf("%T is not sessionctx.Context", resource)
+	}
+	return fn(sctx)
+}
+
+func currentTS(sctx sessionctx.Context) (uint64, error) {
+	ver, err := sctx.GetStore().CurrentVersion(kv.GlobalTxnScope)
+	if err != nil {
+		return 0, err
+	}
+	return ver.Ver, nil
+}
+
+// queryFunc executes a statement and returns the rows.
+type queryFunc func(sql string, args ...any) ([]chunk.Row, error)
+
+// meta is the state of the binlog kept in `mysql.tidb`.
+type meta struct {
+	purgedTS   uint64
+	purgedRank uint64
+	// found is false if no source has been registered.
+	found bool
+}
+
+func loadMeta(query queryFunc, forUpdate bool) (meta, error) {
+	sql := "SELECT VARIABLE_NAME, VARIABLE_VALUE FROM mysql.tidb WHERE VARIABLE_NAME IN (%?, %?)"
+	if forUpdate {
+		sql += " FOR UPDATE"
+	}
+	rows, err := query(sql, purgedTSVariable, purgedRankVariable)
+	if err != nil {
+		return meta{}, err
+	}
+	var m meta
+	for _, row := range rows {
+		v, err := strconv.ParseUint(row.GetString(1), 10, 64)
+		if err != nil {
+			return meta{}, errors.Annotatef(err, "invalid %s", row.GetString(0))
+		}
+		switch row.GetString(0) {
+		case purgedTSVariable:
+			m.purgedTS, m.found = v, true
+		case purgedRankVariable:
+			m.purgedRank = v
+		}
+	}
+	return m, nil
+}
+
+// rank returns the count of the transactions at or before the ts.
+func (m meta) rank(query queryFunc, ts uint64) (uint64, error) {
+	if ts < m.purgedTS {
+		return 0, ErrPurged
+	}
+	rows, err := query("SELECT COUNT(*) FROM mysql.tidb_binlog WHERE chunk = 0 AND commit_ts > %? AND commit_ts <= %?",
+		m.purgedTS, ts)
+	if err != nil {
+		return 0, err
+	}
+	return m.purgedRank + uint64(rows[0].GetInt64(0)), nil
+}
+
+// watermark returns the min resolved ts of the sources, the transactions after it are not readable.
+func watermark(query queryFunc) (uint64, error) {
+	rows, err := query("SELECT IFNULL(MIN(resolved_ts), 0) FROM mysql.tidb_binlog_sources")
+	if err != nil {
+		return 0, err
+	}
+	return rows[0].GetUint64(0), nil
+}
+
+// sessionQuery returns a queryFunc which executes the statements in the current transaction of the session.
+func sessionQuery(ctx context.Context, sctx sessionctx.Context) queryFunc {
+	return func(sql string, args ...any) ([]chunk.Row, error) {
+		rs, err := sctx.GetSQLExecutor().ExecuteInternal(ctx, sql, args...)
+		if rs == nil || err != nil {
+			return nil, err
+		}
+		defer terror.Call(rs.Close)
+		return sqlexec.DrainRecordSet(ctx, rs, 8)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogstore_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/sessionctx/binloginfo"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/tidb-binlog/binlogstore"
+	"github.com/pingcap/tidb/pkg/tidb-binlog/memorypump"
+	pumpcli "github.com/pingcap/tidb/pkg/tidb-binlog/pump_client"
+	"github.com/stretchr/testify/require"
+)
+
+func enableMemoryPump(t *testing.T, tk *testkit.TestKit) {
+	pump := memorypump.New(1 << 20)
+	memorypump.SetPump(pump)
+	binloginfo.SetPumpsClient(pumpcli.NewInProcessPumpsClient(pump, time.Second))
+	t.Cleanup(func() {
+		binloginfo.SetPumpsClient(nil)
+		memorypump.SetPump(nil)
+	})
+	// The binlog is kept from the time the pump is registered as a source.
+	require.Eventually(t, func() bool {
+		return len(tk.MustQuery("select * from mysql.tidb_binlog_sources").Rows()) > 0
+	}, 10*time.Second, 100*time.Millisecond)
+}
+
+func TestReadBinlog(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v longblob)")
+	tk.MustExec("insert into t values (0, 'before')")
+	enableMemoryPump(t, tk)
+
+	reader := binlogstore.NewReader(dom.SysSessionPool())
+	ctx := context.Background()
+	purgedTS, err := reader.PurgedTS(ctx)
+	require.NoError(t, err)
+	require.NotZero(t, purgedTS)
+
+	// The binlog client of a session is set when the session is created.
+	tk = testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("insert into t values (1, 'a')")
+	// The binlog of a large transaction is split into multiple rows.
+	tk.MustExec(fmt.Sprintf("insert into t values (2, '%s')", strings.Repeat("b", 5<<19)))
+	tk.MustExec("insert into t values (3, 'c')")
+	var txns []*binlogstore.Txn
+	require.Eventually(t, func() bool {
+		txns, err = reader.Read(ctx, purgedTS, 100)
+		require.NoError(t, err)
+		return len(txns) >= 3
+	}, 10*time.Second, 100*time.Millisecond)
+	require.Len(t, txns, 3)
+	tk.MustQuery("select count(*) from mysql.tidb_binlog where start_ts = " + fmt.Sprint(txns[1].StartTS)).Check(testkit.Rows("3"))
+	for i, txn := range txns {
+		require.NotNil(t, txn.Prewrite)
+		require.Less(t, txn.StartTS, txn.CommitTS)
+		if i > 0 {
+			require.Less(t, txns[i-1].CommitTS, txn.CommitTS)
+		}
+	}
+
+	// The limit never splits a transaction.
+	split, err := reader.Read(ctx, txns[0].CommitTS, 2)
+	require.NoError(t, err)
+	require.Len(t, split, 1)
+	require.Equal(t, txns[1].CommitTS, split[0].CommitTS)
+	require.Equal(t, txns[1].Prewrite, split[0].Prewrite)
+
+	// The rank of a transaction is the count of the transactions at or before it.
+	for i, txn := range txns {
+		rank, err := reader.Rank(ctx, txn.CommitTS)
+		require.NoError(t, err)
+		require.Equal(t, uint64(i+1), rank)
+		commitTS, ok, err := reader.Seek(ctx, rank)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, txn.CommitTS, commitTS)
+	}
+	commitTS, ok, err := reader.Seek(ctx, 0)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, purgedTS, commitTS)
+	_, ok, err = reader.Seek(ctx, 100)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// The binlog is kept when the pump of the instance is restarted.
+	enableMemoryPump(t, tk)
+	tk = testkit.NewTestKit(t, store)
+	tk.MustExec("insert into test.t values (4, 'd')")
+	require.Eventually(t, func() bool {
+		txns, err = reader.Read(ctx, purgedTS, 100)
+		require.NoError(t, err)
+		return len(txns) >= 4
+	}, 10*time.Second, 100*time.Millisecond)
+	ts, err := reader.PurgedTS(ctx)
+	require.NoError(t, err)
+	require.Equal(t, purgedTS, ts)
+
+	// The rank doesn't change after the binlog before it is purged.
+	tk.MustExec(fmt.Sprintf("update mysql.tidb set variable_value = '%d' where variable_name = 'tidb_binlog_purged_ts'", txns[0].CommitTS))
+	tk.MustExec("update mysql.tidb set variable_value = '1' where variable_name = 'tidb_binlog_purged_rank'")
+	rank, err := reader.Rank(ctx, txns[2].CommitTS)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), rank)
+	_, err = reader.Read(ctx, purgedTS, 100)
+	require.ErrorIs(t, err, binlogstore.ErrPurged)
+	_, _, err = reader.Seek(ctx, 0)
+	require.ErrorIs(t, err, binlogstore.ErrPurged)
+	_, err = reader.Rank(ctx, purgedTS)
+	require.ErrorIs(t, err, binlogstore.ErrPurged)
+}
+
+func TestServerUUID(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	require.Equal(t, binlogstore.ServerUUID(store), binlogstore.ServerUUID(store))
+	require.NotEqual(t, binlogstore.ServerUUID(store), binlogstore.ServerUUID(testkit.CreateMockStore(t)))
+}
@@ -0,0 +1,178 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogstore
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+)
+
+// Reader reads the transactions in `mysql.tidb_binlog` in the order of commit ts. The rank of a transaction
+// is the count of the transactions at or before it, it never changes once the transaction is readable.
+type Reader struct {
+	pool sessionPool
+}
+
+// NewReader creates a Reader.
+func NewReader(pool sessionPool) *Reader {
+	return &Reader{pool: pool}
+}
+
+// Read returns at most limit transactions whose commit ts are greater than ts in the order of commit ts.
+// It returns ErrPurged if the transactions after ts are purged.
+func (r *Reader) Read(ctx context.Context, ts uint64, limit int) (txns []*Txn, err error) {
+	err = r.withSnapshot(ctx, func(query queryFunc) error {
+		m, err := loadMeta(query, false)
+		if err != nil || !m.found {
+			return err
+		}
+		if ts < m.purgedTS {
+			return ErrPurged
+		}
+		wm, err := watermark(query)
+		if err != nil || ts >= wm {
+			return err
+		}
+		rows, err := query(`SELECT commit_ts, start_ts, chunk, chunks, data FROM mysql.tidb_binlog
+			WHERE commit_ts > %? AND commit_ts <= %? ORDER BY commit_ts, start_ts, chunk LIMIT %?`, ts, wm, limit)
+		if err != nil {
+			return err
+		}
+		txns, err = decodeRows(rows)
+		if err != nil || len(rows) < limit {
+			return err
+		}
+		// The last transaction may be split by the limit.
+		last := rows[len(rows)-1]
+		if last.GetInt64(2) == last.GetInt64(3)-1 {
+			return nil
+		}
+		if len(txns) > 0 {
+			return nil
+		}
+		first := rows[0]
+		rows, err = query("SELECT commit_ts, start_ts, chunk, chunks, data FROM mysql.tidb_binlog WHERE commit_ts = %? AND start_ts = %? ORDER BY chunk",
+			first.GetUint64(0), first.GetUint64(1))
+		if err != nil {
+			return err
+		}
+		txns, err = decodeRows(rows)
+		if err == nil && len(txns) == 0 {
+			err = errors.Errorf("the binlog of the transaction %d is incomplete", first.GetUint64(1))
+		}
+		return err
+	})
+	return txns, err
+}
+
+// decodeRows decodes the transactions from the rows, the last transaction is ignored if it's incomplete.
+func decodeRows(rows []chunk.Row) ([]*Txn, error) {
+	var txns []*Txn
+	var payload []byte
+	for i, row := range rows {
+		chunkID, chunks := row.GetInt64(2), row.GetInt64(3)
+		if chunkID == 0 {
+			payload = payload[:0]
+		} else if i == 0 || rows[i-1].GetUint64(1) != row.GetUint64(1) || rows[i-1].GetInt64(2) != chunkID-1 {
+			return nil, errors.Errorf("the binlog of the transaction %d is incomplete", row.GetUint64(1))
+		}
+		payload = append(payload, row.GetBytes(4)...)
+		if chunkID < chunks-1 {
+			continue
+		}
+		txn, err := DecodeTxn(row.GetUint64(1), row.GetUint64(0), payload)
+		if err != nil {
+			return nil, err
+		}
+		txns = append(txns, txn)
+	}
+	return txns, nil
+}
+
+// PurgedTS returns the purged ts, the readers can't read the transactions at or before it. It returns 0
+// if no source has been registered.
+func (r *Reader) PurgedTS(ctx context.Context) (purgedTS uint64, err error) {
+	err = r.withSnapshot(ctx, func(query queryFunc) error {
+		m, err := loadMeta(query, false)
+		purgedTS = m.purgedTS
+		return err
+	})
+	return purgedTS, err
+}
+
+// Rank returns the count of the transactions at or before ts, ts must not be after a readable transaction.
+func (r *Reader) Rank(ctx context.Context, ts uint64) (rank uint64, err error) {
+	err = r.withSnapshot(ctx, func(query queryFunc) error {
+		m, err := loadMeta(query, false)
+		if err != nil {
+			return err
+		}
+		rank, err = m.rank(query, ts)
+		return err
+	})
+	return rank, err
+}
+
+// Seek returns the commit ts of the transaction of the rank. It returns the purged ts if the rank is the
+// count of the purged transactions, and returns ok as false if the transaction is not readable yet.
+func (r *Reader) Seek(ctx context.Context, rank uint64) (commitTS uint64, ok bool, err error) {
+	err = r.withSnapshot(ctx, func(query queryFunc) error {
+		m, err := loadMeta(query, false)
+		if err != nil {
+			return err
+		}
+		if rank < m.purgedRank {
+			return ErrPurged
+		}
+		if rank == m.purgedRank {
+			commitTS, ok = m.purgedTS, true
+			return nil
+		}
+		wm, err := watermark(query)
+		if err != nil {
+			return err
+		}
+		rows, err := query(`SELECT commit_ts FROM mysql.tidb_binlog WHERE chunk = 0 AND commit_ts > %? AND commit_ts <= %?
+			ORDER BY commit_ts, start_ts LIMIT %?, 1`, m.purgedTS, wm, rank-m.purgedRank-1)
+		if err != nil || len(rows) == 0 {
+			return err
+		}
+		commitTS, ok = rows[0].GetUint64(0), true
+		return nil
+	})
+	return commitTS, ok, err
+}
+
+// withSnapshot runs fn with the statements reading the same snapshot.
+func (r *Reader) withSnapshot(ctx context.Context, fn func(query queryFunc) error) error {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	return withSession(r.pool, func(sctx sessionctx.Context) error {
+		ts, err := currentTS(sctx)
+		if err != nil {
+			return err
+		}
+		exec := sctx.GetRestrictedSQLExecutor()
+		opts := []sqlexec.OptionFuncAlias{sqlexec.ExecOptionUseCurSession, sqlexec.ExecOptionWithSnapshot(ts)}
+		return fn(func(sql string, args ...any) ([]chunk.Row, error) {
+			rows, _, err := exec.ExecRestrictedSQL(ctx, opts, sql, args...)
+			return rows, err
+		})
+	})
+}
@@ -0,0 +1,343 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogstore
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/config"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/tidb-binlog/memorypump"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+)
+
+const (
+	// flushInterval is the interval to write the resolved transactions of the pump.
+	flushInterval = 100 * time.Millisecond
+	// maintainInterval is the interval to remove the expired sources and purge the binlog.
+	maintainInterval = 10 * time.Second
+	// sourceTTL is the time after which a source which doesn't write is expired.
+	sourceTTL = time.Minute
+	// chunkSize is the max size of the binlog in a row of `mysql.tidb_binlog`, a transaction with larger
+	// binlog is split into multiple rows.
+	chunkSize = 1 << 20
+	// writeBatchSize is the size of the binlog after which the rows are written by a new statement.
+	writeBatchSize = 8 << 20
+	purgeBatchSize = 1024
+)
+
+// Writer writes the transactions of the memory pump of this TiDB instance into `mysql.tidb_binlog`.
+type Writer struct {
+	pool     sessionPool
+	instance string
+
+	// pump is the memory pump registered as a source, it's nil if no source is registered.
+	pump       *memorypump.Pump
+	maintained time.Time
+}
+
+// NewWriter creates a Writer, instance identifies the source of this TiDB instance.
+func NewWriter(pool sessionPool, instance string) *Writer {
+	return &Writer{
+		pool:     pool,
+		instance: instance,
+	}
+}
+
+// Run writes the binlog until the exit channel is closed.
+func (w *Writer) Run(exit <-chan struct{}) {
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnOthers)
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		if err := w.flush(ctx); err != nil {
+			logutil.BgLogger().Warn("failed to write binlog", zap.String("category", "binlog writer"), zap.Error(err))
+		}
+		select {
+		case <-exit:
+			if w.pump != nil {
+				if err := w.leave(ctx); err != nil {
+					logutil.BgLogger().Warn("failed to leave the binlog sources", zap.String("category", "binlog writer"), zap.Error(err))
+				}
+			}
+			logutil.BgLogger().Info("binlog writer exited")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Writer) flush(ctx context.Context) error {
+	pump := memorypump.GetPump()
+	if w.pump != nil && w.pump != pump {
+		if err := w.leave(ctx); err != nil {
+			return err
+		}
+	}
+	if pump == nil {
+		return nil
+	}
+	return withSession(w.pool, func(sctx sessionctx.Context) error {
+		if w.pump == nil {
+			if err := w.register(ctx, sctx, pump); err != nil {
+				return err
+			}
+			w.pump = pump
+		}
+		if err := w.write(ctx, sctx); err != nil {
+			return err
+		}
+		if time.Since(w.maintained) < maintainInterval {
+			return nil
+		}
+		w.maintained = time.Now()
+		return w.maintain(ctx, sctx)
+	})
+}
+
+// register registers the pump as a source.
+func (w *Writer) register(ctx context.Context, sctx sessionctx.Context, pump *memorypump.Pump) error {
+	return runInTxn(ctx, sctx, func(query queryFunc) error {
+		m, err := loadMeta(query, true)
+		if err != nil {
+			return err
+		}
+		ts, err := currentTS(sctx)
+		if err != nil {
+			return err
+		}
+		resolvedTS := ts
+		first := pump.FirstTS()
+		if first > 0 {
+			resolvedTS = min(ts, first-1)
+		}
+		purgedTS := m.purgedTS
+		if !m.found {
+			// The history of the binlog starts from the first source.
+			purgedTS = resolvedTS
+		} else if first > 0 {
+			// A reader never passes the last transaction in the binlog. The transactions committed before
+			// the source is registered may be committed before the position of a reader, so the binlog
+			// before them is incomplete.
+			rows, err := query("SELECT IFNULL(MAX(commit_ts), 0) FROM mysql.tidb_binlog")
+			if err != nil {
+				return err
+			}
+			if rows[0].GetUint64(0) >= first {
+				logutil.BgLogger().Warn("the binlog committed before the source is registered may be missed by the readers",
+					zap.String("category", "binlog writer"), zap.Uint64("firstTS", first), zap.Uint64("purgedTS", ts))
+				purgedTS = max(purgedTS, ts)
+			}
+		}
+		if err := savePurged(query, m, purgedTS); err != nil {
+			return err
+		}
+		_, err = query(`INSERT INTO mysql.tidb_binlog_sources (instance, resolved_ts, heartbeat_ts) VALUES (%?, %?, %?)
+			ON DUPLICATE KEY UPDATE resolved_ts = VALUES(resolved_ts), heartbeat_ts = VALUES(heartbeat_ts)`,
+			w.instance, resolvedTS, ts)
+		return err
+	})
+}
+
+// leave writes the buffered transactions of the registered pump and removes the source. The source is
+// kept if some transactions can't be written, so it expires and the readers know that the binlog is incomplete.
+func (w *Writer) leave(ctx context.Context) error {
+	pump := w.pump
+	pump.Close()
+	return withSession(w.pool, func(sctx sessionctx.Context) error {
+		if err := w.write(ctx, sctx); err != nil {
+			return err
+		}
+		w.pump = nil
+		if first := pump.FirstTS(); first > 0 {
+			logutil.BgLogger().Warn("leave the binlog sources with unfinished transactions",
+				zap.String("category", "binlog writer"), zap.Uint64("firstTS", first))
+			return nil
+		}
+		_, err := sessionQuery(ctx, sctx)("DELETE FROM mysql.tidb_binlog_sources WHERE instance = %?", w.instance)
+		return err
+	})
+}
+
+// write writes the resolved transactions of the registered pump, and then advances the resolved ts of the source.
+func (w *Writer) write(ctx context.Context, sctx sessionctx.Context) error {
+	var ts uint64
+	bins, resolvedTS, err := w.pump.Resolve(func() (uint64, error) {
+		var err error
+		ts, err = currentTS(sctx)
+		return ts, err
+	})
+	if err != nil {
+		return err
+	}
+	query := sessionQuery(ctx, sctx)
+	// The transactions are written again if some statements fail, INSERT IGNORE skips the rows written before.
+	if err := writeBinlogs(query, bins); err != nil {
+		return err
+	}
+	// The transactions are persisted, so they are not written again even if the source is expired.
+	w.pump.Ack(len(bins))
+	if _, err := query("UPDATE mysql.tidb_binlog_sources SET resolved_ts = GREATEST(resolved_ts, %?), heartbeat_ts = %? WHERE instance = %?",
+		resolvedTS, ts, w.instance); err != nil {
+		return err
+	}
+	if sctx.GetSessionVars().StmtCtx.AffectedRows() == 0 {
+		// The source is removed since it's expired, register it again.
+		w.pump = nil
+		return errors.New("the binlog source of this instance is expired")
+	}
+	return nil
+}
+
+// writeBinlogs writes the transactions into `mysql.tidb_binlog`. The rows are written by multiple statements
+// if they're large, the readers don't read them until the resolved ts of the source passes them.
+func writeBinlogs(query queryFunc, bins []*memorypump.Binlog) error {
+	var (
+		sql  strings.Builder
+		args []any
+		size int
+	)
+	flush := func() error {
+		if len(args) == 0 {
+			return nil
+		}
+		_, err := query(sql.String(), args...)
+		sql.Reset()
+		args, size = args[:0], 0
+		return err
+	}
+	for _, bin := range bins {
+		chunks := max(1, (len(bin.Payload)+chunkSize-1)/chunkSize)
+		for i := 0; i < chunks; i++ {
+			data := bin.Payload[min(len(bin.Payload), i*chunkSize):min(len(bin.Payload), (i+1)*chunkSize)]
+			if data == nil {
+				data = []byte{}
+			}
+			if len(args) == 0 {
+				sql.WriteString("INSERT IGNORE INTO mysql.tidb_binlog (commit_ts, start_ts, chunk, chunks, data) VALUES ")
+			} else {
+				sql.WriteString(", ")
+			}
+			sql.WriteString("(%?, %?, %?, %?, %?)")
+			args = append(args, bin.CommitTS, bin.StartTS, i, chunks, data)
+			if size += len(data); size >= writeBatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return flush()
+}
+
+// maintain removes the expired sources and purges the binlog older than the retention.
+func (w *Writer) maintain(ctx context.Context, sctx sessionctx.Context) error {
+	var purgedTS uint64
+	err := runInTxn(ctx, sctx, func(query queryFunc) error {
+		m, err := loadMeta(query, true)
+		if err != nil || !m.found {
+			return err
+		}
+		ts, err := currentTS(sctx)
+		if err != nil {
+			return err
+		}
+		purgedTS = m.purgedTS
+		expireTS := oracle.GoTimeToTS(oracle.GetTimeFromTS(ts).Add(-sourceTTL))
+		rows, err := query("SELECT instance FROM mysql.tidb_binlog_sources WHERE heartbeat_ts < %?", expireTS)
+		if err != nil {
+			return err
+		}
+		if len(rows) > 0 {
+			instances := make([]string, 0, len(rows))
+			for _, row := range rows {
+				instances = append(instances, row.GetString(0))
+			}
+			// The transactions buffered by the expired sources are lost.
+			logutil.BgLogger().Warn("remove the expired binlog sources, the binlog before now is incomplete",
+				zap.String("category", "binlog writer"), zap.Strings("instances", instances), zap.Uint64("purgedTS", ts))
+			if _, err := query("DELETE FROM mysql.tidb_binlog_sources WHERE heartbeat_ts < %?", expireTS); err != nil {
+				return err
+			}
+			purgedTS = max(purgedTS, ts)
+		}
+		if retention, _ := time.ParseDuration(config.GetGlobalConfig().Binlog.DumpSourceRetention); retention > 0 {
+			// The transactions after the watermark may not be written yet, they must not be purged.
+			wm, err := watermark(query)
+			if err != nil {
+				return err
+			}
+			purgedTS = max(purgedTS, min(wm, oracle.GoTimeToTS(oracle.GetTimeFromTS(ts).Add(-retention))))
+		}
+		return savePurged(query, m, purgedTS)
+	})
+	if err != nil || purgedTS == 0 {
+		return err
+	}
+
+	query := sessionQuery(ctx, sctx)
+	for {
+		if _, err := query("DELETE FROM mysql.tidb_binlog WHERE commit_ts <= %? LIMIT %?", purgedTS, purgeBatchSize); err != nil {
+			return err
+		}
+		if sctx.GetSessionVars().StmtCtx.AffectedRows() < purgeBatchSize {
+			return nil
+		}
+	}
+}
+
+// savePurged moves the purged ts forward, the count of the transactions before it is saved too, so the
+// rank of a transaction doesn't change after the binlog is purged.
+func savePurged(query queryFunc, m meta, purgedTS uint64) error {
+	if m.found && purgedTS <= m.purgedTS {
+		return nil
+	}
+	rank := m.purgedRank
+	if m.found {
+		var err error
+		if rank, err = m.rank(query, purgedTS); err != nil {
+			return err
+		}
+	}
+	_, err := query(`INSERT INTO mysql.tidb VALUES (%?, %?, %?), (%?, %?, %?)
+		ON DUPLICATE KEY UPDATE VARIABLE_VALUE = VALUES(VARIABLE_VALUE)`,
+		purgedTSVariable, purgedTS, "The binlog at or before this ts is purged.",
+		purgedRankVariable, rank, "The count of the transactions in the purged binlog.")
+	return err
+}
+
+// runInTxn runs fn in a pessimistic transaction of the session.
+func runInTxn(ctx context.Context, sctx sessionctx.Context, fn func(query queryFunc) error) (err error) {
+	query := sessionQuery(ctx, sctx)
+	if _, err = query("BEGIN PESSIMISTIC"); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_, _ = query("ROLLBACK")
+		}
+	}()
+	if err = fn(query); err != nil {
+		return err
+	}
+	_, err = query("COMMIT")
+	return err
+}
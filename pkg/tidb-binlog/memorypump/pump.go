@@ -0,0 +1,252 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memorypump
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/config"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	pb "github.com/pingcap/tipb/go-binlog"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// ErrClosed is returned when a transaction is prewritten after the pump is closed.
+var ErrClosed = errors.New("the memory pump is closed")
+
+// Binlog is the binlog of a committed transaction.
+type Binlog struct {
+	StartTS  uint64
+	CommitTS uint64
+	// Payload is the marshaled prewrite binlog of the transaction.
+	Payload []byte
+}
+
+// Pump is a pump running in the TiDB process. It receives the binlog written by the transactions of the
+// TiDB instance, and buffers the committed transactions until they are persisted by the binlog writer.
+//
+// A transaction is resolved only when all the transactions with smaller start ts are committed or rolled
+// back, so it's never followed by a transaction with a smaller commit ts, unless its commit ts is
+// allocated before its prewrite binlog is written. The resolved ts of the pump promises that all the
+// transactions committed by this instance at or before it are resolved.
+type Pump struct {
+	capacity uint64
+
+	mu sync.Mutex
+	// prewrites holds the prewrite binlog of the uncommitted transactions by start ts.
+	prewrites map[uint64][]byte
+	// committed holds the committed transactions that are not resolved yet.
+	committed []*Binlog
+	// resolved holds the resolved transactions ordered by commit ts, until they are acknowledged.
+	resolved []*Binlog
+	// size is the size of the binlog buffered by the pump.
+	size uint64
+	// arrivals is the count of the prewrite binlog received by the pump.
+	arrivals   uint64
+	resolvedTS uint64
+	closed     bool
+	// freed is closed when the buffered binlog is acknowledged or the pump is closed.
+	freed chan struct{}
+}
+
+var _ pb.PumpClient = (*Pump)(nil)
+
+// New creates a Pump which buffers at most capacity bytes of binlog, the transactions wait when it's full.
+func New(capacity uint64) *Pump {
+	return &Pump{
+		capacity:  capacity,
+		prewrites: make(map[uint64][]byte),
+		freed:     make(chan struct{}),
+	}
+}
+
+// WriteBinlog implements the pb.PumpClient interface.
+func (p *Pump) WriteBinlog(ctx context.Context, in *pb.WriteBinlogReq, _ ...grpc.CallOption) (*pb.WriteBinlogResp, error) {
+	bin := &pb.Binlog{}
+	if err := bin.Unmarshal(in.Payload); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	startTS := uint64(bin.StartTs)
+	switch bin.Tp {
+	case pb.BinlogType_Prewrite:
+		for p.size >= p.capacity && !p.closed {
+			freed := p.freed
+			p.mu.Unlock()
+			select {
+			case <-ctx.Done():
+				p.mu.Lock()
+				return nil, errors.Annotate(ctx.Err(), "the memory pump is full")
+			case <-freed:
+			}
+			p.mu.Lock()
+		}
+		if p.closed {
+			return nil, ErrClosed
+		}
+		p.prewrites[startTS] = in.Payload
+		p.size += uint64(len(in.Payload))
+		p.arrivals++
+	case pb.BinlogType_Commit:
+		prewrite, ok := p.prewrites[startTS]
+		if !ok {
+			logutil.BgLogger().Warn("commit binlog without prewrite binlog", zap.String("category", "memory pump"),
+				zap.Uint64("startTS", startTS), zap.Int64("commitTS", bin.CommitTs))
+			return &pb.WriteBinlogResp{}, nil
+		}
+		delete(p.prewrites, startTS)
+		p.committed = append(p.committed, &Binlog{StartTS: startTS, CommitTS: uint64(bin.CommitTs), Payload: prewrite})
+	case pb.BinlogType_Rollback:
+		if prewrite, ok := p.prewrites[startTS]; ok {
+			delete(p.prewrites, startTS)
+			p.free(uint64(len(prewrite)))
+		}
+	}
+	return &pb.WriteBinlogResp{}, nil
+}
+
+// PullBinlogs implements the pb.PumpClient interface.
+func (*Pump) PullBinlogs(context.Context, *pb.PullBinlogReq, ...grpc.CallOption) (pb.Pump_PullBinlogsClient, error) {
+	return nil, errors.New("pulling binlog from the memory pump is not supported")
+}
+
+// Resolve resolves the committed transactions whose commit ts are smaller than the start ts of all the
+// uncommitted transactions, and advances the resolved ts by the ts returned by getTS. It returns all the
+// resolved transactions which are not acknowledged yet in the order of commit ts.
+func (p *Pump) Resolve(getTS func() (uint64, error)) ([]*Binlog, uint64, error) {
+	p.mu.Lock()
+	arrivals := p.arrivals
+	p.mu.Unlock()
+	// The commit ts of a transaction is allocated after its prewrite binlog is written, so the transactions
+	// prewritten after the ts is fetched are committed after it.
+	ts, err := getTS()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// A transaction can't be alive longer than max-txn-ttl, the prewrite binlog is left only if the
+	// commit binlog is lost, it must not block the other transactions forever.
+	maxTxnTTL := time.Duration(config.GetGlobalConfig().Performance.MaxTxnTTL) * time.Millisecond
+	var watermark uint64 = math.MaxUint64
+	for startTS, prewrite := range p.prewrites {
+		if oracle.GetTimeFromTS(ts).Sub(oracle.GetTimeFromTS(startTS)) > maxTxnTTL {
+			logutil.BgLogger().Warn("drop the prewrite binlog of an expired transaction", zap.String("category", "memory pump"),
+				zap.Uint64("startTS", startTS))
+			delete(p.prewrites, startTS)
+			p.free(uint64(len(prewrite)))
+			continue
+		}
+		watermark = min(watermark, startTS)
+	}
+	sort.Slice(p.committed, func(i, j int) bool { return p.committed[i].CommitTS < p.committed[j].CommitTS })
+	n := sort.Search(len(p.committed), func(i int) bool { return p.committed[i].CommitTS >= watermark })
+	for _, bin := range p.committed[:n] {
+		if bin.CommitTS <= p.resolvedTS {
+			// It happens only if the commit ts of a transaction is allocated before its prewrite binlog
+			// is written. The transaction is still resolved, but the readers may miss it.
+			logutil.BgLogger().Warn("transaction is resolved out of order", zap.String("category", "memory pump"),
+				zap.Uint64("startTS", bin.StartTS), zap.Uint64("commitTS", bin.CommitTS))
+		}
+		p.resolved = append(p.resolved, bin)
+	}
+	p.committed = append(p.committed[:0], p.committed[n:]...)
+	if arrivals == p.arrivals {
+		p.resolvedTS = max(p.resolvedTS, min(ts, watermark-1))
+	}
+	return append([]*Binlog(nil), p.resolved...), p.resolvedTS, nil
+}
+
+// Ack releases the first n resolved transactions after they are persisted.
+func (p *Pump) Ack(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	n = min(n, len(p.resolved))
+	var size uint64
+	for _, bin := range p.resolved[:n] {
+		size += uint64(len(bin.Payload))
+	}
+	p.resolved = append(p.resolved[:0], p.resolved[n:]...)
+	p.free(size)
+}
+
+// FirstTS returns the min start ts of the transactions buffered by the pump, it returns 0 if there is
+// no transaction.
+func (p *Pump) FirstTS() uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var first uint64 = math.MaxUint64
+	for startTS := range p.prewrites {
+		first = min(first, startTS)
+	}
+	for _, bin := range p.committed {
+		first = min(first, bin.StartTS)
+	}
+	for _, bin := range p.resolved {
+		first = min(first, bin.StartTS)
+	}
+	if first == math.MaxUint64 {
+		return 0
+	}
+	return first
+}
+
+// Close makes the pump reject the new transactions, the transactions prewritten before are still
+// committed or rolled back.
+func (p *Pump) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.closed {
+		p.closed = true
+		p.free(0)
+	}
+}
+
+// free releases the size of buffered binlog and wakes up the transactions waiting for the space. It must
+// be called with p.mu held.
+func (p *Pump) free(size uint64) {
+	p.size -= size
+	close(p.freed)
+	p.freed = make(chan struct{})
+}
+
+var (
+	globalPump     *Pump
+	globalPumpLock sync.RWMutex
+)
+
+// GetPump returns the pump of the TiDB instance, it's nil if the pump is not enabled.
+func GetPump() *Pump {
+	globalPumpLock.RLock()
+	defer globalPumpLock.RUnlock()
+	return globalPump
+}
+
+// SetPump sets the pump of the TiDB instance.
+func SetPump(pump *Pump) {
+	globalPumpLock.Lock()
+	globalPump = pump
+	globalPumpLock.Unlock()
+}
@@ -0,0 +1,160 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memorypump
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/pingcap/tipb/go-binlog"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func writeBinlog(t *testing.T, p *Pump, bin *pb.Binlog) {
+	payload, err := bin.Marshal()
+	require.NoError(t, err)
+	_, err = p.WriteBinlog(context.Background(), &pb.WriteBinlogReq{Payload: payload})
+	require.NoError(t, err)
+}
+
+func prewrite(t *testing.T, p *Pump, startTS uint64, query string) {
+	writeBinlog(t, p, &pb.Binlog{Tp: pb.BinlogType_Prewrite, StartTs: int64(startTS), DdlQuery: []byte(query)})
+}
+
+func commit(t *testing.T, p *Pump, startTS, commitTS uint64) {
+	writeBinlog(t, p, &pb.Binlog{Tp: pb.BinlogType_Commit, StartTs: int64(startTS), CommitTs: int64(commitTS)})
+}
+
+func ts(physical int64) uint64 {
+	return oracle.ComposeTS(physical, 0)
+}
+
+func fixedTS(ts uint64) func() (uint64, error) {
+	return func() (uint64, error) { return ts, nil }
+}
+
+func queries(t *testing.T, bins []*Binlog) []string {
+	res := make([]string, 0, len(bins))
+	for _, bin := range bins {
+		prewrite := &pb.Binlog{}
+		require.NoError(t, prewrite.Unmarshal(bin.Payload))
+		res = append(res, string(prewrite.DdlQuery))
+	}
+	return res
+}
+
+func TestResolveInCommitOrder(t *testing.T) {
+	p := New(1 << 20)
+	prewrite(t, p, ts(1), "t1")
+	prewrite(t, p, ts(2), "t2")
+	prewrite(t, p, ts(3), "t3")
+
+	// The transaction of t2 can't be resolved before t1 is finished.
+	commit(t, p, ts(2), ts(5))
+	bins, resolvedTS, err := p.Resolve(fixedTS(ts(7)))
+	require.NoError(t, err)
+	require.Empty(t, bins)
+	require.Equal(t, ts(1)-1, resolvedTS)
+	require.Equal(t, ts(1), p.FirstTS())
+
+	commit(t, p, ts(1), ts(6))
+	// t3 is still uncommitted, so only the transactions committed before it started are resolved.
+	bins, resolvedTS, err = p.Resolve(fixedTS(ts(8)))
+	require.NoError(t, err)
+	require.Empty(t, bins)
+	require.Equal(t, ts(3)-1, resolvedTS)
+
+	writeBinlog(t, p, &pb.Binlog{Tp: pb.BinlogType_Rollback, StartTs: int64(ts(3))})
+	bins, resolvedTS, err = p.Resolve(fixedTS(ts(9)))
+	require.NoError(t, err)
+	require.Equal(t, []string{"t2", "t1"}, queries(t, bins))
+	require.Equal(t, ts(9), resolvedTS)
+
+	// The resolved transactions are returned until they are acknowledged.
+	p.Ack(1)
+	bins, _, err = p.Resolve(fixedTS(ts(10)))
+	require.NoError(t, err)
+	require.Equal(t, []string{"t1"}, queries(t, bins))
+	p.Ack(1)
+	require.Zero(t, p.FirstTS())
+}
+
+func TestResolvedTSWithNewPrewrite(t *testing.T) {
+	p := New(1 << 20)
+	_, resolvedTS, err := p.Resolve(fixedTS(ts(1)))
+	require.NoError(t, err)
+	require.Equal(t, ts(1), resolvedTS)
+
+	// A transaction prewritten while the ts is fetched may be committed before the ts.
+	_, resolvedTS, err = p.Resolve(func() (uint64, error) {
+		prewrite(t, p, ts(2), "ddl")
+		commit(t, p, ts(2), ts(3))
+		return ts(4), nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, ts(1), resolvedTS)
+	bins, resolvedTS, err := p.Resolve(fixedTS(ts(5)))
+	require.NoError(t, err)
+	require.Len(t, bins, 1)
+	require.Equal(t, ts(5), resolvedTS)
+}
+
+func TestWaitForSpace(t *testing.T) {
+	p := New(1)
+	prewrite(t, p, ts(1), "ddl")
+	commit(t, p, ts(1), ts(2))
+
+	// The buffer is full until the transaction is acknowledged.
+	payload, err := (&pb.Binlog{Tp: pb.BinlogType_Prewrite, StartTs: int64(ts(3))}).Marshal()
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = p.WriteBinlog(ctx, &pb.WriteBinlogReq{Payload: payload})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	bins, _, err := p.Resolve(fixedTS(ts(3)))
+	require.NoError(t, err)
+	require.Len(t, bins, 1)
+	done := make(chan error)
+	go func() {
+		_, err := p.WriteBinlog(context.Background(), &pb.WriteBinlogReq{Payload: payload})
+		done <- err
+	}()
+	p.Ack(1)
+	require.NoError(t, <-done)
+
+	p.Close()
+	_, err = p.WriteBinlog(context.Background(), &pb.WriteBinlogReq{Payload: payload})
+	require.ErrorIs(t, err, ErrClosed)
+}
+
+func TestDropExpiredPrewrite(t *testing.T) {
+	p := New(1 << 20)
+	// The commit binlog of this transaction is lost.
+	prewrite(t, p, ts(1), "lost")
+	prewrite(t, p, ts(2), "ddl")
+	commit(t, p, ts(2), ts(3))
+	bins, _, err := p.Resolve(fixedTS(ts(4)))
+	require.NoError(t, err)
+	require.Empty(t, bins)
+
+	later := ts(time.Now().Add(24 * time.Hour).UnixMilli())
+	bins, resolvedTS, err := p.Resolve(fixedTS(later))
+	require.NoError(t, err)
+	require.Len(t, bins, 1)
+	require.Equal(t, later, resolvedTS)
+}
@@ -219,6 +219,26 @@ func NewLocalPumpsClient(etcdURLs, binlogSocket string, timeout time.Duration, s
 	return newPumpsClient, nil
 }
 
+// NewInProcessPumpsClient returns a PumpsClient which writes binlog to a pump running in the TiDB process.
+func NewInProcessPumpsClient(client pb.PumpClient, timeout time.Duration) *PumpsClient {
+	ctx, cancel := context.WithCancel(context.Background())
+	newPumpsClient := &PumpsClient{
+		ctx:                ctx,
+		cancel:             cancel,
+		Pumps:              NewPumpInfos(),
+		Selector:           NewSelector(LocalUnix),
+		BinlogWriteTimeout: timeout,
+	}
+	pump := NewPumpStatus(&node.Status{
+		NodeID:  inProcessPump,
+		IsAlive: true,
+		State:   node.Online,
+	}, nil)
+	pump.Client = client
+	newPumpsClient.addPump(pump, true)
+	return newPumpsClient
+}
+
 // getLocalPumpStatus sync the local pump. For compatible with kafka version tidb-binlog.
 func (c *PumpsClient) syncLocalPumpStatus(_ context.Context) {
 	nodeStatus := &node.Status{
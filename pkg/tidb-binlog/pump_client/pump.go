@@ -36,6 +36,9 @@ var (
 	// localPump is used to write local pump through unix socket connection.
 	localPump = "localPump"
 
+	// inProcessPump is the pump running in the TiDB process.
+	inProcessPump = "inProcessPump"
+
 	// if pump failed more than defaultMaxErrNums times, this pump can treat as unavailable.
 	defaultMaxErrNums int64 = 10
 )
@@ -59,6 +59,7 @@ const (
 	KindMaxValue      byte = 16
 	KindRaw           byte = 17
 	KindMysqlJSON     byte = 18
+	KindVectorFloat32 byte = 19
 )
 
 // Datum is a data box holds different kind of data.
@@ -405,6 +406,21 @@ func (d *Datum) SetMysqlJSON(b BinaryJSON) {
 	d.b = b.Value
 }
 
+// GetVectorFloat32 gets VectorFloat32 value
+func (d *Datum) GetVectorFloat32() VectorFloat32 {
+	v, _, err := ZeroCopyDeserializeVectorFloat32(d.b)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// SetVectorFloat32 sets VectorFloat32 value
+func (d *Datum) SetVectorFloat32(vec VectorFloat32) {
+	d.k = KindVectorFloat32
+	d.b = vec.ZeroCopySerialize()
+}
+
 // GetMysqlTime gets types.Time value
 func (d *Datum) GetMysqlTime() Time {
 	return d.x.(Time)
@@ -479,6 +495,8 @@ func (d Datum) String() string {
 		t = "KindRaw"
 	case KindMysqlJSON:
 		t = "KindMysqlJSON"
+	case KindVectorFloat32:
+		t = "KindVectorFloat32"
 	default:
 		t = "Unknown"
 	}
@@ -524,6 +542,8 @@ func (d *Datum) GetValue() any {
 		return d.GetMysqlSet()
 	case KindMysqlJSON:
 		return d.GetMysqlJSON()
+	case KindVectorFloat32:
+		return d.GetVectorFloat32()
 	case KindMysqlTime:
 		return d.GetMysqlTime()
 	default:
@@ -572,6 +592,8 @@ func (d *Datum) SetValueWithDefaultCollation(val any) {
 		d.SetMysqlSet(x, mysql.DefaultCollationName)
 	case BinaryJSON:
 		d.SetMysqlJSON(x)
+	case VectorFloat32:
+		d.SetVectorFloat32(x)
 	case Time:
 		d.SetMysqlTime(x)
 	default:
@@ -620,6 +642,8 @@ func (d *Datum) SetValue(val any, tp *types.FieldType) {
 		d.SetMysqlSet(x, tp.GetCollate())
 	case BinaryJSON:
 		d.SetMysqlJSON(x)
+	case VectorFloat32:
+		d.SetVectorFloat32(x)
 	case Time:
 		d.SetMysqlTime(x)
 	default:
@@ -674,6 +698,8 @@ func (d *Datum) Compare(ctx Context, ad *Datum, comparer collate.Collator) (int,
 		return d.compareMysqlSet(ctx, ad.GetMysqlSet(), comparer)
 	case KindMysqlJSON:
 		return d.compareMysqlJSON(ad.GetMysqlJSON())
+	case KindVectorFloat32:
+		return d.compareVectorFloat32(ctx, ad.GetVectorFloat32())
 	case KindMysqlTime:
 		return d.compareMysqlTime(ctx, ad.GetMysqlTime())
 	default:
@@ -881,6 +907,25 @@ func (d *Datum) compareMysqlJSON(target BinaryJSON) (int, error) {
 	return CompareBinaryJSON(origin, target), nil
 }
 
+func (d *Datum) compareVectorFloat32(ctx Context, vec VectorFloat32) (int, error) {
+	switch d.k {
+	case KindNull, KindMinNotNull:
+		return -1, nil
+	case KindMaxValue:
+		return 1, nil
+	case KindVectorFloat32:
+		return d.GetVectorFloat32().Compare(vec), nil
+	case KindString, KindBytes:
+		origin, err := ParseVectorFloat32(d.GetString())
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		return origin.Compare(vec), nil
+	default:
+		return 0, errors.Errorf("cannot compare %v(type %T) with a vector", d.GetValue(), d.GetValue())
+	}
+}
+
 func (d *Datum) compareMysqlTime(ctx Context, time Time) (int, error) {
 	switch d.k {
 	case KindNull, KindMinNotNull:
@@ -937,6 +982,10 @@ func (d *Datum) ConvertTo(ctx Context, target *FieldType) (Datum, error) {
 		return d.convertToMysqlSet(ctx, target)
 	case mysql.TypeJSON:
 		return d.convertToMysqlJSON(target)
+	case mysql.TypeTiDBVectorFloat32:
+		return d.convertToVectorFloat32(ctx, target)
+	case mysql.TypeGeometry:
+		return d.convertToGeometry(ctx, target)
 	case mysql.TypeNull:
 		return Datum{}, nil
 	default:
@@ -1073,6 +1122,8 @@ func (d *Datum) convertToString(ctx Context, target *FieldType) (Datum, error) {
 		}
 	case KindMysqlJSON:
 		s = d.GetMysqlJSON().String()
+	case KindVectorFloat32:
+		s = d.GetVectorFloat32().String()
 	default:
 		return invalidConv(d, target.GetType())
 	}
@@ -1989,6 +2040,8 @@ func (d *Datum) ToString() (string, error) {
 		return d.GetMysqlSet().String(), nil
 	case KindMysqlJSON:
 		return d.GetMysqlJSON().String(), nil
+	case KindVectorFloat32:
+		return d.GetVectorFloat32().String(), nil
 	case KindBinaryLiteral, KindMysqlBit:
 		return d.GetBinaryLiteral().ToString(), nil
 	case KindNull:
@@ -2026,6 +2079,44 @@ func (d *Datum) ToHashKey() ([]byte, error) {
 	}
 }
 
+func (d *Datum) convertToVectorFloat32(_ Context, target *FieldType) (ret Datum, err error) {
+	var vec VectorFloat32
+	switch d.k {
+	case KindVectorFloat32:
+		vec = d.GetVectorFloat32()
+	case KindString, KindBytes:
+		if vec, err = ParseVectorFloat32(d.GetString()); err != nil {
+			return ret, errors.Trace(err)
+		}
+	default:
+		return invalidConv(d, target.GetType())
+	}
+	if err = vec.CheckDimsFitColumn(target.GetFlen()); err != nil {
+		return ret, errors.Trace(err)
+	}
+	ret.SetVectorFloat32(vec)
+	return ret, nil
+}
+
+// convertToGeometry checks the binary representation of a geometry, the geometry must be of the
+// subtype of the target.
+func (d *Datum) convertToGeometry(_ Context, target *FieldType) (ret Datum, err error) {
+	switch d.k {
+	case KindString, KindBytes:
+	default:
+		return invalidConv(d, target.GetType())
+	}
+	g, err := DecodeGeometry(d.GetBytes())
+	if err != nil {
+		return ret, ErrCantCreateGeometryObject.GenWithStackByArgs()
+	}
+	if tp := target.GetGeometryType(); tp != mysql.GeometryTypeGeometry && tp != g.Type {
+		return ret, ErrCantCreateGeometryObject.GenWithStackByArgs()
+	}
+	ret.SetBytes(d.GetBytes())
+	return ret, nil
+}
+
 // ToMysqlJSON is similar to convertToMysqlJSON, except the
 // latter parses from string, but the former uses it as primitive.
 func (d *Datum) ToMysqlJSON() (j BinaryJSON, err error) {
@@ -2206,6 +2297,12 @@ func NewJSONDatum(j BinaryJSON) (d Datum) {
 	return d
 }
 
+// NewVectorFloat32Datum creates a new Datum from a VectorFloat32 value
+func NewVectorFloat32Datum(v VectorFloat32) (d Datum) {
+	d.SetVectorFloat32(v)
+	return d
+}
+
 // NewBinaryLiteralDatum creates a new BinaryLiteral Datum for a BinaryLiteral value.
 func NewBinaryLiteralDatum(b BinaryLiteral) (d Datum) {
 	d.SetBinaryLiteral(b)
@@ -94,4 +94,14 @@ var (
 	ErrPartitionColumnStatsMissing = dbterror.ClassTypes.NewStd(mysql.ErrPartitionColumnStatsMissing)
 	// ErrIncorrectDatetimeValue is returned when the input value is in wrong format for datetime.
 	ErrIncorrectDatetimeValue = dbterror.ClassTypes.NewStd(mysql.ErrIncorrectDatetimeValue)
+	// ErrVectorDimensionNotFit is returned when the dimensions of a vector don't fit the vector column.
+	ErrVectorDimensionNotFit = dbterror.ClassTypes.NewStd(mysql.ErrVectorDimensionNotFit)
+	// ErrVectorDimensionMismatch is returned when the vectors in a calculation have different dimensions.
+	ErrVectorDimensionMismatch = dbterror.ClassTypes.NewStd(mysql.ErrVectorDimensionMismatch)
+	// ErrCantCreateGeometryObject is returned when the value of a geometry column isn't a valid geometry.
+	ErrCantCreateGeometryObject = dbterror.ClassTypes.NewStd(mysql.ErrCantCreateGeometryObject)
+	// ErrLongitudeOutOfRange is returned when the longitude of a geographic coordinate is out of range.
+	ErrLongitudeOutOfRange = dbterror.ClassTypes.NewStd(mysql.ErrLongitudeOutOfRange)
+	// ErrLatitudeOutOfRange is returned when the latitude of a geographic coordinate is out of range.
+	ErrLatitudeOutOfRange = dbterror.ClassTypes.NewStd(mysql.ErrLatitudeOutOfRange)
 )
@@ -157,6 +157,9 @@ func mergeEvalType(lhs, rhs EvalType, lft, rft *FieldType, isLHSUnsigned, isRHSU
 			rhs = lhs
 		}
 	}
+	if lhs == ETVectorFloat32 && rhs == ETVectorFloat32 {
+		return ETVectorFloat32
+	}
 	if lhs.IsStringKind() || rhs.IsStringKind() {
 		return ETString
 	} else if lhs == ETReal || rhs == ETReal {
@@ -337,6 +340,11 @@ func DefaultTypeForValue(value any, tp *FieldType, char string, collate string)
 		tp.SetDecimal(0)
 		tp.SetCharset(charset.CharsetUTF8MB4)
 		tp.SetCollate(charset.CollationUTF8MB4)
+	case VectorFloat32:
+		tp.SetType(mysql.TypeTiDBVectorFloat32)
+		tp.SetFlen(UnspecifiedLength)
+		tp.SetDecimal(0)
+		SetBinChsClnFlag(tp)
 	default:
 		tp.SetType(mysql.TypeUnspecified)
 		tp.SetFlen(UnspecifiedLength)
@@ -377,11 +385,29 @@ func DefaultCharsetForType(tp byte) (defaultCharset string, defaultCollation str
 // the result should be longlong. However, this function returns long for this case. Please use `AggFieldType`
 // function if you need to handle the range bump.
 func mergeFieldType(a byte, b byte) byte {
+	if a == mysql.TypeTiDBVectorFloat32 || b == mysql.TypeTiDBVectorFloat32 {
+		return mergeVectorFieldType(a, b)
+	}
 	ia := getFieldTypeIndex(a)
 	ib := getFieldTypeIndex(b)
 	return fieldTypeMergeRules[ia][ib]
 }
 
+// mergeVectorFieldType merges a vector type with another type. A vector is kept when it's
+// merged with a vector or NULL, otherwise it's merged like a string.
+func mergeVectorFieldType(a byte, b byte) byte {
+	if (a == mysql.TypeTiDBVectorFloat32 || a == mysql.TypeNull) && (b == mysql.TypeTiDBVectorFloat32 || b == mysql.TypeNull) {
+		return mysql.TypeTiDBVectorFloat32
+	}
+	if a == mysql.TypeTiDBVectorFloat32 {
+		a = mysql.TypeVarString
+	}
+	if b == mysql.TypeTiDBVectorFloat32 {
+		b = mysql.TypeVarString
+	}
+	return mergeFieldType(a, b)
+}
+
 // mergeTypeFlag merges two MySQL type flag to a new one
 // currently only NotNullFlag and UnsignedFlag is checked
 // todo more flag need to be checked
@@ -0,0 +1,632 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/binary"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+)
+
+// SpatialReferenceSystem is the spatial reference system (SRS) of the coordinates of a geometry.
+type SpatialReferenceSystem struct {
+	SRID uint32
+	Name string
+	// Geographic means the coordinates are longitudes and latitudes in degrees on an ellipsoid, the
+	// axis order of them in WKT and WKB is latitude-longitude. Otherwise the SRS is a Cartesian plane.
+	Geographic bool
+	// SemiMajorAxis and InverseFlattening define the ellipsoid of a geographic SRS.
+	SemiMajorAxis     float64
+	InverseFlattening float64
+}
+
+// spatialReferenceSystems are the supported spatial reference systems.
+var spatialReferenceSystems = map[uint32]*SpatialReferenceSystem{
+	0:    {SRID: 0},
+	3857: {SRID: 3857, Name: "WGS 84 / Pseudo-Mercator"},
+	4326: {SRID: 4326, Name: "WGS 84", Geographic: true, SemiMajorAxis: 6378137, InverseFlattening: 298.257223563},
+}
+
+// GetSpatialReferenceSystem returns the spatial reference system of the SRID, ok is false if it's
+// not supported.
+func GetSpatialReferenceSystem(srid uint32) (srs *SpatialReferenceSystem, ok bool) {
+	srs, ok = spatialReferenceSystems[srid]
+	return
+}
+
+// errInvalidGeometry is returned when the data isn't a valid geometry, callers convert it to the
+// error of the context, e.g. ErrGISInvalidData.
+var errInvalidGeometry = errors.New("invalid geometry")
+
+// IsInvalidGeometryErr returns true if the error is caused by invalid geometry data.
+func IsInvalidGeometryErr(err error) bool {
+	return errors.Cause(err) == errInvalidGeometry
+}
+
+// GeometryPoint is a point of a geometry, for a geographic SRS X is the longitude and Y is the latitude.
+type GeometryPoint struct {
+	X, Y float64
+}
+
+// Geometry is a spatial value. Its binary representation is the same as MySQL: the SRID as a
+// little-endian uint32, followed by the WKB of the geometry in little-endian, in which the
+// coordinates of a geographic SRS are in longitude-latitude order.
+type Geometry struct {
+	SRID uint32
+	// Type is the type of the geometry, e.g. mysql.GeometryTypePoint.
+	Type byte
+	// Points are the coordinates of a point or a linestring.
+	Points []GeometryPoint
+	// Rings are the exterior ring and the interior rings of a polygon.
+	Rings [][]GeometryPoint
+	// Geoms are the elements of a multi-geometry or a geometry collection.
+	Geoms []*Geometry
+}
+
+// NewGeometryPoint creates a point.
+func NewGeometryPoint(srid uint32, x, y float64) *Geometry {
+	return &Geometry{SRID: srid, Type: mysql.GeometryTypePoint, Points: []GeometryPoint{{X: x, Y: y}}}
+}
+
+// TypeName returns the name of the type of the geometry in upper case, e.g. "POINT".
+func (g *Geometry) TypeName() string {
+	return strings.ToUpper(mysql.GeometryTypeName(g.Type))
+}
+
+// IsEmpty returns true if the geometry is an empty geometry collection.
+func (g *Geometry) IsEmpty() bool {
+	if g.Type != mysql.GeometryTypeGeometryCollection {
+		return false
+	}
+	for _, e := range g.Geoms {
+		if !e.IsEmpty() {
+			return false
+		}
+	}
+	return true
+}
+
+// SetSRID sets the SRID of the geometry and its elements.
+func (g *Geometry) SetSRID(srid uint32) {
+	g.SRID = srid
+	for _, e := range g.Geoms {
+		e.SetSRID(srid)
+	}
+}
+
+// walkPoints calls fn for all the points of the geometry.
+func (g *Geometry) walkPoints(fn func(p *GeometryPoint)) {
+	for i := range g.Points {
+		fn(&g.Points[i])
+	}
+	for _, ring := range g.Rings {
+		for i := range ring {
+			fn(&ring[i])
+		}
+	}
+	for _, e := range g.Geoms {
+		e.walkPoints(fn)
+	}
+}
+
+// swapXY swaps the coordinates of all the points, it converts between the axis order of a
+// geographic SRS and the internal longitude-latitude order.
+func (g *Geometry) swapXY() {
+	g.walkPoints(func(p *GeometryPoint) {
+		p.X, p.Y = p.Y, p.X
+	})
+}
+
+func (g *Geometry) clone() *Geometry {
+	ret := &Geometry{SRID: g.SRID, Type: g.Type}
+	if g.Points != nil {
+		ret.Points = append([]GeometryPoint(nil), g.Points...)
+	}
+	for _, ring := range g.Rings {
+		ret.Rings = append(ret.Rings, append([]GeometryPoint(nil), ring...))
+	}
+	for _, e := range g.Geoms {
+		ret.Geoms = append(ret.Geoms, e.clone())
+	}
+	return ret
+}
+
+// isGeographic returns true if the SRS of the geometry is geographic.
+func (g *Geometry) isGeographic() bool {
+	srs, ok := GetSpatialReferenceSystem(g.SRID)
+	return ok && srs.Geographic
+}
+
+// CheckGeographicRange checks the longitudes and latitudes of a geometry of a geographic SRS.
+// funcName is used in the error message.
+func (g *Geometry) CheckGeographicRange(funcName string) (err error) {
+	g.walkPoints(func(p *GeometryPoint) {
+		if err != nil {
+			return
+		}
+		if p.X <= -180 || p.X > 180 {
+			err = ErrLongitudeOutOfRange.GenWithStackByArgs(p.X, funcName, -180.0, 180.0)
+		} else if p.Y < -90 || p.Y > 90 {
+			err = ErrLatitudeOutOfRange.GenWithStackByArgs(p.Y, funcName, -90.0, 90.0)
+		}
+	})
+	return err
+}
+
+// validate checks the structure of the geometry, e.g. the rings of a polygon must be closed.
+func (g *Geometry) validate() error {
+	valid := true
+	g.walkPoints(func(p *GeometryPoint) {
+		if math.IsNaN(p.X) || math.IsInf(p.X, 0) || math.IsNaN(p.Y) || math.IsInf(p.Y, 0) {
+			valid = false
+		}
+	})
+	if !valid {
+		return errInvalidGeometry
+	}
+	switch g.Type {
+	case mysql.GeometryTypePoint:
+		valid = len(g.Points) == 1
+	case mysql.GeometryTypeLineString:
+		valid = len(g.Points) >= 2
+	case mysql.GeometryTypePolygon:
+		valid = len(g.Rings) > 0
+		for _, ring := range g.Rings {
+			if len(ring) < 4 || ring[0] != ring[len(ring)-1] {
+				valid = false
+			}
+		}
+	case mysql.GeometryTypeMultiPoint, mysql.GeometryTypeMultiLineString, mysql.GeometryTypeMultiPolygon:
+		valid = len(g.Geoms) > 0
+		for _, e := range g.Geoms {
+			if e.Type != g.Type-3 {
+				valid = false
+			}
+		}
+	case mysql.GeometryTypeGeometryCollection:
+	default:
+		valid = false
+	}
+	if !valid {
+		return errInvalidGeometry
+	}
+	for _, e := range g.Geoms {
+		if err := e.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeGeometry decodes a geometry from its binary representation.
+func DecodeGeometry(b []byte) (*Geometry, error) {
+	if len(b) < 4 {
+		return nil, errInvalidGeometry
+	}
+	srid := binary.LittleEndian.Uint32(b)
+	g, rest, err := decodeWKB(b[4:])
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, errInvalidGeometry
+	}
+	g.SetSRID(srid)
+	if err = g.validate(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// Encode returns the binary representation of the geometry.
+func (g *Geometry) Encode() []byte {
+	b := binary.LittleEndian.AppendUint32(make([]byte, 0, 32), g.SRID)
+	return g.appendWKB(b)
+}
+
+// ParseGeometryFromWKB parses a geometry from its WKB, the coordinates of a geographic SRS are in
+// latitude-longitude order.
+func ParseGeometryFromWKB(wkb []byte, srid uint32) (*Geometry, error) {
+	g, rest, err := decodeWKB(wkb)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, errInvalidGeometry
+	}
+	g.SetSRID(srid)
+	if err = g.validate(); err != nil {
+		return nil, err
+	}
+	if g.isGeographic() {
+		g.swapXY()
+	}
+	return g, nil
+}
+
+// WKB returns the WKB of the geometry, the coordinates of a geographic SRS are in
+// latitude-longitude order.
+func (g *Geometry) WKB() []byte {
+	if g.isGeographic() {
+		g = g.clone()
+		g.swapXY()
+	}
+	return g.appendWKB(nil)
+}
+
+func decodeWKB(b []byte) (g *Geometry, rest []byte, err error) {
+	if len(b) < 5 {
+		return nil, nil, errInvalidGeometry
+	}
+	var order binary.ByteOrder
+	switch b[0] {
+	case 0:
+		order = binary.BigEndian
+	case 1:
+		order = binary.LittleEndian
+	default:
+		return nil, nil, errInvalidGeometry
+	}
+	tp := order.Uint32(b[1:])
+	if tp < uint32(mysql.GeometryTypePoint) || tp > uint32(mysql.GeometryTypeGeometryCollection) {
+		return nil, nil, errInvalidGeometry
+	}
+	g = &Geometry{Type: byte(tp)}
+	b = b[5:]
+	readUint32 := func() (uint32, bool) {
+		if len(b) < 4 {
+			return 0, false
+		}
+		v := order.Uint32(b)
+		b = b[4:]
+		return v, true
+	}
+	readPoints := func(n uint32) ([]GeometryPoint, bool) {
+		if uint64(len(b)) < uint64(n)*16 {
+			return nil, false
+		}
+		points := make([]GeometryPoint, n)
+		for i := range points {
+			points[i].X = math.Float64frombits(order.Uint64(b))
+			points[i].Y = math.Float64frombits(order.Uint64(b[8:]))
+			b = b[16:]
+		}
+		return points, true
+	}
+	ok := true
+	switch g.Type {
+	case mysql.GeometryTypePoint:
+		g.Points, ok = readPoints(1)
+	case mysql.GeometryTypeLineString:
+		var n uint32
+		if n, ok = readUint32(); ok {
+			g.Points, ok = readPoints(n)
+		}
+	case mysql.GeometryTypePolygon:
+		var n uint32
+		if n, ok = readUint32(); ok {
+			for i := uint32(0); i < n && ok; i++ {
+				var cnt uint32
+				var ring []GeometryPoint
+				if cnt, ok = readUint32(); ok {
+					ring, ok = readPoints(cnt)
+					g.Rings = append(g.Rings, ring)
+				}
+			}
+		}
+	default:
+		var n uint32
+		if n, ok = readUint32(); ok {
+			for i := uint32(0); i < n; i++ {
+				var e *Geometry
+				if e, b, err = decodeWKB(b); err != nil {
+					return nil, nil, err
+				}
+				g.Geoms = append(g.Geoms, e)
+			}
+		}
+	}
+	if !ok {
+		return nil, nil, errInvalidGeometry
+	}
+	return g, b, nil
+}
+
+func (g *Geometry) appendWKB(b []byte) []byte {
+	b = append(b, 1)
+	b = binary.LittleEndian.AppendUint32(b, uint32(g.Type))
+	appendPoints := func(points []GeometryPoint) {
+		for _, p := range points {
+			b = binary.LittleEndian.AppendUint64(b, math.Float64bits(p.X))
+			b = binary.LittleEndian.AppendUint64(b, math.Float64bits(p.Y))
+		}
+	}
+	switch g.Type {
+	case mysql.GeometryTypePoint:
+		appendPoints(g.Points)
+	case mysql.GeometryTypeLineString:
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(g.Points)))
+		appendPoints(g.Points)
+	case mysql.GeometryTypePolygon:
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(g.Rings)))
+		for _, ring := range g.Rings {
+			b = binary.LittleEndian.AppendUint32(b, uint32(len(ring)))
+			appendPoints(ring)
+		}
+	default:
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(g.Geoms)))
+		for _, e := range g.Geoms {
+			b = e.appendWKB(b)
+		}
+	}
+	return b
+}
+
+// ParseGeometryFromWKT parses a geometry from its WKT, the coordinates of a geographic SRS are in
+// latitude-longitude order.
+func ParseGeometryFromWKT(wkt string, srid uint32) (*Geometry, error) {
+	p := &wktParser{s: wkt}
+	g, err := p.parseGeometry()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos != len(p.s) {
+		return nil, errInvalidGeometry
+	}
+	g.SetSRID(srid)
+	if err = g.validate(); err != nil {
+		return nil, err
+	}
+	if g.isGeographic() {
+		g.swapXY()
+	}
+	return g, nil
+}
+
+type wktParser struct {
+	s   string
+	pos int
+}
+
+func (p *wktParser) skipSpaces() {
+	for p.pos < len(p.s) && unicode.IsSpace(rune(p.s[p.pos])) {
+		p.pos++
+	}
+}
+
+// consume consumes the byte c after spaces, it returns false if the next byte isn't c.
+func (p *wktParser) consume(c byte) bool {
+	p.skipSpaces()
+	if p.pos < len(p.s) && p.s[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *wktParser) parseWord() string {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.s) && (unicode.IsLetter(rune(p.s[p.pos]))) {
+		p.pos++
+	}
+	return strings.ToUpper(p.s[start:p.pos])
+}
+
+func (p *wktParser) parseNumber() (float64, error) {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.s) && strings.IndexByte("+-.0123456789eE", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+	f, err := strconv.ParseFloat(p.s[start:p.pos], 64)
+	if err != nil {
+		return 0, errInvalidGeometry
+	}
+	return f, nil
+}
+
+func (p *wktParser) parsePoint() (pt GeometryPoint, err error) {
+	if pt.X, err = p.parseNumber(); err != nil {
+		return
+	}
+	pt.Y, err = p.parseNumber()
+	return
+}
+
+// parsePoints parses a parenthesized list of points, e.g. `(0 0, 1 1)`.
+func (p *wktParser) parsePoints() ([]GeometryPoint, error) {
+	if !p.consume('(') {
+		return nil, errInvalidGeometry
+	}
+	var points []GeometryPoint
+	for {
+		pt, err := p.parsePoint()
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, pt)
+		if !p.consume(',') {
+			break
+		}
+	}
+	if !p.consume(')') {
+		return nil, errInvalidGeometry
+	}
+	return points, nil
+}
+
+// parseList parses a parenthesized list of elements, e.g. `((0 0, 1 1), (2 2, 3 3))`.
+func (p *wktParser) parseList(parseElem func() error) error {
+	if !p.consume('(') {
+		return errInvalidGeometry
+	}
+	for {
+		if err := parseElem(); err != nil {
+			return err
+		}
+		if !p.consume(',') {
+			break
+		}
+	}
+	if !p.consume(')') {
+		return errInvalidGeometry
+	}
+	return nil
+}
+
+func (p *wktParser) parseGeometry() (*Geometry, error) {
+	var err error
+	g := &Geometry{}
+	switch p.parseWord() {
+	case "POINT":
+		g.Type = mysql.GeometryTypePoint
+		g.Points, err = p.parsePoints()
+	case "LINESTRING":
+		g.Type = mysql.GeometryTypeLineString
+		g.Points, err = p.parsePoints()
+	case "POLYGON":
+		g.Type = mysql.GeometryTypePolygon
+		err = p.parseList(func() error {
+			ring, err := p.parsePoints()
+			g.Rings = append(g.Rings, ring)
+			return err
+		})
+	case "MULTIPOINT":
+		g.Type = mysql.GeometryTypeMultiPoint
+		err = p.parseList(func() error {
+			// The points of a multipoint may be parenthesized or not.
+			var pt GeometryPoint
+			var err error
+			if p.consume('(') {
+				if pt, err = p.parsePoint(); err == nil && !p.consume(')') {
+					err = errInvalidGeometry
+				}
+			} else {
+				pt, err = p.parsePoint()
+			}
+			g.Geoms = append(g.Geoms, &Geometry{Type: mysql.GeometryTypePoint, Points: []GeometryPoint{pt}})
+			return err
+		})
+	case "MULTILINESTRING":
+		g.Type = mysql.GeometryTypeMultiLineString
+		err = p.parseList(func() error {
+			points, err := p.parsePoints()
+			g.Geoms = append(g.Geoms, &Geometry{Type: mysql.GeometryTypeLineString, Points: points})
+			return err
+		})
+	case "MULTIPOLYGON":
+		g.Type = mysql.GeometryTypeMultiPolygon
+		err = p.parseList(func() error {
+			polygon := &Geometry{Type: mysql.GeometryTypePolygon}
+			g.Geoms = append(g.Geoms, polygon)
+			return p.parseList(func() error {
+				ring, err := p.parsePoints()
+				polygon.Rings = append(polygon.Rings, ring)
+				return err
+			})
+		})
+	case "GEOMETRYCOLLECTION", "GEOMCOLLECTION":
+		g.Type = mysql.GeometryTypeGeometryCollection
+		if p.parseWord() == "EMPTY" {
+			break
+		}
+		start := p.pos
+		if p.consume('(') && p.consume(')') {
+			break
+		}
+		p.pos = start
+		err = p.parseList(func() error {
+			e, err := p.parseGeometry()
+			g.Geoms = append(g.Geoms, e)
+			return err
+		})
+	default:
+		err = errInvalidGeometry
+	}
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// WKT returns the WKT of the geometry, the coordinates of a geographic SRS are in
+// latitude-longitude order.
+func (g *Geometry) WKT() string {
+	var sb strings.Builder
+	swap := g.isGeographic()
+	g.writeWKT(&sb, swap, true)
+	return sb.String()
+}
+
+func (g *Geometry) writeWKT(sb *strings.Builder, swap, withType bool) {
+	writePoint := func(p GeometryPoint) {
+		if swap {
+			p.X, p.Y = p.Y, p.X
+		}
+		sb.WriteString(strconv.FormatFloat(p.X, 'g', -1, 64))
+		sb.WriteByte(' ')
+		sb.WriteString(strconv.FormatFloat(p.Y, 'g', -1, 64))
+	}
+	writePoints := func(points []GeometryPoint) {
+		sb.WriteByte('(')
+		for i, p := range points {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			writePoint(p)
+		}
+		sb.WriteByte(')')
+	}
+	if withType {
+		if g.Type == mysql.GeometryTypeGeometryCollection {
+			sb.WriteString("GEOMETRYCOLLECTION")
+		} else {
+			sb.WriteString(g.TypeName())
+		}
+	}
+	switch g.Type {
+	case mysql.GeometryTypePoint, mysql.GeometryTypeLineString:
+		writePoints(g.Points)
+	case mysql.GeometryTypePolygon:
+		sb.WriteByte('(')
+		for i, ring := range g.Rings {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			writePoints(ring)
+		}
+		sb.WriteByte(')')
+	default:
+		if len(g.Geoms) == 0 {
+			sb.WriteString(" EMPTY")
+			return
+		}
+		sb.WriteByte('(')
+		for i, e := range g.Geoms {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			e.writeWKT(sb, swap, g.Type == mysql.GeometryTypeGeometryCollection)
+		}
+		sb.WriteByte(')')
+	}
+}
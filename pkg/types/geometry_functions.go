@@ -0,0 +1,529 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"math"
+
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+)
+
+// The spatial relations and distances are computed in the plane of the coordinates. For a
+// geographic SRS it's an approximation which is accurate enough for small geometries, except the
+// distances, which are computed on the ellipsoid or the sphere.
+
+// geometryComponents are the basic geometries which a geometry consists of.
+type geometryComponents struct {
+	points   []GeometryPoint
+	lines    [][]GeometryPoint
+	polygons [][][]GeometryPoint
+}
+
+func (g *Geometry) components() *geometryComponents {
+	c := &geometryComponents{}
+	var walk func(g *Geometry)
+	walk = func(g *Geometry) {
+		switch g.Type {
+		case mysql.GeometryTypePoint:
+			c.points = append(c.points, g.Points[0])
+		case mysql.GeometryTypeLineString:
+			c.lines = append(c.lines, g.Points)
+		case mysql.GeometryTypePolygon:
+			c.polygons = append(c.polygons, g.Rings)
+		default:
+			for _, e := range g.Geoms {
+				walk(e)
+			}
+		}
+	}
+	walk(g)
+	return c
+}
+
+// OnlyPoints returns true if the geometry is a point or a multipoint.
+func (g *Geometry) OnlyPoints() bool {
+	return g.Type == mysql.GeometryTypePoint || g.Type == mysql.GeometryTypeMultiPoint
+}
+
+func cross(o, a, b GeometryPoint) float64 {
+	return (a.X-o.X)*(b.Y-o.Y) - (a.Y-o.Y)*(b.X-o.X)
+}
+
+// onSegment returns true if p is on the segment ab.
+func onSegment(p, a, b GeometryPoint) bool {
+	return cross(a, b, p) == 0 &&
+		math.Min(a.X, b.X) <= p.X && p.X <= math.Max(a.X, b.X) &&
+		math.Min(a.Y, b.Y) <= p.Y && p.Y <= math.Max(a.Y, b.Y)
+}
+
+func sign(f float64) int {
+	if f > 0 {
+		return 1
+	} else if f < 0 {
+		return -1
+	}
+	return 0
+}
+
+// segmentsIntersect returns true if the segments ab and cd have a common point.
+func segmentsIntersect(a, b, c, d GeometryPoint) bool {
+	d1, d2 := sign(cross(c, d, a)), sign(cross(c, d, b))
+	d3, d4 := sign(cross(a, b, c)), sign(cross(a, b, d))
+	if d1*d2 < 0 && d3*d4 < 0 {
+		return true
+	}
+	return onSegment(a, c, d) || onSegment(b, c, d) || onSegment(c, a, b) || onSegment(d, a, b)
+}
+
+// segmentsCross returns true if the interiors of the segments ab and cd cross at a single point.
+func segmentsCross(a, b, c, d GeometryPoint) bool {
+	return sign(cross(c, d, a))*sign(cross(c, d, b)) < 0 && sign(cross(a, b, c))*sign(cross(a, b, d)) < 0
+}
+
+// pointInRing returns 1 if p is inside the ring, 0 if it's on the ring and -1 if it's outside.
+func pointInRing(p GeometryPoint, ring []GeometryPoint) int {
+	inside := false
+	for i := 1; i < len(ring); i++ {
+		a, b := ring[i-1], ring[i]
+		if onSegment(p, a, b) {
+			return 0
+		}
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+			inside = !inside
+		}
+	}
+	if inside {
+		return 1
+	}
+	return -1
+}
+
+// pointInPolygon returns 1 if p is in the interior of the polygon, 0 if it's on the boundary and
+// -1 if it's outside.
+func pointInPolygon(p GeometryPoint, rings [][]GeometryPoint) int {
+	r := pointInRing(p, rings[0])
+	if r <= 0 {
+		return r
+	}
+	for _, hole := range rings[1:] {
+		switch pointInRing(p, hole) {
+		case 0:
+			return 0
+		case 1:
+			return -1
+		}
+	}
+	return 1
+}
+
+func forEachSegment(points []GeometryPoint, fn func(a, b GeometryPoint) bool) bool {
+	for i := 1; i < len(points); i++ {
+		if fn(points[i-1], points[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func forEachEdge(rings [][]GeometryPoint, fn func(a, b GeometryPoint) bool) bool {
+	for _, ring := range rings {
+		if forEachSegment(ring, fn) {
+			return true
+		}
+	}
+	return false
+}
+
+func midPoint(a, b GeometryPoint) GeometryPoint {
+	return GeometryPoint{X: (a.X + b.X) / 2, Y: (a.Y + b.Y) / 2}
+}
+
+func lineIntersectsPolygon(line []GeometryPoint, rings [][]GeometryPoint) bool {
+	for _, p := range line {
+		if pointInPolygon(p, rings) >= 0 {
+			return true
+		}
+	}
+	return forEachSegment(line, func(a, b GeometryPoint) bool {
+		return forEachEdge(rings, func(c, d GeometryPoint) bool {
+			return segmentsIntersect(a, b, c, d)
+		})
+	})
+}
+
+func polygonsIntersect(p1, p2 [][]GeometryPoint) bool {
+	return lineIntersectsPolygon(p1[0], p2) || pointInPolygon(p2[0][0], p1) >= 0
+}
+
+// Intersects returns true if the geometries have a common point.
+func (g *Geometry) Intersects(other *Geometry) bool {
+	c1, c2 := g.components(), other.components()
+	for _, p := range c1.points {
+		if c2.coverPoint(p) {
+			return true
+		}
+	}
+	for _, line := range c1.lines {
+		for _, p := range c2.points {
+			if pointOnLine(p, line) {
+				return true
+			}
+		}
+		for _, line2 := range c2.lines {
+			if forEachSegment(line, func(a, b GeometryPoint) bool {
+				return forEachSegment(line2, func(c, d GeometryPoint) bool {
+					return segmentsIntersect(a, b, c, d)
+				})
+			}) {
+				return true
+			}
+		}
+		for _, polygon := range c2.polygons {
+			if lineIntersectsPolygon(line, polygon) {
+				return true
+			}
+		}
+	}
+	for _, polygon := range c1.polygons {
+		for _, p := range c2.points {
+			if pointInPolygon(p, polygon) >= 0 {
+				return true
+			}
+		}
+		for _, line := range c2.lines {
+			if lineIntersectsPolygon(line, polygon) {
+				return true
+			}
+		}
+		for _, polygon2 := range c2.polygons {
+			if polygonsIntersect(polygon, polygon2) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func pointOnLine(p GeometryPoint, line []GeometryPoint) bool {
+	return forEachSegment(line, func(a, b GeometryPoint) bool {
+		return onSegment(p, a, b)
+	})
+}
+
+// coverPoint returns true if p is a point of the components.
+func (c *geometryComponents) coverPoint(p GeometryPoint) bool {
+	covered, _ := c.coverPointWithInterior(p)
+	return covered
+}
+
+// coverPointWithInterior returns whether p is a point of the components, and whether it's in the
+// interior of them.
+func (c *geometryComponents) coverPointWithInterior(p GeometryPoint) (covered, interior bool) {
+	for _, polygon := range c.polygons {
+		switch pointInPolygon(p, polygon) {
+		case 1:
+			return true, true
+		case 0:
+			covered = true
+		}
+	}
+	for _, line := range c.lines {
+		if pointOnLine(p, line) {
+			covered = true
+			// The boundary of a linestring is its endpoints, unless it's closed.
+			first, last := line[0], line[len(line)-1]
+			if first == last || (p != first && p != last) {
+				interior = true
+			}
+		}
+	}
+	for _, q := range c.points {
+		if p == q {
+			return true, true
+		}
+	}
+	return covered, interior
+}
+
+// coverLineByPolygon returns whether the line is in the polygon, and whether it intersects the
+// interior of the polygon.
+func coverLineByPolygon(line []GeometryPoint, polygon [][]GeometryPoint) (covered, interior bool) {
+	check := func(p GeometryPoint) bool {
+		r := pointInPolygon(p, polygon)
+		interior = interior || r == 1
+		return r < 0
+	}
+	for i, p := range line {
+		if check(p) || (i > 0 && check(midPoint(line[i-1], p))) {
+			return false, false
+		}
+	}
+	crossed := forEachSegment(line, func(a, b GeometryPoint) bool {
+		return forEachEdge(polygon, func(c, d GeometryPoint) bool {
+			return segmentsCross(a, b, c, d)
+		})
+	})
+	return !crossed, interior && !crossed
+}
+
+// coverLineWithInterior returns whether the line is in the components, and whether it intersects
+// the interior of them.
+func (c *geometryComponents) coverLineWithInterior(line []GeometryPoint) (covered, interior bool) {
+	for _, polygon := range c.polygons {
+		if covered, interior = coverLineByPolygon(line, polygon); covered {
+			return
+		}
+	}
+	for _, line2 := range c.lines {
+		if !forEachSegment(line, func(a, b GeometryPoint) bool {
+			return !forEachSegment(line2, func(c, d GeometryPoint) bool {
+				return onSegment(a, c, d) && onSegment(b, c, d)
+			})
+		}) {
+			return true, true
+		}
+	}
+	return false, false
+}
+
+// coverPolygon returns true if the polygon is in the components.
+func (c *geometryComponents) coverPolygon(polygon [][]GeometryPoint) bool {
+	for _, outer := range c.polygons {
+		if covered, _ := coverLineByPolygon(polygon[0], outer); !covered {
+			continue
+		}
+		// A hole of the outer polygon mustn't be in the polygon.
+		holeInside := false
+		for _, hole := range outer[1:] {
+			for _, p := range hole {
+				if pointInPolygon(p, polygon) == 1 {
+					holeInside = true
+				}
+			}
+		}
+		if !holeInside {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains returns true if no points of the other geometry lie in the exterior of the geometry,
+// and at least one point of the interior of the other geometry lies in the interior of the geometry.
+func (g *Geometry) Contains(other *Geometry) bool {
+	c, oc := g.components(), other.components()
+	interior := false
+	for _, p := range oc.points {
+		covered, in := c.coverPointWithInterior(p)
+		if !covered {
+			return false
+		}
+		interior = interior || in
+	}
+	for _, line := range oc.lines {
+		covered, in := c.coverLineWithInterior(line)
+		if !covered {
+			return false
+		}
+		interior = interior || in
+	}
+	for _, polygon := range oc.polygons {
+		if !c.coverPolygon(polygon) {
+			return false
+		}
+		interior = true
+	}
+	return interior
+}
+
+func pointDistance(p, q GeometryPoint) float64 {
+	return math.Hypot(p.X-q.X, p.Y-q.Y)
+}
+
+func pointSegmentDistance(p, a, b GeometryPoint) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	if dx == 0 && dy == 0 {
+		return pointDistance(p, a)
+	}
+	t := ((p.X-a.X)*dx + (p.Y-a.Y)*dy) / (dx*dx + dy*dy)
+	t = math.Max(0, math.Min(1, t))
+	return pointDistance(p, GeometryPoint{X: a.X + t*dx, Y: a.Y + t*dy})
+}
+
+func segmentDistance(a, b, c, d GeometryPoint) float64 {
+	if segmentsIntersect(a, b, c, d) {
+		return 0
+	}
+	return math.Min(math.Min(pointSegmentDistance(a, c, d), pointSegmentDistance(b, c, d)),
+		math.Min(pointSegmentDistance(c, a, b), pointSegmentDistance(d, a, b)))
+}
+
+// pointLineDistance returns the distance between a point and a linestring or the rings of a polygon.
+func pointLineDistance(p GeometryPoint, lines ...[]GeometryPoint) float64 {
+	dist := math.Inf(1)
+	for _, line := range lines {
+		forEachSegment(line, func(a, b GeometryPoint) bool {
+			dist = math.Min(dist, pointSegmentDistance(p, a, b))
+			return false
+		})
+	}
+	return dist
+}
+
+func lineLineDistance(line []GeometryPoint, lines ...[]GeometryPoint) float64 {
+	dist := math.Inf(1)
+	for _, line2 := range lines {
+		forEachSegment(line, func(a, b GeometryPoint) bool {
+			forEachSegment(line2, func(c, d GeometryPoint) bool {
+				dist = math.Min(dist, segmentDistance(a, b, c, d))
+				return false
+			})
+			return false
+		})
+	}
+	return dist
+}
+
+// Distance returns the Cartesian distance between the geometries, the geometries mustn't be empty.
+func (g *Geometry) Distance(other *Geometry) float64 {
+	c1, c2 := g.components(), other.components()
+	dist := math.Inf(1)
+	for _, p := range c1.points {
+		for _, q := range c2.points {
+			dist = math.Min(dist, pointDistance(p, q))
+		}
+		dist = math.Min(dist, pointLineDistance(p, c2.lines...))
+		for _, polygon := range c2.polygons {
+			if pointInPolygon(p, polygon) >= 0 {
+				return 0
+			}
+			dist = math.Min(dist, pointLineDistance(p, polygon...))
+		}
+	}
+	for _, line := range c1.lines {
+		for _, q := range c2.points {
+			dist = math.Min(dist, pointLineDistance(q, line))
+		}
+		dist = math.Min(dist, lineLineDistance(line, c2.lines...))
+		for _, polygon := range c2.polygons {
+			if lineIntersectsPolygon(line, polygon) {
+				return 0
+			}
+			dist = math.Min(dist, lineLineDistance(line, polygon...))
+		}
+	}
+	for _, polygon := range c1.polygons {
+		for _, q := range c2.points {
+			if pointInPolygon(q, polygon) >= 0 {
+				return 0
+			}
+			dist = math.Min(dist, pointLineDistance(q, polygon...))
+		}
+		for _, line := range c2.lines {
+			if lineIntersectsPolygon(line, polygon) {
+				return 0
+			}
+			dist = math.Min(dist, lineLineDistance(line, polygon...))
+		}
+		for _, polygon2 := range c2.polygons {
+			if polygonsIntersect(polygon, polygon2) {
+				return 0
+			}
+			for _, ring := range polygon {
+				dist = math.Min(dist, lineLineDistance(ring, polygon2...))
+			}
+		}
+	}
+	return dist
+}
+
+// GeographicDistance returns the distance in meters between two points or multipoints of a
+// geographic SRS on its ellipsoid.
+func (g *Geometry) GeographicDistance(other *Geometry, srs *SpatialReferenceSystem) float64 {
+	c1, c2 := g.components(), other.components()
+	dist := math.Inf(1)
+	for _, p := range c1.points {
+		for _, q := range c2.points {
+			dist = math.Min(dist, vincentyDistance(p, q, srs.SemiMajorAxis, 1/srs.InverseFlattening))
+		}
+	}
+	return dist
+}
+
+// SphereDistance returns the distance between two points or multipoints on a sphere of the radius,
+// the coordinates are longitudes and latitudes in degrees.
+func (g *Geometry) SphereDistance(other *Geometry, radius float64) float64 {
+	c1, c2 := g.components(), other.components()
+	dist := math.Inf(1)
+	for _, p := range c1.points {
+		for _, q := range c2.points {
+			dist = math.Min(dist, haversineDistance(p, q, radius))
+		}
+	}
+	return dist
+}
+
+func haversineDistance(p, q GeometryPoint, radius float64) float64 {
+	lat1, lat2 := p.Y*math.Pi/180, q.Y*math.Pi/180
+	dLat, dLon := lat2-lat1, (q.X-p.X)*math.Pi/180
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * radius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// vincentyDistance returns the distance between two points on an ellipsoid with the Vincenty's
+// inverse formula, a is the semi-major axis and f is the flattening.
+func vincentyDistance(p, q GeometryPoint, a, f float64) float64 {
+	if p == q {
+		return 0
+	}
+	b := a * (1 - f)
+	toRad := math.Pi / 180
+	l := (q.X - p.X) * toRad
+	u1 := math.Atan((1 - f) * math.Tan(p.Y*toRad))
+	u2 := math.Atan((1 - f) * math.Tan(q.Y*toRad))
+	sinU1, cosU1 := math.Sincos(u1)
+	sinU2, cosU2 := math.Sincos(u2)
+	lambda := l
+	var sinSigma, cosSigma, sigma, cosSqAlpha, cos2SigmaM float64
+	for i := 0; i < 200; i++ {
+		sinLambda, cosLambda := math.Sincos(lambda)
+		sinSigma = math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		if sinSigma == 0 {
+			return 0
+		}
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha = 1 - sinAlpha*sinAlpha
+		cos2SigmaM = 0
+		if cosSqAlpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cosSqAlpha
+		}
+		c := f / 16 * cosSqAlpha * (4 + f*(4-3*cosSqAlpha))
+		prev := lambda
+		lambda = l + (1-c)*f*sinAlpha*(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda-prev) < 1e-12 {
+			uSq := cosSqAlpha * (a*a - b*b) / (b * b)
+			bigA := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+			bigB := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+			deltaSigma := bigB * sinSigma * (cos2SigmaM + bigB/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+				bigB/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+			return b * bigA * (sigma - deltaSigma)
+		}
+	}
+	// The formula doesn't converge for nearly antipodal points, fall back to the sphere of the
+	// mean radius.
+	return haversineDistance(p, q, (2*a+b)/3)
+}
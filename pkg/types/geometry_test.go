@@ -0,0 +1,169 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParseWKT(t *testing.T, wkt string, srid uint32) *Geometry {
+	g, err := ParseGeometryFromWKT(wkt, srid)
+	require.NoError(t, err, wkt)
+	return g
+}
+
+func TestGeometry(t *testing.T) {
+	t.Run("WKT", func(t *testing.T) {
+		tests := []struct {
+			input    string
+			expected string
+		}{
+			{"POINT(1 2)", "POINT(1 2)"},
+			{" point ( -1.5  2e3 ) ", "POINT(-1.5 2000)"},
+			{"LINESTRING(0 0, 1 1, 2 0)", "LINESTRING(0 0,1 1,2 0)"},
+			{"POLYGON((0 0,4 0,4 4,0 4,0 0),(1 1,2 1,2 2,1 1))", "POLYGON((0 0,4 0,4 4,0 4,0 0),(1 1,2 1,2 2,1 1))"},
+			{"MULTIPOINT(1 1, 2 2)", "MULTIPOINT((1 1),(2 2))"},
+			{"MULTIPOINT((1 1),(2 2))", "MULTIPOINT((1 1),(2 2))"},
+			{"MULTILINESTRING((0 0,1 1),(2 2,3 3))", "MULTILINESTRING((0 0,1 1),(2 2,3 3))"},
+			{"MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((2 2,3 2,3 3,2 2)))", "MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((2 2,3 2,3 3,2 2)))"},
+			{"GEOMCOLLECTION(POINT(1 1),LINESTRING(0 0,1 1))", "GEOMETRYCOLLECTION(POINT(1 1),LINESTRING(0 0,1 1))"},
+			{"GEOMETRYCOLLECTION EMPTY", "GEOMETRYCOLLECTION EMPTY"},
+			{"GEOMETRYCOLLECTION()", "GEOMETRYCOLLECTION EMPTY"},
+		}
+		for _, test := range tests {
+			g := mustParseWKT(t, test.input, 0)
+			require.Equal(t, test.expected, g.WKT())
+		}
+
+		for _, input := range []string{
+			"", "POINT", "POINT(1)", "POINT(1 2", "POINT(1 2) x", "CIRCLE(1 2)", "LINESTRING(0 0)",
+			"POLYGON((0 0,1 0,1 1))", "POLYGON((0 0,1 0,1 1,0 1))", "MULTIPOINT()", "POINT(a b)",
+		} {
+			_, err := ParseGeometryFromWKT(input, 0)
+			require.True(t, IsInvalidGeometryErr(err), input)
+		}
+	})
+
+	t.Run("Encode", func(t *testing.T) {
+		g := mustParseWKT(t, "POLYGON((0 0,4 0,4 4,0 4,0 0))", 3857)
+		b := g.Encode()
+		require.Equal(t, "110f0000", hex.EncodeToString(b[:4]))
+		g2, err := DecodeGeometry(b)
+		require.NoError(t, err)
+		require.Equal(t, uint32(3857), g2.SRID)
+		require.Equal(t, g.WKT(), g2.WKT())
+
+		for _, b := range [][]byte{nil, {0, 0, 0}, b[:len(b)-1], append(b, 0)} {
+			_, err = DecodeGeometry(b)
+			require.True(t, IsInvalidGeometryErr(err))
+		}
+
+		// A big-endian WKB is accepted, the result is always little-endian.
+		wkb, err := hex.DecodeString("00000000013ff00000000000004000000000000000")
+		require.NoError(t, err)
+		g, err = ParseGeometryFromWKB(wkb, 0)
+		require.NoError(t, err)
+		require.Equal(t, "POINT(1 2)", g.WKT())
+		require.Equal(t, "0101000000000000000000f03f0000000000000040", hex.EncodeToString(g.WKB()))
+	})
+
+	t.Run("Geographic", func(t *testing.T) {
+		// The axis order of SRID 4326 is latitude-longitude, the internal order is longitude-latitude.
+		g := mustParseWKT(t, "POINT(10 20)", 4326)
+		require.Equal(t, GeometryPoint{X: 20, Y: 10}, g.Points[0])
+		require.Equal(t, "POINT(10 20)", g.WKT())
+		g2, err := ParseGeometryFromWKB(g.WKB(), 4326)
+		require.NoError(t, err)
+		require.Equal(t, g.Points, g2.Points)
+		require.NoError(t, g.CheckGeographicRange("f"))
+
+		g = mustParseWKT(t, "POINT(10 200)", 4326)
+		require.True(t, ErrLongitudeOutOfRange.Equal(g.CheckGeographicRange("f")))
+		g = mustParseWKT(t, "POINT(100 20)", 4326)
+		require.True(t, ErrLatitudeOutOfRange.Equal(g.CheckGeographicRange("f")))
+
+		_, ok := GetSpatialReferenceSystem(1234)
+		require.False(t, ok)
+	})
+
+	t.Run("Relation", func(t *testing.T) {
+		square := mustParseWKT(t, "POLYGON((0 0,4 0,4 4,0 4,0 0))", 0)
+		holed := mustParseWKT(t, "POLYGON((0 0,4 0,4 4,0 4,0 0),(1 1,3 1,3 3,1 3,1 1))", 0)
+		tests := []struct {
+			g1, g2     *Geometry
+			contains   bool
+			intersects bool
+		}{
+			{square, mustParseWKT(t, "POINT(2 2)", 0), true, true},
+			{square, mustParseWKT(t, "POINT(4 2)", 0), false, true},
+			{square, mustParseWKT(t, "POINT(5 5)", 0), false, false},
+			{holed, mustParseWKT(t, "POINT(2 2)", 0), false, false},
+			{square, mustParseWKT(t, "LINESTRING(1 1,3 3)", 0), true, true},
+			{square, mustParseWKT(t, "LINESTRING(0 0,4 0)", 0), false, true},
+			{square, mustParseWKT(t, "LINESTRING(2 2,6 6)", 0), false, true},
+			{square, mustParseWKT(t, "POLYGON((1 1,2 1,2 2,1 1))", 0), true, true},
+			{square, mustParseWKT(t, "POLYGON((3 3,5 3,5 5,3 3))", 0), false, true},
+			{holed, mustParseWKT(t, "POLYGON((1.5 1.5,2 1.5,2 2,1.5 1.5))", 0), false, false},
+			{square, mustParseWKT(t, "MULTIPOINT(1 1,2 2)", 0), true, true},
+			{mustParseWKT(t, "LINESTRING(0 0,2 2)", 0), mustParseWKT(t, "POINT(1 1)", 0), true, true},
+			{mustParseWKT(t, "LINESTRING(0 0,2 2)", 0), mustParseWKT(t, "LINESTRING(0 2,2 0)", 0), false, true},
+		}
+		for i, test := range tests {
+			require.Equal(t, test.contains, test.g1.Contains(test.g2), i)
+			require.Equal(t, test.intersects, test.g1.Intersects(test.g2), i)
+			require.Equal(t, test.intersects, test.g2.Intersects(test.g1), i)
+		}
+	})
+
+	t.Run("Distance", func(t *testing.T) {
+		p := mustParseWKT(t, "POINT(0 0)", 0)
+		require.Equal(t, 5.0, p.Distance(mustParseWKT(t, "POINT(3 4)", 0)))
+		require.Equal(t, 1.0, p.Distance(mustParseWKT(t, "LINESTRING(-1 1,1 1)", 0)))
+		require.Equal(t, 0.0, p.Distance(mustParseWKT(t, "POLYGON((-1 -1,1 -1,1 1,-1 1,-1 -1))", 0)))
+		require.Equal(t, 2.0, mustParseWKT(t, "LINESTRING(0 0,0 4)", 0).Distance(mustParseWKT(t, "LINESTRING(2 1,2 3)", 0)))
+
+		// The distance between Paris and London.
+		paris := NewGeometryPoint(4326, 2.3522, 48.8566)
+		london := NewGeometryPoint(4326, -0.1276, 51.5072)
+		srs, _ := GetSpatialReferenceSystem(4326)
+		require.InDelta(t, 343900, paris.GeographicDistance(london, srs), 1000)
+		require.InDelta(t, 343500, paris.SphereDistance(london, 6370986), 1000)
+		require.Equal(t, 0.0, paris.GeographicDistance(paris, srs))
+	})
+
+	t.Run("Convert", func(t *testing.T) {
+		ft := NewFieldType(mysql.TypeGeometry)
+		ft.SetGeometryType(mysql.GeometryTypePoint)
+		point := NewGeometryPoint(0, 1, 2).Encode()
+		d := NewBytesDatum(point)
+		converted, err := d.ConvertTo(DefaultStmtNoWarningContext, ft)
+		require.NoError(t, err)
+		require.Equal(t, point, converted.GetBytes())
+
+		line := mustParseWKT(t, "LINESTRING(0 0,1 1)", 0).Encode()
+		for _, b := range [][]byte{line, []byte("POINT(1 2)")} {
+			d = NewBytesDatum(b)
+			_, err = d.ConvertTo(DefaultStmtNoWarningContext, ft)
+			require.True(t, ErrCantCreateGeometryObject.Equal(err))
+		}
+	})
+
+	require.Equal(t, "POINT", NewGeometryPoint(0, 1, 1).TypeName())
+	require.True(t, (&Geometry{Type: mysql.GeometryTypeGeometryCollection}).IsEmpty())
+}
@@ -103,3 +103,30 @@ func TestBinaryCompare(t *testing.T) {
 		require.Equal(t, test.result, CompareBinaryJSON(test.left, test.right), "%s should be %s %s", test.left.String(), compareMsg[test.result], test.right.String())
 	}
 }
+
+func TestBinaryJSONExtractAll(t *testing.T) {
+	tests := []struct {
+		doc      string
+		path     string
+		expected []string
+	}{
+		{`[1, "a", {"b": 2}]`, `$[*]`, []string{`1`, `"a"`, `{"b": 2}`}},
+		{`[1, "a", {"b": 2}]`, `$[2].b`, []string{`2`}},
+		{`[1, "a", {"b": 2}]`, `$[3]`, []string{}},
+		{`{"a": [1, 2], "b": [3]}`, `$.*[*]`, []string{`1`, `2`, `3`}},
+		{`{"a": [1, 2]}`, `$.a`, []string{`[1, 2]`}},
+		{`"scalar"`, `$`, []string{`"scalar"`}},
+	}
+	for _, tt := range tests {
+		bj, err := ParseBinaryJSONFromString(tt.doc)
+		require.NoError(t, err)
+		pathExpr, err := ParseJSONPathExpr(tt.path)
+		require.NoError(t, err)
+		result := bj.ExtractAll(pathExpr)
+		actual := make([]string, 0, len(result))
+		for _, r := range result {
+			actual = append(actual, r.String())
+		}
+		require.Equal(t, tt.expected, actual, "doc %s path %s", tt.doc, tt.path)
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"strconv"
+	"unsafe"
+
+	"github.com/pingcap/errors"
+)
+
+// MaxVectorDimension is the max number of dimensions of a vector.
+const MaxVectorDimension = 16383
+
+// UnspecifiedVectorDimension is the flen of a vector column whose dimension is not specified.
+const UnspecifiedVectorDimension = UnspecifiedLength
+
+// VectorFloat32 is a vector of float32 values. Its binary representation is the number of
+// dimensions as a little-endian uint32, followed by the elements as little-endian float32.
+type VectorFloat32 struct {
+	data []byte
+}
+
+// ZeroVectorFloat32 is a vector of zero dimension.
+var ZeroVectorFloat32 = InitVectorFloat32(0)
+
+// InitVectorFloat32 creates a vector of the given dimensions, whose elements are all zero.
+func InitVectorFloat32(dims int) VectorFloat32 {
+	data := make([]byte, 4+dims*4)
+	binary.LittleEndian.PutUint32(data, uint32(dims))
+	return VectorFloat32{data: data}
+}
+
+// CreateVectorFloat32 creates a vector from the float32 values.
+func CreateVectorFloat32(elements []float32) (VectorFloat32, error) {
+	if err := checkVectorElements(elements); err != nil {
+		return ZeroVectorFloat32, err
+	}
+	v := InitVectorFloat32(len(elements))
+	copy(v.Elements(), elements)
+	return v, nil
+}
+
+func checkVectorElements(elements []float32) error {
+	if len(elements) > MaxVectorDimension {
+		return errors.Errorf("vector has %d dimensions, exceeds the max dimension %d", len(elements), MaxVectorDimension)
+	}
+	for _, e := range elements {
+		if math.IsNaN(float64(e)) || math.IsInf(float64(e), 0) {
+			return errors.Errorf("NaN or infinite value is not allowed in a vector")
+		}
+	}
+	return nil
+}
+
+// ParseVectorFloat32 parses a vector from its text representation like `[1,2.5,-3]`.
+func ParseVectorFloat32(s string) (VectorFloat32, error) {
+	var elements []float32
+	if err := json.Unmarshal([]byte(s), &elements); err != nil || elements == nil {
+		return ZeroVectorFloat32, ErrWrongValue2.GenWithStackByArgs("vector", s)
+	}
+	return CreateVectorFloat32(elements)
+}
+
+// ZeroCopyDeserializeVectorFloat32 reads a vector from the head of b without copying, and
+// returns the remaining bytes.
+func ZeroCopyDeserializeVectorFloat32(b []byte) (VectorFloat32, []byte, error) {
+	if len(b) < 4 {
+		return ZeroVectorFloat32, b, errors.Errorf("bad VectorFloat32 value header")
+	}
+	size := 4 + int(binary.LittleEndian.Uint32(b))*4
+	if len(b) < size {
+		return ZeroVectorFloat32, b, errors.Errorf("bad VectorFloat32 value (len=%d, expected=%d)", len(b), size)
+	}
+	return VectorFloat32{data: b[:size:size]}, b[size:], nil
+}
+
+// ZeroCopySerialize returns the binary representation of the vector without copying.
+func (v VectorFloat32) ZeroCopySerialize() []byte {
+	return v.data
+}
+
+// SerializeTo appends the binary representation of the vector to buf.
+func (v VectorFloat32) SerializeTo(buf []byte) []byte {
+	return append(buf, v.data...)
+}
+
+// SerializedSize returns the size of the binary representation of the vector.
+func (v VectorFloat32) SerializedSize() int {
+	return len(v.data)
+}
+
+// Len returns the number of dimensions of the vector.
+func (v VectorFloat32) Len() int {
+	if len(v.data) < 4 {
+		return 0
+	}
+	return int(binary.LittleEndian.Uint32(v.data))
+}
+
+// Elements returns the elements of the vector. The returned slice shares the memory with the vector.
+func (v VectorFloat32) Elements() []float32 {
+	l := v.Len()
+	if l == 0 {
+		return nil
+	}
+	return unsafe.Slice((*float32)(unsafe.Pointer(&v.data[4])), l)
+}
+
+// IsZeroValue returns whether the vector is the zero value of VectorFloat32.
+func (v VectorFloat32) IsZeroValue() bool {
+	return len(v.data) == 0
+}
+
+// Clone returns a deep copy of the vector.
+func (v VectorFloat32) Clone() VectorFloat32 {
+	return VectorFloat32{data: append([]byte(nil), v.data...)}
+}
+
+// String returns the text representation of the vector.
+func (v VectorFloat32) String() string {
+	elements := v.Elements()
+	buf := make([]byte, 0, 2+len(elements)*2)
+	buf = append(buf, '[')
+	for i, e := range elements {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = strconv.AppendFloat(buf, float64(e), 'g', -1, 32)
+	}
+	buf = append(buf, ']')
+	return string(buf)
+}
+
+// CheckDimsFitColumn checks whether the vector fits a vector column of the given flen.
+func (v VectorFloat32) CheckDimsFitColumn(flen int) error {
+	if flen != UnspecifiedVectorDimension && v.Len() != flen {
+		return ErrVectorDimensionNotFit.GenWithStackByArgs(v.Len(), flen)
+	}
+	return nil
+}
+
+// Compare compares the vectors element by element. A vector which is a prefix of another
+// vector is smaller.
+func (v VectorFloat32) Compare(other VectorFloat32) int {
+	a, b := v.Elements(), other.Elements()
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] < b[i] {
+			return -1
+		} else if a[i] > b[i] {
+			return 1
+		}
+	}
+	return compareInt64(int64(len(a)), int64(len(b)))
+}
+
+func (v VectorFloat32) checkSameDims(other VectorFloat32) error {
+	if v.Len() != other.Len() {
+		return ErrVectorDimensionMismatch.GenWithStackByArgs(v.Len(), other.Len())
+	}
+	return nil
+}
+
+// L2SquaredDistance returns the squared euclidean distance between the vectors.
+func (v VectorFloat32) L2SquaredDistance(other VectorFloat32) (float64, error) {
+	if err := v.checkSameDims(other); err != nil {
+		return 0, err
+	}
+	a, b := v.Elements(), other.Elements()
+	var distance float32
+	for i := range a {
+		diff := a[i] - b[i]
+		distance += diff * diff
+	}
+	return float64(distance), nil
+}
+
+// L2Distance returns the euclidean distance between the vectors.
+func (v VectorFloat32) L2Distance(other VectorFloat32) (float64, error) {
+	distance, err := v.L2SquaredDistance(other)
+	if err != nil {
+		return 0, err
+	}
+	return math.Sqrt(distance), nil
+}
+
+// L1Distance returns the manhattan distance between the vectors.
+func (v VectorFloat32) L1Distance(other VectorFloat32) (float64, error) {
+	if err := v.checkSameDims(other); err != nil {
+		return 0, err
+	}
+	a, b := v.Elements(), other.Elements()
+	var distance float32
+	for i := range a {
+		distance += float32(math.Abs(float64(a[i] - b[i])))
+	}
+	return float64(distance), nil
+}
+
+// InnerProduct returns the inner product of the vectors.
+func (v VectorFloat32) InnerProduct(other VectorFloat32) (float64, error) {
+	if err := v.checkSameDims(other); err != nil {
+		return 0, err
+	}
+	a, b := v.Elements(), other.Elements()
+	var product float32
+	for i := range a {
+		product += a[i] * b[i]
+	}
+	return float64(product), nil
+}
+
+// NegativeInnerProduct returns the negative inner product of the vectors, so a smaller value
+// means the vectors are more similar like other distances.
+func (v VectorFloat32) NegativeInnerProduct(other VectorFloat32) (float64, error) {
+	product, err := v.InnerProduct(other)
+	if err != nil {
+		return 0, err
+	}
+	return -product, nil
+}
+
+// CosineDistance returns the cosine distance between the vectors. NaN is returned if either
+// vector has a zero norm.
+func (v VectorFloat32) CosineDistance(other VectorFloat32) (float64, error) {
+	if err := v.checkSameDims(other); err != nil {
+		return 0, err
+	}
+	a, b := v.Elements(), other.Elements()
+	var product, normA, normB float32
+	for i := range a {
+		product += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return math.NaN(), nil
+	}
+	similarity := float64(product) / math.Sqrt(float64(normA)*float64(normB))
+	// Keep the similarity in [-1, 1] in case of the rounding error.
+	similarity = math.Max(-1, math.Min(1, similarity))
+	return 1 - similarity, nil
+}
+
+// L2Norm returns the euclidean norm of the vector.
+func (v VectorFloat32) L2Norm() float64 {
+	var norm float32
+	for _, e := range v.Elements() {
+		norm += e * e
+	}
+	return math.Sqrt(float64(norm))
+}
@@ -0,0 +1,158 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVectorFloat32(t *testing.T) {
+	t.Run("Parse", func(t *testing.T) {
+		tests := []struct {
+			input    string
+			expected string
+		}{
+			{"[]", "[]"},
+			{"[1,2,3]", "[1,2,3]"},
+			{" [ 1.5 , -2e2, 0.1 ] ", "[1.5,-200,0.1]"},
+		}
+		for _, test := range tests {
+			v, err := ParseVectorFloat32(test.input)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, v.String())
+		}
+
+		for _, input := range []string{"", "1,2", "[1,2", "[a]", "[[1]]", "null", `{"a":1}`, "[1e100]"} {
+			_, err := ParseVectorFloat32(input)
+			require.Error(t, err, input)
+		}
+	})
+
+	t.Run("Serialize", func(t *testing.T) {
+		v, err := CreateVectorFloat32([]float32{1, 2.5, -3})
+		require.NoError(t, err)
+		require.Equal(t, 3, v.Len())
+		require.Equal(t, 16, v.SerializedSize())
+
+		buf := v.SerializeTo([]byte{0xff})
+		buf = append(buf, 0x01)
+		v2, remain, err := ZeroCopyDeserializeVectorFloat32(buf[1:])
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x01}, remain)
+		require.Equal(t, []float32{1, 2.5, -3}, v2.Elements())
+		require.Equal(t, 0, v.Compare(v2))
+
+		_, _, err = ZeroCopyDeserializeVectorFloat32(buf[1:10])
+		require.Error(t, err)
+		_, _, err = ZeroCopyDeserializeVectorFloat32([]byte{0x01})
+		require.Error(t, err)
+
+		_, err = CreateVectorFloat32([]float32{float32(math.NaN())})
+		require.Error(t, err)
+		_, err = CreateVectorFloat32(make([]float32, MaxVectorDimension+1))
+		require.Error(t, err)
+	})
+
+	t.Run("Compare", func(t *testing.T) {
+		tests := []struct {
+			a, b     string
+			expected int
+		}{
+			{"[1,2,3]", "[1,2,3]", 0},
+			{"[1,2]", "[1,2,3]", -1},
+			{"[1,3]", "[1,2,3]", 1},
+			{"[]", "[]", 0},
+			{"[-1]", "[]", 1},
+		}
+		for _, test := range tests {
+			a, err := ParseVectorFloat32(test.a)
+			require.NoError(t, err)
+			b, err := ParseVectorFloat32(test.b)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, a.Compare(b))
+			require.Equal(t, -test.expected, b.Compare(a))
+		}
+	})
+
+	t.Run("Distance", func(t *testing.T) {
+		a, err := ParseVectorFloat32("[1,2,3]")
+		require.NoError(t, err)
+		b, err := ParseVectorFloat32("[4,6,3]")
+		require.NoError(t, err)
+
+		d, err := a.L2Distance(b)
+		require.NoError(t, err)
+		require.InDelta(t, 5, d, 1e-6)
+		d, err = a.L1Distance(b)
+		require.NoError(t, err)
+		require.InDelta(t, 7, d, 1e-6)
+		d, err = a.InnerProduct(b)
+		require.NoError(t, err)
+		require.InDelta(t, 25, d, 1e-6)
+		d, err = a.NegativeInnerProduct(b)
+		require.NoError(t, err)
+		require.InDelta(t, -25, d, 1e-6)
+		d, err = a.CosineDistance(b)
+		require.NoError(t, err)
+		require.InDelta(t, 1-25/(math.Sqrt(14)*math.Sqrt(61)), d, 1e-6)
+		d, err = a.CosineDistance(a)
+		require.NoError(t, err)
+		require.InDelta(t, 0, d, 1e-6)
+		require.InDelta(t, math.Sqrt(14), a.L2Norm(), 1e-6)
+
+		d, err = a.CosineDistance(InitVectorFloat32(3))
+		require.NoError(t, err)
+		require.True(t, math.IsNaN(d))
+
+		c, err := ParseVectorFloat32("[1,2]")
+		require.NoError(t, err)
+		_, err = a.L2Distance(c)
+		require.True(t, ErrVectorDimensionMismatch.Equal(err))
+	})
+
+	t.Run("Datum", func(t *testing.T) {
+		v, err := ParseVectorFloat32("[1,2]")
+		require.NoError(t, err)
+		d := NewVectorFloat32Datum(v)
+		require.Equal(t, KindVectorFloat32, d.Kind())
+		s, err := d.ToString()
+		require.NoError(t, err)
+		require.Equal(t, "[1,2]", s)
+
+		ctx := DefaultStmtNoWarningContext
+		str := NewStringDatum("[1,2]")
+		cmp, err := str.Compare(ctx, &d, nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, cmp)
+
+		tp := NewFieldType(mysql.TypeTiDBVectorFloat32)
+		tp.SetFlen(2)
+		converted, err := str.ConvertTo(ctx, tp)
+		require.NoError(t, err)
+		require.Equal(t, 0, converted.GetVectorFloat32().Compare(v))
+
+		tp.SetFlen(3)
+		_, err = str.ConvertTo(ctx, tp)
+		require.True(t, ErrVectorDimensionNotFit.Equal(err))
+
+		converted, err = d.ConvertTo(ctx, NewFieldType(mysql.TypeVarString))
+		require.NoError(t, err)
+		require.Equal(t, "[1,2]", converted.GetString())
+	})
+}
@@ -347,6 +347,26 @@ func TestJSONColumn(t *testing.T) {
 	}
 }
 
+func TestVectorFloat32Column(t *testing.T) {
+	chk := NewChunkWithCapacity([]*types.FieldType{types.NewFieldType(mysql.TypeTiDBVectorFloat32)}, 1024)
+	col := chk.Column(0)
+	for i := 0; i < 1024; i++ {
+		v, err := types.CreateVectorFloat32([]float32{float32(i), float32(-i), 0.5})
+		require.NoError(t, err)
+		col.AppendVectorFloat32(v)
+	}
+
+	it := NewIterator4Chunk(chk)
+	var i int
+	for row := it.Begin(); row != it.End(); row = it.Next() {
+		v1 := col.GetVectorFloat32(i)
+		v2 := row.GetVectorFloat32(0)
+		require.Equal(t, fmt.Sprintf("[%d,%d,0.5]", i, -i), v1.String())
+		require.Equal(t, 0, v1.Compare(v2))
+		i++
+	}
+}
+
 func TestTimeColumn(t *testing.T) {
 	chk := NewChunkWithCapacity([]*types.FieldType{types.NewFieldType(mysql.TypeDatetime)}, 1024)
 	col := chk.Column(0)
@@ -115,6 +115,11 @@ func (r Row) GetJSON(colIdx int) types.BinaryJSON {
 	return r.c.columns[colIdx].GetJSON(r.idx)
 }
 
+// GetVectorFloat32 returns the VectorFloat32 value with the colIdx.
+func (r Row) GetVectorFloat32(colIdx int) types.VectorFloat32 {
+	return r.c.columns[colIdx].GetVectorFloat32(r.idx)
+}
+
 // GetDatumRow converts chunk.Row to types.DatumRow.
 // Keep in mind that GetDatumRow has a reference to r.c, which is a chunk,
 // this function works only if the underlying chunk is valid or unchanged.
@@ -162,7 +167,8 @@ func (r Row) DatumWithBuffer(colIdx int, tp *types.FieldType, d *types.Datum) {
 		if !r.IsNull(colIdx) {
 			d.SetFloat64(r.GetFloat64(colIdx))
 		}
-	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob,
+		mysql.TypeGeometry:
 		if !r.IsNull(colIdx) {
 			d.SetString(r.GetString(colIdx), tp.GetCollate())
 		}
@@ -206,6 +212,10 @@ func (r Row) DatumWithBuffer(colIdx int, tp *types.FieldType, d *types.Datum) {
 		if !r.IsNull(colIdx) {
 			d.SetMysqlJSON(r.GetJSON(colIdx))
 		}
+	case mysql.TypeTiDBVectorFloat32:
+		if !r.IsNull(colIdx) {
+			d.SetVectorFloat32(r.GetVectorFloat32(colIdx))
+		}
 	}
 	if r.IsNull(colIdx) {
 		d.SetNull()
@@ -256,6 +266,8 @@ func (r Row) ToString(ft []*types.FieldType) string {
 				buf = append(buf, r.GetDuration(colIdx, ft[colIdx].GetDecimal()).String()...)
 			case types.ETJson:
 				buf = append(buf, r.GetJSON(colIdx).String()...)
+			case types.ETVectorFloat32:
+				buf = append(buf, r.GetVectorFloat32(colIdx).String()...)
 			case types.ETReal:
 				switch ft[colIdx].GetType() {
 				case mysql.TypeFloat:
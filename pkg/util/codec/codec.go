@@ -36,18 +36,19 @@ import (
 
 // First byte in the encoded value which specifies the encoding type.
 const (
-	NilFlag          byte = 0
-	bytesFlag        byte = 1
-	compactBytesFlag byte = 2
-	intFlag          byte = 3
-	uintFlag         byte = 4
-	floatFlag        byte = 5
-	decimalFlag      byte = 6
-	durationFlag     byte = 7
-	varintFlag       byte = 8
-	uvarintFlag      byte = 9
-	jsonFlag         byte = 10
-	maxFlag          byte = 250
+	NilFlag           byte = 0
+	bytesFlag         byte = 1
+	compactBytesFlag  byte = 2
+	intFlag           byte = 3
+	uintFlag          byte = 4
+	floatFlag         byte = 5
+	decimalFlag       byte = 6
+	durationFlag      byte = 7
+	varintFlag        byte = 8
+	uvarintFlag       byte = 9
+	jsonFlag          byte = 10
+	vectorFloat32Flag byte = 20
+	maxFlag           byte = 250
 )
 
 // IntHandleFlag is only used to encode int handle key.
@@ -72,6 +73,8 @@ func preRealloc(b []byte, vals []types.Datum, comparable1 bool) []byte {
 			size++
 		case types.KindMysqlJSON:
 			size += 2 + len(vals[i].GetBytes())
+		case types.KindVectorFloat32:
+			size += 1 + vals[i].GetVectorFloat32().SerializedSize()
 		case types.KindMysqlDecimal:
 			size += 1 + types.MyDecimalStructSize
 		default:
@@ -126,6 +129,9 @@ func encode(loc *time.Location, b []byte, vals []types.Datum, comparable1 bool)
 			j := vals[i].GetMysqlJSON()
 			b = append(b, j.TypeCode)
 			b = append(b, j.Value...)
+		case types.KindVectorFloat32:
+			b = append(b, vectorFloat32Flag)
+			b = vals[i].GetVectorFloat32().SerializeTo(b)
 		case types.KindNull:
 			b = append(b, NilFlag)
 		case types.KindMinNotNull:
@@ -169,6 +175,8 @@ func EstimateValueSize(typeCtx types.Context, val types.Datum) (int, error) {
 		l = valueSizeOfUnsignedInt(val)
 	case types.KindMysqlJSON:
 		l = 2 + len(val.GetMysqlJSON().Value)
+	case types.KindVectorFloat32:
+		l = 1 + val.GetVectorFloat32().SerializedSize()
 	case types.KindNull, types.KindMinNotNull, types.KindMaxValue:
 		l = 1
 	default:
@@ -327,7 +335,8 @@ func encodeHashChunkRowIdx(typeCtx types.Context, row chunk.Row, tp *types.Field
 			f = 0
 		}
 		b = unsafe.Slice((*byte)(unsafe.Pointer(&f)), unsafe.Sizeof(f))
-	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob,
+		mysql.TypeGeometry:
 		flag = compactBytesFlag
 		b = row.GetBytes(idx)
 		b = ConvertByCollation(b, tp)
@@ -385,6 +394,9 @@ func encodeHashChunkRowIdx(typeCtx types.Context, row chunk.Row, tp *types.Field
 		flag = jsonFlag
 		json := row.GetJSON(idx)
 		b = json.HashValue(b)
+	case mysql.TypeTiDBVectorFloat32:
+		flag = vectorFloat32Flag
+		b = row.GetVectorFloat32(idx).ZeroCopySerialize()
 	default:
 		return 0, nil, errors.Errorf("unsupport column type for encode %d", tp.GetType())
 	}
@@ -476,7 +488,8 @@ func HashChunkSelected(typeCtx types.Context, h []hash.Hash64, chk *chunk.Chunk,
 			_, _ = h[i].Write(buf)
 			_, _ = h[i].Write(b)
 		}
-	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob,
+		mysql.TypeGeometry:
 		for i := 0; i < rows; i++ {
 			if sel != nil && !sel[i] {
 				continue
@@ -648,6 +661,24 @@ func HashChunkSelected(typeCtx types.Context, h []hash.Hash64, chk *chunk.Chunk,
 				b = json.HashValue(b)
 			}
 
+			// As the golang doc described, `Hash.Write` never returns an error..
+			// See https://golang.org/pkg/hash/#Hash
+			_, _ = h[i].Write(buf)
+			_, _ = h[i].Write(b)
+		}
+	case mysql.TypeTiDBVectorFloat32:
+		for i := 0; i < rows; i++ {
+			if sel != nil && !sel[i] {
+				continue
+			}
+			if column.IsNull(i) {
+				buf[0], b = NilFlag, nil
+				isNull[i] = !ignoreNull
+			} else {
+				buf[0] = vectorFloat32Flag
+				b = column.GetVectorFloat32(i).ZeroCopySerialize()
+			}
+
 			// As the golang doc described, `Hash.Write` never returns an error..
 			// See https://golang.org/pkg/hash/#Hash
 			_, _ = h[i].Write(buf)
@@ -859,6 +890,12 @@ func DecodeOne(b []byte) (remain []byte, d types.Datum, err error) {
 		j := types.BinaryJSON{TypeCode: b[0], Value: b[1:size]}
 		d.SetMysqlJSON(j)
 		b = b[size:]
+	case vectorFloat32Flag:
+		var v types.VectorFloat32
+		v, b, err = types.ZeroCopyDeserializeVectorFloat32(b)
+		if err == nil {
+			d.SetVectorFloat32(v)
+		}
 	case NilFlag:
 	default:
 		return b, d, errors.Errorf("invalid encoded key flag %v", flag)
@@ -988,6 +1025,8 @@ func peek(b []byte) (length int, err error) {
 		l, err = peekUvarint(b)
 	case jsonFlag:
 		l, err = types.PeekBytesAsJSON(b)
+	case vectorFloat32Flag:
+		l, err = peekVectorFloat32(b)
 	default:
 		return 0, errors.Errorf("invalid encoded key flag %v", flag)
 	}
@@ -1054,6 +1093,14 @@ func peekUvarint(b []byte) (int, error) {
 	return n, nil
 }
 
+func peekVectorFloat32(b []byte) (int, error) {
+	v, _, err := types.ZeroCopyDeserializeVectorFloat32(b)
+	if err != nil {
+		return 0, err
+	}
+	return v.SerializedSize(), nil
+}
+
 // Decoder is used to decode value to chunk.
 type Decoder struct {
 	chk      *chunk.Chunk
@@ -1160,6 +1207,13 @@ func (decoder *Decoder) DecodeOne(b []byte, colIdx int, ft *types.FieldType) (re
 		}
 		chk.AppendJSON(colIdx, types.BinaryJSON{TypeCode: b[0], Value: b[1:size]})
 		b = b[size:]
+	case vectorFloat32Flag:
+		var v types.VectorFloat32
+		v, b, err = types.ZeroCopyDeserializeVectorFloat32(b)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		chk.AppendVectorFloat32(colIdx, v)
 	case NilFlag:
 		chk.AppendNull(colIdx)
 	default:
@@ -1296,6 +1350,15 @@ func HashGroupKey(loc *time.Location, n int, col *chunk.Column, buf [][]byte, ft
 				buf[i] = col.GetJSON(i).HashValue(buf[i])
 			}
 		}
+	case types.ETVectorFloat32:
+		for i := 0; i < n; i++ {
+			if col.IsNull(i) {
+				buf[i] = append(buf[i], NilFlag)
+			} else {
+				buf[i] = append(buf[i], vectorFloat32Flag)
+				buf[i] = col.GetVectorFloat32(i).SerializeTo(buf[i])
+			}
+		}
 	case types.ETString:
 		for i := 0; i < n; i++ {
 			if col.IsNull(i) {
@@ -1361,6 +1424,9 @@ func HashCode(b []byte, d types.Datum) []byte {
 		j := d.GetMysqlJSON()
 		b = append(b, j.TypeCode)
 		b = append(b, j.Value...)
+	case types.KindVectorFloat32:
+		b = append(b, vectorFloat32Flag)
+		b = d.GetVectorFloat32().SerializeTo(b)
 	case types.KindNull:
 		b = append(b, NilFlag)
 	case types.KindMinNotNull:
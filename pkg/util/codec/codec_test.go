@@ -819,6 +819,36 @@ func TestJSON(t *testing.T) {
 	}
 }
 
+func TestVectorFloat32(t *testing.T) {
+	tbl := []string{
+		"[]",
+		"[1,2.5,-3]",
+	}
+
+	originalDatums := make([]types.Datum, 0, len(tbl))
+	for _, vecDatum := range tbl {
+		v, err := types.ParseVectorFloat32(vecDatum)
+		require.NoError(t, err)
+		originalDatums = append(originalDatums, types.NewVectorFloat32Datum(v))
+	}
+
+	buf := make([]byte, 0, 4096)
+	buf, err := encode(nil, buf, originalDatums, false)
+	require.NoError(t, err)
+
+	decodedDatums, err := Decode(buf, 2)
+	require.NoError(t, err)
+	for i := range decodedDatums {
+		require.Equal(t, tbl[i], decodedDatums[i].GetVectorFloat32().String())
+	}
+
+	_, remain, err := CutOne(buf)
+	require.NoError(t, err)
+	decodedDatums, err = Decode(remain, 1)
+	require.NoError(t, err)
+	require.Equal(t, tbl[1], decodedDatums[0].GetVectorFloat32().String())
+}
+
 func TestCut(t *testing.T) {
 	table := []struct {
 		Input  []types.Datum
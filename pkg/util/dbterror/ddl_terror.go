@@ -272,6 +272,14 @@ var (
 	ErrWrongObject = ClassDDL.NewStd(mysql.ErrWrongObject)
 	// ErrTableCantHandleFt returns FULLTEXT keys are not supported by table type
 	ErrTableCantHandleFt = ClassDDL.NewStd(mysql.ErrTableCantHandleFt)
+	// ErrBadFtColumn returns when a column can't be part of a FULLTEXT index.
+	ErrBadFtColumn = ClassDDL.NewStd(mysql.ErrBadFtColumn)
+	// ErrSRSNotFound returns when the SRID of a geometry column isn't a supported spatial reference system.
+	ErrSRSNotFound = ClassDDL.NewStd(mysql.ErrSRSNotFound)
+	// ErrFulltextFunctionalIndex returns when an expression is used in a FULLTEXT index.
+	ErrFulltextFunctionalIndex = ClassDDL.NewStd(mysql.ErrFulltextFunctionalIndex)
+	// ErrFunctionNotDefined returns when the parser of a FULLTEXT index is not defined.
+	ErrFunctionNotDefined = ClassDDL.NewStd(mysql.ErrFunctionNotDefined)
 	// ErrFieldNotFoundPart returns an error when 'partition by columns' are not found in table columns.
 	ErrFieldNotFoundPart = ClassDDL.NewStd(mysql.ErrFieldNotFoundPart)
 	// ErrWrongTypeColumnValue returns 'Partition column values of incorrect type'
@@ -495,6 +503,20 @@ var (
 	ErrUnsupportedDistTask = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation,
 		parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw,
 			"tidb_enable_dist_task setting. To utilize distributed task execution, please enable tidb_ddl_enable_fast_reorg first."), nil))
+	// ErrEventIntervalNotPositiveOrTooBig is returned when the interval of a periodic schedule is not positive or too big.
+	ErrEventIntervalNotPositiveOrTooBig = ClassDDL.NewStd(mysql.ErrEventIntervalNotPositiveOrTooBig)
+	// ErrTrgAlreadyExists is returned when the trigger to create already exists in the schema.
+	ErrTrgAlreadyExists = ClassDDL.NewStd(mysql.ErrTrgAlreadyExists)
+	// ErrTrgDoesNotExist is returned when the trigger to drop doesn't exist.
+	ErrTrgDoesNotExist = ClassDDL.NewStd(mysql.ErrTrgDoesNotExist)
+	// ErrTrgOnViewOrTempTable is returned when creating a trigger on a view or a temporary table.
+	ErrTrgOnViewOrTempTable = ClassDDL.NewStd(mysql.ErrTrgOnViewOrTempTable)
+	// ErrTrgCantChangeRow is returned when a trigger assigns to a row it can't change.
+	ErrTrgCantChangeRow = ClassDDL.NewStd(mysql.ErrTrgCantChangeRow)
+	// ErrTrgNoSuchRowInTrg is returned when a trigger refers to a row the event doesn't have.
+	ErrTrgNoSuchRowInTrg = ClassDDL.NewStd(mysql.ErrTrgNoSuchRowInTrg)
+	// ErrTrgInWrongSchema is returned when a trigger isn't in the schema of its table.
+	ErrTrgInWrongSchema = ClassDDL.NewStd(mysql.ErrTrgInWrongSchema)
 )
 
 // ReorgRetryableErrCodes is the error codes that are retryable for reorganization.
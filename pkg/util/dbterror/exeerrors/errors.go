@@ -76,11 +76,57 @@ var (
 	ErrForeignKeyCascadeDepthExceeded = dbterror.ClassExecutor.NewStd(mysql.ErrForeignKeyCascadeDepthExceeded)
 	ErrPasswordExpireAnonymousUser    = dbterror.ClassExecutor.NewStd(mysql.ErrPasswordExpireAnonymousUser)
 	ErrMustChangePassword             = dbterror.ClassExecutor.NewStd(mysql.ErrMustChangePassword)
+	ErrMissingJSONTableValue          = dbterror.ClassExecutor.NewStd(mysql.ErrMissingJSONTableValue)
+	ErrWrongJSONTableValue            = dbterror.ClassExecutor.NewStd(mysql.ErrWrongJSONTableValue)
+	ErrTooManyRows                    = dbterror.ClassExecutor.NewStd(mysql.ErrTooManyRows)
+	ErrSpAlreadyExists                = dbterror.ClassExecutor.NewStd(mysql.ErrSpAlreadyExists)
+	ErrSpDoesNotExist                 = dbterror.ClassExecutor.NewStd(mysql.ErrSpDoesNotExist)
+	ErrSpLilabelMismatch              = dbterror.ClassExecutor.NewStd(mysql.ErrSpLilabelMismatch)
+	ErrSpLabelRedefine                = dbterror.ClassExecutor.NewStd(mysql.ErrSpLabelRedefine)
+	ErrSpLabelMismatch                = dbterror.ClassExecutor.NewStd(mysql.ErrSpLabelMismatch)
+	ErrSpWrongNoOfArgs                = dbterror.ClassExecutor.NewStd(mysql.ErrSpWrongNoOfArgs)
+	ErrSpCursorMismatch               = dbterror.ClassExecutor.NewStd(mysql.ErrSpCursorMismatch)
+	ErrSpCursorAlreadyOpen            = dbterror.ClassExecutor.NewStd(mysql.ErrSpCursorAlreadyOpen)
+	ErrSpCursorNotOpen                = dbterror.ClassExecutor.NewStd(mysql.ErrSpCursorNotOpen)
+	ErrSpUndeclaredVar                = dbterror.ClassExecutor.NewStd(mysql.ErrSpUndeclaredVar)
+	ErrSpWrongNoOfFetchArgs           = dbterror.ClassExecutor.NewStd(mysql.ErrSpWrongNoOfFetchArgs)
+	ErrSpFetchNoData                  = dbterror.ClassExecutor.NewStd(mysql.ErrSpFetchNoData)
+	ErrSpDupParam                     = dbterror.ClassExecutor.NewStd(mysql.ErrSpDupParam)
+	ErrSpDupVar                       = dbterror.ClassExecutor.NewStd(mysql.ErrSpDupVar)
+	ErrSpDupCurs                      = dbterror.ClassExecutor.NewStd(mysql.ErrSpDupCurs)
+	ErrSpNotVarArg                    = dbterror.ClassExecutor.NewStd(mysql.ErrSpNotVarArg)
+	ErrSpCaseNotFound                 = dbterror.ClassExecutor.NewStd(mysql.ErrSpCaseNotFound)
+	ErrSpRecursionLimit               = dbterror.ClassExecutor.NewStd(mysql.ErrSpRecursionLimit)
+	ErrSpNoRecursion                  = dbterror.ClassExecutor.NewStd(mysql.ErrSpNoRecursion)
+	ErrCantUpdateUsedTableInSfOrTrg   = dbterror.ClassExecutor.NewStd(mysql.ErrCantUpdateUsedTableInSfOrTrg)
+	ErrNoSuchUser                     = dbterror.ClassExecutor.NewStd(mysql.ErrNoSuchUser)
 
 	ErrWrongStringLength            = dbterror.ClassDDL.NewStd(mysql.ErrWrongStringLength)
 	ErrUnsupportedFlashbackTmpTable = dbterror.ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message("Recover/flashback table is not supported on temporary tables", nil))
 	ErrTruncateWrongInsertValue     = dbterror.ClassTable.NewStdErr(mysql.ErrTruncatedWrongValue, parser_mysql.Message("Incorrect %-.32s value: '%-.128s' for column '%.192s' at row %d", nil))
 	ErrExistsInHistoryPassword      = dbterror.ClassExecutor.NewStd(mysql.ErrExistsInHistoryPassword)
+	ErrMergeTargetRowMatchedTwice   = dbterror.ClassExecutor.NewStd(mysql.ErrMergeTargetRowMatchedTwice)
+
+	ErrXAERNota     = dbterror.ClassExecutor.NewStd(mysql.ErrXaerNota)
+	ErrXAERInval    = dbterror.ClassExecutor.NewStd(mysql.ErrXaerInval)
+	ErrXAERRmfail   = dbterror.ClassExecutor.NewStd(mysql.ErrXaerRmfail)
+	ErrXAEROutside  = dbterror.ClassExecutor.NewStd(mysql.ErrXaerOutside)
+	ErrXARBRollback = dbterror.ClassExecutor.NewStd(mysql.ErrXaRbrollback)
+	ErrXAERDupid    = dbterror.ClassExecutor.NewStd(mysql.ErrXaerDupid)
+
+	ErrEventAlreadyExists               = dbterror.ClassExecutor.NewStd(mysql.ErrEventAlreadyExists)
+	ErrEventDoesNotExist                = dbterror.ClassExecutor.NewStd(mysql.ErrEventDoesNotExist)
+	ErrEventIntervalNotPositiveOrTooBig = dbterror.ClassExecutor.NewStd(mysql.ErrEventIntervalNotPositiveOrTooBig)
+	ErrEventEndsBeforeStarts            = dbterror.ClassExecutor.NewStd(mysql.ErrEventEndsBeforeStarts)
+	ErrEventExecTimeInThePast           = dbterror.ClassExecutor.NewStd(mysql.ErrEventExecTimeInThePast)
+	ErrEventSameName                    = dbterror.ClassExecutor.NewStd(mysql.ErrEventSameName)
+	ErrEventRecursionForbidden          = dbterror.ClassExecutor.NewStd(mysql.ErrEventRecursionForbidden)
+	ErrEventCannotCreateInThePast       = dbterror.ClassExecutor.NewStd(mysql.ErrEventCannotCreateInThePast)
+	ErrEventCannotAlterInThePast        = dbterror.ClassExecutor.NewStd(mysql.ErrEventCannotAlterInThePast)
+
+	ErrChangefeedExists    = dbterror.ClassExecutor.NewStd(mysql.ErrChangefeedExists)
+	ErrChangefeedNotExists = dbterror.ClassExecutor.NewStd(mysql.ErrChangefeedNotExists)
+	ErrChangefeedInvalid   = dbterror.ClassExecutor.NewStd(mysql.ErrChangefeedInvalid)
 
 	ErrWarnTooFewRecords              = dbterror.ClassExecutor.NewStd(mysql.ErrWarnTooFewRecords)
 	ErrWarnTooManyRecords             = dbterror.ClassExecutor.NewStd(mysql.ErrWarnTooManyRecords)
@@ -100,4 +146,5 @@ var (
 	ErrLoadDataInvalidOperation       = dbterror.ClassExecutor.NewStd(mysql.ErrLoadDataInvalidOperation)
 	ErrLoadDataLocalUnsupportedOption = dbterror.ClassExecutor.NewStd(mysql.ErrLoadDataLocalUnsupportedOption)
 	ErrLoadDataPreCheckFailed         = dbterror.ClassExecutor.NewStd(mysql.ErrLoadDataPreCheckFailed)
+	ErrFileExists                     = dbterror.ClassExecutor.NewStd(mysql.ErrFileExists)
 )
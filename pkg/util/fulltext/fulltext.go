@@ -0,0 +1,114 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fulltext implements the tokenizers of FULLTEXT indexes and the evaluation of the
+// natural language mode and boolean mode queries of MATCH ... AGAINST.
+package fulltext
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// ParserNgram is the name of the ngram parser. It splits every word into n-grams, which is
+	// suitable for the languages without word delimiters.
+	ParserNgram = "ngram"
+	// NgramTokenSize is the number of characters of an n-gram.
+	NgramTokenSize = 2
+	// MaxTokenSize is the max number of characters of a word, the longer words are not indexed.
+	MaxTokenSize = 84
+)
+
+// IsSupportedParser returns whether the parser can be used by a FULLTEXT index, the empty name
+// is the built-in standard parser.
+func IsSupportedParser(name string) bool {
+	return name == "" || strings.EqualFold(name, ParserNgram)
+}
+
+// Tokenize splits the text into lower case tokens.
+// The standard parser splits the text into words by the characters which are not letters or
+// digits, the runs of CJK characters are split into n-grams since they have no word delimiters.
+// The ngram parser splits all the words into n-grams.
+func Tokenize(parser, text string) []string {
+	ngram := strings.EqualFold(parser, ParserNgram)
+	var tokens []string
+	forEachWord(text, func(word []rune, cjk bool) {
+		if ngram || cjk {
+			tokens = appendNgrams(tokens, word)
+		} else if len(word) <= MaxTokenSize {
+			tokens = append(tokens, strings.ToLower(string(word)))
+		}
+	})
+	return tokens
+}
+
+// DistinctTokens returns the distinct tokens of the text, they are the entries of the text in a
+// FULLTEXT index.
+func DistinctTokens(parser, text string) []string {
+	tokens := Tokenize(parser, text)
+	distinct := tokens[:0]
+	seen := make(map[string]struct{}, len(tokens))
+	for _, token := range tokens {
+		if _, ok := seen[token]; ok {
+			continue
+		}
+		seen[token] = struct{}{}
+		distinct = append(distinct, token)
+	}
+	return distinct
+}
+
+func appendNgrams(tokens []string, word []rune) []string {
+	if len(word) <= NgramTokenSize {
+		return append(tokens, strings.ToLower(string(word)))
+	}
+	for i := 0; i+NgramTokenSize <= len(word); i++ {
+		tokens = append(tokens, strings.ToLower(string(word[i:i+NgramTokenSize])))
+	}
+	return tokens
+}
+
+// forEachWord calls fn for every word of the text, a run of CJK characters is a word.
+func forEachWord(text string, fn func(word []rune, cjk bool)) {
+	var word []rune
+	wordIsCJK := false
+	flush := func() {
+		if len(word) > 0 {
+			fn(word, wordIsCJK)
+			word = word[:0]
+		}
+	}
+	for _, r := range text {
+		if !isWordChar(r) {
+			flush()
+			continue
+		}
+		cjk := isCJK(r)
+		if len(word) > 0 && cjk != wordIsCJK {
+			flush()
+		}
+		wordIsCJK = cjk
+		word = append(word, r)
+	}
+	flush()
+}
+
+func isWordChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r) || r == '_'
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
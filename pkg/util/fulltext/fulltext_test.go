@@ -0,0 +1,115 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenize(t *testing.T) {
+	require.Equal(t, []string{"tidb", "is", "a", "distributed", "sql_db", "v8"}, Tokenize("", "TiDB is a distributed, SQL_DB (v8)!"))
+	require.Equal(t, []string{"hello", "分布", "布式", "数据", "据库", "x"}, Tokenize("", "hello 分布式 数据库x"))
+	require.Equal(t, []string{"数"}, Tokenize("", "数"))
+	require.Equal(t, []string{"ti", "id", "db", "a"}, Tokenize("ngram", "TiDB a"))
+	require.Equal(t, []string{"数据", "据库"}, Tokenize("NGRAM", "数据库"))
+	require.Empty(t, Tokenize("", " ,.; "))
+	require.Equal(t, []string{"a", "b"}, DistinctTokens("", "a b A a"))
+
+	require.True(t, IsSupportedParser(""))
+	require.True(t, IsSupportedParser("Ngram"))
+	require.False(t, IsSupportedParser("mecab"))
+}
+
+func TestNaturalLanguageScore(t *testing.T) {
+	d := NewDocument("", "MySQL tutorial", "MySQL vs TiDB")
+	require.Zero(t, d.NaturalLanguageScore([]string{"oracle"}))
+	require.InDelta(t, 1, d.NaturalLanguageScore([]string{"tidb", "oracle"}), 1e-9)
+	require.Greater(t, d.NaturalLanguageScore([]string{"mysql"}), d.NaturalLanguageScore([]string{"tidb"}))
+	require.Equal(t, d.NaturalLanguageScore([]string{"tidb"}), d.NaturalLanguageScore([]string{"tidb", "tidb"}))
+}
+
+func TestBooleanQuery(t *testing.T) {
+	terms := ParseBooleanQuery("", `+mysql -oracle >"full text" (a* ~b) <c`)
+	require.Len(t, terms, 5)
+	require.Equal(t, BooleanRequired, terms[0].Op)
+	require.Equal(t, []string{"mysql"}, terms[0].Tokens)
+	require.Equal(t, BooleanExcluded, terms[1].Op)
+	require.Equal(t, BooleanIncreased, terms[2].Op)
+	require.Equal(t, []string{"full", "text"}, terms[2].Tokens)
+	require.Len(t, terms[3].Group, 2)
+	require.True(t, terms[3].Group[0].Prefix)
+	require.Equal(t, BooleanNegated, terms[3].Group[1].Op)
+	require.Equal(t, BooleanDecreased, terms[4].Op)
+	require.Empty(t, ParseBooleanQuery("", `+ - "" () ,`))
+
+	d := NewDocument("", "MySQL full text search", "database")
+	cases := []struct {
+		query   string
+		matched bool
+	}{
+		{"mysql", true},
+		{"oracle", false},
+		{"oracle mysql", true},
+		{"+mysql +oracle", false},
+		{"+mysql -oracle", true},
+		{"mysql -search", false},
+		{"-oracle", false},
+		{`"full text"`, true},
+		{`"text full"`, false},
+		{`"search database"`, false},
+		{"sea*", true},
+		{"data*", true},
+		{"ful*", true},
+		{"fox*", false},
+		{"+(oracle mysql) +data*", true},
+		{"+(oracle postgres) mysql", false},
+		{"~mysql", true},
+	}
+	for _, c := range cases {
+		score := d.BooleanScore(ParseBooleanQuery("", c.query))
+		require.Equal(t, c.matched, score != 0, c.query)
+	}
+	require.Greater(t,
+		d.BooleanScore(ParseBooleanQuery("", "mysql >search")),
+		d.BooleanScore(ParseBooleanQuery("", "mysql <search")))
+}
+
+func TestIndexTokens(t *testing.T) {
+	cases := []struct {
+		query        string
+		tokens       []string
+		intersection bool
+		ok           bool
+	}{
+		{"a b -c", []string{"a", "b"}, false, true},
+		{`a "b c"`, []string{"a", "b"}, false, true},
+		{"+a +b c", []string{"a", "b"}, true, true},
+		{`+"a b" +c*`, []string{"a", "b"}, true, true},
+		{"+a* +(b c)", nil, true, false},
+		{"a b*", nil, false, false},
+		{"a (b c)", nil, false, false},
+		{"-a", nil, false, false},
+	}
+	for _, c := range cases {
+		tokens, intersection, ok := IndexTokens(ParseBooleanQuery("", c.query))
+		require.Equal(t, c.ok, ok, c.query)
+		if ok {
+			require.Equal(t, c.tokens, tokens, c.query)
+			require.Equal(t, c.intersection, intersection, c.query)
+		}
+	}
+}
@@ -0,0 +1,322 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltext
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Document is the tokens of the texts matched by a query.
+type Document struct {
+	tokens []string
+	freqs  map[string]int
+}
+
+// NewDocument creates a Document from the texts of the columns.
+func NewDocument(parser string, texts ...string) *Document {
+	d := &Document{freqs: make(map[string]int)}
+	for i, text := range texts {
+		if i > 0 {
+			// Separate the columns so that a phrase never matches across them.
+			d.tokens = append(d.tokens, "")
+		}
+		for _, token := range Tokenize(parser, text) {
+			d.tokens = append(d.tokens, token)
+			d.freqs[token]++
+		}
+	}
+	return d
+}
+
+// termScore is the relevance of a term which appears freq times in the document.
+func termScore(freq int) float64 {
+	if freq == 0 {
+		return 0
+	}
+	return 1 + math.Log(float64(freq))
+}
+
+// NaturalLanguageScore returns the relevance of the document for the tokens of a natural language
+// mode query, 0 means the document contains none of the tokens. The relevance only depends on the
+// term frequencies in the document.
+func (d *Document) NaturalLanguageScore(queryTokens []string) float64 {
+	var score float64
+	seen := make(map[string]struct{}, len(queryTokens))
+	for _, token := range queryTokens {
+		if _, ok := seen[token]; ok {
+			continue
+		}
+		seen[token] = struct{}{}
+		score += termScore(d.freqs[token])
+	}
+	return score
+}
+
+// BooleanOperator is the operator before a term of a boolean mode query.
+type BooleanOperator byte
+
+// The boolean mode operators.
+const (
+	// BooleanOptional means the term is optional, the documents containing it are more relevant.
+	BooleanOptional BooleanOperator = iota
+	// BooleanRequired (+) means the term must be present.
+	BooleanRequired
+	// BooleanExcluded (-) means the term must not be present.
+	BooleanExcluded
+	// BooleanNegated (~) means the term is optional, the documents containing it are less relevant.
+	BooleanNegated
+	// BooleanIncreased (>) increases the contribution of the term to the relevance.
+	BooleanIncreased
+	// BooleanDecreased (<) decreases the contribution of the term to the relevance.
+	BooleanDecreased
+)
+
+func (op BooleanOperator) weight() float64 {
+	switch op {
+	case BooleanNegated:
+		return -0.5
+	case BooleanIncreased:
+		return 1.5
+	case BooleanDecreased:
+		return 0.5
+	}
+	return 1
+}
+
+// BooleanTerm is a term of a boolean mode query.
+type BooleanTerm struct {
+	Op BooleanOperator
+	// Tokens are the tokens of a word or a quoted phrase, they must appear consecutively.
+	Tokens []string
+	// Prefix means the last token is a prefix, e.g. `data*`.
+	Prefix bool
+	// Group is the sub-expression of a parenthesized term.
+	Group []*BooleanTerm
+}
+
+// ParseBooleanQuery parses a boolean mode query.
+func ParseBooleanQuery(parser, query string) []*BooleanTerm {
+	p := &booleanQueryParser{parser: parser, query: []rune(query)}
+	return p.parseGroup(false)
+}
+
+type booleanQueryParser struct {
+	parser string
+	query  []rune
+	pos    int
+}
+
+func (p *booleanQueryParser) parseGroup(inParentheses bool) []*BooleanTerm {
+	var terms []*BooleanTerm
+	for p.pos < len(p.query) {
+		r := p.query[p.pos]
+		if unicode.IsSpace(r) {
+			p.pos++
+			continue
+		}
+		if r == ')' {
+			p.pos++
+			if inParentheses {
+				return terms
+			}
+			continue
+		}
+		term := &BooleanTerm{Op: p.parseOperator()}
+		if p.pos >= len(p.query) {
+			break
+		}
+		switch p.query[p.pos] {
+		case '(':
+			p.pos++
+			term.Group = p.parseGroup(true)
+			if len(term.Group) == 0 {
+				continue
+			}
+		case '"':
+			p.pos++
+			start := p.pos
+			for p.pos < len(p.query) && p.query[p.pos] != '"' {
+				p.pos++
+			}
+			term.Tokens = Tokenize(p.parser, string(p.query[start:p.pos]))
+			p.pos++
+			if len(term.Tokens) == 0 {
+				continue
+			}
+		default:
+			start := p.pos
+			for p.pos < len(p.query) && !unicode.IsSpace(p.query[p.pos]) && !strings.ContainsRune(`()"`, p.query[p.pos]) {
+				p.pos++
+			}
+			word := string(p.query[start:p.pos])
+			term.Prefix = strings.HasSuffix(word, "*")
+			term.Tokens = Tokenize(p.parser, word)
+			if len(term.Tokens) == 0 {
+				continue
+			}
+		}
+		terms = append(terms, term)
+	}
+	return terms
+}
+
+func (p *booleanQueryParser) parseOperator() BooleanOperator {
+	op := BooleanOptional
+	for ; p.pos < len(p.query); p.pos++ {
+		switch p.query[p.pos] {
+		case '+':
+			op = BooleanRequired
+		case '-':
+			op = BooleanExcluded
+		case '~':
+			op = BooleanNegated
+		case '>':
+			op = BooleanIncreased
+		case '<':
+			op = BooleanDecreased
+		default:
+			return op
+		}
+	}
+	return op
+}
+
+// BooleanScore returns the relevance of the document for a boolean mode query, 0 means the
+// document doesn't match the query.
+func (d *Document) BooleanScore(terms []*BooleanTerm) float64 {
+	score, matched := d.matchGroup(terms)
+	if !matched {
+		return 0
+	}
+	return score
+}
+
+// matchGroup matches the terms of a group. A group matches if all the required terms are present
+// and none of the excluded terms is present, a group without required terms also needs at least
+// one of the other terms.
+func (d *Document) matchGroup(terms []*BooleanTerm) (score float64, matched bool) {
+	hasRequired, hasOptional := false, false
+	for _, term := range terms {
+		termScore := d.matchTerm(term)
+		switch term.Op {
+		case BooleanRequired:
+			if termScore == 0 {
+				return 0, false
+			}
+			hasRequired = true
+			score += termScore
+		case BooleanExcluded:
+			if termScore != 0 {
+				return 0, false
+			}
+		default:
+			if termScore != 0 {
+				hasOptional = true
+				score += term.Op.weight() * termScore
+			}
+		}
+	}
+	return score, hasRequired || hasOptional
+}
+
+func (d *Document) matchTerm(term *BooleanTerm) float64 {
+	if term.Group != nil {
+		score, matched := d.matchGroup(term.Group)
+		if !matched {
+			return 0
+		}
+		// A group with a negative relevance still matches.
+		return math.Max(score, math.SmallestNonzeroFloat64)
+	}
+	if len(term.Tokens) == 1 && !term.Prefix {
+		return termScore(d.freqs[term.Tokens[0]])
+	}
+	freq := 0
+	for i := 0; i+len(term.Tokens) <= len(d.tokens); i++ {
+		if d.matchTokensAt(i, term.Tokens, term.Prefix) {
+			freq++
+		}
+	}
+	return termScore(freq)
+}
+
+func (d *Document) matchTokensAt(pos int, tokens []string, prefix bool) bool {
+	last := len(tokens) - 1
+	for i, token := range tokens {
+		if i == last && prefix {
+			return d.tokens[pos+i] != "" && strings.HasPrefix(d.tokens[pos+i], token)
+		}
+		if d.tokens[pos+i] != token {
+			return false
+		}
+	}
+	return true
+}
+
+// IndexTokens returns the tokens to look up in a FULLTEXT index to find all the documents which
+// may match the boolean mode query. If intersection is true the documents must contain all the
+// tokens, otherwise they contain at least one of the tokens. ok is false if the index can't be
+// used, e.g. all the terms are prefixes.
+func IndexTokens(terms []*BooleanTerm) (tokens []string, intersection bool, ok bool) {
+	for _, term := range terms {
+		if term.Op != BooleanRequired || term.Group != nil {
+			continue
+		}
+		intersection = true
+		tokens = appendTermIndexTokens(tokens, term, false)
+	}
+	if intersection {
+		return tokens, true, len(tokens) > 0
+	}
+	// Without required terms, a matched document must contain one of the other terms.
+	for _, term := range terms {
+		if term.Op == BooleanExcluded {
+			continue
+		}
+		if term.Group != nil || (len(term.Tokens) == 1 && term.Prefix) {
+			return nil, false, false
+		}
+		tokens = appendTermIndexTokens(tokens, term, true)
+	}
+	return tokens, false, len(tokens) > 0
+}
+
+// appendTermIndexTokens appends the tokens which must be present if the term matches. If onlyOne
+// is true, one token is enough to find the term.
+func appendTermIndexTokens(tokens []string, term *BooleanTerm, onlyOne bool) []string {
+	for i, token := range term.Tokens {
+		if term.Prefix && i == len(term.Tokens)-1 {
+			break
+		}
+		if !containsToken(tokens, token) {
+			tokens = append(tokens, token)
+		}
+		if onlyOne {
+			break
+		}
+	}
+	return tokens
+}
+
+func containsToken(tokens []string, token string) bool {
+	for _, t := range tokens {
+		if t == token {
+			return true
+		}
+	}
+	return false
+}
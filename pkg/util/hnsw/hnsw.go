@@ -0,0 +1,306 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hnsw implements an in-memory Hierarchical Navigable Small World graph, which is used
+// to search the approximate nearest neighbours of a vector.
+// See https://arxiv.org/abs/1603.09320 for the details of the algorithm.
+package hnsw
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+const (
+	// DefaultM is the default max number of the neighbours of a node on the upper layers.
+	// The nodes on the bottom layer have at most 2*M neighbours.
+	DefaultM = 16
+	// DefaultEfConstruction is the default size of the dynamic candidate list when building the graph.
+	DefaultEfConstruction = 128
+	// DefaultEfSearch is the default size of the dynamic candidate list when searching the graph.
+	DefaultEfSearch = 64
+)
+
+// DistanceFunc computes the distance between two vectors of the same dimension.
+// A smaller distance means the vectors are closer.
+type DistanceFunc func(a, b []float32) float32
+
+// L1Distance returns the manhattan distance between the vectors.
+func L1Distance(a, b []float32) float32 {
+	var distance float32
+	for i := range a {
+		distance += float32(math.Abs(float64(a[i] - b[i])))
+	}
+	return distance
+}
+
+// L2SquaredDistance returns the squared euclidean distance between the vectors, which keeps the
+// same order as the euclidean distance.
+func L2SquaredDistance(a, b []float32) float32 {
+	var distance float32
+	for i := range a {
+		diff := a[i] - b[i]
+		distance += diff * diff
+	}
+	return distance
+}
+
+// CosineDistance returns the cosine distance between the vectors. The vectors with a zero norm
+// are treated as the farthest ones.
+func CosineDistance(a, b []float32) float32 {
+	var product, normA, normB float32
+	for i := range a {
+		product += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 2
+	}
+	return 1 - product/float32(math.Sqrt(float64(normA)*float64(normB)))
+}
+
+// NegativeInnerProduct returns the negative inner product of the vectors.
+func NegativeInnerProduct(a, b []float32) float32 {
+	var product float32
+	for i := range a {
+		product += a[i] * b[i]
+	}
+	return -product
+}
+
+// Result is a vector found by Index.Search.
+type Result struct {
+	// ID is the id returned by Index.Add when the vector is added.
+	ID       int
+	Distance float32
+}
+
+type node struct {
+	vec []float32
+	// neighbours[l] is the neighbours of the node on the layer l.
+	neighbours [][]int32
+}
+
+// Index is an HNSW graph of vectors. It's not safe for concurrent use.
+type Index struct {
+	distance       DistanceFunc
+	m              int
+	efConstruction int
+	levelMult      float64
+	rng            *rand.Rand
+
+	nodes    []node
+	entry    int
+	maxLevel int
+	visited  []bool
+}
+
+// New creates an empty Index.
+func New(distance DistanceFunc, m, efConstruction int) *Index {
+	return &Index{
+		distance:       distance,
+		m:              m,
+		efConstruction: efConstruction,
+		levelMult:      1 / math.Log(float64(m)),
+		// A fixed seed makes the graph deterministic for the same input.
+		rng: rand.New(rand.NewSource(int64(m))),
+	}
+}
+
+// Len returns the number of vectors in the index.
+func (idx *Index) Len() int {
+	return len(idx.nodes)
+}
+
+// Add adds a vector to the index and returns its id, which is the number of the vectors added
+// before it. The index keeps a reference to vec, so it must not be modified later.
+func (idx *Index) Add(vec []float32) int {
+	id := len(idx.nodes)
+	level := int(-math.Log(1-idx.rng.Float64()) * idx.levelMult)
+	idx.nodes = append(idx.nodes, node{vec: vec, neighbours: make([][]int32, level+1)})
+	idx.visited = append(idx.visited, false)
+	if id == 0 {
+		idx.entry, idx.maxLevel = 0, level
+		return id
+	}
+
+	ep := candidate{id: int32(idx.entry), distance: idx.distance(vec, idx.nodes[idx.entry].vec)}
+	for l := idx.maxLevel; l > level; l-- {
+		ep = idx.greedySearch(vec, ep, l)
+	}
+	for l := min(level, idx.maxLevel); l >= 0; l-- {
+		candidates := idx.searchLayer(vec, ep, idx.efConstruction, l)
+		neighbours := candidates
+		if len(neighbours) > idx.m {
+			neighbours = neighbours[:idx.m]
+		}
+		ids := make([]int32, 0, len(neighbours))
+		for _, n := range neighbours {
+			ids = append(ids, n.id)
+			idx.connect(n.id, int32(id), l)
+		}
+		idx.nodes[id].neighbours[l] = ids
+		ep = candidates[0]
+	}
+	if level > idx.maxLevel {
+		idx.entry, idx.maxLevel = id, level
+	}
+	return id
+}
+
+// Search returns at most k vectors nearest to the query, ordered by the distance. A larger ef
+// gives a better recall, and it's at least k.
+func (idx *Index) Search(query []float32, k, ef int) []Result {
+	if len(idx.nodes) == 0 || k <= 0 {
+		return nil
+	}
+	ef = max(ef, k)
+	ep := candidate{id: int32(idx.entry), distance: idx.distance(query, idx.nodes[idx.entry].vec)}
+	for l := idx.maxLevel; l > 0; l-- {
+		ep = idx.greedySearch(query, ep, l)
+	}
+	candidates := idx.searchLayer(query, ep, ef, 0)
+	if len(candidates) > k {
+		candidates = candidates[:k]
+	}
+	results := make([]Result, 0, len(candidates))
+	for _, c := range candidates {
+		results = append(results, Result{ID: int(c.id), Distance: c.distance})
+	}
+	return results
+}
+
+func (idx *Index) maxNeighbours(level int) int {
+	if level == 0 {
+		return 2 * idx.m
+	}
+	return idx.m
+}
+
+// connect adds the edge from the node `from` to the node `to` on the layer, and drops the
+// farthest neighbour of `from` if it has too many neighbours.
+func (idx *Index) connect(from, to int32, level int) {
+	n := &idx.nodes[from]
+	n.neighbours[level] = append(n.neighbours[level], to)
+	if len(n.neighbours[level]) <= idx.maxNeighbours(level) {
+		return
+	}
+	neighbours := make([]candidate, 0, len(n.neighbours[level]))
+	for _, id := range n.neighbours[level] {
+		neighbours = append(neighbours, candidate{id: id, distance: idx.distance(n.vec, idx.nodes[id].vec)})
+	}
+	sort.Slice(neighbours, func(i, j int) bool { return neighbours[i].less(neighbours[j]) })
+	n.neighbours[level] = n.neighbours[level][:0]
+	for _, c := range neighbours[:idx.maxNeighbours(level)] {
+		n.neighbours[level] = append(n.neighbours[level], c.id)
+	}
+}
+
+// greedySearch moves from the entry point to its nearest neighbour on the layer until no
+// neighbour is closer to the query.
+func (idx *Index) greedySearch(query []float32, ep candidate, level int) candidate {
+	for changed := true; changed; {
+		changed = false
+		for _, id := range idx.nodes[ep.id].neighbours[level] {
+			c := candidate{id: id, distance: idx.distance(query, idx.nodes[id].vec)}
+			if c.less(ep) {
+				ep, changed = c, true
+			}
+		}
+	}
+	return ep
+}
+
+// searchLayer returns at most ef nodes nearest to the query on the layer, ordered by the distance.
+func (idx *Index) searchLayer(query []float32, ep candidate, ef int, level int) []candidate {
+	visited := []int32{ep.id}
+	idx.visited[ep.id] = true
+	defer func() {
+		for _, id := range visited {
+			idx.visited[id] = false
+		}
+	}()
+
+	candidates := &minHeap{ep}
+	results := &maxHeap{ep}
+	for candidates.Len() > 0 {
+		c := heap.Pop(candidates).(candidate)
+		if results.Len() >= ef && (*results)[0].less(c) {
+			break
+		}
+		for _, id := range idx.nodes[c.id].neighbours[level] {
+			if idx.visited[id] {
+				continue
+			}
+			idx.visited[id] = true
+			visited = append(visited, id)
+			n := candidate{id: id, distance: idx.distance(query, idx.nodes[id].vec)}
+			if results.Len() < ef || n.less((*results)[0]) {
+				heap.Push(candidates, n)
+				heap.Push(results, n)
+				if results.Len() > ef {
+					heap.Pop(results)
+				}
+			}
+		}
+	}
+
+	sorted := make([]candidate, results.Len())
+	for i := len(sorted) - 1; i >= 0; i-- {
+		sorted[i] = heap.Pop(results).(candidate)
+	}
+	return sorted
+}
+
+type candidate struct {
+	id       int32
+	distance float32
+}
+
+// less orders the candidates by the distance, and then by the id to make the order stable.
+func (c candidate) less(other candidate) bool {
+	if c.distance != other.distance {
+		return c.distance < other.distance
+	}
+	return c.id < other.id
+}
+
+type minHeap []candidate
+
+func (h minHeap) Len() int           { return len(h) }
+func (h minHeap) Less(i, j int) bool { return h[i].less(h[j]) }
+func (h minHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *minHeap) Push(x any)        { *h = append(*h, x.(candidate)) }
+func (h *minHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+type maxHeap []candidate
+
+func (h maxHeap) Len() int           { return len(h) }
+func (h maxHeap) Less(i, j int) bool { return h[j].less(h[i]) }
+func (h maxHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *maxHeap) Push(x any)        { *h = append(*h, x.(candidate)) }
+func (h *maxHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,105 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hnsw
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistance(t *testing.T) {
+	a, b := []float32{1, 2, 3}, []float32{4, 6, 3}
+	require.InDelta(t, 7, L1Distance(a, b), 1e-6)
+	require.InDelta(t, 25, L2SquaredDistance(a, b), 1e-6)
+	require.InDelta(t, -25, NegativeInnerProduct(a, b), 1e-6)
+	require.InDelta(t, 0, CosineDistance(a, []float32{2, 4, 6}), 1e-6)
+	require.InDelta(t, 2, CosineDistance(a, []float32{0, 0, 0}), 1e-6)
+}
+
+func TestSearch(t *testing.T) {
+	idx := New(L2SquaredDistance, DefaultM, DefaultEfConstruction)
+	require.Nil(t, idx.Search([]float32{1}, 1, DefaultEfSearch))
+
+	for i := 0; i < 10; i++ {
+		require.Equal(t, i, idx.Add([]float32{float32(i)}))
+	}
+	require.Equal(t, 10, idx.Len())
+	results := idx.Search([]float32{3.2}, 3, DefaultEfSearch)
+	require.Equal(t, []Result{{3, 0.04}, {4, 0.64}, {2, 1.44}}, roundResults(results))
+	require.Len(t, idx.Search([]float32{0}, 100, DefaultEfSearch), 10)
+}
+
+func TestRecall(t *testing.T) {
+	const (
+		dims  = 16
+		count = 2000
+		k     = 10
+	)
+	rng := rand.New(rand.NewSource(1))
+	randVec := func() []float32 {
+		v := make([]float32, dims)
+		for i := range v {
+			v[i] = rng.Float32()
+		}
+		return v
+	}
+
+	for _, distance := range []DistanceFunc{L1Distance, L2SquaredDistance, CosineDistance} {
+		idx := New(distance, DefaultM, DefaultEfConstruction)
+		vecs := make([][]float32, 0, count)
+		for i := 0; i < count; i++ {
+			vecs = append(vecs, randVec())
+			idx.Add(vecs[i])
+		}
+
+		found := 0
+		for q := 0; q < 20; q++ {
+			query := randVec()
+			expected := make([]int, count)
+			for i := range expected {
+				expected[i] = i
+			}
+			sort.Slice(expected, func(i, j int) bool {
+				return distance(query, vecs[expected[i]]) < distance(query, vecs[expected[j]])
+			})
+			exact := make(map[int]struct{}, k)
+			for _, id := range expected[:k] {
+				exact[id] = struct{}{}
+			}
+
+			results := idx.Search(query, k, DefaultEfSearch)
+			require.Len(t, results, k)
+			for i, r := range results {
+				if i > 0 {
+					require.LessOrEqual(t, results[i-1].Distance, r.Distance)
+				}
+				if _, ok := exact[r.ID]; ok {
+					found++
+				}
+			}
+		}
+		require.Greater(t, float64(found)/float64(20*k), 0.9)
+	}
+}
+
+func roundResults(results []Result) []Result {
+	for i := range results {
+		results[i].Distance = float32(int(results[i].Distance*100+0.5)) / 100
+	}
+	return results
+}
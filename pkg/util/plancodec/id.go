@@ -139,6 +139,12 @@ const (
 	TypeSequence = "Sequence"
 	// TypeScalarSubQuery is the type of ScalarQuery
 	TypeScalarSubQuery = "ScalarSubQuery"
+	// TypeJSONTable is the type of JSONTable.
+	TypeJSONTable = "JSONTable"
+	// TypeMerge is the type of Merge.
+	TypeMerge = "Merge"
+	// TypeUnpivot is the type of Unpivot.
+	TypeUnpivot = "Unpivot"
 )
 
 // plan id.
@@ -204,6 +210,9 @@ const (
 	typeExpandID              int = 58
 	typeImportIntoID          int = 59
 	TypeScalarSubQueryID      int = 60
+	typeJSONTableID           int = 61
+	typeMergeID               int = 62
+	typeUnpivotID             int = 63
 )
 
 // TypeStringToPhysicalID converts the plan type string to plan id.
@@ -329,6 +338,12 @@ func TypeStringToPhysicalID(tp string) int {
 		return typeImportIntoID
 	case TypeScalarSubQuery:
 		return TypeScalarSubQueryID
+	case TypeJSONTable:
+		return typeJSONTableID
+	case TypeMerge:
+		return typeMergeID
+	case TypeUnpivot:
+		return typeUnpivotID
 	}
 	// Should never reach here.
 	return 0
@@ -457,6 +472,12 @@ func PhysicalIDToTypeString(id int) string {
 		return TypeImportInto
 	case TypeScalarSubQueryID:
 		return TypeScalarSubQuery
+	case typeJSONTableID:
+		return TypeJSONTable
+	case typeMergeID:
+		return TypeMerge
+	case typeUnpivotID:
+		return TypeUnpivot
 	}
 
 	// Should never reach here.
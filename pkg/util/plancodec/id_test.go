@@ -82,6 +82,9 @@ func TestPlanIDChanged(t *testing.T) {
 		{typeShuffleID, 54},
 		{typeShuffleReceiverID, 55},
 		{typeImportIntoID, 59},
+		{typeJSONTableID, 61},
+		{typeMergeID, 62},
+		{typeUnpivotID, 63},
 	}
 
 	for _, testcase := range testCases {
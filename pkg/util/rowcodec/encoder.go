@@ -207,6 +207,8 @@ func encodeValueDatum(loc *time.Location, d *types.Datum, buffer []byte) (nBuffe
 		j := d.GetMysqlJSON()
 		buffer = append(buffer, j.TypeCode)
 		buffer = append(buffer, j.Value...)
+	case types.KindVectorFloat32:
+		buffer = d.GetVectorFloat32().SerializeTo(buffer)
 	default:
 		err = errors.Errorf("unsupport encode type %d", d.Kind())
 	}
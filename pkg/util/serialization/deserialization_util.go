@@ -165,6 +165,16 @@ func DeserializeBinaryJSON(posAndBuf *PosAndBuf) types.BinaryJSON {
 	return retValue
 }
 
+// DeserializeVectorFloat32 deserializes VectorFloat32 type
+func DeserializeVectorFloat32(posAndBuf *PosAndBuf) types.VectorFloat32 {
+	buf := deserializeBuffer(posAndBuf)
+	retValue, _, err := types.ZeroCopyDeserializeVectorFloat32(buf)
+	if err != nil {
+		panic(err)
+	}
+	return retValue.Clone()
+}
+
 // DeserializeSet deserializes Set type
 func DeserializeSet(posAndBuf *PosAndBuf) types.Set {
 	retValue := types.Set{}
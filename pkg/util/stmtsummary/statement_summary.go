@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"cmp"
 	"container/list"
+	"encoding/json"
 	"fmt"
 	"math"
 	"slices"
@@ -220,6 +221,8 @@ type stmtSummaryByDigestElement struct {
 
 	planCacheUnqualifiedCount int64
 	lastPlanCacheUnqualified  string // the reason why this query is unqualified for the plan cache
+
+	lastQueryAttributes string // the query attributes of the last statement sent with query attributes
 }
 
 // StmtExecInfo records execution information of each statement.
@@ -262,6 +265,7 @@ type StmtExecInfo struct {
 	RUDetail          *util.RUDetails
 
 	PlanCacheUnqualified string
+	QueryAttributes      map[string]string
 }
 
 // newStmtSummaryByDigestMap creates an empty stmtSummaryByDigestMap.
@@ -870,6 +874,9 @@ func (ssElement *stmtSummaryByDigestElement) add(sei *StmtExecInfo, intervalSeco
 		ssElement.planCacheUnqualifiedCount++
 		ssElement.lastPlanCacheUnqualified = sei.PlanCacheUnqualified
 	}
+	if len(sei.QueryAttributes) > 0 {
+		ssElement.lastQueryAttributes = FormatQueryAttributes(sei.QueryAttributes)
+	}
 
 	// SPM
 	if sei.PlanInBinding {
@@ -931,6 +938,15 @@ func formatSQL(sql string) string {
 	return sql
 }
 
+// FormatQueryAttributes formats the query attributes as a JSON object.
+func FormatQueryAttributes(attrs map[string]string) string {
+	b, err := json.Marshal(attrs)
+	if err != nil {
+		return err.Error()
+	}
+	return string(hack.String(b))
+}
+
 // Format the backoffType map to a string or nil.
 func formatBackoffTypes(backoffMap map[string]int) any {
 	type backoffStat struct {
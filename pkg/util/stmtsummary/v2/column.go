@@ -121,6 +121,7 @@ const (
 	PlanCacheHitsStr                  = "PLAN_CACHE_HITS"
 	PlanCacheUnqualifiedStr           = "PLAN_CACHE_UNQUALIFIED"
 	LastPlanCacheUnqualifiedStr       = "LAST_PLAN_CACHE_UNQUALIFIED_REASON"
+	LastQueryAttributesStr            = "LAST_QUERY_ATTRIBUTES"
 	PlanInBindingStr                  = "PLAN_IN_BINDING"
 	QuerySampleTextStr                = "QUERY_SAMPLE_TEXT"
 	PrevSampleTextStr                 = "PREV_SAMPLE_TEXT"
@@ -490,6 +491,9 @@ var columnFactoryMap = map[string]columnFactory{
 	LastPlanCacheUnqualifiedStr: func(_ columnInfo, record *StmtRecord) any {
 		return record.LastPlanCacheUnqualified
 	},
+	LastQueryAttributesStr: func(_ columnInfo, record *StmtRecord) any {
+		return convertEmptyToNil(record.LastQueryAttributes)
+	},
 }
 
 func makeColumnFactories(columns []*model.ColumnInfo) []columnFactory {
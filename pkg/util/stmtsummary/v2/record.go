@@ -153,6 +153,8 @@ type StmtRecord struct {
 
 	PlanCacheUnqualifiedCount int64  `json:"plan_cache_unqualified_count"`
 	LastPlanCacheUnqualified  string `json:"last_plan_cache_unqualified"` // the reason why this query is unqualified for the plan cache
+
+	LastQueryAttributes string `json:"last_query_attributes,omitempty"` // the query attributes of the last statement sent with query attributes
 }
 
 // NewStmtRecord creates a new StmtRecord from StmtExecInfo.
@@ -375,6 +377,9 @@ func (r *StmtRecord) Add(info *stmtsummary.StmtExecInfo) {
 		r.PlanCacheUnqualifiedCount++
 		r.LastPlanCacheUnqualified = info.PlanCacheUnqualified
 	}
+	if len(info.QueryAttributes) > 0 {
+		r.LastQueryAttributes = stmtsummary.FormatQueryAttributes(info.QueryAttributes)
+	}
 	// SPM
 	if info.PlanInBinding {
 		r.PlanInBinding = true
@@ -553,6 +558,9 @@ func (r *StmtRecord) Merge(other *StmtRecord) {
 	if other.LastPlanCacheUnqualified != "" {
 		r.LastPlanCacheUnqualified = other.LastPlanCacheUnqualified
 	}
+	if other.LastQueryAttributes != "" {
+		r.LastQueryAttributes = other.LastQueryAttributes
+	}
 	// Other
 	r.SumAffectedRows += other.SumAffectedRows
 	r.SumMem += other.SumMem